@@ -9,7 +9,21 @@ import (
 //	P1 + P2 = P3
 //	(x1, y1, z1) + (x2, y2, z2) = (x3, y3, z3)
 //
-// It returns the resulting Jacobian point (x3, y3, z3).
+// It returns the resulting Jacobian point (x3, y3, z3). AddJacobi is a
+// wrapper around JacobianPoint.Add. Any point with z = 0 is the point at
+// infinity, and P1 + -P1 returns (0, 0, 0).
+//
+// This function does not check point validity - it assumes you
+// are passing valid points on the secp256k1 curve.
+func AddJacobi(
+	x1, y1, z1 *big.Int,
+	x2, y2, z2 *big.Int,
+) (x3, y3, z3 *big.Int) {
+	p3 := JacobianPoint{x1, y1, z1}.Add(JacobianPoint{x2, y2, z2})
+	return p3.X, p3.Y, p3.Z
+}
+
+// Add adds two Jacobian points on the secp256k1 curve, returning P1 + P2.
 //
 // We use the "add-1998-cmo-2" addition formulas.
 //
@@ -32,31 +46,25 @@ import (
 //
 // This function does not check point validity - it assumes you
 // are passing valid points on the secp256k1 curve.
-func AddJacobi(
-	x1, y1, z1 *big.Int,
-	x2, y2, z2 *big.Int,
-) (x3, y3, z3 *big.Int) {
-	if equal(x1, zero) || equal(y1, zero) {
+func (p1 JacobianPoint) Add(p2 JacobianPoint) JacobianPoint {
+	x1, y1, z1 := p1.X, p1.Y, p1.Z
+	x2, y2, z2 := p2.X, p2.Y, p2.Z
+
+	if p1.IsInfinity() {
 		// P1 == 0: return P2
-		x3 = new(big.Int).Set(x2)
-		y3 = new(big.Int).Set(y2)
-		z3 = new(big.Int).Set(z2)
-		return
+		return p2.clone()
 	}
-	if equal(x2, zero) || equal(y2, zero) {
+	if p2.IsInfinity() {
 		// P2 == 0: return P1
-		x3 = new(big.Int).Set(x1)
-		y3 = new(big.Int).Set(y1)
-		z3 = new(big.Int).Set(z1)
-		return
+		return p1.clone()
 	}
 
-	// z1² and z2²
-	z1_pow2 := new(big.Int).Mul(z1, z1)
-	z2_pow2 := new(big.Int).Mul(z2, z2)
+	// When z2 = 1, as when adding an affine point from a precomputed table, u1 = x1
+	// and s1 = y1. This "mixed addition" saves five multiplications.
+	z2IsOne := equal(z2, one)
 
-	// u1 = x1 * z2²
-	u1 := new(big.Int).Mul(x1, z2_pow2)
+	// z1²
+	z1_pow2 := new(big.Int).Mul(z1, z1)
 
 	// u2 = x2 * z1²
 	u2 := new(big.Int).Mul(x2, z1_pow2)
@@ -65,13 +73,25 @@ func AddJacobi(
 	z1_pow3 := z1_pow2.Mul(z1_pow2, z1)
 	z1_pow2 = nil
 
-	// z2³
-	z2_pow3 := z2_pow2.Mul(z2_pow2, z2)
-	z2_pow2 = nil
+	var u1, s1 *big.Int
+	if z2IsOne {
+		u1 = new(big.Int).Set(x1)
+		s1 = new(big.Int).Set(y1)
+	} else {
+		// z2²
+		z2_pow2 := new(big.Int).Mul(z2, z2)
+
+		// u1 = x1 * z2²
+		u1 = new(big.Int).Mul(x1, z2_pow2)
 
-	// s1 = y1 * z2³
-	s1 := z2_pow3.Mul(y1, z2_pow3)
-	z2_pow3 = nil
+		// z2³
+		z2_pow3 := z2_pow2.Mul(z2_pow2, z2)
+		z2_pow2 = nil
+
+		// s1 = y1 * z2³
+		s1 = z2_pow3.Mul(y1, z2_pow3)
+		z2_pow3 = nil
+	}
 
 	// s2 = y2 * z1³
 	s2 := z1_pow3.Mul(y2, z1_pow3)
@@ -108,15 +128,12 @@ func AddJacobi(
 	if equal(h, zero) {
 		if equal(r, zero) {
 			// P1 == P2: return the doubled point
-			return DoubleJacobi(x1, y1, z1)
+			return p1.Double()
 		}
 
 		// P1 == -P2: sum will be zero
 		// INVARIANT: for performance, y2 is assumed to be negative y1
-		x3 = new(big.Int).Set(zero)
-		y3 = new(big.Int).Set(zero)
-		z3 = new(big.Int).Set(zero)
-		return
+		return JacobianInfinity()
 	}
 
 	// h²
@@ -131,14 +148,14 @@ func AddJacobi(
 	hh = nil
 
 	// x3 = r² - h³ - 2*v
-	x3 = new(big.Int).Mul(r, r)
+	x3 := new(big.Int).Mul(r, r)
 	x3.Sub(x3, hhh)
 	x3.Sub(x3, v)
 	x3.Sub(x3, v)
 	modCoordinate(x3)
 
 	// y3 = r * (v - x3) - s1 * h³
-	y3 = v.Sub(v, x3)
+	y3 := v.Sub(v, x3)
 	y3.Mul(r, y3)
 	y3.Sub(y3, s1.Mul(s1, hhh))
 	v = nil
@@ -146,11 +163,14 @@ func AddJacobi(
 	modCoordinate(y3)
 
 	// z3 = z1 * z2 * h
-	z3 = hhh.Mul(z1, z2)
-	z3.Mul(z3, h)
+	z3 := hhh.Mul(z1, h)
+	if !z2IsOne {
+		z3.Mul(z3, z2)
+	}
 	modCoordinate(z3)
 	hhh = nil
-	return
+
+	return JacobianPoint{x3, y3, z3}
 }
 
 // AddAffine adds two affine points on the secp256k1 curve:
@@ -158,7 +178,21 @@ func AddJacobi(
 //	P1 + P2 = P3
 //	(x1, y1) + (x2, y2) = (x3, y3)
 //
-// It returns the resulting affine point (x3, y3).
+// It returns the resulting affine point (x3, y3). AddAffine is a
+// wrapper around AffinePoint.Add. The point at infinity is (0, 0),
+// and P1 + -P1 returns (0, 0).
+//
+// This function does not check point validity - it assumes you
+// are passing valid points on the secp256k1 curve.
+func AddAffine(
+	x1, y1 *big.Int,
+	x2, y2 *big.Int,
+) (x3, y3 *big.Int) {
+	p3 := AffinePoint{x1, y1}.Add(AffinePoint{x2, y2})
+	return p3.X, p3.Y
+}
+
+// Add adds two affine points on the secp256k1 curve, returning P1 + P2.
 //
 // We incorporate the standard affine addition and doubling formulas:
 //
@@ -171,21 +205,17 @@ func AddJacobi(
 //
 // This function does not check point validity - it assumes you
 // are passing valid points on the secp256k1 curve.
-func AddAffine(
-	x1, y1 *big.Int,
-	x2, y2 *big.Int,
-) (x3, y3 *big.Int) {
+func (p1 AffinePoint) Add(p2 AffinePoint) AffinePoint {
+	x1, y1 := p1.X, p1.Y
+	x2, y2 := p2.X, p2.Y
+
 	// P2 + 0 = P2
-	if EqualAffine(x1, y1, zero, zero) {
-		x3 = new(big.Int).Set(x2)
-		y3 = new(big.Int).Set(y2)
-		return
+	if p1.IsInfinity() {
+		return p2.clone()
 	}
 	// P1 + 0 = P1
-	if EqualAffine(x2, y2, zero, zero) {
-		x3 = new(big.Int).Set(x1)
-		y3 = new(big.Int).Set(y1)
-		return
+	if p2.IsInfinity() {
+		return p1.clone()
 	}
 
 	xEqual := equal(x1, x2)
@@ -194,9 +224,7 @@ func AddAffine(
 	// INVARIANT: if x1 == x2 && y1 != y2, assume y1 = -y2 (the only other possible point on the curve).
 	// Thus P1 + P2 = 0
 	if xEqual && !yEqual {
-		x3 = new(big.Int).Set(zero)
-		y3 = new(big.Int).Set(zero)
-		return
+		return AffineInfinity()
 	}
 
 	m := new(big.Int)
@@ -219,19 +247,19 @@ func AddAffine(
 	modCoordinate(m)
 
 	// x3 = m² - x1 - x2
-	x3 = buf.Mul(m, m)
+	x3 := buf.Mul(m, m)
 	x3.Sub(x3, x1)
 	x3.Sub(x3, x2)
 	modCoordinate(x3)
 	buf = nil
 
 	// y3 = m * (x1 - x3) - y1
-	y3 = new(big.Int).Sub(x1, x3)
+	y3 := new(big.Int).Sub(x1, x3)
 	y3.Mul(y3, m)
 	y3.Sub(y3, y1)
 	modCoordinate(y3)
 
-	return
+	return AffinePoint{x3, y3}
 }
 
 // SubJacobi subtracts two Jacobian coordinate points on the secp256k1 curve:
@@ -247,10 +275,13 @@ func SubJacobi(
 	x1, y1, z1 *big.Int,
 	x2, y2, z2 *big.Int,
 ) (x3, y3, z3 *big.Int) {
-	return AddJacobi(
-		x1, y1, z1,
-		x2, Negate(y2), z2,
-	)
+	p3 := JacobianPoint{x1, y1, z1}.Sub(JacobianPoint{x2, y2, z2})
+	return p3.X, p3.Y, p3.Z
+}
+
+// Sub subtracts two Jacobian points on the secp256k1 curve, returning P1 - P2.
+func (p1 JacobianPoint) Sub(p2 JacobianPoint) JacobianPoint {
+	return p1.Add(JacobianPoint{p2.X, Negate(p2.Y), p2.Z})
 }
 
 // SubAffine subtracts two affine points on the secp256k1 curve:
@@ -266,8 +297,11 @@ func SubAffine(
 	x1, y1 *big.Int,
 	x2, y2 *big.Int,
 ) (x3, y3 *big.Int) {
-	return AddAffine(
-		x1, y1,
-		x2, Negate(y2),
-	)
+	p3 := AffinePoint{x1, y1}.Sub(AffinePoint{x2, y2})
+	return p3.X, p3.Y
+}
+
+// Sub subtracts two affine points on the secp256k1 curve, returning P1 - P2.
+func (p1 AffinePoint) Sub(p2 AffinePoint) AffinePoint {
+	return p1.Add(AffinePoint{p2.X, Negate(p2.Y)})
 }
@@ -28,6 +28,52 @@ Wanted:
 	}
 }
 
+func TestAddJacobi_Infinity(t *testing.T) {
+	for i, vector := range test_vectors.JacobiInfinityAdditionVectors {
+		x3, y3, z3 := AddJacobi(
+			vector.X1, vector.Y1, vector.Z1,
+			vector.X2, vector.Y2, vector.Z2,
+		)
+
+		if !equal(x3, vector.X3) || !equal(y3, vector.Y3) || !equal(z3, vector.Z3) {
+			t.Errorf(`jacobi point addition with infinity failed for vector %d - Got:
+	x3: %.64x
+	y3: %.64x
+	z3: %.64x
+Wanted:
+	x3: %.64x
+	y3: %.64x
+	z3: %.64x
+`, i, x3, y3, z3, vector.X3, vector.Y3, vector.Z3)
+		}
+	}
+}
+
+func TestAddAffine_Infinity(t *testing.T) {
+	toAffine := func(x, y, z *big.Int) (*big.Int, *big.Int) {
+		x, y = new(big.Int).Set(x), new(big.Int).Set(y)
+		ToAffine(x, y, new(big.Int).Set(z))
+		return x, y
+	}
+
+	for i, vector := range test_vectors.JacobiInfinityAdditionVectors {
+		x1, y1 := toAffine(vector.X1, vector.Y1, vector.Z1)
+		x2, y2 := toAffine(vector.X2, vector.Y2, vector.Z2)
+		expectedX, expectedY := toAffine(vector.X3, vector.Y3, vector.Z3)
+
+		x3, y3 := AddAffine(x1, y1, x2, y2)
+		if !EqualAffine(x3, y3, expectedX, expectedY) {
+			t.Errorf(`affine point addition with infinity failed for vector %d - Got:
+	x3: %.64x
+	y3: %.64x
+Wanted:
+	x3: %.64x
+	y3: %.64x
+`, i, x3, y3, expectedX, expectedY)
+		}
+	}
+}
+
 func TestAddJacobi_MemSafety(t *testing.T) {
 	for i, vector := range test_vectors.JacobiAdditionVectors {
 		originalX1 := new(big.Int).Set(vector.X1)
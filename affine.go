@@ -11,6 +11,12 @@ import "math/big"
 // ToAffine converts the given jacobian coordinates to affine coordinates,
 // normalizing x and y, and setting z = 1. This is an expensive operation,
 // as it involves modular inversion of z to perform finite field division.
+//
+// If z = 0, the point is the point at infinity, and x and y are set to the affine
+// point at infinity, (0, 0).
+//
+// ToAffine modifies x, y and z in place. See JacobianPoint.ToAffine for a
+// variant which returns a new AffinePoint instead.
 func ToAffine(x, y, z *big.Int) {
 	if equal(z, one) {
 		return
@@ -33,3 +39,66 @@ func ToAffine(x, y, z *big.Int) {
 
 	z.Set(one)
 }
+
+// ToAffine converts p to affine coordinates, returning a new AffinePoint.
+// The point at infinity is converted to the affine point at infinity.
+// This is an expensive operation, as it involves modular inversion.
+func (p JacobianPoint) ToAffine() AffinePoint {
+	if p.IsInfinity() {
+		return AffineInfinity()
+	}
+
+	x := new(big.Int).Set(p.X)
+	y := new(big.Int).Set(p.Y)
+	ToAffine(x, y, new(big.Int).Set(p.Z))
+	return AffinePoint{x, y}
+}
+
+// ToJacobian converts p to Jacobian coordinates with z = 1, returning a new JacobianPoint.
+// The affine point at infinity is converted to the Jacobian point at infinity.
+func (p AffinePoint) ToJacobian() JacobianPoint {
+	if p.IsInfinity() {
+		return JacobianInfinity()
+	}
+	return JacobianPoint{new(big.Int).Set(p.X), new(big.Int).Set(p.Y), new(big.Int).Set(one)}
+}
+
+// BatchToAffine converts the given Jacobian points to affine coordinates, returning
+// a new AffinePoint for each. Points at infinity are converted to the affine point at
+// infinity. The input points are not modified.
+//
+// Rather than inverting each z-coordinate separately, as ToAffine would, BatchToAffine
+// uses BatchInvertCoordinates to normalize all N points with a single modular inversion.
+// This is much faster when converting many points at once.
+func BatchToAffine(points []JacobianPoint) []AffinePoint {
+	zInverses := make([]*big.Int, len(points))
+	for i, p := range points {
+		zInverses[i] = new(big.Int).Set(p.Z)
+	}
+	BatchInvertCoordinates(zInverses)
+
+	affine := make([]AffinePoint, len(points))
+	for i, p := range points {
+		if p.IsInfinity() {
+			affine[i] = AffineInfinity()
+			continue
+		}
+
+		// z⁻²
+		zInv := zInverses[i]
+		zInv2 := new(big.Int).Mul(zInv, zInv)
+		modCoordinate(zInv2)
+
+		// x = x / z²
+		x := new(big.Int).Mul(p.X, zInv2)
+		modCoordinate(x)
+
+		// y = y / z³
+		zInv3 := zInv2.Mul(zInv2, zInv)
+		y := new(big.Int).Mul(p.Y, zInv3)
+		modCoordinate(y)
+
+		affine[i] = AffinePoint{x, y}
+	}
+	return affine
+}
@@ -30,6 +30,63 @@ Wanted:
 	}
 }
 
+func TestToAffine_Infinity(t *testing.T) {
+	for i, vector := range test_vectors.JacobiInfinityDoublingVectors {
+		x := new(big.Int).Set(vector.X1)
+		y := new(big.Int).Set(vector.Y1)
+		z := new(big.Int).Set(vector.Z1)
+
+		ToAffine(x, y, z)
+
+		if !EqualAffine(x, y, zero, zero) || !equal(z, zero) {
+			t.Errorf("expected point at infinity %d to convert to (0, 0); got (%x, %x, %x)", i, x, y, z)
+		}
+	}
+}
+
+func TestBatchToAffine(t *testing.T) {
+	var points []JacobianPoint
+	for _, vector := range test_vectors.JacobiPointVectors {
+		points = append(points, JacobianPoint{vector.JacobiX, vector.JacobiY, vector.JacobiZ})
+	}
+	for _, vector := range test_vectors.JacobiInfinityDoublingVectors {
+		points = append(points, JacobianPoint{vector.X1, vector.Y1, vector.Z1})
+	}
+	for _, vector := range test_vectors.JacobiPointVectors {
+		points = append(points, JacobianPoint{vector.JacobiX, vector.JacobiY, vector.JacobiZ})
+	}
+
+	original := make([]JacobianPoint, len(points))
+	for i, p := range points {
+		original[i] = p.clone()
+	}
+
+	affine := BatchToAffine(points)
+	if len(affine) != len(points) {
+		t.Fatalf("expected %d affine points, got %d", len(points), len(affine))
+	}
+
+	for i, p := range points {
+		if expected := p.ToAffine(); !affine[i].Equal(expected) {
+			t.Errorf(`batch affine conversion failed for point %d. Got:
+	x: %.64x
+	y: %.64x
+Wanted:
+	x: %.64x
+	y: %.64x
+`, i, affine[i].X, affine[i].Y, expected.X, expected.Y)
+		}
+
+		if !equal(p.X, original[i].X) || !equal(p.Y, original[i].Y) || !equal(p.Z, original[i].Z) {
+			t.Errorf("BatchToAffine modified input point %d", i)
+		}
+	}
+
+	if len(BatchToAffine(nil)) != 0 {
+		t.Errorf("expected no points from empty input")
+	}
+}
+
 func BenchmarkToAffine(b *testing.B) {
 	x := new(big.Int)
 	y := new(big.Int)
@@ -46,3 +103,25 @@ func BenchmarkToAffine(b *testing.B) {
 		ToAffine(x, y, z)
 	}
 }
+
+func BenchmarkBatchToAffine(b *testing.B) {
+	points := make([]JacobianPoint, 1000)
+	for i := range points {
+		vector := test_vectors.JacobiPointVectors[i%len(test_vectors.JacobiPointVectors)]
+		points[i] = JacobianPoint{vector.JacobiX, vector.JacobiY, vector.JacobiZ}
+	}
+
+	b.Run("BatchToAffine", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BatchToAffine(points)
+		}
+	})
+
+	b.Run("ToAffine", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, p := range points {
+				p.ToAffine()
+			}
+		}
+	})
+}
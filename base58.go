@@ -0,0 +1,85 @@
+package ekliptic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidBase58Check is returned when decoding a base58check string
+// which is malformed or has an invalid checksum.
+var ErrInvalidBase58Check = errors.New("ekliptic: invalid base58check string")
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var fiftyEight = big.NewInt(58)
+
+// base58CheckEncode encodes a version byte and payload in base58, followed by the
+// first four bytes of the double SHA256 hash of both as a checksum.
+func base58CheckEncode(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	checksum := doubleSHA256(data)
+	data = append(data, checksum[:4]...)
+
+	n := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, fiftyEight, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	// Each leading zero byte is encoded as a leading '1'.
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58CheckDecode decodes a base58check string, returning its version byte and payload.
+func base58CheckDecode(s string) (version byte, payload []byte, err error) {
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return 0, nil, ErrInvalidBase58Check
+		}
+		n.Mul(n, fiftyEight)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	leadingZeros := 0
+	for leadingZeros < len(s) && s[leadingZeros] == base58Alphabet[0] {
+		leadingZeros++
+	}
+
+	data := append(make([]byte, leadingZeros), n.Bytes()...)
+	if len(data) < 5 {
+		return 0, nil, ErrInvalidBase58Check
+	}
+
+	checksum := doubleSHA256(data[:len(data)-4])
+	if !bytes.Equal(checksum[:4], data[len(data)-4:]) {
+		return 0, nil, ErrInvalidBase58Check
+	}
+	return data[0], data[1 : len(data)-4], nil
+}
+
+// doubleSHA256 computes SHA256(SHA256(data)).
+func doubleSHA256(data ...[]byte) [32]byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return sha256.Sum256(h.Sum(nil))
+}
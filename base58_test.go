@@ -0,0 +1,35 @@
+package ekliptic
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBase58Check(t *testing.T) {
+	payloads := [][]byte{
+		{},
+		{0, 0, 0},
+		bytes.Repeat([]byte{0xff}, 20),
+	}
+
+	for _, version := range []byte{0x00, 0x05, 0x6f} {
+		for _, payload := range payloads {
+			encoded := base58CheckEncode(version, payload)
+			decodedVersion, decoded, err := base58CheckDecode(encoded)
+			if err != nil {
+				t.Errorf("failed to decode %s: %s", encoded, err)
+				continue
+			}
+			if decodedVersion != version || !bytes.Equal(decoded, payload) {
+				t.Errorf("failed to round trip base58check payload %x", payload)
+			}
+		}
+	}
+
+	invalid := []string{"", "1", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMh", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAM0"}
+	for _, s := range invalid {
+		if _, _, err := base58CheckDecode(s); err != ErrInvalidBase58Check {
+			t.Errorf("expected ErrInvalidBase58Check decoding %q; got %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ekliptic
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidBech32 is returned when decoding a bech32 or bech32m string
+// which is malformed or has an invalid checksum.
+var ErrInvalidBech32 = errors.New("ekliptic: invalid bech32 string")
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants for bech32 (BIP-173) and bech32m (BIP-350).
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range bech32Generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32Encode encodes the human-readable part and 5-bit data values as a bech32 string,
+// or a bech32m string if checksumConst is bech32mConst.
+func bech32Encode(hrp string, data []byte, checksumConst uint32) string {
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ checksumConst
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// bech32Decode decodes a bech32 or bech32m string, returning its human-readable part,
+// its 5-bit data values without the checksum, and the checksum constant which it matched.
+func bech32Decode(s string) (hrp string, data []byte, checksumConst uint32, err error) {
+	if len(s) > 90 || (strings.ToLower(s) != s && strings.ToUpper(s) != s) {
+		return "", nil, 0, ErrInvalidBech32
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, ErrInvalidBech32
+	}
+
+	hrp = s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, ErrInvalidBech32
+		}
+	}
+
+	data = make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return "", nil, 0, ErrInvalidBech32
+		}
+		data = append(data, byte(d))
+	}
+
+	switch checksumConst = bech32Polymod(append(bech32HRPExpand(hrp), data...)); checksumConst {
+	case bech32Const, bech32mConst:
+		return hrp, data[:len(data)-6], checksumConst, nil
+	default:
+		return "", nil, 0, ErrInvalidBech32
+	}
+}
+
+// convertBits regroups a sequence of fromBits-wide values into toBits-wide values.
+// If pad is false, leftover bits must be zero padding of fewer than fromBits bits.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, bool) {
+	var acc, bits uint
+	maxValue := uint(1)<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, v := range data {
+		if uint(v)>>fromBits != 0 {
+			return nil, false
+		}
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// encodeSegwitAddress encodes a segregated witness output program as an address, using
+// bech32 for witness version 0 and bech32m for later versions, per BIP-173 and BIP-350.
+func encodeSegwitAddress(hrp string, version byte, program []byte) string {
+	data, _ := convertBits(program, 8, 5, true)
+	checksumConst := uint32(bech32mConst)
+	if version == 0 {
+		checksumConst = bech32Const
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...), checksumConst)
+}
+
+// decodeSegwitAddress decodes a segregated witness address with the expected
+// human-readable part, returning its witness version and program.
+func decodeSegwitAddress(hrp, address string) (version byte, program []byte, err error) {
+	decodedHRP, data, checksumConst, err := bech32Decode(address)
+	if err != nil || decodedHRP != hrp || len(data) < 1 {
+		return 0, nil, ErrInvalidBech32
+	}
+
+	version = data[0]
+	program, ok := convertBits(data[1:], 5, 8, false)
+	if !ok || version > 16 || len(program) < 2 || len(program) > 40 {
+		return 0, nil, ErrInvalidBech32
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, ErrInvalidBech32
+	}
+	if (version == 0) != (checksumConst == bech32Const) {
+		return 0, nil, ErrInvalidBech32
+	}
+	return version, program, nil
+}
@@ -0,0 +1,100 @@
+package ekliptic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestBech32Decode(t *testing.T) {
+	// Valid checksums from BIP-173 and BIP-350.
+	valid := []struct {
+		s             string
+		checksumConst uint32
+	}{
+		{"A12UEL5L", bech32Const},
+		{"a12uel5l", bech32Const},
+		{"A1LQFN3A", bech32mConst},
+		{"a1lqfn3a", bech32mConst},
+	}
+
+	for _, vector := range valid {
+		hrp, data, checksumConst, err := bech32Decode(vector.s)
+		if err != nil {
+			t.Errorf("failed to decode %s: %s", vector.s, err)
+			continue
+		}
+		if checksumConst != vector.checksumConst {
+			t.Errorf("unexpected checksum variant for %s", vector.s)
+		}
+		if encoded := bech32Encode(hrp, data, checksumConst); encoded != strings.ToLower(vector.s) {
+			t.Errorf("failed to round trip %s: got %s", vector.s, encoded)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"1qqqqqq",
+		"A12UEL5l",
+		"a12uel5m",
+		"a1b2uel5l",
+		"a12ue",
+		strings.Repeat("a", 84) + "1qqqqqqqqqq",
+	}
+	for _, s := range invalid {
+		if _, _, _, err := bech32Decode(s); err != ErrInvalidBech32 {
+			t.Errorf("expected ErrInvalidBech32 decoding %q; got %v", s, err)
+		}
+	}
+}
+
+func TestSegwitAddress(t *testing.T) {
+	// Test vectors from BIP-173 and BIP-350.
+	vectors := []struct {
+		hrp     string
+		address string
+		version byte
+		program string
+	}{
+		{"bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", 0, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc", "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", 1, "751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"bc", "bc1sw50qgdz25j", 16, "751e"},
+	}
+
+	for _, vector := range vectors {
+		program, _ := hex.DecodeString(vector.program)
+
+		if address := encodeSegwitAddress(vector.hrp, vector.version, program); address != vector.address {
+			t.Errorf("unexpected segwit address:\n  got %s\nwanted %s", address, vector.address)
+		}
+
+		version, decoded, err := decodeSegwitAddress(vector.hrp, strings.ToUpper(vector.address))
+		if err != nil {
+			t.Errorf("failed to decode segwit address %s: %s", vector.address, err)
+			continue
+		}
+		if version != vector.version || !bytes.Equal(decoded, program) {
+			t.Errorf("unexpected witness program decoded from %s", vector.address)
+		}
+	}
+
+	program20, _ := convertBits(make([]byte, 20), 8, 5, true)
+
+	invalid := []string{
+		// Witness version 1 with a bech32 checksum.
+		"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kw5rljs90",
+		// Witness version 0 with a bech32m checksum.
+		bech32Encode("bc", append([]byte{0}, program20...), bech32mConst),
+		// Wrong human-readable part.
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+		// Invalid witness version 0 program length.
+		encodeSegwitAddress("bc", 0, make([]byte, 21)),
+	}
+	for _, address := range invalid {
+		if _, _, err := decodeSegwitAddress("bc", address); err != ErrInvalidBech32 {
+			t.Errorf("expected ErrInvalidBech32 decoding %s; got %v", address, err)
+		}
+	}
+}
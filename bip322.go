@@ -0,0 +1,246 @@
+package ekliptic
+
+import (
+	"encoding/base64"
+	"math/big"
+)
+
+const bip322MessageTag = "BIP0322-signed-message"
+
+// BIP322MessageHash computes the BIP-322 tagged hash of a message, which is committed
+// to by the virtual to_spend transaction:
+//
+//	hash_BIP0322-signed-message(message)
+func BIP322MessageHash(message []byte) [32]byte {
+	return taggedHash(bip322MessageTag, message)
+}
+
+// SignBIP322Simple signs a message with the private key d, proving ownership of its P2WPKH or
+// P2TR address. It returns the base64-encoded witness stack of the virtual to_sign transaction,
+// which is the BIP-322 "simple" signature format. ECDSA nonces are derived deterministically
+// with RFC 6979, and Schnorr signatures use BIP-340's deterministic nonce derivation.
+//
+// SignBIP322Simple panics if d is not within the range [1, Secp256k1_CurveOrder), or if
+// addressType is neither BitcoinAddressP2WPKH nor BitcoinAddressP2TR.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0322.mediawiki
+func SignBIP322Simple(d *big.Int, message []byte, addressType BitcoinAddressType) string {
+	toSign := signBIP322(d, message, addressType)
+	return base64.StdEncoding.EncodeToString(appendWitness(nil, toSign.inputs[0].witness))
+}
+
+// SignBIP322Full is like SignBIP322Simple, but returns the base64-encoded serialization of the
+// whole signed to_sign transaction, which is the BIP-322 "full" signature format.
+func SignBIP322Full(d *big.Int, message []byte, addressType BitcoinAddressType) string {
+	toSign := signBIP322(d, message, addressType)
+	return base64.StdEncoding.EncodeToString(toSign.serialize(true))
+}
+
+// VerifyBIP322Simple returns true if signature is a valid BIP-322 "simple" signature on message
+// by the owner of the given P2WPKH or P2TR address on the given network.
+func VerifyBIP322Simple(
+	address string,
+	message []byte,
+	signature string,
+	network *BitcoinNetwork,
+) bool {
+	encoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	witness, err := parseWitness(encoded)
+	if err != nil {
+		return false
+	}
+
+	toSpend, ok := newBIP322ToSpend(address, message, network)
+	if !ok {
+		return false
+	}
+	toSign := newBIP322ToSign(toSpend)
+	toSign.inputs[0].witness = witness
+
+	return verifyBIP322(toSpend, toSign)
+}
+
+// VerifyBIP322Full returns true if signature is a valid BIP-322 "full" signature on message
+// by the owner of the given P2WPKH or P2TR address on the given network.
+//
+// Only to_sign transactions with the basic structure are accepted: a single input spending
+// to_spend and a single OP_RETURN output, with version 0 and lock time 0. Proofs of funds,
+// which add further inputs, are not supported.
+func VerifyBIP322Full(
+	address string,
+	message []byte,
+	signature string,
+	network *BitcoinNetwork,
+) bool {
+	encoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	toSign, err := parseBitcoinTx(encoded)
+	if err != nil {
+		return false
+	}
+
+	toSpend, ok := newBIP322ToSpend(address, message, network)
+	if !ok {
+		return false
+	}
+
+	// The transaction must be exactly the expected to_sign transaction, apart from its witness.
+	expected := newBIP322ToSign(toSpend)
+	if len(toSign.inputs) != 1 {
+		return false
+	}
+	expected.inputs[0].witness = toSign.inputs[0].witness
+	if string(expected.serialize(true)) != string(encoded) {
+		return false
+	}
+
+	return verifyBIP322(toSpend, expected)
+}
+
+// newBIP322ToSpend constructs the virtual to_spend transaction, whose single output
+// is locked by the script of the given address. It returns false if the address
+// is not a valid P2WPKH or P2TR address on the given network.
+func newBIP322ToSpend(address string, message []byte, network *BitcoinNetwork) (*bitcoinTx, bool) {
+	version, program, err := decodeSegwitAddress(network.Bech32HRP, address)
+	if err != nil {
+		return nil, false
+	}
+
+	var script []byte
+	switch {
+	case version == 0 && len(program) == 20:
+		script = append([]byte{0x00, 0x14}, program...)
+	case version == 1 && len(program) == 32:
+		script = append([]byte{0x51, 0x20}, program...)
+	default:
+		return nil, false
+	}
+
+	messageHash := BIP322MessageHash(message)
+
+	toSpend := &bitcoinTx{
+		version: 0,
+		inputs: []bitcoinTxInput{{
+			prevout:   Outpoint{Vout: 0xffffffff},
+			scriptSig: append([]byte{0x00, 0x20}, messageHash[:]...),
+			sequence:  0,
+		}},
+		outputs: []bitcoinTxOutput{{
+			value:  0,
+			script: script,
+		}},
+		lockTime: 0,
+	}
+	return toSpend, true
+}
+
+// newBIP322ToSign constructs the unsigned virtual to_sign transaction,
+// which spends the output of to_spend to an OP_RETURN output.
+func newBIP322ToSign(toSpend *bitcoinTx) *bitcoinTx {
+	return &bitcoinTx{
+		version: 0,
+		inputs: []bitcoinTxInput{{
+			prevout:  Outpoint{TxID: toSpend.txid(), Vout: 0},
+			sequence: 0,
+		}},
+		outputs: []bitcoinTxOutput{{
+			value:  0,
+			script: []byte{0x6a},
+		}},
+		lockTime: 0,
+	}
+}
+
+// signBIP322 constructs and signs the to_sign transaction for the given private key and message.
+func signBIP322(d *big.Int, message []byte, addressType BitcoinAddressType) *bitcoinTx {
+	if !IsValidScalar(d) {
+		panic("SignBIP322: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	} else if addressType != BitcoinAddressP2WPKH && addressType != BitcoinAddressP2TR {
+		panic("SignBIP322: expected address type to be BitcoinAddressP2WPKH or BitcoinAddressP2TR")
+	}
+
+	pubX, pubY := MultiplyBasePoint(d)
+	address := BitcoinAddress(pubX, pubY, addressType, BitcoinMainnet)
+	toSpend, _ := newBIP322ToSpend(address, message, BitcoinMainnet)
+	toSign := newBIP322ToSign(toSpend)
+
+	if addressType == BitcoinAddressP2WPKH {
+		sigHash := toSign.witnessV0SignatureHash(0, p2wpkhScriptCode(pubX, pubY), 0)
+		k := rfc6979Nonce(d, sigHash[:])
+		r, s := SignECDSA(d, k, new(big.Int).SetBytes(sigHash[:]))
+
+		toSign.inputs[0].witness = [][]byte{
+			append(serializeDER(r, s), sigHashAll),
+			serializeCompressed(pubX, pubY),
+		}
+	} else {
+		sigHash := toSign.taprootSignatureHash(0, toSpend.outputs, sigHashDefault)
+		r, s := SignSchnorr(TaprootTweakPrivateKey(d), sigHash[:], nil)
+
+		toSign.inputs[0].witness = [][]byte{SerializeSchnorrSignature(r, s)}
+	}
+
+	return toSign
+}
+
+// verifyBIP322 checks the witness of the to_sign transaction against the
+// output script of the to_spend transaction.
+func verifyBIP322(toSpend, toSign *bitcoinTx) bool {
+	script := toSpend.outputs[0].script
+	witness := toSign.inputs[0].witness
+
+	switch script[0] {
+	case 0x00:
+		// P2WPKH: <signature> <public key>
+		if len(witness) != 2 || len(witness[0]) < 1 || witness[0][len(witness[0])-1] != sigHashAll {
+			return false
+		}
+
+		pubX, pubY, err := parseCompressed(witness[1])
+		if err != nil {
+			return false
+		}
+		if h := hash160(witness[1]); string(h[:]) != string(script[2:]) {
+			return false
+		}
+
+		r, s, err := parseDER(witness[0][:len(witness[0])-1])
+		if err != nil || !IsValidScalar(r) || !IsValidScalar(s) {
+			return false
+		}
+
+		sigHash := toSign.witnessV0SignatureHash(0, p2wpkhScriptCode(pubX, pubY), 0)
+		return VerifyECDSA(new(big.Int).SetBytes(sigHash[:]), r, s, pubX, pubY)
+
+	case 0x51:
+		// P2TR key path: <signature>, with an optional trailing hash type byte.
+		if len(witness) != 1 {
+			return false
+		}
+
+		sig := witness[0]
+		hashType := sigHashDefault
+		if len(sig) == 65 {
+			hashType = sig[64]
+			if hashType != sigHashAll {
+				return false
+			}
+			sig = sig[:64]
+		}
+
+		r, s, err := ParseSchnorrSignature(sig)
+		if err != nil {
+			return false
+		}
+
+		sigHash := toSign.taprootSignatureHash(0, toSpend.outputs, hashType)
+		return VerifySchnorr(sigHash[:], r, s, new(big.Int).SetBytes(script[2:]))
+	}
+
+	return false
+}
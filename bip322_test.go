@@ -0,0 +1,268 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// Test vectors from BIP-322.
+const (
+	bip322TestPrivateKeyWIF = "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k"
+	bip322TestP2WPKHAddress = "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l"
+	bip322TestP2TRAddress   = "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3"
+)
+
+func bip322TestPrivateKey(t *testing.T) *big.Int {
+	version, payload, err := base58CheckDecode(bip322TestPrivateKeyWIF)
+	if err != nil || version != 0x80 || len(payload) != 33 {
+		t.Fatalf("failed to decode test private key: %v", err)
+	}
+	return new(big.Int).SetBytes(payload[:32])
+}
+
+func TestBIP322MessageHash(t *testing.T) {
+	vectors := []struct {
+		message string
+		hash    string
+	}{
+		{"", "c90c269c4f8fcbe6880f72a721ddfbf1914268a794cbb21cfafee13770ae19f1"},
+		{"Hello World", "f0eb03b1a75ac6d9847f55c624a99169b5dccba2a31f5b23bea77ba270de0a7a"},
+	}
+
+	for _, vector := range vectors {
+		hash := BIP322MessageHash([]byte(vector.message))
+		if hex.EncodeToString(hash[:]) != vector.hash {
+			t.Errorf("unexpected message hash for %q: %x", vector.message, hash)
+		}
+	}
+}
+
+func TestVerifyBIP322Simple_Vectors(t *testing.T) {
+	d := bip322TestPrivateKey(t)
+	pubX, pubY := MultiplyBasePoint(d)
+
+	if address := BitcoinAddress(pubX, pubY, BitcoinAddressP2WPKH, BitcoinMainnet); address != bip322TestP2WPKHAddress {
+		t.Errorf("unexpected P2WPKH address for test key: %s", address)
+	}
+	if address := BitcoinAddress(pubX, pubY, BitcoinAddressP2TR, BitcoinMainnet); address != bip322TestP2TRAddress {
+		t.Errorf("unexpected P2TR address for test key: %s", address)
+	}
+
+	vectors := []struct {
+		address   string
+		message   string
+		signature string
+	}{
+		{
+			bip322TestP2WPKHAddress,
+			"",
+			"AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+		},
+		{
+			bip322TestP2WPKHAddress,
+			"Hello World",
+			"AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+		},
+		{
+			bip322TestP2TRAddress,
+			"Hello World",
+			"AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ==",
+		},
+	}
+
+	for i, vector := range vectors {
+		if !VerifyBIP322Simple(vector.address, []byte(vector.message), vector.signature, BitcoinMainnet) {
+			t.Errorf("failed to verify BIP-322 vector %d", i)
+		}
+		if VerifyBIP322Simple(vector.address, []byte("Goodbye World"), vector.signature, BitcoinMainnet) {
+			t.Errorf("verified BIP-322 vector %d for the wrong message", i)
+		}
+	}
+}
+
+func TestSignBIP322(t *testing.T) {
+	message := []byte("I own this address")
+
+	for i := 0; i < 4; i++ {
+		d, _ := RandomScalar(rand.Reader)
+		pubX, pubY := MultiplyBasePoint(d)
+
+		for _, addressType := range []BitcoinAddressType{BitcoinAddressP2WPKH, BitcoinAddressP2TR} {
+			address := BitcoinAddress(pubX, pubY, addressType, BitcoinMainnet)
+			simple := SignBIP322Simple(d, message, addressType)
+			full := SignBIP322Full(d, message, addressType)
+
+			if simple != SignBIP322Simple(d, message, addressType) {
+				t.Errorf("expected BIP-322 signing to be deterministic")
+			}
+
+			if !VerifyBIP322Simple(address, message, simple, BitcoinMainnet) {
+				t.Errorf("failed to verify simple signature for address %s", address)
+			}
+			if !VerifyBIP322Full(address, message, full, BitcoinMainnet) {
+				t.Errorf("failed to verify full signature for address %s", address)
+			}
+
+			if VerifyBIP322Simple(address, []byte("I own that address"), simple, BitcoinMainnet) {
+				t.Errorf("verified simple signature for the wrong message")
+			}
+			if VerifyBIP322Full(address, []byte("I own that address"), full, BitcoinMainnet) {
+				t.Errorf("verified full signature for the wrong message")
+			}
+
+			// The two formats are not interchangeable.
+			if VerifyBIP322Simple(address, message, full, BitcoinMainnet) {
+				t.Errorf("verified full signature as a simple signature")
+			}
+			if VerifyBIP322Full(address, message, simple, BitcoinMainnet) {
+				t.Errorf("verified simple signature as a full signature")
+			}
+
+			testnetAddress := BitcoinAddress(pubX, pubY, addressType, BitcoinTestnet)
+			if VerifyBIP322Simple(testnetAddress, message, simple, BitcoinMainnet) {
+				t.Errorf("verified signature for an address on the wrong network")
+			}
+			if !VerifyBIP322Simple(testnetAddress, message, simple, BitcoinTestnet) {
+				t.Errorf("failed to verify signature for testnet address %s", testnetAddress)
+			}
+		}
+	}
+}
+
+func TestVerifyBIP322_Invalid(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(d)
+	message := []byte("hello")
+
+	p2wpkh := BitcoinAddress(pubX, pubY, BitcoinAddressP2WPKH, BitcoinMainnet)
+	p2tr := BitcoinAddress(pubX, pubY, BitcoinAddressP2TR, BitcoinMainnet)
+	p2pkh := BitcoinAddress(pubX, pubY, BitcoinAddressP2PKH, BitcoinMainnet)
+
+	wpkhSig := SignBIP322Simple(d, message, BitcoinAddressP2WPKH)
+	trSig := SignBIP322Simple(d, message, BitcoinAddressP2TR)
+
+	if VerifyBIP322Simple(p2tr, message, wpkhSig, BitcoinMainnet) {
+		t.Errorf("verified P2WPKH signature for P2TR address")
+	}
+	if VerifyBIP322Simple(p2wpkh, message, trSig, BitcoinMainnet) {
+		t.Errorf("verified P2TR signature for P2WPKH address")
+	}
+	if VerifyBIP322Simple(p2pkh, message, wpkhSig, BitcoinMainnet) {
+		t.Errorf("verified signature for unsupported P2PKH address")
+	}
+
+	otherD, _ := RandomScalar(rand.Reader)
+	otherX, otherY := MultiplyBasePoint(otherD)
+	if VerifyBIP322Simple(BitcoinAddress(otherX, otherY, BitcoinAddressP2WPKH, BitcoinMainnet), message, wpkhSig, BitcoinMainnet) {
+		t.Errorf("verified P2WPKH signature for another key's address")
+	}
+	if VerifyBIP322Simple(BitcoinAddress(otherX, otherY, BitcoinAddressP2TR, BitcoinMainnet), message, trSig, BitcoinMainnet) {
+		t.Errorf("verified P2TR signature for another key's address")
+	}
+
+	// A P2TR signature with an explicit hash type other than SIGHASH_ALL is rejected.
+	witness, _ := base64.StdEncoding.DecodeString(trSig)
+	witness[0]++
+	witness = append(witness, 0x02)
+	if VerifyBIP322Simple(p2tr, message, base64.StdEncoding.EncodeToString(witness), BitcoinMainnet) {
+		t.Errorf("verified P2TR signature with unsupported hash type")
+	}
+
+	invalid := []string{
+		"",
+		"not base64!",
+		base64.StdEncoding.EncodeToString([]byte{0x00}),
+		base64.StdEncoding.EncodeToString([]byte{0x01, 0x40}),
+		wpkhSig[:len(wpkhSig)-8],
+	}
+	for _, sig := range invalid {
+		if VerifyBIP322Simple(p2wpkh, message, sig, BitcoinMainnet) || VerifyBIP322Full(p2wpkh, message, sig, BitcoinMainnet) {
+			t.Errorf("verified invalid signature %q", sig)
+		}
+	}
+
+	// Tampering with any byte of a P2WPKH signature must invalidate it.
+	raw, _ := base64.StdEncoding.DecodeString(wpkhSig)
+	for i := range raw {
+		tampered := append([]byte{}, raw...)
+		tampered[i] ^= 0x01
+		if VerifyBIP322Simple(p2wpkh, message, base64.StdEncoding.EncodeToString(tampered), BitcoinMainnet) {
+			t.Errorf("verified tampered signature with byte %d modified", i)
+		}
+	}
+}
+
+func TestVerifyBIP322Full_Structure(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(d)
+	address := BitcoinAddress(pubX, pubY, BitcoinAddressP2TR, BitcoinMainnet)
+	message := []byte("hello")
+
+	raw, _ := base64.StdEncoding.DecodeString(SignBIP322Full(d, message, BitcoinAddressP2TR))
+	tx, err := parseBitcoinTx(raw)
+	if err != nil {
+		t.Fatalf("failed to parse full signature transaction: %s", err)
+	}
+
+	mutations := []func(tx *bitcoinTx){
+		func(tx *bitcoinTx) { tx.version = 2 },
+		func(tx *bitcoinTx) { tx.lockTime = 1 },
+		func(tx *bitcoinTx) { tx.inputs[0].sequence = 0xffffffff },
+		func(tx *bitcoinTx) { tx.inputs[0].prevout.Vout = 1 },
+		func(tx *bitcoinTx) { tx.outputs[0].value = 1 },
+		func(tx *bitcoinTx) { tx.outputs = append(tx.outputs, tx.outputs[0]) },
+		func(tx *bitcoinTx) { tx.inputs = append(tx.inputs, tx.inputs[0]) },
+	}
+	for i, mutate := range mutations {
+		mutated := *tx
+		mutated.inputs = append([]bitcoinTxInput{}, tx.inputs...)
+		mutated.outputs = append([]bitcoinTxOutput{}, tx.outputs...)
+		mutate(&mutated)
+
+		sig := base64.StdEncoding.EncodeToString(mutated.serialize(true))
+		if VerifyBIP322Full(address, message, sig, BitcoinMainnet) {
+			t.Errorf("verified full signature with mutation %d", i)
+		}
+	}
+}
+
+func TestSignBIP322_Panics(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+
+	for _, addressType := range []BitcoinAddressType{BitcoinAddressP2PKH, BitcoinAddressP2SHP2WPKH} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic when signing for address type %d", addressType)
+				}
+			}()
+			SignBIP322Simple(d, []byte("hello"), addressType)
+		}()
+	}
+}
+
+func BenchmarkSignBIP322Simple(b *testing.B) {
+	d, _ := RandomScalar(rand.Reader)
+	message := []byte("hello")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SignBIP322Simple(d, message, BitcoinAddressP2WPKH)
+	}
+}
+
+func BenchmarkVerifyBIP322Simple(b *testing.B) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(d)
+	address := BitcoinAddress(pubX, pubY, BitcoinAddressP2TR, BitcoinMainnet)
+	message := []byte("hello")
+	sig := SignBIP322Simple(d, message, BitcoinAddressP2TR)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBIP322Simple(address, message, sig, BitcoinMainnet)
+	}
+}
@@ -0,0 +1,122 @@
+package ekliptic
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+)
+
+// BitcoinNetwork holds the address encoding parameters of a bitcoin network.
+type BitcoinNetwork struct {
+	// Bech32HRP is the human-readable part of segregated witness addresses.
+	Bech32HRP string
+
+	// PubKeyHashVersion is the base58check version byte of P2PKH addresses.
+	PubKeyHashVersion byte
+
+	// ScriptHashVersion is the base58check version byte of P2SH addresses.
+	ScriptHashVersion byte
+}
+
+var (
+	// BitcoinMainnet holds the address encoding parameters of the bitcoin main network.
+	BitcoinMainnet = &BitcoinNetwork{Bech32HRP: "bc", PubKeyHashVersion: 0x00, ScriptHashVersion: 0x05}
+
+	// BitcoinTestnet holds the address encoding parameters of the bitcoin test networks.
+	BitcoinTestnet = &BitcoinNetwork{Bech32HRP: "tb", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4}
+)
+
+// BitcoinAddressType is a kind of single-key bitcoin address.
+type BitcoinAddressType int
+
+const (
+	// BitcoinAddressP2PKHUncompressed is a legacy pay-to-pubkey-hash address
+	// of an uncompressed public key.
+	BitcoinAddressP2PKHUncompressed BitcoinAddressType = iota
+
+	// BitcoinAddressP2PKH is a legacy pay-to-pubkey-hash address of a compressed public key.
+	BitcoinAddressP2PKH
+
+	// BitcoinAddressP2SHP2WPKH is a pay-to-witness-pubkey-hash output
+	// nested in a pay-to-script-hash address.
+	BitcoinAddressP2SHP2WPKH
+
+	// BitcoinAddressP2WPKH is a native segregated witness version 0
+	// pay-to-witness-pubkey-hash address.
+	BitcoinAddressP2WPKH
+
+	// BitcoinAddressP2TR is a segregated witness version 1 pay-to-taproot address,
+	// whose output key commits to the public key with no script tree.
+	BitcoinAddressP2TR
+)
+
+// BitcoinAddress encodes the public key (pubX, pubY) as a bitcoin address of the given
+// type on the given network. It panics if the address type is unknown.
+func BitcoinAddress(
+	pubX, pubY *big.Int,
+	addressType BitcoinAddressType,
+	network *BitcoinNetwork,
+) string {
+	switch addressType {
+	case BitcoinAddressP2PKHUncompressed:
+		h := hash160(serializeUncompressed(pubX, pubY))
+		return base58CheckEncode(network.PubKeyHashVersion, h[:])
+
+	case BitcoinAddressP2PKH:
+		h := hash160(serializeCompressed(pubX, pubY))
+		return base58CheckEncode(network.PubKeyHashVersion, h[:])
+
+	case BitcoinAddressP2SHP2WPKH:
+		h := hash160(p2wpkhScript(pubX, pubY))
+		return base58CheckEncode(network.ScriptHashVersion, h[:])
+
+	case BitcoinAddressP2WPKH:
+		h := hash160(serializeCompressed(pubX, pubY))
+		return encodeSegwitAddress(network.Bech32HRP, 0, h[:])
+
+	case BitcoinAddressP2TR:
+		outputX := TaprootOutputKey(pubX)
+		return encodeSegwitAddress(network.Bech32HRP, 1, outputX.FillBytes(make([]byte, 32)))
+	}
+
+	panic("BitcoinAddress: unknown address type")
+}
+
+// normalizeBitcoinAddress lowercases segregated witness addresses, which may
+// legitimately be written in uppercase, so that addresses can be compared as strings.
+func normalizeBitcoinAddress(address string, network *BitcoinNetwork) string {
+	if lower := strings.ToLower(address); strings.HasPrefix(lower, network.Bech32HRP+"1") {
+		return lower
+	}
+	return address
+}
+
+// p2wpkhScript returns the witness version 0 output script OP_0 <hash160(P)>
+// for the public key P.
+func p2wpkhScript(pubX, pubY *big.Int) []byte {
+	h := hash160(serializeCompressed(pubX, pubY))
+	return append([]byte{0x00, 0x14}, h[:]...)
+}
+
+// appendCompactSize appends n to buf as a bitcoin variable length CompactSize integer.
+func appendCompactSize(buf []byte, n uint64) []byte {
+	switch {
+	case n < 0xfd:
+		return append(buf, byte(n))
+	case n <= 0xffff:
+		var b [3]byte
+		b[0] = 0xfd
+		binary.LittleEndian.PutUint16(b[1:], uint16(n))
+		return append(buf, b[:]...)
+	case n <= 0xffffffff:
+		var b [5]byte
+		b[0] = 0xfe
+		binary.LittleEndian.PutUint32(b[1:], uint32(n))
+		return append(buf, b[:]...)
+	default:
+		var b [9]byte
+		b[0] = 0xff
+		binary.LittleEndian.PutUint64(b[1:], n)
+		return append(buf, b[:]...)
+	}
+}
@@ -0,0 +1,102 @@
+package ekliptic
+
+import (
+	"encoding/base64"
+	"math/big"
+)
+
+const bitcoinMessageMagic = "Bitcoin Signed Message:\n"
+
+// BitcoinMessageHash computes the hash signed by bitcoin signed messages:
+//
+//	SHA256(SHA256(CompactSize(24) || "Bitcoin Signed Message:\n" || CompactSize(len(message)) || message))
+func BitcoinMessageHash(message []byte) [32]byte {
+	prefix := appendCompactSize(nil, uint64(len(bitcoinMessageMagic)))
+	prefix = append(prefix, bitcoinMessageMagic...)
+	prefix = appendCompactSize(prefix, uint64(len(message)))
+	return doubleSHA256(prefix, message)
+}
+
+// SignBitcoinMessage signs a message with the private key d, proving ownership of the
+// address of the given type. It returns the base64-encoded 65-byte recoverable signature
+// described by BIP-137, with a nonce derived deterministically with RFC 6979.
+//
+// The first byte of the signature is a header which encodes the recovery ID of the
+// signature, and the type of address it was made for:
+//
+//	27-30: P2PKH, uncompressed public key
+//	31-34: P2PKH, compressed public key
+//	35-38: P2SH-P2WPKH
+//	39-42: P2WPKH
+//
+// The header is followed by the 32-byte big-endian r and s values. SignBitcoinMessage panics
+// if d is not within the range [1, Secp256k1_CurveOrder), or the address type is unknown.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0137.mediawiki
+func SignBitcoinMessage(d *big.Int, message []byte, addressType BitcoinAddressType) string {
+	if !IsValidScalar(d) {
+		panic("SignBitcoinMessage: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	} else if addressType < BitcoinAddressP2PKHUncompressed || addressType > BitcoinAddressP2WPKH {
+		panic("SignBitcoinMessage: unknown address type")
+	}
+
+	hash := BitcoinMessageHash(message)
+	k := rfc6979Nonce(d, hash[:])
+	r, s, recoveryID := SignECDSARecoverable(d, k, new(big.Int).SetBytes(hash[:]))
+	if recoveryID > 1 {
+		// Occurs with negligible probability. The header byte can't express
+		// a nonce point whose x-coordinate overflows the curve order.
+		panic("SignBitcoinMessage: nonce point x-coordinate exceeds curve order")
+	}
+
+	sig := make([]byte, 65)
+	sig[0] = 27 + byte(addressType)*4 + recoveryID
+	r.FillBytes(sig[1:33])
+	s.FillBytes(sig[33:])
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// VerifyBitcoinMessage returns true if signature is a valid BIP-137 signature on message
+// by the owner of the given P2PKH, P2SH-P2WPKH or P2WPKH address on the given network.
+// The signer's public key is recovered from the signature, and its address is compared
+// to the given address.
+//
+// Many wallets sign messages for segregated witness addresses using the header bytes
+// for compressed P2PKH addresses. For compatibility, such signatures are also accepted
+// for P2SH-P2WPKH and P2WPKH addresses of the same public key.
+func VerifyBitcoinMessage(
+	address string,
+	message []byte,
+	signature string,
+	network *BitcoinNetwork,
+) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) != 65 || sig[0] < 27 || sig[0] > 42 {
+		return false
+	}
+
+	recoveryID := (sig[0] - 27) & 3
+	addressType := BitcoinAddressType((sig[0] - 27) >> 2)
+
+	hash := BitcoinMessageHash(message)
+	r := new(big.Int).SetBytes(sig[1:33])
+	s := new(big.Int).SetBytes(sig[33:])
+
+	pubX, pubY, err := RecoverPublicKeyECDSA(new(big.Int).SetBytes(hash[:]), r, s, recoveryID)
+	if err != nil {
+		return false
+	}
+
+	candidates := []BitcoinAddressType{addressType}
+	if addressType == BitcoinAddressP2PKH {
+		candidates = append(candidates, BitcoinAddressP2SHP2WPKH, BitcoinAddressP2WPKH)
+	}
+
+	address = normalizeBitcoinAddress(address, network)
+	for _, candidate := range candidates {
+		if BitcoinAddress(pubX, pubY, candidate, network) == address {
+			return true
+		}
+	}
+	return false
+}
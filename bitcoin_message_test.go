@@ -0,0 +1,188 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSignBitcoinMessage(t *testing.T) {
+	message := []byte("I own this address")
+
+	for i := 0; i < 4; i++ {
+		d, _ := RandomScalar(rand.Reader)
+		pubX, pubY := MultiplyBasePoint(d)
+
+		for addressType := BitcoinAddressP2PKHUncompressed; addressType <= BitcoinAddressP2WPKH; addressType++ {
+			address := BitcoinAddress(pubX, pubY, addressType, BitcoinMainnet)
+			signature := SignBitcoinMessage(d, message, addressType)
+
+			sig, _ := base64.StdEncoding.DecodeString(signature)
+			if header := sig[0]; header < 27+4*byte(addressType) || header > 30+4*byte(addressType) {
+				t.Errorf("unexpected header byte %d for address type %d", header, addressType)
+			}
+
+			if !VerifyBitcoinMessage(address, message, signature, BitcoinMainnet) {
+				t.Errorf("failed to verify signed message for address %s", address)
+			}
+			if VerifyBitcoinMessage(address, []byte("I own that address"), signature, BitcoinMainnet) {
+				t.Errorf("verified signed message for the wrong message")
+			}
+
+			// The header commits to the address type, except for the compressed P2PKH
+			// header which is accepted for segwit addresses for compatibility.
+			for other := BitcoinAddressP2PKHUncompressed; other <= BitcoinAddressP2WPKH; other++ {
+				otherAddress := BitcoinAddress(pubX, pubY, other, BitcoinMainnet)
+				expected := other == addressType ||
+					(addressType == BitcoinAddressP2PKH && other != BitcoinAddressP2PKHUncompressed)
+				if VerifyBitcoinMessage(otherAddress, message, signature, BitcoinMainnet) != expected {
+					t.Errorf("unexpected verification result for address type %d signed as %d", other, addressType)
+				}
+			}
+		}
+
+		segwit := BitcoinAddress(pubX, pubY, BitcoinAddressP2WPKH, BitcoinMainnet)
+		signature := SignBitcoinMessage(d, message, BitcoinAddressP2WPKH)
+		if !VerifyBitcoinMessage(strings.ToUpper(segwit), message, signature, BitcoinMainnet) {
+			t.Errorf("failed to verify signed message for uppercase segwit address")
+		}
+	}
+}
+
+func TestSignBitcoinMessage_Vectors(t *testing.T) {
+	vectors := []struct {
+		wif       string
+		network   *BitcoinNetwork
+		address   string
+		message   string
+		signature string
+	}{
+		// From Bitcoin Core's rpc_signmessage.py functional test.
+		{
+			wif:       "cUeKHd5orzT3mz8P9pxyREHfsWtVfgsfDjiZZBcjUBAaGk1BTj7N",
+			network:   BitcoinTestnet,
+			address:   "mpLQjfK79b7CCV4VMJWEWAj5Mpx8Up5zxB",
+			message:   "This is just a test message",
+			signature: "INbVnW4e6PeRmsv2Qgu8NuopvrVjkcxob+sX8OcZG0SALhWybUjzMLPdAsXI46YZGb0KQTRii+wWIQzRpG/U+S0=",
+		},
+
+		// From Electrum's message signing tests.
+		{
+			wif:       "L1TnU2zbNaAqMoVh65Cyvmcjzbrj41Gs9iTLcWbpJCMynXuap6UN",
+			network:   BitcoinMainnet,
+			address:   "15hETetDmcXm1mM4sEf7U2KXC9hDHFMSzz",
+			message:   "Chancellor on brink of second bailout for banks",
+			signature: "H/9jMOnj4MFbH3d7t4yCQ9i7DgZU/VZ278w3+ySv2F4yIsdqjsc5ng3kmN8OZAThgyfCZOQxZCWza9V5XzlVY0Y=",
+		},
+		{
+			wif:       "5Hxn5C4SQuiV6e62A1MtZmbSeQyrLFhu5uYks62pU5VBUygK2KD",
+			network:   BitcoinMainnet,
+			address:   "1GPHVTY8UD9my6jyP4tb2TYJwUbDetyNC6",
+			message:   "Electrum",
+			signature: "G84dmJ8TKIDKMT9qBRhpX2sNmR0y5t+POcYnFFJCs66lJmAs3T8A6Sbpx7KA6yTQ9djQMabwQXRrDomOkIKGn18=",
+		},
+	}
+
+	for _, vector := range vectors {
+		// WIF private keys of compressed public keys carry a trailing 0x01 byte.
+		_, payload, err := base58CheckDecode(vector.wif)
+		if err != nil {
+			t.Fatalf("failed to decode WIF private key %s: %s", vector.wif, err)
+		}
+		d := new(big.Int).SetBytes(payload[:32])
+		addressType := BitcoinAddressP2PKHUncompressed
+		if len(payload) == 33 {
+			addressType = BitcoinAddressP2PKH
+		}
+
+		pubX, pubY := MultiplyBasePoint(d)
+		if address := BitcoinAddress(pubX, pubY, addressType, vector.network); address != vector.address {
+			t.Errorf("unexpected address:\n  got %s\nwanted %s", address, vector.address)
+		}
+
+		signature := SignBitcoinMessage(d, []byte(vector.message), addressType)
+		if signature != vector.signature {
+			t.Errorf("unexpected signature for address %s:\n  got %s\nwanted %s", vector.address, signature, vector.signature)
+		}
+		if !VerifyBitcoinMessage(vector.address, []byte(vector.message), vector.signature, vector.network) {
+			t.Errorf("failed to verify signature for address %s", vector.address)
+		}
+	}
+}
+
+func TestVerifyBitcoinMessage_Vectors(t *testing.T) {
+	// Segwit signatures with BIP-137 headers, from the Trezor firmware signmessage tests.
+	message := []byte("This is an example of a signed message.")
+	vectors := []struct {
+		address   string
+		signature string
+	}{
+		{
+			// P2SH-P2WPKH, m/49'/0'/0'/0/0
+			address: "3L6TyTisPBmrDAj6RoKmDzNnj4eQi54gD2",
+			signature: "23744de4516fac5c140808015664516a32fead94de89775cec7e24dbc24fe133" +
+				"075ac09301c4cc8e197bea4b6481661d5b8e9bf19d8b7b8a382ecdb53c2ee0750d",
+		},
+		{
+			// P2WPKH, m/84'/0'/0'/0/0
+			address: "bc1qannfxke2tfd4l7vhepehpvt05y83v3qsf6nfkk",
+			signature: "28b55d7600d9e9a7e2a49155ddf3cfdb8e796c207faab833010fa41fb7828889" +
+				"bc47cf62348a7aaa0923c0832a589fab541e8f12eb54fb711c90e2307f0f66b194",
+		},
+	}
+
+	for _, vector := range vectors {
+		sig, _ := hex.DecodeString(vector.signature)
+		signature := base64.StdEncoding.EncodeToString(sig)
+		if !VerifyBitcoinMessage(vector.address, message, signature, BitcoinMainnet) {
+			t.Errorf("failed to verify signature for address %s", vector.address)
+		}
+		if VerifyBitcoinMessage(vector.address, []byte("This is not the signed message."), signature, BitcoinMainnet) {
+			t.Errorf("verified signature for address %s on the wrong message", vector.address)
+		}
+	}
+}
+
+func TestVerifyBitcoinMessage_Invalid(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(d)
+	address := BitcoinAddress(pubX, pubY, BitcoinAddressP2PKH, BitcoinMainnet)
+	message := []byte("hello")
+
+	sig, _ := base64.StdEncoding.DecodeString(SignBitcoinMessage(d, message, BitcoinAddressP2PKH))
+
+	badHeader := append([]byte{43}, sig[1:]...)
+	invalid := []string{
+		"",
+		"not base64!",
+		base64.StdEncoding.EncodeToString(sig[:64]),
+		base64.StdEncoding.EncodeToString(badHeader),
+		base64.StdEncoding.EncodeToString(make([]byte, 65)),
+	}
+	for _, signature := range invalid {
+		if VerifyBitcoinMessage(address, message, signature, BitcoinMainnet) {
+			t.Errorf("verified invalid signature %q", signature)
+		}
+	}
+
+	testnetAddress := BitcoinAddress(pubX, pubY, BitcoinAddressP2PKH, BitcoinTestnet)
+	if VerifyBitcoinMessage(testnetAddress, message, base64.StdEncoding.EncodeToString(sig), BitcoinMainnet) {
+		t.Errorf("verified signature for an address on the wrong network")
+	}
+}
+
+func BenchmarkVerifyBitcoinMessage(b *testing.B) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(d)
+	address := BitcoinAddress(pubX, pubY, BitcoinAddressP2WPKH, BitcoinMainnet)
+	message := []byte("hello")
+	signature := SignBitcoinMessage(d, message, BitcoinAddressP2WPKH)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBitcoinMessage(address, message, signature, BitcoinMainnet)
+	}
+}
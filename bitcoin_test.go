@@ -0,0 +1,49 @@
+package ekliptic
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBitcoinAddress(t *testing.T) {
+	vectors := []struct {
+		addressType BitcoinAddressType
+		network     *BitcoinNetwork
+		address     string
+	}{
+		{BitcoinAddressP2PKHUncompressed, BitcoinMainnet, "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm"},
+		{BitcoinAddressP2PKH, BitcoinMainnet, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"},
+		{BitcoinAddressP2SHP2WPKH, BitcoinMainnet, "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN"},
+		{BitcoinAddressP2WPKH, BitcoinMainnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{BitcoinAddressP2WPKH, BitcoinTestnet, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+		{BitcoinAddressP2TR, BitcoinMainnet, "bc1pmfr3p9j00pfxjh0zmgp99y8zftmd3s5pmedqhyptwy6lm87hf5sspknck9"},
+	}
+
+	// Addresses of the generator point, i.e. private key 1.
+	for _, vector := range vectors {
+		address := BitcoinAddress(Secp256k1_GeneratorX, Secp256k1_GeneratorY, vector.addressType, vector.network)
+		if address != vector.address {
+			t.Errorf("unexpected address of type %d:\n  got %s\nwanted %s", vector.addressType, address, vector.address)
+		}
+	}
+}
+
+func TestAppendCompactSize(t *testing.T) {
+	vectors := []struct {
+		n       uint64
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{0xfc, []byte{0xfc}},
+		{0xfd, []byte{0xfd, 0xfd, 0x00}},
+		{0xffff, []byte{0xfd, 0xff, 0xff}},
+		{0x10000, []byte{0xfe, 0x00, 0x00, 0x01, 0x00}},
+		{0x100000000, []byte{0xff, 0, 0, 0, 0, 1, 0, 0, 0}},
+	}
+
+	for _, vector := range vectors {
+		if encoded := appendCompactSize(nil, vector.n); !bytes.Equal(encoded, vector.encoded) {
+			t.Errorf("unexpected CompactSize encoding of %d: %x", vector.n, encoded)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package ekliptic
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// errInvalidTransaction is returned when parsing a serialized transaction which is malformed.
+var errInvalidTransaction = errors.New("ekliptic: invalid transaction encoding")
+
+const (
+	sigHashDefault byte = 0x00
+	sigHashAll     byte = 0x01
+)
+
+const bip341TapSighashTag = "TapSighash"
+
+// bitcoinTx is a minimal bitcoin transaction, sufficient to construct and sign the
+// virtual transactions used by BIP-322.
+type bitcoinTx struct {
+	version  uint32
+	inputs   []bitcoinTxInput
+	outputs  []bitcoinTxOutput
+	lockTime uint32
+}
+
+type bitcoinTxInput struct {
+	prevout   Outpoint
+	scriptSig []byte
+	sequence  uint32
+	witness   [][]byte
+}
+
+type bitcoinTxOutput struct {
+	value  uint64
+	script []byte
+}
+
+// hasWitness returns true if any of the transaction's inputs has a witness.
+func (tx *bitcoinTx) hasWitness() bool {
+	for _, input := range tx.inputs {
+		if len(input.witness) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// serialize encodes the transaction, using the BIP-144 segregated witness
+// format if withWitness is true and any input has a witness.
+func (tx *bitcoinTx) serialize(withWitness bool) []byte {
+	withWitness = withWitness && tx.hasWitness()
+
+	buf := appendUint32LE(nil, tx.version)
+	if withWitness {
+		buf = append(buf, 0x00, 0x01)
+	}
+
+	buf = appendCompactSize(buf, uint64(len(tx.inputs)))
+	for _, input := range tx.inputs {
+		prevout, _ := input.prevout.MarshalBinary()
+		buf = append(buf, prevout...)
+		buf = appendCompactSize(buf, uint64(len(input.scriptSig)))
+		buf = append(buf, input.scriptSig...)
+		buf = appendUint32LE(buf, input.sequence)
+	}
+
+	buf = appendCompactSize(buf, uint64(len(tx.outputs)))
+	for _, output := range tx.outputs {
+		buf = output.appendTo(buf)
+	}
+
+	if withWitness {
+		for _, input := range tx.inputs {
+			buf = appendWitness(buf, input.witness)
+		}
+	}
+
+	return appendUint32LE(buf, tx.lockTime)
+}
+
+// txid returns the transaction's hash in internal byte order.
+func (tx *bitcoinTx) txid() [32]byte {
+	return doubleSHA256(tx.serialize(false))
+}
+
+func (output bitcoinTxOutput) appendTo(buf []byte) []byte {
+	buf = appendUint64LE(buf, output.value)
+	buf = appendCompactSize(buf, uint64(len(output.script)))
+	return append(buf, output.script...)
+}
+
+// appendWitness appends a witness stack: the number of items,
+// followed by each item prefixed with its length.
+func appendWitness(buf []byte, witness [][]byte) []byte {
+	buf = appendCompactSize(buf, uint64(len(witness)))
+	for _, item := range witness {
+		buf = appendCompactSize(buf, uint64(len(item)))
+		buf = append(buf, item...)
+	}
+	return buf
+}
+
+// witnessV0SignatureHash computes the BIP-143 signature hash of a segregated witness version 0
+// input, spending an output with the given value, using the SIGHASH_ALL hash type.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0143.mediawiki
+func (tx *bitcoinTx) witnessV0SignatureHash(inputIndex int, scriptCode []byte, value uint64) [32]byte {
+	var prevouts, sequences, outputs []byte
+	for _, input := range tx.inputs {
+		prevout, _ := input.prevout.MarshalBinary()
+		prevouts = append(prevouts, prevout...)
+		sequences = appendUint32LE(sequences, input.sequence)
+	}
+	for _, output := range tx.outputs {
+		outputs = output.appendTo(outputs)
+	}
+
+	hashPrevouts := doubleSHA256(prevouts)
+	hashSequence := doubleSHA256(sequences)
+	hashOutputs := doubleSHA256(outputs)
+
+	input := tx.inputs[inputIndex]
+	outpoint, _ := input.prevout.MarshalBinary()
+
+	preimage := appendUint32LE(nil, tx.version)
+	preimage = append(preimage, hashPrevouts[:]...)
+	preimage = append(preimage, hashSequence[:]...)
+	preimage = append(preimage, outpoint...)
+	preimage = append(preimage, scriptCode...)
+	preimage = appendUint64LE(preimage, value)
+	preimage = appendUint32LE(preimage, input.sequence)
+	preimage = append(preimage, hashOutputs[:]...)
+	preimage = appendUint32LE(preimage, tx.lockTime)
+	preimage = appendUint32LE(preimage, uint32(sigHashAll))
+
+	return doubleSHA256(preimage)
+}
+
+// taprootSignatureHash computes the BIP-341 signature hash for a key path spend of
+// the given input, using either the SIGHASH_DEFAULT or SIGHASH_ALL hash type. spent
+// holds the outputs being spent by each of the transaction's inputs.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func (tx *bitcoinTx) taprootSignatureHash(inputIndex int, spent []bitcoinTxOutput, hashType byte) [32]byte {
+	var prevouts, amounts, scripts, sequences, outputs []byte
+	for i, input := range tx.inputs {
+		prevout, _ := input.prevout.MarshalBinary()
+		prevouts = append(prevouts, prevout...)
+		amounts = appendUint64LE(amounts, spent[i].value)
+		scripts = appendCompactSize(scripts, uint64(len(spent[i].script)))
+		scripts = append(scripts, spent[i].script...)
+		sequences = appendUint32LE(sequences, input.sequence)
+	}
+	for _, output := range tx.outputs {
+		outputs = output.appendTo(outputs)
+	}
+
+	shaPrevouts := sha256.Sum256(prevouts)
+	shaAmounts := sha256.Sum256(amounts)
+	shaScripts := sha256.Sum256(scripts)
+	shaSequences := sha256.Sum256(sequences)
+	shaOutputs := sha256.Sum256(outputs)
+
+	// Epoch 0, followed by the hash type.
+	msg := []byte{0x00, hashType}
+	msg = appendUint32LE(msg, tx.version)
+	msg = appendUint32LE(msg, tx.lockTime)
+	msg = append(msg, shaPrevouts[:]...)
+	msg = append(msg, shaAmounts[:]...)
+	msg = append(msg, shaScripts[:]...)
+	msg = append(msg, shaSequences[:]...)
+	msg = append(msg, shaOutputs[:]...)
+
+	// Spend type 0: key path spend without an annex.
+	msg = append(msg, 0x00)
+	msg = appendUint32LE(msg, uint32(inputIndex))
+
+	return taggedHash(bip341TapSighashTag, msg)
+}
+
+// txReader decodes bitcoin serialization primitives from a byte slice.
+type txReader struct {
+	data []byte
+	err  error
+}
+
+func (r *txReader) read(n uint64) []byte {
+	if r.err != nil || uint64(len(r.data)) < n {
+		// Callers only read large lengths after checking them, so this allocation is small.
+		r.err = errInvalidTransaction
+		return make([]byte, n)
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *txReader) readUint32() uint32 {
+	return binary.LittleEndian.Uint32(r.read(4))
+}
+
+func (r *txReader) readUint64() uint64 {
+	return binary.LittleEndian.Uint64(r.read(8))
+}
+
+func (r *txReader) readCompactSize() uint64 {
+	prefix := r.read(1)[0]
+	switch prefix {
+	case 0xfd:
+		return uint64(binary.LittleEndian.Uint16(r.read(2)))
+	case 0xfe:
+		return uint64(r.readUint32())
+	case 0xff:
+		return r.readUint64()
+	default:
+		return uint64(prefix)
+	}
+}
+
+// readBytes reads a CompactSize length-prefixed byte string.
+func (r *txReader) readBytes() []byte {
+	n := r.readCompactSize()
+	if n > uint64(len(r.data)) {
+		r.err = errInvalidTransaction
+		return nil
+	}
+	return append([]byte{}, r.read(n)...)
+}
+
+// readWitness reads a witness stack, as encoded by appendWitness.
+func (r *txReader) readWitness() [][]byte {
+	count := r.readCompactSize()
+	if count > uint64(len(r.data)) {
+		r.err = errInvalidTransaction
+		return nil
+	}
+	witness := make([][]byte, count)
+	for i := range witness {
+		witness[i] = r.readBytes()
+	}
+	return witness
+}
+
+// parseBitcoinTx decodes a transaction serialized by bitcoinTx.serialize.
+func parseBitcoinTx(data []byte) (*bitcoinTx, error) {
+	r := &txReader{data: data}
+	tx := new(bitcoinTx)
+	tx.version = r.readUint32()
+
+	withWitness := len(r.data) >= 2 && r.data[0] == 0x00 && r.data[1] == 0x01
+	if withWitness {
+		r.read(2)
+	}
+
+	inputCount := r.readCompactSize()
+	if inputCount > uint64(len(r.data)) {
+		return nil, errInvalidTransaction
+	}
+	tx.inputs = make([]bitcoinTxInput, inputCount)
+	for i := range tx.inputs {
+		copy(tx.inputs[i].prevout.TxID[:], r.read(32))
+		tx.inputs[i].prevout.Vout = r.readUint32()
+		tx.inputs[i].scriptSig = r.readBytes()
+		tx.inputs[i].sequence = r.readUint32()
+	}
+
+	outputCount := r.readCompactSize()
+	if outputCount > uint64(len(r.data)) {
+		return nil, errInvalidTransaction
+	}
+	tx.outputs = make([]bitcoinTxOutput, outputCount)
+	for i := range tx.outputs {
+		tx.outputs[i].value = r.readUint64()
+		tx.outputs[i].script = r.readBytes()
+	}
+
+	if withWitness {
+		for i := range tx.inputs {
+			tx.inputs[i].witness = r.readWitness()
+		}
+	}
+
+	tx.lockTime = r.readUint32()
+	if r.err != nil || len(r.data) != 0 {
+		return nil, errInvalidTransaction
+	}
+	return tx, nil
+}
+
+// parseWitness decodes a witness stack encoded by appendWitness.
+func parseWitness(data []byte) ([][]byte, error) {
+	r := &txReader{data: data}
+	witness := r.readWitness()
+	if r.err != nil || len(r.data) != 0 {
+		return nil, errInvalidTransaction
+	}
+	return witness, nil
+}
+
+func appendUint32LE(buf []byte, n uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], n)
+	return append(buf, b[:]...)
+}
+
+func appendUint64LE(buf []byte, n uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	return append(buf, b[:]...)
+}
+
+// p2wpkhScriptCode returns the BIP-143 scriptCode for spending a P2WPKH output,
+// which is the equivalent P2PKH script, prefixed with its length.
+func p2wpkhScriptCode(pubX, pubY *big.Int) []byte {
+	h := hash160(serializeCompressed(pubX, pubY))
+	scriptCode := []byte{0x19, 0x76, 0xa9, 0x14}
+	scriptCode = append(scriptCode, h[:]...)
+	return append(scriptCode, 0x88, 0xac)
+}
@@ -0,0 +1,88 @@
+package ekliptic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseBitcoinTx(t *testing.T) {
+	tx := &bitcoinTx{
+		version: 2,
+		inputs: []bitcoinTxInput{
+			{
+				prevout:   Outpoint{TxID: [32]byte{1, 2, 3}, Vout: 7},
+				scriptSig: []byte{0x00, 0x01, 0x02},
+				sequence:  0xfffffffd,
+				witness:   [][]byte{{0xaa, 0xbb}, {}},
+			},
+			{
+				prevout:  Outpoint{TxID: [32]byte{4, 5, 6}, Vout: 0},
+				sequence: 0xffffffff,
+			},
+		},
+		outputs: []bitcoinTxOutput{
+			{value: 50000, script: []byte{0x6a}},
+			{value: 0xffffffffffff, script: bytes.Repeat([]byte{0x51}, 300)},
+		},
+		lockTime: 800000,
+	}
+
+	for _, withWitness := range []bool{false, true} {
+		serialized := tx.serialize(withWitness)
+		parsed, err := parseBitcoinTx(serialized)
+		if err != nil {
+			t.Errorf("failed to parse serialized transaction: %s", err)
+			continue
+		}
+		if !bytes.Equal(parsed.serialize(withWitness), serialized) {
+			t.Errorf("transaction serialization round trip failed")
+		}
+		if parsed.txid() != tx.txid() {
+			t.Errorf("parsed transaction has a different txid")
+		}
+
+		for i := range serialized {
+			if _, err := parseBitcoinTx(serialized[:i]); err == nil {
+				t.Errorf("parsed truncated transaction of length %d", i)
+			}
+		}
+		if _, err := parseBitcoinTx(append(serialized, 0x00)); err == nil {
+			t.Errorf("parsed transaction with trailing data")
+		}
+	}
+
+	if _, err := parseWitness([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err == nil {
+		t.Errorf("parsed witness with oversized item count")
+	}
+}
+
+func TestBitcoinTx_TxID(t *testing.T) {
+	// The BIP-322 to_spend and to_sign transactions for the empty message
+	// spending to the P2WPKH address bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l.
+	toSpend, ok := newBIP322ToSpend("bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l", nil, BitcoinMainnet)
+	if !ok {
+		t.Fatalf("failed to construct to_spend transaction")
+	}
+	toSign := newBIP322ToSign(toSpend)
+
+	vectors := []struct {
+		tx   *bitcoinTx
+		txid string
+	}{
+		{toSpend, "c5680aa69bb8d860bf82d4e9cd3504b55dde018de765a91bb566283c545a99a7"},
+		{toSign, "1e9654e951a5ba44c8604c4de6c67fd78a27e81dcadcfe1edf638ba3aaebaed6"},
+	}
+
+	for _, vector := range vectors {
+		txid := vector.tx.txid()
+
+		// Transaction IDs are displayed in reverse byte order.
+		for i, j := 0, len(txid)-1; i < j; i, j = i+1, j-1 {
+			txid[i], txid[j] = txid[j], txid[i]
+		}
+		if hex.EncodeToString(txid[:]) != vector.txid {
+			t.Errorf("unexpected txid:\n  got %x\nwanted %s", txid, vector.txid)
+		}
+	}
+}
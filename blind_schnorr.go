@@ -0,0 +1,293 @@
+package ekliptic
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrBlindSessionUsed is returned when a BlindSchnorrSigner or BlindSchnorrUser is used
+	// for more than one signature. Responding twice with the same signer nonce would leak the
+	// signer's private key.
+	ErrBlindSessionUsed = errors.New("ekliptic: blind signing session has already been used")
+
+	// ErrInvalidBlindCommitment is returned by NewBlindSchnorrUser if the signer's
+	// commitment is malformed or contains invalid points.
+	ErrInvalidBlindCommitment = errors.New("ekliptic: invalid blind signature commitment")
+
+	// ErrInvalidBlindChallenge is returned by BlindSchnorrSigner.Respond if the user's
+	// challenge is malformed or does not match the signer's commitment.
+	ErrInvalidBlindChallenge = errors.New("ekliptic: invalid blind signature challenge")
+
+	// ErrInvalidBlindResponse is returned by BlindSchnorrUser.Unblind if the signer's
+	// response does not unblind to a valid BIP-340 signature.
+	ErrInvalidBlindResponse = errors.New("ekliptic: invalid blind signature response")
+)
+
+// BlindSchnorrMode selects the blind Schnorr signing protocol variant used by a signer.
+//
+// Plain blind Schnorr signatures are vulnerable to the ROS attack: a user who opens
+// many signing sessions with the same signer concurrently (around 256 or more, and
+// far fewer with Wagner's algorithm) can combine the signer's responses to produce one
+// more valid signature than the number of sessions that were completed. If a signer
+// may run sessions concurrently, use BlindSchnorrClause.
+//
+// https://eprint.iacr.org/2020/945
+type BlindSchnorrMode int
+
+const (
+	// BlindSchnorrSequential is the plain blind Schnorr protocol. It is only secure if the
+	// signer never has more than one session open at a time; every session must be
+	// completed or aborted before the next BlindSchnorrSigner is created for the same key.
+	BlindSchnorrSequential BlindSchnorrMode = iota
+
+	// BlindSchnorrClause is the clause blind Schnorr protocol by Fuchsbauer, Plouviez and
+	// Seurin. The signer commits to two nonces, the user blinds both, and the signer
+	// completes only one of them, picked at random. This defeats the ROS attack, so signers
+	// may run sessions concurrently, at the cost of roughly twice as much computation and
+	// bandwidth.
+	//
+	// https://eprint.iacr.org/2019/877
+	BlindSchnorrClause
+)
+
+// BlindSchnorrCommitment is the first message of a blind signing session, sent from
+// the signer to the user. It contains one nonce point R per protocol clause.
+type BlindSchnorrCommitment struct {
+	R [][2]*big.Int
+}
+
+// BlindSchnorrChallenge is the second message of a blind signing session, sent from
+// the user to the signer. It contains one blinded challenge per nonce point.
+type BlindSchnorrChallenge struct {
+	C []*big.Int
+}
+
+// BlindSchnorrResponse is the final message of a blind signing session, sent from the
+// signer to the user. Index is the nonce which the signer chose to complete, and S
+// is the signer's blinded response for that nonce.
+type BlindSchnorrResponse struct {
+	Index int
+	S     *big.Int
+}
+
+// BlindSchnorrSigner holds the signer's state for a single blind signing session.
+// Each session must use a new BlindSchnorrSigner.
+type BlindSchnorrSigner struct {
+	privateKey *big.Int
+	nonces     []*big.Int
+	commitment *BlindSchnorrCommitment
+}
+
+// NewBlindSchnorrSigner starts a blind signing session for the given private key, using the
+// given protocol variant. Nonces are generated using the given source of randomness, which
+// should be cryptographically secure. Send the result of the Commitment method to the user.
+//
+// The private key should be in the range [1, Secp256k1_CurveOrder). NewBlindSchnorrSigner
+// panics if it is not within this range, or if mode is not a known BlindSchnorrMode.
+func NewBlindSchnorrSigner(
+	random io.Reader,
+	privateKey *big.Int,
+	mode BlindSchnorrMode,
+) (*BlindSchnorrSigner, error) {
+	if !IsValidScalar(privateKey) {
+		panic("NewBlindSchnorrSigner: expected private key to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	var nonceCount int
+	switch mode {
+	case BlindSchnorrSequential:
+		nonceCount = 1
+	case BlindSchnorrClause:
+		nonceCount = 2
+	default:
+		panic("NewBlindSchnorrSigner: expected mode to be BlindSchnorrSequential or BlindSchnorrClause")
+	}
+
+	_, pubY := MultiplyBasePoint(privateKey)
+
+	signer := &BlindSchnorrSigner{
+		privateKey: evenYScalar(privateKey, pubY),
+		nonces:     make([]*big.Int, nonceCount),
+		commitment: &BlindSchnorrCommitment{R: make([][2]*big.Int, nonceCount)},
+	}
+
+	for i := range signer.nonces {
+		k, err := RandomScalar(random)
+		if err != nil {
+			return nil, err
+		}
+		signer.nonces[i] = k
+		signer.commitment.R[i][0], signer.commitment.R[i][1] = MultiplyBasePoint(k)
+	}
+
+	return signer, nil
+}
+
+// Commitment returns the signer's nonce commitment, to be sent to the user.
+func (signer *BlindSchnorrSigner) Commitment() *BlindSchnorrCommitment {
+	return signer.commitment
+}
+
+// Respond signs the user's blinded challenge. In BlindSchnorrClause mode, the signer picks
+// one of the two clauses at random using the given source of randomness, which should be
+// cryptographically secure. In BlindSchnorrSequential mode, random is not used and may be nil.
+//
+// The signer's nonces are erased after responding, so Respond returns ErrBlindSessionUsed
+// if called more than once.
+//
+//	s = k + c * d mod N
+func (signer *BlindSchnorrSigner) Respond(
+	random io.Reader,
+	challenge *BlindSchnorrChallenge,
+) (*BlindSchnorrResponse, error) {
+	if signer.nonces == nil {
+		return nil, ErrBlindSessionUsed
+	}
+	if challenge == nil || len(challenge.C) != len(signer.nonces) {
+		return nil, ErrInvalidBlindChallenge
+	}
+	for _, c := range challenge.C {
+		if !isValidProofScalar(c) {
+			return nil, ErrInvalidBlindChallenge
+		}
+	}
+
+	index := 0
+	if len(signer.nonces) > 1 {
+		var b [1]byte
+		if _, err := io.ReadFull(random, b[:]); err != nil {
+			return nil, err
+		}
+		index = int(b[0] & 1)
+	}
+
+	s := new(big.Int).Mul(challenge.C[index], signer.privateKey)
+	s.Add(s, signer.nonces[index])
+	modScalar(s)
+
+	signer.nonces = nil
+	return &BlindSchnorrResponse{Index: index, S: s}, nil
+}
+
+// blindSchnorrClause holds the user's unblinding state for one of the signer's nonces.
+type blindSchnorrClause struct {
+	alpha  *big.Int
+	r      *big.Int
+	negate bool
+}
+
+// BlindSchnorrUser holds the user's state for a single blind signing session.
+// Each session must use a new BlindSchnorrUser.
+type BlindSchnorrUser struct {
+	pubX    *big.Int
+	message []byte
+	clauses []blindSchnorrClause
+}
+
+// NewBlindSchnorrUser receives a signer's commitment, and blinds it for signing the given
+// message under the signer's x-only BIP-340 public key pubX. Blinding factors are generated
+// using the given source of randomness, which should be cryptographically secure. Send the
+// returned challenge to the signer.
+//
+// For each nonce point R, the user picks random scalars α and β and computes:
+//
+//	R' = R + α * G + β * P
+//	e = hash_challenge(x(R') || x(P) || m)
+//	c = e + β  (if R' has an even y-coordinate)
+//	c = β - e  (if R' has an odd y-coordinate)
+//
+// The signer sees only c, which is uniformly random and unlinkable to the final signature.
+// The protocol variant is inferred from the number of nonces in the commitment.
+func NewBlindSchnorrUser(
+	random io.Reader,
+	pubX *big.Int,
+	message []byte,
+	commitment *BlindSchnorrCommitment,
+) (*BlindSchnorrUser, *BlindSchnorrChallenge, error) {
+	if commitment == nil || len(commitment.R) < 1 || len(commitment.R) > 2 {
+		return nil, nil, ErrInvalidBlindCommitment
+	}
+	for _, R := range commitment.R {
+		if !isValidPublicPoint(R[0], R[1]) {
+			return nil, nil, ErrInvalidBlindCommitment
+		}
+	}
+
+	pubY, _ := Weierstrass(pubX)
+	if pubY == nil {
+		panic("NewBlindSchnorrUser: expected pubX to be the x-coordinate of a point on the curve")
+	}
+
+	user := &BlindSchnorrUser{
+		pubX:    new(big.Int).Set(pubX),
+		message: append([]byte{}, message...),
+		clauses: make([]blindSchnorrClause, len(commitment.R)),
+	}
+	challenge := &BlindSchnorrChallenge{C: make([]*big.Int, len(commitment.R))}
+
+	for i, R := range commitment.R {
+		alpha, err := RandomScalar(random)
+		if err != nil {
+			return nil, nil, err
+		}
+		beta, err := RandomScalar(random)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// R' = R + α * G + β * P
+		x, y := linearCombination(Secp256k1_GeneratorX, Secp256k1_GeneratorY, alpha, pubX, pubY, beta)
+		x, y = AddAffine(x, y, R[0], R[1])
+		if x.Sign() == 0 && y.Sign() == 0 {
+			// Occurs with negligible probability.
+			return nil, nil, ErrInvalidBlindCommitment
+		}
+
+		negate := !isEven(y)
+		c := schnorrChallenge(x, pubX, message)
+		if negate {
+			c.Sub(beta, c)
+		} else {
+			c.Add(c, beta)
+		}
+		modScalar(c)
+
+		user.clauses[i] = blindSchnorrClause{alpha: alpha, r: x, negate: negate}
+		challenge.C[i] = c
+	}
+
+	return user, challenge, nil
+}
+
+// Unblind converts the signer's response into a BIP-340 signature (r, s) on the user's message,
+// which can be checked with VerifySchnorr. The signer cannot link the resulting signature to
+// the session in which it was issued. Unblind returns ErrInvalidBlindResponse if the response
+// does not produce a valid signature, and ErrBlindSessionUsed if called more than once.
+//
+//	s' = s + α       (if R' has an even y-coordinate)
+//	s' = -(s + α)    (if R' has an odd y-coordinate)
+func (user *BlindSchnorrUser) Unblind(response *BlindSchnorrResponse) (r, s *big.Int, err error) {
+	if user.clauses == nil {
+		return nil, nil, ErrBlindSessionUsed
+	}
+	if response == nil || response.Index < 0 || response.Index >= len(user.clauses) ||
+		!isValidProofScalar(response.S) {
+		return nil, nil, ErrInvalidBlindResponse
+	}
+
+	clause := user.clauses[response.Index]
+	s = new(big.Int).Add(response.S, clause.alpha)
+	if clause.negate {
+		s.Neg(s)
+	}
+	modScalar(s)
+
+	if !VerifySchnorr(user.message, clause.r, s, user.pubX) {
+		return nil, nil, ErrInvalidBlindResponse
+	}
+
+	user.clauses = nil
+	return new(big.Int).Set(clause.r), s, nil
+}
@@ -0,0 +1,142 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func runBlindSchnorrSession(
+	t *testing.T,
+	privateKey *big.Int,
+	message []byte,
+	mode BlindSchnorrMode,
+) (r, s *big.Int, challenge *BlindSchnorrChallenge) {
+	pubX, _ := MultiplyBasePoint(privateKey)
+
+	signer, err := NewBlindSchnorrSigner(rand.Reader, privateKey, mode)
+	if err != nil {
+		t.Fatalf("failed to create blind signer: %s", err)
+	}
+
+	user, challenge, err := NewBlindSchnorrUser(rand.Reader, pubX, message, signer.Commitment())
+	if err != nil {
+		t.Fatalf("failed to create blind user: %s", err)
+	}
+
+	response, err := signer.Respond(rand.Reader, challenge)
+	if err != nil {
+		t.Fatalf("failed to respond to blind challenge: %s", err)
+	}
+
+	if _, err := signer.Respond(rand.Reader, challenge); err != ErrBlindSessionUsed {
+		t.Errorf("expected ErrBlindSessionUsed when responding twice; got %v", err)
+	}
+
+	r, s, err = user.Unblind(response)
+	if err != nil {
+		t.Fatalf("failed to unblind signature: %s", err)
+	}
+
+	if _, _, err := user.Unblind(response); err != ErrBlindSessionUsed {
+		t.Errorf("expected ErrBlindSessionUsed when unblinding twice; got %v", err)
+	}
+
+	return r, s, challenge
+}
+
+func TestBlindSchnorr(t *testing.T) {
+	for _, mode := range []BlindSchnorrMode{BlindSchnorrSequential, BlindSchnorrClause} {
+		for i := 0; i < 4; i++ {
+			privateKey, _ := RandomScalar(rand.Reader)
+			pubX, _ := MultiplyBasePoint(privateKey)
+			message := []byte("anonymous token")
+
+			r, s, challenge := runBlindSchnorrSession(t, privateKey, message, mode)
+
+			if !VerifySchnorr(message, r, s, pubX) {
+				t.Errorf("failed to verify unblinded signature in mode %d", mode)
+			}
+			if VerifySchnorr([]byte("another token"), r, s, pubX) {
+				t.Errorf("verified unblinded signature on the wrong message")
+			}
+
+			// The challenge the signer saw must not be the one in the final signature.
+			e := schnorrChallenge(r, pubX, message)
+			for _, c := range challenge.C {
+				if equal(c, e) {
+					t.Errorf("blind challenge was not blinded")
+				}
+			}
+		}
+	}
+}
+
+func TestBlindSchnorr_InvalidMessages(t *testing.T) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	pubX, _ := MultiplyBasePoint(privateKey)
+
+	signer, _ := NewBlindSchnorrSigner(rand.Reader, privateKey, BlindSchnorrClause)
+	commitment := signer.Commitment()
+
+	badCommitments := []*BlindSchnorrCommitment{
+		nil,
+		{},
+		{R: [][2]*big.Int{commitment.R[0], commitment.R[1], commitment.R[0]}},
+		{R: [][2]*big.Int{{big.NewInt(1), big.NewInt(1)}}},
+	}
+	for _, bad := range badCommitments {
+		if _, _, err := NewBlindSchnorrUser(rand.Reader, pubX, nil, bad); err != ErrInvalidBlindCommitment {
+			t.Errorf("expected ErrInvalidBlindCommitment; got %v", err)
+		}
+	}
+
+	user, challenge, err := NewBlindSchnorrUser(rand.Reader, pubX, nil, commitment)
+	if err != nil {
+		t.Fatalf("failed to create blind user: %s", err)
+	}
+
+	badChallenges := []*BlindSchnorrChallenge{
+		nil,
+		{C: challenge.C[:1]},
+		{C: []*big.Int{challenge.C[0], Secp256k1_CurveOrder}},
+	}
+	for _, bad := range badChallenges {
+		if _, err := signer.Respond(rand.Reader, bad); err != ErrInvalidBlindChallenge {
+			t.Errorf("expected ErrInvalidBlindChallenge; got %v", err)
+		}
+	}
+
+	response, err := signer.Respond(rand.Reader, challenge)
+	if err != nil {
+		t.Fatalf("failed to respond to blind challenge: %s", err)
+	}
+
+	badResponses := []*BlindSchnorrResponse{
+		nil,
+		{Index: 2, S: response.S},
+		{Index: response.Index, S: new(big.Int).Add(response.S, one)},
+		{Index: 1 - response.Index, S: response.S},
+	}
+	for _, bad := range badResponses {
+		if _, _, err := user.Unblind(bad); err != ErrInvalidBlindResponse {
+			t.Errorf("expected ErrInvalidBlindResponse; got %v", err)
+		}
+	}
+
+	if _, _, err := user.Unblind(response); err != nil {
+		t.Errorf("failed to unblind valid response after rejecting invalid ones: %s", err)
+	}
+}
+
+func BenchmarkBlindSchnorr(b *testing.B) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	pubX, _ := MultiplyBasePoint(privateKey)
+
+	for i := 0; i < b.N; i++ {
+		signer, _ := NewBlindSchnorrSigner(rand.Reader, privateKey, BlindSchnorrSequential)
+		user, challenge, _ := NewBlindSchnorrUser(rand.Reader, pubX, nil, signer.Commitment())
+		response, _ := signer.Respond(nil, challenge)
+		user.Unblind(response)
+	}
+}
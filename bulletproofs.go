@@ -0,0 +1,682 @@
+package ekliptic
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// RangeProofBits is the bit-length of the range [0, 2^64) proven by a RangeProof.
+const RangeProofBits = 64
+
+// ErrInvalidAggregationSize is returned when constructing a RangeProof for a number
+// of values which is not a power of two, or a mismatched number of blinding factors.
+var ErrInvalidAggregationSize = errors.New("ekliptic: range proofs must aggregate a power-of-two number of values")
+
+const bulletproofGeneratorsDST = "ekliptic/Bulletproofs/generators"
+
+// bulletproofGens caches the vector Pedersen generators used by range proofs. Each generator
+// is derived deterministically with HashToCurve, so nobody knows the discrete log relations
+// between any of them (nothing up my sleeve). The cache grows as larger proofs are built.
+var bulletproofGens struct {
+	sync.Mutex
+	g, h [][2]*big.Int
+	u    [2]*big.Int
+}
+
+// bulletproofGenerators returns the first n vector generators G and H, and the
+// generator U used to bind the inner product in the inner-product argument.
+//
+//	G_i = HashToCurve("G" || uint32(i))
+//	H_i = HashToCurve("H" || uint32(i))
+//	U   = HashToCurve("U")
+func bulletproofGenerators(n int) (g, h [][2]*big.Int, u [2]*big.Int) {
+	bulletproofGens.Lock()
+	defer bulletproofGens.Unlock()
+
+	if bulletproofGens.u[0] == nil {
+		bulletproofGens.u[0], bulletproofGens.u[1] = HashToCurve([]byte("U"), []byte(bulletproofGeneratorsDST))
+	}
+
+	for i := len(bulletproofGens.g); i < n; i++ {
+		msg := make([]byte, 5)
+		binary.BigEndian.PutUint32(msg[1:], uint32(i))
+
+		var gi, hi [2]*big.Int
+		msg[0] = 'G'
+		gi[0], gi[1] = HashToCurve(msg, []byte(bulletproofGeneratorsDST))
+		msg[0] = 'H'
+		hi[0], hi[1] = HashToCurve(msg, []byte(bulletproofGeneratorsDST))
+
+		bulletproofGens.g = append(bulletproofGens.g, gi)
+		bulletproofGens.h = append(bulletproofGens.h, hi)
+	}
+
+	return bulletproofGens.g[:n], bulletproofGens.h[:n], bulletproofGens.u
+}
+
+// RangeProof is a Bulletproofs range proof that one or more PedersenCommitments each
+// commit to a value in the range [0, 2^64), without revealing the values.
+// Proofs for m values can be aggregated into a single proof, where m is a power of
+// two. The size of a proof grows only logarithmically with m.
+//
+// The proof follows the construction of Bünz et al., "Bulletproofs: Short Proofs for
+// Confidential Transactions and More", made non-interactive with a Transcript.
+// Commitments use PedersenGeneratorH as the value generator and the secp256k1
+// generator G as the blinding generator:
+//
+//	V = v * H + gamma * G
+//
+// https://eprint.iacr.org/2017/1066.pdf
+type RangeProof struct {
+	// Commitments to the bit vectors and blinding vectors.
+	A, S [2]*big.Int
+
+	// Commitments to the coefficients of t(X).
+	T1, T2 [2]*big.Int
+
+	// Blinding factor for t(x), blinding factor for A + xS, and t(x) itself.
+	TauX, Mu, THat *big.Int
+
+	// The inner-product argument: the left and right commitments for each round,
+	// and the final folded scalars.
+	L, R                         [][2]*big.Int
+	InnerProductA, InnerProductB *big.Int
+}
+
+// ProveRange constructs an aggregated RangeProof that each of the given values is in the
+// range [0, 2^64), and returns the proof along with the PedersenCommitment to each value
+// under the corresponding blinding factor. The number of values must be a power of two,
+// and each value must have its own blinding factor. Blinding vectors and nonces are
+// generated using the given source of randomness, which should be cryptographically
+// secure.
+func ProveRange(
+	random io.Reader,
+	values []uint64,
+	blindingFactors []*big.Int,
+) (*RangeProof, []*PedersenCommitment, error) {
+	m := len(values)
+	if m == 0 || m&(m-1) != 0 || len(blindingFactors) != m {
+		return nil, nil, ErrInvalidAggregationSize
+	}
+
+	n := RangeProofBits
+	nm := n * m
+	gens, hens, u := bulletproofGenerators(nm)
+
+	commitments := make([]*PedersenCommitment, m)
+	for j, v := range values {
+		commitments[j] = CommitPedersen(new(big.Int).SetUint64(v), blindingFactors[j])
+	}
+
+	t := newRangeProofTranscript(m, commitments)
+
+	randoms := make([]*big.Int, 4+2*nm)
+	for i := range randoms {
+		r, err := RandomScalar(random)
+		if err != nil {
+			return nil, nil, err
+		}
+		randoms[i] = r
+	}
+	alpha, rho, tau1, tau2 := randoms[0], randoms[1], randoms[2], randoms[3]
+	sL, sR := randoms[4:4+nm], randoms[4+nm:]
+
+	// aL holds the bits of each value. aR = aL - 1.
+	aL := make([]*big.Int, nm)
+	aR := make([]*big.Int, nm)
+	for j, v := range values {
+		for k := 0; k < n; k++ {
+			bit := int64((v >> k) & 1)
+			aL[j*n+k] = big.NewInt(bit)
+			aR[j*n+k] = big.NewInt(bit - 1)
+		}
+	}
+
+	// A = alpha * G + <aL, G_vec> + <aR, H_vec>
+	//
+	// Since aL is a bit vector and aR = aL - 1, each bit adds either G_i or -H_i, so this
+	// requires only point additions. The bits are secret, so the point to add is selected
+	// with a constant-time copy, and every bit performs the same single addition.
+	aX, aY := MultiplyBasePoint(alpha)
+	a := AffinePoint{aX, aY}.ToJacobian()
+	addend := JacobianPoint{new(big.Int), new(big.Int), one}
+	var selected, gBytes [64]byte
+	for i := range aL {
+		hens[i][0].FillBytes(selected[:32])
+		Negate(hens[i][1]).FillBytes(selected[32:])
+		gens[i][0].FillBytes(gBytes[:32])
+		gens[i][1].FillBytes(gBytes[32:])
+		subtle.ConstantTimeCopy(int(aL[i].Int64()), selected[:], gBytes[:])
+
+		addend.X.SetBytes(selected[:32])
+		addend.Y.SetBytes(selected[32:])
+		a = a.Add(addend)
+	}
+	aAffine := a.ToAffine()
+	aX, aY = aAffine.X, aAffine.Y
+
+	// S = rho * G + <sL, G_vec> + <sR, H_vec>
+	//
+	// The blinding vectors are secret, so S is computed in constant time.
+	sPoints := make([][2]*big.Int, 0, 1+2*nm)
+	sPoints = append(append(append(sPoints, [2]*big.Int{Secp256k1_GeneratorX, Secp256k1_GeneratorY}), gens...), hens...)
+	sScalars := make([]*big.Int, 0, 1+2*nm)
+	sScalars = append(append(append(sScalars, rho), sL...), sR...)
+	sX, sY := secretMultiScalarMultiply(sPoints, sScalars)
+
+	t.AppendPoint("A", aX, aY)
+	t.AppendPoint("S", sX, sY)
+	y := t.ChallengeScalar("y")
+	z := t.ChallengeScalar("z")
+
+	yPowers := scalarPowers(y, nm)
+	zPowers := scalarPowers(z, m+2)
+	twoPowers := scalarPowers(two, n)
+
+	// l(X) = (aL - z) + sL * X
+	// r(X) = y^nm ∘ (aR + z + sR * X) + z^(2+j) * 2^n
+	l0 := make([]*big.Int, nm)
+	r0 := make([]*big.Int, nm)
+	r1 := make([]*big.Int, nm)
+	for i := range l0 {
+		l0[i] = new(big.Int).Sub(aL[i], z)
+		modScalar(l0[i])
+
+		r0[i] = new(big.Int).Add(aR[i], z)
+		r0[i].Mul(r0[i], yPowers[i])
+		r0[i].Add(r0[i], new(big.Int).Mul(zPowers[2+i/n], twoPowers[i%n]))
+		modScalar(r0[i])
+
+		r1[i] = new(big.Int).Mul(yPowers[i], sR[i])
+		modScalar(r1[i])
+	}
+
+	// t(X) = <l(X), r(X)> = t0 + t1 * X + t2 * X²
+	t1 := innerProduct(l0, r1)
+	t1.Add(t1, innerProduct(sL, r0))
+	modScalar(t1)
+	t2 := innerProduct(sL, r1)
+
+	bigT1 := CommitPedersen(t1, tau1)
+	bigT2 := CommitPedersen(t2, tau2)
+
+	t.AppendPoint("T1", bigT1.X, bigT1.Y)
+	t.AppendPoint("T2", bigT2.X, bigT2.Y)
+	x := t.ChallengeScalar("x")
+
+	// l = l0 + sL * x
+	// r = r0 + r1 * x
+	l := make([]*big.Int, nm)
+	r := make([]*big.Int, nm)
+	for i := range l {
+		l[i] = new(big.Int).Mul(sL[i], x)
+		l[i].Add(l[i], l0[i])
+		modScalar(l[i])
+
+		r[i] = new(big.Int).Mul(r1[i], x)
+		r[i].Add(r[i], r0[i])
+		modScalar(r[i])
+	}
+	tHat := innerProduct(l, r)
+
+	// tauX = tau2 * x² + tau1 * x + sum(z^(2+j) * gamma_j)
+	tauX := new(big.Int).Mul(tau2, x)
+	tauX.Add(tauX, tau1)
+	tauX.Mul(tauX, x)
+	for j, gamma := range blindingFactors {
+		tauX.Add(tauX, new(big.Int).Mul(zPowers[2+j], gamma))
+	}
+	modScalar(tauX)
+
+	// mu = alpha + rho * x
+	mu := new(big.Int).Mul(rho, x)
+	mu.Add(mu, alpha)
+	modScalar(mu)
+
+	t.AppendScalar("tau_x", tauX)
+	t.AppendScalar("mu", mu)
+	t.AppendScalar("t_hat", tHat)
+	w := t.ChallengeScalar("w")
+
+	// Q = w * U binds the inner product into the inner-product argument.
+	qX, qY := MultiplyAffine(u[0], u[1], w, nil)
+
+	// The inner-product argument uses H'_i = y^-i * H_i as its H generators.
+	gFactors := make([]*big.Int, nm)
+	for i := range gFactors {
+		gFactors[i] = one
+	}
+	hFactors := scalarPowers(InvertScalar(y), nm)
+
+	lPoints, rPoints, ipaA, ipaB := proveInnerProduct(
+		t,
+		[2]*big.Int{qX, qY},
+		gens, hens,
+		gFactors, hFactors,
+		l, r,
+	)
+
+	proof := &RangeProof{
+		A:             [2]*big.Int{aX, aY},
+		S:             [2]*big.Int{sX, sY},
+		T1:            [2]*big.Int{bigT1.X, bigT1.Y},
+		T2:            [2]*big.Int{bigT2.X, bigT2.Y},
+		TauX:          tauX,
+		Mu:            mu,
+		THat:          tHat,
+		L:             lPoints,
+		R:             rPoints,
+		InnerProductA: ipaA,
+		InnerProductB: ipaB,
+	}
+	return proof, commitments, nil
+}
+
+// proveInnerProduct constructs an inner-product argument that the prover knows vectors
+// a and b such that:
+//
+//	P = <a, G'> + <b, H'> + <a, b> * Q
+//
+// Where G'_i = gFactors_i * G_i and H'_i = hFactors_i * H_i. Each round halves the
+// vectors, committing to the cross terms with L and R:
+//
+//	L = <a_lo, G_hi> + <b_hi, H_lo> + <a_lo, b_hi> * Q
+//	R = <a_hi, G_lo> + <b_lo, H_hi> + <a_hi, b_lo> * Q
+//	u = challenge
+//	a = a_lo * u + a_hi * u⁻¹
+//	b = b_lo * u⁻¹ + b_hi * u
+//	G = G_lo * u⁻¹ + G_hi * u
+//	H = H_lo * u + H_hi * u⁻¹
+//
+// The vectors a and b are derived from the witness, so L and R are computed in constant
+// time. The generators are folded with public challenges, so this can use the faster
+// variable-time multi-scalar multiplication. The folded generators are computed in Jacobian
+// coordinates, and normalized with a single BatchToAffine call per round.
+//
+// It returns the L and R commitments for each round, and the final scalars a and b.
+func proveInnerProduct(
+	t *Transcript,
+	q [2]*big.Int,
+	gens, hens [][2]*big.Int,
+	gFactors, hFactors []*big.Int,
+	a, b []*big.Int,
+) (lPoints, rPoints [][2]*big.Int, finalA, finalB *big.Int) {
+	n := len(a)
+
+	for n > 1 {
+		n /= 2
+		aLo, aHi := a[:n], a[n:]
+		bLo, bHi := b[:n], b[n:]
+		gLo, gHi := gens[:n], gens[n:]
+		hLo, hHi := hens[:n], hens[n:]
+
+		cL := innerProduct(aLo, bHi)
+		cR := innerProduct(aHi, bLo)
+
+		points := make([][2]*big.Int, 0, 2*n+1)
+		scalars := make([]*big.Int, 0, 2*n+1)
+		points = append(append(append(points, gHi...), hLo...), q)
+		for i := 0; i < n; i++ {
+			scalars = append(scalars, mulScalars(aLo[i], gFactors[n+i]))
+		}
+		for i := 0; i < n; i++ {
+			scalars = append(scalars, mulScalars(bHi[i], hFactors[i]))
+		}
+		scalars = append(scalars, cL)
+		lX, lY := secretMultiScalarMultiply(points, scalars)
+
+		points = append(append(append(points[:0], gLo...), hHi...), q)
+		scalars = scalars[:0]
+		for i := 0; i < n; i++ {
+			scalars = append(scalars, mulScalars(aHi[i], gFactors[i]))
+		}
+		for i := 0; i < n; i++ {
+			scalars = append(scalars, mulScalars(bLo[i], hFactors[n+i]))
+		}
+		scalars = append(scalars, cR)
+		rX, rY := secretMultiScalarMultiply(points, scalars)
+
+		lPoints = append(lPoints, [2]*big.Int{lX, lY})
+		rPoints = append(rPoints, [2]*big.Int{rX, rY})
+
+		t.AppendPoint("L", lX, lY)
+		t.AppendPoint("R", rX, rY)
+		u := t.ChallengeScalar("u")
+		uInv := InvertScalar(u)
+
+		nextA := make([]*big.Int, n)
+		nextB := make([]*big.Int, n)
+
+		// The folded G generators, followed by the folded H generators.
+		folded := make([]JacobianPoint, 2*n)
+		for i := 0; i < n; i++ {
+			nextA[i] = new(big.Int).Add(mulScalars(aLo[i], u), mulScalars(aHi[i], uInv))
+			modScalar(nextA[i])
+			nextB[i] = new(big.Int).Add(mulScalars(bLo[i], uInv), mulScalars(bHi[i], u))
+			modScalar(nextB[i])
+
+			folded[i] = multiScalarMultiplyJacobi(
+				[][2]*big.Int{gLo[i], gHi[i]},
+				[]*big.Int{mulScalars(uInv, gFactors[i]), mulScalars(u, gFactors[n+i])},
+			)
+			folded[n+i] = multiScalarMultiplyJacobi(
+				[][2]*big.Int{hLo[i], hHi[i]},
+				[]*big.Int{mulScalars(u, hFactors[i]), mulScalars(uInv, hFactors[n+i])},
+			)
+		}
+
+		// Normalize all the folded generators with a single inversion.
+		nextGH := make([][2]*big.Int, 2*n)
+		for i, p := range BatchToAffine(folded) {
+			nextGH[i] = [2]*big.Int{p.X, p.Y}
+		}
+
+		a, b, gens, hens = nextA, nextB, nextGH[:n], nextGH[n:]
+
+		// The factors have been folded into the generators.
+		gFactors = make([]*big.Int, n)
+		hFactors = make([]*big.Int, n)
+		for i := 0; i < n; i++ {
+			gFactors[i] = one
+			hFactors[i] = one
+		}
+	}
+
+	return lPoints, rPoints, a[0], b[0]
+}
+
+// VerifyRange returns true if the given RangeProof proves that every one of the given
+// commitments commits to a value in the range [0, 2^64). The commitments must be
+// given in the same order they were returned by ProveRange.
+//
+// Both the range proof relation and the inner-product argument are checked using a
+// single multi-scalar multiplication over the generator vectors, which must sum to
+// the point at infinity. The two equations are combined with a random weight c
+// derived from the transcript.
+func VerifyRange(proof *RangeProof, commitments []*PedersenCommitment) bool {
+	m := len(commitments)
+	if proof == nil || m == 0 || m&(m-1) != 0 {
+		return false
+	}
+
+	n := RangeProofBits
+	nm := n * m
+	rounds := len(proof.L)
+	if len(proof.R) != rounds || 1<<rounds != nm {
+		return false
+	}
+
+	for _, c := range commitments {
+		if !isValidPublicPoint(c.X, c.Y) {
+			return false
+		}
+	}
+	points := append([][2]*big.Int{proof.A, proof.S, proof.T1, proof.T2}, proof.L...)
+	for _, p := range append(points, proof.R...) {
+		if !isValidPublicPoint(p[0], p[1]) {
+			return false
+		}
+	}
+	for _, s := range []*big.Int{proof.TauX, proof.Mu, proof.THat, proof.InnerProductA, proof.InnerProductB} {
+		if !isValidProofScalar(s) {
+			return false
+		}
+	}
+
+	gens, hens, u := bulletproofGenerators(nm)
+
+	t := newRangeProofTranscript(m, commitments)
+	t.AppendPoint("A", proof.A[0], proof.A[1])
+	t.AppendPoint("S", proof.S[0], proof.S[1])
+	y := t.ChallengeScalar("y")
+	z := t.ChallengeScalar("z")
+	t.AppendPoint("T1", proof.T1[0], proof.T1[1])
+	t.AppendPoint("T2", proof.T2[0], proof.T2[1])
+	x := t.ChallengeScalar("x")
+	t.AppendScalar("tau_x", proof.TauX)
+	t.AppendScalar("mu", proof.Mu)
+	t.AppendScalar("t_hat", proof.THat)
+	w := t.ChallengeScalar("w")
+
+	challenges := make([]*big.Int, rounds)
+	challengesInv := make([]*big.Int, rounds)
+	for k := range challenges {
+		t.AppendPoint("L", proof.L[k][0], proof.L[k][1])
+		t.AppendPoint("R", proof.R[k][0], proof.R[k][1])
+		challenges[k] = t.ChallengeScalar("u")
+		if equal(challenges[k], zero) {
+			return false
+		}
+		challengesInv[k] = InvertScalar(challenges[k])
+	}
+	c := t.ChallengeScalar("c")
+
+	if equal(y, zero) {
+		return false
+	}
+
+	yPowers := scalarPowers(y, nm)
+	yInvPowers := scalarPowers(InvertScalar(y), nm)
+	zPowers := scalarPowers(z, m+3)
+	twoPowers := scalarPowers(two, n)
+
+	// δ(y, z) = (z - z²) * <1, y^nm> - sum(z^(3+j) * <1, 2^n>)
+	sumY := new(big.Int)
+	for _, yi := range yPowers {
+		sumY.Add(sumY, yi)
+	}
+	sumTwo := new(big.Int).Sub(new(big.Int).Lsh(one, uint(n)), one)
+	delta := new(big.Int).Sub(z, zPowers[2])
+	delta.Mul(delta, sumY)
+	for j := 0; j < m; j++ {
+		delta.Sub(delta, new(big.Int).Mul(zPowers[3+j], sumTwo))
+	}
+	modScalar(delta)
+
+	// The scalar s_i is the product of each round's challenge u_k if the i-th generator
+	// was in the high half of that round, or u_k⁻¹ otherwise.
+	s := make([]*big.Int, nm)
+	sInv := make([]*big.Int, nm)
+	for i := range s {
+		s[i] = new(big.Int).Set(one)
+		sInv[i] = new(big.Int).Set(one)
+		for k := 0; k < rounds; k++ {
+			if (i>>(rounds-1-k))&1 == 1 {
+				s[i].Mul(s[i], challenges[k])
+				sInv[i].Mul(sInv[i], challengesInv[k])
+			} else {
+				s[i].Mul(s[i], challengesInv[k])
+				sInv[i].Mul(sInv[i], challenges[k])
+			}
+			modScalar(s[i])
+			modScalar(sInv[i])
+		}
+	}
+
+	ab := mulScalars(proof.InnerProductA, proof.InnerProductB)
+
+	msmPoints := make([][2]*big.Int, 0, 2*nm+8+m+2*rounds)
+	msmScalars := make([]*big.Int, 0, cap(msmPoints))
+	addTerm := func(point [2]*big.Int, scalar *big.Int) {
+		msmPoints = append(msmPoints, point)
+		msmScalars = append(msmScalars, scalar)
+	}
+
+	// Inner-product argument:
+	//
+	//	A + x * S - mu * G - z * <1, G_vec> + <z + y^-i * z^(2+j) * 2^k, H_vec>
+	//	  + t_hat * Q + sum(u_k² * L_k + u_k⁻² * R_k)
+	//	  - <a * s, G_vec> - <b * s⁻¹ * y^-i, H_vec> - a * b * Q == 0
+	addTerm(proof.A, one)
+	addTerm(proof.S, x)
+	for i := 0; i < nm; i++ {
+		gScalar := new(big.Int).Mul(proof.InnerProductA, s[i])
+		gScalar.Add(gScalar, z)
+		gScalar.Neg(gScalar)
+		addTerm(gens[i], gScalar)
+
+		hScalar := new(big.Int).Mul(proof.InnerProductB, sInv[i])
+		hScalar.Neg(hScalar)
+		hScalar.Add(hScalar, mulScalars(zPowers[2+i/n], twoPowers[i%n]))
+		hScalar.Mul(hScalar, yInvPowers[i])
+		hScalar.Add(hScalar, z)
+		addTerm(hens[i], hScalar)
+	}
+	for k := 0; k < rounds; k++ {
+		addTerm(proof.L[k], mulScalars(challenges[k], challenges[k]))
+		addTerm(proof.R[k], mulScalars(challengesInv[k], challengesInv[k]))
+	}
+	uScalar := new(big.Int).Sub(proof.THat, ab)
+	uScalar.Mul(uScalar, w)
+	addTerm(u, uScalar)
+
+	// Range proof relation, weighted by c:
+	//
+	//	c * (t_hat * H + tau_x * G - sum(z^(2+j) * V_j) - δ(y, z) * H - x * T1 - x² * T2) == 0
+	gScalar := mulScalars(c, proof.TauX)
+	gScalar.Sub(gScalar, proof.Mu)
+	addTerm([2]*big.Int{Secp256k1_GeneratorX, Secp256k1_GeneratorY}, gScalar)
+
+	hScalar := new(big.Int).Sub(proof.THat, delta)
+	addTerm([2]*big.Int{PedersenGeneratorHX, PedersenGeneratorHY}, hScalar.Mul(hScalar, c))
+
+	for j, commitment := range commitments {
+		vScalar := mulScalars(c, zPowers[2+j])
+		addTerm([2]*big.Int{commitment.X, commitment.Y}, vScalar.Neg(vScalar))
+	}
+
+	t1Scalar := mulScalars(c, x)
+	addTerm(proof.T1, new(big.Int).Neg(t1Scalar))
+	addTerm(proof.T2, t1Scalar.Neg(t1Scalar.Mul(t1Scalar, x)))
+
+	resultX, resultY := MultiScalarMultiply(msmPoints, msmScalars)
+	return EqualAffine(resultX, resultY, zero, zero)
+}
+
+// MarshalBinary serializes the proof as the compressed points A, S, T1 and T2, followed
+// by the 32-byte scalars tau_x, mu and t_hat, followed by each round's compressed L and
+// R points, followed by the final inner-product scalars a and b.
+func (proof *RangeProof) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, rangeProofSize(len(proof.L)))
+	for _, p := range [][2]*big.Int{proof.A, proof.S, proof.T1, proof.T2} {
+		buf = append(buf, serializeCompressed(p[0], p[1])...)
+	}
+	buf = append(buf, serializeProofScalars(proof.TauX, proof.Mu, proof.THat)...)
+	for k := range proof.L {
+		buf = append(buf, serializeCompressed(proof.L[k][0], proof.L[k][1])...)
+		buf = append(buf, serializeCompressed(proof.R[k][0], proof.R[k][1])...)
+	}
+	buf = append(buf, serializeProofScalars(proof.InnerProductA, proof.InnerProductB)...)
+	return buf, nil
+}
+
+// UnmarshalBinary parses a proof serialized by MarshalBinary.
+func (proof *RangeProof) UnmarshalBinary(data []byte) error {
+	rounds := (len(data) - rangeProofSize(0)) / 66
+	if rounds < 0 || len(data) != rangeProofSize(rounds) {
+		return ErrInvalidProofEncoding
+	}
+
+	parsePoint := func() ([2]*big.Int, error) {
+		x, y, err := parseCompressed(data[:33])
+		data = data[33:]
+		return [2]*big.Int{x, y}, err
+	}
+	parseScalar := func() (*big.Int, error) {
+		n := new(big.Int).SetBytes(data[:32])
+		data = data[32:]
+		if !isValidProofScalar(n) {
+			return nil, ErrInvalidProofEncoding
+		}
+		return n, nil
+	}
+
+	var (
+		parsed RangeProof
+		err    error
+	)
+	for _, p := range []*[2]*big.Int{&parsed.A, &parsed.S, &parsed.T1, &parsed.T2} {
+		if *p, err = parsePoint(); err != nil {
+			return err
+		}
+	}
+	for _, s := range []**big.Int{&parsed.TauX, &parsed.Mu, &parsed.THat} {
+		if *s, err = parseScalar(); err != nil {
+			return err
+		}
+	}
+	parsed.L = make([][2]*big.Int, rounds)
+	parsed.R = make([][2]*big.Int, rounds)
+	for k := 0; k < rounds; k++ {
+		if parsed.L[k], err = parsePoint(); err != nil {
+			return err
+		}
+		if parsed.R[k], err = parsePoint(); err != nil {
+			return err
+		}
+	}
+	for _, s := range []**big.Int{&parsed.InnerProductA, &parsed.InnerProductB} {
+		if *s, err = parseScalar(); err != nil {
+			return err
+		}
+	}
+
+	*proof = parsed
+	return nil
+}
+
+// rangeProofSize returns the serialized size of a range proof whose
+// inner-product argument has the given number of rounds.
+func rangeProofSize(rounds int) int {
+	return 4*33 + 3*32 + rounds*2*33 + 2*32
+}
+
+func newRangeProofTranscript(m int, commitments []*PedersenCommitment) *Transcript {
+	t := NewTranscript("ekliptic/Bulletproofs/RangeProof")
+
+	var sizes [16]byte
+	binary.BigEndian.PutUint64(sizes[:8], uint64(RangeProofBits))
+	binary.BigEndian.PutUint64(sizes[8:], uint64(m))
+	t.AppendMessage("n", sizes[:8])
+	t.AppendMessage("m", sizes[8:])
+
+	for _, c := range commitments {
+		t.AppendPoint("V", c.X, c.Y)
+	}
+	return t
+}
+
+// scalarPowers returns the vector [1, x, x², ..., x^(n-1)] modulo Secp256k1_CurveOrder.
+func scalarPowers(x *big.Int, n int) []*big.Int {
+	powers := make([]*big.Int, n)
+	if n == 0 {
+		return powers
+	}
+	powers[0] = new(big.Int).Set(one)
+	for i := 1; i < n; i++ {
+		powers[i] = mulScalars(powers[i-1], x)
+	}
+	return powers
+}
+
+// innerProduct returns <a, b> modulo Secp256k1_CurveOrder.
+func innerProduct(a, b []*big.Int) *big.Int {
+	sum := new(big.Int)
+	product := new(big.Int)
+	for i := range a {
+		sum.Add(sum, product.Mul(a[i], b[i]))
+	}
+	modScalar(sum)
+	return sum
+}
+
+// mulScalars returns a * b modulo Secp256k1_CurveOrder.
+func mulScalars(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	modScalar(product)
+	return product
+}
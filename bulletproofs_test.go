@@ -0,0 +1,147 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func randomBlindingFactors(t testing.TB, m int) []*big.Int {
+	blindingFactors := make([]*big.Int, m)
+	for i := range blindingFactors {
+		r, err := RandomScalar(rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate blinding factor: %s", err)
+		}
+		blindingFactors[i] = r
+	}
+	return blindingFactors
+}
+
+func TestRangeProof(t *testing.T) {
+	valueSets := [][]uint64{
+		{0},
+		{1 << 63},
+		{0xffffffffffffffff, 42},
+	}
+
+	for _, values := range valueSets {
+		blindingFactors := randomBlindingFactors(t, len(values))
+
+		proof, commitments, err := ProveRange(rand.Reader, values, blindingFactors)
+		if err != nil {
+			t.Errorf("failed to construct range proof for %v: %s", values, err)
+			continue
+		}
+
+		for j, c := range commitments {
+			if !c.Open(new(big.Int).SetUint64(values[j]), blindingFactors[j]) {
+				t.Errorf("range proof commitment %d does not commit to value %d", j, values[j])
+			}
+		}
+
+		if !VerifyRange(proof, commitments) {
+			t.Errorf("failed to verify valid range proof for %v", values)
+			continue
+		}
+
+		serialized, err := proof.MarshalBinary()
+		if err != nil {
+			t.Errorf("failed to serialize range proof: %s", err)
+			continue
+		}
+		if len(serialized) != rangeProofSize(len(proof.L)) {
+			t.Errorf("unexpected range proof size %d", len(serialized))
+		}
+
+		parsed := new(RangeProof)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse range proof: %s", err)
+			continue
+		}
+		if !VerifyRange(parsed, commitments) {
+			t.Errorf("failed to verify deserialized range proof for %v", values)
+		}
+
+		// A commitment to value v + 1 must not verify with the same proof.
+		shifted := commitments[0].Add(CommitPedersen(one, zero))
+		wrongCommitments := append([]*PedersenCommitment{shifted}, commitments[1:]...)
+		if VerifyRange(proof, wrongCommitments) {
+			t.Errorf("verified range proof for the wrong commitment")
+		}
+	}
+}
+
+func TestRangeProof_Tampered(t *testing.T) {
+	proof, commitments, err := ProveRange(rand.Reader, []uint64{1000}, randomBlindingFactors(t, 1))
+	if err != nil {
+		t.Fatalf("failed to construct range proof: %s", err)
+	}
+
+	tamperings := []func(p *RangeProof){
+		func(p *RangeProof) { p.THat = new(big.Int).Add(p.THat, one) },
+		func(p *RangeProof) { p.TauX = new(big.Int).Add(p.TauX, one) },
+		func(p *RangeProof) { p.Mu = new(big.Int).Add(p.Mu, one) },
+		func(p *RangeProof) { p.InnerProductA = new(big.Int).Add(p.InnerProductA, one) },
+		func(p *RangeProof) { p.A[1] = Negate(p.A[1]) },
+		func(p *RangeProof) { p.T1, p.T2 = p.T2, p.T1 },
+		func(p *RangeProof) { p.L[0], p.R[0] = p.R[0], p.L[0] },
+		func(p *RangeProof) { p.L = p.L[1:] },
+	}
+
+	for i, tamper := range tamperings {
+		tampered := *proof
+		tampered.L = append([][2]*big.Int{}, proof.L...)
+		tampered.R = append([][2]*big.Int{}, proof.R...)
+		tamper(&tampered)
+
+		if VerifyRange(&tampered, commitments) {
+			t.Errorf("verified tampered range proof %d", i)
+		}
+	}
+}
+
+func TestProveRange_InvalidAggregationSize(t *testing.T) {
+	if _, _, err := ProveRange(rand.Reader, []uint64{1, 2, 3}, randomBlindingFactors(t, 3)); err != ErrInvalidAggregationSize {
+		t.Errorf("expected ErrInvalidAggregationSize for 3 values; got %v", err)
+	}
+	if _, _, err := ProveRange(rand.Reader, []uint64{1, 2}, randomBlindingFactors(t, 1)); err != ErrInvalidAggregationSize {
+		t.Errorf("expected ErrInvalidAggregationSize for mismatched blinding factors; got %v", err)
+	}
+}
+
+func TestBulletproofGenerators(t *testing.T) {
+	g, h, u := bulletproofGenerators(4)
+
+	expectedX, expectedY := HashToCurve([]byte("G\x00\x00\x00\x03"), []byte(bulletproofGeneratorsDST))
+	if !EqualAffine(g[3][0], g[3][1], expectedX, expectedY) {
+		t.Errorf("unexpected generator G_3")
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range append(append(g, h...), u) {
+		key := string(serializeCompressed(p[0], p[1]))
+		if seen[key] {
+			t.Errorf("found duplicate range proof generator")
+		}
+		seen[key] = true
+	}
+}
+
+func BenchmarkProveRange(b *testing.B) {
+	blindingFactors := randomBlindingFactors(b, 1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveRange(rand.Reader, []uint64{123456789}, blindingFactors)
+	}
+}
+
+func BenchmarkVerifyRange(b *testing.B) {
+	proof, commitments, _ := ProveRange(rand.Reader, []uint64{123456789}, randomBlindingFactors(b, 1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyRange(proof, commitments)
+	}
+}
@@ -0,0 +1,234 @@
+package ekliptic
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/big"
+	"sync/atomic"
+)
+
+const (
+	// MaxCombTeeth is the largest number of teeth supported by CombTable.
+	MaxCombTeeth = 8
+
+	// DefaultCombTeeth is a reasonable default number of teeth for a CombTable.
+	DefaultCombTeeth = 8
+
+	// DefaultCombBlocks is a reasonable default number of blocks for a CombTable.
+	DefaultCombBlocks = 4
+)
+
+// CombTable is a table of precomputed multiples of a fixed point P, for fast fixed-base
+// multiplication with the Lim-Lee comb method.
+//
+// The bits of a 256-bit scalar k are laid out in a grid of blocks * teeth rows, each of
+// spacing bits, where spacing = ceil(256 / (teeth * blocks)). Bit number
+//
+//	i*teeth*spacing + j*spacing + s
+//
+// ...is in block i, tooth j, at position s. For each block i, the table holds 2^teeth
+// entries, where the entry m is the sum of the points 2^(i*teeth*spacing + j*spacing) * P
+// for each bit j which is set in m.
+//
+// Multiplication then proceeds one position at a time, from the most significant position
+// to the least, doubling an accumulator and adding the entry selected by the bits of k at
+// that position in each block. This costs spacing - 1 doublings and blocks * spacing
+// additions, and the table holds blocks * 2^teeth points. More teeth and blocks make
+// multiplication faster, at the cost of a larger table.
+//
+// Lookups read every entry in a block and select the required one with masking, and zero
+// digits of k are added to a dummy point, as in the windowed method. This hides the table
+// access pattern, but multiplication is NOT constant time: the choice between the dummy and
+// the accumulator is a branch, JacobianPoint.Add takes shortcuts for the point at infinity and
+// for equal points, and big.Int arithmetic itself is variable time.
+type CombTable struct {
+	teeth, blocks, spacing uint
+
+	// entries[i][m] holds the affine coordinates of the entry m of block i,
+	// as big-endian 64-bit words: x in words 0 to 3, y in words 4 to 7.
+	entries [][]combEntry
+}
+
+type combEntry [8]uint64
+
+// NewCombTable computes a CombTable for the affine point (x, y) with the given number
+// of teeth and blocks. It panics if teeth is not in the range [1, MaxCombTeeth], or if
+// blocks is not in the range [1, 256 / teeth].
+//
+// The table entries are computed in Jacobian coordinates, and then normalized to affine
+// coordinates all at once with BatchToAffine.
+func NewCombTable(x, y *big.Int, teeth, blocks uint) *CombTable {
+	if teeth < 1 || teeth > MaxCombTeeth {
+		panic("NewCombTable: expected teeth to be in range [1, MaxCombTeeth]")
+	} else if blocks < 1 || teeth*blocks > 256 {
+		panic("NewCombTable: expected blocks to be in range [1, 256 / teeth]")
+	}
+
+	spacing := (256 + teeth*blocks - 1) / (teeth * blocks)
+	size := 1 << teeth
+
+	// The entries of each block other than the point at infinity, flattened in block order.
+	points := make([]JacobianPoint, 0, int(blocks)*(size-1))
+
+	// tooth holds 2^(i*teeth*spacing + j*spacing) * P for the current block i and tooth j.
+	tooth := AffinePoint{x, y}.ToJacobian()
+	for i := uint(0); i < blocks; i++ {
+		block := make([]JacobianPoint, size)
+		block[0] = JacobianInfinity()
+
+		for j := uint(0); j < teeth; j++ {
+			// The entries in [2^j, 2^(j+1)) add this tooth to the entries in [0, 2^j).
+			for m := 0; m < 1<<j; m++ {
+				block[1<<j+m] = block[m].Add(tooth)
+			}
+
+			for s := uint(0); s < spacing; s++ {
+				tooth = tooth.Double()
+			}
+		}
+
+		points = append(points, block[1:]...)
+	}
+
+	affine := BatchToAffine(points)
+
+	table := &CombTable{
+		teeth:   teeth,
+		blocks:  blocks,
+		spacing: spacing,
+		entries: make([][]combEntry, blocks),
+	}
+
+	var buf [64]byte
+	for i := range table.entries {
+		table.entries[i] = make([]combEntry, size)
+		for m := 1; m < size; m++ {
+			p := affine[i*(size-1)+m-1]
+			p.X.FillBytes(buf[:32])
+			p.Y.FillBytes(buf[32:])
+			for w := range table.entries[i][m] {
+				table.entries[i][m][w] = binary.BigEndian.Uint64(buf[w*8:])
+			}
+		}
+	}
+
+	return table
+}
+
+// Teeth returns the number of teeth of the comb.
+func (table *CombTable) Teeth() uint { return table.teeth }
+
+// Blocks returns the number of blocks of the comb.
+func (table *CombTable) Blocks() uint { return table.blocks }
+
+// Spacing returns the spacing of the comb, ceil(256 / (teeth * blocks)).
+func (table *CombTable) Spacing() uint { return table.spacing }
+
+// Multiply multiplies the table's base point by the scalar value k, which must be
+// less than 2²⁵⁶, using the comb method. It returns the resulting Jacobian point.
+func (table *CombTable) Multiply(k *big.Int) JacobianPoint {
+	var kBytes [32]byte
+	k.FillBytes(kBytes[:])
+
+	// bit returns bit number n of k, where bits beyond the 256 bits of k are zero.
+	bit := func(n uint) uint {
+		if n >= 256 {
+			return 0
+		}
+		return uint(kBytes[31-n/8]>>(n%8)) & 1
+	}
+
+	result := JacobianInfinity()
+	dummy := JacobianInfinity()
+
+	// Each entry is loaded into the same point. Add never retains its arguments,
+	// so the coordinates can be reused across lookups without allocating.
+	var buf [64]byte
+	entry := JacobianPoint{new(big.Int), new(big.Int), one}
+
+	for s := int(table.spacing) - 1; s >= 0; s-- {
+		if s < int(table.spacing)-1 {
+			result = result.Double()
+		}
+
+		for i := uint(0); i < table.blocks; i++ {
+			var m uint
+			for j := uint(0); j < table.teeth; j++ {
+				m |= bit(i*table.teeth*table.spacing+j*table.spacing+uint(s)) << j
+			}
+
+			// If m is zero, we add an arbitrary entry to the dummy point instead,
+			// so that zero digits are not skipped.
+			isZero := uint(subtle.ConstantTimeEq(int32(m), 0))
+			combLookup(table.entries[i], m|isZero).load(entry, &buf)
+
+			if isZero == 0 {
+				result = result.Add(entry)
+			} else {
+				dummy = dummy.Add(entry)
+			}
+		}
+	}
+
+	return result
+}
+
+// combLookup returns entries[m], reading every entry and selecting the required one with
+// constant-time masking, so that the memory access pattern does not depend on m.
+func combLookup(entries []combEntry, m uint) combEntry {
+	var selected combEntry
+	for i := range entries {
+		mask := -uint64(subtle.ConstantTimeEq(int32(i), int32(m)))
+		entry := &entries[i]
+		selected[0] |= entry[0] & mask
+		selected[1] |= entry[1] & mask
+		selected[2] |= entry[2] & mask
+		selected[3] |= entry[3] & mask
+		selected[4] |= entry[4] & mask
+		selected[5] |= entry[5] & mask
+		selected[6] |= entry[6] & mask
+		selected[7] |= entry[7] & mask
+	}
+	return selected
+}
+
+// toJacobian converts the entry to a new Jacobian point with z = 1.
+func (entry combEntry) toJacobian() JacobianPoint {
+	var buf [64]byte
+	p := JacobianPoint{new(big.Int), new(big.Int), big.NewInt(1)}
+	entry.load(p, &buf)
+	return p
+}
+
+// load sets the x and y coordinates of p to those of the entry, using buf as scratch space.
+// Once p's coordinates have grown to hold 256 bits, load does not allocate.
+func (entry combEntry) load(p JacobianPoint, buf *[64]byte) {
+	for w, word := range entry {
+		binary.BigEndian.PutUint64(buf[w*8:], word)
+	}
+	p.X.SetBytes(buf[:32])
+	p.Y.SetBytes(buf[32:])
+}
+
+// basePointComb holds the *CombTable used by MultiplyBasePoint, if any.
+var basePointComb atomic.Value
+
+// SetBasePointCombTable configures MultiplyBasePoint to use the given comb table, which
+// must have been computed for the secp256k1 base point, instead of the default windowed
+// table. Passing nil reverts MultiplyBasePoint to the windowed table. It is safe to call
+// SetBasePointCombTable concurrently with MultiplyBasePoint. Comb multiplication is not
+// constant time; see CombTable.
+//
+// SetBasePointCombTable panics if the table was not computed for the base point.
+func SetBasePointCombTable(table *CombTable) {
+	if table != nil && !table.entries[0][1].toJacobian().Equal(Generator().ToJacobian()) {
+		panic("SetBasePointCombTable: expected comb table to be computed for the base point")
+	}
+	basePointComb.Store(table)
+}
+
+// basePointCombTable returns the comb table configured with SetBasePointCombTable, or nil.
+func basePointCombTable() *CombTable {
+	table, _ := basePointComb.Load().(*CombTable)
+	return table
+}
@@ -0,0 +1,168 @@
+package ekliptic
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+var combTestConfigs = [][2]uint{
+	{1, 1},
+	{2, 3},
+	{4, 8},
+	{5, 7},
+	{6, 11},
+	{8, 4},
+	{8, 32},
+}
+
+func TestCombTable(t *testing.T) {
+	for _, config := range combTestConfigs {
+		teeth, blocks := config[0], config[1]
+		tables := make(map[string]*CombTable)
+
+		for i, vector := range test_vectors.AffineMultiplicationVectors {
+			key := fmt.Sprintf("%x", vector.X1)
+			table, ok := tables[key]
+			if !ok {
+				table = NewCombTable(vector.X1, vector.Y1, teeth, blocks)
+				tables[key] = table
+			}
+
+			result := table.Multiply(vector.K).ToAffine()
+			if !equal(result.X, vector.X2) || !equal(result.Y, vector.Y2) {
+				t.Errorf("comb multiplication with %d teeth and %d blocks failed for vector %d", teeth, blocks, i)
+			}
+		}
+	}
+}
+
+func TestCombTable_EdgeScalars(t *testing.T) {
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(Secp256k1_CurveOrder, one),
+		new(big.Int).Set(Secp256k1_CurveOrder),
+		new(big.Int).Sub(new(big.Int).Lsh(one, 256), one),
+	}
+
+	for _, config := range combTestConfigs {
+		teeth, blocks := config[0], config[1]
+		table := NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, teeth, blocks)
+
+		if table.Teeth() != teeth || table.Blocks() != blocks || table.Teeth()*table.Blocks()*table.Spacing() < 256 {
+			t.Errorf("unexpected comb dimensions for %d teeth and %d blocks", teeth, blocks)
+		}
+
+		for _, k := range scalars {
+			expectedX, expectedY := MultiplyAffine(Secp256k1_GeneratorX, Secp256k1_GeneratorY, k, nil)
+			result := table.Multiply(k).ToAffine()
+			if !equal(result.X, expectedX) || !equal(result.Y, expectedY) {
+				t.Errorf("comb multiplication with %d teeth and %d blocks failed for k = %x", teeth, blocks, k)
+			}
+		}
+	}
+}
+
+func TestNewCombTable_Panic(t *testing.T) {
+	for _, config := range [][2]uint{{0, 1}, {9, 1}, {1, 0}, {4, 65}, {8, 33}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %d teeth and %d blocks", config[0], config[1])
+				}
+			}()
+			NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, config[0], config[1])
+		}()
+	}
+}
+
+func TestCombLookup(t *testing.T) {
+	table := NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, 4, 2)
+
+	for i, entries := range table.entries {
+		for m := range entries {
+			if combLookup(entries, uint(m)) != entries[m] {
+				t.Errorf("lookup of entry %d in block %d returned the wrong entry", m, i)
+			}
+		}
+	}
+}
+
+func TestSetBasePointCombTable(t *testing.T) {
+	defer SetBasePointCombTable(nil)
+
+	SetBasePointCombTable(NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, DefaultCombTeeth, DefaultCombBlocks))
+	if basePointCombTable() == nil {
+		t.Fatalf("expected comb table to be configured")
+	}
+	TestMultiplyBasePoint(t)
+
+	SetBasePointCombTable(nil)
+	if basePointCombTable() != nil {
+		t.Fatalf("expected comb table to be removed")
+	}
+	TestMultiplyBasePoint(t)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic when setting comb table for another point")
+			}
+		}()
+		x, y := MultiplyBasePoint(two)
+		SetBasePointCombTable(NewCombTable(x, y, 2, 2))
+	}()
+	if basePointCombTable() != nil {
+		t.Errorf("comb table for another point should not be configured")
+	}
+}
+
+func BenchmarkCombTable_Multiply(b *testing.B) {
+	vector := test_vectors.AffineMultiplicationVectors[0]
+
+	b.Run("windowed-4", func(b *testing.B) {
+		table := NewPrecomputedTable(vector.X1, vector.Y1)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			MultiplyJacobi(vector.X1, vector.Y1, one, vector.K, table)
+		}
+	})
+
+	for _, config := range combTestConfigs[2:] {
+		teeth, blocks := config[0], config[1]
+		table := NewCombTable(vector.X1, vector.Y1, teeth, blocks)
+
+		b.Run(fmt.Sprintf("teeth=%d,blocks=%d", teeth, blocks), func(b *testing.B) {
+			b.ReportMetric(float64(blocks<<teeth), "table-points")
+			for i := 0; i < b.N; i++ {
+				table.Multiply(vector.K)
+			}
+		})
+	}
+}
+
+func BenchmarkNewCombTable(b *testing.B) {
+	for _, config := range combTestConfigs[2:] {
+		teeth, blocks := config[0], config[1]
+		b.Run(fmt.Sprintf("teeth=%d,blocks=%d", teeth, blocks), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, teeth, blocks)
+			}
+		})
+	}
+}
+
+func BenchmarkMultiplyBasePoint_Comb(b *testing.B) {
+	SetBasePointCombTable(NewCombTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY, DefaultCombTeeth, DefaultCombBlocks))
+	defer SetBasePointCombTable(nil)
+
+	vector := test_vectors.AffineMultiplicationVectors[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MultiplyBasePoint(vector.K)
+	}
+}
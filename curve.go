@@ -29,10 +29,8 @@ func (c *Curve) Params() *elliptic.CurveParams {
 // IsOnCurve reports whether the given (x,y) lies on the curve. Satisfies elliptic.Curve.
 // Note: The elliptic.Curve interface requires that infinity is NOT on the curve.
 func (_ *Curve) IsOnCurve(x, y *big.Int) bool {
-	if equal(x, zero) && equal(y, zero) {
-		return false
-	}
-	return IsOnCurveAffine(x, y)
+	p := AffinePoint{x, y}
+	return !p.IsInfinity() && p.IsOnCurve()
 }
 
 // Add returns the sum of (x1,y1) and (x2,y2). Satisfies elliptic.Curve.
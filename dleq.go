@@ -0,0 +1,282 @@
+package ekliptic
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// ErrInvalidProofEncoding is returned when parsing a serialized proof which is malformed.
+var ErrInvalidProofEncoding = errors.New("ekliptic: invalid proof encoding")
+
+// DLEQProof is a non-interactive Chaum-Pedersen proof that two points A and B share the same
+// discrete logarithm x with respect to two bases G and H respectively, without revealing x:
+//
+//	A = x * G
+//	B = x * H
+//
+// The proof is made non-interactive with the Fiat-Shamir heuristic, by deriving
+// the challenge C from a Transcript of all public inputs and the prover's commitments.
+type DLEQProof struct {
+	C, S *big.Int
+}
+
+// ProveDLEQ constructs a DLEQProof that log_G(A) == log_H(B), where A = x * G and B = x * H.
+// G is given by (gX, gY), and H is given by (hX, hY). The secret x is expected to be in
+// the range [1, Secp256k1_CurveOrder). A nonce is generated using the given source of
+// randomness, which should be cryptographically secure.
+//
+// The proof is constructed as follows:
+//
+//	r = random scalar
+//	R1 = r * G
+//	R2 = r * H
+//	c = hash(G, H, A, B, R1, R2)
+//	s = r - c * x mod N
+func ProveDLEQ(
+	random io.Reader,
+	x *big.Int,
+	gX, gY *big.Int,
+	hX, hY *big.Int,
+) (*DLEQProof, error) {
+	if !IsValidScalar(x) {
+		panic("ProveDLEQ: expected secret x to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	r, err := RandomScalar(random)
+	if err != nil {
+		return nil, err
+	}
+
+	aX, aY := MultiplyAffine(gX, gY, x, nil)
+	bX, bY := MultiplyAffine(hX, hY, x, nil)
+	r1X, r1Y := MultiplyAffine(gX, gY, r, nil)
+	r2X, r2Y := MultiplyAffine(hX, hY, r, nil)
+
+	c := dleqChallenge(
+		gX, gY, hX, hY,
+		aX, aY, bX, bY,
+		r1X, r1Y, r2X, r2Y,
+	)
+
+	// s = r - c * x mod N
+	s := new(big.Int).Mul(c, x)
+	s.Sub(r, s)
+	modScalar(s)
+
+	return &DLEQProof{C: c, S: s}, nil
+}
+
+// VerifyDLEQ returns true if the given DLEQProof proves that A and B share
+// the same discrete logarithm with respect to bases G and H respectively.
+//
+// The verifier reconstructs the prover's commitments and checks the challenge:
+//
+//	R1 = s * G + c * A
+//	R2 = s * H + c * B
+//	c == hash(G, H, A, B, R1, R2)
+func VerifyDLEQ(
+	proof *DLEQProof,
+	gX, gY *big.Int,
+	hX, hY *big.Int,
+	aX, aY *big.Int,
+	bX, bY *big.Int,
+) bool {
+	if proof == nil || !isValidProofScalar(proof.C) || !isValidProofScalar(proof.S) {
+		return false
+	}
+
+	for _, point := range [][2]*big.Int{{gX, gY}, {hX, hY}, {aX, aY}, {bX, bY}} {
+		if !isValidPublicPoint(point[0], point[1]) {
+			return false
+		}
+	}
+
+	r1X, r1Y := linearCombination(gX, gY, proof.S, aX, aY, proof.C)
+	r2X, r2Y := linearCombination(hX, hY, proof.S, bX, bY, proof.C)
+
+	c := dleqChallenge(
+		gX, gY, hX, hY,
+		aX, aY, bX, bY,
+		r1X, r1Y, r2X, r2Y,
+	)
+
+	return equal(c, proof.C)
+}
+
+// MarshalBinary serializes the proof as the 32-byte big-endian challenge c,
+// followed by the 32-byte big-endian response s.
+func (proof *DLEQProof) MarshalBinary() ([]byte, error) {
+	return serializeProofScalars(proof.C, proof.S), nil
+}
+
+// UnmarshalBinary parses a 64-byte proof serialized by MarshalBinary.
+func (proof *DLEQProof) UnmarshalBinary(data []byte) (err error) {
+	proof.C, proof.S, err = parseProofScalars(data)
+	return
+}
+
+// ProveBatchDLEQ constructs a single DLEQProof that every point B_i shares the same discrete
+// logarithm x with respect to H_i, as A = x * G does with respect to G:
+//
+//	A = x * G
+//	B_i = x * H_i
+//
+// G is given by (gX, gY), and each H_i is an affine point in hs. The secret x is expected to be
+// in the range [1, Secp256k1_CurveOrder). A nonce is generated using the given source of
+// randomness, which should be cryptographically secure.
+//
+// The points are compressed into one pair of composite points using weights d_i derived
+// from a Transcript of all public inputs, and a regular DLEQProof is made for the composites:
+//
+//	M = d_1 * H_1 + d_2 * H_2 + ... + d_n * H_n
+//	Z = x * M
+//
+// This is the same technique used by RFC 9497 to batch VOPRF evaluations.
+func ProveBatchDLEQ(
+	random io.Reader,
+	x *big.Int,
+	gX, gY *big.Int,
+	hs [][2]*big.Int,
+) (*DLEQProof, error) {
+	if !IsValidScalar(x) {
+		panic("ProveBatchDLEQ: expected secret x to be in range [1, Secp256k1_CurveOrder)")
+	} else if len(hs) == 0 {
+		panic("ProveBatchDLEQ: expected at least one point H_i")
+	}
+
+	aX, aY := MultiplyAffine(gX, gY, x, nil)
+	xs := make([]*big.Int, len(hs))
+	for i := range xs {
+		xs[i] = x
+	}
+	bs := batchMultiplyAffine(hs, xs)
+
+	return proveBatchDLEQ(random, x, gX, gY, aX, aY, hs, bs)
+}
+
+// proveBatchDLEQ is ProveBatchDLEQ for callers which have already computed A = x * G
+// and each B_i = x * H_i, so that the products need not be computed twice.
+func proveBatchDLEQ(
+	random io.Reader,
+	x *big.Int,
+	gX, gY *big.Int,
+	aX, aY *big.Int,
+	hs, bs [][2]*big.Int,
+) (*DLEQProof, error) {
+	weights := batchDLEQWeights(gX, gY, aX, aY, hs, bs)
+	mX, mY := MultiScalarMultiply(hs, weights)
+	if !isValidPublicPoint(mX, mY) {
+		// Occurs with negligible probability, unless the inputs are degenerate.
+		panic("ProveBatchDLEQ: composite point is infinity")
+	}
+
+	return ProveDLEQ(random, x, gX, gY, mX, mY)
+}
+
+// VerifyBatchDLEQ returns true if the given DLEQProof, constructed by ProveBatchDLEQ,
+// proves that log_G(A) == log_H_i(B_i) for every i. hs and bs must have the same length.
+func VerifyBatchDLEQ(
+	proof *DLEQProof,
+	gX, gY *big.Int,
+	aX, aY *big.Int,
+	hs, bs [][2]*big.Int,
+) bool {
+	if len(hs) == 0 || len(hs) != len(bs) {
+		return false
+	}
+	if !isValidPublicPoint(gX, gY) || !isValidPublicPoint(aX, aY) {
+		return false
+	}
+	for i := range hs {
+		if !isValidPublicPoint(hs[i][0], hs[i][1]) || !isValidPublicPoint(bs[i][0], bs[i][1]) {
+			return false
+		}
+	}
+
+	weights := batchDLEQWeights(gX, gY, aX, aY, hs, bs)
+	mX, mY := MultiScalarMultiply(hs, weights)
+	zX, zY := MultiScalarMultiply(bs, weights)
+
+	return VerifyDLEQ(proof, gX, gY, mX, mY, aX, aY, zX, zY)
+}
+
+// batchDLEQWeights derives one weight per point pair from a Transcript of all public
+// inputs to a batched DLEQ proof.
+func batchDLEQWeights(gX, gY, aX, aY *big.Int, hs, bs [][2]*big.Int) []*big.Int {
+	t := NewTranscript("ekliptic/BatchDLEQ")
+	t.AppendPoint("G", gX, gY)
+	t.AppendPoint("A", aX, aY)
+	for i := range hs {
+		t.AppendPoint("H", hs[i][0], hs[i][1])
+		t.AppendPoint("B", bs[i][0], bs[i][1])
+	}
+
+	weights := make([]*big.Int, len(hs))
+	for i := range weights {
+		weights[i] = t.ChallengeScalar("d")
+	}
+	return weights
+}
+
+func dleqChallenge(
+	gX, gY, hX, hY *big.Int,
+	aX, aY, bX, bY *big.Int,
+	r1X, r1Y, r2X, r2Y *big.Int,
+) *big.Int {
+	t := NewTranscript("ekliptic/DLEQ")
+	t.AppendPoint("G", gX, gY)
+	t.AppendPoint("H", hX, hY)
+	t.AppendPoint("A", aX, aY)
+	t.AppendPoint("B", bX, bY)
+	t.AppendPoint("R1", r1X, r1Y)
+	t.AppendPoint("R2", r2X, r2Y)
+	return t.ChallengeScalar("c")
+}
+
+// linearCombination computes a * P + b * Q, returning the resulting affine point.
+func linearCombination(
+	pX, pY *big.Int, a *big.Int,
+	qX, qY *big.Int, b *big.Int,
+) (x, y *big.Int) {
+	ap := AffinePoint{pX, pY}.ToJacobian().Multiply(a, nil)
+	bq := AffinePoint{qX, qY}.ToJacobian().Multiply(b, nil)
+	sum := ap.Add(bq).ToAffine()
+	return sum.X, sum.Y
+}
+
+// isValidPublicPoint returns true if (x, y) is a non-infinity point on the secp256k1 curve.
+func isValidPublicPoint(x, y *big.Int) bool {
+	if x == nil || y == nil || EqualAffine(x, y, zero, zero) {
+		return false
+	}
+	if x.Sign() < 0 || x.Cmp(Secp256k1_P) >= 0 || y.Sign() < 0 || y.Cmp(Secp256k1_P) >= 0 {
+		return false
+	}
+	return IsOnCurveAffine(x, y)
+}
+
+// isValidProofScalar returns true if n is in the range [0, Secp256k1_CurveOrder).
+func isValidProofScalar(n *big.Int) bool {
+	return n != nil && n.Sign() >= 0 && n.Cmp(Secp256k1_CurveOrder) == -1
+}
+
+func serializeProofScalars(scalars ...*big.Int) []byte {
+	buf := make([]byte, 32*len(scalars))
+	for i, n := range scalars {
+		n.FillBytes(buf[i*32 : (i+1)*32])
+	}
+	return buf
+}
+
+func parseProofScalars(data []byte) (c, s *big.Int, err error) {
+	if len(data) != 64 {
+		return nil, nil, ErrInvalidProofEncoding
+	}
+	c = new(big.Int).SetBytes(data[:32])
+	s = new(big.Int).SetBytes(data[32:])
+	if !isValidProofScalar(c) || !isValidProofScalar(s) {
+		return nil, nil, ErrInvalidProofEncoding
+	}
+	return c, s, nil
+}
@@ -0,0 +1,130 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestDLEQProof(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		h, _ := RandomScalar(rand.Reader)
+		hX, hY := MultiplyBasePoint(h)
+
+		x, _ := RandomScalar(rand.Reader)
+		aX, aY := MultiplyBasePoint(x)
+		bX, bY := MultiplyAffine(hX, hY, x, nil)
+
+		proof, err := ProveDLEQ(rand.Reader, x, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY)
+		if err != nil {
+			t.Errorf("failed to construct DLEQ proof: %s", err)
+			return
+		}
+
+		if !VerifyDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY, aX, aY, bX, bY) {
+			t.Errorf("failed to verify valid DLEQ proof %d", i)
+			return
+		}
+
+		serialized, _ := proof.MarshalBinary()
+		parsed := new(DLEQProof)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse serialized DLEQ proof: %s", err)
+			return
+		}
+		if !VerifyDLEQ(parsed, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY, aX, aY, bX, bY) {
+			t.Errorf("failed to verify deserialized DLEQ proof %d", i)
+			return
+		}
+
+		// B' = (x+1) * H does not share a discrete log with A
+		badX, badY := AddAffine(bX, bY, hX, hY)
+		if VerifyDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY, aX, aY, badX, badY) {
+			t.Errorf("verified DLEQ proof %d for points with unequal discrete logs", i)
+			return
+		}
+
+		tampered := &DLEQProof{C: proof.C, S: new(big.Int).Add(proof.S, one)}
+		if VerifyDLEQ(tampered, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY, aX, aY, bX, bY) {
+			t.Errorf("verified tampered DLEQ proof %d", i)
+			return
+		}
+	}
+}
+
+func TestDLEQProof_UnmarshalBinary(t *testing.T) {
+	overflow := make([]byte, 64)
+	Secp256k1_CurveOrder.FillBytes(overflow[:32])
+
+	for _, data := range [][]byte{
+		nil,
+		make([]byte, 63),
+		make([]byte, 65),
+		overflow,
+	} {
+		if err := new(DLEQProof).UnmarshalBinary(data); err != ErrInvalidProofEncoding {
+			t.Errorf("expected ErrInvalidProofEncoding when parsing %x; got %v", data, err)
+		}
+	}
+}
+
+func TestBatchDLEQProof(t *testing.T) {
+	x, _ := RandomScalar(rand.Reader)
+	aX, aY := MultiplyBasePoint(x)
+
+	for _, size := range []int{1, 2, 5} {
+		hs := make([][2]*big.Int, size)
+		bs := make([][2]*big.Int, size)
+		for i := range hs {
+			h, _ := RandomScalar(rand.Reader)
+			hs[i][0], hs[i][1] = MultiplyBasePoint(h)
+			bs[i][0], bs[i][1] = MultiplyAffine(hs[i][0], hs[i][1], x, nil)
+		}
+
+		proof, err := ProveBatchDLEQ(rand.Reader, x, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hs)
+		if err != nil {
+			t.Errorf("failed to construct batch DLEQ proof: %s", err)
+			continue
+		}
+
+		if !VerifyBatchDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, aX, aY, hs, bs) {
+			t.Errorf("failed to verify batch DLEQ proof of size %d", size)
+		}
+
+		if VerifyBatchDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, aX, aY, hs[1:], bs[1:]) {
+			t.Errorf("verified batch DLEQ proof with a missing point")
+		}
+
+		// Replace one B_i with a point using a different discrete log.
+		forged := append([][2]*big.Int{}, bs...)
+		forged[size-1][0], forged[size-1][1] = MultiplyAffine(hs[size-1][0], hs[size-1][1], two, nil)
+		if VerifyBatchDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, aX, aY, hs, forged) {
+			t.Errorf("verified batch DLEQ proof with a forged point")
+		}
+	}
+}
+
+func BenchmarkProveDLEQ(b *testing.B) {
+	h, _ := RandomScalar(rand.Reader)
+	hX, hY := MultiplyBasePoint(h)
+	x, _ := RandomScalar(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveDLEQ(rand.Reader, x, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY)
+	}
+}
+
+func BenchmarkVerifyDLEQ(b *testing.B) {
+	h, _ := RandomScalar(rand.Reader)
+	hX, hY := MultiplyBasePoint(h)
+	x, _ := RandomScalar(rand.Reader)
+	aX, aY := MultiplyBasePoint(x)
+	bX, bY := MultiplyAffine(hX, hY, x, nil)
+	proof, _ := ProveDLEQ(rand.Reader, x, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyDLEQ(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, hX, hY, aX, aY, bX, bY)
+	}
+}
@@ -2,7 +2,14 @@ package ekliptic
 
 import "math/big"
 
-// DoubleJacobi doubles a Jacobian coordinate point on the secp256k1 curve, using the "dbl-2009-l" doubling formulas.
+// DoubleJacobi doubles a Jacobian coordinate point on the secp256k1 curve. DoubleJacobi
+// is a wrapper around JacobianPoint.Double. Doubling any point with z = 0 returns (0, 0, 0).
+func DoubleJacobi(x1, y1, z1 *big.Int) (x3, y3, z3 *big.Int) {
+	p3 := JacobianPoint{x1, y1, z1}.Double()
+	return p3.X, p3.Y, p3.Z
+}
+
+// Double doubles a Jacobian point on the secp256k1 curve, using the "dbl-2009-l" doubling formulas.
 //
 // http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
 //
@@ -15,7 +22,16 @@ import "math/big"
 //	X3 = F-2*D
 //	Y3 = E*(D-X3)-8*C
 //	Z3 = 2*Y1*Z1
-func DoubleJacobi(x1, y1, z1 *big.Int) (x3, y3, z3 *big.Int) {
+func (p JacobianPoint) Double() JacobianPoint {
+	// 2 * 0 = 0
+	//
+	// The formulas would also yield z3 = 0 here, but with meaningless x3 and y3.
+	if p.IsInfinity() {
+		return JacobianInfinity()
+	}
+
+	x1, y1, z1 := p.X, p.Y, p.Z
+
 	// a = x1²
 	a := new(big.Int).Mul(x1, x1)
 
@@ -50,13 +66,13 @@ func DoubleJacobi(x1, y1, z1 *big.Int) (x3, y3, z3 *big.Int) {
 	f := new(big.Int).Mul(e, e)
 
 	// x3 = f - 2 * d
-	x3 = f.Sub(f, d)
+	x3 := f.Sub(f, d)
 	x3.Sub(x3, d)
 	modCoordinate(x3)
 	f = nil
 
 	// y3 = e * (d - x3) - 8 * c
-	y3 = d.Sub(d, x3)
+	y3 := d.Sub(d, x3)
 	y3.Mul(y3, e)
 	y3.Sub(y3, c.Mul(c, eight))
 	modCoordinate(y3)
@@ -64,17 +80,26 @@ func DoubleJacobi(x1, y1, z1 *big.Int) (x3, y3, z3 *big.Int) {
 	d = nil
 
 	// z3 = 2 * y1 * z1
-	z3 = e.Mul(y1, z1)
+	z3 := e.Mul(y1, z1)
 	z3.Mul(z3, two)
 	modCoordinate(z3)
 	e = nil
 
-	return
+	return JacobianPoint{x3, y3, z3}
 }
 
-// m = (3*x1²+a) / (2*y1)
-// x3 = m² - x1 - x2
-// y3 = m(x1-x3) - y1
+// DoubleAffine doubles an affine point on the secp256k1 curve. DoubleAffine
+// is a wrapper around AffinePoint.Double.
 func DoubleAffine(x1, y1 *big.Int) (x3, y3 *big.Int) {
-	return AddAffine(x1, y1, x1, y1)
+	p3 := AffinePoint{x1, y1}.Double()
+	return p3.X, p3.Y
+}
+
+// Double doubles an affine point on the secp256k1 curve:
+//
+//	m = (3*x1²+a) / (2*y1)
+//	x3 = m² - x1 - x2
+//	y3 = m(x1-x3) - y1
+func (p AffinePoint) Double() AffinePoint {
+	return p.Add(p)
 }
@@ -25,6 +25,28 @@ Wanted:
 	}
 }
 
+func TestDoubleJacobi_Infinity(t *testing.T) {
+	for i, vector := range test_vectors.JacobiInfinityDoublingVectors {
+		x3, y3, z3 := DoubleJacobi(vector.X1, vector.Y1, vector.Z1)
+
+		if !equal(x3, vector.X3) || !equal(y3, vector.Y3) || !equal(z3, vector.Z3) {
+			t.Errorf(`jacobi point doubling of infinity failed for vector %d - Got:
+	x3: %.64x
+	y3: %.64x
+	z3: %.64x
+Wanted:
+	x3: %.64x
+	y3: %.64x
+	z3: %.64x
+`, i, x3, y3, z3, vector.X3, vector.Y3, vector.Z3)
+		}
+	}
+
+	if x, y := DoubleAffine(zero, zero); !EqualAffine(x, y, zero, zero) {
+		t.Errorf("expected doubling affine infinity to yield (0, 0); got (%x, %x)", x, y)
+	}
+}
+
 func TestDoubleJacobi_MemSafety(t *testing.T) {
 	for i, vector := range test_vectors.JacobiDoublingVectors {
 		originalX1 := new(big.Int).Set(vector.X1)
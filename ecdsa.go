@@ -1,6 +1,13 @@
 package ekliptic
 
-import "math/big"
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrPublicKeyRecovery is returned by RecoverPublicKeyECDSA if no public key
+// can be recovered from the given signature and recovery ID.
+var ErrPublicKeyRecovery = errors.New("ekliptic: failed to recover public key from signature")
 
 // SignECDSA signs a message hash z using the private key d, and a random (or deterministically
 // derived) nonce k. It returns the resulting signature parts r and s.
@@ -14,8 +21,19 @@ func SignECDSA(d, k, z *big.Int) (r, s *big.Int) {
 		panic("SignECDSA: expected private key d to be in range [1, Secp256k1_CurveOrder)")
 	}
 
-	// (x, _) = k * G
-	x, _ := MultiplyBasePoint(k)
+	r, s, _ = signECDSA(d, k, z)
+	return
+}
+
+func signECDSA(d, k, z *big.Int) (r, s *big.Int, recoveryID byte) {
+	// (x, y) = k * G
+	x, y := MultiplyBasePoint(k)
+
+	recoveryID = byte(y.Bit(0))
+	if x.Cmp(Secp256k1_CurveOrder) >= 0 {
+		recoveryID |= 2
+	}
+	y = nil
 
 	// r = x mod N
 	r = new(big.Int).Mod(x, Secp256k1_CurveOrder)
@@ -34,8 +52,11 @@ func SignECDSA(d, k, z *big.Int) (r, s *big.Int) {
 	//
 	//  if s > (N/2):
 	//    s = N - s
+	//
+	// Negating s is equivalent to negating R, which flips the parity of its y-coordinate.
 	if s.Cmp(Secp256k1_CurveOrderHalf) == 1 {
 		s.Sub(Secp256k1_CurveOrder, s)
+		recoveryID ^= 1
 	}
 	return
 }
@@ -73,3 +94,71 @@ func VerifyECDSA(
 
 	return equal(r, px)
 }
+
+// SignECDSARecoverable is like SignECDSA, but also returns the recovery ID of the signature,
+// which lets RecoverPublicKeyECDSA recover the signer's public key from the signature and
+// message hash alone. The recovery ID is in the range [0, 3]:
+//
+//	bit 0: parity of the y-coordinate of the nonce point R = k * G
+//	bit 1: set if the x-coordinate of R was greater than or equal to Secp256k1_CurveOrder
+//
+// Like SignECDSA, SignECDSARecoverable always produces canonical signatures, flipping the
+// parity bit of the recovery ID when negating s. It panics if k or d is not within the range
+// [1, Secp256k1_CurveOrder).
+func SignECDSARecoverable(d, k, z *big.Int) (r, s *big.Int, recoveryID byte) {
+	if !IsValidScalar(k) {
+		panic("SignECDSARecoverable: expected nonce k to be in range [1, Secp256k1_CurveOrder)")
+	} else if !IsValidScalar(d) {
+		panic("SignECDSARecoverable: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	return signECDSA(d, k, z)
+}
+
+// RecoverPublicKeyECDSA recovers the public key which produced the signature (r, s) on message
+// hash z, given the signature's recovery ID in the range [0, 3]:
+//
+//	R = the point with x-coordinate r + (recoveryID >> 1) * N, and y parity recoveryID & 1
+//	Q = r⁻¹ * (s * R - z * G)
+//
+// It returns ErrPublicKeyRecovery if r or s is not in the range [1, Secp256k1_CurveOrder),
+// or if no valid public key can be recovered.
+func RecoverPublicKeyECDSA(z, r, s *big.Int, recoveryID byte) (pubX, pubY *big.Int, err error) {
+	if recoveryID > 3 || !IsValidScalar(r) || !IsValidScalar(s) {
+		return nil, nil, ErrPublicKeyRecovery
+	}
+
+	rX := new(big.Int).Set(r)
+	if recoveryID&2 != 0 {
+		rX.Add(rX, Secp256k1_CurveOrder)
+		if rX.Cmp(Secp256k1_P) >= 0 {
+			return nil, nil, ErrPublicKeyRecovery
+		}
+	}
+
+	evenY, oddY := Weierstrass(rX)
+	if evenY == nil {
+		return nil, nil, ErrPublicKeyRecovery
+	}
+	rY := evenY
+	if recoveryID&1 != 0 {
+		rY = oddY
+	}
+
+	rInverse := InvertScalar(r)
+
+	// u1 = -z * r⁻¹ mod N
+	u1 := new(big.Int).Mul(z, rInverse)
+	u1.Neg(u1)
+	modScalar(u1)
+
+	// u2 = s * r⁻¹ mod N
+	u2 := rInverse.Mul(s, rInverse)
+	modScalar(u2)
+
+	pubX, pubY = linearCombination(Secp256k1_GeneratorX, Secp256k1_GeneratorY, u1, rX, rY, u2)
+	if !isValidPublicPoint(pubX, pubY) {
+		return nil, nil, ErrPublicKeyRecovery
+	}
+	return pubX, pubY, nil
+}
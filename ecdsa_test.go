@@ -30,6 +30,44 @@ Wanted:
 	}
 }
 
+func TestRecoverPublicKeyECDSA(t *testing.T) {
+	// Recovery is slow, so only a sample of the vectors is checked.
+	for i, vector := range test_vectors.ECDSAVectors[:40] {
+		r, s, recoveryID := SignECDSARecoverable(vector.PrivateKey, vector.Nonce, vector.Hash)
+		if !equal(r, vector.R) || !equal(s, vector.S) {
+			t.Errorf("recoverable signature differs from SignECDSA for vector %d", i)
+			continue
+		}
+
+		pubX, pubY, err := RecoverPublicKeyECDSA(vector.Hash, r, s, recoveryID)
+		if err != nil {
+			t.Errorf("failed to recover public key for vector %d: %s", i, err)
+			continue
+		}
+
+		expectedX, expectedY := MultiplyBasePoint(vector.PrivateKey)
+		if !EqualAffine(pubX, pubY, expectedX, expectedY) {
+			t.Errorf("recovered wrong public key for vector %d", i)
+		}
+
+		// The other parity recovers a different key.
+		otherX, otherY, err := RecoverPublicKeyECDSA(vector.Hash, r, s, recoveryID^1)
+		if err == nil && EqualAffine(otherX, otherY, expectedX, expectedY) {
+			t.Errorf("recovered the same public key with the wrong recovery ID for vector %d", i)
+		}
+	}
+
+	vector := test_vectors.ECDSAVectors[0]
+	for _, recoveryID := range []byte{2, 3, 4} {
+		if _, _, err := RecoverPublicKeyECDSA(vector.Hash, vector.R, vector.S, recoveryID); err != ErrPublicKeyRecovery {
+			t.Errorf("expected ErrPublicKeyRecovery for recovery ID %d; got %v", recoveryID, err)
+		}
+	}
+	if _, _, err := RecoverPublicKeyECDSA(vector.Hash, zero, vector.S, 0); err != ErrPublicKeyRecovery {
+		t.Errorf("expected ErrPublicKeyRecovery for r = 0; got %v", err)
+	}
+}
+
 func BenchmarkSignECDSA(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		vector := test_vectors.ECDSAVectors[i%len(test_vectors.ECDSAVectors)]
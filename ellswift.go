@@ -0,0 +1,315 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// EllSwiftSize is the length of an ElligatorSwift-encoded public key.
+const EllSwiftSize = 64
+
+// ErrInvalidEllSwiftEncoding is returned when an ElligatorSwift encoding has the wrong length.
+var ErrInvalidEllSwiftEncoding = errors.New("ekliptic: ElligatorSwift encoding must be 64 bytes")
+
+var (
+	// ellswiftSqrtMinus3 is the square root of -3 modulo P, as computed by sqrtCoordinate.
+	ellswiftSqrtMinus3 = sqrtCoordinate(new(big.Int).Sub(Secp256k1_P, three))
+
+	// fieldOrderMinusOne is P - 1.
+	fieldOrderMinusOne = new(big.Int).Sub(Secp256k1_P, one)
+)
+
+// EllSwiftEncode encodes the x-coordinate of a point on the secp256k1 curve as a
+// 64-byte ElligatorSwift encoding (u, t), as specified by BIP-324. The encoding
+// is indistinguishable from 64 uniformly random bytes. Many encodings exist for
+// every x-coordinate; one is chosen at random using the given source of randomness,
+// which should be cryptographically secure.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki#elligatorswift-encoding-of-curve-x-coordinates
+//
+// EllSwiftEncode panics if x is not a valid x-coordinate on the secp256k1 curve.
+func EllSwiftEncode(random io.Reader, x *big.Int) ([]byte, error) {
+	if x.Sign() <= 0 || x.Cmp(Secp256k1_P) >= 0 || !isValidXCoordinate(x) {
+		panic("EllSwiftEncode: expected x to be a valid x-coordinate on the secp256k1 curve")
+	}
+
+	var caseByte [1]byte
+	for {
+		// u is uniformly distributed in the range [1, P)
+		u, err := rand.Int(random, fieldOrderMinusOne)
+		if err != nil {
+			return nil, err
+		}
+		u.Add(u, one)
+
+		if _, err := io.ReadFull(random, caseByte[:]); err != nil {
+			return nil, err
+		}
+
+		if t := xSwiftECInverse(x, u, caseByte[0]&7); t != nil {
+			encoded := make([]byte, EllSwiftSize)
+			u.FillBytes(encoded[:32])
+			t.FillBytes(encoded[32:])
+			return encoded, nil
+		}
+	}
+}
+
+// EllSwiftDecode decodes the 64-byte ElligatorSwift encoding of a public key, returning
+// the x-coordinate of the encoded point. Every 64-byte string decodes to a valid
+// x-coordinate. The point's y-coordinate can be recovered using Weierstrass. By
+// BIP-324 convention, the point with an even y-coordinate is used.
+func EllSwiftDecode(encoded []byte) (x *big.Int, err error) {
+	if len(encoded) != EllSwiftSize {
+		return nil, ErrInvalidEllSwiftEncoding
+	}
+
+	u := new(big.Int).SetBytes(encoded[:32])
+	modCoordinate(u)
+	t := new(big.Int).SetBytes(encoded[32:])
+	modCoordinate(t)
+
+	return xSwiftEC(u, t), nil
+}
+
+// EllSwiftCreate generates a new private key along with the ElligatorSwift
+// encoding of its public key, using the given source of randomness.
+func EllSwiftCreate(random io.Reader) (privateKey *big.Int, encoded []byte, err error) {
+	privateKey, err = RandomScalar(random)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x, _ := MultiplyBasePoint(privateKey)
+	encoded, err = EllSwiftEncode(random, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, encoded, nil
+}
+
+// EllSwiftECDH computes the BIP-324 x-only ECDH shared secret between our private key
+// and their ElligatorSwift-encoded public key. Both parties' encodings are hashed into
+// the secret, with the initiating party's encoding placed first:
+//
+//	ecdh_x = x(privateKey * decode(theirs))
+//	secret = tagged_hash("bip324_ellswift_xonly_ecdh", ellswift_A || ellswift_B || ecdh_x)
+//
+// Where A is the initiator and B is the responder.
+func EllSwiftECDH(
+	privateKey *big.Int,
+	ours, theirs []byte,
+	initiating bool,
+) ([32]byte, error) {
+	if !IsValidScalar(privateKey) {
+		panic("EllSwiftECDH: expected private key to be in range [1, Secp256k1_CurveOrder)")
+	}
+	if len(ours) != EllSwiftSize {
+		return [32]byte{}, ErrInvalidEllSwiftEncoding
+	}
+
+	theirX, err := EllSwiftDecode(theirs)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	theirY, _ := Weierstrass(theirX)
+
+	sharedX, _ := MultiplyAffine(theirX, theirY, privateKey, nil)
+	ecdhX := sharedX.FillBytes(make([]byte, 32))
+
+	if initiating {
+		return taggedHash("bip324_ellswift_xonly_ecdh", ours, theirs, ecdhX), nil
+	}
+	return taggedHash("bip324_ellswift_xonly_ecdh", theirs, ours, ecdhX), nil
+}
+
+// xSwiftEC decodes the field elements (u, t) to an x-coordinate on the curve.
+//
+//	if u == 0: u = 1
+//	if t == 0: t = 1
+//	if u³ + t² + 7 == 0: t = 2t
+//	X = (u³ + 7 - t²) / 2t
+//	Y = (X + t) / (sqrt(-3) * u)
+//	return the first valid x-coordinate of:
+//	  u + 4Y²
+//	  (-X/Y - u) / 2
+//	  (X/Y - u) / 2
+func xSwiftEC(u, t *big.Int) *big.Int {
+	u = new(big.Int).Set(u)
+	t = new(big.Int).Set(t)
+	if equal(u, zero) {
+		u.Set(one)
+	}
+	if equal(t, zero) {
+		t.Set(one)
+	}
+
+	// g = u³ + 7
+	g := new(big.Int).Mul(u, u)
+	g.Mul(g, u)
+	g.Add(g, Secp256k1_B)
+	modCoordinate(g)
+
+	// t²
+	t2 := new(big.Int).Mul(t, t)
+	modCoordinate(t2)
+
+	// if u³ + t² + 7 == 0: t = 2t
+	if sum := new(big.Int).Add(g, t2); equal(sum.Mod(sum, Secp256k1_P), zero) {
+		t.Lsh(t, 1)
+		modCoordinate(t)
+		t2.Mul(t, t)
+		modCoordinate(t2)
+	}
+
+	// X = (u³ + 7 - t²) / 2t
+	bigX := new(big.Int).Lsh(t, 1)
+	invertCoordinate(bigX)
+	bigX.Mul(bigX, g.Sub(g, t2))
+	modCoordinate(bigX)
+
+	// Y = (X + t) / (sqrt(-3) * u)
+	bigY := new(big.Int).Mul(ellswiftSqrtMinus3, u)
+	invertCoordinate(bigY)
+	bigY.Mul(bigY, t.Add(bigX, t))
+	modCoordinate(bigY)
+
+	// x = u + 4Y²
+	x := new(big.Int).Mul(bigY, bigY)
+	x.Lsh(x, 2)
+	x.Add(x, u)
+	modCoordinate(x)
+	if isValidXCoordinate(x) {
+		return x
+	}
+
+	// X/Y
+	xOverY := bigY
+	invertCoordinate(xOverY)
+	xOverY.Mul(xOverY, bigX)
+	modCoordinate(xOverY)
+
+	// x = (-X/Y - u) / 2
+	x.Neg(xOverY)
+	x.Sub(x, u)
+	halveCoordinate(x)
+	if isValidXCoordinate(x) {
+		return x
+	}
+
+	// x = (X/Y - u) / 2
+	// At least one of the three candidates is guaranteed to be valid.
+	x.Sub(xOverY, u)
+	halveCoordinate(x)
+	return x
+}
+
+// xSwiftECInverse finds t such that xSwiftEC(u, t) == x, or returns nil if no such
+// t exists for the given branch. There are up to 8 solutions for t, selected by c,
+// which must be in the range [0, 7].
+func xSwiftECInverse(x, u *big.Int, c byte) *big.Int {
+	var s, v *big.Int
+
+	// g = u³ + 7
+	g := new(big.Int).Mul(u, u)
+	g.Mul(g, u)
+	g.Add(g, Secp256k1_B)
+	modCoordinate(g)
+
+	if c&2 == 0 {
+		// if -x - u is a valid x-coordinate, the first decoding candidate would have
+		// been chosen instead of this one.
+		negXMinusU := new(big.Int).Neg(x)
+		negXMinusU.Sub(negXMinusU, u)
+		modCoordinate(negXMinusU)
+		if isValidXCoordinate(negXMinusU) {
+			return nil
+		}
+
+		// s = -(u³ + 7) / (u² + uv + v²)
+		v = x
+		denominator := new(big.Int).Mul(u, u)
+		denominator.Add(denominator, new(big.Int).Mul(u, v))
+		denominator.Add(denominator, new(big.Int).Mul(v, v))
+		modCoordinate(denominator)
+		if equal(denominator, zero) {
+			return nil
+		}
+		invertCoordinate(denominator)
+
+		s = denominator.Mul(denominator, g)
+		s.Neg(s)
+		modCoordinate(s)
+	} else {
+		// s = x - u
+		s = new(big.Int).Sub(x, u)
+		modCoordinate(s)
+		if equal(s, zero) {
+			return nil
+		}
+
+		// r = sqrt(-s * (4(u³ + 7) + 3su²))
+		r := new(big.Int).Mul(u, u)
+		r.Mul(r, s)
+		r.Mul(r, three)
+		r.Add(r, new(big.Int).Lsh(g, 2))
+		r.Mul(r, s)
+		r.Neg(r)
+		modCoordinate(r)
+		if r = sqrtCoordinate(r); r == nil {
+			return nil
+		}
+		if c&1 == 1 && equal(r, zero) {
+			return nil
+		}
+
+		// v = (-u + r/s) / 2
+		v = new(big.Int).Set(s)
+		invertCoordinate(v)
+		v.Mul(v, r)
+		v.Sub(v, u)
+		halveCoordinate(v)
+	}
+
+	w := sqrtCoordinate(s)
+	if w == nil {
+		return nil
+	}
+
+	// sqrt(-3) * u, negated for cases 0 and 4
+	// t = ±w * (u * (1 ± sqrt(-3)) / 2 + v)
+	t := new(big.Int).Mul(u, ellswiftSqrtMinus3)
+	if c&1 == 0 {
+		t.Neg(t)
+	}
+	t.Add(t, u)
+	halveCoordinate(t)
+	t.Add(t, v)
+	t.Mul(t, w)
+	if c&5 == 0 || c&5 == 5 {
+		t.Neg(t)
+	}
+	modCoordinate(t)
+	return t
+}
+
+// isValidXCoordinate returns true if x³ + 7 is a square modulo P, i.e. if x is
+// the x-coordinate of some point on the secp256k1 curve.
+func isValidXCoordinate(x *big.Int) bool {
+	c := new(big.Int).Mul(x, x)
+	c.Mul(c, x)
+	c.Add(c, Secp256k1_B)
+	modCoordinate(c)
+	return sqrtCoordinate(c) != nil
+}
+
+// halveCoordinate sets n to n / 2 modulo P.
+func halveCoordinate(n *big.Int) {
+	modCoordinate(n)
+	if n.Bit(0) == 1 {
+		n.Add(n, Secp256k1_P)
+	}
+	n.Rsh(n, 1)
+}
@@ -0,0 +1,188 @@
+package ekliptic
+
+import (
+	"bytes"
+	"crypto/rand"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestEllSwiftDecode(t *testing.T) {
+	for i, vector := range test_vectors.EllSwiftDecodeVectors {
+		x, err := EllSwiftDecode(vector.EllSwift)
+		if err != nil {
+			t.Errorf("failed to decode ellswift vector %d: %s", i, err)
+			continue
+		}
+		if !equal(x, vector.X) {
+			t.Errorf("ellswift decoding failed for vector %d (%s)\nGot:    %.64x\nWanted: %.64x", i, vector.Comment, x, vector.X)
+		}
+	}
+
+	// Every 64-byte string must decode to a valid x-coordinate, including encodings
+	// of u and t which overflow the field.
+	random := mathrand.New(mathrand.NewSource(1))
+	encoded := make([]byte, EllSwiftSize)
+	for i := 0; i < 64; i++ {
+		random.Read(encoded)
+		if i%4 == 0 {
+			copy(encoded[:32], bytes.Repeat([]byte{0xff}, 32))
+		}
+		x, _ := EllSwiftDecode(encoded)
+		if !isValidXCoordinate(x) {
+			t.Errorf("decoded ellswift encoding %x to invalid x-coordinate %x", encoded, x)
+		}
+	}
+
+	if _, err := EllSwiftDecode(make([]byte, 63)); err != ErrInvalidEllSwiftEncoding {
+		t.Errorf("expected ErrInvalidEllSwiftEncoding for short encoding; got %v", err)
+	}
+}
+
+func TestEllSwiftEncode(t *testing.T) {
+	random := mathrand.New(mathrand.NewSource(2))
+
+	for i := 0; i < 32; i++ {
+		d, _ := RandomScalar(random)
+		x, _ := MultiplyBasePoint(d)
+
+		encoded, err := EllSwiftEncode(random, x)
+		if err != nil {
+			t.Errorf("failed to encode x-coordinate: %s", err)
+			return
+		}
+
+		decoded, _ := EllSwiftDecode(encoded)
+		if !equal(decoded, x) {
+			t.Errorf("ellswift round trip failed\nGot:    %.64x\nWanted: %.64x", decoded, x)
+		}
+	}
+}
+
+func TestXSwiftECInverse_Vectors(t *testing.T) {
+	for i, vector := range test_vectors.XSwiftECInverseVectors {
+		for c, expected := range vector.T {
+			tee := xSwiftECInverse(vector.X, vector.U, byte(c))
+
+			if expected == nil {
+				if tee != nil {
+					t.Errorf("expected no xswiftec_inv preimage for vector %d case %d; got %.64x", i, c, tee)
+				}
+			} else if tee == nil || !equal(tee, expected) {
+				t.Errorf(
+					"xswiftec_inv failed for vector %d case %d (%s)\nGot:    %.64x\nWanted: %.64x",
+					i, c, vector.Comment, tee, expected,
+				)
+			}
+		}
+	}
+}
+
+func TestXSwiftECInverse(t *testing.T) {
+	random := mathrand.New(mathrand.NewSource(3))
+
+	found := 0
+	for i := 0; i < 16; i++ {
+		d, _ := RandomScalar(random)
+		x, _ := MultiplyBasePoint(d)
+		u, _ := RandomScalar(random)
+
+		for c := byte(0); c < 8; c++ {
+			tee := xSwiftECInverse(x, u, c)
+			if tee == nil {
+				continue
+			}
+			found++
+			if decoded := xSwiftEC(u, tee); !equal(decoded, x) {
+				t.Errorf("xswiftec(u, xswiftec_inv(x, u, %d)) != x\nGot:    %.64x\nWanted: %.64x", c, decoded, x)
+			}
+		}
+	}
+
+	if found == 0 {
+		t.Errorf("expected xswiftec_inv to find at least one preimage")
+	}
+}
+
+func TestEllSwiftECDH(t *testing.T) {
+	alicePriv, aliceEncoded, err := EllSwiftCreate(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to create ellswift key: %s", err)
+	}
+	bobPriv, bobEncoded, err := EllSwiftCreate(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to create ellswift key: %s", err)
+	}
+
+	aliceSecret, err := EllSwiftECDH(alicePriv, aliceEncoded, bobEncoded, true)
+	if err != nil {
+		t.Fatalf("failed to compute ellswift ECDH: %s", err)
+	}
+	bobSecret, err := EllSwiftECDH(bobPriv, bobEncoded, aliceEncoded, false)
+	if err != nil {
+		t.Fatalf("failed to compute ellswift ECDH: %s", err)
+	}
+
+	if aliceSecret != bobSecret {
+		t.Errorf("ellswift ECDH secrets do not match\nAlice: %x\nBob:   %x", aliceSecret, bobSecret)
+	}
+
+	wrongRole, _ := EllSwiftECDH(bobPriv, bobEncoded, aliceEncoded, true)
+	if wrongRole == aliceSecret {
+		t.Errorf("expected ellswift ECDH secret to depend on the initiator role")
+	}
+}
+
+func TestEllSwiftECDH_Vectors(t *testing.T) {
+	for i, vector := range test_vectors.EllSwiftECDHVectors {
+		if x, _ := MultiplyBasePoint(vector.PrivateKey); !equal(x, vector.XOurs) {
+			t.Errorf("unexpected public key for ellswift ECDH vector %d", i)
+		}
+
+		xOurs, err := EllSwiftDecode(vector.EllSwiftOurs)
+		if err != nil || !equal(xOurs, vector.XOurs) {
+			t.Errorf("failed to decode our ellswift encoding for ECDH vector %d", i)
+		}
+
+		xTheirs, err := EllSwiftDecode(vector.EllSwiftTheirs)
+		if err != nil || !equal(xTheirs, vector.XTheirs) {
+			t.Errorf("failed to decode their ellswift encoding for ECDH vector %d", i)
+			continue
+		}
+
+		yTheirs, _ := Weierstrass(xTheirs)
+		if xShared, _ := MultiplyAffine(xTheirs, yTheirs, vector.PrivateKey, nil); !equal(xShared, vector.XShared) {
+			t.Errorf("unexpected shared x-coordinate for ECDH vector %d\nGot:    %.64x\nWanted: %.64x", i, xShared, vector.XShared)
+		}
+
+		secret, err := EllSwiftECDH(vector.PrivateKey, vector.EllSwiftOurs, vector.EllSwiftTheirs, vector.Initiating)
+		if err != nil {
+			t.Errorf("failed to compute ellswift ECDH for vector %d: %s", i, err)
+			continue
+		}
+		if !bytes.Equal(secret[:], vector.SharedSecret) {
+			t.Errorf("ellswift ECDH failed for vector %d\nGot:    %x\nWanted: %x", i, secret, vector.SharedSecret)
+		}
+	}
+}
+
+func BenchmarkEllSwiftEncode(b *testing.B) {
+	d, _ := RandomScalar(rand.Reader)
+	x, _ := MultiplyBasePoint(d)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EllSwiftEncode(rand.Reader, x)
+	}
+}
+
+func BenchmarkEllSwiftDecode(b *testing.B) {
+	_, encoded, _ := EllSwiftCreate(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EllSwiftDecode(encoded)
+	}
+}
@@ -0,0 +1,102 @@
+package ekliptic
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidPointEncoding is returned when parsing a serialized point which is malformed,
+// or which does not describe a valid point on the secp256k1 curve.
+var ErrInvalidPointEncoding = errors.New("ekliptic: invalid point encoding")
+
+// serializeCompressed encodes the affine point (x, y) in the 33-byte SEC1 compressed format:
+// a prefix byte of 0x02 or 0x03 indicating the parity of y, followed by the 32-byte x-coordinate.
+func serializeCompressed(x, y *big.Int) []byte {
+	buf := make([]byte, 33)
+	buf[0] = 0x02 + byte(y.Bit(0))
+	x.FillBytes(buf[1:])
+	return buf
+}
+
+// serializeUncompressed encodes the affine point (x, y) in the 65-byte SEC1 uncompressed format:
+// a prefix byte of 0x04, followed by the 32-byte x-coordinate and the 32-byte y-coordinate.
+func serializeUncompressed(x, y *big.Int) []byte {
+	buf := make([]byte, 65)
+	buf[0] = 0x04
+	x.FillBytes(buf[1:33])
+	y.FillBytes(buf[33:])
+	return buf
+}
+
+// parseCompressed decodes a 33-byte SEC1 compressed point, returning its affine coordinates.
+func parseCompressed(buf []byte) (x, y *big.Int, err error) {
+	if len(buf) != 33 || (buf[0] != 0x02 && buf[0] != 0x03) {
+		return nil, nil, ErrInvalidPointEncoding
+	}
+
+	x = new(big.Int).SetBytes(buf[1:])
+	if equal(x, zero) {
+		return nil, nil, ErrInvalidPointEncoding
+	}
+
+	evenY, oddY := Weierstrass(x)
+	if evenY == nil {
+		return nil, nil, ErrInvalidPointEncoding
+	}
+
+	if buf[0] == 0x02 {
+		y = evenY
+	} else {
+		y = oddY
+	}
+	return x, y, nil
+}
+
+// serializeDER encodes an ECDSA signature (r, s) in the strict DER format
+// required by BIP-66: SEQUENCE { INTEGER r, INTEGER s }.
+func serializeDER(r, s *big.Int) []byte {
+	encodeInt := func(n *big.Int) []byte {
+		b := n.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0x00}, b...)
+		}
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+
+	body := append(encodeInt(r), encodeInt(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
+
+// parseDER decodes a strict DER-encoded ECDSA signature, as produced by serializeDER.
+func parseDER(sig []byte) (r, s *big.Int, err error) {
+	if len(sig) < 8 || len(sig) > 72 || sig[0] != 0x30 || int(sig[1]) != len(sig)-2 {
+		return nil, nil, ErrInvalidSignatureEncoding
+	}
+
+	parseInt := func(data []byte) (*big.Int, []byte, error) {
+		if len(data) < 3 || data[0] != 0x02 {
+			return nil, nil, ErrInvalidSignatureEncoding
+		}
+		length := int(data[1])
+		if length == 0 || length > 33 || len(data) < 2+length {
+			return nil, nil, ErrInvalidSignatureEncoding
+		}
+		b := data[2 : 2+length]
+
+		// Integers must be positive and minimally encoded.
+		if b[0]&0x80 != 0 || (length > 1 && b[0] == 0x00 && b[1]&0x80 == 0) {
+			return nil, nil, ErrInvalidSignatureEncoding
+		}
+		return new(big.Int).SetBytes(b), data[2+length:], nil
+	}
+
+	r, rest, err := parseInt(sig[2:])
+	if err != nil {
+		return nil, nil, err
+	}
+	s, rest, err = parseInt(rest)
+	if err != nil || len(rest) != 0 {
+		return nil, nil, ErrInvalidSignatureEncoding
+	}
+	return r, s, nil
+}
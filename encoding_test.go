@@ -0,0 +1,86 @@
+package ekliptic
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestParseCompressed(t *testing.T) {
+	for i, vector := range test_vectors.AffineMultiplicationVectors {
+		if EqualAffine(vector.X2, vector.Y2, zero, zero) {
+			continue
+		}
+
+		serialized := serializeCompressed(vector.X2, vector.Y2)
+
+		x, y, err := parseCompressed(serialized)
+		if err != nil {
+			t.Errorf("failed to parse compressed point for vector %d: %s", i, err)
+			continue
+		}
+
+		if !EqualAffine(x, y, vector.X2, vector.Y2) {
+			t.Errorf(`compressed point round trip failed for vector %d. Got:
+	x: %.64x
+	y: %.64x
+Wanted:
+	x: %.64x
+	y: %.64x
+`, i, x, y, vector.X2, vector.Y2)
+		}
+	}
+
+	invalid := [][]byte{
+		nil,
+		make([]byte, 33),
+		append([]byte{0x04}, make([]byte, 32)...),
+		append([]byte{0x02}, make([]byte, 32)...),
+		append([]byte{0x02}, Secp256k1_P.Bytes()...),
+	}
+
+	for _, data := range invalid {
+		if _, _, err := parseCompressed(data); err != ErrInvalidPointEncoding {
+			t.Errorf("expected ErrInvalidPointEncoding when parsing %x; got %v", data, err)
+		}
+	}
+}
+
+func TestParseDER(t *testing.T) {
+	for i, vector := range test_vectors.ECDSAVectors[:40] {
+		serialized := serializeDER(vector.R, vector.S)
+
+		r, s, err := parseDER(serialized)
+		if err != nil {
+			t.Errorf("failed to parse DER signature for vector %d: %s", i, err)
+			continue
+		}
+		if !equal(r, vector.R) || !equal(s, vector.S) {
+			t.Errorf("DER signature round trip failed for vector %d", i)
+		}
+	}
+
+	// r = 0x80 needs a leading zero byte to stay positive.
+	serialized := serializeDER(big.NewInt(0x80), one)
+	if expected := []byte{0x30, 0x07, 0x02, 0x02, 0x00, 0x80, 0x02, 0x01, 0x01}; !bytes.Equal(serialized, expected) {
+		t.Errorf("unexpected DER encoding: %x", serialized)
+	}
+
+	invalid := [][]byte{
+		nil,
+		{0x30, 0x05, 0x02, 0x01, 0x01, 0x02, 0x01},
+		{0x31, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x00},
+		{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x00},
+		{0x30, 0x07, 0x02, 0x01, 0x80, 0x02, 0x01, 0x01, 0x00},
+		{0x30, 0x07, 0x02, 0x02, 0x00, 0x01, 0x02, 0x01, 0x01},
+		{0x30, 0x07, 0x02, 0x01, 0x01, 0x03, 0x02, 0x00, 0x81},
+		{0x30, 0x08, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x02, 0x00},
+	}
+	for _, sig := range invalid {
+		if _, _, err := parseDER(sig); err != ErrInvalidSignatureEncoding {
+			t.Errorf("expected ErrInvalidSignatureEncoding when parsing %x; got %v", sig, err)
+		}
+	}
+}
@@ -22,6 +22,23 @@ func TestEqualJacobi(t *testing.T) {
 	}
 }
 
+func TestEqualJacobi_Infinity(t *testing.T) {
+	for i, vector1 := range test_vectors.JacobiInfinityDoublingVectors {
+		for j, vector2 := range test_vectors.JacobiInfinityDoublingVectors {
+			if !EqualJacobi(vector1.X1, vector1.Y1, vector1.Z1, vector2.X1, vector2.Y1, vector2.Z1) {
+				t.Errorf("expected points at infinity %d and %d to be equal", i, j)
+			}
+		}
+
+		for j, vector2 := range test_vectors.JacobiPointVectors {
+			if EqualJacobi(vector1.X1, vector1.Y1, vector1.Z1, vector2.JacobiX, vector2.JacobiY, vector2.JacobiZ) ||
+				EqualJacobi(vector2.JacobiX, vector2.JacobiY, vector2.JacobiZ, vector1.X1, vector1.Y1, vector1.Z1) {
+				t.Errorf("expected point at infinity %d not to equal point %d", i, j)
+			}
+		}
+	}
+}
+
 func TestEqualAffine(t *testing.T) {
 	x1 := new(big.Int)
 	y1 := new(big.Int)
@@ -0,0 +1,235 @@
+package ekliptic
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidEthereumAddress is returned by ParseEthereumAddress if the address is
+	// malformed, or if it is mixed-case and its EIP-55 checksum is incorrect.
+	ErrInvalidEthereumAddress = errors.New("ekliptic: invalid ethereum address")
+
+	// ErrInvalidEthereumSignature is returned when recovering an address from an Ethereum
+	// signature which is malformed or invalid.
+	ErrInvalidEthereumSignature = errors.New("ekliptic: invalid ethereum signature")
+)
+
+const (
+	// EthereumAddressSize is the size in bytes of an Ethereum address.
+	EthereumAddressSize = 20
+
+	// EthereumSignatureSize is the size in bytes of an Ethereum signature r || s || v.
+	EthereumSignatureSize = 65
+)
+
+// EthereumAddress derives the Ethereum address of a public key: the last 20 bytes of
+// the Keccak-256 hash of the 64-byte uncompressed public key, without its 0x04 prefix.
+func EthereumAddress(pubX, pubY *big.Int) [EthereumAddressSize]byte {
+	h := Keccak256(pubX.FillBytes(make([]byte, 32)), pubY.FillBytes(make([]byte, 32)))
+
+	var address [EthereumAddressSize]byte
+	copy(address[:], h[32-EthereumAddressSize:])
+	return address
+}
+
+// EthereumChecksumAddress encodes an Ethereum address as a 0x-prefixed hex string with an
+// EIP-55 mixed-case checksum. Each hex letter is uppercased if the corresponding nibble of
+// the Keccak-256 hash of the lowercase hex address is 8 or greater.
+//
+// https://eips.ethereum.org/EIPS/eip-55
+func EthereumChecksumAddress(address [EthereumAddressSize]byte) string {
+	lower := hex.EncodeToString(address[:])
+	h := Keccak256([]byte(lower))
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		nibble := h[i/2] >> 4
+		if i%2 == 1 {
+			nibble = h[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
+
+// ParseEthereumAddress decodes a 0x-prefixed hex Ethereum address. All-lowercase and
+// all-uppercase addresses carry no checksum and are accepted as-is. Mixed-case addresses
+// must have a valid EIP-55 checksum.
+func ParseEthereumAddress(s string) ([EthereumAddressSize]byte, error) {
+	var address [EthereumAddressSize]byte
+
+	if !strings.HasPrefix(s, "0x") || len(s) != 2+2*EthereumAddressSize {
+		return address, ErrInvalidEthereumAddress
+	}
+	if _, err := hex.Decode(address[:], []byte(s[2:])); err != nil {
+		return address, ErrInvalidEthereumAddress
+	}
+
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) &&
+		EthereumChecksumAddress(address) != s {
+		return address, ErrInvalidEthereumAddress
+	}
+	return address, nil
+}
+
+// EIP191Hash computes the hash signed by the personal_sign method, as defined
+// by version 0x45 of EIP-191:
+//
+//	Keccak256("\x19Ethereum Signed Message:\n" || len(message) || message)
+//
+// https://eips.ethereum.org/EIPS/eip-191
+func EIP191Hash(message []byte) [32]byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))
+	return Keccak256([]byte(prefix), message)
+}
+
+// EIP712Hash computes the hash of EIP-712 typed structured data, given the domain
+// separator and the hash of the message struct:
+//
+//	Keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+//
+// Both inputs must be computed by the caller according to EIP-712's hashStruct encoding,
+// using Keccak256.
+//
+// https://eips.ethereum.org/EIPS/eip-712
+func EIP712Hash(domainSeparator, structHash [32]byte) [32]byte {
+	return Keccak256([]byte{0x19, 0x01}, domainSeparator[:], structHash[:])
+}
+
+// SignEthereumHash signs a 32-byte hash with the private key d, using a nonce derived
+// deterministically with RFC 6979. It returns the 65-byte signature r || s || v,
+// with v in {27, 28}.
+//
+// SignEthereumHash panics if d is not within the range [1, Secp256k1_CurveOrder).
+func SignEthereumHash(d *big.Int, hash [32]byte) []byte {
+	r, s, recoveryID := signEthereumHash(d, hash)
+
+	sig := make([]byte, EthereumSignatureSize)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = 27 + recoveryID
+	return sig
+}
+
+// SignEthereumHashEIP155 signs a transaction hash with the private key d for the given chain ID,
+// using a nonce derived deterministically with RFC 6979. It returns the signature parts r and s,
+// along with the replay-protected EIP-155 value of v:
+//
+//	v = chainID * 2 + 35 + recoveryID
+//
+// SignEthereumHashEIP155 panics if d is not within the range [1, Secp256k1_CurveOrder),
+// or if chainID is not positive.
+//
+// https://eips.ethereum.org/EIPS/eip-155
+func SignEthereumHashEIP155(d *big.Int, hash [32]byte, chainID *big.Int) (r, s, v *big.Int) {
+	if chainID == nil || chainID.Sign() <= 0 {
+		panic("SignEthereumHashEIP155: expected chain ID to be positive")
+	}
+
+	r, s, recoveryID := signEthereumHash(d, hash)
+
+	v = new(big.Int).Lsh(chainID, 1)
+	v.Add(v, big.NewInt(35+int64(recoveryID)))
+	return
+}
+
+// RecoverEthereumHash recovers the address which produced the 65-byte signature r || s || v
+// on the given hash. Both v in {27, 28} and v in {0, 1} are accepted.
+func RecoverEthereumHash(hash [32]byte, sig []byte) ([EthereumAddressSize]byte, error) {
+	if len(sig) != EthereumSignatureSize {
+		return [EthereumAddressSize]byte{}, ErrInvalidEthereumSignature
+	}
+
+	recoveryID := sig[64]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 1 {
+		return [EthereumAddressSize]byte{}, ErrInvalidEthereumSignature
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	return recoverEthereumAddress(hash, r, s, recoveryID)
+}
+
+// RecoverEthereumHashEIP155 recovers the address which produced the signature (r, s, v)
+// on a transaction hash, where v is an EIP-155 value for the given chain ID.
+func RecoverEthereumHashEIP155(
+	hash [32]byte,
+	r, s, v *big.Int,
+	chainID *big.Int,
+) ([EthereumAddressSize]byte, error) {
+	if v == nil || chainID == nil || chainID.Sign() <= 0 {
+		return [EthereumAddressSize]byte{}, ErrInvalidEthereumSignature
+	}
+
+	// recoveryID = v - chainID * 2 - 35
+	recoveryID := new(big.Int).Lsh(chainID, 1)
+	recoveryID.Sub(v, recoveryID)
+	recoveryID.Sub(recoveryID, big.NewInt(35))
+	if recoveryID.Sign() < 0 || recoveryID.Cmp(one) > 0 {
+		return [EthereumAddressSize]byte{}, ErrInvalidEthereumSignature
+	}
+
+	return recoverEthereumAddress(hash, r, s, byte(recoveryID.Uint64()))
+}
+
+// SignPersonalMessage signs a message with the private key d as the personal_sign method
+// does, returning the 65-byte signature r || s || v of EIP191Hash(message).
+func SignPersonalMessage(d *big.Int, message []byte) []byte {
+	return SignEthereumHash(d, EIP191Hash(message))
+}
+
+// RecoverPersonalMessage recovers the address which signed a message using personal_sign.
+func RecoverPersonalMessage(message, sig []byte) ([EthereumAddressSize]byte, error) {
+	return RecoverEthereumHash(EIP191Hash(message), sig)
+}
+
+// SignTypedData signs EIP-712 typed data with the private key d, returning the 65-byte
+// signature r || s || v of EIP712Hash(domainSeparator, structHash).
+func SignTypedData(d *big.Int, domainSeparator, structHash [32]byte) []byte {
+	return SignEthereumHash(d, EIP712Hash(domainSeparator, structHash))
+}
+
+// RecoverTypedData recovers the address which signed EIP-712 typed data.
+func RecoverTypedData(domainSeparator, structHash [32]byte, sig []byte) ([EthereumAddressSize]byte, error) {
+	return RecoverEthereumHash(EIP712Hash(domainSeparator, structHash), sig)
+}
+
+func signEthereumHash(d *big.Int, hash [32]byte) (r, s *big.Int, recoveryID byte) {
+	if !IsValidScalar(d) {
+		panic("SignEthereumHash: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	k := rfc6979Nonce(d, hash[:])
+	z := new(big.Int).SetBytes(hash[:])
+	r, s, recoveryID = SignECDSARecoverable(d, k, z)
+
+	if recoveryID > 1 {
+		// Occurs with negligible probability. Ethereum's v values can't express
+		// a nonce point whose x-coordinate overflows the curve order.
+		panic("SignEthereumHash: nonce point x-coordinate exceeds curve order")
+	}
+	return
+}
+
+func recoverEthereumAddress(
+	hash [32]byte,
+	r, s *big.Int,
+	recoveryID byte,
+) ([EthereumAddressSize]byte, error) {
+	z := new(big.Int).SetBytes(hash[:])
+	pubX, pubY, err := RecoverPublicKeyECDSA(z, r, s, recoveryID)
+	if err != nil {
+		return [EthereumAddressSize]byte{}, ErrInvalidEthereumSignature
+	}
+	return EthereumAddress(pubX, pubY), nil
+}
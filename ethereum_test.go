@@ -0,0 +1,250 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestEthereumAddress(t *testing.T) {
+	vectors := []struct {
+		privateKey string
+		address    string
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000001", "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"},
+		{"4646464646464646464646464646464646464646464646464646464646464646", "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"},
+	}
+
+	for _, vector := range vectors {
+		pubX, pubY := MultiplyBasePoint(hexint(vector.privateKey))
+		address := EthereumChecksumAddress(EthereumAddress(pubX, pubY))
+		if address != vector.address {
+			t.Errorf("unexpected ethereum address:\n  got %s\nwanted %s", address, vector.address)
+		}
+	}
+}
+
+func TestEthereumChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55.
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, vector := range vectors {
+		address, err := ParseEthereumAddress(vector)
+		if err != nil {
+			t.Errorf("failed to parse address %s: %s", vector, err)
+			continue
+		}
+		if checksummed := EthereumChecksumAddress(address); checksummed != vector {
+			t.Errorf("unexpected checksum address:\n  got %s\nwanted %s", checksummed, vector)
+		}
+
+		if _, err := ParseEthereumAddress("0x" + strings.ToLower(vector[2:])); err != nil {
+			t.Errorf("failed to parse lowercase address: %s", err)
+		}
+
+		// Flip the case of the first letter to break the checksum.
+		broken := []byte(vector)
+		for i := 2; i < len(broken); i++ {
+			if c := broken[i]; c >= 'a' && c <= 'f' {
+				broken[i] = c - 'a' + 'A'
+				break
+			} else if c >= 'A' && c <= 'F' {
+				broken[i] = c - 'A' + 'a'
+				break
+			}
+		}
+		if _, err := ParseEthereumAddress(string(broken)); err != ErrInvalidEthereumAddress {
+			t.Errorf("expected ErrInvalidEthereumAddress for bad checksum %s; got %v", broken, err)
+		}
+	}
+
+	for _, invalid := range []string{"", "0x", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", "0xzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := ParseEthereumAddress(invalid); err != ErrInvalidEthereumAddress {
+			t.Errorf("expected ErrInvalidEthereumAddress for %q; got %v", invalid, err)
+		}
+	}
+}
+
+func TestSignEthereumHashEIP155(t *testing.T) {
+	// Example transaction from EIP-155.
+	d := hexint("4646464646464646464646464646464646464646464646464646464646464646")
+	var hash [32]byte
+	hex.Decode(hash[:], []byte("daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"))
+	chainID := big.NewInt(1)
+
+	expectedR, _ := new(big.Int).SetString("18515461264373351373200002665853028612451056578545711640558177340181847433846", 10)
+	expectedS, _ := new(big.Int).SetString("46948507304638947509940763649030358759909902576025900602547168820602576006531", 10)
+
+	r, s, v := SignEthereumHashEIP155(d, hash, chainID)
+	if !equal(r, expectedR) || !equal(s, expectedS) || v.Int64() != 37 {
+		t.Errorf("unexpected EIP-155 signature:\n  r: %d\n  s: %d\n  v: %d", r, s, v)
+	}
+
+	address, err := RecoverEthereumHashEIP155(hash, r, s, v, chainID)
+	if err != nil {
+		t.Fatalf("failed to recover EIP-155 signer: %s", err)
+	}
+	if EthereumChecksumAddress(address) != "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F" {
+		t.Errorf("recovered wrong EIP-155 signer: %s", EthereumChecksumAddress(address))
+	}
+
+	if _, err := RecoverEthereumHashEIP155(hash, r, s, v, big.NewInt(5)); err != ErrInvalidEthereumSignature {
+		t.Errorf("expected ErrInvalidEthereumSignature for wrong chain ID; got %v", err)
+	}
+}
+
+func TestSignPersonalMessage(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	expected := EthereumAddress(MultiplyBasePoint(d))
+	message := []byte("Sign in to example.com")
+
+	sig := SignPersonalMessage(d, message)
+	if len(sig) != EthereumSignatureSize || (sig[64] != 27 && sig[64] != 28) {
+		t.Fatalf("unexpected personal_sign signature: %x", sig)
+	}
+
+	address, err := RecoverPersonalMessage(message, sig)
+	if err != nil || address != expected {
+		t.Errorf("failed to recover personal_sign signer: %v", err)
+	}
+
+	// v in {0, 1} is also accepted.
+	sig[64] -= 27
+	if address, err := RecoverPersonalMessage(message, sig); err != nil || address != expected {
+		t.Errorf("failed to recover personal_sign signer with v in {0, 1}: %v", err)
+	}
+
+	if address, err := RecoverPersonalMessage([]byte("Sign in to evil.com"), sig); err == nil && address == expected {
+		t.Errorf("recovered signer for the wrong message")
+	}
+
+	sig[64] = 29
+	if _, err := RecoverPersonalMessage(message, sig); err != ErrInvalidEthereumSignature {
+		t.Errorf("expected ErrInvalidEthereumSignature for v = 29; got %v", err)
+	}
+	if _, err := RecoverPersonalMessage(message, sig[:64]); err != ErrInvalidEthereumSignature {
+		t.Errorf("expected ErrInvalidEthereumSignature for short signature; got %v", err)
+	}
+}
+
+func TestSignTypedData(t *testing.T) {
+	// The "Mail" example from EIP-712, signed by the key keccak256("cow").
+	uint256 := func(n int64) []byte { return new(big.Int).SetInt64(n).FillBytes(make([]byte, 32)) }
+	address := func(s string) []byte {
+		address, err := ParseEthereumAddress(s)
+		if err != nil {
+			t.Fatalf("failed to parse address %s: %s", s, err)
+		}
+		return append(make([]byte, 32-EthereumAddressSize), address[:]...)
+	}
+	hashString := func(s string) []byte {
+		h := Keccak256([]byte(s))
+		return h[:]
+	}
+	hashPerson := func(name, wallet string) []byte {
+		h := Keccak256(hashString("Person(string name,address wallet)"), hashString(name), address(wallet))
+		return h[:]
+	}
+
+	domainSeparator := Keccak256(
+		hashString("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"),
+		hashString("Ether Mail"),
+		hashString("1"),
+		uint256(1),
+		address("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"),
+	)
+	structHash := Keccak256(
+		hashString("Mail(Person from,Person to,string contents)Person(string name,address wallet)"),
+		hashPerson("Cow", "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"),
+		hashPerson("Bob", "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"),
+		hashString("Hello, Bob!"),
+	)
+
+	if hex.EncodeToString(domainSeparator[:]) != "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f" {
+		t.Errorf("unexpected EIP-712 domain separator: %x", domainSeparator)
+	}
+	if hex.EncodeToString(structHash[:]) != "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e" {
+		t.Errorf("unexpected EIP-712 struct hash: %x", structHash)
+	}
+	digest := EIP712Hash(domainSeparator, structHash)
+	if hex.EncodeToString(digest[:]) != "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Errorf("unexpected EIP-712 digest: %x", digest)
+	}
+
+	cow := Keccak256([]byte("cow"))
+	d := new(big.Int).SetBytes(cow[:])
+	expectedSig := "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" +
+		"1c"
+
+	sig := SignTypedData(d, domainSeparator, structHash)
+	if hex.EncodeToString(sig) != expectedSig {
+		t.Errorf("unexpected EIP-712 signature:\n  got %x\nwanted %s", sig, expectedSig)
+	}
+
+	signer, err := RecoverTypedData(domainSeparator, structHash, sig)
+	if err != nil || EthereumChecksumAddress(signer) != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Errorf("failed to recover typed data signer: %v", err)
+	}
+
+	otherDomain := Keccak256([]byte("other domain"))
+	if recovered, err := RecoverTypedData(otherDomain, structHash, sig); err == nil && recovered == signer {
+		t.Errorf("recovered signer for the wrong domain")
+	}
+}
+
+func TestEIP191Hash(t *testing.T) {
+	vectors := []struct {
+		message string
+		hash    string
+	}{
+		// From go-ethereum's accounts.TextHash tests.
+		{"Hello Joe", "a080337ae51c4e064c189e113edd0ba391df9206e2f49db658bb32cf2911730b"},
+		// From the web3.js eth.accounts.sign documentation.
+		{"Some data", "1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655"},
+	}
+
+	for _, vector := range vectors {
+		hash := EIP191Hash([]byte(vector.message))
+		if hex.EncodeToString(hash[:]) != vector.hash {
+			t.Errorf("unexpected EIP-191 hash of %q:\n  got %x\nwanted %s", vector.message, hash, vector.hash)
+		}
+	}
+}
+
+func TestSignPersonalMessage_Vector(t *testing.T) {
+	// From the web3.js eth.accounts.sign documentation.
+	d := hexint("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	message := []byte("Some data")
+	expectedSig := "b91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd" +
+		"6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a029" +
+		"1c"
+
+	sig := SignPersonalMessage(d, message)
+	if hex.EncodeToString(sig) != expectedSig {
+		t.Errorf("unexpected personal_sign signature:\n  got %x\nwanted %s", sig, expectedSig)
+	}
+
+	signer, err := RecoverPersonalMessage(message, sig)
+	if err != nil || EthereumChecksumAddress(signer) != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("failed to recover personal_sign signer: %v", err)
+	}
+}
+
+func BenchmarkRecoverPersonalMessage(b *testing.B) {
+	d, _ := RandomScalar(rand.Reader)
+	message := []byte("hello world")
+	sig := SignPersonalMessage(d, message)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RecoverPersonalMessage(message, sig)
+	}
+}
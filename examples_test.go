@@ -190,3 +190,99 @@ func ExampleInvertScalar() {
 	// output:
 	// found correct unblinded point q * h * G
 }
+
+// A DLEQ proof lets Bob prove to Alice that he blinded her point B with the same secret r
+// which backs his public key R = r * G, without revealing r. Alice can then be sure the
+// blinded point C = r * B she receives was computed honestly.
+func ExampleProveDLEQ() {
+	// Alice: blinded input point B
+	bX, _ := new(big.Int).SetString("8bf20851cc16007dbf3df0c109dc016b360ca0f729f368ea38c385ceeffaf3cf", 16)
+	bY, _ := new(big.Int).SetString("a0c7bd73154c02cc5e002c3a4f876158a4276c185ef859df589675a92c745e3a", 16)
+
+	// Bob: secret r and public key R
+	r, _ := new(big.Int).SetString("825e7984ae7843f9c13371d9a54143a465b1e2d278e67de1ca713127e40a52f1", 16)
+	rX, rY := ekliptic.MultiplyBasePoint(r)
+
+	// Bob: blind B and prove log_G(R) == log_B(C)
+	cX, cY := ekliptic.MultiplyAffine(bX, bY, r, nil)
+	proof, err := ekliptic.ProveDLEQ(
+		rand.Reader,
+		r,
+		ekliptic.Secp256k1_GeneratorX, ekliptic.Secp256k1_GeneratorY,
+		bX, bY,
+	)
+	if err != nil {
+		panic("failed to construct DLEQ proof: " + err.Error())
+	}
+
+	// Alice: verify that C was blinded with Bob's secret key
+	valid := ekliptic.VerifyDLEQ(
+		proof,
+		ekliptic.Secp256k1_GeneratorX, ekliptic.Secp256k1_GeneratorY,
+		bX, bY,
+		rX, rY,
+		cX, cY,
+	)
+	fmt.Printf("valid: %v\n", valid)
+
+	// output:
+	// valid: true
+}
+
+// Blind Schnorr signatures let a signer issue a BIP-340 signature on a message it never sees.
+// The user's final signature cannot be linked to the signing session which produced it.
+func ExampleNewBlindSchnorrSigner() {
+	// Signer: private key d and x-only public key
+	d, _ := new(big.Int).SetString("825e7984ae7843f9c13371d9a54143a465b1e2d278e67de1ca713127e40a52f1", 16)
+	pubX, _ := ekliptic.MultiplyBasePoint(d)
+
+	// Signer: start a session and send the nonce commitment to the user.
+	signer, err := ekliptic.NewBlindSchnorrSigner(rand.Reader, d, ekliptic.BlindSchnorrClause)
+	if err != nil {
+		panic("failed to start blind signing session: " + err.Error())
+	}
+
+	// User: blind the commitment for a message, and send the challenge to the signer.
+	message := []byte("one anonymous token")
+	user, challenge, err := ekliptic.NewBlindSchnorrUser(rand.Reader, pubX, message, signer.Commitment())
+	if err != nil {
+		panic("failed to blind commitment: " + err.Error())
+	}
+
+	// Signer: sign the blinded challenge.
+	response, err := signer.Respond(rand.Reader, challenge)
+	if err != nil {
+		panic("failed to sign blinded challenge: " + err.Error())
+	}
+
+	// User: unblind the response into a regular BIP-340 signature.
+	r, s, err := user.Unblind(response)
+	if err != nil {
+		panic("failed to unblind signature: " + err.Error())
+	}
+
+	fmt.Printf("valid: %v\n", ekliptic.VerifySchnorr(message, r, s, pubX))
+
+	// output:
+	// valid: true
+}
+
+// Points can also be handled as AffinePoint and JacobianPoint values, which keep their coordinates together.
+func ExampleJacobianPoint() {
+	g := ekliptic.Generator().ToJacobian()
+
+	// 3G = G + 2G, computed in Jacobian coordinates.
+	sum := g.Add(g.Double())
+	product := g.Multiply(big.NewInt(3), nil)
+	fmt.Printf("equal: %v\n", sum.Equal(product))
+
+	// 3G - 3G is the point at infinity.
+	fmt.Printf("infinity: %v\n", sum.Sub(product).IsInfinity())
+
+	fmt.Printf("x: %x\n", sum.ToAffine().X)
+
+	// output:
+	// equal: true
+	// infinity: true
+	// x: f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9
+}
@@ -1,53 +1,134 @@
 // genprecompute precomputes a base-point table for the secp256k1 generator point,
-// and writes it as generated code to a given output file.
+// and writes it in its binary serialization to a given output file.
+//
+// With the default "embed" format, the serialized table is written to a .bin file
+// alongside the Go output file, which embeds it with a go:embed directive. With the
+// "string" format, the serialized table is written into the Go output file as a
+// string constant. Either way, ekliptic decodes the table lazily on first use.
+//
+// The Go output file is excluded by the ekliptic_nobasetable build tag, which drops
+// the table from the build entirely.
 package main
 
 import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/kklash/ekliptic"
 )
 
 var (
 	outputFilePath string
+	window         uint
+	format         string
 )
 
-func run() error {
-	flag.StringVar(&outputFilePath, "o", "", "output precomputed table code to this file")
-	flag.Parse()
+const header = `//go:build !ekliptic_nobasetable
+// +build !ekliptic_nobasetable
+
+package ekliptic
+
+// DO NOT EDIT!
+// This file was automatically generated.
+`
 
-	table := ekliptic.NewPrecomputedTable(
+// generate builds the precomputed table for the generator point with the given window width,
+// and returns the generated files, keyed by their names relative to the directory of the Go
+// output file goFileName.
+func generate(goFileName string, window uint, format string) (map[string][]byte, error) {
+	if window < ekliptic.MinPrecomputedWindow || window > ekliptic.MaxPrecomputedWindow {
+		return nil, fmt.Errorf(
+			"window must be in range [%d, %d]",
+			ekliptic.MinPrecomputedWindow, ekliptic.MaxPrecomputedWindow,
+		)
+	}
+
+	table := ekliptic.NewPrecomputedTableWindow(
 		ekliptic.Secp256k1_GeneratorX,
 		ekliptic.Secp256k1_GeneratorY,
+		window,
 	)
+	data, err := table.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
 
-	code := `package ekliptic
-
-import "math/big"
+	files := make(map[string][]byte)
 
-// DO NOT EDIT!
-// This file was automatically generated.
+	switch format {
+	case "embed":
+		binFileName := strings.TrimSuffix(goFileName, ".go") + ".bin"
+		code := header + `
+import _ "embed"
 
-func init() {
-	basePointPrecomputations = PrecomputedTable{
+//go:embed ` + binFileName + `
+var basePointTableData string
 `
+		files[goFileName] = []byte(code)
+		files[binFileName] = data
+
+	case "string":
+		code := header + "\nconst basePointTableData = \"\" +\n"
+		for len(data) > 0 {
+			n := 32
+			if n > len(data) {
+				n = len(data)
+			}
+			line := ""
+			for _, b := range data[:n] {
+				line += fmt.Sprintf("\\x%02x", b)
+			}
+			data = data[n:]
+
+			if len(data) > 0 {
+				code += "\t\"" + line + "\" +\n"
+			} else {
+				code += "\t\"" + line + "\"\n"
+			}
+		}
+		files[goFileName] = []byte(code)
+
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+
+	return files, nil
+}
+
+func run() error {
+	flag.StringVar(&outputFilePath, "o", "", "output precomputed table code to this file")
+	flag.UintVar(&window, "w", ekliptic.DefaultPrecomputedWindow, "window width of the precomputed table in bits")
+	flag.StringVar(&format, "format", "embed", `output format: "embed" for a go:embed binary file, or "string" for a string constant`)
+	flag.Parse()
+
+	if outputFilePath == "" {
+		return fmt.Errorf("missing output file path")
+	}
+
+	dir, goFileName := filepath.Split(outputFilePath)
+	files, err := generate(goFileName, window, format)
+	if err != nil {
+		return err
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			return err
+		}
+	}
 
-	for _, row := range table {
-		code += "\t\t{\n"
-		for _, point := range row {
-			code += "\t\t\t{\n"
-			code += fmt.Sprintf("\t\t\t\tnew(big.Int).SetBytes(%#v),\n", point[0].Bytes())
-			code += fmt.Sprintf("\t\t\t\tnew(big.Int).SetBytes(%#v),\n", point[1].Bytes())
-			code += "\t\t\t},\n"
+	// Remove a stale binary file left behind by a previous "embed" output.
+	if format != "embed" {
+		binFilePath := strings.TrimSuffix(outputFilePath, ".go") + ".bin"
+		if err := os.Remove(binFilePath); err != nil && !os.IsNotExist(err) {
+			return err
 		}
-		code += "\t\t},\n"
 	}
-	code += "\t}\n"
-	code += "}\n"
 
-	return os.WriteFile(outputFilePath, []byte(code), 0644)
+	return nil
 }
 
 func main() {
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kklash/ekliptic"
+)
+
+// TestGeneratedTableUpToDate regenerates the base point table with the same settings
+// used by go:generate, and checks that the committed files match.
+func TestGeneratedTableUpToDate(t *testing.T) {
+	files, err := generate("precomputed_table.go", ekliptic.DefaultPrecomputedWindow, "embed")
+	if err != nil {
+		t.Fatalf("failed to generate table: %s", err)
+	}
+
+	for name, expected := range files {
+		committed, err := os.ReadFile(filepath.Join("..", name))
+		if err != nil {
+			t.Fatalf("failed to read committed file: %s", err)
+		}
+		if !bytes.Equal(committed, expected) {
+			t.Errorf("committed %s is out of date; run go generate", name)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for window := uint(ekliptic.MinPrecomputedWindow); window <= ekliptic.MaxPrecomputedWindow; window += 3 {
+		for _, format := range []string{"embed", "string"} {
+			files, err := generate("table.go", window, format)
+			if err != nil {
+				t.Fatalf("failed to generate table with window %d and format %s: %s", window, format, err)
+			}
+
+			if _, ok := files["table.go"]; !ok {
+				t.Errorf("missing Go file with window %d and format %s", window, format)
+			}
+			if _, ok := files["table.bin"]; ok != (format == "embed") {
+				t.Errorf("unexpected binary file presence with window %d and format %s", window, format)
+			}
+		}
+	}
+
+	if _, err := generate("table.go", ekliptic.MaxPrecomputedWindow+1, "embed"); err == nil {
+		t.Errorf("expected error for invalid window")
+	}
+	if _, err := generate("table.go", ekliptic.DefaultPrecomputedWindow, "json"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
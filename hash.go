@@ -0,0 +1,21 @@
+package ekliptic
+
+import "crypto/sha256"
+
+// taggedHash computes the BIP-340 style domain-separated hash of the given data:
+//
+//	SHA256(SHA256(tag) || SHA256(tag) || data)
+func taggedHash(tag string, data ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	var digest [32]byte
+	h.Sum(digest[:0])
+	return digest
+}
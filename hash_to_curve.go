@@ -0,0 +1,289 @@
+package ekliptic
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// Parameters of the RFC 9380 hash-to-curve suites for secp256k1.
+//
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-suites-for-secp256k1
+const (
+	// HashToCurveSuiteRO is the suite ID of the uniform random oracle encoding
+	// implemented by HashToCurve.
+	HashToCurveSuiteRO = "secp256k1_XMD:SHA-256_SSWU_RO_"
+
+	// HashToCurveSuiteNU is the suite ID of the nonuniform encoding implemented
+	// by EncodeToCurve.
+	HashToCurveSuiteNU = "secp256k1_XMD:SHA-256_SSWU_NU_"
+
+	// hashToFieldLength is L = ceil((ceil(log2(p)) + k) / 8) where k = 128 is the target
+	// security level. This is the number of bytes hashed per field element.
+	hashToFieldLength = 48
+)
+
+var (
+	// The parameters of the curve E' which is 3-isogenous to secp256k1:
+	//
+	//	y² = x³ + A'x + B'
+	//
+	// The simplified SWU map cannot be applied to secp256k1 directly because A = 0.
+	isoSecp256k1_A = hexint("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	isoSecp256k1_B = big.NewInt(1771)
+
+	// sswuZ is the non-square constant Z = -11 mod P used by the simplified SWU map.
+	sswuZ = new(big.Int).Sub(Secp256k1_P, big.NewInt(11))
+
+	// The coefficients of the rational maps for the 3-isogeny from E' to secp256k1.
+	//
+	// https://www.rfc-editor.org/rfc/rfc9380.html#appx-iso-secp256k1
+	isoMapXNum = []*big.Int{
+		hexint("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"),
+		hexint("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581"),
+		hexint("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262"),
+		hexint("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c"),
+	}
+	isoMapXDen = []*big.Int{
+		hexint("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b"),
+		hexint("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14"),
+		one,
+	}
+	isoMapYNum = []*big.Int{
+		hexint("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c"),
+		hexint("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3"),
+		hexint("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931"),
+		hexint("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84"),
+	}
+	isoMapYDen = []*big.Int{
+		hexint("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b"),
+		hexint("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
+		hexint("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
+		one,
+	}
+)
+
+// HashToCurve hashes an arbitrary message to a point on the secp256k1 curve, using
+// the RFC 9380 suite secp256k1_XMD:SHA-256_SSWU_RO_. The discrete logarithm of the
+// resulting point with respect to any other point is unknown.
+//
+// The output is indistinguishable from a random oracle. The domain separation tag dst
+// should be unique to the protocol and context in which HashToCurve is used.
+//
+//	u0, u1 = hash_to_field(msg, 2)
+//	Q0 = map_to_curve(u0)
+//	Q1 = map_to_curve(u1)
+//	P = Q0 + Q1
+//
+// It returns the resulting affine point (x, y).
+func HashToCurve(msg, dst []byte) (x, y *big.Int) {
+	u := hashToField(msg, dst, 2)
+
+	x0, y0 := mapToCurve(u[0])
+	x1, y1 := mapToCurve(u[1])
+
+	// secp256k1 has a cofactor of 1, so clearing the cofactor is not required.
+	return AddAffine(x0, y0, x1, y1)
+}
+
+// EncodeToCurve encodes an arbitrary message as a point on the secp256k1 curve, using
+// the RFC 9380 suite secp256k1_XMD:SHA-256_SSWU_NU_. This is roughly twice as fast as
+// HashToCurve, but its output is only distributed across a subset of the curve's points.
+// Use HashToCurve unless you know this is acceptable for your protocol.
+//
+//	u = hash_to_field(msg, 1)
+//	P = map_to_curve(u)
+//
+// It returns the resulting affine point (x, y).
+func EncodeToCurve(msg, dst []byte) (x, y *big.Int) {
+	u := hashToField(msg, dst, 1)
+	return mapToCurve(u[0])
+}
+
+// mapToCurve maps the field element u to a point on secp256k1, by applying the simplified
+// SWU map to the isogenous curve E', and then mapping the result to secp256k1 with the
+// 3-isogeny map.
+func mapToCurve(u *big.Int) (x, y *big.Int) {
+	x, y = mapToCurveSimpleSWU(u)
+	return isoMap(x, y)
+}
+
+// mapToCurveSimpleSWU maps the field element u to a point (x, y) on the curve E'
+// which is 3-isogenous to secp256k1.
+//
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-simplified-shallue-van-de-w
+//
+//	tv1 = inv0(Z² * u⁴ + Z * u²)
+//	x1 = (-B / A) * (1 + tv1)
+//	if tv1 == 0: x1 = B / (Z * A)
+//	gx1 = x1³ + A * x1 + B
+//	x2 = Z * u² * x1
+//	gx2 = x2³ + A * x2 + B
+//	if is_square(gx1): x = x1, y = sqrt(gx1)
+//	else: x = x2, y = sqrt(gx2)
+//	if sgn0(u) != sgn0(y): y = -y
+func mapToCurveSimpleSWU(u *big.Int) (x, y *big.Int) {
+	// zu2 = Z * u²
+	zu2 := new(big.Int).Mul(u, u)
+	zu2.Mul(zu2, sswuZ)
+	modCoordinate(zu2)
+
+	// tv1 = Z² * u⁴ + Z * u²
+	tv1 := new(big.Int).Mul(zu2, zu2)
+	tv1.Add(tv1, zu2)
+	modCoordinate(tv1)
+
+	x1 := new(big.Int)
+	if equal(tv1, zero) {
+		// x1 = B / (Z * A)
+		x1.Mul(sswuZ, isoSecp256k1_A)
+		invertCoordinate(x1)
+		x1.Mul(x1, isoSecp256k1_B)
+	} else {
+		// x1 = (-B / A) * (1 + 1/tv1)
+		invertCoordinate(tv1)
+		tv1.Add(tv1, one)
+
+		x1.Set(isoSecp256k1_A)
+		invertCoordinate(x1)
+		x1.Mul(x1, isoSecp256k1_B)
+		x1.Neg(x1)
+		x1.Mul(x1, tv1)
+	}
+	modCoordinate(x1)
+	tv1 = nil
+
+	if y = sqrtCoordinate(isoCurveEquation(x1)); y != nil {
+		x = x1
+	} else {
+		// x2 = Z * u² * x1
+		x = x1.Mul(x1, zu2)
+		modCoordinate(x)
+		y = sqrtCoordinate(isoCurveEquation(x))
+	}
+
+	if u.Bit(0) != y.Bit(0) {
+		y = Negate(y)
+	}
+	return
+}
+
+// isoCurveEquation computes x³ + A'x + B' modulo P.
+func isoCurveEquation(x *big.Int) *big.Int {
+	gx := new(big.Int).Mul(x, x)
+	gx.Add(gx, isoSecp256k1_A)
+	gx.Mul(gx, x)
+	gx.Add(gx, isoSecp256k1_B)
+	modCoordinate(gx)
+	return gx
+}
+
+// isoMap maps a point (x', y') on the curve E' to secp256k1 using the 3-isogeny map:
+//
+//	x = x_num / x_den
+//	y = y' * y_num / y_den
+//
+// Each of x_num, x_den, y_num and y_den is a polynomial in x'.
+func isoMap(xPrime, yPrime *big.Int) (x, y *big.Int) {
+	xNum := evalPolynomial(isoMapXNum, xPrime)
+	xDen := evalPolynomial(isoMapXDen, xPrime)
+	yNum := evalPolynomial(isoMapYNum, xPrime)
+	yDen := evalPolynomial(isoMapYDen, xPrime)
+
+	if equal(xDen, zero) || equal(yDen, zero) {
+		// Exceptional case: the point is mapped to infinity.
+		return new(big.Int), new(big.Int)
+	}
+
+	invertCoordinate(xDen)
+	x = xNum.Mul(xNum, xDen)
+	modCoordinate(x)
+
+	invertCoordinate(yDen)
+	y = yNum.Mul(yNum, yDen)
+	y.Mul(y, yPrime)
+	modCoordinate(y)
+
+	return
+}
+
+// evalPolynomial evaluates the polynomial with the given coefficients at x, modulo P,
+// using Horner's method. Coefficients are given from lowest to highest degree.
+func evalPolynomial(coefficients []*big.Int, x *big.Int) *big.Int {
+	result := new(big.Int).Set(coefficients[len(coefficients)-1])
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, coefficients[i])
+		modCoordinate(result)
+	}
+	return result
+}
+
+// hashToField hashes the message into count field elements modulo P.
+//
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-hash_to_field-implementatio
+func hashToField(msg, dst []byte, count int) []*big.Int {
+	uniformBytes := expandMessageXMD(msg, dst, count*hashToFieldLength)
+
+	elements := make([]*big.Int, count)
+	for i := range elements {
+		e := new(big.Int).SetBytes(uniformBytes[i*hashToFieldLength : (i+1)*hashToFieldLength])
+		modCoordinate(e)
+		elements[i] = e
+	}
+	return elements
+}
+
+// expandMessageXMD produces a uniformly random byte string of the given length using
+// SHA-256, following RFC 9380's expand_message_xmd. It panics if length is greater
+// than 8160 bytes.
+//
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-expand_message_xmd
+//
+//	ell = ceil(len_in_bytes / b_in_bytes)
+//	DST_prime = DST || I2OSP(len(DST), 1)
+//	msg_prime = Z_pad || msg || I2OSP(len_in_bytes, 2) || I2OSP(0, 1) || DST_prime
+//	b_0 = H(msg_prime)
+//	b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+//	b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+//	uniform_bytes = b_1 || ... || b_ell
+func expandMessageXMD(msg, dst []byte, length int) []byte {
+	ell := (length + sha256.Size - 1) / sha256.Size
+	if ell > 255 || length > 0xffff {
+		panic("expandMessageXMD: requested output length is too large")
+	}
+
+	// Oversized DSTs must be hashed down first.
+	if len(dst) > 255 {
+		h := sha256.New()
+		h.Write([]byte("H2C-OVERSIZE-DST-"))
+		h.Write(dst)
+		dst = h.Sum(nil)
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize)) // Z_pad
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	uniformBytes := make([]byte, 0, ell*sha256.Size)
+	bi := make([]byte, sha256.Size)
+	for i := 1; i <= ell; i++ {
+		// strxor(b_0, b_(i - 1)), where b_1 is computed with an all-zero b_(i - 1).
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+
+		uniformBytes = append(uniformBytes, bi...)
+	}
+
+	return uniformBytes[:length]
+}
@@ -0,0 +1,74 @@
+package ekliptic
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestHashToCurve(t *testing.T) {
+	for i, vector := range test_vectors.HashToCurveVectors {
+		var x, y *big.Int
+		if vector.Suite == "RO" {
+			x, y = HashToCurve(vector.Msg, vector.DST)
+		} else {
+			x, y = EncodeToCurve(vector.Msg, vector.DST)
+		}
+
+		if !equal(x, vector.X) || !equal(y, vector.Y) {
+			t.Errorf(`hash to curve (%s) failed for vector %d. Got:
+	x: %.64x
+	y: %.64x
+Wanted:
+	x: %.64x
+	y: %.64x
+`, vector.Suite, i, x, y, vector.X, vector.Y)
+		}
+	}
+}
+
+func TestExpandMessageXMD(t *testing.T) {
+	for i, vector := range test_vectors.ExpandMessageXMDVectors {
+		uniformBytes := expandMessageXMD(vector.Msg, vector.DST, vector.LenInBytes)
+
+		if !bytes.Equal(uniformBytes, vector.UniformBytes) {
+			t.Errorf(`expand_message_xmd failed for vector %d. Got:
+	%x
+Wanted:
+	%x
+`, i, uniformBytes, vector.UniformBytes)
+		}
+	}
+}
+
+func TestMapToCurve(t *testing.T) {
+	inputs := []*big.Int{
+		new(big.Int),
+		new(big.Int).Set(one),
+		new(big.Int).Sub(Secp256k1_P, one),
+		hexint("6b0f9910dd2ba71c78f2ee9f04d73b5f4c5f7fc773a701abea1e573cab002fb3"),
+	}
+
+	for i, u := range inputs {
+		x, y := mapToCurve(u)
+		if !IsOnCurveAffine(x, y) {
+			t.Errorf("mapped field element %d to a point not on the curve", i)
+		}
+	}
+}
+
+func BenchmarkHashToCurve(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	for i := 0; i < b.N; i++ {
+		HashToCurve([]byte("abc"), dst)
+	}
+}
+
+func BenchmarkEncodeToCurve(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_NU_")
+	for i := 0; i < b.N; i++ {
+		EncodeToCurve([]byte("abc"), dst)
+	}
+}
@@ -26,3 +26,49 @@ func invertCoordinate(n *big.Int) {
 func InvertScalar(d *big.Int) *big.Int {
 	return new(big.Int).ModInverse(d, Secp256k1_CurveOrder)
 }
+
+// BatchInvertCoordinates inverts each of the given point coordinate values modulo the curve's
+// prime parameter P, in place. Values must be within the range [0, P). Zero has no inverse,
+// so zero values are left unchanged.
+//
+// It uses Montgomery's simultaneous inversion trick, computing all the inverses with a single
+// modular inversion plus 3(N-1) multiplications, which is far cheaper than inverting each
+// value on its own:
+//
+//	a = v₁ * v₂ * ... * vₙ
+//	a⁻¹ = 1 / a
+//	vₙ⁻¹ = a⁻¹ * (v₁ * ... * vₙ₋₁)
+//	(v₁ * ... * vₙ₋₁)⁻¹ = a⁻¹ * vₙ
+//	...
+func BatchInvertCoordinates(values []*big.Int) {
+	// prefixes[i] holds the product of all non-zero values before index i.
+	prefixes := make([]*big.Int, len(values))
+	acc := new(big.Int).Set(one)
+	for i, v := range values {
+		prefixes[i] = new(big.Int).Set(acc)
+		if v.Sign() != 0 {
+			acc.Mul(acc, v)
+			modCoordinate(acc)
+		}
+	}
+
+	// acc = (v₁ * v₂ * ... * vₙ)⁻¹
+	invertCoordinate(acc)
+
+	for i := len(values) - 1; i >= 0; i-- {
+		v := values[i]
+		if v.Sign() == 0 {
+			continue
+		}
+
+		// vᵢ⁻¹ = (v₁ * ... * vᵢ)⁻¹ * (v₁ * ... * vᵢ₋₁)
+		inverse := prefixes[i].Mul(prefixes[i], acc)
+		modCoordinate(inverse)
+
+		// (v₁ * ... * vᵢ₋₁)⁻¹ = (v₁ * ... * vᵢ)⁻¹ * vᵢ
+		acc.Mul(acc, v)
+		modCoordinate(acc)
+
+		v.Set(inverse)
+	}
+}
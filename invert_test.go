@@ -2,6 +2,7 @@ package ekliptic
 
 import (
 	"crypto/rand"
+	"math/big"
 	"testing"
 )
 
@@ -23,6 +24,39 @@ func TestInvertScalar(t *testing.T) {
 	}
 }
 
+func TestBatchInvertCoordinates(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 17} {
+		values := make([]*big.Int, n)
+		expected := make([]*big.Int, n)
+		for i := range values {
+			// Sprinkle in some zeros, which should be left untouched.
+			if i%5 == 3 {
+				values[i] = new(big.Int)
+				expected[i] = new(big.Int)
+				continue
+			}
+
+			v, err := rand.Int(rand.Reader, Secp256k1_P)
+			if err != nil {
+				t.Fatalf("failed to generate random value: %s", err)
+			}
+			v.Add(v, one)
+			modCoordinate(v)
+
+			values[i] = v
+			expected[i] = new(big.Int).ModInverse(v, Secp256k1_P)
+		}
+
+		BatchInvertCoordinates(values)
+
+		for i := range values {
+			if !equal(values[i], expected[i]) {
+				t.Errorf("batch inversion of %d values failed at index %d:\n  got %x\nwanted %x", n, i, values[i], expected[i])
+			}
+		}
+	}
+}
+
 func BenchmarkInvertScalar(b *testing.B) {
 	r, err := RandomScalar(rand.Reader)
 	if err != nil {
@@ -35,3 +69,16 @@ func BenchmarkInvertScalar(b *testing.B) {
 		InvertScalar(r)
 	}
 }
+
+func BenchmarkBatchInvertCoordinates(b *testing.B) {
+	values := make([]*big.Int, 1000)
+	for i := range values {
+		values[i], _ = rand.Int(rand.Reader, Secp256k1_P)
+		values[i].Add(values[i], one)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchInvertCoordinates(values)
+	}
+}
@@ -2,15 +2,22 @@ package ekliptic
 
 import "math/big"
 
-// IsOnCurveAffine determines if the affine point at (x, y) is a valid point on the secp256k1 curve.
+// IsOnCurveAffine determines if the affine point at (x, y) is a valid point on the secp256k1 curve,
+// or the point at infinity (0, 0). IsOnCurveAffine is a wrapper around AffinePoint.IsOnCurve.
+func IsOnCurveAffine(x, y *big.Int) bool {
+	return AffinePoint{x, y}.IsOnCurve()
+}
+
+// IsOnCurve determines if p is a valid point on the secp256k1 curve, or the point at infinity.
 // It checks for equality using the Weierstrass curve equation:
 //
 //	y² = x³ + ax + b
-func IsOnCurveAffine(x, y *big.Int) bool {
-	if equal(x, zero) && equal(y, zero) {
-		return true
-	}
+func (p AffinePoint) IsOnCurve() bool {
+	return p.IsInfinity() || satisfiesCurveEquation(p.X, p.Y)
+}
 
+// satisfiesCurveEquation returns true if y² = x³ + 7 for the affine coordinates (x, y).
+func satisfiesCurveEquation(x, y *big.Int) bool {
 	// y²
 	left := new(big.Int).Mul(y, y)
 	modCoordinate(left)
@@ -25,8 +32,18 @@ func IsOnCurveAffine(x, y *big.Int) bool {
 }
 
 // IsOnCurveJacobi determines if the jacobian point at (x, y, z) is a valid point on the secp256k1 curve.
-// If z is nil, it is assumed to be 1, meaning x and y are affine coordinates. It uses the Weierstrass
-// curve equation to determine whether the given point is valid:
+// If z is nil, it is assumed to be 1, meaning x and y are affine coordinates. IsOnCurveJacobi is a
+// wrapper around JacobianPoint.IsOnCurve, so any point with z = 0 is considered to be on the curve,
+// as it is the point at infinity.
+func IsOnCurveJacobi(x, y, z *big.Int) bool {
+	if z == nil {
+		return IsOnCurveAffine(x, y)
+	}
+	return JacobianPoint{x, y, z}.IsOnCurve()
+}
+
+// IsOnCurve determines if p is a valid point on the secp256k1 curve, or the point at infinity.
+// It uses the Weierstrass curve equation to determine whether the given point is valid:
 //
 //	y² = x³ + ax + b
 //
@@ -47,12 +64,13 @@ func IsOnCurveAffine(x, y *big.Int) bool {
 //
 // This approach is 2.5x more performant than converting a Jacobian point to affine
 // coordinates first, because it does not require modular inversion to arrive at a result.
-func IsOnCurveJacobi(x, y, z *big.Int) bool {
-	if equal(x, zero) && equal(y, zero) {
+func (p JacobianPoint) IsOnCurve() bool {
+	if p.IsInfinity() {
 		return true
 	}
-	if z == nil || equal(z, one) {
-		return IsOnCurveAffine(x, y)
+	x, y, z := p.X, p.Y, p.Z
+	if equal(z, one) {
+		return satisfiesCurveEquation(x, y)
 	}
 
 	// y²
@@ -33,6 +33,25 @@ func TestIsOnCurveJacobi(t *testing.T) {
 	}
 }
 
+func TestIsOnCurveJacobi_Infinity(t *testing.T) {
+	for i, vector := range test_vectors.JacobiInfinityDoublingVectors {
+		if !IsOnCurveJacobi(vector.X1, vector.Y1, vector.Z1) {
+			t.Errorf("expected point at infinity %d to be considered on the curve", i)
+		}
+	}
+
+	// (0, 0) is only the point at infinity in affine coordinates.
+	if IsOnCurveJacobi(zero, zero, one) {
+		t.Errorf("expected (0, 0, 1) not to be on the curve")
+	}
+	if !IsOnCurveJacobi(zero, zero, nil) || !IsOnCurveAffine(zero, zero) {
+		t.Errorf("expected affine point at infinity to be considered on the curve")
+	}
+	if new(Curve).IsOnCurve(zero, zero) {
+		t.Errorf("expected Curve.IsOnCurve to exclude the point at infinity")
+	}
+}
+
 func TestIsOnCurveAffine(t *testing.T) {
 	for i, vector := range test_vectors.JacobiPointVectors {
 		x := new(big.Int).Set(vector.JacobiX)
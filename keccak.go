@@ -0,0 +1,110 @@
+package ekliptic
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// keccak256Rate is the sponge rate in bytes of Keccak-256: (1600 - 2 * 256) / 8.
+const keccak256Rate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations holds the rho step rotation offset for each lane, indexed by x + 5y.
+var keccakRotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// Keccak256 computes the Keccak-256 hash of the concatenation of the given data, as used by
+// Ethereum. This is the original Keccak submission to the SHA-3 competition, which differs
+// from the standardized SHA3-256 only in its padding byte, so the two produce different digests.
+//
+//	Keccak256("") = c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470
+func Keccak256(data ...[]byte) [32]byte {
+	var state [25]uint64
+	var block [keccak256Rate]byte
+	n := 0
+
+	for _, d := range data {
+		for len(d) > 0 {
+			copied := copy(block[n:], d)
+			n += copied
+			d = d[copied:]
+
+			if n == keccak256Rate {
+				keccakAbsorb(&state, &block)
+				n = 0
+			}
+		}
+	}
+
+	// Keccak padding: 0x01, zeros, then 0x80 in the final byte of the block.
+	for i := n; i < keccak256Rate; i++ {
+		block[i] = 0
+	}
+	block[n] ^= 0x01
+	block[keccak256Rate-1] ^= 0x80
+	keccakAbsorb(&state, &block)
+
+	var digest [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(digest[i*8:], state[i])
+	}
+	return digest
+}
+
+// keccakAbsorb XORs a block into the sponge state and applies the permutation.
+func keccakAbsorb(state *[25]uint64, block *[keccak256Rate]byte) {
+	for i := 0; i < keccak256Rate/8; i++ {
+		state[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+	}
+	keccakF1600(state)
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation to the state, whose lanes
+// are indexed by x + 5y.
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	var b [25]uint64
+
+	for round := 0; round < 24; round++ {
+		// θ
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		// ρ and π
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], keccakRotations[x+5*y])
+			}
+		}
+
+		// χ
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[y+x] = b[y+x] ^ (^b[y+(x+1)%5] & b[y+(x+2)%5])
+			}
+		}
+
+		// ι
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
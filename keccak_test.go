@@ -0,0 +1,49 @@
+package ekliptic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	vectors := []struct {
+		input  string
+		digest string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	}
+
+	for _, vector := range vectors {
+		digest := Keccak256([]byte(vector.input))
+		if hex.EncodeToString(digest[:]) != vector.digest {
+			t.Errorf("unexpected Keccak256 digest of %q:\n  got %x\nwanted %s", vector.input, digest, vector.digest)
+		}
+	}
+}
+
+func TestKeccak256_Chunking(t *testing.T) {
+	// Inputs around the 136-byte rate boundary exercise padding across blocks.
+	for _, length := range []int{135, 136, 137, 272, 300} {
+		data := bytes.Repeat([]byte{0xa5}, length)
+		expected := Keccak256(data)
+
+		for split := 0; split <= length; split += 17 {
+			if Keccak256(data[:split], nil, data[split:]) != expected {
+				t.Errorf("Keccak256 digest of %d bytes depends on chunking at %d", length, split)
+			}
+		}
+
+		if Keccak256(data[:length-1]) == expected {
+			t.Errorf("expected different digests for different inputs")
+		}
+	}
+}
+
+func BenchmarkKeccak256(b *testing.B) {
+	data := make([]byte, 64)
+	for i := 0; i < b.N; i++ {
+		Keccak256(data)
+	}
+}
@@ -5,3 +5,7 @@ import "math/big"
 func modCoordinate(n *big.Int) {
 	n.Mod(n, Secp256k1_P)
 }
+
+func modScalar(n *big.Int) {
+	n.Mod(n, Secp256k1_CurveOrder)
+}
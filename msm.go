@@ -0,0 +1,118 @@
+package ekliptic
+
+import (
+	"math/big"
+)
+
+// MultiScalarMultiply computes the sum of the products of each affine point with its
+// corresponding scalar:
+//
+//	k1 * P1 + k2 * P2 + ... + kn * Pn
+//
+// It returns the resulting affine point (x, y). This is considerably faster than
+// multiplying each point separately and summing the products, because all points
+// share the same sequence of doublings.
+//
+// MultiScalarMultiply uses Straus' method with 4-bit windows. Each point's multiples
+// 1P through 15P are computed first. Then for each 4-bit window of the scalars, from
+// most significant to least, the accumulator is doubled 4 times, and the appropriate
+// multiple of each point is added.
+//
+// Scalars are interpreted modulo Secp256k1_CurveOrder. MultiScalarMultiply is NOT
+// constant-time, and should only be used with public scalars, such as when verifying
+// proofs. It panics if points and scalars have different lengths, or if any point
+// is not on the secp256k1 curve.
+func MultiScalarMultiply(points [][2]*big.Int, scalars []*big.Int) (x, y *big.Int) {
+	if len(points) != len(scalars) {
+		panic("MultiScalarMultiply: expected equal number of points and scalars")
+	}
+
+	result := multiScalarMultiplyJacobi(points, scalars).ToAffine()
+	return result.X, result.Y
+}
+
+// multiScalarMultiplyJacobi is like MultiScalarMultiply, but returns the sum as a Jacobian
+// point, so that callers computing many sums can normalize them together with BatchToAffine.
+func multiScalarMultiplyJacobi(points [][2]*big.Int, scalars []*big.Int) JacobianPoint {
+	type multiples [16][3]*big.Int
+
+	tables := make([]*multiples, 0, len(points))
+	windows := make([][]byte, 0, len(points))
+
+	for i, point := range points {
+		if !IsOnCurveAffine(point[0], point[1]) {
+			panic("MultiScalarMultiply: refusing to multiply point not on the curve")
+		}
+
+		k := new(big.Int).Mod(scalars[i], Secp256k1_CurveOrder)
+		if equal(k, zero) || EqualAffine(point[0], point[1], zero, zero) {
+			continue
+		}
+
+		table := new(multiples)
+		table[1] = [3]*big.Int{point[0], point[1], one}
+		for j := 2; j < len(table); j++ {
+			table[j][0], table[j][1], table[j][2] = AddJacobi(
+				table[j-1][0], table[j-1][1], table[j-1][2],
+				point[0], point[1], one,
+			)
+		}
+		tables = append(tables, table)
+		windows = append(windows, scalarWindows(k))
+	}
+
+	x, y, z := new(big.Int), new(big.Int), new(big.Int)
+
+	for w := 0; w < 64; w++ {
+		if !equal(z, zero) {
+			for i := 0; i < 4; i++ {
+				x, y, z = DoubleJacobi(x, y, z)
+			}
+		}
+
+		for i, table := range tables {
+			if d := windows[i][w]; d > 0 {
+				x, y, z = AddJacobi(
+					x, y, z,
+					table[d][0], table[d][1], table[d][2],
+				)
+			}
+		}
+	}
+
+	return JacobianPoint{x, y, z}
+}
+
+// secretMultiScalarMultiply computes the same sum as MultiScalarMultiply, but multiplies
+// each point separately with the constant-time JacobianPoint.Multiply, so that it can be
+// used with secret scalars. This is much slower than MultiScalarMultiply, and should only
+// be used where the scalars must be kept secret, such as when constructing proofs.
+//
+// It panics if points and scalars have different lengths, or if any point is not on
+// the secp256k1 curve.
+func secretMultiScalarMultiply(points [][2]*big.Int, scalars []*big.Int) (x, y *big.Int) {
+	if len(points) != len(scalars) {
+		panic("secretMultiScalarMultiply: expected equal number of points and scalars")
+	}
+
+	sum := JacobianInfinity()
+	for i, point := range points {
+		product := AffinePoint{point[0], point[1]}.ToJacobian().Multiply(scalars[i], nil)
+		sum = sum.Add(product)
+	}
+
+	result := sum.ToAffine()
+	return result.X, result.Y
+}
+
+// scalarWindows splits the 256-bit scalar k into 64 4-bit windows, from most
+// significant to least significant.
+func scalarWindows(k *big.Int) []byte {
+	kBytes := k.FillBytes(make([]byte, 32))
+	windows := make([]byte, 64)
+	for i := range kBytes {
+		windows[i*2] = kBytes[i] >> 4
+		windows[i*2+1] = kBytes[i] & 0b1111
+	}
+	return windows
+}
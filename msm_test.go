@@ -0,0 +1,107 @@
+package ekliptic
+
+import (
+	"math/big"
+	mathrand "math/rand"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestMultiScalarMultiply(t *testing.T) {
+	random := mathrand.New(mathrand.NewSource(1))
+
+	for _, size := range []int{0, 1, 2, 5, 16} {
+		points := make([][2]*big.Int, size)
+		scalars := make([]*big.Int, size)
+
+		expectedX, expectedY := new(big.Int), new(big.Int)
+		for i := range points {
+			d, _ := RandomScalar(random)
+			points[i][0], points[i][1] = MultiplyBasePoint(d)
+			scalars[i], _ = RandomScalar(random)
+
+			px, py := MultiplyAffine(points[i][0], points[i][1], scalars[i], nil)
+			expectedX, expectedY = AddAffine(expectedX, expectedY, px, py)
+		}
+
+		x, y := MultiScalarMultiply(points, scalars)
+		if !EqualAffine(x, y, expectedX, expectedY) {
+			t.Errorf(`multi-scalar multiplication failed for %d points. Got:
+	x: %.64x
+	y: %.64x
+Wanted:
+	x: %.64x
+	y: %.64x
+`, size, x, y, expectedX, expectedY)
+		}
+	}
+}
+
+func TestSecretMultiScalarMultiply(t *testing.T) {
+	random := mathrand.New(mathrand.NewSource(2))
+
+	for _, size := range []int{0, 1, 2, 5} {
+		points := make([][2]*big.Int, size)
+		scalars := make([]*big.Int, size)
+		for i := range points {
+			d, _ := RandomScalar(random)
+			points[i][0], points[i][1] = MultiplyBasePoint(d)
+			scalars[i], _ = RandomScalar(random)
+		}
+
+		expectedX, expectedY := MultiScalarMultiply(points, scalars)
+		x, y := secretMultiScalarMultiply(points, scalars)
+		if !EqualAffine(x, y, expectedX, expectedY) {
+			t.Errorf("secret multi-scalar multiplication of %d points disagrees with MultiScalarMultiply", size)
+		}
+	}
+}
+
+func TestMultiScalarMultiply_Vectors(t *testing.T) {
+	for i, vector := range test_vectors.AffineMultiplicationVectors {
+		x, y := MultiScalarMultiply([][2]*big.Int{{vector.X1, vector.Y1}}, []*big.Int{vector.K})
+
+		if !equal(x, vector.X2) || !equal(y, vector.Y2) {
+			t.Errorf(`multi-scalar multiplication failed for vector %d. Got:
+	x: %.64x
+	y: %.64x
+Wanted:
+	x: %.64x
+	y: %.64x
+`, i, x, y, vector.X2, vector.Y2)
+		}
+	}
+}
+
+func TestMultiScalarMultiply_Cancellation(t *testing.T) {
+	k := big.NewInt(12345)
+	negK := new(big.Int).Sub(Secp256k1_CurveOrder, k)
+
+	x, y := MultiScalarMultiply(
+		[][2]*big.Int{
+			{Secp256k1_GeneratorX, Secp256k1_GeneratorY},
+			{Secp256k1_GeneratorX, Secp256k1_GeneratorY},
+		},
+		[]*big.Int{k, negK},
+	)
+	if !EqualAffine(x, y, zero, zero) {
+		t.Errorf("expected k * G - k * G to be the point at infinity")
+	}
+}
+
+func BenchmarkMultiScalarMultiply(b *testing.B) {
+	random := mathrand.New(mathrand.NewSource(1))
+	points := make([][2]*big.Int, 128)
+	scalars := make([]*big.Int, len(points))
+	for i := range points {
+		d, _ := RandomScalar(random)
+		points[i][0], points[i][1] = MultiplyBasePoint(d)
+		scalars[i], _ = RandomScalar(random)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MultiScalarMultiply(points, scalars)
+	}
+}
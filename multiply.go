@@ -7,34 +7,57 @@ import (
 // MultiplyJacobi multiplies the given Jacobian point (x1, y1, z1) by the scalar value k
 // in constant time.
 //
-// It returns the resulting Jacobian point (x2, y2, z2).
+// It returns the resulting Jacobian point (x2, y2, z2). MultiplyJacobi is a wrapper
+// around JacobianPoint.Multiply.
+func MultiplyJacobi(
+	x1, y1, z1 *big.Int,
+	k *big.Int,
+	precomputedTable *PrecomputedTable,
+) (x2, y2, z2 *big.Int) {
+	if !IsOnCurveJacobi(x1, y1, z1) {
+		panic("MultiplyJacobi: refusing to multiply point not on the curve; this could leak private data")
+	}
+
+	p2 := JacobianPoint{x1, y1, z1}.Multiply(k, precomputedTable)
+	return p2.X, p2.Y, p2.Z
+}
+
+// Multiply multiplies the Jacobian point p by the scalar value k in constant time.
 //
 // Callers can construct and pass a PrecomputedTable which massively boosts performance
-// of a MultiplyJacobi call, at the cost of a larger up-front computational investment to
+// of a Multiply call, at the cost of a larger up-front computational investment to
 // build the precomputations. If a you plan to multiply the same point several times,
-// precomputing is definitely worthwhile.
+// precomputing is definitely worthwhile. The table must have been computed for p.
 //
-// If a PrecomputedTable is passed, MultiplyJacobi will use the windowed multiplication
+// If a PrecomputedTable is passed, Multiply will use the windowed multiplication
 // method for fast computation. Otherwise, it will use the Montgomery Ladder algorithm.
 //
-// MultiplyJacobi checks and panics if the given point you are multiplying is not actually
+// Multiply checks and panics if the given point you are multiplying is not actually
 // on the secp256k1 curve, as this could leak private data about the scalar value k.
-func MultiplyJacobi(
-	x1, y1, z1 *big.Int,
-	k *big.Int,
-	precomputedTable PrecomputedTable,
-) (x2, y2, z2 *big.Int) {
-	if !IsOnCurveJacobi(x1, y1, z1) {
-		panic("MultiplyJacobi: refusing to multiply point not on the curve; this could leak private data")
+// If the table is marked as Untrusted, Multiply also panics if the table is not a
+// valid table for p. See PrecomputedTable.Untrusted for details.
+func (p JacobianPoint) Multiply(k *big.Int, precomputedTable *PrecomputedTable) JacobianPoint {
+	if !p.IsOnCurve() {
+		panic("Multiply: refusing to multiply point not on the curve; this could leak private data")
+	}
+
+	if precomputedTable != nil && precomputedTable.Untrusted {
+		if err := precomputedTable.validateUntrusted(p); err != nil {
+			panic("Multiply: refusing to multiply with an invalid precomputed table")
+		}
 	}
 
 	if precomputedTable != nil {
-		return multiplyJacobiTable(x1, y1, z1, k, precomputedTable)
+		x2, y2, z2 := multiplyJacobiTable(p.X, p.Y, p.Z, k, precomputedTable)
+		return JacobianPoint{x2, y2, z2}
+	} else if p.IsInfinity() {
+		return JacobianInfinity()
 	}
 
-	x2 = new(big.Int)
-	y2 = new(big.Int)
-	z2 = new(big.Int)
+	x1, y1, z1 := p.X, p.Y, p.Z
+	x2 := new(big.Int)
+	y2 := new(big.Int)
+	z2 := new(big.Int)
 
 	dummyX := new(big.Int).Set(x1)
 	dummyY := new(big.Int).Set(y1)
@@ -55,25 +78,26 @@ func MultiplyJacobi(
 			x2, y2, z2 = DoubleJacobi(x2, y2, z2)
 		}
 	}
-	return
+	return JacobianPoint{x2, y2, z2}
 }
 
 // multiplyJacobiTable multiplies the given Jacobian point by the scalar value k using
-// the windowed multiplication method, with a window size of 4. This function expects
-// to receive a precomputed multiplication table for lookups of point doubles and the
-// products of point doubles.
+// the windowed multiplication method, with the window width of the table. This function
+// expects to receive a precomputed multiplication table for lookups of point doubles and
+// the products of point doubles. It panics if the table does not have the dimensions
+// implied by its window width.
 func multiplyJacobiTable(
 	x1, y1, z1 *big.Int,
 	k *big.Int,
-	precomputedTable PrecomputedTable,
+	precomputedTable *PrecomputedTable,
 ) (x2, y2, z2 *big.Int) {
-	kBytes := k.FillBytes(make([]byte, 32))
-	windows := make([]byte, 64)
-	for i := range kBytes {
-		windows[i*2] = kBytes[i] >> 4
-		windows[i*2+1] = kBytes[i] & 0b1111
+	if err := precomputedTable.checkDimensions(); err != nil {
+		panic("multiplyJacobiTable: expected table dimensions to match its window width")
 	}
 
+	windows := scalarWindowsOfWidth(k, precomputedTable.Window)
+	points := precomputedTable.Points
+
 	x2 = new(big.Int)
 	y2 = new(big.Int)
 	z2 = new(big.Int)
@@ -82,10 +106,10 @@ func multiplyJacobiTable(
 	dummyY := new(big.Int)
 	dummyZ := new(big.Int)
 
-	rowLen := len(precomputedTable[0])
+	rowLen := len(points[0])
 
 	for i, d := range windows {
-		row := precomputedTable[63-i]
+		row := points[len(windows)-1-i]
 		if d > 0 {
 			point := row[d]
 			x2, y2, z2 = AddJacobi(
@@ -107,6 +131,27 @@ func multiplyJacobiTable(
 	return
 }
 
+// scalarWindowsOfWidth splits the 256-bit scalar k into ceil(256 / width) windows of
+// the given bit width, from most significant to least significant.
+func scalarWindowsOfWidth(k *big.Int, width uint) []uint {
+	kBytes := k.FillBytes(make([]byte, 32))
+	rows, _ := precomputedTableSize(width)
+
+	windows := make([]uint, rows)
+	for i := 0; i < rows; i++ {
+		var d uint
+		for b := uint(0); b < width; b++ {
+			// Index of the bit in k, counting from the least significant bit.
+			bit := uint(i)*width + b
+			if bit < 256 {
+				d |= uint(kBytes[31-bit/8]>>(bit%8)&1) << b
+			}
+		}
+		windows[rows-1-i] = d
+	}
+	return windows
+}
+
 // MultiplyAffine multiplies the given affine point (x1, y1) by the scalar value k in constant time.
 //
 // If a PrecomputedTable is passed, MultiplyAffine will use the windowed multiplication
@@ -119,20 +164,26 @@ func multiplyJacobiTable(
 // build the precomputations. If a you plan to multiply the same point several times,
 // precomputing is definitely worthwhile.
 //
-// MultiplyAffine uses MultiplyJacobi under the hood, as it is about 30% faster than performing affine addition.
+// MultiplyAffine is a wrapper around AffinePoint.Multiply.
 func MultiplyAffine(
 	x1, y1 *big.Int,
 	k *big.Int,
-	precomputedTable PrecomputedTable,
+	precomputedTable *PrecomputedTable,
 ) (x2, y2 *big.Int) {
-	x2, y2, z2 := MultiplyJacobi(
-		x1, y1, one,
-		k,
-		precomputedTable,
-	)
+	p2 := AffinePoint{x1, y1}.Multiply(k, precomputedTable)
+	return p2.X, p2.Y
+}
 
-	ToAffine(x2, y2, z2)
-	return
+// Multiply multiplies the affine point p by the scalar value k in constant time, returning
+// the resulting affine point. See JacobianPoint.Multiply for details on precomputedTable.
+//
+// Multiply uses Jacobian multiplication under the hood, as it is about 30% faster than
+// performing affine addition.
+func (p AffinePoint) Multiply(k *big.Int, precomputedTable *PrecomputedTable) AffinePoint {
+	if !p.IsOnCurve() {
+		panic("Multiply: refusing to multiply point not on the curve; this could leak private data")
+	}
+	return p.ToJacobian().Multiply(k, precomputedTable).ToAffine()
 }
 
 // MultiplyAffineNaive multiplies the given affine point by the scalar value k, using the Montgomery
@@ -168,3 +219,23 @@ func MultiplyAffineNaive(
 	}
 	return
 }
+
+// batchMultiplyAffine multiplies each affine point by the corresponding scalar, normalizing
+// all the products to affine coordinates together with BatchToAffine. It panics if any
+// point is not on the secp256k1 curve.
+func batchMultiplyAffine(points [][2]*big.Int, scalars []*big.Int) [][2]*big.Int {
+	products := make([]JacobianPoint, len(points))
+	for i, point := range points {
+		if !IsOnCurveAffine(point[0], point[1]) {
+			panic("batchMultiplyAffine: refusing to multiply point not on the curve; this could leak private data")
+		}
+		products[i] = AffinePoint{point[0], point[1]}.ToJacobian().Multiply(scalars[i], nil)
+	}
+
+	affine := BatchToAffine(products)
+	results := make([][2]*big.Int, len(affine))
+	for i, p := range affine {
+		results[i] = [2]*big.Int{p.X, p.Y}
+	}
+	return results
+}
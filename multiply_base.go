@@ -2,19 +2,66 @@ package ekliptic
 
 import (
 	"math/big"
+	"sync"
 )
 
-var basePointPrecomputations PrecomputedTable
+var (
+	basePointPrecomputations     *PrecomputedTable
+	basePointPrecomputationsOnce sync.Once
+)
+
+// basePointTable returns the precomputed table for the secp256k1 base point, decoding
+// it from its serialization in basePointTableData the first time it is called. It returns
+// nil if the table was dropped from the build with the ekliptic_nobasetable build tag.
+func basePointTable() *PrecomputedTable {
+	basePointPrecomputationsOnce.Do(func() {
+		if len(basePointTableData) == 0 {
+			return
+		}
+
+		table := new(PrecomputedTable)
+		if err := table.UnmarshalBinary([]byte(basePointTableData)); err != nil {
+			panic("ekliptic: failed to decode precomputed base point table: " + err.Error())
+		}
+		if !table.hasBasePoint(Generator().ToJacobian()) {
+			panic("ekliptic: precomputed base point table does not hold multiples of the base point")
+		}
+		basePointPrecomputations = table
+	})
+	return basePointPrecomputations
+}
+
+// WarmBasePointTable decodes the precomputed table for the secp256k1 base point, if it
+// has not been decoded already. Calling it is never required, but it lets programs pay
+// the one-time cost of decoding the table when they choose, such as at startup, rather
+// than on the first call to MultiplyBasePoint. It is safe to call concurrently.
+//
+// WarmBasePointTable returns false if the table was dropped from the build with the
+// ekliptic_nobasetable build tag.
+func WarmBasePointTable() bool {
+	return basePointTable() != nil
+}
 
 // MultiplyBasePoint multiplies the secp256k1 generator base point by the
 // given integer k, and returns the resulting affine point (x, y). This uses a
 // precomputed table for the secp256k1 base point to speed up multiplications.
+// The table is decoded the first time MultiplyBasePoint is called.
+//
+// If the table was dropped from the build with the ekliptic_nobasetable build
+// tag, MultiplyBasePoint uses the slower Montgomery ladder instead. If a comb
+// table was configured with SetBasePointCombTable, MultiplyBasePoint uses the
+// comb method instead of either.
 //
 // This function is used to derive the public key for a private key k, among other uses.
 func MultiplyBasePoint(k *big.Int) (x, y *big.Int) {
+	if comb := basePointCombTable(); comb != nil {
+		p := comb.Multiply(k).ToAffine()
+		return p.X, p.Y
+	}
+
 	return MultiplyAffine(
 		Secp256k1_GeneratorX, Secp256k1_GeneratorY,
 		k,
-		basePointPrecomputations,
+		basePointTable(),
 	)
 }
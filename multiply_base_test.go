@@ -1,6 +1,7 @@
 package ekliptic
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/kklash/ekliptic/test_vectors"
@@ -27,6 +28,26 @@ Wanted:
 	}
 }
 
+func TestWarmBasePointTable(t *testing.T) {
+	hasTable := len(basePointTableData) > 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if WarmBasePointTable() != hasTable {
+				t.Errorf("expected WarmBasePointTable to return %v", hasTable)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if (basePointTable() != nil) != hasTable {
+		t.Errorf("unexpected base point table after warming")
+	}
+}
+
 func BenchmarkMultiplyBasePoint(b *testing.B) {
 	vector := test_vectors.AffineMultiplicationVectors[0]
 
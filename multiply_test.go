@@ -1,7 +1,9 @@
 package ekliptic
 
 import (
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/kklash/ekliptic/test_vectors"
@@ -50,6 +52,65 @@ Wanted:
 	}
 }
 
+func TestMultiplyJacobi_PrecomputedWindow(t *testing.T) {
+	vectors := test_vectors.AffineMultiplicationVectors[:4]
+
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		for i, vector := range vectors {
+			table := NewPrecomputedTableWindow(vector.X1, vector.Y1, window)
+			resultX, resultY := MultiplyAffine(vector.X1, vector.Y1, vector.K, table)
+
+			if !equal(resultX, vector.X2) || !equal(resultY, vector.Y2) {
+				t.Errorf("precomputed multiplication with window %d failed for vector %d", window, i)
+			}
+		}
+	}
+}
+
+func TestMultiplyJacobi_PrecomputedMalformed(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+	k := hexint("deadbeef")
+
+	tables := map[string]func() *PrecomputedTable{
+		"zero window": func() *PrecomputedTable {
+			table := NewPrecomputedTable(x, y)
+			table.Window = 0
+			return table
+		},
+		"window too wide": func() *PrecomputedTable {
+			table := NewPrecomputedTable(x, y)
+			table.Window = MaxPrecomputedWindow + 1
+			return table
+		},
+		"mismatched window": func() *PrecomputedTable {
+			table := NewPrecomputedTable(x, y)
+			table.Window = DefaultPrecomputedWindow + 1
+			return table
+		},
+		"missing row": func() *PrecomputedTable {
+			table := NewPrecomputedTable(x, y)
+			table.Points = table.Points[1:]
+			return table
+		},
+		"short row": func() *PrecomputedTable {
+			table := NewPrecomputedTable(x, y)
+			table.Points[5] = table.Points[5][:3]
+			return table
+		},
+	}
+
+	for name, newTable := range tables {
+		func() {
+			defer func() {
+				if msg, ok := recover().(string); !ok || !strings.HasPrefix(msg, "multiplyJacobiTable:") {
+					t.Errorf("%s: expected multiplyJacobiTable to panic", name)
+				}
+			}()
+			MultiplyAffine(x, y, k, newTable())
+		}()
+	}
+}
+
 func TestMultiplyJacobi_MemSafety(t *testing.T) {
 	// inputs and outputs are the same pointers
 	for i, vector := range test_vectors.AffineMultiplicationVectors {
@@ -149,6 +210,26 @@ func BenchmarkMultiplyJacobi_Precomputed(b *testing.B) {
 	}
 }
 
+func BenchmarkMultiplyJacobi_PrecomputedWindow(b *testing.B) {
+	vector := test_vectors.AffineMultiplicationVectors[0]
+
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		precomputes := NewPrecomputedTableWindow(vector.X1, vector.Y1, window)
+		rows, cols := precomputedTableSize(window)
+
+		b.Run(fmt.Sprintf("window=%d", window), func(b *testing.B) {
+			b.ReportMetric(float64(rows*cols), "table-points")
+			for i := 0; i < b.N; i++ {
+				MultiplyJacobi(
+					vector.X1, vector.Y1, one,
+					vector.K,
+					precomputes,
+				)
+			}
+		})
+	}
+}
+
 func BenchmarkMultiplyAffine(b *testing.B) {
 	vector := test_vectors.AffineMultiplicationVectors[0]
 
@@ -14,3 +14,13 @@ func Negate(y *big.Int) *big.Int {
 	}
 	return new(big.Int).Sub(Secp256k1_P, y)
 }
+
+// Negate returns the additive inverse -P of the affine point P.
+func (p AffinePoint) Negate() AffinePoint {
+	return AffinePoint{new(big.Int).Set(p.X), Negate(p.Y)}
+}
+
+// Negate returns the additive inverse -P of the Jacobian point P.
+func (p JacobianPoint) Negate() JacobianPoint {
+	return JacobianPoint{new(big.Int).Set(p.X), Negate(p.Y), new(big.Int).Set(p.Z)}
+}
@@ -0,0 +1,156 @@
+package ekliptic
+
+import (
+	"math/big"
+)
+
+var (
+	// PedersenGeneratorHX and PedersenGeneratorHY describe the secondary generator point H
+	// used for Pedersen commitments. This is the same generator used by Elements and
+	// libsecp256k1-zkp. Its x-coordinate is the SHA256 hash of the uncompressed encoding
+	// of the secp256k1 generator point G, so nobody knows the discrete log of H with
+	// respect to G (nothing up my sleeve).
+	PedersenGeneratorHX = hexint("50929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0")
+	PedersenGeneratorHY = hexint("31d3c6863973926e049e637cb1b5f40a36dac28af1766968c30c2313f3a38904")
+)
+
+// PedersenCommitmentSize is the length of a serialized PedersenCommitment.
+const PedersenCommitmentSize = 33
+
+// PedersenCommitment is a perfectly hiding and computationally binding commitment C
+// to a value v using a secret blinding factor r:
+//
+//	C = v * H + r * G
+//
+// Commitments are additively homomorphic, so the sum of two commitments is a commitment
+// to the sum of their values, blinded by the sum of their blinding factors:
+//
+//	C1 + C2 = (v1 + v2) * H + (r1 + r2) * G
+//
+// The affine point (X, Y) is (0, 0) if the commitment is the point at infinity.
+type PedersenCommitment struct {
+	X, Y *big.Int
+}
+
+// CommitPedersen computes the PedersenCommitment to the given value, blinded by the
+// given blinding factor. Both the value and blinding factor are interpreted modulo
+// Secp256k1_CurveOrder. The blinding factor should be a random scalar.
+func CommitPedersen(value, blindingFactor *big.Int) *PedersenCommitment {
+	v := new(big.Int).Mod(value, Secp256k1_CurveOrder)
+	r := new(big.Int).Mod(blindingFactor, Secp256k1_CurveOrder)
+
+	vh := AffinePoint{PedersenGeneratorHX, PedersenGeneratorHY}.ToJacobian().Multiply(v, nil)
+	rgX, rgY := MultiplyBasePoint(r)
+
+	// r * G is infinity if r = 0.
+	commitment := vh.Add(AffinePoint{rgX, rgY}.ToJacobian()).ToAffine()
+
+	return &PedersenCommitment{X: commitment.X, Y: commitment.Y}
+}
+
+// Add returns the sum of two commitments, committing to the sum of their values.
+func (c *PedersenCommitment) Add(other *PedersenCommitment) *PedersenCommitment {
+	x, y := AddAffine(c.X, c.Y, other.X, other.Y)
+	return &PedersenCommitment{X: x, Y: y}
+}
+
+// Sub returns the difference of two commitments, committing to the difference of their values.
+func (c *PedersenCommitment) Sub(other *PedersenCommitment) *PedersenCommitment {
+	x, y := SubAffine(c.X, c.Y, other.X, other.Y)
+	return &PedersenCommitment{X: x, Y: y}
+}
+
+// Equal returns true if both commitments are the same point.
+func (c *PedersenCommitment) Equal(other *PedersenCommitment) bool {
+	return EqualAffine(c.X, c.Y, other.X, other.Y)
+}
+
+// Open returns true if the commitment commits to the given value and blinding factor.
+func (c *PedersenCommitment) Open(value, blindingFactor *big.Int) bool {
+	return c.Equal(CommitPedersen(value, blindingFactor))
+}
+
+// MarshalBinary serializes the commitment in the 33-byte format used by Elements and
+// libsecp256k1-zkp: a prefix byte of 0x08 if the y-coordinate is a quadratic residue
+// modulo P, or 0x09 otherwise, followed by the 32-byte x-coordinate.
+//
+// The point at infinity cannot be serialized, and returns ErrInvalidPointEncoding.
+func (c *PedersenCommitment) MarshalBinary() ([]byte, error) {
+	if EqualAffine(c.X, c.Y, zero, zero) {
+		return nil, ErrInvalidPointEncoding
+	}
+
+	buf := make([]byte, PedersenCommitmentSize)
+	buf[0] = 0x09
+	if sqrtCoordinate(c.Y) != nil {
+		buf[0] = 0x08
+	}
+	c.X.FillBytes(buf[1:])
+	return buf, nil
+}
+
+// UnmarshalBinary parses a 33-byte commitment serialized by MarshalBinary.
+func (c *PedersenCommitment) UnmarshalBinary(data []byte) error {
+	if len(data) != PedersenCommitmentSize || (data[0] != 0x08 && data[0] != 0x09) {
+		return ErrInvalidPointEncoding
+	}
+
+	x := new(big.Int).SetBytes(data[1:])
+	if equal(x, zero) {
+		return ErrInvalidPointEncoding
+	}
+
+	y, _ := Weierstrass(x)
+	if y == nil {
+		return ErrInvalidPointEncoding
+	}
+
+	// Exactly one of y and -y is a quadratic residue, because -1 is not.
+	if (sqrtCoordinate(y) != nil) != (data[0] == 0x08) {
+		y = Negate(y)
+	}
+
+	c.X, c.Y = x, y
+	return nil
+}
+
+// SumBlindingFactors returns the sum of the positive blinding factors, minus the sum
+// of the negative blinding factors, modulo Secp256k1_CurveOrder. The result is the
+// blinding factor of the commitment:
+//
+//	sum(positive commitments) - sum(negative commitments)
+func SumBlindingFactors(positive, negative []*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, r := range positive {
+		sum.Add(sum, r)
+	}
+	for _, r := range negative {
+		sum.Sub(sum, r)
+	}
+	modScalar(sum)
+	return sum
+}
+
+// BalancingBlindingFactor returns the blinding factor which must be used for the final
+// output commitment, so that the sum of the input commitments equals the sum of the
+// output commitments, provided the values also balance:
+//
+//	r_final = sum(input blinding factors) - sum(other output blinding factors)
+func BalancingBlindingFactor(inputs, outputs []*big.Int) *big.Int {
+	return SumBlindingFactors(inputs, outputs)
+}
+
+// VerifyCommitmentSum returns true if the sum of the positive commitments equals
+// the sum of the negative commitments. Together with range proofs on each output,
+// this proves that no value was created or destroyed.
+func VerifyCommitmentSum(positive, negative []*PedersenCommitment) bool {
+	// Commitments may be the point at infinity, so they must be converted with ToJacobian.
+	sum := JacobianInfinity()
+	for _, c := range positive {
+		sum = sum.Add(AffinePoint{c.X, c.Y}.ToJacobian())
+	}
+	for _, c := range negative {
+		sum = sum.Sub(AffinePoint{c.X, c.Y}.ToJacobian())
+	}
+	return sum.IsInfinity()
+}
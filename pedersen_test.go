@@ -0,0 +1,143 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestPedersenGeneratorH(t *testing.T) {
+	uncompressedG := make([]byte, 65)
+	uncompressedG[0] = 0x04
+	Secp256k1_GeneratorX.FillBytes(uncompressedG[1:33])
+	Secp256k1_GeneratorY.FillBytes(uncompressedG[33:])
+
+	hash := sha256.Sum256(uncompressedG)
+	if !equal(new(big.Int).SetBytes(hash[:]), PedersenGeneratorHX) {
+		t.Errorf("expected H to be derived from the hash of G")
+	}
+	if !IsOnCurveAffine(PedersenGeneratorHX, PedersenGeneratorHY) {
+		t.Errorf("expected H to be on the curve")
+	}
+
+	serialized, err := CommitPedersen(one, zero).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to serialize commitment: %s", err)
+	}
+	expected := "0950929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0"
+	if hex.EncodeToString(serialized) != expected {
+		t.Errorf("unexpected serialization of H\nGot:    %x\nWanted: %s", serialized, expected)
+	}
+}
+
+func TestPedersenCommitment(t *testing.T) {
+	v1, v2 := big.NewInt(4000), big.NewInt(2500)
+	r1, _ := RandomScalar(rand.Reader)
+	r2, _ := RandomScalar(rand.Reader)
+
+	c1 := CommitPedersen(v1, r1)
+	c2 := CommitPedersen(v2, r2)
+
+	if !c1.Open(v1, r1) {
+		t.Errorf("failed to open commitment")
+	}
+	if c1.Open(v2, r1) || c1.Open(v1, r2) {
+		t.Errorf("opened commitment with the wrong value or blinding factor")
+	}
+
+	sum := c1.Add(c2)
+	if !sum.Open(new(big.Int).Add(v1, v2), SumBlindingFactors([]*big.Int{r1, r2}, nil)) {
+		t.Errorf("expected sum of commitments to commit to the sum of values")
+	}
+
+	diff := c1.Sub(c2)
+	if !diff.Open(new(big.Int).Sub(v1, v2), SumBlindingFactors([]*big.Int{r1}, []*big.Int{r2})) {
+		t.Errorf("expected difference of commitments to commit to the difference of values")
+	}
+
+	if !diff.Add(c2).Equal(c1) {
+		t.Errorf("expected (c1 - c2) + c2 == c1")
+	}
+
+	for i, c := range []*PedersenCommitment{c1, c2, sum, diff} {
+		serialized, err := c.MarshalBinary()
+		if err != nil {
+			t.Errorf("failed to serialize commitment %d: %s", i, err)
+			continue
+		}
+
+		parsed := new(PedersenCommitment)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse commitment %d: %s", i, err)
+			continue
+		}
+		if !parsed.Equal(c) {
+			t.Errorf("commitment %d serialization round trip failed", i)
+		}
+	}
+}
+
+func TestVerifyCommitmentSum(t *testing.T) {
+	inputValues := []*big.Int{big.NewInt(10), big.NewInt(25)}
+	outputValues := []*big.Int{big.NewInt(5), big.NewInt(12), big.NewInt(18)}
+
+	inputBlinds := make([]*big.Int, len(inputValues))
+	inputs := make([]*PedersenCommitment, len(inputValues))
+	for i, v := range inputValues {
+		inputBlinds[i], _ = RandomScalar(rand.Reader)
+		inputs[i] = CommitPedersen(v, inputBlinds[i])
+	}
+
+	outputBlinds := make([]*big.Int, len(outputValues))
+	outputs := make([]*PedersenCommitment, len(outputValues))
+	for i, v := range outputValues {
+		if i == len(outputValues)-1 {
+			outputBlinds[i] = BalancingBlindingFactor(inputBlinds, outputBlinds[:i])
+		} else {
+			outputBlinds[i], _ = RandomScalar(rand.Reader)
+		}
+		outputs[i] = CommitPedersen(v, outputBlinds[i])
+	}
+
+	if !VerifyCommitmentSum(inputs, outputs) {
+		t.Errorf("expected balanced commitments to verify")
+	}
+
+	outputs[0] = CommitPedersen(big.NewInt(6), outputBlinds[0])
+	if VerifyCommitmentSum(inputs, outputs) {
+		t.Errorf("expected commitments creating value to fail verification")
+	}
+}
+
+func TestPedersenCommitment_UnmarshalBinary(t *testing.T) {
+	invalid := [][]byte{
+		nil,
+		make([]byte, 33),
+		append([]byte{0x02}, PedersenGeneratorHX.Bytes()...),
+		append([]byte{0x08}, make([]byte, 32)...),
+		append([]byte{0x08}, Secp256k1_P.Bytes()...),
+	}
+
+	for _, data := range invalid {
+		if err := new(PedersenCommitment).UnmarshalBinary(data); err != ErrInvalidPointEncoding {
+			t.Errorf("expected ErrInvalidPointEncoding when parsing %x; got %v", data, err)
+		}
+	}
+
+	infinity := &PedersenCommitment{X: new(big.Int), Y: new(big.Int)}
+	if _, err := infinity.MarshalBinary(); err != ErrInvalidPointEncoding {
+		t.Errorf("expected ErrInvalidPointEncoding when serializing infinity; got %v", err)
+	}
+}
+
+func BenchmarkCommitPedersen(b *testing.B) {
+	v := big.NewInt(123456789)
+	r, _ := RandomScalar(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CommitPedersen(v, r)
+	}
+}
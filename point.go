@@ -0,0 +1,63 @@
+package ekliptic
+
+import "math/big"
+
+// AffinePoint is a point (X, Y) on the secp256k1 curve in affine coordinates.
+//
+// The point at infinity is represented as (0, 0). This is unambiguous, because
+// (0, 0) does not satisfy the curve equation y² = x³ + 7. Use AffineInfinity
+// to construct it, and IsInfinity to test for it.
+//
+// Methods on AffinePoint never modify their receivers or arguments, and always
+// return newly allocated points.
+type AffinePoint struct {
+	X, Y *big.Int
+}
+
+// JacobianPoint is a point (X, Y, Z) on the secp256k1 curve in Jacobian coordinates,
+// representing the affine point (X / Z², Y / Z³).
+//
+// The point at infinity is any point with Z = 0. Use JacobianInfinity to construct
+// the canonical form (0, 0, 0), and IsInfinity to test for it.
+//
+// Methods on JacobianPoint never modify their receivers or arguments, and always
+// return newly allocated points.
+type JacobianPoint struct {
+	X, Y, Z *big.Int
+}
+
+// AffineInfinity returns the affine point at infinity, (0, 0).
+func AffineInfinity() AffinePoint {
+	return AffinePoint{new(big.Int), new(big.Int)}
+}
+
+// JacobianInfinity returns the Jacobian point at infinity, (0, 0, 0).
+func JacobianInfinity() JacobianPoint {
+	return JacobianPoint{new(big.Int), new(big.Int), new(big.Int)}
+}
+
+// Generator returns the secp256k1 generator point G.
+func Generator() AffinePoint {
+	return AffinePoint{
+		new(big.Int).Set(Secp256k1_GeneratorX),
+		new(big.Int).Set(Secp256k1_GeneratorY),
+	}
+}
+
+// IsInfinity returns true if p is the point at infinity.
+func (p AffinePoint) IsInfinity() bool {
+	return equal(p.X, zero) && equal(p.Y, zero)
+}
+
+// IsInfinity returns true if p is the point at infinity, i.e. if Z = 0.
+func (p JacobianPoint) IsInfinity() bool {
+	return equal(p.Z, zero)
+}
+
+func (p AffinePoint) clone() AffinePoint {
+	return AffinePoint{new(big.Int).Set(p.X), new(big.Int).Set(p.Y)}
+}
+
+func (p JacobianPoint) clone() JacobianPoint {
+	return JacobianPoint{new(big.Int).Set(p.X), new(big.Int).Set(p.Y), new(big.Int).Set(p.Z)}
+}
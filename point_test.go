@@ -0,0 +1,173 @@
+package ekliptic
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestJacobianPoint_Add(t *testing.T) {
+	for i, vector := range test_vectors.JacobiAdditionVectors {
+		p1 := JacobianPoint{vector.X1, vector.Y1, vector.Z1}
+		p2 := JacobianPoint{vector.X2, vector.Y2, vector.Z2}
+		expected := JacobianPoint{vector.X3, vector.Y3, vector.Z3}
+
+		if p3 := p1.Add(p2); !equal(p3.X, expected.X) || !equal(p3.Y, expected.Y) || !equal(p3.Z, expected.Z) {
+			t.Errorf("jacobi point addition method failed for vector %d", i)
+		}
+		if p3 := p1.Sub(p2.Negate()); !p3.Equal(expected) {
+			t.Errorf("jacobi point subtraction method failed for vector %d", i)
+		}
+		if !p1.IsOnCurve() || !p2.IsOnCurve() || !expected.IsOnCurve() {
+			t.Errorf("expected points of vector %d to be on the curve", i)
+		}
+	}
+}
+
+func TestJacobianPoint_Double(t *testing.T) {
+	for i, vector := range test_vectors.JacobiDoublingVectors {
+		p := JacobianPoint{vector.X1, vector.Y1, vector.Z1}
+		expected := JacobianPoint{vector.X3, vector.Y3, vector.Z3}
+
+		if doubled := p.Double(); !equal(doubled.X, expected.X) || !equal(doubled.Y, expected.Y) || !equal(doubled.Z, expected.Z) {
+			t.Errorf("jacobi point doubling method failed for vector %d", i)
+		}
+		if sum := p.Add(p); !sum.Equal(expected) {
+			t.Errorf("adding point to itself did not match doubling for vector %d", i)
+		}
+	}
+}
+
+func TestAffinePoint_Multiply(t *testing.T) {
+	for i, vector := range test_vectors.AffineMultiplicationVectors {
+		p := AffinePoint{vector.X1, vector.Y1}
+		expected := AffinePoint{vector.X2, vector.Y2}
+
+		if product := p.Multiply(vector.K, nil); !product.Equal(expected) {
+			t.Errorf("affine multiplication method failed for vector %d", i)
+		}
+		if product := p.ToJacobian().Multiply(vector.K, nil).ToAffine(); !product.Equal(expected) {
+			t.Errorf("jacobi multiplication method failed for vector %d", i)
+		}
+	}
+}
+
+func TestAffinePoint_Add(t *testing.T) {
+	g := Generator()
+	g2 := g.Double()
+	g3 := g2.Add(g)
+
+	if expected := g.Multiply(three, nil); !g3.Equal(expected) {
+		t.Errorf("affine addition method does not match multiplication")
+	}
+	if !g3.Sub(g2).Equal(g) {
+		t.Errorf("affine subtraction method does not reverse addition")
+	}
+	if !g3.IsOnCurve() || !g3.Negate().IsOnCurve() {
+		t.Errorf("expected affine points to be on the curve")
+	}
+	if !g.ToJacobian().Add(g2.ToJacobian()).ToAffine().Equal(g3) {
+		t.Errorf("jacobi addition does not match affine addition")
+	}
+}
+
+func TestPoint_Infinity(t *testing.T) {
+	g := Generator()
+	gj := g.ToJacobian()
+
+	if !AffineInfinity().IsInfinity() || !JacobianInfinity().IsInfinity() {
+		t.Fatalf("expected infinity constructors to return the point at infinity")
+	}
+	if g.IsInfinity() || gj.IsInfinity() {
+		t.Fatalf("expected generator not to be the point at infinity")
+	}
+
+	// Any Jacobian point with Z = 0 is the point at infinity.
+	other := JacobianPoint{big.NewInt(4), big.NewInt(8), big.NewInt(0)}
+	if !other.IsInfinity() || !other.Equal(JacobianInfinity()) {
+		t.Errorf("expected point with z = 0 to equal the canonical point at infinity")
+	}
+	if other.Equal(gj) || gj.Equal(other) {
+		t.Errorf("expected point at infinity not to equal the generator")
+	}
+
+	if !AffineInfinity().IsOnCurve() || !JacobianInfinity().IsOnCurve() || !other.IsOnCurve() {
+		t.Errorf("expected point at infinity to be considered on the curve")
+	}
+
+	if !g.Add(g.Negate()).IsInfinity() {
+		t.Errorf("expected P + -P to be infinity in affine coordinates")
+	}
+	if !gj.Add(gj.Negate()).IsInfinity() || !gj.Sub(gj).IsInfinity() {
+		t.Errorf("expected P + -P to be infinity in Jacobian coordinates")
+	}
+
+	if !g.Add(AffineInfinity()).Equal(g) || !AffineInfinity().Add(g).Equal(g) {
+		t.Errorf("expected P + infinity = P in affine coordinates")
+	}
+	if !gj.Add(other).Equal(gj) || !other.Add(gj).Equal(gj) {
+		t.Errorf("expected P + infinity = P in Jacobian coordinates")
+	}
+
+	if !other.Double().IsInfinity() || !AffineInfinity().Double().IsInfinity() {
+		t.Errorf("expected doubling infinity to yield infinity")
+	}
+
+	if !other.ToAffine().IsInfinity() || !AffineInfinity().ToJacobian().IsInfinity() {
+		t.Errorf("expected conversion to preserve the point at infinity")
+	}
+
+	if !g.Multiply(zero, nil).IsInfinity() || !g.Multiply(Secp256k1_CurveOrder, nil).IsInfinity() {
+		t.Errorf("expected multiplication by 0 or n to yield infinity")
+	}
+	if !other.Multiply(big.NewInt(12345), nil).IsInfinity() {
+		t.Errorf("expected multiplying infinity to yield infinity")
+	}
+}
+
+func TestPoint_MemSafety(t *testing.T) {
+	g := Generator()
+	gj := g.ToJacobian()
+	h := g.Double()
+	hj := h.ToJacobian()
+
+	g.Add(h)
+	g.Sub(h)
+	g.Double()
+	g.Negate()
+	g.Multiply(big.NewInt(7), nil)
+	gj.Add(hj)
+	gj.Sub(hj)
+	gj.Double()
+	gj.Negate()
+	gj.Multiply(big.NewInt(7), nil)
+	gj.Add(hj).ToAffine()
+
+	if !g.Equal(Generator()) || !gj.Equal(Generator().ToJacobian()) || !equal(gj.Z, one) {
+		t.Errorf("point methods modified their receivers")
+	}
+	if !h.Equal(Generator().Double()) || !hj.Equal(Generator().Double().ToJacobian()) {
+		t.Errorf("point methods modified their arguments")
+	}
+}
+
+func TestJacobianPoint_Multiply_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic when multiplying a point not on the curve")
+		}
+	}()
+
+	JacobianPoint{one, two, one}.Multiply(three, nil)
+}
+
+func BenchmarkJacobianPoint_Add(b *testing.B) {
+	vector := test_vectors.JacobiAdditionVectors[0]
+	p1 := JacobianPoint{vector.X1, vector.Y1, vector.Z1}
+	p2 := JacobianPoint{vector.X2, vector.Y2, vector.Z2}
+
+	for i := 0; i < b.N; i++ {
+		p1.Add(p2)
+	}
+}
@@ -1,57 +1,376 @@
 package ekliptic
 
-import "math/big"
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
 
 //go:generate go run ./genprecompute -o precomputed_table.go
 
-// PrecomputedTable is a table in the form of a 2d slice, holding precomputed
-// multiplications of a point P. Each entry in the table is the product of P and
-// some multiple of a power of 2.
+// ErrInvalidPrecomputedTable is returned when a PrecomputedTable is malformed, or when
+// its entries are not consistent with one another.
+var ErrInvalidPrecomputedTable = errors.New("ekliptic: invalid precomputed table")
+
+const (
+	// MinPrecomputedWindow is the smallest window width supported by PrecomputedTable.
+	MinPrecomputedWindow = 2
+
+	// MaxPrecomputedWindow is the largest window width supported by PrecomputedTable.
+	MaxPrecomputedWindow = 8
+
+	// DefaultPrecomputedWindow is the window width used by NewPrecomputedTable,
+	// and by the precomputed table for the secp256k1 base point.
+	DefaultPrecomputedWindow = 4
+)
+
+// PrecomputedTable is a table holding precomputed multiplications of a point P,
+// for use with the windowed multiplication method. Each entry in the table is the
+// product of P and some multiple of a power of 2.
 //
-// The table is laid out like this:
+// With a window width w, the table is laid out like this:
 //
-//	      0 1 2 3 4 ... 15
-//	-----------------------
-//	2^0  |* * * * * ...  *
-//	2^4  |* * * * * ...  *
-//	2^8  |* * * * * ...  *
-//	2^16 |* * * * * ...  *
-//	...  |* * * * * ...  *
-//	2^252|* * * * * ...  *
+//	         0 1 2 3 4 ... 2^w-1
+//	----------------------------
+//	2^0     |* * * * * ...     *
+//	2^w     |* * * * * ...     *
+//	2^(2w)  |* * * * * ...     *
+//	2^(3w)  |* * * * * ...     *
+//	...     |* * * * * ...     *
 //
-// Each row i of the table holds the multiplications of the point, doubled 4i times.
-// i should be less than 64.
+// Each row i of the table holds the multiplications of the point, doubled w*i times.
+// There are ceil(256 / w) rows, enough to cover any 256-bit scalar.
 //
 // Each column j of the table holds the doublings of the point, multiplied j times.
-// j should be less than 16.
+// There are 2^w columns.
 //
-// Indexing the table like so:
+// Indexing the table's points like so:
 //
-//	table[i][j]
+//	table.Points[i][j]
 //
 // ...Looks up the following precomputed point multiplication:
 //
-//	2^(4i) * j * P
-type PrecomputedTable [][][2]*big.Int
+//	2^(wi) * j * P
+//
+// Larger windows make multiplications faster, as one point addition is performed
+// per row, at the cost of exponentially more memory and a longer precomputation.
+type PrecomputedTable struct {
+	// Window is the width w of the windows in bits, in the range
+	// [MinPrecomputedWindow, MaxPrecomputedWindow].
+	Window uint
+
+	// Points holds the affine points of the table, indexed by row and column.
+	Points [][][2]*big.Int
+
+	// Untrusted marks a table obtained from a source which is not trusted, such as a file
+	// or the network. Before multiplying with an untrusted table, multiplication functions
+	// check that the table's base point is the point being multiplied, and validate the
+	// whole table the first time it is used. They panic if either check fails.
+	//
+	// Tables are only fully validated once, so an untrusted table must not be modified
+	// after it has been used.
+	Untrusted bool
+
+	// validated is set atomically to 1 once the table has been fully validated.
+	validated uint32
+}
+
+// precomputedTableSize returns the number of rows and columns in a PrecomputedTable
+// with the given window width, which must be in the range [MinPrecomputedWindow,
+// MaxPrecomputedWindow].
+func precomputedTableSize(window uint) (rows, cols int) {
+	rows = int((256 + window - 1) / window)
+	cols = 1 << window
+	return
+}
 
 // NewPrecomputedTable computes a PrecomputedTable used for speeding up multiplications
-// of a fixed affine point (x, y).
-func NewPrecomputedTable(x, y *big.Int) PrecomputedTable {
-	table := make(PrecomputedTable, 64)
-	for i := range table {
-		table[i] = make([][2]*big.Int, 16)
-		table[i][0] = [2]*big.Int{new(big.Int), new(big.Int)}
-		for j := 1; j < len(table[i]); j++ {
-			table[i][j][0], table[i][j][1] = AddAffine(
-				table[i][j-1][0], table[i][j-1][1],
-				x, y,
-			)
-		}
-		if i+1 < len(table) {
-			for k := 0; k < 4; k++ {
-				x, y = DoubleAffine(x, y)
-			}
+// of a fixed affine point (x, y), with the default window width of 4 bits.
+//
+// The table entries are computed in Jacobian coordinates, and then normalized to affine
+// coordinates all at once with BatchToAffine, so that building the table requires only
+// a single modular inversion.
+func NewPrecomputedTable(x, y *big.Int) *PrecomputedTable {
+	return NewPrecomputedTableWindow(x, y, DefaultPrecomputedWindow)
+}
+
+// NewPrecomputedTableWindow is like NewPrecomputedTable, but computes a table with the
+// given window width, which must be in the range [MinPrecomputedWindow, MaxPrecomputedWindow].
+func NewPrecomputedTableWindow(x, y *big.Int, window uint) *PrecomputedTable {
+	if window < MinPrecomputedWindow || window > MaxPrecomputedWindow {
+		panic("NewPrecomputedTableWindow: expected window to be in range [MinPrecomputedWindow, MaxPrecomputedWindow]")
+	}
+
+	rows, _ := precomputedTableSize(window)
+	rowBases := precomputedRowBases(x, y, window, rows)
+	table := &PrecomputedTable{
+		Window: window,
+		Points: make([][][2]*big.Int, rows),
+	}
+	fillPrecomputedRows(table, rowBases, 0, rows)
+	return table
+}
+
+// NewPrecomputedTableParallel is like NewPrecomputedTableWindow, but fills the rows of the
+// table concurrently across the given number of goroutines. If workers is zero or negative,
+// runtime.GOMAXPROCS(0) is used. The resulting table is identical to the one returned by
+// NewPrecomputedTableWindow.
+//
+// Each goroutine normalizes its own rows with BatchToAffine, so building the table
+// requires one modular inversion per goroutine.
+func NewPrecomputedTableParallel(x, y *big.Int, window uint, workers int) *PrecomputedTable {
+	if window < MinPrecomputedWindow || window > MaxPrecomputedWindow {
+		panic("NewPrecomputedTableParallel: expected window to be in range [MinPrecomputedWindow, MaxPrecomputedWindow]")
+	}
+
+	rows, _ := precomputedTableSize(window)
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > rows {
+		workers = rows
+	}
+	if workers <= 1 {
+		return NewPrecomputedTableWindow(x, y, window)
+	}
+
+	// The row bases form a chain of doublings, so they must be computed sequentially.
+	// This is cheap compared to filling the rows.
+	rowBases := precomputedRowBases(x, y, window, rows)
+	table := &PrecomputedTable{
+		Window: window,
+		Points: make([][][2]*big.Int, rows),
+	}
+	chunkSize := (rows + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < rows; start += chunkSize {
+		end := start + chunkSize
+		if end > rows {
+			end = rows
 		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fillPrecomputedRows(table, rowBases, start, end)
+		}(start, end)
 	}
+	wg.Wait()
+
 	return table
 }
+
+// precomputedRowBases returns the Jacobian points 2^(wi) * P for each row i of a table.
+func precomputedRowBases(x, y *big.Int, window uint, rows int) []JacobianPoint {
+	rowBases := make([]JacobianPoint, rows)
+	rowBases[0] = AffinePoint{x, y}.ToJacobian()
+	for i := 1; i < rows; i++ {
+		rowBase := rowBases[i-1]
+		for k := uint(0); k < window; k++ {
+			rowBase = rowBase.Double()
+		}
+		rowBases[i] = rowBase
+	}
+	return rowBases
+}
+
+// fillPrecomputedRows computes the rows [start, end) of table from their row bases,
+// normalizing all of their entries to affine coordinates with a single BatchToAffine call.
+func fillPrecomputedRows(table *PrecomputedTable, rowBases []JacobianPoint, start, end int) {
+	_, cols := precomputedTableSize(table.Window)
+
+	// Entries 1..cols-1 of each row, flattened in row order.
+	entries := make([]JacobianPoint, (end-start)*(cols-1))
+	for i := start; i < end; i++ {
+		// row[j-1] holds j * rowBases[i]. Even multiples are computed by doubling,
+		// which is cheaper than adding the row base once more.
+		row := entries[(i-start)*(cols-1) : (i-start+1)*(cols-1)]
+		row[0] = rowBases[i]
+		for j := 2; j < cols; j++ {
+			if j%2 == 0 {
+				row[j-1] = row[j/2-1].Double()
+			} else {
+				row[j-1] = row[j-2].Add(rowBases[i])
+			}
+		}
+	}
+
+	affine := BatchToAffine(entries)
+
+	for i := start; i < end; i++ {
+		row := make([][2]*big.Int, cols)
+		row[0] = [2]*big.Int{new(big.Int), new(big.Int)}
+		for j := 1; j < cols; j++ {
+			p := affine[(i-start)*(cols-1)+j-1]
+			row[j] = [2]*big.Int{p.X, p.Y}
+		}
+		table.Points[i] = row
+	}
+}
+
+// Validate checks that the table is a valid PrecomputedTable for the affine point (x, y).
+// It returns ErrInvalidPrecomputedTable if the table does not have the dimensions implied
+// by its window width, if the table's base point Points[0][1] is not (x, y), or if any
+// entry of the table is not the correct multiple of (x, y).
+//
+// Validate checks every entry of the table without recomputing it, so it is several times
+// faster than building the table with NewPrecomputedTableWindow.
+func (table *PrecomputedTable) Validate(x, y *big.Int) error {
+	if err := table.check(); err != nil {
+		return err
+	}
+	if !table.hasBasePoint(AffinePoint{x, y}.ToJacobian()) {
+		return ErrInvalidPrecomputedTable
+	}
+	return nil
+}
+
+// validateUntrusted is used to check an untrusted table before multiplying the point p
+// with it. The table is fully validated on first use, and on every use its base point
+// is checked to be p.
+func (table *PrecomputedTable) validateUntrusted(p JacobianPoint) error {
+	if atomic.LoadUint32(&table.validated) == 0 {
+		if err := table.check(); err != nil {
+			return err
+		}
+		atomic.StoreUint32(&table.validated, 1)
+	}
+
+	if !table.hasBasePoint(p) {
+		return ErrInvalidPrecomputedTable
+	}
+	return nil
+}
+
+// hasBasePoint returns true if the table's base point Points[0][1] is p. The
+// table must have the dimensions implied by its window width.
+func (table *PrecomputedTable) hasBasePoint(p JacobianPoint) bool {
+	base := table.Points[0][1]
+	return !p.IsInfinity() && p.Equal(AffinePoint{base[0], base[1]}.ToJacobian())
+}
+
+// checkDimensions verifies that the table's window width is supported, and that the table
+// has the number of rows and columns implied by it. It returns ErrInvalidPrecomputedTable
+// if either check fails.
+func (table *PrecomputedTable) checkDimensions() error {
+	if table.Window < MinPrecomputedWindow || table.Window > MaxPrecomputedWindow {
+		return ErrInvalidPrecomputedTable
+	}
+
+	rows, cols := precomputedTableSize(table.Window)
+	if len(table.Points) != rows {
+		return ErrInvalidPrecomputedTable
+	}
+	for _, row := range table.Points {
+		if len(row) != cols {
+			return ErrInvalidPrecomputedTable
+		}
+	}
+	return nil
+}
+
+// check verifies that the table has the dimensions implied by its window width, and that
+// every entry is consistent with the table's base point Points[0][1]. It returns
+// ErrInvalidPrecomputedTable if any check fails.
+//
+// Rather than recomputing the table, check relies on the fact that a line intersects the
+// curve in exactly three points. Given three points A, B and C on the curve, C = A + B if
+// -C lies on the line through A and B, which costs only a couple of field multiplications
+// to test. Every entry Points[i][j] is checked to be the sum of Points[i][j-1] and the row
+// base Points[i][1], and every row base Points[i+1][1] is checked to be the sum of the last
+// entry Points[i][2^w-1] and Points[i][1].
+func (table *PrecomputedTable) check() error {
+	if err := table.checkDimensions(); err != nil {
+		return err
+	}
+
+	rows, cols := precomputedTableSize(table.Window)
+	for _, row := range table.Points {
+		for j, p := range row {
+			if p[0] == nil || p[1] == nil {
+				return ErrInvalidPrecomputedTable
+			}
+			if j == 0 {
+				if !(AffinePoint{p[0], p[1]}).IsInfinity() {
+					return ErrInvalidPrecomputedTable
+				}
+			} else if !isCanonicalCoordinate(p[0]) || !isCanonicalCoordinate(p[1]) ||
+				!satisfiesCurveEquation(p[0], p[1]) {
+				return ErrInvalidPrecomputedTable
+			}
+		}
+	}
+
+	for i, row := range table.Points {
+		if !isAffineDouble(row[1], row[2]) {
+			return ErrInvalidPrecomputedTable
+		}
+		for j := 3; j < cols; j++ {
+			if !isAffineSum(row[j-1], row[1], row[j]) {
+				return ErrInvalidPrecomputedTable
+			}
+		}
+		if i+1 < rows && !isAffineSum(row[cols-1], row[1], table.Points[i+1][1]) {
+			return ErrInvalidPrecomputedTable
+		}
+	}
+
+	return nil
+}
+
+// isCanonicalCoordinate returns true if n is in the range [0, P).
+func isCanonicalCoordinate(n *big.Int) bool {
+	return n.Sign() >= 0 && n.Cmp(Secp256k1_P) < 0
+}
+
+// isAffineSum returns true if c = a + b, for affine points a, b and c on the curve, none
+// of which are the point at infinity, and whose x-coordinates are pairwise distinct.
+//
+// The line through a and b intersects the curve at exactly one other point, -(a + b).
+// Since -c is distinct from a and b, c = a + b if and only if -c is on that line:
+//
+//	(yc + ya) * (xb - xa) + (yb - ya) * (xc - xa) = 0
+func isAffineSum(a, b, c [2]*big.Int) bool {
+	if equal(a[0], b[0]) || equal(c[0], a[0]) || equal(c[0], b[0]) {
+		return false
+	}
+
+	left := new(big.Int).Add(c[1], a[1])
+	left.Mul(left, new(big.Int).Sub(b[0], a[0]))
+
+	right := new(big.Int).Sub(b[1], a[1])
+	right.Mul(right, new(big.Int).Sub(c[0], a[0]))
+
+	left.Add(left, right)
+	modCoordinate(left)
+	return equal(left, zero)
+}
+
+// isAffineDouble returns true if c = 2a, for affine points a and c on the curve,
+// neither of which is the point at infinity, with distinct x-coordinates.
+//
+// The tangent line at a intersects the curve at exactly one other point, -2a. Since
+// -c is distinct from a, c = 2a if and only if -c is on that tangent line:
+//
+//	2ya * (yc + ya) + 3xa² * (xc - xa) = 0
+func isAffineDouble(a, c [2]*big.Int) bool {
+	if equal(c[0], a[0]) {
+		return false
+	}
+
+	left := new(big.Int).Add(c[1], a[1])
+	left.Mul(left, a[1])
+	left.Lsh(left, 1)
+
+	right := new(big.Int).Mul(a[0], a[0])
+	modCoordinate(right)
+	right.Mul(right, three)
+	right.Mul(right, new(big.Int).Sub(c[0], a[0]))
+
+	left.Add(left, right)
+	modCoordinate(left)
+	return equal(left, zero)
+}
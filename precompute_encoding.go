@@ -0,0 +1,195 @@
+package ekliptic
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"sync/atomic"
+)
+
+// ErrUnsupportedPrecomputedTableVersion is returned when decoding a serialized
+// PrecomputedTable whose format version is not supported by this package.
+var ErrUnsupportedPrecomputedTableVersion = errors.New("ekliptic: unsupported precomputed table version")
+
+const (
+	precomputedTableMagic   = "EKPT"
+	precomputedTableVersion = 1
+
+	// magic, version byte and window byte.
+	precomputedTableHeaderSize = len(precomputedTableMagic) + 2
+)
+
+// precomputedTableEncodedSize returns the size of a serialized PrecomputedTable with the given window width.
+func precomputedTableEncodedSize(window uint) int {
+	rows, cols := precomputedTableSize(window)
+	return precomputedTableHeaderSize + rows*(cols-1)*64
+}
+
+// MarshalBinary serializes the table. See WriteTo for details on the format.
+func (table *PrecomputedTable) MarshalBinary() ([]byte, error) {
+	if err := table.checkEncodable(); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, precomputedTableEncodedSize(table.Window)))
+	if _, err := table.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary parses a table serialized by MarshalBinary or WriteTo. See ReadFrom
+// for details on the integrity checks performed. The table is left unmodified if the
+// data is invalid, and its Untrusted field is always preserved.
+func (table *PrecomputedTable) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	var parsed PrecomputedTable
+	if _, err := parsed.ReadFrom(r); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrInvalidPrecomputedTable
+		}
+		return err
+	}
+	if r.Len() != 0 {
+		return ErrInvalidPrecomputedTable
+	}
+
+	table.Window = parsed.Window
+	table.Points = parsed.Points
+	atomic.StoreUint32(&table.validated, 1)
+	return nil
+}
+
+// WriteTo streams the serialized table to w, and returns the number of bytes written.
+//
+// The serialization begins with a header consisting of the 4-byte magic string "EKPT",
+// a format version byte (currently 1), and the window width byte. It is followed by
+// each row of the table in order. Each row holds the 32-byte big-endian x and y
+// coordinates of the row's entries, omitting the point at infinity in column 0.
+//
+// WriteTo returns ErrInvalidPrecomputedTable without writing anything if the table
+// does not have the dimensions implied by its window width, or if any of its
+// coordinates are outside the range [0, P).
+func (table *PrecomputedTable) WriteTo(w io.Writer) (int64, error) {
+	if err := table.checkEncodable(); err != nil {
+		return 0, err
+	}
+
+	header := append([]byte(precomputedTableMagic), precomputedTableVersion, byte(table.Window))
+	n, err := w.Write(header)
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	_, cols := precomputedTableSize(table.Window)
+	buf := make([]byte, (cols-1)*64)
+	for _, row := range table.Points {
+		for j := 1; j < cols; j++ {
+			row[j][0].FillBytes(buf[(j-1)*64 : (j-1)*64+32])
+			row[j][1].FillBytes(buf[(j-1)*64+32 : j*64])
+		}
+
+		n, err := w.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// ReadFrom reads a serialized table from r into the table, consuming exactly as many
+// bytes as the serialized table occupies, and returns the number of bytes read. The
+// table is left unmodified if an error occurs. The Untrusted field of the table is
+// preserved.
+//
+// After decoding, ReadFrom checks that every entry of the table is on the curve, and
+// that every entry is consistent with the base point of the table, Points[0][1]. It
+// returns ErrInvalidPrecomputedTable if any check fails. Callers should separately check
+// that the base point is the point they expect the table to hold multiples of, either with
+// Validate, or by marking the table as Untrusted so that multiplication checks it.
+func (table *PrecomputedTable) ReadFrom(r io.Reader) (int64, error) {
+	header := make([]byte, precomputedTableHeaderSize)
+	n, err := io.ReadFull(r, header)
+	read := int64(n)
+	if err != nil {
+		return read, err
+	}
+
+	if string(header[:len(precomputedTableMagic)]) != precomputedTableMagic {
+		return read, ErrInvalidPrecomputedTable
+	}
+	if header[len(precomputedTableMagic)] != precomputedTableVersion {
+		return read, ErrUnsupportedPrecomputedTableVersion
+	}
+
+	window := uint(header[len(precomputedTableMagic)+1])
+	if window < MinPrecomputedWindow || window > MaxPrecomputedWindow {
+		return read, ErrInvalidPrecomputedTable
+	}
+
+	rows, cols := precomputedTableSize(window)
+	parsed := PrecomputedTable{
+		Window: window,
+		Points: make([][][2]*big.Int, rows),
+	}
+
+	buf := make([]byte, (cols-1)*64)
+	for i := range parsed.Points {
+		n, err := io.ReadFull(r, buf)
+		read += int64(n)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return read, err
+		}
+
+		row := make([][2]*big.Int, cols)
+		row[0] = [2]*big.Int{new(big.Int), new(big.Int)}
+		for j := 1; j < cols; j++ {
+			row[j] = [2]*big.Int{
+				new(big.Int).SetBytes(buf[(j-1)*64 : (j-1)*64+32]),
+				new(big.Int).SetBytes(buf[(j-1)*64+32 : j*64]),
+			}
+		}
+		parsed.Points[i] = row
+	}
+
+	if err := parsed.check(); err != nil {
+		return read, err
+	}
+
+	table.Window = parsed.Window
+	table.Points = parsed.Points
+	atomic.StoreUint32(&table.validated, 1)
+	return read, nil
+}
+
+// checkEncodable returns ErrInvalidPrecomputedTable if the table cannot be serialized,
+// because it lacks the dimensions implied by its window width, or has coordinates
+// outside the range [0, P).
+func (table *PrecomputedTable) checkEncodable() error {
+	if table.Window < MinPrecomputedWindow || table.Window > MaxPrecomputedWindow {
+		return ErrInvalidPrecomputedTable
+	}
+
+	rows, cols := precomputedTableSize(table.Window)
+	if len(table.Points) != rows {
+		return ErrInvalidPrecomputedTable
+	}
+	for _, row := range table.Points {
+		if len(row) != cols {
+			return ErrInvalidPrecomputedTable
+		}
+		for _, p := range row[1:] {
+			if p[0] == nil || p[1] == nil || !isCanonicalCoordinate(p[0]) || !isCanonicalCoordinate(p[1]) {
+				return ErrInvalidPrecomputedTable
+			}
+		}
+	}
+	return nil
+}
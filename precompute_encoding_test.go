@@ -0,0 +1,235 @@
+package ekliptic
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+)
+
+func TestPrecomputedTableBinary(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		table := NewPrecomputedTableWindow(x, y, window)
+
+		serialized, err := table.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal table with window %d: %s", window, err)
+		}
+		if len(serialized) != precomputedTableEncodedSize(window) {
+			t.Errorf("unexpected serialized length %d for window %d", len(serialized), window)
+		}
+
+		var parsed PrecomputedTable
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Fatalf("failed to unmarshal table with window %d: %s", window, err)
+		}
+		assertTablesEqual(t, &parsed, table)
+	}
+}
+
+func TestPrecomputedTableStreaming(t *testing.T) {
+	baseTable := NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+	var buf bytes.Buffer
+
+	n, err := baseTable.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("failed to write table: %s", err)
+	} else if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo reported %d bytes written, but wrote %d", n, buf.Len())
+	}
+
+	// ReadFrom must consume only the serialized table, leaving any following data intact.
+	buf.WriteString("trailing data")
+
+	var parsed PrecomputedTable
+	n, err = parsed.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("failed to read table: %s", err)
+	} else if n != int64(precomputedTableEncodedSize(DefaultPrecomputedWindow)) {
+		t.Fatalf("ReadFrom reported %d bytes read", n)
+	}
+	assertTablesEqual(t, &parsed, baseTable)
+
+	if buf.String() != "trailing data" {
+		t.Errorf("ReadFrom consumed too much data from the stream")
+	}
+
+	// Truncated streams report the underlying I/O error.
+	serialized, _ := baseTable.MarshalBinary()
+	if _, err := parsed.ReadFrom(bytes.NewReader(serialized[:1000])); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF reading truncated stream, got %v", err)
+	}
+}
+
+func TestPrecomputedTableUnmarshalBinary_Invalid(t *testing.T) {
+	baseTable := NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+	serialized, err := baseTable.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal base point table: %s", err)
+	}
+
+	// offset of the entry [i][j] in the serialization.
+	_, cols := precomputedTableSize(DefaultPrecomputedWindow)
+	entryOffset := func(i, j int) int {
+		return precomputedTableHeaderSize + (i*(cols-1)+j-1)*64
+	}
+
+	modify := func(f func(data []byte) []byte) []byte {
+		return f(append([]byte{}, serialized...))
+	}
+
+	otherX, otherY := MultiplyBasePoint(hexint("c0ffee"))
+	otherTable, _ := NewPrecomputedTable(otherX, otherY).MarshalBinary()
+
+	cases := map[string]struct {
+		data []byte
+		err  error
+	}{
+		"empty": {nil, ErrInvalidPrecomputedTable},
+		"header only": {
+			serialized[:precomputedTableHeaderSize],
+			ErrInvalidPrecomputedTable,
+		},
+		"truncated": {
+			serialized[:len(serialized)-1],
+			ErrInvalidPrecomputedTable,
+		},
+		"trailing bytes": {
+			append(append([]byte{}, serialized...), 0),
+			ErrInvalidPrecomputedTable,
+		},
+		"bad magic": {
+			modify(func(data []byte) []byte { data[0] = 'X'; return data }),
+			ErrInvalidPrecomputedTable,
+		},
+		"unsupported version": {
+			modify(func(data []byte) []byte { data[4] = 2; return data }),
+			ErrUnsupportedPrecomputedTableVersion,
+		},
+		"window too small": {
+			modify(func(data []byte) []byte { data[5] = 1; return data }),
+			ErrInvalidPrecomputedTable,
+		},
+		"window too large": {
+			modify(func(data []byte) []byte { data[5] = 9; return data }),
+			ErrInvalidPrecomputedTable,
+		},
+		"mismatched window": {
+			modify(func(data []byte) []byte { data[5] = 3; return data }),
+			ErrInvalidPrecomputedTable,
+		},
+		"point off curve": {
+			modify(func(data []byte) []byte { data[entryOffset(7, 9)+63] ^= 1; return data }),
+			ErrInvalidPrecomputedTable,
+		},
+		"coordinate out of range": {
+			modify(func(data []byte) []byte {
+				offset := entryOffset(0, 1)
+				copy(data[offset:offset+32], bytes.Repeat([]byte{0xff}, 32))
+				return data
+			}),
+			ErrInvalidPrecomputedTable,
+		},
+		"negated point": {
+			modify(func(data []byte) []byte {
+				offset := entryOffset(12, 5) + 32
+				y := new(big.Int).SetBytes(data[offset : offset+32])
+				y.Sub(Secp256k1_P, y)
+				y.FillBytes(data[offset : offset+32])
+				return data
+			}),
+			ErrInvalidPrecomputedTable,
+		},
+		"swapped entries": {
+			modify(func(data []byte) []byte {
+				a, b := entryOffset(3, 5), entryOffset(3, 6)
+				tmp := append([]byte{}, data[a:a+64]...)
+				copy(data[a:a+64], data[b:b+64])
+				copy(data[b:b+64], tmp)
+				return data
+			}),
+			ErrInvalidPrecomputedTable,
+		},
+		"row from another table": {
+			modify(func(data []byte) []byte {
+				a := entryOffset(20, 1)
+				copy(data[a:a+(cols-1)*64], otherTable[a:a+(cols-1)*64])
+				return data
+			}),
+			ErrInvalidPrecomputedTable,
+		},
+		"last row from another table": {
+			modify(func(data []byte) []byte {
+				a := entryOffset(63, 1)
+				copy(data[a:], otherTable[a:])
+				return data
+			}),
+			ErrInvalidPrecomputedTable,
+		},
+	}
+
+	for name, c := range cases {
+		table := NewPrecomputedTable(otherX, otherY)
+		if err := table.UnmarshalBinary(c.data); err != c.err {
+			t.Errorf("%s: expected error %v, got %v", name, c.err, err)
+		}
+
+		// The table should be left unmodified.
+		if !equal(table.Points[0][1][0], otherX) {
+			t.Errorf("%s: table was modified by failed UnmarshalBinary", name)
+		}
+	}
+}
+
+func TestPrecomputedTableMarshalBinary_Invalid(t *testing.T) {
+	baseTable := NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+	tables := map[string]*PrecomputedTable{
+		"zero value": {},
+		"bad window": {
+			Window: 9,
+			Points: baseTable.Points,
+		},
+		"mismatched window": {
+			Window: 5,
+			Points: baseTable.Points,
+		},
+		"truncated rows": {
+			Window: DefaultPrecomputedWindow,
+			Points: baseTable.Points[:63],
+		},
+		"unreduced coordinate": {
+			Window: DefaultPrecomputedWindow,
+			Points: func() [][][2]*big.Int {
+				points := append([][][2]*big.Int{}, baseTable.Points...)
+				points[1] = append([][2]*big.Int{}, points[1]...)
+				points[1][1] = [2]*big.Int{new(big.Int).Set(Secp256k1_P), one}
+				return points
+			}(),
+		},
+	}
+
+	for name, table := range tables {
+		if _, err := table.MarshalBinary(); err != ErrInvalidPrecomputedTable {
+			t.Errorf("%s: expected ErrInvalidPrecomputedTable, got %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		if n, err := table.WriteTo(&buf); err != ErrInvalidPrecomputedTable || n != 0 || buf.Len() != 0 {
+			t.Errorf("%s: expected WriteTo to fail without writing, got %d bytes and error %v", name, n, err)
+		}
+	}
+}
+
+func BenchmarkPrecomputedTableUnmarshalBinary(b *testing.B) {
+	serialized, _ := NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY).MarshalBinary()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var table PrecomputedTable
+		if err := table.UnmarshalBinary(serialized); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,9 +1,295 @@
 package ekliptic
 
-import "testing"
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func assertTablesEqual(t *testing.T, table, expected *PrecomputedTable) {
+	t.Helper()
+
+	if table.Window != expected.Window {
+		t.Fatalf("unexpected table window %d, wanted %d", table.Window, expected.Window)
+	}
+	if len(table.Points) != len(expected.Points) {
+		t.Fatalf("unexpected table length %d, wanted %d", len(table.Points), len(expected.Points))
+	}
+	for i := range table.Points {
+		if len(table.Points[i]) != len(expected.Points[i]) {
+			t.Fatalf("unexpected row length %d, wanted %d", len(table.Points[i]), len(expected.Points[i]))
+		}
+		for j := range table.Points[i] {
+			p, q := table.Points[i][j], expected.Points[i][j]
+			if !EqualAffine(p[0], p[1], q[0], q[1]) {
+				t.Errorf("precomputed table entry [%d][%d] does not match", i, j)
+			}
+		}
+	}
+}
+
+func TestNewPrecomputedTable(t *testing.T) {
+	if basePointTable() == nil {
+		t.Skip("base point table dropped with the ekliptic_nobasetable build tag")
+	}
+
+	table := NewPrecomputedTableWindow(Secp256k1_GeneratorX, Secp256k1_GeneratorY, basePointTable().Window)
+	assertTablesEqual(t, table, basePointTable())
+}
+
+func TestNewPrecomputedTableWindow(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		table := NewPrecomputedTableWindow(x, y, window)
+
+		rows, cols := precomputedTableSize(window)
+		if table.Window != window || len(table.Points) != rows {
+			t.Fatalf("unexpected table dimensions for window %d", window)
+		}
+		if rows*int(window) < 256 {
+			t.Fatalf("table with window %d does not cover 256-bit scalars", window)
+		}
+
+		// Spot-check entries against direct multiplication.
+		for _, i := range []int{0, 1, rows / 2, rows - 1} {
+			for _, j := range []int{0, 1, 2, cols - 1} {
+				k := new(big.Int).Lsh(big.NewInt(int64(j)), window*uint(i))
+				modScalar(k)
+				expectedX, expectedY := MultiplyAffine(x, y, k, nil)
+
+				p := table.Points[i][j]
+				if len(table.Points[i]) != cols || !EqualAffine(p[0], p[1], expectedX, expectedY) {
+					t.Errorf("entry [%d][%d] of table with window %d is incorrect", i, j, window)
+				}
+			}
+		}
+	}
+}
+
+func TestNewPrecomputedTableWindow_Panic(t *testing.T) {
+	for _, window := range []uint{0, 1, 9, 16} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for window %d", window)
+				}
+			}()
+			NewPrecomputedTableWindow(Secp256k1_GeneratorX, Secp256k1_GeneratorY, window)
+		}()
+	}
+}
+
+func TestNewPrecomputedTableParallel(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+
+	for _, window := range []uint{3, 4, 5} {
+		expected := NewPrecomputedTableWindow(x, y, window)
+
+		for _, workers := range []int{-1, 0, 1, 2, 3, 7, 64, 100} {
+			table := NewPrecomputedTableParallel(x, y, window, workers)
+			assertTablesEqual(t, table, expected)
+		}
+	}
+}
+
+func TestScalarWindowsOfWidth(t *testing.T) {
+	k, _ := new(big.Int).SetString("c0ffee000000000000000000000000000000000000000000000000123456789a", 16)
+
+	for width := uint(MinPrecomputedWindow); width <= MaxPrecomputedWindow; width++ {
+		windows := scalarWindowsOfWidth(k, width)
+
+		// Reassemble k from its windows.
+		reassembled := new(big.Int)
+		for _, d := range windows {
+			reassembled.Lsh(reassembled, width)
+			reassembled.Add(reassembled, big.NewInt(int64(d)))
+		}
+		if !equal(reassembled, k) {
+			t.Errorf("windows of width %d do not reassemble to k: got %x", width, reassembled)
+		}
+	}
+
+	// Width 4 should match the windows used by multi-scalar multiplication.
+	windows := scalarWindowsOfWidth(k, 4)
+	for i, d := range scalarWindows(k) {
+		if uint(d) != windows[i] {
+			t.Fatalf("window %d of width 4 does not match scalarWindows", i)
+		}
+	}
+}
+
+// clonePrecomputedTable returns a deep copy of the table, which can be modified freely.
+func clonePrecomputedTable(table *PrecomputedTable) *PrecomputedTable {
+	cloned := &PrecomputedTable{
+		Window: table.Window,
+		Points: make([][][2]*big.Int, len(table.Points)),
+	}
+	for i, row := range table.Points {
+		cloned.Points[i] = make([][2]*big.Int, len(row))
+		for j, p := range row {
+			cloned.Points[i][j] = [2]*big.Int{new(big.Int).Set(p[0]), new(big.Int).Set(p[1])}
+		}
+	}
+	return cloned
+}
+
+func TestPrecomputedTableValidate(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		table := NewPrecomputedTableWindow(x, y, window)
+		if err := table.Validate(x, y); err != nil {
+			t.Errorf("valid table with window %d failed validation: %s", window, err)
+		}
+		if err := table.Validate(Secp256k1_GeneratorX, Secp256k1_GeneratorY); err != ErrInvalidPrecomputedTable {
+			t.Errorf("table with window %d validated for the wrong point", window)
+		}
+	}
+
+	if basePointTable() != nil {
+		if err := basePointTable().Validate(Secp256k1_GeneratorX, Secp256k1_GeneratorY); err != nil {
+			t.Errorf("base point table failed validation: %s", err)
+		}
+	}
+
+	valid := NewPrecomputedTable(x, y)
+	corruptions := map[string]func(table *PrecomputedTable){
+		"zero window":       func(table *PrecomputedTable) { table.Window = 0 },
+		"mismatched window": func(table *PrecomputedTable) { table.Window = 5 },
+		"missing row":       func(table *PrecomputedTable) { table.Points = table.Points[1:] },
+		"short row":         func(table *PrecomputedTable) { table.Points[9] = table.Points[9][:15] },
+		"nil coordinate":    func(table *PrecomputedTable) { table.Points[4][3][1] = nil },
+		"nonzero column 0":  func(table *PrecomputedTable) { table.Points[2][0] = table.Points[2][1] },
+		"infinity entry":    func(table *PrecomputedTable) { table.Points[2][7] = table.Points[2][0] },
+		"point off curve":   func(table *PrecomputedTable) { table.Points[30][8][0].Add(table.Points[30][8][0], one) },
+		"unreduced coordinate": func(table *PrecomputedTable) {
+			table.Points[5][5][1].Add(table.Points[5][5][1], Secp256k1_P)
+		},
+		"negated entry": func(table *PrecomputedTable) {
+			table.Points[17][11][1].Sub(Secp256k1_P, table.Points[17][11][1])
+		},
+		"negated row": func(table *PrecomputedTable) {
+			for _, p := range table.Points[40][1:] {
+				p[1].Sub(Secp256k1_P, p[1])
+			}
+		},
+		"swapped entries": func(table *PrecomputedTable) {
+			row := table.Points[3]
+			row[5], row[6] = row[6], row[5]
+		},
+		"swapped rows": func(table *PrecomputedTable) {
+			table.Points[10], table.Points[11] = table.Points[11], table.Points[10]
+		},
+		"doubled entry": func(table *PrecomputedTable) {
+			table.Points[0][2] = table.Points[0][1]
+		},
+		"row from another table": func(table *PrecomputedTable) {
+			table.Points[63] = NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY).Points[63]
+		},
+	}
+
+	for name, corrupt := range corruptions {
+		table := clonePrecomputedTable(valid)
+		corrupt(table)
+		if err := table.Validate(x, y); err != ErrInvalidPrecomputedTable {
+			t.Errorf("%s: expected ErrInvalidPrecomputedTable, got %v", name, err)
+		}
+	}
+
+	if err := new(PrecomputedTable).Validate(x, y); err != ErrInvalidPrecomputedTable {
+		t.Errorf("zero-value table: expected ErrInvalidPrecomputedTable, got %v", err)
+	}
+}
+
+func TestPrecomputedTable_Untrusted(t *testing.T) {
+	x, y := MultiplyBasePoint(hexint("c0ffee"))
+	k := hexint("deadbeef")
+	expectedX, expectedY := MultiplyAffine(x, y, k, nil)
+
+	assertPanics := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		f()
+	}
+
+	table := NewPrecomputedTable(x, y)
+	table.Untrusted = true
+
+	resultX, resultY := MultiplyAffine(x, y, k, table)
+	if !equal(resultX, expectedX) || !equal(resultY, expectedY) {
+		t.Errorf("multiplication with untrusted table returned incorrect result")
+	}
+	if table.validated != 1 {
+		t.Errorf("expected untrusted table to be marked as validated after first use")
+	}
+
+	// The base point is checked on every use, even after validation.
+	assertPanics("wrong point", func() {
+		MultiplyAffine(Secp256k1_GeneratorX, Secp256k1_GeneratorY, k, table)
+	})
+
+	corrupted := clonePrecomputedTable(table)
+	corrupted.Points[3][5], corrupted.Points[3][6] = corrupted.Points[3][6], corrupted.Points[3][5]
+	corrupted.Untrusted = true
+	assertPanics("corrupted table", func() {
+		MultiplyAffine(x, y, k, corrupted)
+	})
+	assertPanics("corrupted table jacobi", func() {
+		MultiplyJacobi(x, y, one, k, corrupted)
+	})
+
+	// Deserialized tables remain untrusted, but do not need to be validated again.
+	serialized, _ := table.MarshalBinary()
+	loaded := &PrecomputedTable{Untrusted: true}
+	if err := loaded.UnmarshalBinary(serialized); err != nil {
+		t.Fatalf("failed to unmarshal table: %s", err)
+	}
+	if !loaded.Untrusted || loaded.validated != 1 {
+		t.Errorf("expected unmarshaled table to remain untrusted and be marked as validated")
+	}
+	assertPanics("wrong point after unmarshaling", func() {
+		MultiplyAffine(Secp256k1_GeneratorX, Secp256k1_GeneratorY, k, loaded)
+	})
+}
+
+func BenchmarkPrecomputedTableValidate(b *testing.B) {
+	table := NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := table.Validate(Secp256k1_GeneratorX, Secp256k1_GeneratorY); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
 
 func BenchmarkNewPrecomputedTable(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		NewPrecomputedTable(Secp256k1_GeneratorX, Secp256k1_GeneratorY)
 	}
 }
+
+func BenchmarkNewPrecomputedTableWindow(b *testing.B) {
+	for window := uint(MinPrecomputedWindow); window <= MaxPrecomputedWindow; window++ {
+		b.Run(fmt.Sprintf("window=%d", window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewPrecomputedTableWindow(Secp256k1_GeneratorX, Secp256k1_GeneratorY, window)
+			}
+		})
+	}
+}
+
+func BenchmarkNewPrecomputedTableParallel(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewPrecomputedTableParallel(Secp256k1_GeneratorX, Secp256k1_GeneratorY, DefaultPrecomputedWindow, workers)
+			}
+		})
+	}
+}
@@ -1,4235 +1,12 @@
-package ekliptic
+//go:build !ekliptic_nobasetable
+// +build !ekliptic_nobasetable
 
-import "math/big"
+package ekliptic
 
 // DO NOT EDIT!
 // This file was automatically generated.
 
-func init() {
-	basePointPrecomputations = PrecomputedTable{
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac, 0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0xb, 0x7, 0x2, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28, 0xd9, 0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17, 0x98}),
-				new(big.Int).SetBytes([]byte{0x48, 0x3a, 0xda, 0x77, 0x26, 0xa3, 0xc4, 0x65, 0x5d, 0xa4, 0xfb, 0xfc, 0xe, 0x11, 0x8, 0xa8, 0xfd, 0x17, 0xb4, 0x48, 0xa6, 0x85, 0x54, 0x19, 0x9c, 0x47, 0xd0, 0x8f, 0xfb, 0x10, 0xd4, 0xb8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x4, 0x7f, 0x94, 0x41, 0xed, 0x7d, 0x6d, 0x30, 0x45, 0x40, 0x6e, 0x95, 0xc0, 0x7c, 0xd8, 0x5c, 0x77, 0x8e, 0x4b, 0x8c, 0xef, 0x3c, 0xa7, 0xab, 0xac, 0x9, 0xb9, 0x5c, 0x70, 0x9e, 0xe5}),
-				new(big.Int).SetBytes([]byte{0x1a, 0xe1, 0x68, 0xfe, 0xa6, 0x3d, 0xc3, 0x39, 0xa3, 0xc5, 0x84, 0x19, 0x46, 0x6c, 0xea, 0xee, 0xf7, 0xf6, 0x32, 0x65, 0x32, 0x66, 0xd0, 0xe1, 0x23, 0x64, 0x31, 0xa9, 0x50, 0xcf, 0xe5, 0x2a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf9, 0x30, 0x8a, 0x1, 0x92, 0x58, 0xc3, 0x10, 0x49, 0x34, 0x4f, 0x85, 0xf8, 0x9d, 0x52, 0x29, 0xb5, 0x31, 0xc8, 0x45, 0x83, 0x6f, 0x99, 0xb0, 0x86, 0x1, 0xf1, 0x13, 0xbc, 0xe0, 0x36, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x38, 0x8f, 0x7b, 0xf, 0x63, 0x2d, 0xe8, 0x14, 0xf, 0xe3, 0x37, 0xe6, 0x2a, 0x37, 0xf3, 0x56, 0x65, 0x0, 0xa9, 0x99, 0x34, 0xc2, 0x23, 0x1b, 0x6c, 0xb9, 0xfd, 0x75, 0x84, 0xb8, 0xe6, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe4, 0x93, 0xdb, 0xf1, 0xc1, 0xd, 0x80, 0xf3, 0x58, 0x1e, 0x49, 0x4, 0x93, 0xb, 0x14, 0x4, 0xcc, 0x6c, 0x13, 0x90, 0xe, 0xe0, 0x75, 0x84, 0x74, 0xfa, 0x94, 0xab, 0xe8, 0xc4, 0xcd, 0x13}),
-				new(big.Int).SetBytes([]byte{0x51, 0xed, 0x99, 0x3e, 0xa0, 0xd4, 0x55, 0xb7, 0x56, 0x42, 0xe2, 0x9, 0x8e, 0xa5, 0x14, 0x48, 0xd9, 0x67, 0xae, 0x33, 0xbf, 0xbd, 0xfe, 0x40, 0xcf, 0xe9, 0x7b, 0xdc, 0x47, 0x73, 0x99, 0x22}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x8b, 0xde, 0x4d, 0x1a, 0x7, 0x20, 0x93, 0x55, 0xb4, 0xa7, 0x25, 0xa, 0x5c, 0x51, 0x28, 0xe8, 0x8b, 0x84, 0xbd, 0xdc, 0x61, 0x9a, 0xb7, 0xcb, 0xa8, 0xd5, 0x69, 0xb2, 0x40, 0xef, 0xe4}),
-				new(big.Int).SetBytes([]byte{0xd8, 0xac, 0x22, 0x26, 0x36, 0xe5, 0xe3, 0xd6, 0xd4, 0xdb, 0xa9, 0xdd, 0xa6, 0xc9, 0xc4, 0x26, 0xf7, 0x88, 0x27, 0x1b, 0xab, 0xd, 0x68, 0x40, 0xdc, 0xa8, 0x7d, 0x3a, 0xa6, 0xac, 0x62, 0xd6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xff, 0xf9, 0x7b, 0xd5, 0x75, 0x5e, 0xee, 0xa4, 0x20, 0x45, 0x3a, 0x14, 0x35, 0x52, 0x35, 0xd3, 0x82, 0xf6, 0x47, 0x2f, 0x85, 0x68, 0xa1, 0x8b, 0x2f, 0x5, 0x7a, 0x14, 0x60, 0x29, 0x75, 0x56}),
-				new(big.Int).SetBytes([]byte{0xae, 0x12, 0x77, 0x7a, 0xac, 0xfb, 0xb6, 0x20, 0xf3, 0xbe, 0x96, 0x1, 0x7f, 0x45, 0xc5, 0x60, 0xde, 0x80, 0xf0, 0xf6, 0x51, 0x8f, 0xe4, 0xa0, 0x3c, 0x87, 0xc, 0x36, 0xb0, 0x75, 0xf2, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5c, 0xbd, 0xf0, 0x64, 0x6e, 0x5d, 0xb4, 0xea, 0xa3, 0x98, 0xf3, 0x65, 0xf2, 0xea, 0x7a, 0xe, 0x3d, 0x41, 0x9b, 0x7e, 0x3, 0x30, 0xe3, 0x9c, 0xe9, 0x2b, 0xdd, 0xed, 0xca, 0xc4, 0xf9, 0xbc}),
-				new(big.Int).SetBytes([]byte{0x6a, 0xeb, 0xca, 0x40, 0xba, 0x25, 0x59, 0x60, 0xa3, 0x17, 0x8d, 0x6d, 0x86, 0x1a, 0x54, 0xdb, 0xa8, 0x13, 0xd0, 0xb8, 0x13, 0xfd, 0xe7, 0xb5, 0xa5, 0x8, 0x26, 0x28, 0x8, 0x72, 0x64, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x1, 0xe5, 0xe1, 0x5c, 0xca, 0x35, 0x1d, 0xaf, 0xf3, 0x84, 0x3f, 0xb7, 0xf, 0x3c, 0x2f, 0xa, 0x1b, 0xdd, 0x5, 0xe5, 0xaf, 0x88, 0x8a, 0x67, 0x78, 0x4e, 0xf3, 0xe1, 0xa, 0x2a, 0x1}),
-				new(big.Int).SetBytes([]byte{0x5c, 0x4d, 0xa8, 0xa7, 0x41, 0x53, 0x99, 0x49, 0x29, 0x3d, 0x8, 0x2a, 0x13, 0x2d, 0x13, 0xb4, 0xc2, 0xe2, 0x13, 0xd6, 0xba, 0x5b, 0x76, 0x17, 0xb5, 0xda, 0x2c, 0xb7, 0x6c, 0xbd, 0xe9, 0x4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0xd4, 0x84, 0xe2, 0xf0, 0xc7, 0xf6, 0x53, 0x9, 0xad, 0x17, 0x8a, 0x9f, 0x55, 0x9a, 0xbd, 0xe0, 0x97, 0x96, 0x97, 0x4c, 0x57, 0xe7, 0x14, 0xc3, 0x5f, 0x11, 0xd, 0xfc, 0x27, 0xcc, 0xbe}),
-				new(big.Int).SetBytes([]byte{0xcc, 0x33, 0x89, 0x21, 0xb0, 0xa7, 0xd9, 0xfd, 0x64, 0x38, 0x9, 0x71, 0x76, 0x3b, 0x61, 0xe9, 0xad, 0xd8, 0x88, 0xa4, 0x37, 0x5f, 0x8e, 0xf, 0x5, 0xcc, 0x26, 0x2a, 0xc6, 0x4f, 0x9c, 0x37}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa0, 0x43, 0x4d, 0x9e, 0x47, 0xf3, 0xc8, 0x62, 0x35, 0x47, 0x7c, 0x7b, 0x1a, 0xe6, 0xae, 0x5d, 0x34, 0x42, 0xd4, 0x9b, 0x19, 0x43, 0xc2, 0xb7, 0x52, 0xa6, 0x8e, 0x2a, 0x47, 0xe2, 0x47, 0xc7}),
-				new(big.Int).SetBytes([]byte{0x89, 0x3a, 0xba, 0x42, 0x54, 0x19, 0xbc, 0x27, 0xa3, 0xb6, 0xc7, 0xe6, 0x93, 0xa2, 0x4c, 0x69, 0x6f, 0x79, 0x4c, 0x2e, 0xd8, 0x77, 0xa1, 0x59, 0x3c, 0xbe, 0xe5, 0x3b, 0x3, 0x73, 0x68, 0xd7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x77, 0x4a, 0xe7, 0xf8, 0x58, 0xa9, 0x41, 0x1e, 0x5e, 0xf4, 0x24, 0x6b, 0x70, 0xc6, 0x5a, 0xac, 0x56, 0x49, 0x98, 0xb, 0xe5, 0xc1, 0x78, 0x91, 0xbb, 0xec, 0x17, 0x89, 0x5d, 0xa0, 0x8, 0xcb}),
-				new(big.Int).SetBytes([]byte{0xd9, 0x84, 0xa0, 0x32, 0xeb, 0x6b, 0x5e, 0x19, 0x2, 0x43, 0xdd, 0x56, 0xd7, 0xb7, 0xb3, 0x65, 0x37, 0x2d, 0xb1, 0xe2, 0xdf, 0xf9, 0xd6, 0xa8, 0x30, 0x1d, 0x74, 0xc9, 0xc9, 0x53, 0xc6, 0x1b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x11, 0x15, 0xd5, 0x48, 0xe7, 0x56, 0x1b, 0x15, 0xc3, 0x8f, 0x0, 0x4d, 0x73, 0x46, 0x33, 0x68, 0x7c, 0xf4, 0x41, 0x96, 0x20, 0x9, 0x5b, 0xc5, 0xb0, 0xf4, 0x70, 0x70, 0xaf, 0xe8, 0x5a}),
-				new(big.Int).SetBytes([]byte{0xa9, 0xf3, 0x4f, 0xfd, 0xc8, 0x15, 0xe0, 0xd7, 0xa8, 0xb6, 0x45, 0x37, 0xe1, 0x7b, 0xd8, 0x15, 0x79, 0x23, 0x8c, 0x5d, 0xd9, 0xa8, 0x6d, 0x52, 0x6b, 0x5, 0x1b, 0x13, 0xf4, 0x6, 0x23, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf2, 0x87, 0x73, 0xc2, 0xd9, 0x75, 0x28, 0x8b, 0xc7, 0xd1, 0xd2, 0x5, 0xc3, 0x74, 0x86, 0x51, 0xb0, 0x75, 0xfb, 0xc6, 0x61, 0xe, 0x58, 0xcd, 0xde, 0xed, 0xdf, 0x8f, 0x19, 0x40, 0x5a, 0xa8}),
-				new(big.Int).SetBytes([]byte{0xa, 0xb0, 0x90, 0x2e, 0x8d, 0x88, 0xa, 0x89, 0x75, 0x82, 0x12, 0xeb, 0x65, 0xcd, 0xaf, 0x47, 0x3a, 0x1a, 0x6, 0xda, 0x52, 0x1f, 0xa9, 0x1f, 0x29, 0xb5, 0xcb, 0x52, 0xdb, 0x3, 0xed, 0x81}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x49, 0x9f, 0xdf, 0x9e, 0x89, 0x5e, 0x71, 0x9c, 0xfd, 0x64, 0xe6, 0x7f, 0x7, 0xd3, 0x8e, 0x32, 0x26, 0xaa, 0x7b, 0x63, 0x67, 0x89, 0x49, 0xe6, 0xe4, 0x9b, 0x24, 0x1a, 0x60, 0xe8, 0x23, 0xe4}),
-				new(big.Int).SetBytes([]byte{0xca, 0xc2, 0xf6, 0xc4, 0xb5, 0x4e, 0x85, 0x51, 0x90, 0xf0, 0x44, 0xe4, 0xa7, 0xb3, 0xd4, 0x64, 0x46, 0x42, 0x79, 0xc2, 0x7a, 0x3f, 0x95, 0xbc, 0xc6, 0x5f, 0x40, 0xd4, 0x3, 0xa1, 0x3f, 0x5b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd7, 0x92, 0x4d, 0x4f, 0x7d, 0x43, 0xea, 0x96, 0x5a, 0x46, 0x5a, 0xe3, 0x9, 0x5f, 0xf4, 0x11, 0x31, 0xe5, 0x94, 0x6f, 0x3c, 0x85, 0xf7, 0x9e, 0x44, 0xad, 0xbc, 0xf8, 0xe2, 0x7e, 0x8, 0xe}),
-				new(big.Int).SetBytes([]byte{0x58, 0x1e, 0x28, 0x72, 0xa8, 0x6c, 0x72, 0xa6, 0x83, 0x84, 0x2e, 0xc2, 0x28, 0xcc, 0x6d, 0xef, 0xea, 0x40, 0xaf, 0x2b, 0xd8, 0x96, 0xd3, 0xa5, 0xc5, 0x4, 0xdc, 0x9f, 0xf6, 0xa2, 0x6b, 0x58}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe6, 0xf, 0xce, 0x93, 0xb5, 0x9e, 0x9e, 0xc5, 0x30, 0x11, 0xaa, 0xbc, 0x21, 0xc2, 0x3e, 0x97, 0xb2, 0xa3, 0x13, 0x69, 0xb8, 0x7a, 0x5a, 0xe9, 0xc4, 0x4e, 0xe8, 0x9e, 0x2a, 0x6d, 0xec, 0xa}),
-				new(big.Int).SetBytes([]byte{0xf7, 0xe3, 0x50, 0x73, 0x99, 0xe5, 0x95, 0x92, 0x9d, 0xb9, 0x9f, 0x34, 0xf5, 0x79, 0x37, 0x10, 0x12, 0x96, 0x89, 0x1e, 0x44, 0xd2, 0x3f, 0xb, 0xe1, 0xf3, 0x2c, 0xce, 0x69, 0x61, 0x68, 0x21}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0x1, 0x99, 0xd7, 0x4f, 0xb5, 0xa2, 0x2d, 0x47, 0xb6, 0xe0, 0x54, 0xe2, 0xf3, 0x78, 0xce, 0xda, 0xcf, 0xfc, 0xb8, 0x99, 0x4, 0xa6, 0x1d, 0x75, 0xd0, 0xdb, 0xd4, 0x7, 0x14, 0x3e, 0x65}),
-				new(big.Int).SetBytes([]byte{0x95, 0x3, 0x8d, 0x9d, 0xa, 0xe3, 0xd5, 0xc3, 0xb3, 0xd6, 0xde, 0xc9, 0xe9, 0x83, 0x80, 0x65, 0x1f, 0x76, 0xc, 0xc3, 0x64, 0xed, 0x81, 0x96, 0x5, 0xb3, 0xff, 0x1f, 0x24, 0x10, 0x6a, 0xb9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6e, 0xca, 0x33, 0x5d, 0x96, 0x45, 0x30, 0x7d, 0xb4, 0x41, 0x65, 0x6e, 0xf4, 0xe6, 0x5b, 0x4b, 0xfc, 0x57, 0x9b, 0x27, 0x45, 0x2b, 0xeb, 0xc1, 0x9b, 0xd8, 0x70, 0xaa, 0x11, 0x18, 0xe5, 0xc3}),
-				new(big.Int).SetBytes([]byte{0xd5, 0x1, 0x23, 0xb5, 0x7a, 0x7a, 0x7, 0x10, 0x59, 0x2f, 0x57, 0x90, 0x74, 0xb8, 0x75, 0xa0, 0x3a, 0x49, 0x6a, 0x3a, 0x3b, 0xf8, 0xec, 0x34, 0x49, 0x8a, 0x2f, 0x78, 0x5, 0xa0, 0x86, 0x68}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbf, 0x23, 0xc1, 0x54, 0x2d, 0x16, 0xea, 0xb7, 0xb, 0x10, 0x51, 0xea, 0xf8, 0x32, 0x82, 0x3c, 0xfc, 0x4c, 0x6f, 0x1d, 0xcd, 0xba, 0xfd, 0x81, 0xe3, 0x79, 0x18, 0xe6, 0xf8, 0x74, 0xef, 0x8b}),
-				new(big.Int).SetBytes([]byte{0x5c, 0xb3, 0x86, 0x6f, 0xc3, 0x30, 0x3, 0x73, 0x7a, 0xd9, 0x28, 0xa0, 0xba, 0x53, 0x92, 0xe4, 0xc5, 0x22, 0xfc, 0x54, 0x81, 0x1e, 0x2f, 0x78, 0x4d, 0xc3, 0x7e, 0xfe, 0x66, 0x83, 0x1d, 0x9f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe9, 0x62, 0x3b, 0xbe, 0xf1, 0xbf, 0x90, 0xec, 0xd, 0x7c, 0x74, 0x4e, 0xd3, 0x46, 0x59, 0xf0, 0x10, 0xe6, 0xe6, 0x38, 0x63, 0x71, 0x61, 0x27, 0xe, 0xcd, 0x31, 0xe1, 0x4f, 0x87, 0xf6, 0x2e}),
-				new(big.Int).SetBytes([]byte{0x38, 0xa9, 0x74, 0x3b, 0x4b, 0xc2, 0x99, 0xe9, 0xe0, 0xfe, 0x95, 0x3a, 0x8e, 0xda, 0xa9, 0x29, 0xfe, 0x60, 0x43, 0xc9, 0xdd, 0x68, 0x84, 0x4e, 0x53, 0x1, 0x3e, 0xaf, 0xa4, 0x4e, 0xe7, 0x37}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3f, 0xe, 0x80, 0xe5, 0x74, 0x45, 0x6d, 0x8f, 0x8f, 0xa6, 0x4e, 0x4, 0x4b, 0x2e, 0xb7, 0x2e, 0xa2, 0x2e, 0xb5, 0x3f, 0xe1, 0xef, 0xe3, 0xa4, 0x43, 0x93, 0x3a, 0xca, 0x7f, 0x8c, 0xb0, 0xe3}),
-				new(big.Int).SetBytes([]byte{0xcb, 0x66, 0xd7, 0xd7, 0x29, 0x6c, 0xbc, 0x91, 0xe9, 0xb, 0x9c, 0x8, 0x48, 0x5d, 0x1, 0xb3, 0x95, 0x1, 0x25, 0x3a, 0xa6, 0x5b, 0x53, 0xa4, 0xcb, 0x2, 0x89, 0xe2, 0xea, 0x5f, 0x40, 0x4f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbc, 0x82, 0xdd, 0x73, 0xe5, 0x16, 0x1d, 0xba, 0x8, 0x84, 0xa3, 0x6f, 0x20, 0x80, 0xd6, 0x82, 0xff, 0xc2, 0x74, 0xbf, 0x62, 0xfc, 0xa8, 0xf9, 0xeb, 0xa, 0xad, 0xf8, 0x2a, 0x8d, 0x73, 0x3c}),
-				new(big.Int).SetBytes([]byte{0xe5, 0xf2, 0x8c, 0x3a, 0x4, 0x4b, 0x1c, 0xac, 0x54, 0xa9, 0xb4, 0xbf, 0x71, 0x9f, 0x2, 0xdf, 0xae, 0x93, 0xa0, 0xba, 0xe7, 0x38, 0x97, 0x30, 0x1e, 0x78, 0x61, 0x4, 0xf4, 0x77, 0x97, 0xf0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x34, 0xff, 0x3b, 0xe4, 0x3, 0x3f, 0x7a, 0x6, 0x69, 0x6c, 0x3d, 0x9, 0xf7, 0xd1, 0x67, 0x1c, 0xbc, 0xf5, 0x5c, 0xd7, 0x0, 0x53, 0x56, 0x55, 0x64, 0x70, 0x77, 0x45, 0x67, 0x69, 0xa2, 0x4e}),
-				new(big.Int).SetBytes([]byte{0x5d, 0x9d, 0x11, 0x62, 0x3a, 0x23, 0x6c, 0x55, 0x3f, 0x66, 0x19, 0xd8, 0x98, 0x32, 0x9, 0x8c, 0x55, 0xdf, 0x16, 0xc3, 0xe8, 0xf8, 0xb6, 0x81, 0x84, 0x91, 0x6, 0x7a, 0x73, 0xcc, 0x2f, 0x1a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0x3d, 0x12, 0x48, 0xc7, 0x65, 0x72, 0x11, 0xd2, 0x2, 0x91, 0xce, 0x17, 0x98, 0xf4, 0x90, 0x74, 0x3f, 0x1b, 0xc8, 0x52, 0x85, 0x8e, 0x32, 0xd7, 0xef, 0xe2, 0x31, 0x5f, 0xbc, 0x76, 0x71}),
-				new(big.Int).SetBytes([]byte{0x9, 0x9a, 0x48, 0xe1, 0xe, 0xcf, 0xcb, 0x81, 0xf6, 0x44, 0x80, 0xe1, 0x93, 0x93, 0xe9, 0xe, 0xb9, 0x35, 0x2b, 0xaa, 0xa6, 0x3e, 0x14, 0x4a, 0x7e, 0xf1, 0xdc, 0x64, 0x18, 0x71, 0x7d, 0xec}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x30, 0x89, 0x13, 0xa2, 0x7a, 0x52, 0xd9, 0x22, 0x2b, 0xc7, 0x76, 0x83, 0x8f, 0x73, 0xf5, 0x76, 0xa4, 0xd0, 0x47, 0x12, 0x2a, 0x9b, 0x18, 0x4b, 0x5, 0xec, 0x32, 0xad, 0x51, 0xb0, 0x3f, 0x6c}),
-				new(big.Int).SetBytes([]byte{0xf4, 0xa5, 0xb0, 0x95, 0x43, 0xfe, 0xbe, 0x5f, 0x91, 0xe3, 0x53, 0x1f, 0x66, 0xc0, 0x37, 0x5d, 0xa8, 0x33, 0x3f, 0xea, 0x82, 0xbd, 0x1f, 0x12, 0x60, 0xab, 0x5e, 0xfc, 0xe8, 0xfe, 0x4c, 0x67}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x78, 0xa8, 0x91, 0xaa, 0x22, 0x34, 0xa4, 0x98, 0x89, 0x6a, 0x19, 0x3e, 0xd0, 0x88, 0xa2, 0xb6, 0x8f, 0xca, 0xe8, 0x27, 0x88, 0xf5, 0x6, 0xa0, 0xf3, 0x28, 0x74, 0x32, 0xbe, 0xb3, 0x1d, 0xb2}),
-				new(big.Int).SetBytes([]byte{0x69, 0x12, 0xa3, 0x5b, 0xeb, 0x50, 0x35, 0xcb, 0xfc, 0xf5, 0xf2, 0x55, 0x27, 0x30, 0x2d, 0xf6, 0x54, 0x37, 0x9b, 0xcd, 0xd8, 0x0, 0xb8, 0x2d, 0x30, 0x69, 0xd6, 0x23, 0xb9, 0xfa, 0x43, 0x43}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd7, 0xa0, 0xda, 0x58, 0xd0, 0x1d, 0xc6, 0x35, 0x81, 0x2d, 0xdf, 0x64, 0xd9, 0x9c, 0x9a, 0xea, 0xe7, 0x83, 0xc7, 0x97, 0xd7, 0xcd, 0x20, 0x4e, 0xc7, 0xb7, 0x50, 0xf7, 0x33, 0xce, 0x17, 0x52}),
-				new(big.Int).SetBytes([]byte{0x91, 0x27, 0x70, 0xe0, 0x68, 0x0, 0x80, 0x32, 0xf6, 0xf2, 0x92, 0x83, 0x40, 0xe2, 0x84, 0x65, 0xb, 0xe0, 0x40, 0xa8, 0xc0, 0x62, 0xb7, 0x42, 0xbb, 0xc0, 0x27, 0x38, 0x7, 0x62, 0xce, 0xf4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7d, 0x86, 0x78, 0x18, 0x55, 0xdb, 0x1b, 0x17, 0xd7, 0xce, 0x37, 0x65, 0x81, 0x60, 0x76, 0xeb, 0xa7, 0x16, 0x3c, 0xb9, 0xfb, 0xa0, 0x82, 0xbb, 0x65, 0x34, 0x8f, 0x77, 0x8d, 0xb0, 0xe5, 0x95}),
-				new(big.Int).SetBytes([]byte{0xe2, 0xb9, 0x9a, 0xdf, 0xec, 0x86, 0xf8, 0x77, 0x2e, 0x56, 0x2e, 0x2b, 0xed, 0x4f, 0x88, 0x38, 0x29, 0x37, 0x84, 0x4e, 0xe, 0x25, 0xd5, 0x32, 0x99, 0x95, 0x1e, 0x3a, 0xbc, 0x73, 0x3d, 0xe8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8, 0xbc, 0x89, 0xc2, 0xf9, 0x19, 0xed, 0x15, 0x88, 0x85, 0xc3, 0x56, 0x0, 0x84, 0x4d, 0x49, 0x89, 0x9, 0x5, 0xc7, 0x9b, 0x35, 0x73, 0x22, 0x60, 0x9c, 0x45, 0x70, 0x6c, 0xe6, 0xb5, 0x14}),
-				new(big.Int).SetBytes([]byte{0xd3, 0x13, 0xf3, 0xcd, 0xd7, 0xcd, 0xcc, 0x16, 0xde, 0x77, 0x6f, 0xec, 0x3b, 0x58, 0x92, 0xc1, 0x17, 0x2d, 0x30, 0x56, 0x11, 0x27, 0x76, 0xf0, 0x6f, 0x63, 0xf4, 0xce, 0xa8, 0xc9, 0x51, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdd, 0xc5, 0x31, 0xf, 0x0, 0x58, 0x2a, 0xc8, 0x48, 0x49, 0x4b, 0x9d, 0xc4, 0x1a, 0xb0, 0x86, 0x76, 0x54, 0x5f, 0x84, 0x20, 0x5e, 0x6a, 0x2a, 0x0, 0x8f, 0xef, 0x85, 0x16, 0x6, 0xd, 0xfc}),
-				new(big.Int).SetBytes([]byte{0xba, 0xd, 0x2f, 0x3a, 0xf2, 0xd, 0x96, 0x92, 0x1, 0x91, 0xab, 0x6d, 0xcc, 0x8f, 0xe, 0x90, 0x41, 0xdb, 0xaf, 0xc6, 0xab, 0xd0, 0x47, 0x30, 0xfb, 0x5f, 0x8a, 0xb6, 0xe7, 0x82, 0xc, 0xa8}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x82, 0x82, 0x26, 0x32, 0x12, 0xc6, 0x9, 0xd9, 0xea, 0x2a, 0x6e, 0x3e, 0x17, 0x2d, 0xe2, 0x38, 0xd8, 0xc3, 0x9c, 0xab, 0xd5, 0xac, 0x1c, 0xa1, 0x6, 0x46, 0xe2, 0x3f, 0xd5, 0xf5, 0x15, 0x8}),
-				new(big.Int).SetBytes([]byte{0x11, 0xf8, 0xa8, 0x9, 0x85, 0x57, 0xdf, 0xe4, 0x5e, 0x82, 0x56, 0xe8, 0x30, 0xb6, 0xa, 0xce, 0x62, 0xd6, 0x13, 0xac, 0x2f, 0x7b, 0x17, 0xbe, 0xd3, 0x1b, 0x6e, 0xaf, 0xf6, 0xe2, 0x6c, 0xaf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x46, 0x53, 0x70, 0xb2, 0x87, 0xa7, 0x9f, 0xf3, 0x90, 0x5a, 0x85, 0x7a, 0x9c, 0xf9, 0x18, 0xd5, 0xa, 0xdb, 0xc9, 0x68, 0xd9, 0xe1, 0x59, 0xd0, 0x92, 0x6e, 0x2c, 0x0, 0xef, 0x34, 0xa2, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x35, 0xe5, 0x31, 0xb3, 0x83, 0x68, 0xc0, 0x82, 0xa4, 0xaf, 0x8b, 0xda, 0xfd, 0xee, 0xc2, 0xc1, 0x58, 0x8e, 0x9, 0xb2, 0x15, 0xd3, 0x7a, 0x10, 0xa2, 0xf8, 0xfb, 0x20, 0xb3, 0x38, 0x87, 0xf4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x82, 0x62, 0xcf, 0x2f, 0xf0, 0x79, 0x9c, 0x4c, 0xd, 0x9a, 0x30, 0xf8, 0xac, 0xa9, 0x8c, 0xa0, 0x9, 0x80, 0x91, 0x91, 0xa3, 0xc7, 0xe1, 0x84, 0xfc, 0xfc, 0xc, 0xb9, 0xe5, 0x7e, 0x8d, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x83, 0xfd, 0x95, 0xe2, 0x9, 0x10, 0x9e, 0x4e, 0x66, 0xfe, 0xe2, 0x2e, 0xc5, 0xb3, 0x4f, 0x34, 0x57, 0xb6, 0xed, 0x33, 0x2b, 0x14, 0xc4, 0x78, 0x35, 0xcf, 0xf8, 0xd8, 0xfb, 0xac, 0x37, 0x6a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x24, 0x1f, 0xeb, 0xb8, 0xe2, 0x3c, 0xbd, 0x77, 0xd6, 0x64, 0xa1, 0x8f, 0x66, 0xad, 0x62, 0x40, 0xaa, 0xec, 0x6e, 0xcd, 0xc8, 0x13, 0xb0, 0x88, 0xd5, 0xb9, 0x1, 0xb2, 0xe2, 0x85, 0x13, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x51, 0x33, 0x78, 0xd9, 0xff, 0x94, 0xf8, 0xd3, 0xd6, 0xc4, 0x20, 0xbd, 0x13, 0x98, 0x1d, 0xf8, 0xcd, 0x50, 0xfd, 0xf, 0xbd, 0xc, 0xb5, 0xaf, 0xab, 0xb3, 0xe6, 0x6f, 0x27, 0x50, 0x2, 0x6d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x19, 0x82, 0x5c, 0x8b, 0x1d, 0xa0, 0xdd, 0xd5, 0x16, 0x81, 0x5, 0xb2, 0x4c, 0xe9, 0x9c, 0x87, 0x7c, 0xa4, 0x1b, 0xd4, 0x7b, 0x73, 0x4b, 0x94, 0x90, 0x52, 0xe4, 0x8b, 0x2, 0x6b, 0xdb, 0x6f}),
-				new(big.Int).SetBytes([]byte{0x62, 0x94, 0x31, 0xf, 0xd, 0x4c, 0x87, 0x8f, 0x32, 0x2, 0x61, 0xcc, 0x94, 0xf5, 0x9f, 0x6c, 0xeb, 0xe9, 0xee, 0xcc, 0x8c, 0xf6, 0xd3, 0xa6, 0xb5, 0xdf, 0x70, 0x84, 0xc4, 0x9c, 0xfc, 0x9b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0x53, 0xa8, 0xa4, 0x8d, 0x2c, 0x23, 0x6d, 0xc6, 0xd, 0xd3, 0x1a, 0x2b, 0x67, 0x17, 0x80, 0x49, 0x98, 0xc4, 0xbe, 0xab, 0xc2, 0x88, 0xd9, 0xd1, 0x7c, 0xc1, 0xf2, 0x7c, 0x70, 0x62, 0xc}),
-				new(big.Int).SetBytes([]byte{0x3, 0x38, 0x29, 0x9, 0x35, 0xaf, 0x7f, 0x7a, 0xea, 0xfa, 0x99, 0x47, 0x4e, 0xfa, 0x70, 0x1c, 0x1, 0x2a, 0xf7, 0x48, 0xdf, 0xd3, 0xdc, 0x52, 0x6c, 0xa2, 0xe8, 0x1d, 0x31, 0x5b, 0x32, 0xcd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6f, 0x12, 0xd8, 0x6c, 0x11, 0x60, 0x19, 0x14, 0x43, 0xc5, 0xf5, 0x6e, 0xc6, 0xc2, 0x99, 0x9e, 0xdf, 0xa5, 0x8e, 0x34, 0x5e, 0x15, 0x34, 0xe6, 0x50, 0xed, 0x9, 0x52, 0x3d, 0x82, 0x82, 0x4c}),
-				new(big.Int).SetBytes([]byte{0x5c, 0x4f, 0xf7, 0xf4, 0x4a, 0xb3, 0xbf, 0xa0, 0x87, 0x59, 0x94, 0xf3, 0xfd, 0x62, 0x37, 0x69, 0x39, 0x1c, 0x92, 0x41, 0x8, 0x54, 0xbc, 0x5b, 0x85, 0x79, 0xc3, 0x48, 0x6, 0xeb, 0x34, 0xd0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5d, 0x1b, 0xdb, 0x4e, 0xa1, 0x72, 0xfa, 0x79, 0xfc, 0xe4, 0xcc, 0x29, 0x83, 0xd8, 0xf8, 0xd9, 0xfc, 0x31, 0x8b, 0x85, 0xf4, 0x23, 0xde, 0xd, 0xed, 0xcb, 0x63, 0x6, 0x9b, 0x92, 0x4, 0x71}),
-				new(big.Int).SetBytes([]byte{0x28, 0x43, 0x82, 0x67, 0x79, 0x37, 0x9e, 0x2e, 0x79, 0x4b, 0xb9, 0x94, 0x38, 0xa2, 0x26, 0x56, 0x79, 0xeb, 0x1e, 0x99, 0x96, 0xc5, 0x6e, 0x7b, 0x70, 0x33, 0x6, 0x66, 0xf7, 0xb8, 0x31, 0x3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x20, 0x3a, 0x8c, 0x6f, 0x9a, 0xa, 0xaa, 0x5d, 0x14, 0x26, 0x27, 0x16, 0xa2, 0x3a, 0xbe, 0xf6, 0x45, 0xcf, 0xdc, 0xdc, 0xf, 0x59, 0xe6, 0x3, 0x7, 0x6d, 0xdc, 0x2, 0xdb, 0x45, 0x36, 0x29}),
-				new(big.Int).SetBytes([]byte{0x3b, 0xf, 0xb, 0x53, 0xde, 0x5d, 0xd9, 0xb9, 0x36, 0xcc, 0x76, 0xd1, 0x5f, 0x41, 0x6, 0x12, 0x68, 0x6d, 0xeb, 0x25, 0xc5, 0x28, 0x5e, 0xd4, 0x59, 0x71, 0xc7, 0x85, 0x3f, 0xf8, 0x9f, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x47, 0x4a, 0x47, 0x32, 0xc2, 0x94, 0xb1, 0xe1, 0x19, 0xe6, 0xa3, 0x63, 0x24, 0x65, 0x51, 0x3, 0x55, 0x31, 0x99, 0x21, 0x7a, 0xb8, 0xcb, 0x45, 0xb9, 0x44, 0x65, 0x57, 0xb1, 0x47, 0xf3, 0xd6}),
-				new(big.Int).SetBytes([]byte{0x94, 0x62, 0x5e, 0x23, 0x12, 0x6, 0xf0, 0x4e, 0xed, 0x2c, 0xc3, 0xc, 0xc3, 0xa4, 0x8a, 0xa3, 0x11, 0xfa, 0x7f, 0x17, 0x1, 0xe, 0x13, 0x0, 0xdc, 0xee, 0x85, 0x28, 0x28, 0x62, 0x8b, 0xa4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6e, 0x2a, 0xca, 0xeb, 0x3d, 0x3, 0x41, 0x81, 0xc8, 0x1e, 0xf7, 0x33, 0x48, 0x66, 0xe1, 0xec, 0x9d, 0x3a, 0xed, 0x3f, 0xe5, 0xbb, 0x4c, 0xe9, 0x78, 0x31, 0x30, 0xdd, 0xe4, 0x6c, 0x7e, 0xcb}),
-				new(big.Int).SetBytes([]byte{0x9e, 0x61, 0xa4, 0x67, 0x97, 0xef, 0xee, 0x14, 0x9d, 0x80, 0xc4, 0xda, 0xf0, 0xfb, 0x64, 0x3a, 0xfa, 0xc7, 0x6, 0xb9, 0x1b, 0x67, 0x51, 0x2c, 0x84, 0x49, 0x20, 0x1e, 0xee, 0xbc, 0x87, 0x20}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd4, 0x9e, 0xe4, 0xfb, 0x6b, 0x63, 0xf4, 0x3c, 0x60, 0x98, 0xae, 0x32, 0x60, 0xb5, 0x37, 0x3f, 0x54, 0xd3, 0xfe, 0x49, 0x89, 0xe5, 0xcb, 0x4f, 0x47, 0xd4, 0x2b, 0xa6, 0xe7, 0x1d, 0xab, 0xcd}),
-				new(big.Int).SetBytes([]byte{0x5, 0x31, 0xe3, 0x92, 0x9, 0xa5, 0x49, 0xd, 0xd7, 0xc8, 0x7e, 0xa7, 0xa6, 0x1b, 0xf3, 0x56, 0x12, 0x9c, 0x50, 0x93, 0x12, 0xff, 0x70, 0x31, 0xe6, 0x6a, 0x90, 0xcf, 0x1, 0x66, 0x3, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd5, 0xa7, 0x4, 0x92, 0xe9, 0xe9, 0x15, 0x6b, 0xaf, 0x61, 0x71, 0x7e, 0x8a, 0x49, 0xa, 0x58, 0x27, 0x47, 0xdd, 0x8b, 0xf7, 0x75, 0xf2, 0x1, 0xeb, 0x70, 0x18, 0xf3, 0xf0, 0xa4, 0x14, 0x7e}),
-				new(big.Int).SetBytes([]byte{0x9d, 0xb5, 0x26, 0xf5, 0xdb, 0xab, 0x89, 0xc6, 0xfc, 0x49, 0x9, 0x90, 0x76, 0x5e, 0x5, 0x32, 0xf4, 0xc4, 0xd9, 0x84, 0x79, 0x67, 0xf5, 0x7f, 0x8e, 0x4b, 0x3c, 0xb8, 0x33, 0xfb, 0x65, 0xff}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6c, 0xc1, 0x10, 0x9e, 0x3, 0xdf, 0x8, 0x99, 0x49, 0xbb, 0x6b, 0x52, 0xdc, 0xeb, 0xf4, 0xc2, 0x12, 0xaa, 0x3c, 0xc0, 0xd3, 0x43, 0xef, 0xbd, 0x63, 0xda, 0x8f, 0x68, 0xb4, 0x38, 0x41, 0xc6}),
-				new(big.Int).SetBytes([]byte{0xaf, 0x56, 0x1a, 0xfe, 0x9c, 0x9, 0x4b, 0x76, 0xf, 0xfd, 0x3d, 0x6b, 0xa, 0x29, 0xff, 0xec, 0xd7, 0x9b, 0x43, 0x3e, 0x2d, 0x90, 0x6c, 0xf5, 0xb9, 0x73, 0x3a, 0x46, 0xb9, 0x54, 0xe9, 0x4d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0xc5, 0x11, 0x9a, 0xab, 0xe1, 0x8b, 0xa8, 0x5, 0x23, 0xef, 0xc6, 0x30, 0x2c, 0xda, 0xc6, 0xa1, 0x6, 0x1c, 0xc2, 0xd3, 0x63, 0x4f, 0x45, 0x4e, 0xdd, 0xb4, 0x6b, 0xd8, 0xed, 0xce, 0xc6}),
-				new(big.Int).SetBytes([]byte{0xe6, 0x49, 0xdd, 0x22, 0x85, 0xd9, 0x73, 0x2a, 0x66, 0x8c, 0xb2, 0xda, 0x27, 0x5f, 0x28, 0x2f, 0x28, 0xa1, 0x6c, 0x82, 0x2b, 0x55, 0x30, 0xa6, 0x45, 0x6e, 0xb, 0xfb, 0x19, 0x33, 0xdb, 0x8}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x17, 0x5e, 0x15, 0x9f, 0x72, 0x8b, 0x86, 0x5a, 0x72, 0xf9, 0x9c, 0xc6, 0xc6, 0xfc, 0x84, 0x6d, 0xe0, 0xb9, 0x38, 0x33, 0xfd, 0x22, 0x22, 0xed, 0x73, 0xfc, 0xe5, 0xb5, 0x51, 0xe5, 0xb7, 0x39}),
-				new(big.Int).SetBytes([]byte{0xd3, 0x50, 0x6e, 0xd, 0x9e, 0x3c, 0x79, 0xeb, 0xa4, 0xef, 0x97, 0xa5, 0x1f, 0xf7, 0x1f, 0x5e, 0xac, 0xb5, 0x95, 0x5a, 0xdd, 0x24, 0x34, 0x5c, 0x6e, 0xfa, 0x6f, 0xfe, 0xe9, 0xfe, 0xd6, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x42, 0x3a, 0x1, 0x3f, 0x3, 0xff, 0x32, 0xd7, 0xa5, 0xff, 0xbc, 0xc8, 0xe1, 0x39, 0xc6, 0x21, 0x30, 0xfd, 0xfe, 0xb5, 0xc6, 0xda, 0x12, 0x1b, 0xce, 0x78, 0x4, 0x9e, 0x46, 0xbc, 0x47, 0xd6}),
-				new(big.Int).SetBytes([]byte{0xb9, 0x1a, 0xe0, 0xf, 0xe1, 0xe1, 0xd9, 0x70, 0xa1, 0x17, 0x9f, 0x7b, 0xba, 0xf6, 0xb3, 0xc7, 0x72, 0xd, 0x8e, 0xc3, 0x52, 0x4f, 0x0, 0x9e, 0xd1, 0x23, 0x6e, 0x6d, 0x8b, 0x54, 0x8a, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x75, 0x31, 0x7b, 0x21, 0xf7, 0xac, 0xf4, 0x12, 0x8b, 0x59, 0xef, 0xdc, 0x2f, 0xed, 0x52, 0x3f, 0x73, 0x35, 0xf6, 0x84, 0x2b, 0x83, 0x6a, 0x65, 0xb7, 0xf8, 0xf1, 0xc5, 0x4, 0x12, 0x16}),
-				new(big.Int).SetBytes([]byte{0x73, 0xf8, 0xa0, 0x46, 0xbf, 0x72, 0xd5, 0xf0, 0xdf, 0x19, 0xe2, 0x1b, 0x34, 0x2d, 0x7f, 0xc6, 0xe9, 0xaa, 0xc0, 0x7a, 0xe7, 0x7a, 0xce, 0xda, 0xda, 0xed, 0x32, 0x98, 0x6e, 0x70, 0x85, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x11, 0x1d, 0x6a, 0x45, 0xac, 0x1f, 0xb9, 0x5, 0x8, 0x90, 0x7a, 0x7a, 0xbc, 0xd6, 0x87, 0x76, 0x49, 0xdf, 0x66, 0x2f, 0x3b, 0x3e, 0x27, 0x41, 0x30, 0x2d, 0xf6, 0xf7, 0x84, 0x16, 0x82, 0x4a}),
-				new(big.Int).SetBytes([]byte{0x6, 0x96, 0x91, 0x1c, 0x47, 0x8e, 0xaf, 0xfb, 0xb9, 0xd, 0x48, 0xdb, 0xff, 0x6, 0x59, 0x52, 0xf0, 0x70, 0x0, 0x89, 0x96, 0xda, 0xca, 0x4c, 0xa9, 0xa1, 0x11, 0xd4, 0x21, 0x8, 0xe9, 0xd0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0x71, 0xc5, 0xb4, 0x8e, 0x97, 0x49, 0xd7, 0x5, 0x73, 0xc5, 0x8c, 0x4a, 0x82, 0xeb, 0x1e, 0x25, 0x87, 0xf1, 0xc1, 0x6b, 0x13, 0x52, 0xfd, 0xb0, 0x14, 0x3e, 0x71, 0xe4, 0x65, 0xa9, 0x30}),
-				new(big.Int).SetBytes([]byte{0x4a, 0x91, 0xc3, 0x34, 0xe8, 0xf5, 0xfa, 0xc, 0x27, 0x13, 0xf1, 0xf2, 0x82, 0x4b, 0xb6, 0x8c, 0x79, 0x34, 0x5e, 0x3f, 0xb7, 0x17, 0x4d, 0x47, 0x1d, 0x87, 0x3f, 0x6c, 0xc3, 0x46, 0x38, 0xb5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x95, 0x30, 0xf0, 0xf9, 0x2, 0x3f, 0x46, 0x9c, 0x13, 0x64, 0xb7, 0xd2, 0xc3, 0xe8, 0xb7, 0xe, 0x19, 0x15, 0xd, 0xdf, 0x51, 0xf0, 0xab, 0x6, 0x95, 0x5, 0x32, 0x4f, 0x3c, 0x62, 0xba, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x8f, 0x3c, 0x30, 0x5a, 0x8f, 0x9f, 0x21, 0xe2, 0x34, 0xdd, 0x2f, 0x7f, 0x59, 0xb3, 0x33, 0xe0, 0xe2, 0x5b, 0x32, 0x85, 0x2f, 0x1f, 0xdc, 0x68, 0x47, 0x8a, 0xbd, 0xa9, 0x76, 0x18, 0xe3, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd8, 0x4e, 0x4a, 0xfc, 0x1f, 0x31, 0xa5, 0x66, 0x93, 0x6e, 0x83, 0x7a, 0x90, 0x9b, 0x4c, 0x9c, 0x78, 0x50, 0xdd, 0x43, 0x27, 0x44, 0xa0, 0x77, 0xe3, 0x18, 0xda, 0xe5, 0xba, 0xdb, 0x6e, 0xe7}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x25, 0x80, 0x9a, 0x7c, 0x7b, 0x79, 0xce, 0x12, 0xa3, 0x8d, 0x58, 0xf5, 0x65, 0xde, 0x4d, 0xfd, 0xd8, 0xac, 0x97, 0x4a, 0xa3, 0xe6, 0x49, 0x82, 0xd5, 0x56, 0xe6, 0xd4, 0x2e, 0xbe, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4a, 0x4a, 0x6d, 0xc9, 0x7a, 0xc7, 0xc8, 0xb8, 0xad, 0x79, 0x5d, 0xbe, 0xbc, 0xb9, 0xdc, 0xff, 0x72, 0x90, 0xb6, 0x8a, 0x5e, 0xf7, 0x4e, 0x56, 0xab, 0x5e, 0xdd, 0xe0, 0x1b, 0xce, 0xd7, 0x75}),
-				new(big.Int).SetBytes([]byte{0x52, 0x99, 0x11, 0xb0, 0x16, 0x63, 0x1e, 0x72, 0x94, 0x3e, 0xf9, 0xf7, 0x39, 0xc0, 0xf4, 0x57, 0x1d, 0xe9, 0xc, 0xdb, 0x42, 0x47, 0x42, 0xac, 0xb2, 0xbf, 0x8f, 0x68, 0xa7, 0x8d, 0xd6, 0x6d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf3, 0xd4, 0x44, 0x4b, 0xde, 0x66, 0x81, 0x4d, 0x41, 0xb2, 0x2b, 0x92, 0x85, 0xff, 0x6e, 0xd3, 0xf6, 0xa, 0xdf, 0xf3, 0xae, 0xac, 0x99, 0xd2, 0x39, 0x4e, 0x9e, 0xcf, 0xa4, 0x9e, 0x6d, 0x10}),
-				new(big.Int).SetBytes([]byte{0xa, 0x43, 0x24, 0xdf, 0xa6, 0xf0, 0x16, 0x3d, 0x4b, 0xab, 0x95, 0xac, 0x19, 0x8a, 0x4b, 0x5b, 0xfc, 0x1a, 0xda, 0x50, 0xce, 0x9d, 0x6c, 0x63, 0xa, 0x3, 0x8c, 0xc0, 0x53, 0x47, 0xda, 0x3f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf0, 0x6, 0xc4, 0x2f, 0x1d, 0x8e, 0x4f, 0x75, 0x1d, 0xf8, 0x2e, 0xfe, 0xe0, 0x99, 0x52, 0x2c, 0x35, 0x69, 0x29, 0xd1, 0xfd, 0x66, 0x54, 0x11, 0xf5, 0xe9, 0x1b, 0x77, 0xa5, 0x47, 0xb4, 0xb3}),
-				new(big.Int).SetBytes([]byte{0xf6, 0x81, 0x54, 0xd4, 0xa6, 0x66, 0x52, 0xa, 0x5c, 0x47, 0x84, 0x8a, 0x4e, 0x4f, 0x7d, 0x16, 0x75, 0xc9, 0x33, 0xee, 0xd6, 0xbe, 0x7a, 0xe1, 0xb5, 0xef, 0x44, 0x9f, 0xa8, 0x6a, 0xa7, 0x4b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xae, 0x30, 0x65, 0x2c, 0x9d, 0x9c, 0x1d, 0x89, 0xf9, 0x8, 0x55, 0x20, 0xcd, 0xbd, 0xf4, 0x4, 0x4e, 0x72, 0xee, 0x8, 0xaa, 0x39, 0xbc, 0xab, 0x48, 0xcb, 0x34, 0x6, 0xe9, 0xd3, 0x3a, 0x7}),
-				new(big.Int).SetBytes([]byte{0x6c, 0xb9, 0xd9, 0xc3, 0x8d, 0x63, 0xfe, 0x57, 0xef, 0xcc, 0xe7, 0xd3, 0x3d, 0xb8, 0xd5, 0xcf, 0x1c, 0x9c, 0x37, 0xf5, 0xdf, 0xd7, 0xe9, 0x5c, 0x74, 0x87, 0xc, 0xf, 0x60, 0xa0, 0xb2, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x67, 0xbe, 0x2, 0xdc, 0xbe, 0x42, 0x98, 0xfd, 0x6f, 0x9, 0xff, 0x43, 0xaa, 0xa3, 0x32, 0xb0, 0xf2, 0x81, 0x6f, 0xee, 0x3e, 0x43, 0x67, 0xb2, 0x6, 0x70, 0x43, 0x85, 0xdc, 0x3c, 0x9c, 0x8f}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x9b, 0x55, 0xa7, 0x3e, 0x4d, 0xef, 0x84, 0xa2, 0xc9, 0x45, 0xb6, 0x8, 0x29, 0x16, 0x93, 0xcd, 0x99, 0x3b, 0xf6, 0xb, 0x88, 0xc2, 0xbe, 0x55, 0x38, 0x49, 0x98, 0x59, 0x36, 0x52, 0xd9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd8, 0xdc, 0x1b, 0x2a, 0x5b, 0xd5, 0xe1, 0xc8, 0x15, 0xe1, 0x7e, 0x75, 0x83, 0x38, 0x8c, 0x9a, 0x18, 0x69, 0xc7, 0xbb, 0xcc, 0x82, 0x81, 0xda, 0x75, 0x96, 0x54, 0xb9, 0xc, 0x28, 0xca, 0xca}),
-				new(big.Int).SetBytes([]byte{0x8c, 0xec, 0xa, 0xd9, 0x27, 0xce, 0xc7, 0xd5, 0x8c, 0x6d, 0x8d, 0xa4, 0x20, 0x24, 0x7d, 0x94, 0x7d, 0x31, 0x5d, 0x2c, 0xec, 0x81, 0x28, 0xf6, 0xcd, 0xb6, 0x76, 0xea, 0x23, 0xb3, 0xec, 0x7a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe, 0x69, 0xb3, 0x46, 0x40, 0x3d, 0xe8, 0x85, 0xc1, 0xa5, 0xc, 0x45, 0x35, 0x24, 0x5, 0xb1, 0xdd, 0xcc, 0xc8, 0x8b, 0x87, 0x10, 0xba, 0xda, 0xc5, 0xc2, 0xf9, 0x74, 0x51, 0x8b, 0x1, 0xb5}),
-				new(big.Int).SetBytes([]byte{0xb, 0x4e, 0xfa, 0xc5, 0xfc, 0xd7, 0xe4, 0x52, 0x61, 0xde, 0xd7, 0xa, 0xb7, 0xd7, 0xdd, 0x37, 0xce, 0xdf, 0x59, 0x5f, 0x3d, 0x0, 0x68, 0x3e, 0x41, 0x8e, 0xe3, 0x68, 0x78, 0xd9, 0x65, 0xc5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x27, 0x49, 0xe2, 0x92, 0xc5, 0xf8, 0x4e, 0xcc, 0xd5, 0x94, 0x25, 0xa8, 0xd0, 0x32, 0xf3, 0x1d, 0xa7, 0xae, 0xfd, 0x23, 0xf3, 0xb, 0x4, 0x2, 0x89, 0x21, 0xfb, 0x66, 0x3b, 0xc4, 0x41, 0x6f}),
-				new(big.Int).SetBytes([]byte{0x50, 0xcc, 0x2d, 0x4e, 0x37, 0x67, 0x2b, 0xc4, 0x40, 0x3d, 0x94, 0x99, 0xf, 0xca, 0xdb, 0x3e, 0xf5, 0x9e, 0x9f, 0xb6, 0x5e, 0xe9, 0x61, 0x5, 0x7e, 0x98, 0xbd, 0xe2, 0xfc, 0x6b, 0xbd, 0x8e}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x36, 0x3d, 0x90, 0xd4, 0x47, 0xb0, 0xc, 0x9c, 0x99, 0xce, 0xac, 0x5, 0xb6, 0x26, 0x2e, 0xe0, 0x53, 0x44, 0x1c, 0x7e, 0x55, 0x55, 0x2f, 0xfe, 0x52, 0x6b, 0xad, 0x8f, 0x83, 0xff, 0x46, 0x40}),
-				new(big.Int).SetBytes([]byte{0x4, 0xe2, 0x73, 0xad, 0xfc, 0x73, 0x22, 0x21, 0x95, 0x3b, 0x44, 0x53, 0x97, 0xf3, 0x36, 0x31, 0x45, 0xb9, 0xa8, 0x90, 0x8, 0x19, 0x9e, 0xcb, 0x62, 0x0, 0x3c, 0x7f, 0x3b, 0xee, 0x9d, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4c, 0x1b, 0x98, 0x66, 0xed, 0x9a, 0x7e, 0x9b, 0x55, 0x39, 0x73, 0xc6, 0xc9, 0x3b, 0x2, 0xbf, 0xb, 0x62, 0xfb, 0x1, 0x2e, 0xdf, 0xb5, 0x9d, 0xd2, 0x71, 0x2a, 0x5c, 0xaf, 0x92, 0xc5, 0x41}),
-				new(big.Int).SetBytes([]byte{0xc1, 0xf7, 0x92, 0xd3, 0x20, 0xbe, 0x8a, 0xf, 0x7f, 0xbc, 0xb7, 0x53, 0xce, 0x56, 0xe6, 0x9c, 0xc6, 0x52, 0xea, 0xd7, 0xe4, 0x3e, 0xb1, 0xad, 0x72, 0xc4, 0xf3, 0xfd, 0xc6, 0x8f, 0xe0, 0x20}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x44, 0x31, 0x40, 0x47, 0x90, 0xc5, 0xff, 0xb2, 0xba, 0x84, 0xa4, 0x40, 0xc0, 0x50, 0x94, 0x42, 0x6f, 0xf9, 0x5a, 0xb6, 0xea, 0xca, 0x4, 0x39, 0x4b, 0x89, 0x12, 0x16, 0xf6, 0xe5, 0x5d, 0xc8}),
-				new(big.Int).SetBytes([]byte{0x96, 0xb0, 0xc1, 0x42, 0xe6, 0x53, 0x66, 0xf8, 0xfe, 0x99, 0x83, 0x7f, 0x56, 0x42, 0xfe, 0xd7, 0xa6, 0x6a, 0x29, 0xb7, 0x9e, 0xaa, 0x2e, 0x50, 0x31, 0xd9, 0x44, 0xae, 0xdb, 0xe3, 0x23, 0xb3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa4, 0x8, 0x38, 0x77, 0xba, 0x83, 0xb1, 0x2b, 0x52, 0x9a, 0x2f, 0x3c, 0x7, 0x80, 0xb5, 0x4e, 0x32, 0x33, 0xed, 0xbc, 0x1a, 0x28, 0xf1, 0x35, 0xe0, 0xc8, 0xf2, 0x8c, 0xbe, 0xaa, 0xf3, 0xd1}),
-				new(big.Int).SetBytes([]byte{0x40, 0xe9, 0xf6, 0x12, 0xfe, 0xef, 0xbc, 0x79, 0xb8, 0xbf, 0x83, 0xd6, 0x93, 0x61, 0xb3, 0xe2, 0x20, 0x1, 0xe7, 0x57, 0x6e, 0xd1, 0xef, 0x90, 0xb1, 0x2b, 0x53, 0x4d, 0xf0, 0xb2, 0x54, 0xb9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9e, 0x22, 0xfe, 0x8d, 0x86, 0x6c, 0xa8, 0x7c, 0x12, 0x62, 0x43, 0xd5, 0xb9, 0x21, 0x8, 0x9d, 0xab, 0x7b, 0x7d, 0x47, 0xa, 0x87, 0xee, 0xa, 0xdf, 0xe9, 0x48, 0x5d, 0x70, 0x1b, 0x23, 0xa8}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x2f, 0xf0, 0xe9, 0xca, 0x12, 0x2d, 0x10, 0x17, 0x7f, 0x3f, 0x2, 0x9, 0x9c, 0x15, 0x33, 0xc0, 0xf7, 0xc9, 0x49, 0xfb, 0x51, 0x1c, 0xec, 0xf7, 0xa4, 0x13, 0xc5, 0x8, 0x84, 0xed, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe5, 0x38, 0xf, 0xe8, 0x57, 0x5f, 0x26, 0xad, 0xb7, 0x92, 0x4a, 0xe0, 0xd5, 0x81, 0x38, 0xd2, 0x68, 0x11, 0x27, 0x76, 0xb1, 0x1b, 0xd3, 0x4b, 0x3e, 0xb5, 0xe1, 0x96, 0x33, 0xf0, 0xe9, 0xaa}),
-				new(big.Int).SetBytes([]byte{0xb9, 0x7f, 0xd8, 0x73, 0x90, 0x87, 0xb4, 0x1d, 0x5b, 0x69, 0x13, 0x63, 0x92, 0x4c, 0x8e, 0x1, 0x6f, 0xb9, 0x4e, 0x5d, 0xf4, 0x68, 0x31, 0x8e, 0xc4, 0xba, 0x41, 0x36, 0x40, 0x82, 0x72, 0xf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x50, 0x8d, 0xf6, 0xd5, 0x3, 0xce, 0x2a, 0x8d, 0x2e, 0xdd, 0x69, 0xe6, 0x47, 0x5, 0x30, 0x6d, 0xcd, 0x5e, 0xe5, 0x1f, 0x5f, 0x5c, 0xf4, 0x75, 0xdd, 0x74, 0x8, 0xbf, 0x7, 0x1a, 0x70, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x15, 0x4c, 0x43, 0x9b, 0x93, 0x3b, 0xc4, 0x2d, 0x77, 0x73, 0x4, 0xaa, 0x73, 0x3e, 0x49, 0xc5, 0x4e, 0xc0, 0x32, 0x28, 0xee, 0x8a, 0xad, 0xfe, 0xdf, 0x2e, 0x5b, 0xf7, 0x29, 0x95, 0x9, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa8, 0x4, 0xc6, 0x41, 0xd2, 0x8c, 0xc0, 0xb5, 0x3a, 0x4e, 0x3e, 0x1a, 0x2f, 0x56, 0xc8, 0x6f, 0x6e, 0xd, 0x88, 0xa, 0x45, 0x42, 0x3, 0xb9, 0x8c, 0xd3, 0xdb, 0x5a, 0x79, 0x40, 0xd3, 0x3a}),
-				new(big.Int).SetBytes([]byte{0x95, 0xbe, 0x83, 0x25, 0x2b, 0x2f, 0xa6, 0xd0, 0x3d, 0xec, 0x28, 0x42, 0xc1, 0x60, 0x47, 0xe8, 0x1a, 0xf1, 0x8c, 0xa8, 0x9c, 0xf7, 0x36, 0xa9, 0x43, 0xce, 0x95, 0xfa, 0x6d, 0x46, 0x96, 0x7a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe3, 0xdb, 0xff, 0x84, 0x55, 0x10, 0x97, 0x63, 0x33, 0x8d, 0xf5, 0x81, 0x93, 0x1, 0x25, 0xb2, 0xda, 0xb9, 0x21, 0xc2, 0x59, 0xcb, 0x22, 0xf, 0x6e, 0xaf, 0xda, 0x76, 0xce, 0x1a, 0xbe, 0x11}),
-				new(big.Int).SetBytes([]byte{0x6, 0xf2, 0xf9, 0x9, 0x9a, 0x34, 0x14, 0x21, 0x64, 0x38, 0xfa, 0x75, 0xdb, 0x8a, 0x8e, 0xf3, 0xd3, 0xc9, 0x7d, 0x90, 0x3c, 0x6b, 0x5c, 0x41, 0x4b, 0x49, 0xad, 0x54, 0x9f, 0xa8, 0xde, 0x63}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf7, 0xa4, 0xbe, 0x3d, 0xfc, 0x65, 0x79, 0xfb, 0x43, 0xc5, 0xa9, 0x60, 0x8, 0x90, 0x89, 0x3a, 0xf4, 0x2, 0x61, 0x65, 0xed, 0x9e, 0x3a, 0xb2, 0xe6, 0x92, 0x93, 0x78, 0xb6, 0x39, 0x68, 0xb6}),
-				new(big.Int).SetBytes([]byte{0xd6, 0xdd, 0xef, 0x6c, 0xe8, 0x23, 0xc6, 0x15, 0x21, 0xd2, 0x90, 0x30, 0x38, 0xca, 0x52, 0xd2, 0x99, 0x70, 0x2f, 0xb8, 0xd1, 0x10, 0x10, 0x5e, 0x49, 0x4d, 0xda, 0x32, 0xd0, 0xc3, 0x48, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x19, 0xac, 0xe0, 0x64, 0xc7, 0xde, 0x94, 0xd, 0x87, 0x8d, 0x9c, 0x13, 0xa0, 0x7d, 0x1, 0x4a, 0xf6, 0x1b, 0x7c, 0x12, 0xe4, 0x2a, 0x46, 0xff, 0xdc, 0xf1, 0xb2, 0x36, 0x3, 0x59, 0x34, 0x49}),
-				new(big.Int).SetBytes([]byte{0xe3, 0x79, 0x92, 0x3, 0x52, 0x68, 0xa3, 0x33, 0xbf, 0xeb, 0xd7, 0x39, 0xd9, 0x40, 0x2c, 0x46, 0xac, 0x71, 0xb, 0x9f, 0x40, 0x84, 0x6, 0x8a, 0x3a, 0x41, 0x4b, 0x93, 0xad, 0xf8, 0x36, 0x31}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x93, 0x9f, 0xf3, 0xe4, 0xa3, 0xed, 0x9a, 0xf0, 0xc3, 0x95, 0xf0, 0xd1, 0x5d, 0x6b, 0x1f, 0x51, 0x8a, 0x8f, 0xd3, 0xa7, 0xd9, 0xff, 0x42, 0xde, 0x1a, 0xed, 0x36, 0x1b, 0xee, 0xbc, 0x61, 0xd6}),
-				new(big.Int).SetBytes([]byte{0xde, 0xab, 0x3b, 0xcf, 0x8, 0xb7, 0xa9, 0xe, 0x9d, 0x46, 0xfc, 0x4e, 0xef, 0x1, 0x6c, 0x4, 0x98, 0xb, 0xf2, 0x6e, 0xb7, 0x8c, 0x7f, 0x82, 0xe7, 0x5e, 0xa4, 0x66, 0xa3, 0xf5, 0xcb, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd8, 0x74, 0xc, 0xec, 0x20, 0xf8, 0x7d, 0xaa, 0x10, 0x8c, 0x33, 0x22, 0x8e, 0xa8, 0x8e, 0x31, 0xa, 0xf, 0xe6, 0x74, 0xfd, 0xb, 0x3b, 0xb5, 0xee, 0x3e, 0x98, 0x92, 0xcc, 0xba, 0x6b, 0x63}),
-				new(big.Int).SetBytes([]byte{0x64, 0x72, 0xc1, 0x33, 0xc6, 0xb9, 0x32, 0xbf, 0x37, 0x8a, 0x6e, 0xe9, 0x90, 0x3a, 0xc3, 0x7d, 0x40, 0x10, 0x4f, 0x5c, 0xc3, 0x81, 0x69, 0x4a, 0xbe, 0xea, 0x36, 0xc0, 0x69, 0x34, 0xc5, 0xf3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1, 0x9a, 0x9e, 0x5f, 0x1c, 0x4c, 0xae, 0x30, 0x79, 0x7a, 0x98, 0xdb, 0xa2, 0x40, 0x51, 0xa4, 0x38, 0xea, 0x75, 0x5c, 0x7b, 0x6, 0x20, 0x94, 0xa3, 0x4e, 0xd6, 0xe8, 0x8a, 0x8f, 0xf2, 0xcf}),
-				new(big.Int).SetBytes([]byte{0x1e, 0x66, 0x1c, 0x67, 0xea, 0x85, 0xaf, 0x5e, 0xfa, 0x90, 0x62, 0x92, 0x50, 0x12, 0xb7, 0x30, 0xc, 0xe7, 0xeb, 0xee, 0x88, 0x48, 0x6d, 0x35, 0x3a, 0x77, 0xe7, 0x66, 0xdb, 0xe, 0x43, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0xac, 0x33, 0x39, 0x1b, 0x50, 0x60, 0x83, 0x64, 0x88, 0x3e, 0x76, 0x2f, 0x29, 0xa, 0x50, 0xd9, 0xfd, 0x51, 0x6a, 0xe6, 0xc, 0x6b, 0x27, 0x61, 0x94, 0xff, 0x2c, 0x1b, 0x3e, 0xc0, 0x38}),
-				new(big.Int).SetBytes([]byte{0x91, 0x63, 0xd7, 0x6, 0xd5, 0x5c, 0x92, 0xd9, 0x78, 0x77, 0x92, 0x49, 0xe9, 0x91, 0xfe, 0x97, 0x2, 0x19, 0x4, 0x3c, 0xb, 0x4f, 0xbb, 0xca, 0x16, 0xea, 0xa3, 0xf1, 0x10, 0x24, 0x62, 0x79}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8b, 0x4b, 0x5f, 0x16, 0x5d, 0xf3, 0xc2, 0xbe, 0x8c, 0x62, 0x44, 0xb5, 0xb7, 0x45, 0x63, 0x88, 0x43, 0xe4, 0xa7, 0x81, 0xa1, 0x5b, 0xcd, 0x1b, 0x69, 0xf7, 0x9a, 0x55, 0xdf, 0xfd, 0xf8, 0xc}),
-				new(big.Int).SetBytes([]byte{0x4a, 0xad, 0xa, 0x6f, 0x68, 0xd3, 0x8, 0xb4, 0xb3, 0xfb, 0xd7, 0x81, 0x3a, 0xb0, 0xda, 0x4, 0xf9, 0xe3, 0x36, 0x54, 0x61, 0x62, 0xee, 0x56, 0xb3, 0xef, 0xf0, 0xc6, 0x5f, 0xd4, 0xfd, 0x36}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xed, 0xc, 0x5c, 0xe4, 0xe1, 0x32, 0x91, 0x71, 0x8c, 0xe1, 0x7c, 0x7e, 0xc8, 0x3c, 0x61, 0x10, 0x71, 0xaf, 0x64, 0xee, 0x41, 0x7c, 0x99, 0x7a, 0xbb, 0x3f, 0x26, 0x71, 0x47, 0x55, 0xe4, 0xbe}),
-				new(big.Int).SetBytes([]byte{0x22, 0x1a, 0x9f, 0xc7, 0xbc, 0x23, 0x45, 0xbd, 0xbf, 0x3d, 0xad, 0x7f, 0x5a, 0x7e, 0xa6, 0x80, 0x49, 0xd9, 0x39, 0x25, 0x76, 0x3d, 0xda, 0xb1, 0x63, 0xf9, 0xfa, 0x6e, 0xa0, 0x7b, 0xf4, 0x2f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x70, 0x29, 0xbd, 0x7a, 0x92, 0xff, 0x35, 0x2f, 0xb, 0x6a, 0xbe, 0xd6, 0xc0, 0x58, 0xf7, 0x8e, 0x3d, 0x44, 0x67, 0x23, 0x55, 0x2d, 0x30, 0xe2, 0xa0, 0xa2, 0xa5, 0x82, 0xf5, 0x58, 0x12, 0xdd}),
-				new(big.Int).SetBytes([]byte{0xb0, 0xee, 0xfa, 0xda, 0xfd, 0xe8, 0xb3, 0xd2, 0x7d, 0xd6, 0x54, 0x4a, 0xe3, 0x6, 0x83, 0xac, 0x47, 0xda, 0xe8, 0x42, 0x43, 0xb2, 0xc7, 0x3c, 0x72, 0x1c, 0xc6, 0x6b, 0x1a, 0x2d, 0x29, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfa, 0xec, 0xb0, 0x13, 0xc4, 0x4c, 0xe6, 0x94, 0xb3, 0xb1, 0x5c, 0x3f, 0x83, 0xf1, 0xfa, 0xe8, 0xe5, 0x32, 0x54, 0x56, 0x6e, 0x5, 0x52, 0xce, 0xd4, 0xb6, 0xe6, 0xc8, 0x7, 0xce, 0xc8, 0xab}),
-				new(big.Int).SetBytes([]byte{0xcc, 0x9, 0xb5, 0xe9, 0xe, 0x9e, 0xcb, 0x57, 0xfc, 0x2e, 0x2, 0xc6, 0xec, 0x2f, 0xb1, 0x3d, 0x9c, 0x32, 0xb2, 0x86, 0xb8, 0x5e, 0x2e, 0x2e, 0x89, 0x81, 0xdf, 0xd9, 0xab, 0x15, 0x50, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9c, 0xcf, 0xed, 0xca, 0xea, 0xe6, 0x5c, 0x99, 0x0, 0x9d, 0x41, 0x9, 0xd8, 0xcf, 0x75, 0x60, 0x57, 0x45, 0xbe, 0xba, 0x49, 0x56, 0x5b, 0x6a, 0x49, 0xce, 0x56, 0x83, 0xbd, 0x48, 0x6e, 0xd1}),
-				new(big.Int).SetBytes([]byte{0x7c, 0x2f, 0x4d, 0x71, 0x3d, 0x6a, 0x32, 0xcf, 0xb6, 0x12, 0x24, 0x81, 0x20, 0xb, 0x34, 0x11, 0x24, 0x21, 0x67, 0x59, 0x69, 0x59, 0x2a, 0xa2, 0x4f, 0x6d, 0x59, 0xed, 0x75, 0xe9, 0x5d, 0x8d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf4, 0x2c, 0x10, 0x2a, 0xf4, 0x7e, 0x6e, 0x47, 0x4a, 0xa2, 0x64, 0xb8, 0x18, 0xa3, 0x4e, 0x7e, 0xdd, 0x2f, 0x62, 0xbb, 0x5c, 0xc6, 0x23, 0x64, 0xdc, 0xda, 0xbf, 0xf9, 0xb1, 0x81, 0xfd, 0xc2}),
-				new(big.Int).SetBytes([]byte{0x57, 0x50, 0x3a, 0xb4, 0x6c, 0xfe, 0x80, 0x6c, 0x78, 0xfa, 0xd0, 0x5c, 0xb8, 0x6f, 0xe2, 0x2a, 0x4c, 0x15, 0x50, 0x2d, 0x9a, 0x2a, 0xcf, 0x26, 0x81, 0xf0, 0x0, 0x93, 0xa4, 0x85, 0xd7, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcd, 0x9a, 0x4b, 0x87, 0x63, 0x41, 0x41, 0x43, 0x35, 0xa8, 0xfd, 0xe3, 0x3c, 0xa4, 0xdf, 0x19, 0xd0, 0xc7, 0x45, 0x76, 0xba, 0x2d, 0x4a, 0xb7, 0xa2, 0x6, 0xb1, 0xa7, 0x5b, 0xd0, 0xea, 0xca}),
-				new(big.Int).SetBytes([]byte{0xf0, 0x45, 0x58, 0x79, 0xa1, 0xe8, 0xf2, 0x3e, 0x81, 0x54, 0x88, 0xae, 0x93, 0x3e, 0xa0, 0x8b, 0x1, 0x27, 0xb3, 0x8e, 0xed, 0x6f, 0x63, 0x4f, 0x6e, 0x6f, 0xaf, 0xb5, 0xab, 0xff, 0x4a, 0xcc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9, 0xbb, 0x8a, 0x13, 0x2d, 0xca, 0xd2, 0xf2, 0xc8, 0x73, 0x1a, 0xb, 0x37, 0xcb, 0xca, 0xfd, 0xb3, 0xb2, 0xdd, 0x82, 0x4f, 0x23, 0xcd, 0x3e, 0x7, 0xf6, 0x4e, 0xae, 0x9a, 0xd1, 0xb1, 0xf7}),
-				new(big.Int).SetBytes([]byte{0x94, 0x5b, 0xb2, 0xb2, 0xaf, 0xee, 0xe3, 0xb9, 0xb6, 0xf9, 0xdd, 0x28, 0x4f, 0x86, 0x3e, 0x85, 0xf, 0x54, 0xa8, 0x40, 0xf4, 0x75, 0x2d, 0x53, 0x64, 0x13, 0x6, 0x27, 0xc3, 0x81, 0x1c, 0x80}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xad, 0x9, 0x88, 0x2f, 0x88, 0xed, 0x9b, 0xea, 0x88, 0x4f, 0x2d, 0x2e, 0x93, 0xd6, 0x30, 0x94, 0x49, 0x3, 0xd7, 0xa2, 0x3e, 0xb2, 0x76, 0xcb, 0xf2, 0x9, 0x53, 0xa3, 0xc7, 0xbc, 0x57, 0xc6}),
-				new(big.Int).SetBytes([]byte{0x72, 0x43, 0xc0, 0x8c, 0x42, 0xfb, 0xa5, 0x2b, 0xa2, 0x81, 0x86, 0xc7, 0x29, 0xda, 0xbe, 0x5f, 0x7b, 0x31, 0x1d, 0x37, 0x35, 0x90, 0xe2, 0xd3, 0xca, 0x32, 0xc0, 0xed, 0xae, 0x4a, 0xa, 0xb8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe2, 0x60, 0xa0, 0xcc, 0x8, 0x2b, 0xa6, 0xbb, 0xe6, 0x69, 0xed, 0x95, 0x36, 0x6a, 0x14, 0x23, 0x37, 0xa7, 0xa4, 0xd, 0x63, 0xd4, 0x18, 0xa4, 0x9e, 0x2d, 0xab, 0x64, 0x4f, 0x92, 0x7d, 0xc7}),
-				new(big.Int).SetBytes([]byte{0x37, 0xee, 0x67, 0x44, 0xb0, 0xda, 0x9d, 0x67, 0xf0, 0x73, 0xcb, 0x3d, 0x72, 0x5b, 0xf4, 0x40, 0xed, 0xbc, 0xc0, 0xb4, 0x46, 0x2c, 0xb8, 0x2d, 0xa4, 0x5f, 0x36, 0xf4, 0x46, 0xae, 0xf3, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd9, 0xd1, 0x29, 0xa, 0xeb, 0x3c, 0xa4, 0x1f, 0x1e, 0xad, 0xa2, 0xd2, 0xa9, 0xfa, 0xd2, 0xe0, 0xf5, 0x6, 0xa9, 0x37, 0xaf, 0xf, 0x18, 0x62, 0xea, 0x83, 0xc, 0x72, 0xab, 0xa5, 0x63, 0x2}),
-				new(big.Int).SetBytes([]byte{0x7e, 0xb5, 0x31, 0x13, 0xec, 0x2d, 0x3e, 0xed, 0x2, 0x97, 0x15, 0xc6, 0x72, 0x63, 0x86, 0xe4, 0x17, 0x39, 0xae, 0xcd, 0x9f, 0xa7, 0x4f, 0x2a, 0xea, 0xad, 0xda, 0xfa, 0x88, 0x29, 0x1c, 0x29}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0xcf, 0xcf, 0x6a, 0x24, 0xc7, 0x52, 0x4b, 0xca, 0x5a, 0x78, 0x40, 0xee, 0x7c, 0xfd, 0xec, 0x49, 0x37, 0x6f, 0xe2, 0x65, 0xe1, 0x96, 0x8c, 0x38, 0x66, 0xd4, 0x7d, 0xee, 0xdd, 0x7d, 0xd6}),
-				new(big.Int).SetBytes([]byte{0x21, 0x84, 0x6a, 0x34, 0x97, 0x6a, 0x77, 0x48, 0x62, 0x8, 0x9, 0x6a, 0x40, 0x9f, 0x56, 0x1e, 0x7a, 0xd, 0x8c, 0xd2, 0x3, 0x49, 0xa0, 0x8b, 0x25, 0xfd, 0x44, 0xae, 0xfe, 0x8, 0xe3, 0x30}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbc, 0x50, 0x79, 0xde, 0x53, 0x9d, 0x6c, 0xef, 0x91, 0xcd, 0x21, 0xac, 0x6, 0x3f, 0x55, 0x84, 0x2, 0xb1, 0x26, 0x2, 0x3f, 0x54, 0xa5, 0xfd, 0xba, 0xcd, 0x37, 0x4, 0xf4, 0xd1, 0x24, 0x3a}),
-				new(big.Int).SetBytes([]byte{0x65, 0x6, 0x2a, 0x3b, 0x3a, 0x70, 0x5c, 0xbb, 0x8e, 0xec, 0x72, 0xb9, 0x4d, 0xff, 0xc8, 0xd7, 0x5f, 0x7e, 0xe4, 0xf5, 0xb1, 0xf4, 0x71, 0x69, 0xf5, 0xc9, 0x51, 0x68, 0x85, 0x5d, 0xb6, 0x8a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x23, 0x5c, 0xaa, 0xdd, 0xe8, 0x5a, 0xc4, 0x9f, 0x3e, 0xcb, 0xe6, 0x8f, 0x10, 0xd2, 0x2c, 0x2, 0xb8, 0xf9, 0x40, 0x58, 0xfb, 0x2d, 0x5, 0x96, 0x69, 0x6d, 0x3, 0xfd, 0xf6, 0xab, 0xf8, 0x3d}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x12, 0xf9, 0xc7, 0x45, 0xfd, 0x47, 0xeb, 0xee, 0x42, 0x32, 0xa5, 0xbc, 0x93, 0xad, 0x89, 0x3f, 0x5, 0x18, 0xd6, 0x32, 0x36, 0x10, 0xae, 0x7f, 0x4e, 0xc9, 0xe9, 0xd, 0x33, 0xe1, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0x31, 0xa7, 0x7e, 0x50, 0x5f, 0xc7, 0xe8, 0x91, 0x3d, 0xea, 0xae, 0x7d, 0xb2, 0x62, 0x5a, 0xc2, 0x87, 0xa6, 0x65, 0xdc, 0x9, 0x81, 0x69, 0x8, 0xd2, 0x44, 0xe6, 0xb7, 0x4a, 0x3f, 0x9f}),
-				new(big.Int).SetBytes([]byte{0x22, 0x24, 0x1e, 0xc9, 0x60, 0x98, 0x57, 0x5b, 0x6, 0xb1, 0x8f, 0x38, 0xc7, 0x80, 0x27, 0x53, 0xcb, 0xf6, 0xfb, 0x51, 0xb6, 0xe0, 0xa, 0x75, 0x4f, 0xcb, 0x2, 0x41, 0x30, 0x1e, 0xb, 0xa7}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x72, 0x3c, 0xba, 0xa6, 0xe5, 0xdb, 0x99, 0x6d, 0x6b, 0xf7, 0x71, 0xc0, 0xb, 0xd5, 0x48, 0xc7, 0xb7, 0x0, 0xdb, 0xff, 0xa6, 0xc0, 0xe7, 0x7b, 0xcb, 0x61, 0x15, 0x92, 0x52, 0x32, 0xfc, 0xda}),
-				new(big.Int).SetBytes([]byte{0x96, 0xe8, 0x67, 0xb5, 0x59, 0x5c, 0xc4, 0x98, 0xa9, 0x21, 0x13, 0x74, 0x88, 0x82, 0x4d, 0x6e, 0x26, 0x60, 0xa0, 0x65, 0x37, 0x79, 0x49, 0x48, 0x1, 0xdc, 0x6, 0x9d, 0x9e, 0xb3, 0x9f, 0x5f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x57, 0xef, 0xa7, 0x86, 0x43, 0x7b, 0x74, 0x4d, 0x34, 0x3d, 0x7d, 0xc4, 0x57, 0x73, 0xa3, 0xc6, 0x2d, 0x24, 0xa, 0x43, 0x7, 0x98, 0x49, 0x7, 0x1f, 0xd3, 0x83, 0xd6, 0xc, 0xa0, 0x30, 0xd5}),
-				new(big.Int).SetBytes([]byte{0xd7, 0x12, 0xdb, 0xb, 0xd1, 0xb4, 0x85, 0x18, 0x89, 0x36, 0x27, 0xc9, 0x28, 0xde, 0x3, 0xec, 0x68, 0x9b, 0x6d, 0x2a, 0xe5, 0xe9, 0x97, 0x4a, 0xb0, 0x7a, 0xb4, 0x42, 0x74, 0xb0, 0x2f, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6d, 0xde, 0x9c, 0xf3, 0x17, 0xaa, 0xca, 0xd4, 0x0, 0xc6, 0x27, 0x32, 0x12, 0x18, 0x1f, 0xcb, 0x57, 0x5a, 0x22, 0x4b, 0x69, 0xd0, 0x21, 0x13, 0x25, 0x67, 0xe0, 0x9e, 0x80, 0x63, 0x3c, 0xb1}),
-				new(big.Int).SetBytes([]byte{0x91, 0x88, 0xfb, 0xe7, 0xa7, 0x7, 0xe4, 0x1d, 0x5c, 0x99, 0xef, 0x86, 0xa1, 0xba, 0x66, 0xa8, 0x80, 0xb2, 0x7f, 0xda, 0xcf, 0x85, 0x9e, 0xf3, 0x57, 0xdd, 0x49, 0xaa, 0x67, 0xce, 0x6b, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x26, 0x4b, 0xbd, 0x43, 0x6a, 0x28, 0xbc, 0x42, 0xa2, 0xdf, 0x7e, 0x9c, 0xd5, 0x22, 0x6c, 0xb9, 0x10, 0x80, 0x57, 0x7e, 0x32, 0x7b, 0x1, 0x2a, 0x7f, 0xaf, 0xc7, 0x77, 0xc, 0x58, 0x4d, 0xd5}),
-				new(big.Int).SetBytes([]byte{0xd8, 0x7c, 0x6f, 0xa9, 0x4e, 0xe0, 0x93, 0xb4, 0xd4, 0xf7, 0x5c, 0xe2, 0x4c, 0x33, 0xbe, 0x22, 0x6a, 0x11, 0x82, 0x43, 0x71, 0x7b, 0x8d, 0x8d, 0xe6, 0x12, 0x27, 0x93, 0x77, 0x4, 0xab, 0x11}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x48, 0x6f, 0xa7, 0x2c, 0xd5, 0xb5, 0xcd, 0xe8, 0x13, 0xc4, 0xbb, 0x7f, 0x8e, 0x47, 0xb8, 0x50, 0x8, 0x5a, 0xf, 0x71, 0x15, 0xf1, 0x25, 0x22, 0x41, 0x9a, 0x51, 0x8d, 0x29, 0x33, 0xf3, 0xc5}),
-				new(big.Int).SetBytes([]byte{0x62, 0xe1, 0x23, 0x19, 0xf5, 0x6b, 0xdd, 0x43, 0xe4, 0x8c, 0x48, 0xba, 0xec, 0xc8, 0xf1, 0x9f, 0x62, 0xd9, 0xb7, 0x83, 0xcf, 0xf, 0x23, 0xb7, 0x9a, 0xd4, 0xa7, 0x1a, 0xca, 0xfb, 0xf, 0x53}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0xd0, 0x64, 0xf0, 0xfc, 0x69, 0xa1, 0x22, 0x2c, 0x21, 0xf6, 0x2e, 0xe, 0xc9, 0x70, 0xe9, 0x40, 0x90, 0x8a, 0xb8, 0x19, 0xbb, 0xfa, 0xce, 0x4d, 0xb, 0xd7, 0x6a, 0x44, 0xe5, 0x46, 0x7d}),
-				new(big.Int).SetBytes([]byte{0x89, 0x97, 0x4f, 0x2e, 0xd3, 0x34, 0x2, 0xcc, 0x3, 0xf7, 0xc6, 0x6f, 0xa8, 0x50, 0x86, 0x1f, 0xda, 0x5f, 0xb3, 0xb8, 0x54, 0xf1, 0x7c, 0xcd, 0x79, 0x73, 0x0, 0xfd, 0x1e, 0x9c, 0xb3, 0xfa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x24, 0x79, 0x69, 0x74, 0xa8, 0x94, 0xaf, 0x4f, 0xb6, 0x64, 0xff, 0x27, 0xb7, 0x6a, 0x53, 0x3, 0x67, 0x73, 0x75, 0xfb, 0xe6, 0xf1, 0x22, 0x4, 0xeb, 0xd5, 0x94, 0x22, 0x5e, 0x99, 0xf7, 0x28}),
-				new(big.Int).SetBytes([]byte{0xe3, 0xd7, 0x8d, 0x44, 0x68, 0x8f, 0x30, 0x1, 0xec, 0x52, 0xe8, 0x7e, 0x7d, 0x8d, 0x66, 0x4a, 0x5a, 0xdb, 0xf3, 0xc0, 0x95, 0x75, 0xa2, 0xd8, 0x37, 0xa0, 0x5, 0x16, 0xeb, 0xaa, 0xeb, 0xff}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0x4c, 0x65, 0x24, 0xbd, 0x40, 0xd2, 0xbb, 0xda, 0xc8, 0x5c, 0x5, 0x62, 0x36, 0xa7, 0x9d, 0xa7, 0x8b, 0xc6, 0x1f, 0xd5, 0xbd, 0xec, 0x9d, 0x2b, 0xf2, 0x6b, 0xd8, 0x4b, 0x24, 0x38, 0xe8}),
-				new(big.Int).SetBytes([]byte{0xb5, 0x20, 0x1f, 0xd9, 0x92, 0xf9, 0x62, 0x80, 0xfd, 0x79, 0x21, 0x95, 0x5, 0x1, 0x9e, 0x3a, 0x7e, 0x5d, 0x3c, 0x60, 0xa0, 0xe3, 0x9b, 0x2b, 0xc2, 0xe2, 0xc8, 0xdb, 0xf1, 0x86, 0x61, 0xf4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x39, 0xcb, 0xda, 0xa3, 0xd5, 0x5f, 0xf0, 0x63, 0x1a, 0x59, 0xee, 0x76, 0xb3, 0x83, 0x24, 0x91, 0x6f, 0x9e, 0xf7, 0x23, 0x92, 0x60, 0x49, 0xc9, 0xe0, 0xc6, 0xd4, 0x2f, 0xb0, 0x7, 0x9a}),
-				new(big.Int).SetBytes([]byte{0xab, 0xea, 0xdb, 0xde, 0x13, 0x86, 0x39, 0x83, 0x4d, 0xa9, 0x36, 0xd0, 0x27, 0x8d, 0xa5, 0x8b, 0x9b, 0xfb, 0x3f, 0xbc, 0x88, 0x2a, 0x2, 0x30, 0xfe, 0x22, 0x97, 0x30, 0x2c, 0x56, 0x90, 0xba}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0x84, 0x2e, 0x5b, 0xaa, 0x80, 0x2b, 0xdd, 0x28, 0x92, 0x63, 0xb7, 0xa, 0xa8, 0xe6, 0xfc, 0x40, 0xc1, 0xe, 0x34, 0xb6, 0x26, 0x63, 0xff, 0x5f, 0x46, 0xf2, 0x89, 0xb9, 0x80, 0x86, 0xc8}),
-				new(big.Int).SetBytes([]byte{0x89, 0xf2, 0x4f, 0xf5, 0xfc, 0x92, 0xe7, 0x2e, 0x1b, 0x93, 0x70, 0x46, 0x31, 0xb0, 0x77, 0xc0, 0x37, 0xb8, 0xe5, 0x76, 0xb7, 0x5e, 0x7d, 0x4e, 0xf9, 0x94, 0xb5, 0x13, 0x8e, 0xe9, 0x39, 0xa9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe5, 0xa3, 0x1d, 0x6c, 0x32, 0x7d, 0x61, 0xba, 0x56, 0xa3, 0x9e, 0x86, 0xc5, 0x34, 0xae, 0xd2, 0x62, 0xe3, 0xd4, 0xea, 0x9d, 0x44, 0x43, 0xa7, 0x79, 0x33, 0x0, 0xb2, 0xe4, 0xf7, 0xab, 0x73}),
-				new(big.Int).SetBytes([]byte{0x37, 0x78, 0x8c, 0x3d, 0x8d, 0x1e, 0x9d, 0x7a, 0xc, 0x11, 0x8a, 0x97, 0x7a, 0x4e, 0xce, 0x5d, 0xbf, 0x8c, 0x44, 0x49, 0xa8, 0xc, 0xba, 0x21, 0x39, 0x13, 0xa3, 0xfc, 0x70, 0x56, 0x1f, 0x42}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2d, 0xcf, 0xca, 0xb8, 0xc9, 0x39, 0x37, 0xbd, 0xed, 0xf1, 0xb3, 0x3d, 0xc7, 0x4f, 0x97, 0x2a, 0x8b, 0xb5, 0xfc, 0x9d, 0x10, 0x1c, 0xfe, 0x67, 0xcc, 0xa8, 0x1c, 0xb9, 0x13, 0x61, 0x3b, 0xec}),
-				new(big.Int).SetBytes([]byte{0x46, 0xdb, 0xc4, 0xdd, 0x94, 0x74, 0xa4, 0x12, 0x65, 0xf0, 0x88, 0xb7, 0xd6, 0xf6, 0xc6, 0xf4, 0x37, 0x32, 0xcf, 0xba, 0xd3, 0x3f, 0x5f, 0x38, 0x3e, 0x73, 0x9, 0x24, 0x9a, 0x3, 0x92, 0x15}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcc, 0x38, 0x9d, 0x4a, 0xd, 0x15, 0x34, 0x47, 0xdf, 0x50, 0xdd, 0x98, 0x20, 0x51, 0xdc, 0xf1, 0x1f, 0xc5, 0xd4, 0x20, 0x96, 0xbf, 0x71, 0xb5, 0x88, 0xf9, 0x29, 0xa3, 0xc1, 0x4d, 0xcd, 0x86}),
-				new(big.Int).SetBytes([]byte{0x93, 0xae, 0x4f, 0xd6, 0x60, 0xf6, 0xbb, 0xed, 0x9b, 0x56, 0x8b, 0xdb, 0xbe, 0xa9, 0x10, 0xca, 0x5, 0xc5, 0x7e, 0x18, 0xfe, 0x7e, 0x78, 0x74, 0x68, 0xeb, 0x6d, 0xd9, 0xc8, 0xf0, 0xa8, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0xae, 0x53, 0xd1, 0xae, 0x75, 0x82, 0x28, 0x8e, 0xc4, 0xbb, 0x4, 0x28, 0xf0, 0xe8, 0xb6, 0xb5, 0x67, 0xd5, 0xff, 0x4f, 0xa3, 0xeb, 0x64, 0x84, 0x43, 0xd7, 0x64, 0xdf, 0xb4, 0xf6, 0xbe}),
-				new(big.Int).SetBytes([]byte{0x69, 0x33, 0x59, 0x4d, 0x44, 0xb3, 0xa0, 0x98, 0x7c, 0x95, 0x67, 0x9c, 0x7c, 0x1b, 0x54, 0x7c, 0x4c, 0xe, 0xfa, 0x67, 0xc3, 0xd4, 0x4, 0x36, 0xc0, 0x42, 0x25, 0x67, 0x4a, 0xee, 0xb9, 0xa2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7f, 0x92, 0x91, 0xc8, 0x9d, 0x71, 0xe9, 0x2, 0x37, 0x50, 0xa1, 0x7e, 0xc, 0xf5, 0xed, 0x1f, 0xb, 0x3e, 0x1e, 0xe2, 0x10, 0x87, 0x71, 0x47, 0x24, 0xf9, 0xc6, 0xeb, 0x78, 0x81, 0x93, 0x11}),
-				new(big.Int).SetBytes([]byte{0x9d, 0xa0, 0xd, 0x10, 0x63, 0xec, 0x3e, 0xf0, 0xf5, 0xd0, 0xde, 0x8c, 0x45, 0x24, 0x15, 0xb6, 0x12, 0xa, 0xaa, 0x40, 0x9c, 0x86, 0xa1, 0x74, 0xaa, 0x9f, 0x9b, 0x57, 0xac, 0x2e, 0xb1, 0x25}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xee, 0xbf, 0xa4, 0xd4, 0x93, 0xbe, 0xbf, 0x98, 0xba, 0x5f, 0xee, 0xc8, 0x12, 0xc2, 0xd3, 0xb5, 0x9, 0x47, 0x96, 0x12, 0x37, 0xa9, 0x19, 0x83, 0x9a, 0x53, 0x3e, 0xca, 0xe, 0x7d, 0xd7, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x5d, 0x9a, 0x8c, 0xa3, 0x97, 0xe, 0xf0, 0xf2, 0x69, 0xee, 0x7e, 0xda, 0xf1, 0x78, 0x8, 0x9d, 0x9a, 0xe4, 0xcd, 0xc3, 0xa7, 0x11, 0xf7, 0x12, 0xdd, 0xfd, 0x4f, 0xda, 0xe1, 0xde, 0x89, 0x99}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x1c, 0x4a, 0xd7, 0xa7, 0xa9, 0x7b, 0xfd, 0xa6, 0x1c, 0x60, 0x31, 0xc1, 0x18, 0x49, 0x5f, 0xc4, 0xea, 0x4b, 0xc0, 0x8f, 0x67, 0x66, 0xd6, 0x76, 0xbe, 0xe9, 0x8, 0x47, 0xd2, 0x97, 0xfd}),
-				new(big.Int).SetBytes([]byte{0x93, 0x6a, 0xf5, 0x3b, 0x23, 0x8e, 0xee, 0xe4, 0x8f, 0x3e, 0x5f, 0xa7, 0x9, 0x91, 0x5e, 0xcc, 0xf0, 0x45, 0x10, 0x32, 0xdb, 0x93, 0x9c, 0x0, 0x93, 0xac, 0xe3, 0x18, 0x7d, 0x49, 0x3f, 0xc5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x43, 0x7a, 0x86, 0x20, 0x42, 0x76, 0xd4, 0x50, 0x36, 0xff, 0xb8, 0x12, 0x6f, 0x6e, 0x68, 0x14, 0x73, 0xa5, 0x9f, 0x93, 0x88, 0x97, 0xfa, 0xf0, 0xf3, 0xf6, 0x78, 0xff, 0xbb, 0x7c, 0xec, 0xeb}),
-				new(big.Int).SetBytes([]byte{0xb, 0x91, 0x6b, 0xa1, 0x3e, 0xea, 0xc3, 0x2f, 0x69, 0xb8, 0xfe, 0xb6, 0x99, 0xd2, 0x97, 0xff, 0x87, 0x22, 0xf, 0xcf, 0xdc, 0x8f, 0x97, 0x82, 0x73, 0x63, 0xbc, 0xc3, 0x56, 0xc1, 0x81, 0xe1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0xef, 0xb9, 0xcd, 0x5, 0xad, 0xc6, 0x3b, 0xcc, 0xe1, 0x8, 0x31, 0xd9, 0x53, 0x8c, 0x47, 0x9c, 0xf1, 0xd0, 0x5f, 0xef, 0xdd, 0x8, 0xb2, 0x44, 0x8d, 0x70, 0x42, 0x2e, 0xde, 0x45, 0x4c}),
-				new(big.Int).SetBytes([]byte{0xe, 0xcb, 0x45, 0x30, 0xd8, 0xaf, 0x9b, 0xe7, 0xb0, 0x15, 0x4c, 0x1f, 0xfe, 0x47, 0x71, 0x23, 0x46, 0x4e, 0x32, 0x44, 0xa7, 0xa2, 0xd4, 0xc6, 0xad, 0x9f, 0xd2, 0x33, 0xa8, 0x91, 0x37, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0xef, 0x9f, 0x13, 0xe2, 0xa4, 0x89, 0xbc, 0x83, 0xcb, 0x7e, 0x3b, 0x9a, 0x3c, 0xc2, 0x73, 0x35, 0x82, 0x35, 0x29, 0xd2, 0xc8, 0x73, 0x5c, 0xd5, 0x8d, 0x72, 0x9e, 0x9, 0x7f, 0x96, 0xf2}),
-				new(big.Int).SetBytes([]byte{0xe8, 0x14, 0xcc, 0xe5, 0x94, 0x55, 0x9d, 0x7c, 0xd9, 0x56, 0xee, 0x16, 0xe, 0xbb, 0x61, 0x3d, 0xb7, 0x4f, 0x89, 0xaf, 0x5a, 0x9b, 0x47, 0x2, 0xc0, 0x3d, 0x55, 0xb0, 0x56, 0xc0, 0x4b, 0xe4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0x90, 0x70, 0xae, 0x96, 0xea, 0xd4, 0xdc, 0x49, 0xbe, 0x16, 0xf6, 0xa1, 0xa3, 0xb, 0xc2, 0x41, 0xb4, 0xe9, 0x8b, 0xc1, 0x8d, 0x2, 0x27, 0x4c, 0x9d, 0x9d, 0x87, 0xdc, 0xbf, 0x0, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x6f, 0x24, 0xc8, 0xc2, 0xc8, 0xa2, 0xd8, 0x8f, 0x47, 0x22, 0x94, 0xe4, 0xc1, 0xc4, 0xa7, 0x66, 0xcb, 0xd, 0x8b, 0x6, 0xb6, 0xb9, 0x6a, 0x67, 0x1b, 0x7f, 0x1b, 0xcd, 0x1b, 0xe, 0x66, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x66, 0xd8, 0x5, 0x41, 0xee, 0x1d, 0x35, 0xbe, 0x55, 0x38, 0x27, 0xd6, 0x9f, 0xaa, 0x6, 0x42, 0x7, 0xdb, 0xf5, 0x74, 0x54, 0xa8, 0xab, 0xd, 0xbc, 0xa, 0xb5, 0xa1, 0x35, 0xc, 0xf7, 0x7e}),
-				new(big.Int).SetBytes([]byte{0x51, 0xcf, 0xdf, 0xe7, 0x32, 0xff, 0xfb, 0x42, 0xc3, 0xf1, 0xd4, 0x20, 0x53, 0x56, 0x13, 0xf6, 0x94, 0x44, 0xb4, 0x3a, 0xb7, 0xb1, 0xb7, 0x6d, 0x2a, 0x5f, 0x97, 0xaf, 0xa0, 0xea, 0xa3, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0x18, 0xf9, 0xb1, 0xa2, 0x69, 0x70, 0x10, 0xc5, 0xac, 0x23, 0x5e, 0x9a, 0xf4, 0x75, 0xa8, 0xc7, 0xe5, 0x41, 0x9f, 0x33, 0xd4, 0x7b, 0x18, 0xd3, 0x3f, 0xee, 0xb3, 0x29, 0xeb, 0x99, 0xa4}),
-				new(big.Int).SetBytes([]byte{0xf4, 0x4c, 0xcf, 0xeb, 0x4b, 0xed, 0xa4, 0x19, 0x57, 0x72, 0xd9, 0x3a, 0xeb, 0xb4, 0x5, 0xe8, 0xa4, 0x1f, 0x2b, 0x40, 0xd1, 0xe3, 0xec, 0x65, 0x2c, 0x72, 0x6e, 0xee, 0xfe, 0x91, 0xf9, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x62, 0xac, 0x5, 0xe1, 0x36, 0x50, 0x3f, 0xa2, 0xbf, 0x35, 0x8, 0xa1, 0xc6, 0x4c, 0xc3, 0xc8, 0xb, 0x73, 0x20, 0x4a, 0x78, 0x5f, 0xa6, 0x2b, 0x93, 0x54, 0x86, 0x28, 0x87, 0x21, 0x3a, 0x5a}),
-				new(big.Int).SetBytes([]byte{0x23, 0x6f, 0xbd, 0xf3, 0xa0, 0xd1, 0xa6, 0xe4, 0xd3, 0x95, 0xe7, 0x9e, 0x7, 0xa9, 0x25, 0x79, 0xe5, 0x59, 0x9, 0x1, 0x81, 0x79, 0x94, 0xef, 0xc1, 0xd, 0x21, 0xc0, 0xf4, 0x6a, 0x9e, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x18, 0x8e, 0xf3, 0xbf, 0xab, 0x10, 0x37, 0x84, 0x37, 0xe1, 0x85, 0x9a, 0x7d, 0x53, 0xe8, 0x99, 0x61, 0x24, 0xc3, 0x95, 0xca, 0x42, 0x2c, 0x5, 0xd6, 0xb2, 0x1b, 0x3c, 0xef, 0xc7, 0x6a, 0xc1}),
-				new(big.Int).SetBytes([]byte{0xe6, 0xfc, 0x99, 0x7a, 0x5, 0x2f, 0xba, 0x99, 0xd0, 0x5, 0xfb, 0x18, 0xcf, 0x39, 0x8e, 0x38, 0xe4, 0xd6, 0x6d, 0xe8, 0x3c, 0x76, 0x38, 0x75, 0x35, 0x16, 0x3, 0x2a, 0x52, 0x8f, 0x0, 0xbc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xca, 0x13, 0xc4, 0x49, 0x72, 0x44, 0x8f, 0x5b, 0x5b, 0xf6, 0x13, 0xaf, 0x5c, 0x6f, 0x2d, 0xb3, 0x85, 0xd5, 0xea, 0x0, 0xea, 0xf6, 0x6e, 0x1e, 0xc3, 0xf, 0xd4, 0x98, 0x67, 0xba, 0xd1, 0x2b}),
-				new(big.Int).SetBytes([]byte{0x83, 0xaa, 0x9, 0x83, 0x61, 0xc2, 0x87, 0xc8, 0xac, 0x15, 0xfa, 0x64, 0xea, 0xad, 0xa3, 0xd6, 0x63, 0x70, 0xab, 0x71, 0x11, 0xa5, 0x75, 0x66, 0x89, 0x69, 0x9f, 0x46, 0x97, 0x23, 0xb0, 0xf2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x26, 0x48, 0x87, 0x66, 0x9c, 0xb4, 0x41, 0xf8, 0xf3, 0x36, 0xbb, 0xb1, 0xb2, 0xa8, 0x84, 0x60, 0x5e, 0xad, 0xed, 0xc1, 0x45, 0x8d, 0x65, 0xa3, 0xb9, 0xd6, 0xff, 0x9a, 0xb6, 0xfb, 0xe7, 0xb2}),
-				new(big.Int).SetBytes([]byte{0x9e, 0x15, 0xda, 0xb4, 0xb2, 0x1, 0x44, 0x98, 0x8f, 0x2f, 0x2d, 0xca, 0xd0, 0x2d, 0xdf, 0x18, 0x56, 0x38, 0xd9, 0x81, 0x6a, 0xe, 0xb6, 0x3, 0x93, 0x2a, 0x78, 0xbc, 0x21, 0xbc, 0x2a, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0xec, 0xb1, 0x1, 0xa7, 0x5, 0x2a, 0xe8, 0x5a, 0xc1, 0x1c, 0x4f, 0x44, 0x60, 0x9e, 0x49, 0x14, 0xef, 0x8a, 0x86, 0x2d, 0x66, 0xcf, 0x7f, 0xdd, 0xae, 0xe5, 0xf9, 0x5c, 0x80, 0x41, 0x4e}),
-				new(big.Int).SetBytes([]byte{0xf3, 0x43, 0x60, 0x66, 0x96, 0x9, 0x7c, 0xfb, 0x4e, 0x5a, 0xa3, 0x28, 0x35, 0xed, 0xad, 0x16, 0x90, 0x75, 0x57, 0xe5, 0x44, 0x72, 0x31, 0xd0, 0xdc, 0xae, 0x94, 0x92, 0xd2, 0x16, 0x9a, 0x3b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf9, 0x8b, 0x5d, 0xbc, 0x3e, 0x54, 0xc2, 0x27, 0xb0, 0x2, 0xf4, 0x58, 0x94, 0x85, 0xf, 0xf4, 0x3b, 0x15, 0xb2, 0xd6, 0x16, 0x7, 0x85, 0x1c, 0x50, 0xd1, 0xe4, 0xd1, 0x8b, 0x20, 0x90, 0x7d}),
-				new(big.Int).SetBytes([]byte{0xe2, 0xd5, 0x8, 0xbd, 0x4f, 0x4a, 0x13, 0xfa, 0x42, 0x93, 0x9b, 0x70, 0x6b, 0xa9, 0xcc, 0x9e, 0x41, 0xfc, 0xd9, 0xe0, 0x25, 0xa9, 0xe, 0x35, 0x83, 0x51, 0x31, 0x9b, 0x5c, 0xc9, 0x26, 0xaf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2a, 0x69, 0x90, 0x75, 0x4, 0xd7, 0x8f, 0x9c, 0xc9, 0x50, 0xda, 0xf5, 0xf1, 0xdf, 0x23, 0x92, 0x4f, 0x88, 0x9d, 0x3f, 0x3c, 0x70, 0x1, 0x55, 0xc4, 0xf5, 0xbc, 0x8c, 0x4e, 0xd8, 0x10, 0xa9}),
-				new(big.Int).SetBytes([]byte{0x54, 0xf9, 0x3, 0x9b, 0x6c, 0x3d, 0xff, 0xda, 0xc3, 0xec, 0x58, 0x69, 0x54, 0xfb, 0x4, 0xfc, 0x66, 0x7d, 0x47, 0xe9, 0x7e, 0xc1, 0x45, 0xc7, 0xcf, 0x86, 0xb3, 0x88, 0xfa, 0x9b, 0x47, 0x28}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x10, 0xf, 0x44, 0xda, 0x69, 0x6e, 0x71, 0x67, 0x27, 0x91, 0xd0, 0xa0, 0x9b, 0x7b, 0xde, 0x45, 0x9f, 0x12, 0x15, 0xa2, 0x9b, 0x3c, 0x3, 0xbf, 0xef, 0xd7, 0x83, 0x5b, 0x39, 0xa4, 0x8d, 0xb0}),
-				new(big.Int).SetBytes([]byte{0xcd, 0xd9, 0xe1, 0x31, 0x92, 0xa0, 0xb, 0x77, 0x2e, 0xc8, 0xf3, 0x30, 0xc, 0x9, 0x6, 0x66, 0xb7, 0xff, 0x4a, 0x18, 0xff, 0x51, 0x95, 0xac, 0xf, 0xbd, 0x5c, 0xd6, 0x2b, 0xc6, 0x5a, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8c, 0x9, 0x89, 0xf2, 0xce, 0xb5, 0xc7, 0x71, 0xa8, 0x41, 0x5d, 0xff, 0x2b, 0x4c, 0x41, 0x99, 0xd8, 0xd9, 0xc8, 0xf9, 0x23, 0x7d, 0x8, 0x8, 0x4b, 0x5, 0x28, 0x4f, 0x1e, 0x4d, 0xf7, 0x6}),
-				new(big.Int).SetBytes([]byte{0xfb, 0x4d, 0xbd, 0x4, 0x4f, 0x43, 0x20, 0x34, 0xff, 0xd2, 0x17, 0x2c, 0xb9, 0xdc, 0x96, 0x6c, 0x60, 0xde, 0x6b, 0xf5, 0x15, 0x65, 0x11, 0xaa, 0x73, 0x6a, 0xc5, 0xa3, 0x5d, 0x72, 0xfa, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x10, 0xe9, 0xe, 0x2e, 0x51, 0xee, 0xad, 0xc9, 0xed, 0x85, 0x8e, 0xe9, 0xce, 0xd7, 0xca, 0x8d, 0x92, 0x75, 0x2, 0x8e, 0x46, 0x5a, 0x2e, 0xe6, 0x9c, 0xb9, 0xa1, 0x34, 0x95, 0xbc, 0x15, 0xb4}),
-				new(big.Int).SetBytes([]byte{0xc6, 0x8a, 0x37, 0x3, 0x80, 0xd5, 0xe0, 0x42, 0x4d, 0x57, 0xa8, 0xc6, 0x16, 0xad, 0x1f, 0x75, 0x4c, 0xa5, 0x89, 0x63, 0x2, 0xbb, 0x6a, 0x88, 0x34, 0xeb, 0xe6, 0x9, 0x58, 0xaa, 0x25, 0x8d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0x8f, 0x15, 0x3c, 0x5e, 0x26, 0x67, 0x4, 0xc4, 0xa4, 0x81, 0x74, 0x32, 0x62, 0xc0, 0x25, 0x9c, 0x52, 0x85, 0x39, 0xbc, 0x95, 0xbc, 0x1b, 0xb1, 0xe6, 0x3c, 0x33, 0xdc, 0x47, 0xbf, 0xfd}),
-				new(big.Int).SetBytes([]byte{0x6c, 0xa2, 0x7a, 0x9d, 0xc5, 0xe0, 0x62, 0x18, 0x16, 0xfa, 0x11, 0xd9, 0xb4, 0xbc, 0xcd, 0x53, 0x1d, 0xde, 0x13, 0x89, 0xac, 0x54, 0x26, 0x13, 0x9, 0xa, 0x45, 0xdd, 0xd9, 0x49, 0xb0, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf7, 0x42, 0x2f, 0x42, 0xda, 0x54, 0x16, 0x38, 0x45, 0x75, 0xb9, 0xb, 0x71, 0x4b, 0x7d, 0xcd, 0xa3, 0x77, 0xa3, 0xcc, 0x5, 0x3d, 0x33, 0x18, 0x2d, 0xd3, 0xfc, 0x30, 0x3f, 0xe7, 0x52, 0x69}),
-				new(big.Int).SetBytes([]byte{0x40, 0x6c, 0x2f, 0x1a, 0x33, 0x13, 0x9, 0x3f, 0xd1, 0x8c, 0xe7, 0xdc, 0xb9, 0xf6, 0x35, 0x97, 0x7f, 0xb3, 0xa2, 0x37, 0xf4, 0xa3, 0x98, 0xe0, 0x18, 0x98, 0xe, 0x87, 0x17, 0xe4, 0x9b, 0xd5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb6, 0xb1, 0x5a, 0x68, 0xa6, 0x14, 0xcc, 0xa5, 0xb1, 0x7c, 0x9b, 0xe7, 0x0, 0x2f, 0xf9, 0xc3, 0x4d, 0x62, 0x51, 0x58, 0xc6, 0x5a, 0x9c, 0x74, 0xf, 0x62, 0xab, 0xc8, 0x7a, 0x1c, 0xa, 0x80}),
-				new(big.Int).SetBytes([]byte{0xfa, 0xe6, 0x2e, 0x14, 0xd6, 0xcd, 0xb6, 0x1e, 0xa5, 0x4e, 0x22, 0x3e, 0xef, 0x65, 0x36, 0xd4, 0x9c, 0x9a, 0x12, 0xb3, 0x8, 0x2e, 0x16, 0xee, 0xb6, 0xcd, 0x1, 0x10, 0x41, 0xce, 0xa, 0x3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2d, 0x8c, 0xad, 0x4, 0x17, 0xd4, 0x3c, 0xff, 0x72, 0x87, 0x9a, 0x55, 0x82, 0xd5, 0xde, 0xbb, 0xed, 0xb8, 0xe7, 0x71, 0xd3, 0x1c, 0xf4, 0x2a, 0x65, 0x3b, 0x66, 0x96, 0xf5, 0xa7, 0x17, 0x5f}),
-				new(big.Int).SetBytes([]byte{0xc7, 0x3f, 0x3b, 0x83, 0x31, 0x8c, 0xa9, 0x4a, 0x7b, 0xb2, 0x32, 0xfa, 0x61, 0x2c, 0x9d, 0x37, 0x7a, 0x84, 0x6b, 0xfd, 0x9c, 0xb5, 0xe5, 0xe0, 0xcf, 0x37, 0xbb, 0x91, 0xbb, 0x9d, 0x59, 0x2a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe7, 0x47, 0x33, 0x3f, 0xd7, 0x5d, 0x51, 0x75, 0x5a, 0xc, 0xc9, 0xf0, 0xa7, 0x28, 0x70, 0x84, 0x65, 0xa0, 0x2c, 0x58, 0x77, 0x37, 0xa7, 0xb8, 0xb8, 0xfa, 0x1b, 0x8b, 0x4b, 0xb2, 0x62, 0x9a}),
-				new(big.Int).SetBytes([]byte{0xf2, 0xaf, 0xfe, 0x1, 0x45, 0x7, 0xc, 0x11, 0x4c, 0xc4, 0x36, 0x3, 0x80, 0x4c, 0x25, 0x81, 0xc8, 0x83, 0x76, 0xaa, 0x6e, 0x1a, 0x96, 0x9a, 0x9f, 0x8d, 0x96, 0x1a, 0x69, 0x46, 0xf6, 0xd6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1e, 0xcb, 0xfd, 0x1d, 0xb9, 0x8a, 0x6e, 0xa5, 0x8a, 0xe7, 0x3d, 0x4e, 0xb1, 0xe8, 0xcf, 0x73, 0xbb, 0xc6, 0xc8, 0x96, 0xf3, 0x1c, 0x25, 0xb3, 0xe3, 0x4c, 0x9b, 0xc3, 0x94, 0xb5, 0x10, 0x45}),
-				new(big.Int).SetBytes([]byte{0x1c, 0xf6, 0xe2, 0x30, 0x8b, 0x99, 0xc3, 0xa6, 0x44, 0x7d, 0xb, 0xb1, 0x84, 0x9a, 0x9b, 0x38, 0xb1, 0x90, 0x6, 0x46, 0xb4, 0x36, 0x42, 0x2d, 0x53, 0xa6, 0x71, 0x1, 0x2, 0xc7, 0x0, 0x26}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x99, 0x74, 0x1b, 0xb8, 0xb4, 0x77, 0xe2, 0x96, 0xaf, 0x8b, 0x1b, 0xfe, 0xb7, 0xed, 0xb6, 0x4a, 0xf9, 0x2f, 0x69, 0xef, 0xdd, 0xd5, 0xef, 0x67, 0x4a, 0x35, 0xb5, 0x6c, 0x64, 0x13, 0xe4, 0x39}),
-				new(big.Int).SetBytes([]byte{0xde, 0x47, 0xe9, 0xb0, 0x7b, 0xd9, 0xc9, 0xa5, 0xe7, 0x35, 0xaa, 0x56, 0x25, 0x8e, 0x3e, 0x7a, 0xb1, 0x7c, 0x15, 0xc9, 0x69, 0x74, 0x66, 0xe4, 0x3e, 0x65, 0x7d, 0x82, 0x68, 0x32, 0xcd, 0x5b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9a, 0x8, 0x94, 0xc5, 0xfe, 0x57, 0x75, 0x28, 0x83, 0xe9, 0x55, 0xa2, 0x91, 0xd3, 0x2a, 0x8c, 0x10, 0xa9, 0x33, 0xf9, 0xd4, 0xfb, 0x4b, 0x9d, 0xf7, 0xac, 0xd7, 0x66, 0xe9, 0x35, 0x85, 0x33}),
-				new(big.Int).SetBytes([]byte{0xa7, 0x98, 0x83, 0xc4, 0x20, 0x1b, 0x8f, 0xc3, 0x1f, 0x91, 0x7d, 0x5f, 0x95, 0x4f, 0xc3, 0x21, 0x65, 0xa6, 0xe5, 0xbd, 0xbb, 0x80, 0xdd, 0xad, 0xfb, 0x3e, 0x1c, 0x5d, 0xc3, 0x60, 0xba, 0x8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x35, 0x96, 0x3e, 0xa4, 0x32, 0x56, 0xea, 0xca, 0x6, 0xfb, 0x17, 0x12, 0x83, 0xea, 0x22, 0x7a, 0x7a, 0x5c, 0xe7, 0xe5, 0x9b, 0xb6, 0xa1, 0x41, 0xa7, 0xca, 0xa5, 0xa, 0x92, 0xb0, 0x62, 0xd4}),
-				new(big.Int).SetBytes([]byte{0xf6, 0x5b, 0xe1, 0x45, 0x49, 0x51, 0xe5, 0xc9, 0xbf, 0xcc, 0xe1, 0x96, 0xf9, 0xc0, 0x8a, 0x96, 0xd0, 0x40, 0x82, 0xb9, 0x64, 0xde, 0x59, 0xb1, 0xa1, 0xa, 0xa4, 0xd1, 0xbb, 0xb2, 0x53, 0x2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x66, 0x4d, 0xd8, 0x49, 0xdb, 0x4f, 0xd2, 0xe3, 0x5b, 0xd9, 0xc8, 0x52, 0x73, 0xb8, 0xae, 0xa6, 0x59, 0x23, 0xf3, 0xf7, 0x7a, 0x7, 0x8f, 0x9f, 0x69, 0x44, 0x5, 0xd6, 0x19, 0x8e, 0xf7, 0xf6}),
-				new(big.Int).SetBytes([]byte{0xad, 0x51, 0x20, 0x17, 0x17, 0xf2, 0x79, 0x32, 0xa1, 0x44, 0x8c, 0xe5, 0xdd, 0x4, 0x58, 0xcf, 0xfc, 0x3d, 0x3a, 0xb3, 0x1b, 0x8e, 0x6e, 0xce, 0xe7, 0x49, 0x6f, 0xf3, 0x5d, 0x1e, 0xac, 0x94}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xce, 0xbb, 0x7b, 0x7b, 0x2, 0xcf, 0xc3, 0x21, 0xc6, 0xa0, 0xe8, 0x24, 0x85, 0xc2, 0x9e, 0x41, 0x74, 0x72, 0x8e, 0xfb, 0x3a, 0xa, 0xd1, 0xb6, 0xd, 0xb, 0x66, 0xf3, 0x11, 0x93, 0x34, 0xf0}),
-				new(big.Int).SetBytes([]byte{0xfe, 0x3d, 0xf2, 0x43, 0x63, 0x3b, 0x4a, 0x32, 0xba, 0x1e, 0xc0, 0xf7, 0x4d, 0xd2, 0xe3, 0x70, 0x47, 0x65, 0xa2, 0x9d, 0xb9, 0xe0, 0x36, 0xcb, 0x16, 0x75, 0xb, 0xd2, 0x51, 0xe4, 0x51, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x82, 0x11, 0x3a, 0x93, 0x77, 0xd0, 0xb8, 0x63, 0x82, 0x31, 0xd9, 0x66, 0x98, 0x11, 0xa7, 0x2, 0x2b, 0x31, 0xf5, 0x80, 0x5b, 0xa, 0xe2, 0xc4, 0xe0, 0xc0, 0xa6, 0xb7, 0xc3, 0xc9, 0x34, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x8d, 0xa1, 0xb8, 0xda, 0xc9, 0xae, 0x36, 0x66, 0x8, 0x46, 0x6c, 0xf2, 0xb5, 0xa0, 0xc6, 0x28, 0xaa, 0x1f, 0x7c, 0x26, 0x9a, 0x44, 0x68, 0x3, 0x77, 0xe5, 0xe6, 0x2a, 0xc4, 0x2c, 0x6a, 0xf}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0x3, 0x1b, 0xe2, 0x62, 0xc7, 0xed, 0x1b, 0x1d, 0xc9, 0x22, 0x7a, 0x4a, 0x4, 0xc0, 0x17, 0xa7, 0x7f, 0x8d, 0x44, 0x64, 0xf3, 0xb3, 0x85, 0x2c, 0x8a, 0xcd, 0xe6, 0xe5, 0x34, 0xfd, 0x2d}),
-				new(big.Int).SetBytes([]byte{0x9d, 0x70, 0x61, 0x92, 0x89, 0x40, 0x40, 0x5e, 0x6b, 0xb6, 0xa4, 0x17, 0x65, 0x97, 0x53, 0x5a, 0xf2, 0x92, 0xdd, 0x41, 0x9e, 0x1c, 0xed, 0x79, 0xa4, 0x4f, 0x18, 0xf2, 0x94, 0x56, 0xa0, 0xd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf4, 0xb9, 0x3f, 0x22, 0x4c, 0x80, 0x89, 0xea, 0xb9, 0xf9, 0x5d, 0xcd, 0xf, 0x29, 0xb2, 0xc9, 0x2, 0x8a, 0x6a, 0xc5, 0xde, 0x94, 0xd8, 0x57, 0x84, 0xe2, 0x7e, 0x36, 0xa9, 0x5c, 0x83, 0x56}),
-				new(big.Int).SetBytes([]byte{0xa6, 0x7a, 0x92, 0xec, 0x6, 0x29, 0x62, 0xdf, 0xb0, 0xe5, 0xf6, 0xa7, 0xa4, 0xe, 0xee, 0x90, 0xc3, 0x7e, 0xf1, 0x34, 0x49, 0x15, 0x60, 0x9a, 0xbd, 0x58, 0x61, 0xb9, 0xbe, 0x0, 0x1f, 0xd3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0xeb, 0xf7, 0xc4, 0xe3, 0xc7, 0x85, 0xec, 0x6a, 0x5a, 0xbe, 0x5a, 0x15, 0xde, 0x69, 0xdb, 0x61, 0x95, 0x92, 0x6d, 0xba, 0x74, 0x39, 0x61, 0x57, 0x96, 0x23, 0xae, 0xef, 0x2, 0x8d, 0x83}),
-				new(big.Int).SetBytes([]byte{0x62, 0x5, 0x15, 0x2f, 0xbf, 0xe3, 0x62, 0xd5, 0xcf, 0xd9, 0xc7, 0x37, 0x70, 0x44, 0x80, 0x4b, 0x47, 0xa3, 0x89, 0x27, 0x4b, 0x5, 0x39, 0x19, 0x96, 0x40, 0x39, 0x2b, 0x99, 0xd0, 0xbe, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9, 0xd1, 0xac, 0xa1, 0xfc, 0xe5, 0x52, 0x36, 0xb1, 0x96, 0x22, 0xea, 0x2, 0x5b, 0x8, 0xb0, 0xd5, 0x1e, 0x85, 0x12, 0xf9, 0x7e, 0x69, 0x6c, 0x20, 0xd6, 0x2f, 0xe1, 0x7b, 0x16, 0xe, 0x8a}),
-				new(big.Int).SetBytes([]byte{0x11, 0x53, 0x18, 0x8f, 0x51, 0x1, 0xf0, 0xc6, 0x3e, 0x56, 0x69, 0x2c, 0xe0, 0xd8, 0xc2, 0x7e, 0x6f, 0xe9, 0xe0, 0xee, 0x92, 0x12, 0xb5, 0xe5, 0x34, 0xe0, 0x50, 0xc5, 0x7c, 0xa0, 0x4c, 0x44}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5b, 0x5c, 0xa0, 0x8d, 0xcb, 0x2, 0x4f, 0x4c, 0x1c, 0xdc, 0x6a, 0x53, 0x42, 0x63, 0x46, 0x33, 0x6a, 0x89, 0xc5, 0x13, 0x7f, 0x9, 0xd4, 0xb5, 0xba, 0xe0, 0xe4, 0x2, 0x27, 0xdd, 0x5c, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x3e, 0xcc, 0xb6, 0xf7, 0xa, 0xa1, 0x58, 0x25, 0xe9, 0x91, 0xf0, 0xce, 0xa8, 0x86, 0x90, 0x94, 0xbf, 0xd0, 0x67, 0xcc, 0xaf, 0x32, 0x69, 0xd3, 0xe6, 0x64, 0xa6, 0xf9, 0x9e, 0x48, 0xe9, 0x8c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd4, 0x93, 0x32, 0x30, 0xcc, 0x87, 0x21, 0xb8, 0x66, 0x26, 0x38, 0xcd, 0x89, 0x74, 0xae, 0x6e, 0xda, 0x4f, 0x4, 0xe2, 0xfb, 0x1f, 0xc, 0x13, 0xf2, 0x88, 0x44, 0x13, 0x7b, 0xb6, 0x1e, 0xe5}),
-				new(big.Int).SetBytes([]byte{0x2, 0x1c, 0x9, 0xab, 0xf5, 0x1a, 0x9d, 0x23, 0xed, 0xcc, 0x5e, 0x9d, 0x1e, 0xca, 0xfb, 0x5e, 0x1a, 0xd1, 0x2c, 0x8c, 0x5a, 0x43, 0x8d, 0xdc, 0x66, 0x2d, 0xa4, 0xd0, 0xe5, 0xd6, 0x94, 0xa8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4, 0x6f, 0x26, 0xac, 0xc1, 0x11, 0x4b, 0xb5, 0xcc, 0xef, 0x3e, 0x74, 0x89, 0xc2, 0x2c, 0x29, 0xff, 0x51, 0x32, 0x6b, 0xb1, 0xc, 0x2c, 0x9f, 0x2b, 0x95, 0x28, 0xe3, 0x23, 0x53, 0x1f, 0x82}),
-				new(big.Int).SetBytes([]byte{0x6b, 0x80, 0x4b, 0x31, 0x63, 0x5b, 0x82, 0xea, 0xce, 0x23, 0x70, 0xac, 0x2f, 0xc3, 0x25, 0x79, 0xd0, 0x25, 0x94, 0x5c, 0xda, 0xb5, 0x5c, 0x7f, 0xa5, 0x5, 0xfc, 0x8b, 0xb, 0xce, 0xda, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x6c, 0x59, 0xcc, 0x45, 0x4c, 0x2b, 0x9e, 0x18, 0xa2, 0xad, 0x79, 0x38, 0x21, 0xcd, 0xe7, 0x51, 0x8b, 0x3a, 0x93, 0xbf, 0xc3, 0x95, 0x62, 0xe9, 0x7d, 0x7d, 0x4, 0x75, 0xba, 0x7f, 0xc2}),
-				new(big.Int).SetBytes([]byte{0xd9, 0x59, 0x2f, 0xe2, 0xbf, 0xb3, 0xf, 0xcf, 0xbe, 0xa4, 0xf3, 0xce, 0xaa, 0xc1, 0xc, 0xb2, 0xf0, 0xa, 0x60, 0xdd, 0xb1, 0x59, 0x55, 0x97, 0x7e, 0xc3, 0xc6, 0x9c, 0xf7, 0x5f, 0x59, 0x56}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x19, 0x26, 0xd9, 0x31, 0xe0, 0xef, 0xb5, 0x35, 0xf9, 0x6f, 0xf, 0x8, 0x99, 0x64, 0x5a, 0xcf, 0x14, 0xb, 0x2f, 0xdd, 0x91, 0x6b, 0x90, 0x60, 0xb1, 0x54, 0x37, 0x10, 0x43, 0x27, 0x11}),
-				new(big.Int).SetBytes([]byte{0x8b, 0xe1, 0xf8, 0xcc, 0x7d, 0x25, 0xb6, 0x89, 0x18, 0x49, 0x4b, 0xf1, 0x86, 0xcc, 0xc6, 0xcf, 0x83, 0xab, 0x92, 0x2c, 0xa0, 0xf3, 0x40, 0x55, 0xe4, 0x92, 0x61, 0xe2, 0xef, 0xe2, 0x61, 0xc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x55, 0x9c, 0xd5, 0xaa, 0x4a, 0xb, 0x37, 0xb3, 0x2b, 0x17, 0xce, 0xf5, 0xb2, 0x5e, 0xf5, 0xf1, 0x6b, 0xf4, 0x4, 0x3f, 0x27, 0x8e, 0x7a, 0xd0, 0xf8, 0x36, 0xc6, 0xcd, 0x22, 0xbe, 0x31, 0x6c}),
-				new(big.Int).SetBytes([]byte{0xaa, 0xd5, 0x44, 0x9c, 0x8, 0xd4, 0x40, 0x76, 0xf2, 0xdb, 0xa9, 0x29, 0x87, 0x4c, 0x69, 0x2f, 0x8a, 0x38, 0x9a, 0x1d, 0x36, 0x7, 0x80, 0xe7, 0x1a, 0x6a, 0xb9, 0xc9, 0x9f, 0xe0, 0x40, 0x13}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x69, 0x8, 0x46, 0xe9, 0xeb, 0x68, 0x8f, 0x2e, 0x6c, 0xdb, 0xb5, 0x4b, 0x53, 0x89, 0x79, 0xc4, 0x5b, 0x1, 0x8d, 0x52, 0xc3, 0x4a, 0xa8, 0x61, 0xc1, 0x79, 0x87, 0x4f, 0xa1, 0x25, 0x79, 0x63}),
-				new(big.Int).SetBytes([]byte{0xe2, 0x48, 0x5f, 0xcb, 0x7f, 0x3f, 0xeb, 0xc5, 0xb4, 0x1, 0x70, 0x24, 0xf9, 0x2f, 0xbb, 0x7f, 0x6c, 0xbc, 0x3c, 0xa8, 0x21, 0xc9, 0x2b, 0x25, 0x3a, 0x57, 0x65, 0x99, 0x46, 0x6f, 0x98, 0x35}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x6f, 0x37, 0xc8, 0x98, 0x73, 0xd1, 0xa0, 0x47, 0x46, 0x52, 0xc2, 0x7a, 0x10, 0xa2, 0xec, 0xaa, 0xdc, 0xff, 0xbb, 0x46, 0x88, 0xcf, 0xfe, 0x53, 0xd, 0xdc, 0xbc, 0xec, 0x4, 0x55, 0x4a}),
-				new(big.Int).SetBytes([]byte{0x38, 0x4, 0x23, 0xe7, 0x22, 0x4a, 0xc4, 0xac, 0xda, 0x62, 0x2b, 0xb0, 0x4e, 0x81, 0x1b, 0xf1, 0xe6, 0x8, 0x5b, 0x61, 0xac, 0xc4, 0x17, 0xdc, 0xd6, 0x8f, 0x9f, 0xe1, 0x92, 0x91, 0x38, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0x3d, 0xf7, 0xfb, 0x8c, 0x92, 0x20, 0xba, 0xbb, 0xa7, 0x55, 0x2d, 0xf4, 0xa3, 0x29, 0x15, 0xde, 0xc8, 0x7c, 0x65, 0xb5, 0x4d, 0xd2, 0xfd, 0x2d, 0x11, 0x39, 0x4b, 0xde, 0xe2, 0x3a, 0xce}),
-				new(big.Int).SetBytes([]byte{0x51, 0xe, 0x29, 0xbc, 0x11, 0xc7, 0x3c, 0x56, 0x52, 0xa2, 0x51, 0x54, 0xd, 0xba, 0xb5, 0x8a, 0x9e, 0x72, 0x71, 0x42, 0xfe, 0xd2, 0x7f, 0x5e, 0xe0, 0x9d, 0xef, 0x4c, 0x97, 0x22, 0xe8, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x48, 0x2b, 0x6f, 0x17, 0x69, 0x15, 0x52, 0x39, 0x65, 0x1d, 0x68, 0xb8, 0x72, 0x90, 0xe, 0xe3, 0x1e, 0x47, 0x54, 0xc6, 0xe7, 0x0, 0xcb, 0x92, 0x5f, 0x90, 0xf1, 0x30, 0x5c, 0xd3, 0x6f, 0x89}),
-				new(big.Int).SetBytes([]byte{0xe8, 0xc3, 0xc1, 0x1d, 0xf9, 0xa5, 0xcc, 0x5a, 0xcb, 0x18, 0xc2, 0x3e, 0x6d, 0x7f, 0xe9, 0x2e, 0x19, 0xee, 0x7, 0x34, 0x42, 0xd4, 0x9a, 0x68, 0x46, 0x7c, 0xa3, 0x72, 0x70, 0xa4, 0xf4, 0x23}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6d, 0xd8, 0x5e, 0xc2, 0x4f, 0x5c, 0x51, 0x8b, 0x3e, 0xb8, 0xa3, 0xeb, 0x3a, 0xb1, 0xcd, 0x41, 0x70, 0x73, 0x4b, 0x3d, 0xf9, 0x9f, 0xf1, 0x9, 0x86, 0x7, 0xcf, 0xef, 0x50, 0x27, 0x23, 0x51}),
-				new(big.Int).SetBytes([]byte{0x16, 0xea, 0x67, 0xf4, 0x12, 0x1f, 0x42, 0x7e, 0x9, 0xd4, 0x7, 0xf9, 0xaf, 0x5c, 0x41, 0xb7, 0x87, 0x7c, 0x6f, 0xf7, 0xc3, 0xe0, 0x81, 0x7c, 0x9b, 0x7, 0xfa, 0x2b, 0x7f, 0xc7, 0x66, 0x4b}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0xea, 0x6c, 0xae, 0x46, 0xd5, 0x5b, 0x53, 0xa, 0xc2, 0x83, 0x9f, 0x14, 0x3b, 0xd7, 0xec, 0x5c, 0xf8, 0xb2, 0x66, 0xa4, 0x1d, 0x6a, 0xf5, 0x2d, 0x5e, 0x68, 0x8d, 0x90, 0x94, 0x69, 0x6d}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x7c, 0x6b, 0x6c, 0x97, 0xdc, 0xe1, 0xba, 0xb0, 0x6e, 0x4e, 0x12, 0xbf, 0x3e, 0xcd, 0x5c, 0x98, 0x1c, 0x89, 0x57, 0xcc, 0x41, 0x44, 0x2d, 0x31, 0x55, 0xde, 0xbf, 0x18, 0x9, 0x0, 0x88}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0x0, 0xb, 0x62, 0x1a, 0xdb, 0x87, 0xe1, 0xc5, 0x32, 0x61, 0xaf, 0x9d, 0xb2, 0xe1, 0x79, 0x14, 0x1e, 0xca, 0xe0, 0xb3, 0x31, 0xa1, 0x87, 0xa, 0xa4, 0x4, 0xa, 0xee, 0x75, 0x2b, 0x8}),
-				new(big.Int).SetBytes([]byte{0x6a, 0xd, 0x5b, 0x8f, 0x18, 0xe0, 0xd2, 0x55, 0xcb, 0x6d, 0x82, 0x55, 0x82, 0xd9, 0x72, 0xcc, 0xcb, 0x7d, 0xf5, 0xf1, 0x19, 0xc7, 0x29, 0x3a, 0x3e, 0x72, 0x85, 0x1f, 0x48, 0x30, 0x2c, 0xea}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x50, 0x84, 0xb4, 0x1b, 0xac, 0xf4, 0x50, 0x8b, 0x34, 0x86, 0x7a, 0xaa, 0x2c, 0xf5, 0xa1, 0x2d, 0x5e, 0xc4, 0xba, 0x38, 0xc9, 0xb0, 0x26, 0x56, 0x7, 0x93, 0x61, 0xbb, 0x48, 0xdf, 0xd5, 0x87}),
-				new(big.Int).SetBytes([]byte{0x34, 0xa9, 0x63, 0x1a, 0x1d, 0x98, 0xd, 0x31, 0x61, 0x9a, 0xa6, 0xc8, 0x55, 0x29, 0x27, 0x47, 0x5d, 0xb6, 0xf5, 0x60, 0x68, 0x91, 0xf5, 0x60, 0x6e, 0x79, 0xe9, 0x7f, 0x91, 0x47, 0xe, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0xf5, 0x70, 0xca, 0x20, 0x3d, 0xa0, 0x5d, 0xd6, 0xaa, 0x26, 0x21, 0x14, 0x71, 0x71, 0x28, 0xd6, 0x57, 0xa0, 0x40, 0x3e, 0x1f, 0x1b, 0x77, 0xf8, 0x99, 0x62, 0xfd, 0x47, 0x5c, 0x58, 0xef}),
-				new(big.Int).SetBytes([]byte{0xeb, 0x42, 0x41, 0x5b, 0x95, 0xdc, 0x88, 0xd, 0xd2, 0x55, 0x57, 0x34, 0x5b, 0xc9, 0x5b, 0x8d, 0xf2, 0x44, 0x5d, 0x0, 0xc3, 0x36, 0x3e, 0x7d, 0xf8, 0x64, 0x9a, 0x72, 0xd3, 0x5d, 0x42, 0xe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0x14, 0xc0, 0x3e, 0x6, 0x42, 0xd5, 0xea, 0x53, 0xfb, 0x3f, 0x1f, 0xd1, 0x8d, 0x71, 0x28, 0xc8, 0xc, 0x29, 0x76, 0x7c, 0xf3, 0xa, 0x12, 0xd0, 0x82, 0x32, 0x61, 0x7a, 0xb3, 0x4c, 0xc6}),
-				new(big.Int).SetBytes([]byte{0x7b, 0x53, 0xd0, 0xa8, 0xca, 0xa4, 0xe8, 0x94, 0xc6, 0x53, 0xa7, 0xf, 0x43, 0xa6, 0x25, 0x40, 0xb9, 0xde, 0x3c, 0xce, 0x8e, 0x81, 0xdd, 0x2, 0x25, 0xec, 0x25, 0x2f, 0x98, 0x7e, 0x68, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa4, 0x9e, 0xd1, 0xe, 0xaa, 0xab, 0x93, 0x23, 0x3a, 0x5f, 0x48, 0x5d, 0x4b, 0xd1, 0x8c, 0x6, 0x28, 0xab, 0x26, 0x29, 0xf0, 0xb8, 0xc3, 0xdb, 0x4d, 0x5, 0x95, 0x6d, 0x6c, 0x95, 0x3f, 0xa9}),
-				new(big.Int).SetBytes([]byte{0xcc, 0x72, 0xb8, 0x94, 0x66, 0xf, 0x53, 0x98, 0xe0, 0x34, 0x76, 0xc0, 0xa0, 0xdf, 0xdd, 0xfb, 0x5a, 0xe8, 0x75, 0x34, 0x96, 0x8e, 0x18, 0x1b, 0x67, 0xb2, 0xbd, 0x22, 0x46, 0xfb, 0x4c, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x4d, 0xb8, 0x7e, 0x49, 0xc7, 0x9e, 0xd1, 0xd4, 0x14, 0x36, 0x9, 0x5e, 0xdd, 0xd3, 0x63, 0xe8, 0x1c, 0xf1, 0x41, 0xdb, 0x24, 0x44, 0xf8, 0x34, 0x27, 0x71, 0x1, 0x12, 0x41, 0xd9, 0xd}),
-				new(big.Int).SetBytes([]byte{0xf7, 0x86, 0x91, 0xcd, 0xaf, 0x23, 0xee, 0xf3, 0x27, 0xfd, 0xd0, 0x8a, 0x58, 0x81, 0x71, 0xc8, 0xa9, 0xee, 0x50, 0x93, 0x44, 0xa0, 0xa3, 0x13, 0xf3, 0x25, 0x18, 0xb8, 0x3f, 0x7a, 0xda, 0xd4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0xb7, 0xb3, 0x62, 0x9f, 0x7b, 0xd2, 0x53, 0xb7, 0xd2, 0x82, 0xb5, 0xc2, 0x1d, 0xa0, 0x14, 0x46, 0xb4, 0x82, 0x1d, 0xc6, 0x5e, 0x76, 0x51, 0x60, 0x48, 0xb0, 0x60, 0x43, 0xff, 0x83, 0x59}),
-				new(big.Int).SetBytes([]byte{0x69, 0x30, 0x38, 0x94, 0x16, 0x95, 0x12, 0x2d, 0x57, 0xa9, 0x37, 0xa3, 0xf7, 0x1e, 0x29, 0xc9, 0x10, 0xd1, 0x8, 0x35, 0x4, 0x6f, 0x38, 0x35, 0xa2, 0x39, 0x7f, 0xec, 0xfe, 0x86, 0xfe, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x69, 0x1, 0xfa, 0x57, 0x44, 0xba, 0xa2, 0xcf, 0x9a, 0xcd, 0xcd, 0x44, 0xeb, 0xa3, 0x56, 0x58, 0x45, 0x6e, 0x58, 0xc6, 0x8a, 0xcd, 0x40, 0xb2, 0xa5, 0xec, 0xca, 0x22, 0x31, 0xc1, 0xae, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x35, 0xde, 0x5c, 0x88, 0x22, 0x73, 0xc2, 0x12, 0x46, 0x1b, 0x53, 0x80, 0xc2, 0x9c, 0x90, 0xd, 0xd7, 0x9f, 0x3b, 0xa5, 0xe5, 0xc7, 0x7a, 0x93, 0xf, 0x83, 0x30, 0x65, 0xd2, 0x28, 0x38, 0xb0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5d, 0x13, 0x4e, 0x5c, 0x5d, 0xa4, 0x7f, 0x7b, 0xa4, 0x95, 0xf2, 0x16, 0xb4, 0x99, 0x4d, 0x90, 0x4a, 0x79, 0x5, 0x7a, 0x3d, 0x0, 0x76, 0xbe, 0x34, 0xf0, 0x90, 0x13, 0x99, 0xf1, 0xf3, 0x66}),
-				new(big.Int).SetBytes([]byte{0x3, 0xb1, 0xef, 0x4d, 0x3e, 0x8, 0x45, 0x4, 0x2d, 0xbe, 0x2a, 0x4a, 0xe3, 0xa8, 0x2b, 0x73, 0xcf, 0x2, 0xd6, 0x16, 0x20, 0xa8, 0x8b, 0x39, 0xe2, 0xb0, 0xd3, 0xe9, 0xc4, 0x99, 0xf, 0x2c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8d, 0x3c, 0xd8, 0x2d, 0x1d, 0x43, 0x81, 0x27, 0xa2, 0x44, 0xb6, 0x43, 0xf1, 0xbb, 0xa3, 0x5d, 0x4, 0x6a, 0x6d, 0x4, 0x35, 0x49, 0x4c, 0xac, 0x4b, 0x3, 0x10, 0x81, 0xb2, 0x7a, 0x4b, 0xdb}),
-				new(big.Int).SetBytes([]byte{0x9b, 0xd4, 0x25, 0x61, 0x80, 0xee, 0x41, 0xf4, 0x70, 0xcf, 0xbf, 0x9d, 0x0, 0x76, 0x23, 0x6e, 0xd4, 0xe3, 0x80, 0x7d, 0x37, 0xa, 0xd2, 0x96, 0xc3, 0xd3, 0x4c, 0x7c, 0xe6, 0x9a, 0x8a, 0x2c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x65, 0xe, 0xf9, 0xac, 0xb6, 0xd5, 0x9d, 0xc, 0xba, 0xc6, 0x15, 0x8a, 0x65, 0xdb, 0xb0, 0x70, 0x31, 0x5b, 0xa, 0x39, 0x5d, 0x1f, 0x1f, 0x3a, 0x3c, 0x9, 0x6d, 0x3f, 0xe7, 0xa1, 0xd9, 0x16}),
-				new(big.Int).SetBytes([]byte{0x8, 0x5d, 0x11, 0xf2, 0x11, 0x62, 0xb1, 0x18, 0x70, 0xe9, 0xc0, 0xb9, 0x27, 0x9b, 0x82, 0xc5, 0x58, 0xbb, 0x46, 0x8, 0xa7, 0x6c, 0x26, 0xba, 0x85, 0x84, 0x9c, 0xa6, 0x70, 0x68, 0x38, 0x7b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xea, 0xf9, 0x83, 0x63, 0xd6, 0x6, 0x47, 0x39, 0xaf, 0xe9, 0xd6, 0x72, 0x96, 0x7b, 0x69, 0x1f, 0xd9, 0x9, 0x9f, 0x5c, 0x6e, 0x7d, 0x8f, 0x45, 0x44, 0xc, 0xd3, 0xc6, 0xb4, 0xec, 0x1d, 0x2d}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x18, 0x18, 0x3a, 0x7f, 0xe7, 0x8d, 0x22, 0x77, 0x52, 0x28, 0xa0, 0xe6, 0xb1, 0x99, 0xaa, 0xa9, 0xf5, 0x2d, 0x54, 0x57, 0xe8, 0x4b, 0x53, 0xfc, 0xfe, 0x75, 0x60, 0x4c, 0x83, 0x84, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1d, 0x77, 0xa, 0xce, 0xc, 0xeb, 0x21, 0x83, 0xfe, 0xa7, 0xc9, 0x24, 0xa8, 0xfb, 0xa1, 0x97, 0x57, 0x9d, 0xf3, 0x7a, 0x90, 0x89, 0x92, 0xbe, 0xd4, 0x2c, 0x8e, 0x80, 0x8b, 0xd9, 0x8e, 0xe1}),
-				new(big.Int).SetBytes([]byte{0x27, 0x8f, 0x88, 0x5, 0xf4, 0x6e, 0xf5, 0xde, 0x78, 0x96, 0x30, 0x38, 0xb6, 0x55, 0x2a, 0x86, 0x37, 0xf6, 0x1c, 0xa4, 0xe, 0x3d, 0x57, 0xec, 0xf9, 0xfb, 0x81, 0x79, 0x1d, 0x38, 0xff, 0xca}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0x95, 0xbd, 0x16, 0x3a, 0xed, 0xb6, 0x34, 0x66, 0xf4, 0xe, 0x5, 0x2c, 0x6, 0xe5, 0xd8, 0xc2, 0x73, 0xa9, 0xcb, 0x9e, 0x89, 0x77, 0x1c, 0xbc, 0xb3, 0x5b, 0xf7, 0x4, 0x5a, 0xe7, 0x67}),
-				new(big.Int).SetBytes([]byte{0x66, 0x4c, 0x14, 0xd8, 0x11, 0xa8, 0xdd, 0xbc, 0x52, 0x0, 0x4d, 0x9d, 0x36, 0x82, 0x27, 0x5b, 0xb8, 0x16, 0xf4, 0x71, 0x8a, 0x59, 0xf1, 0x34, 0x18, 0x53, 0xea, 0xa, 0xfb, 0xbf, 0xe, 0x11}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x67, 0xa9, 0x1d, 0x91, 0x4, 0x9c, 0xdc, 0xb3, 0x67, 0xbe, 0x4b, 0xe6, 0xff, 0xca, 0x3c, 0xfe, 0xed, 0x65, 0x7d, 0x80, 0x85, 0x83, 0xde, 0x33, 0xfa, 0x97, 0x8b, 0xc1, 0xec, 0x6c, 0xb1}),
-				new(big.Int).SetBytes([]byte{0x9b, 0xac, 0xaa, 0x35, 0x48, 0x16, 0x42, 0xbc, 0x41, 0xf4, 0x63, 0xf7, 0xec, 0x97, 0x80, 0xe5, 0xde, 0xc7, 0xad, 0xc5, 0x8, 0xf7, 0x40, 0xa1, 0x7e, 0x9e, 0xa8, 0xe2, 0x7a, 0x68, 0xbe, 0x1d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0xba, 0xcd, 0x36, 0x5f, 0xa1, 0xef, 0x58, 0x7c, 0xc, 0xc, 0xfa, 0xaf, 0x0, 0xd8, 0x71, 0x8b, 0xbd, 0x9f, 0x35, 0xcc, 0xea, 0x5a, 0x83, 0x5e, 0xe3, 0xcc, 0x82, 0x1f, 0xe7, 0x41, 0xc9}),
-				new(big.Int).SetBytes([]byte{0x16, 0xc3, 0x54, 0xe, 0x8a, 0x51, 0x89, 0x2e, 0x7f, 0xdc, 0xfd, 0x59, 0xe8, 0x38, 0x29, 0x9d, 0xc, 0xc3, 0x84, 0xa0, 0x9f, 0xc0, 0x53, 0x5f, 0x60, 0xbe, 0x10, 0xf8, 0x33, 0x8e, 0xb6, 0x23}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0x1, 0x80, 0x58, 0x3c, 0xfc, 0xed, 0xa3, 0xd3, 0x85, 0x65, 0xa4, 0xba, 0x5a, 0x5f, 0xc7, 0x68, 0x41, 0x1, 0x77, 0xcb, 0xe1, 0x51, 0xa1, 0x9e, 0xfd, 0xd0, 0x65, 0x15, 0xbc, 0x8a, 0x44}),
-				new(big.Int).SetBytes([]byte{0x3a, 0x33, 0xc6, 0xc1, 0x8c, 0xb4, 0xf5, 0xd3, 0x67, 0xe9, 0xba, 0x80, 0x7, 0xd6, 0x38, 0x13, 0x96, 0x94, 0x20, 0x46, 0x85, 0x82, 0xda, 0x36, 0x2f, 0x1f, 0x94, 0xc9, 0x1a, 0xdb, 0xc0, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0xd1, 0xff, 0xc4, 0x81, 0x50, 0x9b, 0xee, 0xe6, 0x8f, 0x17, 0xd8, 0xff, 0x41, 0xc2, 0x59, 0xf, 0x4c, 0x85, 0xf1, 0x52, 0x68, 0x60, 0x50, 0x87, 0xed, 0xa8, 0xba, 0xb4, 0xe2, 0x18, 0xda}),
-				new(big.Int).SetBytes([]byte{0x60, 0x8, 0x39, 0x1f, 0xa9, 0x91, 0x96, 0x1d, 0xce, 0xcb, 0x93, 0x37, 0xb1, 0xb7, 0x58, 0xbd, 0xa4, 0xad, 0x1, 0x20, 0x6d, 0x5b, 0xd1, 0x27, 0xe0, 0xdb, 0x41, 0x9d, 0xdb, 0x19, 0x1c, 0x19}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x66, 0x15, 0x7, 0xdf, 0x5c, 0xf9, 0x57, 0x8, 0x2c, 0xb6, 0xa2, 0x73, 0xb6, 0xe9, 0xd1, 0x59, 0x23, 0xeb, 0x24, 0xce, 0x64, 0x5f, 0x76, 0xbc, 0x1a, 0xb5, 0x28, 0x65, 0xda, 0xeb, 0x0}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x5c, 0x12, 0x13, 0x6f, 0x52, 0xb3, 0x3f, 0x6e, 0xf9, 0x53, 0x7a, 0x82, 0x0, 0xad, 0xd0, 0x6e, 0xcd, 0xee, 0x27, 0x19, 0x5d, 0x30, 0x8c, 0x12, 0x27, 0x67, 0x89, 0x83, 0x39, 0x92, 0xc0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x19, 0x68, 0xe4, 0x3a, 0xdf, 0x22, 0x56, 0xf0, 0xff, 0x54, 0xa3, 0xcc, 0xaf, 0x2d, 0xce, 0x7, 0xf5, 0x57, 0xf1, 0xab, 0x8b, 0x5a, 0x3c, 0xf5, 0x22, 0x13, 0xe4, 0x93, 0x5b, 0x4e, 0xb8}),
-				new(big.Int).SetBytes([]byte{0xbf, 0xf5, 0xe6, 0x93, 0x77, 0x86, 0x45, 0x8f, 0x83, 0x79, 0xd8, 0x1f, 0xe6, 0x76, 0x6f, 0x82, 0x42, 0xf8, 0xb4, 0x94, 0x12, 0xa7, 0x14, 0x9b, 0x70, 0x44, 0x99, 0x6d, 0x6f, 0x91, 0x1a, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf5, 0x94, 0x11, 0x7d, 0x5, 0xfe, 0x47, 0xd2, 0x25, 0x4f, 0x17, 0x4d, 0xa1, 0x83, 0x5a, 0x38, 0x36, 0x62, 0xc8, 0xb6, 0x47, 0x70, 0x2d, 0xcf, 0x18, 0x2a, 0x90, 0xa0, 0x91, 0x6a, 0xa6, 0xd9}),
-				new(big.Int).SetBytes([]byte{0xca, 0xa7, 0x61, 0xa5, 0x6e, 0x97, 0x1b, 0x12, 0xcf, 0x65, 0xdb, 0xda, 0xb0, 0x94, 0xbc, 0xeb, 0x7a, 0x92, 0x77, 0x88, 0x3e, 0x6b, 0x5b, 0x86, 0xc7, 0xd0, 0x69, 0x6e, 0xbf, 0x2e, 0x50, 0xcf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x21, 0x9b, 0x4f, 0x9c, 0xef, 0x6c, 0x60, 0x0, 0x76, 0x59, 0xc7, 0x9c, 0x45, 0xb0, 0x53, 0x3b, 0x3c, 0xc9, 0xd9, 0x16, 0xce, 0x29, 0xdb, 0xff, 0x13, 0x3b, 0x40, 0xca, 0xa2, 0xe9, 0x6d, 0xb8}),
-				new(big.Int).SetBytes([]byte{0x24, 0xd9, 0xc6, 0x5, 0xd9, 0x59, 0xef, 0xea, 0xf5, 0xa4, 0x41, 0x80, 0xc0, 0x37, 0x2a, 0x6e, 0x39, 0x4f, 0x8a, 0xc5, 0x3e, 0x90, 0x57, 0x65, 0x27, 0xdf, 0x1, 0xa7, 0x8d, 0x3b, 0x6b, 0xc7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf, 0x2d, 0x4d, 0x7f, 0x1f, 0x4d, 0x9c, 0xde, 0xc4, 0xe1, 0xc3, 0x8b, 0xe6, 0x96, 0x71, 0x1d, 0xda, 0x4e, 0x8d, 0xe8, 0xcc, 0x4b, 0xdc, 0x4c, 0xca, 0xae, 0x3, 0x95, 0x7a, 0xc6, 0xf4, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x3e, 0xc8, 0x9f, 0x85, 0x7e, 0x93, 0xde, 0x4d, 0x17, 0xfb, 0x27, 0xfd, 0xf3, 0x81, 0xe7, 0x1e, 0x8f, 0x6a, 0x10, 0xa7, 0x90, 0x24, 0x26, 0x56, 0xe7, 0xe7, 0xbb, 0xad, 0x72, 0x33, 0x9b, 0x58}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf, 0x68, 0x40, 0xbd, 0xcd, 0x64, 0xb4, 0x5c, 0xdd, 0x43, 0xeb, 0xdd, 0x80, 0x6a, 0x34, 0x17, 0xac, 0x40, 0x20, 0xb0, 0x38, 0x3a, 0x6f, 0xac, 0xc5, 0x6d, 0x1f, 0x9a, 0xf4, 0x28, 0x43, 0x27}),
-				new(big.Int).SetBytes([]byte{0xf3, 0x4, 0xd3, 0x7f, 0x10, 0xf, 0x1a, 0x77, 0x49, 0x5e, 0x8b, 0xff, 0x4b, 0x61, 0x14, 0xaf, 0xb6, 0x8d, 0xab, 0xa7, 0x71, 0x2b, 0x9e, 0xb7, 0x40, 0xd, 0x27, 0x5a, 0x45, 0xe2, 0xab, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1d, 0x5d, 0xce, 0xc2, 0xe2, 0xa1, 0x4b, 0xbd, 0x1e, 0xb7, 0xa1, 0x81, 0x59, 0x58, 0xd0, 0xf1, 0x23, 0x38, 0x22, 0xfd, 0x66, 0xa2, 0x2b, 0x55, 0xd8, 0x55, 0x5, 0x3f, 0xdf, 0x42, 0x8c, 0xb2}),
-				new(big.Int).SetBytes([]byte{0x6e, 0x5c, 0x40, 0x83, 0xb1, 0x42, 0xc8, 0xc5, 0xd, 0x7, 0x87, 0x82, 0x48, 0x6f, 0xb, 0x88, 0xbd, 0x3e, 0x91, 0x2f, 0x87, 0x60, 0x44, 0xa5, 0x1e, 0x5e, 0xd1, 0x0, 0x89, 0x5b, 0x18, 0x9b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0x1c, 0x6c, 0xbe, 0xe1, 0x48, 0x3d, 0xea, 0xf6, 0xf9, 0xb3, 0x95, 0xc8, 0x17, 0xeb, 0x1, 0x92, 0x28, 0xcd, 0xa5, 0xaf, 0xac, 0x58, 0x57, 0x29, 0x5b, 0xa1, 0x9, 0x59, 0xdf, 0xfc, 0x96}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x26, 0xca, 0x92, 0xa6, 0xb9, 0xff, 0x98, 0x5b, 0xd2, 0x59, 0x39, 0x1c, 0xb1, 0x83, 0x5, 0x9b, 0x96, 0x34, 0xc9, 0x2e, 0xaf, 0x86, 0x21, 0xc9, 0x3b, 0xcf, 0x61, 0x1b, 0x49, 0x2d, 0xe4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0xd9, 0xa2, 0xfd, 0x30, 0x9, 0x67, 0x43, 0x9f, 0xde, 0xa9, 0xb2, 0xcc, 0x41, 0x26, 0xce, 0xd8, 0x43, 0x76, 0x96, 0x6, 0x93, 0xda, 0xe5, 0xfb, 0xe, 0xa8, 0x8f, 0xa8, 0x38, 0x12, 0x73}),
-				new(big.Int).SetBytes([]byte{0xdf, 0xca, 0x25, 0xb4, 0x51, 0xd6, 0x6f, 0x50, 0x37, 0x7a, 0xaf, 0x20, 0x76, 0xcf, 0x62, 0xf5, 0x8f, 0x61, 0xd6, 0x80, 0xad, 0x12, 0x73, 0x24, 0xa0, 0x3e, 0xb3, 0xee, 0xd3, 0x9, 0x77, 0x3d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x78, 0x3d, 0xe, 0xcc, 0x3f, 0x64, 0xd4, 0xa0, 0xfe, 0x6c, 0x72, 0x85, 0xf1, 0x89, 0xaa, 0x8c, 0x52, 0x48, 0xbd, 0x31, 0xbf, 0x6e, 0xb1, 0xbe, 0x4c, 0xf6, 0x15, 0x5f, 0x81, 0xa, 0x68, 0x3f}),
-				new(big.Int).SetBytes([]byte{0x20, 0xa, 0x6d, 0x2, 0x90, 0x73, 0xf4, 0xa3, 0x1, 0x6b, 0x74, 0x57, 0x27, 0xf2, 0xd5, 0x1e, 0xb5, 0xde, 0x92, 0x88, 0x72, 0x39, 0x5e, 0xe9, 0xdf, 0xd6, 0x6c, 0xe2, 0xe0, 0xf5, 0x4c, 0x4c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x83, 0x19, 0x1b, 0x87, 0x83, 0xa5, 0xa, 0xe1, 0xc5, 0x2a, 0x1c, 0x13, 0x11, 0x5a, 0xfe, 0xce, 0x23, 0x94, 0x5e, 0x2e, 0x2a, 0x89, 0x4f, 0xd2, 0x1c, 0xc3, 0x2b, 0x2c, 0xb5, 0xc7, 0x1d, 0x91}),
-				new(big.Int).SetBytes([]byte{0xe0, 0xac, 0x7f, 0x15, 0x73, 0x80, 0x1b, 0x70, 0x9e, 0x6, 0xde, 0x13, 0xd1, 0x7c, 0xae, 0x8a, 0x17, 0xa1, 0x1f, 0x51, 0x75, 0x4, 0x45, 0xee, 0x70, 0xb9, 0xec, 0x8, 0xe2, 0x14, 0x8a, 0x61}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0x90, 0x4f, 0xaa, 0xb, 0x33, 0x4c, 0xdd, 0xa6, 0xe0, 0x0, 0x93, 0x5e, 0xf2, 0x21, 0x51, 0xec, 0x8, 0xd0, 0xf7, 0xbb, 0x11, 0x6, 0x9f, 0x57, 0x54, 0x5c, 0xcc, 0x1a, 0x37, 0xb7, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x5b, 0xc0, 0x87, 0xd0, 0xbc, 0x80, 0x10, 0x6d, 0x88, 0xc9, 0xec, 0xca, 0xc2, 0xd, 0x3c, 0x1c, 0x13, 0x99, 0x99, 0x81, 0xe1, 0x44, 0x34, 0x69, 0x9d, 0xcb, 0x9, 0x6b, 0x2, 0x27, 0x71, 0xc8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1, 0xa5, 0x75, 0xaf, 0x9d, 0x41, 0x46, 0x75, 0x3c, 0xf9, 0x91, 0x19, 0x63, 0x16, 0x99, 0x5d, 0x2a, 0x6e, 0xe7, 0xaa, 0xad, 0xf, 0x85, 0xad, 0x57, 0xcd, 0xf, 0x1f, 0x38, 0xa4, 0x7c, 0xa9}),
-				new(big.Int).SetBytes([]byte{0x30, 0x38, 0xf1, 0xcb, 0x8a, 0xb2, 0xd, 0xc3, 0xcc, 0x55, 0xfc, 0x52, 0xe1, 0xbb, 0x86, 0x98, 0xbd, 0xb9, 0x3c, 0x5d, 0x9f, 0x4d, 0x7e, 0xa6, 0x67, 0xc5, 0xdf, 0x2e, 0x77, 0xeb, 0xcd, 0xb7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x67, 0x37, 0x24, 0xfd, 0x24, 0xbc, 0x73, 0x18, 0x96, 0xb7, 0x69, 0xcc, 0x6e, 0x47, 0x9b, 0x89, 0x74, 0x2e, 0xf5, 0x57, 0x61, 0x5f, 0x8a, 0x67, 0x71, 0xac, 0x42, 0xfe, 0x48, 0xa2, 0x5, 0xe}),
-				new(big.Int).SetBytes([]byte{0xe4, 0xcf, 0x82, 0x57, 0x89, 0x6a, 0x4a, 0x20, 0x20, 0x34, 0x82, 0xc0, 0x9a, 0x88, 0x6b, 0x6d, 0xbe, 0x6b, 0xac, 0xfd, 0x43, 0x34, 0x9c, 0xc2, 0xb9, 0xc, 0x9a, 0x49, 0x6, 0x1d, 0x3d, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf5, 0xf0, 0xe0, 0x43, 0x76, 0x21, 0xd4, 0x39, 0xca, 0x71, 0xf5, 0xc1, 0xb7, 0x61, 0x55, 0xd6, 0xd3, 0xa6, 0x1a, 0x83, 0xd3, 0xc2, 0xc, 0x6e, 0xe3, 0x9, 0xd7, 0x55, 0xe3, 0x15, 0x56, 0x5b}),
-				new(big.Int).SetBytes([]byte{0x6b, 0x9f, 0x4e, 0x62, 0xbe, 0x5a, 0x5, 0x2b, 0xf6, 0x21, 0x89, 0x16, 0xd, 0xf7, 0x10, 0x1a, 0xa5, 0xbf, 0x61, 0xbf, 0x3e, 0xd7, 0xe4, 0xa, 0x67, 0x84, 0x30, 0xaf, 0xdd, 0x2e, 0xcc, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x43, 0x66, 0xef, 0xa4, 0x72, 0xdf, 0x4c, 0x30, 0x9e, 0x59, 0x8a, 0x63, 0x1f, 0x61, 0x66, 0xa8, 0xe0, 0x3a, 0xf5, 0x32, 0x87, 0x26, 0x1c, 0x66, 0xcb, 0xf6, 0xe4, 0x83, 0x82, 0xde, 0x63, 0xbf}),
-				new(big.Int).SetBytes([]byte{0x2e, 0x7d, 0xd9, 0x9, 0xbe, 0xe2, 0xd7, 0xce, 0x2e, 0xe2, 0x53, 0x7e, 0x13, 0x2, 0x68, 0xea, 0xf3, 0x3b, 0xc, 0x52, 0x5a, 0xaa, 0x6d, 0x6b, 0x2, 0xc6, 0xa4, 0x8, 0xe1, 0x79, 0x24, 0xcd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x99, 0x5c, 0xa7, 0xf3, 0x70, 0x81, 0xb8, 0xdc, 0x90, 0x5a, 0x47, 0x33, 0x62, 0x9, 0xed, 0x9f, 0x6b, 0x50, 0xe9, 0x5, 0x26, 0xd, 0x6a, 0xfe, 0x34, 0xcf, 0x60, 0x1f, 0xa9, 0xa7, 0x81, 0x79}),
-				new(big.Int).SetBytes([]byte{0xd9, 0xa0, 0x59, 0xe9, 0x55, 0x53, 0x57, 0x3a, 0xb5, 0xe3, 0x4d, 0x88, 0x4, 0x92, 0x86, 0x3a, 0xbb, 0xcc, 0x85, 0x56, 0x9a, 0xb6, 0x4e, 0x52, 0x93, 0x1f, 0xbb, 0x4c, 0xaf, 0xec, 0x1f, 0x47}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7b, 0xd7, 0x53, 0x62, 0x79, 0x91, 0xab, 0x1f, 0x97, 0xbe, 0x55, 0x69, 0x66, 0x7a, 0xa7, 0x5f, 0xd1, 0xf0, 0x2d, 0xe9, 0x7, 0xc9, 0x52, 0x5e, 0xf1, 0x5, 0x27, 0xff, 0x6, 0xf9, 0x61, 0x90}),
-				new(big.Int).SetBytes([]byte{0x83, 0x36, 0xf2, 0xb3, 0xdb, 0xba, 0x63, 0x9, 0xd5, 0xed, 0x64, 0x74, 0x94, 0x38, 0x27, 0xd6, 0xce, 0xf, 0xcc, 0x5c, 0x1e, 0xe, 0xa6, 0x95, 0xa3, 0xd1, 0x72, 0x4, 0xab, 0xda, 0x0, 0xf6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8f, 0x50, 0x6f, 0xb, 0x6c, 0xb, 0x6e, 0x9a, 0x57, 0xa7, 0xf3, 0x6d, 0x97, 0xc, 0xa4, 0xe3, 0x47, 0xcb, 0xc9, 0x21, 0x46, 0x22, 0x76, 0x42, 0xcb, 0xe7, 0x81, 0xd9, 0xf5, 0x36, 0x2d, 0x33}),
-				new(big.Int).SetBytes([]byte{0x46, 0x9f, 0x95, 0x5d, 0x2a, 0xfa, 0x61, 0x71, 0x95, 0x30, 0xc5, 0x42, 0x4f, 0x1c, 0x33, 0x68, 0x48, 0xcf, 0x92, 0x5d, 0x43, 0xbb, 0x8e, 0xaf, 0x30, 0x48, 0x7d, 0xc, 0x87, 0xfa, 0x24, 0x3f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0x7e, 0x92, 0x7b, 0xdd, 0xaa, 0xd5, 0xc1, 0xaf, 0xa3, 0x41, 0x9e, 0x89, 0x18, 0xdc, 0x92, 0x3, 0x8, 0xac, 0x35, 0xf4, 0xf0, 0x35, 0x24, 0x33, 0xb1, 0x53, 0x15, 0x56, 0xad, 0x41, 0xed}),
-				new(big.Int).SetBytes([]byte{0xdf, 0xe7, 0x74, 0x51, 0x56, 0xa8, 0x8b, 0x10, 0x55, 0x29, 0x80, 0xdf, 0x53, 0xa8, 0xf6, 0xa6, 0x2e, 0x73, 0x14, 0x71, 0x4e, 0xaf, 0x77, 0x5b, 0xdf, 0x43, 0x57, 0x86, 0x7e, 0x64, 0x2d, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0xc2, 0x10, 0x73, 0x38, 0xb9, 0x7d, 0xbf, 0x87, 0xe0, 0x17, 0x14, 0x1e, 0x41, 0x24, 0x63, 0x16, 0x6d, 0x65, 0xf5, 0x9d, 0x8f, 0xfc, 0xb4, 0xc1, 0x84, 0x3a, 0x1a, 0x3e, 0x5e, 0x66, 0x18}),
-				new(big.Int).SetBytes([]byte{0x9f, 0x9b, 0x8, 0x59, 0x18, 0x5c, 0x94, 0x14, 0xb4, 0x96, 0xc, 0x1a, 0x6f, 0x39, 0xb1, 0x1d, 0x7f, 0x3c, 0x4f, 0x3f, 0x1d, 0xcb, 0xcc, 0xf2, 0xe, 0xd5, 0x4d, 0xa0, 0x22, 0x30, 0xc8, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x23, 0x55, 0xcb, 0x86, 0x7d, 0x29, 0x1c, 0xcc, 0x94, 0xb4, 0x52, 0xb1, 0xed, 0xd3, 0x3d, 0x0, 0x7d, 0x57, 0x90, 0x4d, 0xd8, 0x63, 0xb, 0x1, 0xcf, 0xa6, 0x5, 0x12, 0x67, 0x74, 0x86, 0x90}),
-				new(big.Int).SetBytes([]byte{0x21, 0xc2, 0xf1, 0x8a, 0x5a, 0x71, 0xe1, 0xf8, 0xf5, 0x5b, 0x85, 0xec, 0x15, 0xaa, 0x7c, 0x58, 0x58, 0x28, 0x68, 0x58, 0xcd, 0x1c, 0x93, 0x91, 0x47, 0xc4, 0x75, 0x31, 0x4c, 0x89, 0x58, 0x2b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5, 0x94, 0xb, 0xc7, 0xef, 0x82, 0xa8, 0x5a, 0x65, 0xc1, 0x34, 0x29, 0x41, 0x30, 0xb1, 0x6a, 0x74, 0x20, 0x3c, 0xd0, 0x12, 0xe4, 0x31, 0xfc, 0x13, 0xd8, 0xd8, 0x5d, 0x84, 0x91, 0x35, 0xd3}),
-				new(big.Int).SetBytes([]byte{0x90, 0x53, 0x79, 0x85, 0xeb, 0x4f, 0xab, 0x61, 0x2f, 0x75, 0xb0, 0xc7, 0xaa, 0xb6, 0x68, 0x7a, 0xf1, 0x28, 0x63, 0x28, 0xc9, 0x90, 0x23, 0x91, 0xd0, 0x2d, 0x74, 0x51, 0x96, 0x22, 0x55, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb, 0x66, 0x82, 0x5b, 0x9b, 0x3d, 0xa5, 0x6c, 0x1, 0x35, 0x92, 0xab, 0x6c, 0x27, 0x5d, 0x24, 0xae, 0x9c, 0xba, 0xe9, 0x3c, 0xbe, 0x2, 0x8c, 0x6d, 0xae, 0xa2, 0x16, 0xa, 0xde, 0x7f, 0x16}),
-				new(big.Int).SetBytes([]byte{0xa1, 0xfb, 0xa0, 0xb8, 0x18, 0xe0, 0x9b, 0x3f, 0xef, 0x3a, 0x76, 0xba, 0x8b, 0x57, 0xa9, 0x7f, 0xb7, 0x24, 0x3c, 0xd, 0xf3, 0xe7, 0xcd, 0x2b, 0xd9, 0x44, 0x42, 0x68, 0x48, 0xc5, 0x62, 0x17}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0xee, 0x8e, 0xd8, 0xb5, 0xe5, 0xc9, 0xd2, 0x5d, 0xa5, 0xa9, 0xf7, 0x1b, 0x3f, 0xf6, 0xa4, 0xdc, 0xf3, 0x8a, 0x96, 0x84, 0xcf, 0xeb, 0x2b, 0xc6, 0x33, 0x7, 0x1, 0x64, 0xd2, 0x37, 0x4d}),
-				new(big.Int).SetBytes([]byte{0xf5, 0x0, 0xe7, 0xf0, 0x54, 0x6f, 0xf1, 0x10, 0x8a, 0x26, 0xb8, 0x43, 0xe5, 0xb6, 0xff, 0x62, 0x7, 0x55, 0x13, 0xe, 0xe6, 0xd4, 0xdd, 0xd9, 0x80, 0xe5, 0xbc, 0xb4, 0xfc, 0x86, 0x59, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf6, 0x2, 0x4, 0x3c, 0xf0, 0xbd, 0x2, 0x2c, 0xb6, 0x10, 0x9b, 0x2f, 0xd9, 0xf9, 0x51, 0xcb, 0x90, 0x46, 0x13, 0xb1, 0x1c, 0x3a, 0x3a, 0x7c, 0x29, 0x6, 0xb0, 0x22, 0x99, 0x6, 0xd, 0x5b}),
-				new(big.Int).SetBytes([]byte{0xf0, 0x36, 0xb7, 0x6, 0xc1, 0xf0, 0xcf, 0x19, 0x88, 0x1a, 0x8f, 0x64, 0xac, 0x4f, 0x7b, 0xea, 0x37, 0xaa, 0x56, 0xd2, 0xb7, 0xdd, 0x1a, 0x3f, 0x76, 0xf6, 0xf5, 0xb, 0x1a, 0xf8, 0x8f, 0x13}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0x7b, 0xcd, 0xb, 0xd3, 0x59, 0x83, 0xa7, 0x71, 0x9c, 0xca, 0x77, 0x64, 0xca, 0x90, 0x67, 0x79, 0xb5, 0x3a, 0x4, 0x3a, 0x9b, 0x8b, 0xca, 0xef, 0xf9, 0x59, 0xf4, 0x3a, 0xd8, 0x60, 0x47}),
-				new(big.Int).SetBytes([]byte{0x10, 0xb7, 0x77, 0xb, 0x2a, 0x3d, 0xa4, 0xb3, 0x94, 0x3, 0x10, 0x42, 0xc, 0xa9, 0x51, 0x45, 0x79, 0xe8, 0x8e, 0x2e, 0x47, 0xfd, 0x68, 0xb3, 0xea, 0x10, 0x4, 0x7e, 0x84, 0x60, 0x37, 0x2a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x33, 0xb3, 0x5b, 0xaa, 0x19, 0x5e, 0x72, 0x9d, 0xc3, 0x50, 0xf3, 0x19, 0x99, 0x69, 0x50, 0xdf, 0x3b, 0xc1, 0x5b, 0x8d, 0x3d, 0x3, 0x89, 0xe7, 0x77, 0xd2, 0x80, 0x8b, 0xf1, 0x3f, 0x3, 0x51}),
-				new(big.Int).SetBytes([]byte{0xa5, 0x8a, 0x1, 0x85, 0x64, 0xa, 0xbf, 0x87, 0xf9, 0x46, 0x40, 0x36, 0x24, 0x8d, 0x52, 0xbc, 0xaa, 0x65, 0x60, 0xef, 0xbc, 0x88, 0x9b, 0x70, 0x2b, 0xc5, 0x3, 0xcc, 0xcb, 0x8d, 0x74, 0x18}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbf, 0xc9, 0xc, 0xc, 0x8c, 0x8f, 0x33, 0x7e, 0xc7, 0x14, 0x73, 0x4e, 0xfa, 0xfe, 0x76, 0xbe, 0x3a, 0x75, 0xed, 0xfb, 0x69, 0x1b, 0x3, 0xc1, 0xff, 0xe8, 0x87, 0x9a, 0x4, 0x1e, 0xad, 0x4b}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x94, 0x81, 0xb1, 0xe0, 0x9c, 0xde, 0xd2, 0x4d, 0xba, 0x71, 0x8d, 0xd5, 0x4, 0x2d, 0x36, 0xfb, 0x46, 0x8e, 0xff, 0x32, 0xe0, 0xae, 0x3e, 0x74, 0x52, 0xc6, 0xf0, 0x86, 0xfe, 0xda, 0xed}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x37, 0x4d, 0xee, 0xae, 0x22, 0xc9, 0x3f, 0x95, 0x5c, 0xb8, 0x3a, 0xd2, 0x7, 0x1f, 0x7e, 0x22, 0x56, 0xf6, 0xe1, 0x9, 0xca, 0xd7, 0xbc, 0xa6, 0xd7, 0x1d, 0xc7, 0xb2, 0x44, 0x14, 0xbb, 0x36}),
-				new(big.Int).SetBytes([]byte{0x17, 0x11, 0x65, 0xb6, 0x4f, 0xcd, 0x4f, 0x99, 0x16, 0x3, 0x2c, 0x6, 0xf8, 0x6, 0xf7, 0x29, 0x38, 0x28, 0xd6, 0x63, 0x0, 0xe5, 0x43, 0x21, 0x78, 0x75, 0xbe, 0xa9, 0x8d, 0xaf, 0x73, 0x4a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x73, 0x2d, 0xf1, 0x1c, 0xbe, 0x3f, 0xaa, 0xa4, 0xdc, 0xa5, 0x99, 0x3e, 0x8c, 0x2d, 0x3f, 0x5b, 0x50, 0x75, 0x36, 0x17, 0xef, 0x9f, 0x73, 0xcb, 0x26, 0xe7, 0xbd, 0x7, 0x75, 0xbb, 0x3b, 0x3e}),
-				new(big.Int).SetBytes([]byte{0x7f, 0x41, 0x90, 0x3e, 0xde, 0x8f, 0x99, 0x77, 0xcb, 0x7e, 0x25, 0x58, 0x40, 0x25, 0x39, 0x16, 0xe6, 0x9d, 0xad, 0x6d, 0x64, 0xc5, 0x84, 0x36, 0xcc, 0x57, 0x7e, 0x1e, 0xd7, 0x36, 0x66, 0x93}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0x55, 0x69, 0xd, 0xab, 0xb5, 0xe0, 0xd, 0xc2, 0xd0, 0xd8, 0xa4, 0x96, 0xd1, 0x6c, 0x44, 0x76, 0xee, 0x76, 0x7c, 0xa9, 0xd0, 0xd1, 0xd3, 0x69, 0x4c, 0x85, 0x6e, 0xe5, 0xb7, 0xad, 0xd}),
-				new(big.Int).SetBytes([]byte{0xc3, 0xe2, 0x8e, 0x19, 0x75, 0xa0, 0x65, 0x7b, 0xfb, 0x21, 0xfb, 0xac, 0x97, 0xef, 0x99, 0xf3, 0x1, 0xca, 0xcb, 0x8a, 0xca, 0xc3, 0x12, 0x18, 0xd6, 0xc9, 0x87, 0x90, 0xb2, 0xe8, 0xc4, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4c, 0xe0, 0x94, 0xb9, 0x60, 0x39, 0x47, 0xb4, 0x28, 0xda, 0x88, 0x40, 0xcc, 0x97, 0xef, 0x60, 0x19, 0xa6, 0x69, 0x24, 0xe9, 0x77, 0x4c, 0x99, 0xc5, 0xf5, 0xb3, 0xc1, 0x88, 0x8d, 0xc3, 0xb9}),
-				new(big.Int).SetBytes([]byte{0x5, 0x39, 0xf, 0xba, 0xbf, 0x1a, 0x9b, 0x3e, 0xd5, 0x7b, 0x4d, 0x80, 0xca, 0x76, 0xac, 0xa2, 0x16, 0x20, 0x5b, 0x20, 0xc9, 0xea, 0x6, 0x50, 0x2c, 0x14, 0xf7, 0xb6, 0xe5, 0xc0, 0xde, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x23, 0x80, 0xc0, 0x9c, 0x7f, 0x3a, 0xea, 0xe5, 0x7c, 0x46, 0xe0, 0x73, 0x95, 0xae, 0xb0, 0xdc, 0x94, 0x4d, 0xba, 0xf2, 0xb6, 0x2a, 0x9f, 0xc, 0x5e, 0x8a, 0x64, 0xad, 0x6a, 0xe7, 0xd6, 0x16}),
-				new(big.Int).SetBytes([]byte{0x6f, 0x8e, 0x86, 0x19, 0x34, 0x64, 0x95, 0x6a, 0xf1, 0x59, 0x8a, 0xef, 0xd5, 0x9, 0xb0, 0x9a, 0x93, 0xaf, 0x92, 0x14, 0x8f, 0x84, 0x67, 0x56, 0x0, 0x99, 0xbe, 0x48, 0x16, 0x1b, 0xbc, 0x1a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9a, 0x96, 0x8e, 0xb7, 0x6f, 0xc6, 0x67, 0xde, 0x96, 0xd8, 0xa6, 0xa9, 0x5b, 0x2, 0x3f, 0xd0, 0xe2, 0x54, 0x70, 0xb3, 0xa5, 0x9, 0xae, 0xdd, 0x4c, 0x37, 0x5e, 0xb, 0xa, 0xaa, 0xfe, 0x5a}),
-				new(big.Int).SetBytes([]byte{0xab, 0xf6, 0xfb, 0x7, 0xa6, 0xd3, 0xba, 0x29, 0x54, 0xfc, 0x66, 0xd0, 0x3d, 0x86, 0x1d, 0xe, 0xe7, 0xb5, 0x88, 0xa, 0x65, 0xe7, 0xc, 0xc0, 0xce, 0x6b, 0xfd, 0x42, 0x4e, 0xd9, 0x75, 0xc0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x18, 0xa, 0x4e, 0xce, 0xd7, 0x4c, 0xea, 0xab, 0xe0, 0xf7, 0xdb, 0x3b, 0xb0, 0x38, 0x3, 0x4e, 0x5e, 0x65, 0x9c, 0x61, 0x3c, 0x66, 0xa5, 0x34, 0x8d, 0x96, 0x2d, 0x14, 0xef, 0xa3, 0x24, 0x2}),
-				new(big.Int).SetBytes([]byte{0x49, 0x8a, 0x4d, 0x57, 0x47, 0xba, 0xd7, 0xe2, 0xad, 0xbb, 0xfc, 0x2f, 0x24, 0xfe, 0xbe, 0x85, 0xa6, 0x2, 0xc5, 0x93, 0x7f, 0xdc, 0xd1, 0x8b, 0x32, 0x3, 0x78, 0xd, 0xc4, 0x89, 0x4b, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0xc6, 0xfb, 0xed, 0x1, 0x71, 0x61, 0x95, 0x4f, 0xa, 0xef, 0x80, 0x25, 0x36, 0xb2, 0x21, 0x7, 0xdd, 0x3d, 0xb5, 0x0, 0x74, 0xcd, 0x9, 0x37, 0x51, 0xcb, 0xf9, 0xf3, 0x4c, 0x63, 0x97}),
-				new(big.Int).SetBytes([]byte{0x4a, 0xd, 0xd2, 0xc5, 0x5e, 0x5e, 0x2a, 0xf1, 0x94, 0x58, 0x39, 0x4d, 0x92, 0xea, 0xb6, 0x46, 0x8a, 0xc, 0x77, 0x8, 0x90, 0x5e, 0xe, 0x0, 0xb1, 0xfc, 0x8d, 0x5, 0x68, 0xcb, 0x3f, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x40, 0x5a, 0x64, 0x5c, 0x62, 0xbf, 0xb9, 0x2c, 0xcc, 0x92, 0xd1, 0x8e, 0xcc, 0xe3, 0x2c, 0x4, 0xec, 0x5e, 0x7a, 0x16, 0x47, 0x10, 0x4f, 0xb1, 0x92, 0x7a, 0x47, 0xb0, 0x99, 0xb2, 0xdf, 0x82}),
-				new(big.Int).SetBytes([]byte{0xbc, 0x3c, 0xb4, 0x14, 0xf4, 0x4d, 0x96, 0xe0, 0xf5, 0xca, 0xca, 0x61, 0xe2, 0x37, 0xe7, 0x12, 0x2f, 0xfa, 0x9d, 0xb2, 0xce, 0xdc, 0x46, 0x2b, 0x5d, 0xa2, 0x83, 0x4a, 0x9, 0x30, 0x7d, 0xbf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4c, 0xbd, 0xe3, 0x98, 0x12, 0x92, 0x43, 0xb8, 0xe0, 0x2f, 0x6, 0xa7, 0x6a, 0xab, 0x39, 0x53, 0xfe, 0x92, 0x5b, 0x62, 0xa5, 0xaf, 0x64, 0x46, 0x19, 0xb8, 0x25, 0x93, 0xa, 0xc3, 0x13, 0x7e}),
-				new(big.Int).SetBytes([]byte{0x6c, 0xe5, 0x54, 0x60, 0x8f, 0x13, 0x61, 0x59, 0x50, 0x9, 0x2a, 0x13, 0x4b, 0x94, 0xc3, 0x70, 0xd1, 0x93, 0xa7, 0xae, 0x58, 0x8e, 0xad, 0x60, 0xc0, 0x8, 0xf1, 0x5c, 0xee, 0xc0, 0x2f, 0xe6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaa, 0x6d, 0xc4, 0xd3, 0xce, 0x53, 0x1a, 0xf3, 0xe6, 0x85, 0x90, 0xfc, 0xac, 0xbe, 0x78, 0x16, 0xc8, 0x29, 0x3a, 0xf0, 0xd7, 0x79, 0x14, 0xa3, 0xc7, 0x11, 0x9e, 0x23, 0xe5, 0xc, 0xe5, 0x6e}),
-				new(big.Int).SetBytes([]byte{0xeb, 0xc7, 0x99, 0x8f, 0xa2, 0x41, 0xe5, 0x29, 0x6e, 0x1f, 0x6e, 0xdc, 0x5e, 0x65, 0xe6, 0x63, 0xc1, 0x19, 0x84, 0xda, 0xf8, 0xf7, 0x10, 0xa8, 0xb9, 0xdf, 0x3a, 0x34, 0x7d, 0xed, 0xd7, 0x39}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x9d, 0x33, 0x3c, 0x82, 0xb1, 0x62, 0x47, 0xb8, 0xbd, 0x9c, 0x73, 0xc, 0x14, 0xce, 0xbb, 0x3, 0xc4, 0x8f, 0xcb, 0xbc, 0x3f, 0xb, 0x8a, 0x47, 0xa2, 0x30, 0x6c, 0xe0, 0xfb, 0xf8, 0x4b}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x7f, 0xc7, 0xfb, 0xe2, 0x4c, 0xc1, 0x52, 0x55, 0x31, 0x3d, 0x98, 0x26, 0x79, 0xb7, 0xeb, 0x67, 0x66, 0xd5, 0x43, 0xe2, 0xf5, 0xb5, 0xe6, 0x23, 0x9e, 0xe4, 0xb4, 0xa3, 0x6c, 0x3c, 0x48}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x5e, 0xed, 0x34, 0xc1, 0xcd, 0xff, 0x21, 0xe6, 0xd0, 0x81, 0x86, 0x89, 0xb8, 0x1b, 0xde, 0x71, 0xa7, 0xf4, 0xf1, 0x83, 0x97, 0xe6, 0x69, 0xa, 0x84, 0x1e, 0x15, 0x99, 0xc4, 0x38, 0x62}),
-				new(big.Int).SetBytes([]byte{0x28, 0x3b, 0xeb, 0xc3, 0xe8, 0xea, 0x23, 0xf5, 0x67, 0x1, 0xde, 0x19, 0xe9, 0xeb, 0xf4, 0x57, 0x6b, 0x30, 0x4e, 0xec, 0x20, 0x86, 0xdc, 0x8c, 0xc0, 0x45, 0x8f, 0xe5, 0x54, 0x2e, 0x54, 0x53}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf6, 0xf6, 0x22, 0x8, 0x3d, 0xaf, 0x54, 0x80, 0x4, 0x56, 0xbe, 0x13, 0x4d, 0x5f, 0x67, 0xd1, 0x47, 0xc8, 0x26, 0x42, 0xbe, 0xfc, 0x1c, 0xe2, 0xdc, 0x83, 0xa2, 0x70, 0x78, 0xf2, 0x82, 0x7c}),
-				new(big.Int).SetBytes([]byte{0x1b, 0xcd, 0x4e, 0x81, 0x7d, 0xe7, 0x3a, 0xf, 0xaf, 0x2c, 0x57, 0x15, 0xb3, 0x67, 0xce, 0xe7, 0xe6, 0x57, 0xca, 0x74, 0x48, 0x32, 0x1b, 0xf6, 0xd1, 0x5b, 0x20, 0xb5, 0x20, 0xaa, 0xa1, 0x2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x19, 0xa3, 0x14, 0xf3, 0x97, 0xc7, 0x5, 0xe7, 0x5e, 0x12, 0xea, 0x61, 0x39, 0xcf, 0x84, 0x56, 0x41, 0xdb, 0x92, 0x83, 0x1b, 0x38, 0xd6, 0x35, 0x14, 0xbd, 0x30, 0x6a, 0xb6, 0xe2, 0xd9, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x6c, 0xac, 0xd8, 0xf5, 0xda, 0xc7, 0x28, 0xdd, 0x23, 0x49, 0x65, 0xf8, 0x87, 0xf5, 0x28, 0xb3, 0xa5, 0x2, 0x1d, 0x1d, 0x24, 0x4, 0xbe, 0x56, 0xd5, 0x52, 0xee, 0x25, 0xcb, 0xaa, 0xaf, 0x33}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0x26, 0xe5, 0x18, 0x8f, 0x95, 0x3d, 0xe2, 0xbd, 0x70, 0xcb, 0x3c, 0x3d, 0x1f, 0xc2, 0x55, 0xcd, 0x91, 0xc3, 0xce, 0x7d, 0x8c, 0x6f, 0x36, 0x9d, 0x89, 0x32, 0x9, 0x71, 0x5a, 0xdc, 0xb6}),
-				new(big.Int).SetBytes([]byte{0xf3, 0xe1, 0x28, 0x81, 0x10, 0x12, 0xa3, 0x4d, 0x58, 0xe8, 0x46, 0xa7, 0x19, 0xd0, 0x17, 0x69, 0x16, 0xd2, 0xcb, 0x31, 0xb8, 0xb7, 0xab, 0x54, 0x49, 0xdb, 0xca, 0x3b, 0x58, 0xba, 0x68, 0xf3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0x40, 0xed, 0x4b, 0x95, 0xa8, 0xda, 0xa3, 0x55, 0xdc, 0x98, 0x63, 0x64, 0xf6, 0x72, 0x19, 0x20, 0x35, 0x88, 0x4, 0xa1, 0x0, 0xdc, 0xee, 0x7d, 0x85, 0x87, 0xeb, 0x12, 0xf0, 0x4, 0x80}),
-				new(big.Int).SetBytes([]byte{0x67, 0xc, 0xda, 0x6b, 0x22, 0xb, 0xf1, 0x41, 0x7, 0x96, 0x8d, 0xea, 0xa1, 0x5d, 0xd8, 0xda, 0xba, 0x75, 0x22, 0x54, 0x52, 0xae, 0x38, 0x72, 0x15, 0x92, 0xd5, 0xe2, 0xbe, 0x22, 0xcf, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0xff, 0xdc, 0xd, 0xe8, 0x18, 0x7f, 0xe9, 0xa8, 0x6, 0xe2, 0xa9, 0xaa, 0xe5, 0x1c, 0xff, 0xd0, 0xad, 0xfc, 0x3, 0x15, 0x40, 0xb, 0x37, 0x52, 0xe7, 0x37, 0xd9, 0x63, 0xa5, 0x22, 0x64}),
-				new(big.Int).SetBytes([]byte{0x3f, 0xee, 0x30, 0x18, 0x7a, 0xe2, 0x94, 0x8d, 0xb, 0xe5, 0x68, 0xf5, 0x5, 0x54, 0x70, 0x6c, 0x67, 0x81, 0x82, 0xc9, 0x2b, 0x17, 0x9d, 0x4, 0x98, 0xc7, 0xd2, 0x9a, 0x82, 0xda, 0x20, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9f, 0x57, 0x1, 0xa5, 0x34, 0x69, 0x18, 0xfb, 0x1d, 0xc5, 0x8c, 0x1f, 0x4e, 0xce, 0x53, 0x25, 0x45, 0xdc, 0x1a, 0x3c, 0x26, 0x9a, 0x3d, 0xc8, 0xe4, 0x84, 0xde, 0xe8, 0x23, 0xf5, 0x4c, 0x42}),
-				new(big.Int).SetBytes([]byte{0xce, 0x7b, 0x8f, 0xb8, 0x80, 0x1d, 0x9e, 0x57, 0xbf, 0xb9, 0x5b, 0x6b, 0x57, 0x29, 0xbf, 0xdd, 0x89, 0xee, 0x78, 0x4a, 0xb2, 0x19, 0xe5, 0x27, 0x86, 0xe, 0x1c, 0x49, 0x2f, 0xeb, 0x6a, 0x21}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x91, 0x22, 0x59, 0x11, 0xb9, 0x13, 0x2d, 0x28, 0xf5, 0xc6, 0xbc, 0x76, 0x3c, 0xea, 0xb7, 0xd1, 0x8c, 0x37, 0x6, 0xe, 0x8b, 0xd1, 0xd7, 0xed, 0x44, 0xdb, 0x75, 0x60, 0x78, 0x8c, 0x1e}),
-				new(big.Int).SetBytes([]byte{0xda, 0x8b, 0x4d, 0x98, 0x7c, 0xc9, 0xac, 0x9b, 0x27, 0xb8, 0x76, 0x35, 0x59, 0xb1, 0x36, 0xfa, 0x36, 0x96, 0x9c, 0x84, 0xfd, 0xef, 0x9e, 0x11, 0x63, 0x5c, 0x42, 0x22, 0x8e, 0x8f, 0xe, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x27, 0xf6, 0x11, 0x69, 0x23, 0x5a, 0x8c, 0xfc, 0x1, 0xbb, 0x87, 0x1, 0x6, 0x7e, 0xf9, 0xa0, 0xbc, 0xb3, 0xa, 0x26, 0x5b, 0xc7, 0x18, 0x32, 0x7e, 0x94, 0x13, 0x32, 0x95, 0x22, 0x46, 0x1a}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x12, 0xf1, 0xa9, 0x90, 0xa, 0x6a, 0xd2, 0xea, 0x48, 0xc5, 0x61, 0x27, 0x42, 0x8e, 0xe8, 0xe4, 0x98, 0x1b, 0x20, 0xda, 0x86, 0x39, 0x44, 0x6a, 0xa4, 0xca, 0xf9, 0xc7, 0x30, 0x1a, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x96, 0xee, 0x53, 0xe6, 0x73, 0x2a, 0x2e, 0x9, 0x58, 0x52, 0xd9, 0xd, 0x6c, 0xe2, 0xd5, 0xa3, 0x7b, 0xf8, 0xf6, 0x33, 0xa2, 0xf0, 0x6, 0x51, 0x6d, 0xbd, 0x98, 0x95, 0xe6, 0xdd, 0xd3, 0xac}),
-				new(big.Int).SetBytes([]byte{0x39, 0xb9, 0xca, 0xea, 0xe4, 0xb6, 0x29, 0xf2, 0xd8, 0xc4, 0xc9, 0x71, 0x1e, 0xbf, 0x1e, 0xe, 0xef, 0xd, 0x7, 0x97, 0x6c, 0x66, 0xdc, 0x6, 0xfb, 0xf2, 0x5b, 0x36, 0x72, 0x9b, 0x6f, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x64, 0x7, 0x79, 0x85, 0x6c, 0xee, 0xf9, 0x41, 0x26, 0x1b, 0xf2, 0xf4, 0x35, 0xf4, 0xa9, 0x81, 0x25, 0x98, 0xca, 0xbb, 0x63, 0xb9, 0xf3, 0x90, 0xcc, 0xe2, 0xc9, 0xb2, 0xd1, 0x4f, 0x36, 0xb9}),
-				new(big.Int).SetBytes([]byte{0xda, 0x61, 0x92, 0x8f, 0x44, 0xe5, 0x6e, 0xfa, 0xef, 0x90, 0xd7, 0x5f, 0xd8, 0x5a, 0x7b, 0x6c, 0x8c, 0xda, 0x8, 0x70, 0x49, 0x9c, 0xee, 0xb2, 0x89, 0x4b, 0x1e, 0xe9, 0xe4, 0xb4, 0xb5, 0xa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x78, 0x65, 0x90, 0x81, 0xe5, 0x47, 0x91, 0x39, 0x8b, 0x21, 0xd9, 0xac, 0x97, 0x2c, 0xaa, 0x2a, 0xa3, 0x7b, 0x68, 0x66, 0x31, 0x49, 0xdf, 0x6, 0x9c, 0x1f, 0xf5, 0xa4, 0xb6, 0xa8, 0x8c, 0x9c}),
-				new(big.Int).SetBytes([]byte{0x59, 0xa2, 0x30, 0x77, 0x92, 0xa3, 0xd, 0x2b, 0x69, 0xe8, 0xfa, 0x72, 0x25, 0xe8, 0x19, 0x61, 0x12, 0xcf, 0x91, 0x9b, 0x3, 0xab, 0x6a, 0x15, 0x5e, 0x5, 0x93, 0x45, 0x75, 0xa7, 0xd1, 0x9b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0x37, 0x50, 0xe3, 0x1c, 0x85, 0x66, 0x9f, 0x45, 0x2f, 0x70, 0x8b, 0xc0, 0x9d, 0xfe, 0xae, 0x7f, 0xe5, 0xa9, 0xfb, 0xb4, 0xc8, 0x15, 0xdb, 0xce, 0xf6, 0x3d, 0xf9, 0x94, 0xd6, 0xb7, 0x6f}),
-				new(big.Int).SetBytes([]byte{0xf7, 0x33, 0x9b, 0x14, 0xa6, 0xe7, 0xde, 0x6d, 0xac, 0x32, 0xfa, 0x3e, 0x4d, 0x41, 0x11, 0x13, 0xe7, 0x70, 0x7c, 0x11, 0xfd, 0xb8, 0x48, 0xd1, 0x41, 0xd9, 0x2c, 0xcc, 0x8d, 0xcc, 0xa8, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5e, 0x52, 0xb5, 0xb, 0x6e, 0x1f, 0xe, 0xbb, 0x80, 0x9f, 0xea, 0x76, 0xed, 0xaf, 0x30, 0x16, 0x6f, 0x3e, 0x5a, 0x1, 0x54, 0xdd, 0x16, 0x88, 0xd4, 0xfc, 0xdb, 0x13, 0xd0, 0xc1, 0x30, 0x79}),
-				new(big.Int).SetBytes([]byte{0x19, 0x9e, 0xde, 0xe7, 0x3a, 0x35, 0x4b, 0xc, 0x9b, 0x69, 0x87, 0x85, 0x34, 0xa0, 0x3e, 0xac, 0x4a, 0x38, 0x29, 0xd1, 0x3d, 0x63, 0xce, 0x1c, 0xc1, 0x3, 0xc4, 0x8e, 0x70, 0xe1, 0x29, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbb, 0xf1, 0xac, 0x7, 0xa3, 0xf2, 0x37, 0x8d, 0x5e, 0xd3, 0xc0, 0x21, 0xf3, 0xca, 0xff, 0x41, 0xab, 0x6a, 0xc8, 0x28, 0x40, 0xed, 0x78, 0x2b, 0x12, 0xee, 0x9c, 0x64, 0xfc, 0xee, 0xe4, 0x75}),
-				new(big.Int).SetBytes([]byte{0xb4, 0xbf, 0xb8, 0xde, 0xea, 0xd4, 0x60, 0xe6, 0x10, 0x88, 0x3c, 0xc8, 0x36, 0xb1, 0x5f, 0xe3, 0x24, 0xeb, 0x9d, 0x89, 0x43, 0x8b, 0x41, 0xd6, 0x84, 0x62, 0x2a, 0x0, 0x87, 0xfe, 0x50, 0x67}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6, 0xf9, 0xd9, 0xb8, 0x3, 0xec, 0xf1, 0x91, 0x63, 0x7c, 0x73, 0xa4, 0x41, 0x3d, 0xfa, 0x18, 0xf, 0xdd, 0xf8, 0x4a, 0x59, 0x47, 0xfb, 0xc9, 0xc6, 0x6, 0xed, 0x86, 0xc3, 0xfa, 0xc3, 0xa7}),
-				new(big.Int).SetBytes([]byte{0x7c, 0x80, 0xc6, 0x8e, 0x60, 0x30, 0x59, 0xba, 0x69, 0xb8, 0xe2, 0xa3, 0xe, 0x45, 0xc4, 0xd4, 0x7e, 0xa4, 0xdd, 0x2f, 0x5c, 0x28, 0x10, 0x2, 0xd8, 0x68, 0x90, 0x60, 0x3a, 0x84, 0x21, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xae, 0x86, 0xee, 0xea, 0x25, 0x2b, 0x41, 0x1c, 0x1c, 0xdc, 0x36, 0xc2, 0x84, 0x48, 0x29, 0x39, 0xda, 0x17, 0x45, 0xe5, 0xa7, 0xe4, 0xda, 0x17, 0x5c, 0x9d, 0x22, 0x74, 0x4b, 0x7f, 0xd7, 0x2d}),
-				new(big.Int).SetBytes([]byte{0x19, 0xe9, 0x93, 0xc9, 0x70, 0x73, 0x2, 0xf9, 0x62, 0xab, 0xa, 0xce, 0x58, 0x9f, 0xf0, 0xe9, 0x8d, 0x92, 0x11, 0x55, 0x14, 0x72, 0xf7, 0x28, 0x23, 0x34, 0xcb, 0x7a, 0x4e, 0xee, 0x38, 0xbc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x43, 0xca, 0x41, 0xd1, 0x62, 0xb3, 0xc6, 0x4f, 0xb8, 0x37, 0x4d, 0x85, 0x9c, 0xa6, 0xf7, 0x2f, 0xb2, 0xa7, 0x25, 0x8e, 0x42, 0x67, 0x63, 0xd5, 0x24, 0xed, 0x75, 0xe8, 0xd2, 0x1c, 0xe2, 0x4}),
-				new(big.Int).SetBytes([]byte{0xdc, 0xea, 0x5a, 0x82, 0xe3, 0x70, 0x23, 0xfa, 0x1c, 0x65, 0xf, 0x92, 0x18, 0xdb, 0xa3, 0x1f, 0x9a, 0xb6, 0xc7, 0xb3, 0x3e, 0xa4, 0xa4, 0x68, 0xe5, 0x25, 0x4, 0x4e, 0x93, 0x4a, 0x8f, 0x6b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x22, 0x48, 0xc9, 0xf9, 0xb, 0xbf, 0xff, 0x55, 0xe6, 0x1d, 0x2f, 0x8c, 0x56, 0xdc, 0x2c, 0x48, 0x87, 0x18, 0xbe, 0x75, 0xcf, 0x36, 0xf2, 0xee, 0x7a, 0x14, 0x74, 0x26, 0x7c, 0x16, 0x92, 0x90}),
-				new(big.Int).SetBytes([]byte{0xfa, 0x5, 0x94, 0x69, 0x2d, 0x21, 0xee, 0xd7, 0xa5, 0x6, 0xbb, 0x55, 0xb4, 0x35, 0xba, 0x18, 0xe1, 0x63, 0x75, 0x2, 0x35, 0xda, 0x2b, 0xe2, 0x36, 0x9d, 0x8a, 0x12, 0x88, 0x3e, 0xa2, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9c, 0x3e, 0x6, 0xef, 0x22, 0x89, 0x2b, 0xf5, 0xb6, 0x66, 0xf7, 0x23, 0x78, 0x5a, 0x50, 0x6d, 0x92, 0x2d, 0x4f, 0xf3, 0xf8, 0x72, 0x80, 0x59, 0x64, 0xfc, 0xe9, 0x2c, 0xeb, 0xe6, 0xef, 0xda}),
-				new(big.Int).SetBytes([]byte{0xa7, 0xb7, 0x9, 0xe5, 0xe7, 0x62, 0x92, 0x3d, 0x8a, 0xf0, 0xb2, 0xd4, 0x4c, 0xe2, 0x6f, 0xd5, 0xd4, 0x3b, 0xc8, 0x68, 0x7b, 0x36, 0xfd, 0xf7, 0xdf, 0x14, 0xf, 0x32, 0xa7, 0xae, 0xfc, 0x7d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x30, 0xab, 0xf8, 0x9b, 0x9c, 0xf2, 0x31, 0x37, 0x49, 0x78, 0x41, 0x99, 0x90, 0xf9, 0x22, 0x14, 0xcd, 0xd6, 0xe9, 0xae, 0xf0, 0x61, 0x61, 0x3f, 0x5b, 0x3a, 0x43, 0x20, 0x14, 0x97, 0x85, 0x83}),
-				new(big.Int).SetBytes([]byte{0x4b, 0x3, 0x51, 0x15, 0x47, 0x7f, 0x74, 0x98, 0xcd, 0xf7, 0xb4, 0xf2, 0xd0, 0xe1, 0xab, 0x80, 0x63, 0x12, 0xe, 0xa2, 0x3b, 0xb5, 0x84, 0xac, 0x44, 0x61, 0x80, 0x35, 0x1d, 0xc7, 0x57, 0x77}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5d, 0x6f, 0x8a, 0xa3, 0x13, 0xe2, 0xf, 0x3, 0x3b, 0xb7, 0x27, 0x59, 0xd8, 0x30, 0x77, 0x5b, 0xea, 0x2c, 0x78, 0x20, 0xd0, 0x6e, 0xe5, 0xe2, 0x79, 0x12, 0x7a, 0xb5, 0xc6, 0xc8, 0x8b, 0xe2}),
-				new(big.Int).SetBytes([]byte{0xad, 0xc4, 0xb1, 0x8d, 0x8d, 0x56, 0xd4, 0xe8, 0xc7, 0x1a, 0xaf, 0x33, 0xab, 0x8, 0xbc, 0x20, 0xed, 0xab, 0x6f, 0x8e, 0xa6, 0xbf, 0x92, 0xc2, 0x89, 0x2e, 0x55, 0x3f, 0xd, 0x7a, 0xd7, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0x1a, 0x6e, 0x16, 0xe0, 0x5c, 0x44, 0x7, 0x4a, 0xc1, 0x1b, 0x48, 0xd9, 0x40, 0x85, 0xd0, 0xa9, 0x9f, 0x8, 0x77, 0xdd, 0x1c, 0x6f, 0x76, 0xfd, 0xd, 0xac, 0x4b, 0xb5, 0x9, 0x64, 0xe3}),
-				new(big.Int).SetBytes([]byte{0x87, 0xd6, 0x6, 0x5b, 0x87, 0xa2, 0xd4, 0x30, 0xe1, 0xad, 0x5e, 0x25, 0x96, 0xf0, 0xaf, 0x24, 0x17, 0xad, 0xc6, 0xe1, 0x38, 0x31, 0x8c, 0x6f, 0x76, 0x7f, 0xbf, 0x8b, 0x6, 0x82, 0xbf, 0xc8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf5, 0x7d, 0x35, 0xc3, 0x4, 0xa6, 0xd, 0x5f, 0xc8, 0x6b, 0x15, 0xc3, 0xc1, 0x38, 0x8b, 0xc5, 0xc6, 0x9c, 0x3, 0x82, 0xc1, 0x73, 0xe4, 0x84, 0xf2, 0x89, 0x35, 0x1b, 0x92, 0xd1, 0xb8, 0x44}),
-				new(big.Int).SetBytes([]byte{0x70, 0x7f, 0x3d, 0x9e, 0xa9, 0x8e, 0xf4, 0xd9, 0x79, 0x4, 0xd3, 0xf5, 0xa9, 0xe4, 0x63, 0x4c, 0x70, 0x6, 0x97, 0x20, 0x30, 0x91, 0x5c, 0x6a, 0x46, 0x4b, 0xab, 0xbd, 0x61, 0x26, 0x68, 0x37}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6a, 0x73, 0xfb, 0x21, 0x67, 0x26, 0xa9, 0x55, 0xce, 0x8d, 0x41, 0x9b, 0x9d, 0xa1, 0xb, 0xa8, 0x11, 0xf8, 0xd, 0x46, 0x2c, 0x39, 0xfd, 0xf8, 0x73, 0x97, 0x16, 0x47, 0xb2, 0x1f, 0x51, 0xd3}),
-				new(big.Int).SetBytes([]byte{0x4f, 0x3c, 0xee, 0xfc, 0xa1, 0xed, 0xf3, 0xc2, 0x7f, 0xea, 0x7d, 0x29, 0x49, 0x72, 0x11, 0x32, 0x2d, 0xb4, 0x82, 0x46, 0x8b, 0x60, 0xb7, 0x74, 0x1, 0x82, 0x4d, 0xd9, 0xa5, 0x22, 0x4b, 0x17}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0xe7, 0x60, 0x7a, 0x3a, 0x65, 0x94, 0xa5, 0xf5, 0x96, 0xe1, 0xfe, 0xe3, 0xd8, 0x71, 0x46, 0x3b, 0xc7, 0xeb, 0x68, 0xa1, 0xd1, 0xb4, 0x35, 0x23, 0x4d, 0x0, 0x7f, 0x3c, 0xc7, 0xcb, 0x9}),
-				new(big.Int).SetBytes([]byte{0x28, 0x4d, 0xc8, 0x8d, 0xe8, 0xfb, 0xb8, 0xb1, 0xe3, 0x25, 0xfd, 0xd8, 0x5b, 0x34, 0x29, 0x9d, 0x65, 0xea, 0xe2, 0xbb, 0x66, 0xb5, 0x76, 0x41, 0x1b, 0xe7, 0x5a, 0x8a, 0x8, 0x7, 0x91, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaa, 0x1, 0x4e, 0xaa, 0xd9, 0x36, 0xde, 0x6f, 0x68, 0x1f, 0xac, 0x61, 0xa0, 0x5b, 0x6a, 0xa6, 0x46, 0x73, 0xf8, 0x53, 0xee, 0xdc, 0x99, 0xcc, 0x39, 0xa3, 0x7e, 0x63, 0xec, 0x28, 0x80, 0x15}),
-				new(big.Int).SetBytes([]byte{0x3d, 0xe9, 0x13, 0xc0, 0x46, 0x36, 0xbe, 0x9a, 0xc3, 0xf2, 0xa7, 0xe4, 0x3f, 0x7b, 0xf, 0x63, 0xef, 0x4b, 0x9b, 0x7, 0x43, 0xce, 0xca, 0xd9, 0xdf, 0x81, 0x4e, 0x66, 0x46, 0xc1, 0x0, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x29, 0xbe, 0xa3, 0x22, 0x56, 0x81, 0x82, 0xe6, 0x24, 0x0, 0x25, 0xb, 0x1b, 0x6, 0x90, 0x1d, 0xa8, 0x76, 0x1f, 0xb2, 0x17, 0x2e, 0xc3, 0xb9, 0x0, 0x60, 0xce, 0x12, 0x58, 0xcd, 0xee, 0x5}),
-				new(big.Int).SetBytes([]byte{0x7c, 0x40, 0xd9, 0xa2, 0x69, 0xe, 0x9a, 0xe, 0xa9, 0x0, 0x9b, 0x3f, 0xb8, 0x70, 0x5e, 0xc7, 0xf9, 0xfc, 0x31, 0xb6, 0xc8, 0xc6, 0x98, 0xe3, 0x9e, 0x4a, 0x3, 0x59, 0xa6, 0x26, 0x51, 0xc8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x36, 0xae, 0xe3, 0xd3, 0x53, 0x37, 0x51, 0x2b, 0x4e, 0xa2, 0xef, 0x2c, 0x77, 0xdb, 0x79, 0x6e, 0xba, 0x4c, 0x1c, 0xde, 0x6c, 0x79, 0x66, 0x14, 0x4c, 0xf8, 0x55, 0x78, 0xf1, 0x1d, 0x45, 0xf9}),
-				new(big.Int).SetBytes([]byte{0xca, 0xad, 0xda, 0x35, 0xcd, 0x69, 0x60, 0x9c, 0x79, 0xcb, 0x95, 0x47, 0x7, 0xc9, 0x8f, 0x54, 0x0, 0x4b, 0x35, 0xd0, 0xac, 0x47, 0x36, 0x6, 0x9d, 0x3b, 0xab, 0x3f, 0x7c, 0xfe, 0xba, 0xd4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf5, 0x21, 0x78, 0x6d, 0x23, 0x82, 0x88, 0xe7, 0xd9, 0xc3, 0x6f, 0xaf, 0xf3, 0x3d, 0x4a, 0x59, 0xd4, 0x60, 0xbd, 0x93, 0xbb, 0xdb, 0xdb, 0xcc, 0x11, 0xb6, 0xc7, 0x56, 0x12, 0xf1, 0x8a, 0xda}),
-				new(big.Int).SetBytes([]byte{0xe0, 0x68, 0x6f, 0xbf, 0x60, 0x38, 0xdb, 0x57, 0xec, 0xc2, 0x61, 0x2, 0xbc, 0xd1, 0xd1, 0xd6, 0xaa, 0x5e, 0xf4, 0xa3, 0x6c, 0x5b, 0xf0, 0x98, 0x1d, 0xd6, 0x8a, 0xfd, 0x23, 0x95, 0x35, 0x16}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x33, 0x22, 0xd4, 0x1, 0x24, 0x3c, 0x4e, 0x25, 0x82, 0xa2, 0x14, 0x7c, 0x10, 0x4d, 0x6e, 0xcb, 0xf7, 0x74, 0xd1, 0x63, 0xdb, 0xf, 0x5e, 0x53, 0x13, 0xb7, 0xe0, 0xe7, 0x42, 0xd0, 0xe6, 0xbd}),
-				new(big.Int).SetBytes([]byte{0x56, 0xe7, 0x7, 0x97, 0xe9, 0x66, 0x4e, 0xf5, 0xbf, 0xb0, 0x19, 0xbc, 0x4d, 0xda, 0xf9, 0xb7, 0x28, 0x5, 0xf6, 0x3e, 0xa2, 0x87, 0x3a, 0xf6, 0x24, 0xf3, 0xa2, 0xe9, 0x6c, 0x28, 0xb2, 0xa0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8d, 0x26, 0x20, 0x2, 0x50, 0xce, 0xbd, 0xae, 0x12, 0xe, 0xf3, 0x1b, 0x4, 0xc8, 0xc, 0xd5, 0xd, 0x4c, 0xdd, 0xc8, 0xea, 0xdb, 0xcf, 0x29, 0xfc, 0x69, 0x6d, 0x32, 0xc0, 0xad, 0xe4, 0x62}),
-				new(big.Int).SetBytes([]byte{0xeb, 0xed, 0x3b, 0xb4, 0x71, 0x5b, 0xf4, 0x37, 0xd3, 0x1f, 0x6f, 0x2d, 0xc3, 0xee, 0x36, 0xba, 0x1d, 0x4a, 0xfb, 0x4e, 0x72, 0x67, 0x8b, 0x3a, 0xd8, 0xe0, 0xa8, 0xb9, 0xf, 0x26, 0x47, 0xc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x78, 0xba, 0xaf, 0xf3, 0x1, 0x5c, 0x5, 0xba, 0x5d, 0x21, 0x96, 0xb3, 0xc6, 0x7f, 0x1, 0xbc, 0xb, 0x13, 0x29, 0x9c, 0x6e, 0x73, 0xc3, 0x30, 0xab, 0xd9, 0xd3, 0xf2, 0x5, 0x9a, 0xb4, 0x99}),
-				new(big.Int).SetBytes([]byte{0xad, 0x4b, 0xdc, 0xdb, 0xdb, 0x6, 0xc0, 0xaf, 0xaf, 0xca, 0x84, 0xe0, 0xed, 0x82, 0x8, 0x2e, 0x91, 0x63, 0x2e, 0xee, 0x8d, 0x12, 0x51, 0x99, 0x68, 0x1d, 0x23, 0x18, 0xfe, 0xe0, 0x97, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0x38, 0xc0, 0x76, 0x6e, 0xae, 0xbe, 0xa9, 0xce, 0x40, 0x68, 0xa1, 0xf5, 0x94, 0xd0, 0x3b, 0x8e, 0xd4, 0x93, 0xd, 0x7, 0x2d, 0x9c, 0x8b, 0x91, 0x64, 0x64, 0x3e, 0x15, 0x16, 0xe6, 0x33}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x9d, 0xb0, 0x2d, 0xbb, 0x27, 0x13, 0x59, 0xd6, 0xc9, 0x79, 0xe2, 0xd1, 0xc3, 0xdc, 0x17, 0x9, 0x46, 0x25, 0x2d, 0xcc, 0x74, 0x2, 0x28, 0x5, 0xcd, 0xb7, 0x28, 0xc7, 0x7b, 0x78, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6f, 0x70, 0xf2, 0x11, 0xa1, 0x4a, 0xe3, 0xd4, 0xc2, 0xb, 0x84, 0x98, 0x49, 0x29, 0xab, 0x1a, 0x23, 0x70, 0x9b, 0x36, 0xf8, 0x3a, 0x20, 0xca, 0x44, 0x93, 0xe1, 0x6c, 0xfd, 0x6, 0xac, 0xe6}),
-				new(big.Int).SetBytes([]byte{0x79, 0x1e, 0x8a, 0x30, 0x94, 0x2, 0x7b, 0x73, 0x6f, 0x95, 0xd8, 0xf3, 0x47, 0xb9, 0x9f, 0x50, 0x75, 0x32, 0x95, 0x66, 0xbe, 0x5a, 0xc5, 0xee, 0x4, 0x8b, 0xed, 0x34, 0xb6, 0x2, 0xd5, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x17, 0xc0, 0x72, 0xd5, 0x6b, 0xdd, 0x13, 0x82, 0xa7, 0x82, 0x48, 0x1b, 0x8a, 0xa4, 0xd2, 0x23, 0x2d, 0xb7, 0x94, 0x38, 0x58, 0x70, 0xbc, 0xad, 0xc3, 0x6, 0x33, 0x30, 0xa5, 0xcd, 0x53, 0x79}),
-				new(big.Int).SetBytes([]byte{0xd9, 0x1, 0xbd, 0xf4, 0x28, 0x3d, 0xa0, 0x64, 0xe7, 0x7c, 0x12, 0x47, 0xaf, 0x1d, 0x3, 0x4f, 0x89, 0x59, 0xac, 0x76, 0x26, 0x5b, 0xad, 0xd, 0xf7, 0xca, 0xe0, 0x51, 0xb1, 0x8, 0xcd, 0x25}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0x59, 0x9d, 0xb2, 0x9d, 0x6a, 0xa4, 0x15, 0xf8, 0x9, 0x49, 0xf1, 0x91, 0x3, 0xcc, 0xd4, 0x8c, 0xed, 0x48, 0x5b, 0x71, 0xe9, 0x62, 0x47, 0xdc, 0x8e, 0xe3, 0xee, 0x60, 0xee, 0x1b, 0x40}),
-				new(big.Int).SetBytes([]byte{0x79, 0x33, 0x62, 0x23, 0x2a, 0x81, 0xd4, 0xa0, 0xef, 0xaf, 0x89, 0x4a, 0xaa, 0x2f, 0xc7, 0xcf, 0xa6, 0x36, 0x3a, 0x74, 0xbc, 0x32, 0x99, 0x9d, 0xe1, 0xd6, 0x26, 0x5e, 0xd7, 0x8f, 0x93, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x27, 0x1d, 0x5b, 0x7, 0x70, 0xcb, 0x9c, 0x15, 0xe7, 0xb2, 0xea, 0x75, 0x8a, 0x6a, 0x11, 0xb9, 0xcd, 0xdc, 0xd7, 0x28, 0x2b, 0xe, 0xc2, 0x16, 0x19, 0xb0, 0x15, 0x52, 0x78, 0x8e, 0x7a, 0x66}),
-				new(big.Int).SetBytes([]byte{0x5d, 0x3a, 0xa4, 0x58, 0x34, 0xe7, 0xf4, 0x91, 0xe4, 0x57, 0xd0, 0x99, 0x49, 0xac, 0x87, 0x7f, 0xe2, 0xa0, 0x65, 0xe3, 0x50, 0x8a, 0x82, 0x4e, 0x7a, 0x8d, 0x72, 0x58, 0xe0, 0x3c, 0x97, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbb, 0xb, 0x4, 0x97, 0x4, 0x40, 0x69, 0x56, 0x43, 0x65, 0x62, 0xd3, 0x3d, 0x45, 0x18, 0x59, 0xf0, 0xe, 0x8f, 0x3, 0xc9, 0x12, 0x8, 0xe2, 0xf8, 0x1d, 0xfa, 0x28, 0x49, 0xc0, 0xc, 0x3e}),
-				new(big.Int).SetBytes([]byte{0x40, 0x67, 0xe4, 0x58, 0x53, 0xaf, 0x9f, 0x63, 0x65, 0x5d, 0x2f, 0xa1, 0x7a, 0xb1, 0xb0, 0x52, 0xfe, 0x67, 0x4, 0x4e, 0x90, 0x5d, 0xc9, 0xa, 0x79, 0x9a, 0x98, 0x2d, 0x11, 0x95, 0x5a, 0x35}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0x2a, 0xcb, 0x9b, 0x21, 0x99, 0x9a, 0x70, 0x54, 0x7, 0x8, 0xab, 0x68, 0x33, 0x82, 0x66, 0xae, 0xf6, 0x50, 0xee, 0xd8, 0x1c, 0x5b, 0x30, 0xda, 0x1e, 0x87, 0xd8, 0xa8, 0xa9, 0x23, 0xb7}),
-				new(big.Int).SetBytes([]byte{0x76, 0x84, 0x42, 0x85, 0x11, 0xc1, 0x72, 0x4d, 0x1c, 0x9a, 0xfa, 0xd, 0xf1, 0x3d, 0x9e, 0xb3, 0x60, 0xb0, 0xd0, 0xbf, 0x12, 0xd2, 0x7a, 0x4f, 0xa2, 0xdc, 0x12, 0x4a, 0xd7, 0xcd, 0x20, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdc, 0x5a, 0x41, 0x55, 0x41, 0x95, 0x78, 0x9e, 0x9b, 0x64, 0x9d, 0xbe, 0x7, 0x5a, 0x5f, 0xbf, 0xc6, 0x63, 0xb5, 0x51, 0xb5, 0x3e, 0x5e, 0xe7, 0xe0, 0xe7, 0x5b, 0x9c, 0x5, 0xdd, 0x32, 0xe6}),
-				new(big.Int).SetBytes([]byte{0x4a, 0xf3, 0xa8, 0xa6, 0x3f, 0x9f, 0x67, 0xa7, 0x6d, 0xce, 0xa5, 0xe2, 0x1, 0xbf, 0x59, 0x44, 0x2f, 0xf2, 0xee, 0x90, 0x76, 0xe4, 0x9b, 0xcf, 0x80, 0xe7, 0xdb, 0x7a, 0x75, 0x4a, 0x99, 0xb9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x88, 0x27, 0x1c, 0x2, 0x62, 0x11, 0x92, 0xf9, 0xba, 0x6b, 0x25, 0xef, 0x9c, 0xb2, 0x25, 0x6e, 0xac, 0x32, 0xa5, 0xf9, 0x1f, 0xd2, 0x5e, 0xa9, 0x57, 0x93, 0xc0, 0x18, 0xca, 0x2d, 0x8d, 0xae}),
-				new(big.Int).SetBytes([]byte{0xd7, 0x19, 0xdd, 0x53, 0x50, 0x71, 0x76, 0xaa, 0x40, 0x1c, 0x8b, 0x3a, 0xe5, 0xab, 0xf5, 0xac, 0xc3, 0x0, 0x87, 0x6d, 0xc7, 0x17, 0xd0, 0x99, 0xfb, 0x42, 0x6c, 0xf, 0x3e, 0x1e, 0x77, 0xd9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x15, 0x6e, 0x19, 0x70, 0x39, 0x87, 0x3b, 0x9d, 0xbe, 0x33, 0x54, 0xa5, 0x71, 0x1, 0x4e, 0x99, 0xa1, 0x6, 0x42, 0x25, 0xb5, 0x29, 0x60, 0x35, 0x9e, 0xd4, 0x5b, 0xac, 0x45, 0x44, 0xe7, 0xcb}),
-				new(big.Int).SetBytes([]byte{0x6b, 0xc0, 0x8d, 0x9f, 0x8f, 0x31, 0x90, 0x7d, 0xa9, 0x39, 0x57, 0x2a, 0x63, 0x83, 0x4b, 0xe8, 0x94, 0x96, 0xd5, 0x8d, 0x5e, 0xb4, 0x39, 0xcd, 0x6d, 0x53, 0x92, 0xc0, 0xad, 0x25, 0xa, 0x37}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x15, 0xb8, 0x39, 0xd, 0x65, 0x2d, 0x73, 0x38, 0xe1, 0x8e, 0xe0, 0x91, 0x97, 0xe0, 0xe1, 0x76, 0x74, 0xf8, 0xc4, 0xba, 0xfa, 0x2e, 0x7b, 0x85, 0x8f, 0x5b, 0xad, 0xc9, 0x9c, 0x89, 0x24, 0xf}),
-				new(big.Int).SetBytes([]byte{0x78, 0x6c, 0xf2, 0xc, 0x8e, 0xfe, 0x8d, 0x8, 0x3a, 0xbd, 0xd7, 0xcc, 0xc7, 0xa5, 0x9f, 0x99, 0xb3, 0x3, 0x67, 0xab, 0x5c, 0x1a, 0x33, 0x35, 0x2e, 0x2f, 0x9e, 0xf8, 0xe3, 0x26, 0xf0, 0x4a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x42, 0x69, 0xbc, 0xce, 0xcb, 0x68, 0x43, 0x82, 0x6b, 0xca, 0x76, 0xa2, 0x28, 0xe7, 0x16, 0x13, 0x48, 0xe9, 0x62, 0x6b, 0x9f, 0x19, 0xbf, 0x54, 0xdb, 0xfb, 0xc2, 0x9c, 0xc5, 0x98, 0x53, 0xca}),
-				new(big.Int).SetBytes([]byte{0xed, 0x2b, 0x1c, 0x1a, 0x82, 0xc0, 0x16, 0xb7, 0x23, 0xc8, 0x4c, 0xa9, 0xc4, 0x31, 0xa4, 0x9, 0xfd, 0x39, 0x40, 0xa5, 0xf9, 0xe5, 0xa8, 0xa3, 0xda, 0x95, 0x8e, 0xf5, 0x35, 0xb8, 0xd3, 0x67}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0x67, 0x2c, 0x7d, 0x2d, 0xe0, 0xb7, 0xda, 0x2b, 0xd1, 0x77, 0xd, 0x89, 0x66, 0x58, 0x68, 0x74, 0x1b, 0x3f, 0x9a, 0xf7, 0x64, 0x33, 0x97, 0x72, 0x1d, 0x74, 0xd2, 0x81, 0x34, 0xab, 0x83}),
-				new(big.Int).SetBytes([]byte{0x7c, 0x48, 0x1b, 0x9b, 0x5b, 0x43, 0xb2, 0xeb, 0x63, 0x74, 0x4, 0x9b, 0xfa, 0x62, 0xc2, 0xe5, 0xe7, 0x7f, 0x17, 0xfc, 0xc5, 0x29, 0x8f, 0x44, 0xc8, 0xe3, 0x9, 0x4f, 0x79, 0x3, 0x13, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0x4c, 0xcf, 0x6b, 0x74, 0xf, 0x9e, 0xc0, 0x36, 0xc1, 0x86, 0x12, 0x15, 0xc8, 0xa6, 0x1f, 0x3b, 0x89, 0xea, 0x46, 0xdf, 0x2e, 0x6d, 0x96, 0x99, 0x8b, 0x90, 0xbc, 0x1f, 0x17, 0xfc, 0x25}),
-				new(big.Int).SetBytes([]byte{0xd5, 0x71, 0x5c, 0xb0, 0x9c, 0x8b, 0x2d, 0xdb, 0x46, 0x2a, 0xe3, 0xdd, 0x32, 0xd5, 0x43, 0x55, 0xa, 0xe3, 0xd2, 0x77, 0xbf, 0xdd, 0x28, 0xdd, 0xd7, 0x1c, 0x7f, 0x6e, 0xcf, 0xe8, 0x6e, 0x76}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0x38, 0x74, 0xf9, 0xff, 0xf1, 0xd8, 0xc1, 0x68, 0x48, 0x76, 0x7, 0x58, 0x40, 0x14, 0x3d, 0xc4, 0x87, 0x2f, 0x9c, 0x68, 0x25, 0xe8, 0xb6, 0xfd, 0xf0, 0x72, 0x3a, 0x83, 0xba, 0x90, 0x0}),
-				new(big.Int).SetBytes([]byte{0xaa, 0x65, 0xe9, 0x23, 0x8, 0xa1, 0xc0, 0x69, 0xb8, 0x62, 0xdd, 0xe8, 0x94, 0x11, 0x7f, 0x93, 0x8, 0x5e, 0x35, 0xd, 0x7f, 0x66, 0xe9, 0xfa, 0x4d, 0xa3, 0xc7, 0xd9, 0x6f, 0x10, 0xcf, 0xa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0x1d, 0x1f, 0x5c, 0xee, 0x87, 0xb7, 0xf3, 0x8, 0x1e, 0x14, 0x20, 0x18, 0xf8, 0xaa, 0xed, 0x79, 0x2, 0xd, 0x47, 0xec, 0xfb, 0xc8, 0xd2, 0xc7, 0x17, 0x9, 0x23, 0xe8, 0xbe, 0xe8, 0xb6}),
-				new(big.Int).SetBytes([]byte{0x74, 0x8a, 0x32, 0x4e, 0xe2, 0xdf, 0x8e, 0xe1, 0x5a, 0x71, 0x89, 0xc8, 0xdd, 0xda, 0xd3, 0xb2, 0xf8, 0x0, 0x56, 0x9f, 0x62, 0x8c, 0xb2, 0x25, 0x0, 0x3d, 0x16, 0xaa, 0x41, 0x6, 0x44, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x57, 0xd, 0x5c, 0xe7, 0xaa, 0x68, 0x70, 0x13, 0x9a, 0xd1, 0x40, 0x75, 0xe9, 0xa3, 0xe7, 0x29, 0xec, 0xe, 0x3f, 0x85, 0x78, 0xa2, 0xd, 0x8, 0x1a, 0x60, 0x6f, 0x66, 0xed, 0x6, 0xdb, 0xd4}),
-				new(big.Int).SetBytes([]byte{0xa6, 0xae, 0x53, 0x49, 0x42, 0xe, 0x2, 0xf6, 0x5, 0xb6, 0x6e, 0x67, 0x11, 0xd0, 0x1b, 0xbb, 0xb6, 0x83, 0xa3, 0x6d, 0xc6, 0x46, 0xb, 0xed, 0x5a, 0x65, 0xbe, 0xce, 0xbd, 0x1e, 0xd4, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0x89, 0x1b, 0x5c, 0xd1, 0x8f, 0xa0, 0x2a, 0x58, 0xbe, 0xad, 0xc, 0x48, 0x48, 0xe3, 0xc3, 0xc3, 0xb8, 0x1a, 0x5c, 0x2d, 0x4, 0x29, 0x89, 0xae, 0x5d, 0x63, 0xb, 0x17, 0xba, 0x40, 0x2e}),
-				new(big.Int).SetBytes([]byte{0xe5, 0xd3, 0xe, 0xe, 0x6a, 0x9e, 0xc6, 0x68, 0xb, 0x38, 0xd0, 0xe4, 0x4, 0xf2, 0xa3, 0x6, 0xaf, 0x65, 0xc, 0x24, 0x21, 0x57, 0xa7, 0xee, 0x8e, 0x1b, 0x62, 0x79, 0xc4, 0xfa, 0xd9, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x75, 0xb5, 0xf8, 0x70, 0x28, 0x26, 0x8b, 0xb6, 0xf8, 0x7c, 0x22, 0x9e, 0xe0, 0x36, 0x6e, 0xf5, 0xda, 0xee, 0x32, 0xa0, 0xd2, 0x93, 0x39, 0x28, 0x43, 0x4c, 0x1f, 0x92, 0x9, 0x2d, 0x23, 0xe}),
-				new(big.Int).SetBytes([]byte{0x52, 0x7c, 0xce, 0x21, 0xe3, 0xa7, 0x85, 0x23, 0x63, 0xd7, 0x87, 0x45, 0x54, 0xdd, 0xfa, 0x8e, 0x2, 0x67, 0xa4, 0xb0, 0x5, 0x11, 0xf8, 0xfb, 0x3, 0x7c, 0xbd, 0xfb, 0xd5, 0x15, 0x70, 0xb8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x5c, 0x8c, 0x23, 0xd9, 0xc, 0x8e, 0x35, 0xc1, 0xa2, 0x14, 0xdd, 0xe2, 0xd4, 0x38, 0x3c, 0x7, 0x35, 0xae, 0x45, 0xbe, 0xf6, 0x1f, 0x10, 0xaa, 0x1a, 0x1c, 0x25, 0x59, 0x84, 0xcf, 0x74}),
-				new(big.Int).SetBytes([]byte{0x2b, 0xa9, 0x54, 0xd8, 0x28, 0x52, 0x22, 0x35, 0xc8, 0xdc, 0x6f, 0x45, 0xe2, 0x5f, 0xd7, 0xba, 0x47, 0xbf, 0x77, 0x2d, 0x50, 0xb0, 0x15, 0xa2, 0xc4, 0xa4, 0x8c, 0xd8, 0x39, 0xcc, 0xb0, 0x0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x44, 0xfc, 0x8e, 0xfa, 0xe1, 0xed, 0xde, 0xc2, 0xb0, 0xa5, 0xb8, 0xe, 0x27, 0xd2, 0x59, 0xc8, 0x92, 0x7f, 0x10, 0xa4, 0x2b, 0xe3, 0x88, 0x4c, 0x47, 0xb6, 0xbf, 0x6a, 0xc3, 0x4f, 0xcc, 0xe}),
-				new(big.Int).SetBytes([]byte{0xd2, 0xc7, 0xde, 0x94, 0xba, 0x9b, 0x13, 0x67, 0xe4, 0x92, 0x67, 0xe7, 0x88, 0x33, 0xd3, 0xd7, 0x72, 0xe, 0x94, 0xa4, 0xbc, 0xd0, 0x48, 0x9d, 0x9c, 0xff, 0x3, 0x1e, 0x71, 0x9c, 0x42, 0xa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x15, 0x66, 0x86, 0x13, 0x34, 0x28, 0x5d, 0xb6, 0x79, 0x46, 0xad, 0x8b, 0xa6, 0xa, 0x87, 0xfe, 0xab, 0x35, 0xa9, 0x8c, 0xb8, 0xb7, 0xf1, 0x30, 0x58, 0x41, 0x3f, 0xe, 0x89, 0x42, 0xde, 0xa9}),
-				new(big.Int).SetBytes([]byte{0x4f, 0x35, 0x97, 0xaa, 0xe, 0x82, 0xf0, 0x51, 0x4, 0xe8, 0x94, 0xef, 0xfb, 0x20, 0xfd, 0x22, 0xde, 0x48, 0x28, 0x68, 0x8b, 0x23, 0xed, 0xcb, 0xf9, 0x25, 0x46, 0xf0, 0x36, 0x7b, 0xe3, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xde, 0xa2, 0xba, 0x47, 0xba, 0xce, 0x7b, 0xc6, 0x86, 0x6a, 0x16, 0x62, 0xe, 0x2, 0xf5, 0x35, 0xff, 0xfb, 0x3f, 0xa2, 0xc3, 0x30, 0x71, 0x75, 0xd5, 0xa4, 0x81, 0xfc, 0x8b, 0xb6, 0x99, 0x91}),
-				new(big.Int).SetBytes([]byte{0xae, 0x28, 0xbf, 0xd6, 0xd9, 0xc, 0x28, 0xe4, 0xac, 0x5c, 0xbf, 0xec, 0x84, 0xf7, 0xb9, 0xe7, 0x22, 0x9d, 0xcd, 0xb3, 0x62, 0x1e, 0xd9, 0xbc, 0x70, 0x67, 0x42, 0xa9, 0xf1, 0x6f, 0xe2, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0x32, 0xf8, 0xbc, 0x1c, 0xc6, 0x99, 0x9b, 0x47, 0x2c, 0xa8, 0xf2, 0x30, 0x6b, 0x31, 0x9a, 0xe, 0xc, 0x17, 0x9e, 0xd6, 0x9b, 0x7b, 0xb3, 0xe6, 0x1e, 0xd6, 0x1e, 0x53, 0x6b, 0xcf, 0xe8}),
-				new(big.Int).SetBytes([]byte{0xc3, 0x9d, 0xc7, 0xee, 0x29, 0x60, 0x2e, 0xd7, 0x13, 0xf6, 0x7c, 0x45, 0x35, 0xdc, 0x6, 0x81, 0xba, 0x93, 0xb4, 0x24, 0xd5, 0xa8, 0xc2, 0x14, 0x51, 0xb0, 0xdd, 0x2c, 0xda, 0xe7, 0x84, 0x2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x39, 0x68, 0xfc, 0x98, 0xa6, 0xe1, 0x68, 0xd6, 0xef, 0xc1, 0xcf, 0x6c, 0xc4, 0xb8, 0xa8, 0x36, 0x26, 0xd0, 0xa, 0xbc, 0x29, 0xa4, 0x61, 0x65, 0x8c, 0x24, 0xd, 0x2, 0x51, 0x4f, 0xc9, 0xcc}),
-				new(big.Int).SetBytes([]byte{0x78, 0x9c, 0xbb, 0xd0, 0xdb, 0x4b, 0x59, 0x28, 0x12, 0x37, 0x98, 0x2d, 0x1a, 0xad, 0x32, 0xc9, 0x1a, 0x14, 0x65, 0x76, 0xff, 0x88, 0xf3, 0x34, 0x1, 0x93, 0x94, 0x89, 0x5f, 0xad, 0x37, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb5, 0xdd, 0x69, 0x36, 0x4e, 0x54, 0x8, 0x16, 0x83, 0xe, 0x37, 0x15, 0x9c, 0x2a, 0x49, 0x53, 0x80, 0xbf, 0x1d, 0x6d, 0xee, 0xba, 0x1f, 0x27, 0xc0, 0x23, 0xaf, 0x3e, 0x14, 0xcc, 0x84, 0xbf}),
-				new(big.Int).SetBytes([]byte{0x4b, 0x36, 0xec, 0x58, 0x53, 0xc7, 0xcb, 0x6b, 0x6b, 0xd1, 0x87, 0x33, 0xc5, 0x8d, 0x41, 0x45, 0x35, 0x31, 0x10, 0xde, 0xdd, 0x6b, 0x1a, 0x90, 0xc7, 0x85, 0x21, 0x7c, 0xe3, 0x74, 0x75, 0xc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x68, 0x96, 0x91, 0x6, 0x98, 0xe0, 0x6a, 0x29, 0x26, 0xb2, 0x5d, 0xf0, 0x95, 0x79, 0x45, 0xd5, 0xa6, 0xa5, 0x2e, 0x53, 0x60, 0x3a, 0x3a, 0x25, 0xa, 0xec, 0x21, 0x91, 0xae, 0xc, 0x85, 0xf1}),
-				new(big.Int).SetBytes([]byte{0xae, 0xfd, 0x3f, 0xb4, 0xb3, 0x8c, 0x97, 0x92, 0x56, 0xf3, 0x5, 0xc1, 0xd8, 0x45, 0xd7, 0x2d, 0x1f, 0x40, 0xc1, 0x9d, 0xa0, 0xd5, 0x3, 0x92, 0x2c, 0x74, 0x82, 0x33, 0x2d, 0xc1, 0xde, 0x21}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9, 0x48, 0xbf, 0x80, 0x9b, 0x19, 0x88, 0xa4, 0x6b, 0x6, 0xc9, 0xf1, 0x91, 0x94, 0x13, 0xb1, 0xf, 0x92, 0x26, 0xc6, 0xf, 0x66, 0x88, 0x32, 0xff, 0xd9, 0x59, 0xaf, 0x60, 0xc8, 0x2a, 0xa}),
-				new(big.Int).SetBytes([]byte{0x53, 0xa5, 0x62, 0x85, 0x6d, 0xcb, 0x66, 0x46, 0xdc, 0x6b, 0x74, 0xc5, 0xd1, 0xc3, 0x41, 0x8c, 0x6d, 0x4d, 0xff, 0x8, 0xc9, 0x7c, 0xd2, 0xbe, 0xd4, 0xcb, 0x7f, 0x88, 0xd8, 0xc8, 0xe5, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x26, 0x95, 0x2c, 0x7f, 0x37, 0x2e, 0x59, 0x36, 0xd, 0x5c, 0xe4, 0xc6, 0x62, 0x91, 0xf0, 0xb6, 0xef, 0x16, 0xc1, 0x33, 0x1e, 0x82, 0x5e, 0x51, 0x39, 0x6e, 0xb0, 0x45, 0x7e, 0x8b, 0x0, 0xa}),
-				new(big.Int).SetBytes([]byte{0xf5, 0x13, 0xea, 0x4c, 0x58, 0x0, 0xa6, 0x88, 0x62, 0xbc, 0x89, 0x3d, 0x2d, 0x68, 0x84, 0x22, 0xde, 0xbe, 0x39, 0x8f, 0x65, 0x3d, 0x67, 0x31, 0x8c, 0x3d, 0x40, 0x1f, 0x5, 0xef, 0x70, 0x5a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x99, 0x45, 0xb2, 0xfb, 0xe3, 0x82, 0x2b, 0xbc, 0xb5, 0xc3, 0x39, 0x6d, 0x20, 0x56, 0xf8, 0x49, 0x64, 0x12, 0x42, 0xee, 0x65, 0xe2, 0xaa, 0x52, 0x72, 0x82, 0xfe, 0x5f, 0xb8, 0xc8, 0xac, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x3e, 0xef, 0xed, 0x82, 0x4b, 0xf, 0x28, 0x2d, 0x60, 0x7d, 0xb4, 0x4f, 0xfb, 0x28, 0xef, 0xf5, 0x28, 0x2f, 0x7a, 0x23, 0xee, 0xda, 0xcd, 0xfa, 0x96, 0xd9, 0x43, 0xa1, 0x69, 0xae, 0xa3, 0xb0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x2e, 0x58, 0xe6, 0xfc, 0x23, 0xc5, 0xbd, 0xbe, 0xf2, 0xbe, 0x8b, 0x13, 0x1f, 0xf2, 0x43, 0xf5, 0x21, 0x19, 0x65, 0x72, 0xd6, 0xb0, 0xe9, 0xf1, 0x2, 0x58, 0x89, 0x76, 0x13, 0x4f, 0x96}),
-				new(big.Int).SetBytes([]byte{0x43, 0x97, 0x82, 0x7d, 0x45, 0xb1, 0xa1, 0x67, 0x8c, 0x3d, 0x67, 0x67, 0x53, 0x14, 0x1f, 0xc5, 0xbc, 0xfb, 0x85, 0x35, 0x63, 0x73, 0x1c, 0x3e, 0x82, 0x27, 0x7e, 0xd4, 0xd1, 0x4c, 0xf9, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2a, 0x31, 0x4c, 0x6b, 0x20, 0x58, 0x70, 0xe6, 0x23, 0x5f, 0x82, 0x22, 0x71, 0x7, 0xd5, 0xfe, 0x10, 0xa0, 0x44, 0x8, 0x52, 0xbb, 0xe1, 0xf6, 0xed, 0x1d, 0x79, 0xe3, 0x96, 0x9e, 0x35, 0x3a}),
-				new(big.Int).SetBytes([]byte{0x15, 0xa4, 0xac, 0xb, 0xf3, 0x5a, 0x27, 0xac, 0x1a, 0x46, 0x3e, 0x47, 0x6b, 0xaa, 0x1b, 0xa0, 0x13, 0x8a, 0x54, 0xaa, 0xdb, 0x26, 0x58, 0xbf, 0xc2, 0x59, 0x26, 0xe1, 0xe5, 0x74, 0x60, 0x67}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc, 0x7d, 0x11, 0x5c, 0xe, 0xb4, 0x63, 0x7d, 0xb0, 0x68, 0x77, 0x2c, 0x77, 0xc9, 0x5d, 0xbf, 0x3b, 0xd2, 0x78, 0xde, 0x25, 0x81, 0x31, 0x8e, 0x3b, 0xaa, 0x49, 0x98, 0x92, 0xfc, 0xcf, 0x64}),
-				new(big.Int).SetBytes([]byte{0x4a, 0xa8, 0x74, 0x7b, 0x19, 0x25, 0xb0, 0x44, 0x7a, 0xa4, 0x24, 0xb8, 0x47, 0x8a, 0xf1, 0x45, 0x48, 0x78, 0x5d, 0x83, 0x95, 0x9a, 0xe6, 0x8d, 0x53, 0xf6, 0xd3, 0xb3, 0x28, 0x78, 0xfe, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x59, 0x59, 0xa5, 0x0, 0xb7, 0x3, 0xfc, 0x2d, 0x49, 0x8b, 0x6f, 0xaf, 0x3a, 0x6b, 0x6c, 0x91, 0x1b, 0x4f, 0x41, 0x6, 0xdd, 0x7f, 0x3f, 0xf9, 0x89, 0xe2, 0xf4, 0x9e, 0xe9, 0xb8, 0x49, 0x66}),
-				new(big.Int).SetBytes([]byte{0x3, 0x70, 0xe6, 0x74, 0x1f, 0x5c, 0xa8, 0x97, 0xf9, 0x43, 0x12, 0x82, 0xd, 0xc8, 0x2a, 0x70, 0x91, 0x0, 0xdc, 0xc0, 0x8c, 0xfe, 0x24, 0x26, 0x40, 0xa6, 0x63, 0x21, 0x87, 0x47, 0x3a, 0x6a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x10, 0x74, 0x60, 0x52, 0xe, 0xec, 0x5c, 0x74, 0x16, 0x83, 0x32, 0x9a, 0x71, 0x66, 0x22, 0xb0, 0xb8, 0x1c, 0x3, 0x20, 0x8, 0x7, 0xde, 0x97, 0x36, 0x86, 0xf8, 0x80, 0xb, 0x18, 0x8c, 0xbb}),
-				new(big.Int).SetBytes([]byte{0xab, 0xe5, 0xd4, 0xc0, 0x9a, 0x21, 0x59, 0x8c, 0x35, 0x32, 0x6b, 0x9b, 0x9c, 0xf5, 0x4a, 0x11, 0x24, 0x2e, 0xd, 0x74, 0x8d, 0xce, 0x3d, 0xa6, 0x1, 0xd7, 0xb6, 0x36, 0x1f, 0x27, 0x21, 0x24}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9e, 0xeb, 0x31, 0x39, 0x37, 0x22, 0x2f, 0xe8, 0xe1, 0x16, 0xc, 0x46, 0x30, 0x5b, 0x5f, 0x9d, 0x7d, 0xd8, 0x62, 0x3c, 0x7e, 0xed, 0x3f, 0xeb, 0x3b, 0xc, 0x9b, 0x92, 0x2b, 0xc6, 0xb1, 0x73}),
-				new(big.Int).SetBytes([]byte{0xe1, 0x21, 0xf1, 0xe0, 0x11, 0xe, 0xd5, 0x8d, 0x95, 0x2a, 0xab, 0xfd, 0xbb, 0xba, 0x1a, 0xe3, 0xe, 0xa7, 0x1a, 0xbe, 0xd0, 0xe7, 0xc, 0x4b, 0x95, 0x39, 0x62, 0xd, 0x97, 0x23, 0xa7, 0x1d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x52, 0xd, 0x9a, 0x9b, 0xf3, 0xda, 0xa9, 0xcb, 0x18, 0xd1, 0xe8, 0xb, 0xa2, 0x7b, 0x3c, 0x2f, 0x7b, 0xa4, 0x9b, 0x4d, 0xbc, 0x92, 0x7a, 0x94, 0x45, 0x7e, 0x9b, 0xc9, 0x2a, 0xd2, 0xcf, 0x25}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x26, 0xf4, 0x9b, 0x10, 0x27, 0x1e, 0xae, 0x48, 0xf0, 0xae, 0x71, 0xb4, 0xba, 0x97, 0x6, 0x2b, 0xab, 0xb, 0x65, 0x11, 0xbf, 0x70, 0xc7, 0x61, 0xb, 0x6c, 0x83, 0xa2, 0x85, 0x18, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x39, 0xcc, 0x4f, 0xe4, 0xc7, 0xf7, 0x18, 0xf5, 0x16, 0xeb, 0xb2, 0x38, 0x80, 0x6e, 0x97, 0x88, 0x7d, 0xe4, 0xf, 0x75, 0x9, 0xd9, 0x95, 0x91, 0x57, 0x72, 0x44, 0x3f, 0x7e, 0xc8, 0x5, 0xf3}),
-				new(big.Int).SetBytes([]byte{0xec, 0xb1, 0x47, 0x2c, 0x5a, 0x46, 0xf8, 0xf3, 0x15, 0x1e, 0x66, 0x93, 0xcf, 0x42, 0xde, 0x7d, 0xbb, 0xb9, 0x57, 0x37, 0x3f, 0xc9, 0x12, 0xe4, 0xd1, 0x91, 0xa0, 0xc1, 0x3a, 0x3c, 0x48, 0xd3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5f, 0xc6, 0xc1, 0x1e, 0x61, 0x68, 0x95, 0x35, 0xa3, 0x95, 0x86, 0xa8, 0x1b, 0xf3, 0x38, 0x1b, 0xe1, 0xdb, 0x7, 0xef, 0x7a, 0x51, 0xbd, 0x2e, 0x8b, 0x5a, 0xeb, 0xac, 0x54, 0x8d, 0x56, 0x22}),
-				new(big.Int).SetBytes([]byte{0x10, 0xe8, 0xe, 0x54, 0x9a, 0xa1, 0xd9, 0xdb, 0x9c, 0x19, 0xba, 0x2d, 0xb0, 0x36, 0x7a, 0xcb, 0xe, 0x5, 0xad, 0x8, 0xe6, 0xc7, 0xbf, 0x30, 0xd, 0xd, 0x84, 0x1, 0x1, 0xac, 0x16, 0x83}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf9, 0x4c, 0x80, 0x74, 0x66, 0xec, 0xdc, 0x69, 0x68, 0x92, 0xff, 0xde, 0x64, 0xdb, 0xd4, 0xd2, 0xc7, 0x13, 0x28, 0x96, 0xd2, 0xd6, 0xf8, 0x87, 0x93, 0xd6, 0x2f, 0xb5, 0xce, 0x22, 0x58, 0xe}),
-				new(big.Int).SetBytes([]byte{0x5e, 0x9c, 0x7f, 0xdc, 0x67, 0x85, 0x22, 0x5a, 0x91, 0x94, 0xbf, 0xf6, 0xab, 0x5a, 0x81, 0xf6, 0x98, 0x87, 0xec, 0x45, 0x32, 0x37, 0x1d, 0x4e, 0x81, 0xe5, 0x23, 0xf6, 0x71, 0x27, 0xdb, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0xa4, 0x33, 0xe5, 0xd8, 0x72, 0xd6, 0xe9, 0x34, 0x31, 0x27, 0x4a, 0x33, 0xed, 0x9, 0xad, 0x26, 0xd7, 0x7d, 0x68, 0x45, 0x73, 0xef, 0x99, 0x12, 0x3a, 0xb6, 0x7, 0xc0, 0xa3, 0xd8, 0xa5}),
-				new(big.Int).SetBytes([]byte{0xdd, 0xce, 0xa1, 0xfc, 0x68, 0x2a, 0xe7, 0x9a, 0x19, 0xa3, 0x94, 0xae, 0xc7, 0x28, 0xa5, 0x61, 0x16, 0x47, 0x5c, 0xf5, 0x2d, 0x9d, 0x6e, 0xf4, 0xfb, 0x5f, 0x7e, 0xf7, 0x6d, 0x3c, 0x2b, 0xa7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8c, 0xcc, 0xb8, 0x6c, 0x6a, 0xd5, 0xd1, 0x62, 0xfc, 0xc0, 0x20, 0x68, 0xdb, 0x19, 0x99, 0xd2, 0x50, 0x24, 0xa9, 0x59, 0x4d, 0x3, 0xda, 0x7a, 0x6b, 0xe4, 0xb, 0xad, 0x10, 0xc4, 0xf2, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x57, 0xf8, 0x96, 0x58, 0x62, 0x75, 0x1c, 0x43, 0x69, 0x9b, 0xef, 0x54, 0x9c, 0x87, 0x58, 0xa5, 0x4b, 0x92, 0xdc, 0xac, 0x82, 0x64, 0xbe, 0x28, 0xf7, 0xf1, 0x26, 0xea, 0x72, 0x9d, 0xfe, 0xa0}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x62, 0x60, 0xce, 0x7f, 0x46, 0x18, 0x1, 0xc3, 0x4f, 0x6, 0x7c, 0xe0, 0xf0, 0x28, 0x73, 0xa8, 0xf1, 0xb0, 0xe4, 0x4d, 0xfc, 0x69, 0x75, 0x2a, 0xcc, 0xec, 0xd8, 0x19, 0xf3, 0x8f, 0xd8, 0xe8}),
-				new(big.Int).SetBytes([]byte{0xbc, 0x2d, 0xa8, 0x2b, 0x6f, 0xa5, 0xb5, 0x71, 0xa7, 0xf0, 0x90, 0x49, 0x77, 0x6a, 0x1e, 0xf7, 0xec, 0xd2, 0x92, 0x23, 0x80, 0x51, 0xc1, 0x98, 0xc1, 0xa8, 0x4e, 0x95, 0xb2, 0xb4, 0xae, 0x17}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0xd8, 0xda, 0x47, 0x48, 0xad, 0x1a, 0x73, 0xde, 0xc8, 0x40, 0x9b, 0xe8, 0x4f, 0x1a, 0x13, 0x16, 0xe6, 0x5c, 0x51, 0x96, 0xaa, 0xd2, 0x7e, 0x7, 0x66, 0x74, 0x6f, 0x3d, 0x47, 0x7c, 0x2d}),
-				new(big.Int).SetBytes([]byte{0x58, 0x94, 0x8b, 0x53, 0x66, 0x5c, 0x66, 0x90, 0x58, 0x6b, 0x53, 0x65, 0x31, 0xef, 0xc7, 0xbc, 0x94, 0xb0, 0xa0, 0x20, 0x33, 0xc4, 0xd5, 0xa6, 0x20, 0x79, 0x81, 0x6f, 0xc7, 0xd1, 0xdd, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x87, 0xd1, 0x27, 0x28, 0x4, 0x82, 0xdf, 0xc3, 0x3d, 0x5, 0x89, 0x37, 0xf2, 0x33, 0x3b, 0x3d, 0x38, 0xc4, 0x6f, 0x48, 0xd, 0x9e, 0x3a, 0x5b, 0xbb, 0xb, 0xa4, 0x65, 0x41, 0x13, 0x66, 0x2}),
-				new(big.Int).SetBytes([]byte{0x71, 0xce, 0x24, 0x87, 0xa, 0x5a, 0x3, 0xde, 0x92, 0x6a, 0x27, 0x6c, 0xff, 0x67, 0x74, 0x53, 0x87, 0xa8, 0x84, 0x74, 0x6f, 0xd3, 0xbf, 0x7c, 0x4f, 0x68, 0x3c, 0x41, 0xd8, 0xaf, 0x6a, 0xac}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0x2a, 0x71, 0x66, 0xe7, 0xec, 0x4b, 0x96, 0x8c, 0x8, 0x92, 0xe9, 0xcc, 0x3e, 0xe3, 0xee, 0x4d, 0x1e, 0x7e, 0x10, 0xf, 0xdc, 0x47, 0xf0, 0x48, 0x50, 0x31, 0x2d, 0x6c, 0xb, 0x80, 0xd9}),
-				new(big.Int).SetBytes([]byte{0xea, 0xdb, 0xb, 0xa9, 0xae, 0x2c, 0xbe, 0x59, 0x2c, 0xed, 0xd2, 0x9b, 0x71, 0x6a, 0x9d, 0x48, 0x52, 0x97, 0xb6, 0x88, 0xd7, 0x6, 0x34, 0x9a, 0x49, 0xc6, 0x1f, 0x2a, 0xd6, 0xb2, 0x9f, 0x50}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x5d, 0x7d, 0x3f, 0xe2, 0x61, 0xe9, 0x74, 0x6b, 0xa, 0xcc, 0x63, 0xda, 0xb5, 0x4a, 0xa1, 0x3f, 0x5c, 0x44, 0xd, 0x53, 0x56, 0x10, 0xf2, 0x5b, 0x20, 0x5d, 0x73, 0x48, 0xc5, 0xa9, 0x16}),
-				new(big.Int).SetBytes([]byte{0xd, 0xd8, 0x3e, 0xd0, 0xee, 0xb5, 0x5b, 0x7, 0xd2, 0xb4, 0x3c, 0xa6, 0x79, 0xc7, 0xb5, 0x2f, 0xe5, 0xd7, 0x38, 0x14, 0xbd, 0xcf, 0x6f, 0xaa, 0xb1, 0x4b, 0x37, 0xb0, 0x7a, 0xdb, 0x8b, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x28, 0xdf, 0x78, 0x1d, 0x4e, 0xc0, 0x56, 0x80, 0x59, 0xf, 0x46, 0x58, 0x71, 0x3c, 0x8a, 0x91, 0xfe, 0xf2, 0x37, 0x63, 0x87, 0xdd, 0xb6, 0xdd, 0x67, 0x4a, 0x35, 0xc8, 0xb0, 0xe7, 0x44, 0x59}),
-				new(big.Int).SetBytes([]byte{0xf1, 0x49, 0x9e, 0xa6, 0x6a, 0x13, 0xf, 0x17, 0xcb, 0xd, 0xb1, 0x78, 0x90, 0xf2, 0x27, 0x94, 0xa3, 0x79, 0x5b, 0xa5, 0x1, 0xae, 0x6f, 0xa, 0x8d, 0xe0, 0x7c, 0xc0, 0xef, 0x5e, 0x65, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xde, 0xd, 0xd4, 0x10, 0x98, 0x1c, 0x26, 0x12, 0x2b, 0xa4, 0x53, 0xc3, 0x2d, 0x34, 0x43, 0x81, 0x38, 0x76, 0x5b, 0x98, 0xb5, 0xbd, 0x51, 0xdd, 0x45, 0xd9, 0x90, 0x96, 0x35, 0xf7, 0x52, 0x9c}),
-				new(big.Int).SetBytes([]byte{0xd7, 0xa, 0x6e, 0x9d, 0x10, 0xa2, 0x14, 0x5f, 0x9b, 0x21, 0x9, 0x82, 0x2d, 0x7a, 0x35, 0x70, 0x17, 0x89, 0x24, 0xc6, 0x88, 0x9b, 0x77, 0x40, 0x63, 0xc2, 0xc, 0x2, 0xe4, 0xcd, 0x88, 0xfe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x76, 0x9b, 0xc7, 0x58, 0x42, 0xbf, 0xf5, 0x8e, 0xdc, 0x83, 0x66, 0xec, 0xd7, 0x8f, 0x89, 0x50, 0xee, 0x4a, 0xb2, 0xe8, 0x13, 0x59, 0xd9, 0xf, 0x99, 0x21, 0xfa, 0x3d, 0x2c, 0x45, 0x61, 0xbe}),
-				new(big.Int).SetBytes([]byte{0x4b, 0xf8, 0x17, 0x36, 0x2f, 0xe7, 0x83, 0xba, 0xc8, 0xdc, 0xe4, 0xce, 0xf7, 0x3f, 0x5d, 0x47, 0x41, 0xa1, 0x77, 0x76, 0x7b, 0x78, 0x73, 0xad, 0xd5, 0x92, 0xb, 0xff, 0xb0, 0xd9, 0x68, 0x5f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb2, 0x6c, 0x20, 0x8a, 0xda, 0x4c, 0xf4, 0x4e, 0x69, 0xc5, 0xbe, 0xfc, 0x39, 0xc2, 0x92, 0xa2, 0x15, 0x11, 0x0, 0xf, 0xbf, 0x85, 0x15, 0x57, 0x3c, 0x82, 0xf4, 0x8d, 0x38, 0xf7, 0x6d, 0x11}),
-				new(big.Int).SetBytes([]byte{0x1f, 0x1c, 0xf8, 0x82, 0x9, 0x49, 0xd3, 0x3c, 0xf0, 0xc4, 0xe8, 0x7d, 0x90, 0xcd, 0x64, 0x33, 0x5, 0x0, 0x30, 0x65, 0x9d, 0x82, 0x62, 0x4b, 0xdc, 0x1c, 0x0, 0xb1, 0xe3, 0xb7, 0x35, 0x6b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x66, 0xe3, 0xfc, 0xef, 0xb7, 0xb2, 0x4c, 0xf7, 0xaf, 0xf, 0x8d, 0xe2, 0x8e, 0x53, 0xc2, 0xdf, 0x2b, 0xd1, 0xdf, 0xb2, 0xc0, 0x28, 0x9b, 0x10, 0x31, 0x23, 0x9d, 0xa0, 0x94, 0x8e, 0x12, 0x9e}),
-				new(big.Int).SetBytes([]byte{0x20, 0xbf, 0x8b, 0x4f, 0xb7, 0x70, 0xc0, 0xf6, 0x59, 0x8b, 0x5b, 0xf3, 0x4c, 0xdf, 0x80, 0xcb, 0x5f, 0xd7, 0xd9, 0x9e, 0xd1, 0xa5, 0x3b, 0x51, 0x72, 0xbb, 0xc3, 0x60, 0x2e, 0xe7, 0xbb, 0xea}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfc, 0xeb, 0x14, 0xb8, 0xfc, 0x7f, 0xf5, 0x23, 0xa7, 0xe3, 0xbd, 0x29, 0x1, 0x8c, 0x29, 0x4e, 0xac, 0x99, 0x3f, 0xf5, 0x9f, 0x9c, 0x89, 0x33, 0xf9, 0x4e, 0x3b, 0x6, 0x66, 0x9e, 0x22, 0xdb}),
-				new(big.Int).SetBytes([]byte{0x64, 0xaa, 0x6b, 0x3a, 0x40, 0xd6, 0xb8, 0xd1, 0xfc, 0xd8, 0xa5, 0xa7, 0x52, 0x5f, 0x9, 0x24, 0xad, 0x55, 0xe, 0x79, 0x3a, 0x4e, 0xbe, 0x4c, 0xbf, 0x57, 0x2d, 0xb2, 0x5c, 0x22, 0x60, 0xa1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe, 0xb4, 0x5f, 0x96, 0x22, 0xbb, 0xea, 0x10, 0x3c, 0xa7, 0xcc, 0xf9, 0x0, 0x23, 0xfa, 0x35, 0x2e, 0x7e, 0xad, 0xac, 0x44, 0xf0, 0x57, 0xea, 0x34, 0x6d, 0x9d, 0xc5, 0x29, 0xc7, 0x11, 0x43}),
-				new(big.Int).SetBytes([]byte{0x52, 0xc4, 0x2f, 0x58, 0x58, 0x38, 0xbd, 0xf, 0x57, 0x36, 0x1, 0xf5, 0x90, 0x1e, 0x28, 0x57, 0xd6, 0x59, 0x69, 0xea, 0x6a, 0x2b, 0x1b, 0xf4, 0xc8, 0xa8, 0xcd, 0xa2, 0xb, 0x70, 0xb1, 0x36}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4e, 0x90, 0x9a, 0x62, 0x3c, 0xcd, 0x5c, 0xac, 0x55, 0xc1, 0x75, 0x77, 0x4b, 0x50, 0x7f, 0xaa, 0x53, 0x72, 0x1a, 0x71, 0x65, 0x49, 0xc5, 0x50, 0x6f, 0x8, 0x41, 0x79, 0xa3, 0x8a, 0x27, 0x55}),
-				new(big.Int).SetBytes([]byte{0xae, 0x56, 0xda, 0x87, 0x8c, 0xce, 0x35, 0xae, 0xce, 0xf2, 0x46, 0xdd, 0x50, 0x75, 0x59, 0xdb, 0xb6, 0xcd, 0x53, 0x94, 0xd2, 0x29, 0x80, 0xc, 0xaf, 0x7a, 0x49, 0xf6, 0x23, 0xcc, 0xa3, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3c, 0xf9, 0x20, 0x8a, 0xb2, 0x30, 0xb7, 0x3d, 0x68, 0x47, 0x4, 0x11, 0xbf, 0x3d, 0x48, 0x99, 0xe0, 0xf1, 0x96, 0x75, 0xf8, 0x29, 0xe3, 0x2f, 0x0, 0xcd, 0x14, 0x8d, 0x3c, 0x7, 0xd7, 0xc8}),
-				new(big.Int).SetBytes([]byte{0xde, 0x3, 0xc6, 0x4c, 0x15, 0xaf, 0x86, 0x5d, 0x51, 0xec, 0xe6, 0xca, 0x65, 0xc1, 0x67, 0x20, 0x9d, 0x46, 0x84, 0xc8, 0xd5, 0xb8, 0xc5, 0x9c, 0xc, 0x4a, 0x91, 0xda, 0xda, 0x4c, 0xee, 0x8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc3, 0x67, 0x45, 0x5c, 0x22, 0xe0, 0x4c, 0x3, 0xbf, 0xb0, 0xa1, 0x45, 0xeb, 0x8e, 0x40, 0xe2, 0xbd, 0xe6, 0xf, 0x77, 0x88, 0xc5, 0x95, 0xa3, 0xa8, 0x25, 0x89, 0x59, 0x98, 0x3b, 0xa6, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x3a, 0x52, 0xa, 0xd0, 0x69, 0xcb, 0x60, 0x33, 0x8, 0xe, 0xe, 0x6e, 0x84, 0xd9, 0x64, 0xc7, 0x42, 0x62, 0x6a, 0xac, 0xa1, 0x77, 0x8f, 0x14, 0x61, 0x4c, 0xc8, 0xf9, 0x6a, 0x21, 0x81, 0xfd}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe5, 0x3, 0x7d, 0xe0, 0xaf, 0xc1, 0xd8, 0xd4, 0x3d, 0x83, 0x48, 0x41, 0x4b, 0xbf, 0x41, 0x3, 0x4, 0x3e, 0xc8, 0xf5, 0x75, 0xbf, 0xdc, 0x43, 0x29, 0x53, 0xcc, 0x8d, 0x20, 0x37, 0xfa, 0x2d}),
-				new(big.Int).SetBytes([]byte{0x45, 0x71, 0x53, 0x4b, 0xaa, 0x94, 0xd3, 0xb5, 0xf9, 0xf9, 0x8d, 0x9, 0xfb, 0x99, 0xb, 0xdd, 0xbd, 0x5f, 0x5b, 0x3, 0xec, 0x48, 0x1f, 0x10, 0xe0, 0xe5, 0xdc, 0x84, 0x1d, 0x75, 0x5b, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0xe0, 0xd, 0xa4, 0x67, 0xfd, 0x54, 0x94, 0xf4, 0xb, 0x6c, 0xf7, 0xd2, 0xd6, 0x1b, 0x3e, 0xc3, 0xab, 0x21, 0x7c, 0x79, 0x2a, 0x2d, 0xdb, 0x8c, 0x63, 0xc8, 0xc7, 0x9e, 0x3d, 0x34, 0xef}),
-				new(big.Int).SetBytes([]byte{0x9, 0x8f, 0xe5, 0xf5, 0xe5, 0x60, 0x85, 0x55, 0x42, 0x17, 0x26, 0xfe, 0x99, 0xbf, 0x43, 0xd2, 0x5b, 0x60, 0xdc, 0xfe, 0x79, 0x9, 0x0, 0xac, 0xb8, 0x55, 0xc5, 0xce, 0x2f, 0x7a, 0xdb, 0x4c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9d, 0x89, 0x6a, 0x3a, 0xff, 0x96, 0x33, 0xce, 0xe5, 0x85, 0x43, 0xba, 0xcf, 0x52, 0x91, 0xae, 0xb5, 0xe1, 0x55, 0x93, 0x88, 0xed, 0x95, 0xf6, 0x38, 0x8a, 0x8a, 0x6e, 0x17, 0x7e, 0x77, 0x75}),
-				new(big.Int).SetBytes([]byte{0xdd, 0x91, 0xa9, 0xe4, 0x3f, 0x49, 0xbf, 0xb, 0x94, 0xe9, 0x64, 0xed, 0x72, 0x50, 0x92, 0x7d, 0xe8, 0x99, 0xcd, 0x7e, 0xe2, 0x99, 0x25, 0x3b, 0xef, 0xcf, 0x6d, 0x3a, 0xba, 0x5, 0x66, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0x94, 0x15, 0xf5, 0xef, 0x3a, 0x2b, 0x40, 0x35, 0x19, 0xf4, 0xbb, 0x1c, 0x9b, 0xfb, 0xc4, 0x6d, 0x4a, 0xfd, 0x2e, 0x44, 0x77, 0x57, 0x2a, 0xe6, 0x73, 0x71, 0x60, 0xd7, 0xb9, 0x12, 0x52}),
-				new(big.Int).SetBytes([]byte{0x82, 0xd0, 0xe6, 0x4c, 0xae, 0x81, 0xf8, 0x4b, 0xb9, 0xe2, 0xf1, 0xf, 0x24, 0xf6, 0xf6, 0xb6, 0x89, 0x9a, 0x16, 0xad, 0x59, 0xf, 0x4d, 0xdd, 0x73, 0xa3, 0x77, 0xac, 0x4b, 0xed, 0xc2, 0x64}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x83, 0x27, 0xb8, 0xee, 0x71, 0x16, 0x37, 0x92, 0xd0, 0xeb, 0xa, 0x57, 0x32, 0x82, 0xf4, 0xcd, 0x56, 0x88, 0xb8, 0x6e, 0xe9, 0x3, 0x47, 0x6c, 0x5f, 0xde, 0x4, 0xde, 0x3c, 0x2a, 0x32, 0x93}),
-				new(big.Int).SetBytes([]byte{0x4, 0x99, 0x7e, 0x26, 0x6e, 0xe0, 0xa9, 0x8e, 0x18, 0xbb, 0x3e, 0xa6, 0x62, 0x79, 0x70, 0x84, 0x96, 0xaf, 0xda, 0xb4, 0xee, 0x32, 0x6a, 0x41, 0x6b, 0xc8, 0x54, 0xe1, 0x8e, 0xd, 0xf9, 0xbd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcf, 0x8c, 0x2d, 0x2d, 0xb8, 0x18, 0xdf, 0xf9, 0x7f, 0x8e, 0x64, 0x31, 0x27, 0x23, 0x84, 0xdf, 0x2a, 0x74, 0x58, 0x90, 0x58, 0x57, 0x84, 0x15, 0x95, 0x7f, 0x1e, 0x8c, 0x90, 0x4e, 0xd3}),
-				new(big.Int).SetBytes([]byte{0xaa, 0xad, 0x0, 0xe, 0xa7, 0x81, 0xd4, 0x41, 0x19, 0xdd, 0x5c, 0x1b, 0xd5, 0x1b, 0xd8, 0x11, 0x22, 0x99, 0x1d, 0x79, 0xd3, 0x2f, 0x68, 0x27, 0xb0, 0x9, 0x96, 0x3b, 0x79, 0x6f, 0x77, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0xe4, 0x2a, 0xaa, 0x2a, 0x6d, 0xb1, 0x68, 0x97, 0xd6, 0x66, 0x1d, 0x8f, 0x9a, 0x8e, 0xd6, 0x9, 0x2d, 0x23, 0x23, 0x4b, 0x8d, 0xfb, 0x1a, 0x24, 0x21, 0xb2, 0x6c, 0x45, 0x62, 0xc0, 0x42}),
-				new(big.Int).SetBytes([]byte{0x99, 0xd9, 0x3a, 0x7c, 0x5, 0xff, 0x5, 0x1e, 0xab, 0x5c, 0x1d, 0xdc, 0x60, 0x38, 0x9d, 0x4a, 0x94, 0xe0, 0xdb, 0x95, 0x10, 0x7c, 0xd8, 0xdb, 0xf9, 0x5, 0xcc, 0xdf, 0x8f, 0x79, 0x26, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb5, 0x6f, 0x4e, 0x9f, 0x9e, 0x4f, 0xd1, 0xfc, 0x7d, 0x8e, 0xdd, 0xe0, 0x98, 0xf9, 0x35, 0xf8, 0x4c, 0x75, 0xd, 0x70, 0x5f, 0xc, 0x13, 0x2b, 0xd8, 0xc4, 0x65, 0xb6, 0x6a, 0x54, 0xf, 0x17}),
-				new(big.Int).SetBytes([]byte{0x32, 0xe8, 0xe5, 0x34, 0x29, 0xcc, 0xa8, 0x56, 0xd3, 0xdc, 0x11, 0xad, 0xf0, 0x58, 0x2d, 0x1d, 0x21, 0xd4, 0x29, 0x63, 0xcb, 0xcc, 0xa8, 0x54, 0x46, 0xa2, 0xfc, 0xae, 0x2, 0x0, 0x10, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x92, 0xc2, 0x3a, 0xe4, 0x26, 0xa1, 0xc8, 0xeb, 0x59, 0xae, 0xb6, 0x8f, 0xf6, 0xc2, 0x1b, 0xf, 0x4e, 0x8e, 0xd7, 0x22, 0xdd, 0x4a, 0xe7, 0x66, 0xb3, 0x48, 0x81, 0x28, 0x5e, 0x85, 0xaf, 0x61}),
-				new(big.Int).SetBytes([]byte{0x41, 0x4c, 0xf8, 0x8f, 0x1, 0x55, 0x1b, 0xb4, 0x9, 0xf2, 0x17, 0xd5, 0xc7, 0x38, 0xd5, 0x79, 0x2d, 0x23, 0xad, 0x82, 0xb6, 0x28, 0x6f, 0xed, 0x4e, 0x72, 0x36, 0x69, 0xc3, 0x6a, 0x2b, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0xba, 0x51, 0x51, 0xca, 0xf6, 0x48, 0x3b, 0xd1, 0xe7, 0xe4, 0xc6, 0xf0, 0xfc, 0xdb, 0x30, 0x48, 0xc9, 0xb7, 0x89, 0xfd, 0x6c, 0xaa, 0x3e, 0x28, 0xa, 0xa4, 0x82, 0x59, 0xe5, 0xd1, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x8c, 0x1e, 0x72, 0x23, 0xbf, 0xf2, 0x51, 0xd0, 0x90, 0x64, 0xec, 0x80, 0x47, 0xd7, 0x2c, 0xc9, 0xe1, 0x84, 0x63, 0x59, 0xd2, 0xde, 0x6e, 0xe6, 0xa8, 0x5b, 0x67, 0x8d, 0x42, 0xf3, 0x0, 0x87}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0xe5, 0x60, 0x8c, 0x76, 0xaf, 0xdf, 0x10, 0x88, 0xd0, 0xf4, 0x17, 0x6a, 0xf8, 0xdf, 0x42, 0x2d, 0xab, 0xed, 0xc7, 0xf9, 0x18, 0xea, 0x36, 0xce, 0x2a, 0xf1, 0xb2, 0xc7, 0xb5, 0xeb, 0xa8}),
-				new(big.Int).SetBytes([]byte{0x38, 0x7, 0x59, 0x91, 0x34, 0xfe, 0x58, 0xaf, 0xb1, 0x14, 0x2, 0xf9, 0x92, 0x87, 0xc1, 0x4e, 0xe3, 0x80, 0xff, 0x42, 0xbf, 0x90, 0x54, 0x79, 0x3f, 0x51, 0x5d, 0x36, 0x8d, 0xb, 0x9b, 0x5c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x80, 0x9b, 0x7f, 0x1f, 0xe7, 0x8c, 0x1b, 0xd4, 0x5a, 0xfb, 0x88, 0xf7, 0x9b, 0xdd, 0x89, 0x43, 0x2e, 0x6b, 0x15, 0xb7, 0xa4, 0x5a, 0xe6, 0x1b, 0x2b, 0x59, 0x88, 0x8c, 0xf8, 0xcf, 0xfd}),
-				new(big.Int).SetBytes([]byte{0xf8, 0x17, 0xe0, 0x22, 0xbf, 0x41, 0xa8, 0x63, 0x2, 0x88, 0xc8, 0x36, 0xde, 0x52, 0xfd, 0xc9, 0x12, 0xa3, 0x40, 0xfa, 0xd9, 0x2b, 0x9f, 0x93, 0xd9, 0x1d, 0x45, 0xa1, 0x8d, 0xb5, 0xf3, 0x2f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x42, 0xe5, 0x44, 0xeb, 0x92, 0xe6, 0x67, 0xe6, 0x20, 0xa1, 0x13, 0xb4, 0xc6, 0x2a, 0x6a, 0xfc, 0xa2, 0x8f, 0xf3, 0xab, 0x28, 0xf5, 0x3d, 0x52, 0x81, 0xe3, 0xba, 0x4b, 0x3c, 0x63, 0xca, 0xf4}),
-				new(big.Int).SetBytes([]byte{0x9f, 0xf8, 0x54, 0xe0, 0xf9, 0x1c, 0xc6, 0x71, 0xf7, 0x1f, 0x22, 0xc, 0x17, 0x41, 0x5e, 0x78, 0x73, 0xa5, 0xe, 0x7b, 0x6b, 0xef, 0xc5, 0xf1, 0x18, 0x7a, 0xec, 0x9, 0x96, 0x9c, 0x29, 0xc8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0xdd, 0xe6, 0xdd, 0xd3, 0x52, 0xd4, 0xa7, 0x41, 0xdf, 0x78, 0x49, 0x47, 0xfc, 0x19, 0x2a, 0xad, 0x2b, 0xa5, 0xa0, 0xe, 0xf0, 0xc6, 0x3d, 0x6c, 0x61, 0x90, 0x5e, 0xf7, 0xed, 0x9e, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x41, 0xd1, 0x7b, 0xdc, 0x34, 0x20, 0x67, 0xbc, 0xe2, 0x36, 0xa4, 0xf9, 0x4d, 0x58, 0xc1, 0xf4, 0x59, 0xec, 0x1b, 0x37, 0x9c, 0x98, 0xf4, 0xb2, 0x1a, 0xbb, 0x84, 0x68, 0x3, 0x1f, 0xb8, 0x71}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7a, 0xed, 0x83, 0xb6, 0x65, 0x53, 0x2c, 0xe3, 0x83, 0x99, 0x6e, 0xd2, 0xa4, 0x70, 0xe, 0xfe, 0xf0, 0xa1, 0x9, 0xf1, 0xb9, 0x40, 0x32, 0x83, 0x80, 0xa2, 0xbd, 0x98, 0x5e, 0xf, 0x9, 0xa1}),
-				new(big.Int).SetBytes([]byte{0xf5, 0xb8, 0x54, 0x5f, 0x9a, 0x31, 0xef, 0x7c, 0x7d, 0xda, 0xfd, 0xa, 0xb1, 0x3, 0x2d, 0x8e, 0xd8, 0x8b, 0x2f, 0x30, 0xf, 0x3f, 0x4a, 0x54, 0x4, 0x5d, 0x8c, 0xde, 0x85, 0x85, 0x7d, 0x73}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe0, 0x63, 0x72, 0xb0, 0xf4, 0xa2, 0x7, 0xad, 0xf5, 0xea, 0x90, 0x5e, 0x8f, 0x17, 0x71, 0xb4, 0xe7, 0xe8, 0xdb, 0xd1, 0xc6, 0xa6, 0xc5, 0xb7, 0x25, 0x86, 0x6a, 0xa, 0xe4, 0xfc, 0xe7, 0x25}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x90, 0x89, 0x74, 0xbc, 0xe1, 0x8c, 0xfe, 0x12, 0xa2, 0x7b, 0xb2, 0xad, 0x5a, 0x48, 0x8c, 0xd7, 0x48, 0x4a, 0x77, 0x87, 0x10, 0x48, 0x70, 0xb2, 0x70, 0x34, 0xf9, 0x4e, 0xee, 0x31, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe, 0xac, 0x13, 0x4c, 0xa2, 0x4, 0x6b, 0x8f, 0x9c, 0x8d, 0xbd, 0x30, 0x4f, 0xad, 0x3f, 0x3c, 0x4, 0x5e, 0xbf, 0xdb, 0x4e, 0xc6, 0xed, 0x3c, 0xfe, 0x9, 0xae, 0xe4, 0x3e, 0xd2, 0xff, 0x3e}),
-				new(big.Int).SetBytes([]byte{0x49, 0x63, 0xd, 0xbe, 0x79, 0x35, 0x9b, 0x42, 0x45, 0xbf, 0x10, 0x3b, 0xf2, 0xb1, 0x17, 0x99, 0xac, 0x19, 0xf6, 0x96, 0xb7, 0xf2, 0x13, 0x76, 0xe1, 0x72, 0x6, 0x20, 0x7d, 0x21, 0x9, 0x88}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x63, 0xc0, 0x5b, 0xa6, 0x23, 0x4e, 0x0, 0xa3, 0x46, 0x89, 0x91, 0x85, 0xb0, 0x8f, 0xa7, 0xb2, 0x4d, 0x77, 0x3a, 0x95, 0xad, 0xc1, 0x8b, 0xdb, 0xaa, 0x81, 0x88, 0xda, 0x32, 0x8d, 0x6a}),
-				new(big.Int).SetBytes([]byte{0x33, 0x31, 0xe9, 0x8d, 0x5f, 0x72, 0x1c, 0x38, 0xd3, 0x9a, 0xfd, 0xcf, 0x27, 0x57, 0x13, 0x17, 0xdd, 0x85, 0x51, 0xea, 0x51, 0x2b, 0xf9, 0xcc, 0x23, 0xb0, 0xbb, 0x6a, 0xbe, 0xc9, 0xb8, 0xc0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd6, 0x78, 0x85, 0x90, 0x73, 0x1f, 0xea, 0x19, 0x83, 0x92, 0x11, 0x9d, 0x7a, 0xdb, 0xb4, 0x1f, 0xf5, 0x94, 0x8a, 0x78, 0x4, 0xc8, 0x5b, 0x17, 0x47, 0x67, 0x6, 0xe4, 0xdf, 0xbf, 0xa4, 0xdc}),
-				new(big.Int).SetBytes([]byte{0x28, 0xea, 0xa8, 0xc8, 0x9d, 0x50, 0x63, 0xc4, 0x94, 0xe, 0xf5, 0xc6, 0xd2, 0x1c, 0x13, 0xaa, 0x62, 0x6, 0xf1, 0xc4, 0xdd, 0xc9, 0xa0, 0x7c, 0xca, 0x7b, 0xcd, 0x6b, 0xbd, 0x3b, 0x54, 0x6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0xfc, 0x26, 0x82, 0xdf, 0xb8, 0x6a, 0x45, 0x9c, 0xeb, 0x30, 0xde, 0xea, 0xf, 0xe4, 0xe9, 0x77, 0xce, 0xdf, 0x2e, 0xe0, 0xb2, 0x51, 0x14, 0xbc, 0x91, 0xc8, 0x48, 0x39, 0x96, 0xde, 0x2f}),
-				new(big.Int).SetBytes([]byte{0xc4, 0xf0, 0xdf, 0x99, 0xa4, 0x5f, 0xa, 0x18, 0xf6, 0x8b, 0x47, 0x54, 0xd4, 0xf7, 0x81, 0xda, 0x4e, 0x59, 0xb4, 0x98, 0xdf, 0x7a, 0xbf, 0x16, 0x8c, 0x49, 0x22, 0x41, 0xd4, 0x52, 0x6f, 0x8c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x29, 0x2a, 0xdc, 0x1c, 0x33, 0xaf, 0x83, 0x79, 0xc, 0xfa, 0xff, 0x33, 0x94, 0x79, 0x95, 0x97, 0xa9, 0xfb, 0x92, 0x83, 0xb, 0xc2, 0x5, 0xdd, 0x6d, 0xc3, 0x5a, 0x65, 0x62, 0x14, 0x3f, 0xb0}),
-				new(big.Int).SetBytes([]byte{0xa0, 0x72, 0x66, 0x1b, 0xce, 0xe0, 0xb6, 0x47, 0xf3, 0xf1, 0x96, 0x8a, 0xc, 0xc4, 0xec, 0xf6, 0xd8, 0x3e, 0x66, 0x2d, 0x73, 0x5e, 0x9d, 0x4d, 0x8a, 0x9d, 0xb6, 0x3c, 0xe3, 0x6a, 0xd0, 0x1c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x7a, 0x4b, 0x43, 0xfe, 0xb2, 0xc0, 0x23, 0x7d, 0xca, 0x1f, 0xec, 0x9a, 0x3f, 0xab, 0xd1, 0x9c, 0xe6, 0x78, 0xfb, 0x98, 0x5f, 0x83, 0xe8, 0x5c, 0x6c, 0x48, 0xd1, 0x3, 0xe6, 0x97, 0xea}),
-				new(big.Int).SetBytes([]byte{0x39, 0x35, 0x5c, 0x2d, 0x55, 0xab, 0x59, 0x54, 0xca, 0xe1, 0x78, 0xf7, 0x19, 0x60, 0x1f, 0xae, 0x96, 0xe1, 0xa1, 0xb5, 0x7f, 0x9f, 0x2, 0xab, 0xe, 0xe3, 0xb8, 0x7d, 0xde, 0xdc, 0x6c, 0x87}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x69, 0x30, 0xfc, 0xcb, 0xd9, 0xa0, 0x40, 0x97, 0x4a, 0xbf, 0x21, 0xf, 0x12, 0xb7, 0x1d, 0x4b, 0xc7, 0xb1, 0xa6, 0x20, 0x55, 0x99, 0xb0, 0x1a, 0x72, 0x75, 0xfb, 0x40, 0xe4, 0x8f, 0xf9, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x7f, 0x2, 0xae, 0x94, 0xb9, 0x47, 0x1, 0xea, 0xda, 0x30, 0xfc, 0xdb, 0x87, 0x5f, 0x6d, 0x78, 0x9, 0xf, 0x9b, 0x13, 0xe4, 0xac, 0xc5, 0x1a, 0xcf, 0xdd, 0xab, 0x5f, 0x8e, 0xe9, 0x6a, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8f, 0x61, 0x8b, 0x7c, 0xa2, 0x67, 0x90, 0xb4, 0x68, 0xc9, 0x34, 0x9d, 0x7f, 0xc1, 0x1f, 0xde, 0x9a, 0xe, 0xde, 0xe6, 0xf, 0x93, 0xa3, 0x11, 0x82, 0xd2, 0xc3, 0x2, 0x31, 0x63, 0xda, 0x1b}),
-				new(big.Int).SetBytes([]byte{0x78, 0x23, 0x3f, 0x25, 0xf0, 0x8b, 0xeb, 0x6e, 0xb5, 0xfb, 0xda, 0x74, 0xd7, 0xac, 0x5a, 0x42, 0x64, 0x50, 0xc6, 0x6a, 0x32, 0x49, 0xb6, 0xe7, 0xc7, 0xf3, 0x12, 0x11, 0x3, 0xbd, 0xd7, 0x6e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0x95, 0x2a, 0x9c, 0xa3, 0x32, 0x1e, 0x69, 0xae, 0xab, 0xe, 0x61, 0xb2, 0xd3, 0xce, 0x72, 0xba, 0x25, 0xf0, 0xfc, 0xaa, 0xde, 0xd6, 0xb0, 0x84, 0xfb, 0x9, 0xb5, 0x4d, 0xc, 0xec, 0x10}),
-				new(big.Int).SetBytes([]byte{0xb1, 0x78, 0x18, 0x4a, 0x66, 0xdd, 0x27, 0x9e, 0x45, 0x42, 0xae, 0xc7, 0x87, 0xb5, 0xcb, 0x4e, 0xba, 0x4a, 0xa2, 0xdf, 0xc8, 0x4f, 0x54, 0xda, 0x8e, 0xe3, 0x6b, 0x13, 0xbb, 0xd0, 0x69, 0x32}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0x98, 0xc0, 0xf1, 0x5b, 0x7a, 0x1e, 0x6e, 0xb8, 0x6d, 0x5b, 0x8d, 0xac, 0x83, 0xf1, 0xb8, 0xcf, 0xb2, 0x6b, 0x92, 0xe8, 0xbb, 0xc5, 0xde, 0xee, 0x85, 0x90, 0xfe, 0xe, 0xc8, 0xae, 0x90}),
-				new(big.Int).SetBytes([]byte{0xba, 0x40, 0xe2, 0xaa, 0x75, 0xa4, 0x2e, 0xd3, 0xdd, 0x2e, 0xc9, 0x49, 0x4f, 0x9c, 0x73, 0xbe, 0xe2, 0xe4, 0x96, 0x5b, 0xd0, 0x31, 0x88, 0xe2, 0x7a, 0x9a, 0xc2, 0xea, 0xe5, 0x28, 0x44, 0xd3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa4, 0x35, 0x7, 0xf9, 0xad, 0x54, 0x67, 0xd3, 0xfe, 0xdc, 0x80, 0x4a, 0x59, 0xc6, 0x75, 0xb3, 0xd6, 0xff, 0x1d, 0x39, 0xfb, 0x9, 0x4a, 0x45, 0x89, 0xec, 0x1e, 0x6c, 0x30, 0xb9, 0xe, 0xb}),
-				new(big.Int).SetBytes([]byte{0x7c, 0x8f, 0x2d, 0xc9, 0x4c, 0x3f, 0xd7, 0x1a, 0x14, 0x2d, 0x7, 0xcc, 0xb4, 0xb, 0xe8, 0xc0, 0xdc, 0x2a, 0x20, 0x36, 0x53, 0x59, 0x9b, 0x5a, 0x3f, 0x0, 0xb, 0x99, 0x3a, 0x1f, 0xdc, 0x20}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0xfa, 0x6e, 0xe3, 0xfb, 0x5a, 0x2d, 0x67, 0x47, 0xcf, 0x1b, 0x90, 0xb8, 0x92, 0x84, 0x75, 0x7a, 0xa6, 0x92, 0x50, 0x51, 0x87, 0xdc, 0x81, 0x5, 0xa1, 0x97, 0x8f, 0x11, 0xd6, 0x6b, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x4d, 0x9c, 0xf3, 0x1e, 0x22, 0x49, 0x98, 0xdb, 0x93, 0xc0, 0xc1, 0xc9, 0xa3, 0xda, 0x18, 0xc4, 0xd8, 0x46, 0xf9, 0xdd, 0x13, 0xd7, 0x8d, 0xe9, 0xda, 0xa8, 0xf8, 0x6, 0x78, 0xe5, 0x17, 0x8b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x72, 0xb0, 0x4e, 0xcd, 0x4b, 0x7b, 0xfb, 0xba, 0xef, 0xe1, 0x1c, 0xa1, 0xa7, 0x49, 0xe6, 0x71, 0x1d, 0xdb, 0x64, 0xdb, 0x7a, 0x2b, 0x49, 0x3a, 0xb8, 0x36, 0xb5, 0x86, 0x59, 0xc0, 0xd7, 0xad}),
-				new(big.Int).SetBytes([]byte{0x68, 0x65, 0xb3, 0xea, 0xe, 0x64, 0xa3, 0x58, 0xce, 0xdd, 0x45, 0x2d, 0x6d, 0x93, 0x4d, 0x1, 0x96, 0x26, 0x5d, 0x68, 0x1d, 0xc6, 0xf4, 0xfb, 0x2e, 0xfe, 0x6f, 0xf4, 0x67, 0x23, 0xcd, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x58, 0xce, 0x38, 0xde, 0x22, 0x9e, 0x43, 0xad, 0xde, 0xa9, 0xa4, 0x61, 0xcf, 0x22, 0xe, 0x69, 0xce, 0x2b, 0x78, 0x41, 0x1c, 0x7d, 0x30, 0xb8, 0x7, 0x57, 0x84, 0x92, 0x65, 0xbc, 0xf0}),
-				new(big.Int).SetBytes([]byte{0xff, 0xe0, 0xa5, 0xe5, 0x37, 0x63, 0x21, 0x5b, 0xd5, 0x38, 0x2a, 0xb9, 0xa4, 0x85, 0xe4, 0xfb, 0x87, 0x26, 0x1b, 0xfe, 0x28, 0xf1, 0xcd, 0x1d, 0xf3, 0x3e, 0x7a, 0x9c, 0xeb, 0x99, 0x62, 0x92}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x21, 0x3c, 0x7a, 0x71, 0x5c, 0xd5, 0xd4, 0x53, 0x58, 0xd0, 0xbb, 0xf9, 0xdc, 0xc, 0xe0, 0x22, 0x4, 0xb1, 0xb, 0xdd, 0xe2, 0xa3, 0xf5, 0x85, 0x40, 0xad, 0x69, 0x8, 0xd0, 0x55, 0x97, 0x54}),
-				new(big.Int).SetBytes([]byte{0x4b, 0x6d, 0xad, 0xb, 0x5a, 0xe4, 0x62, 0x50, 0x70, 0x13, 0xad, 0x6, 0x24, 0x5b, 0xa1, 0x90, 0xbb, 0x48, 0x50, 0xf5, 0xf3, 0x6a, 0x7e, 0xed, 0xdf, 0xf2, 0xc2, 0x75, 0x34, 0xb4, 0x58, 0xf2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0x5e, 0x54, 0x81, 0x32, 0xb4, 0x9a, 0x7f, 0x66, 0xae, 0x9f, 0xed, 0x83, 0x23, 0x48, 0xe, 0xd, 0x1a, 0xb9, 0x74, 0x62, 0x2e, 0x7c, 0xf0, 0x89, 0x93, 0x89, 0x5e, 0xe, 0xc8, 0x7f, 0xac}),
-				new(big.Int).SetBytes([]byte{0x4f, 0xfc, 0xf6, 0xf, 0x83, 0x7f, 0x46, 0x8f, 0x2b, 0xb9, 0x59, 0xfa, 0x1d, 0x4c, 0x2a, 0xd3, 0xa3, 0xde, 0xac, 0xeb, 0x26, 0xfe, 0x32, 0x4c, 0x55, 0x5d, 0x7b, 0x3d, 0x5f, 0xc2, 0xd4, 0xef}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0xce, 0xf6, 0xe1, 0x75, 0x3d, 0xa0, 0x30, 0x95, 0x46, 0xe0, 0x96, 0xb9, 0x53, 0xd1, 0x72, 0xae, 0x3f, 0xa3, 0xed, 0x4c, 0x19, 0xa9, 0x3e, 0x53, 0x2d, 0x80, 0x11, 0x9e, 0x5, 0xdc, 0xcc}),
-				new(big.Int).SetBytes([]byte{0x30, 0x2b, 0x8a, 0x60, 0xa6, 0xcc, 0x9b, 0xbf, 0x6a, 0x4d, 0x4a, 0x74, 0xe4, 0xd2, 0xbd, 0x99, 0xdf, 0x75, 0x7f, 0xc3, 0x6a, 0x6b, 0x68, 0x13, 0x30, 0x14, 0xa0, 0xcf, 0xcc, 0x6d, 0x57, 0x50}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x46, 0x27, 0x6d, 0x6, 0x2, 0xc5, 0x66, 0x8d, 0xde, 0xf6, 0xe9, 0x42, 0x10, 0xbb, 0xc7, 0xce, 0x1f, 0x90, 0x1c, 0x19, 0xfe, 0xd5, 0xc9, 0x70, 0xe2, 0xf, 0xcb, 0xa1, 0xd4, 0x53, 0x1d, 0xbc}),
-				new(big.Int).SetBytes([]byte{0xe, 0xf, 0x7f, 0x24, 0xd4, 0x4c, 0x75, 0xb8, 0x4a, 0x29, 0x22, 0x87, 0x57, 0xd, 0xed, 0x99, 0x49, 0x8b, 0xad, 0xfb, 0xff, 0xe1, 0xbc, 0x99, 0xaf, 0x87, 0x30, 0x9, 0x96, 0x86, 0xb8, 0xe2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0xfb, 0x33, 0xe7, 0x79, 0xb4, 0x73, 0x85, 0xe0, 0x2a, 0xb, 0xde, 0x2e, 0xbb, 0x5f, 0x49, 0x70, 0x1f, 0x7b, 0x6b, 0x5f, 0xdb, 0x97, 0xb4, 0x85, 0xbd, 0xfe, 0xe1, 0x37, 0x3b, 0xb3, 0x1a}),
-				new(big.Int).SetBytes([]byte{0xf3, 0x6a, 0xd9, 0x52, 0x54, 0x8e, 0xfe, 0x28, 0x1e, 0xc, 0x16, 0x1a, 0xab, 0xbb, 0x57, 0x2b, 0xc6, 0x5a, 0x7c, 0x76, 0xc4, 0x76, 0x40, 0xd4, 0xe3, 0x4c, 0xbe, 0x69, 0x7d, 0x21, 0x5c, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0x57, 0x16, 0x30, 0x93, 0x5c, 0x1f, 0x2, 0xd6, 0xfd, 0x87, 0x44, 0x2c, 0x8, 0x20, 0x60, 0xcd, 0x5e, 0x79, 0x2a, 0x1c, 0x6f, 0x92, 0xbd, 0xc, 0x4e, 0xed, 0x1, 0x68, 0x6d, 0xf5, 0xd}),
-				new(big.Int).SetBytes([]byte{0x85, 0xe1, 0x38, 0x73, 0xb5, 0x99, 0xf3, 0x2f, 0x51, 0x6f, 0x3f, 0xf2, 0xe5, 0x70, 0xa0, 0xdd, 0xe0, 0xaa, 0xf8, 0x1e, 0xbb, 0x78, 0x1a, 0x11, 0xd2, 0x15, 0x8b, 0x28, 0xe8, 0x59, 0x67, 0x9b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x17, 0x7c, 0xd1, 0x9, 0xec, 0x3e, 0x11, 0x61, 0xf1, 0x6f, 0x7b, 0x4d, 0xf, 0x7a, 0x36, 0x71, 0x7c, 0x36, 0xc1, 0x85, 0x5b, 0xb7, 0xc0, 0xa5, 0xb5, 0x15, 0xeb, 0xdd, 0x9a, 0x4a, 0xb4}),
-				new(big.Int).SetBytes([]byte{0x3e, 0xc9, 0x66, 0xe9, 0xa5, 0xe2, 0xfa, 0x65, 0xfd, 0xa8, 0xf6, 0x72, 0x93, 0x62, 0x6b, 0x48, 0x6e, 0x37, 0xe2, 0x55, 0xf1, 0x74, 0x1f, 0x55, 0x8a, 0xad, 0xdf, 0xe4, 0x63, 0x5a, 0xb6, 0xf7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xef, 0xea, 0x68, 0xec, 0xa7, 0xa6, 0xc2, 0x4f, 0x4e, 0x65, 0xeb, 0x21, 0x1c, 0x31, 0x91, 0x63, 0x68, 0x50, 0xe0, 0xac, 0xdc, 0x78, 0xd8, 0x99, 0x61, 0x14, 0xef, 0x13, 0x52, 0x2f, 0x0, 0x1d}),
-				new(big.Int).SetBytes([]byte{0xaa, 0xb8, 0x47, 0x86, 0x9d, 0x58, 0x3c, 0x14, 0xda, 0x15, 0x3, 0x7, 0xa3, 0x71, 0x9a, 0x17, 0xe4, 0x13, 0x95, 0x9f, 0xb3, 0x84, 0x87, 0x71, 0xc1, 0x28, 0x41, 0x9f, 0x73, 0xbc, 0x44, 0x15}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5e, 0x87, 0x3, 0x52, 0x6, 0xa2, 0x7a, 0x6, 0xc2, 0xa0, 0x38, 0x29, 0xb9, 0xb9, 0xcd, 0x75, 0xc2, 0x2a, 0x41, 0x58, 0x47, 0xa4, 0x9c, 0xf5, 0x6f, 0x46, 0xfb, 0x29, 0xb0, 0x1c, 0x23, 0xa4}),
-				new(big.Int).SetBytes([]byte{0x5b, 0x96, 0x64, 0x4e, 0xfb, 0x9c, 0x92, 0x21, 0xe7, 0x17, 0x55, 0xd1, 0xae, 0x2c, 0x6e, 0x2d, 0xa2, 0x17, 0x1d, 0x87, 0x4a, 0x4c, 0xcf, 0xda, 0x12, 0x17, 0x99, 0x6b, 0x10, 0xd9, 0x86, 0xf9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcf, 0xb, 0xdf, 0x47, 0xa3, 0xf1, 0x5b, 0x24, 0x5c, 0x71, 0x36, 0xc2, 0x3a, 0x3e, 0x38, 0xf2, 0x66, 0x1f, 0xa8, 0xa9, 0x4d, 0xf2, 0x9f, 0xbd, 0x8b, 0x41, 0xef, 0x22, 0xc2, 0x91, 0xdc, 0x6e}),
-				new(big.Int).SetBytes([]byte{0x38, 0xc2, 0xe0, 0x1c, 0x6e, 0x61, 0xf5, 0x11, 0xa4, 0xc5, 0x23, 0xb4, 0xe5, 0x79, 0x18, 0xf6, 0xb4, 0xfc, 0x6c, 0xfd, 0x2b, 0xb5, 0xf2, 0xa9, 0x9c, 0x73, 0xc1, 0x44, 0xdb, 0x7a, 0x81, 0x96}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe5, 0x45, 0xc3, 0x1, 0x93, 0xd, 0x68, 0xa, 0x44, 0x72, 0x24, 0x4f, 0x60, 0xac, 0xeb, 0x74, 0x34, 0xa7, 0xfe, 0xe4, 0x65, 0x6c, 0x86, 0xfb, 0x1f, 0x3f, 0x35, 0xd2, 0x0, 0x7b, 0xc, 0x66}),
-				new(big.Int).SetBytes([]byte{0xe0, 0x6a, 0x34, 0xe, 0x8c, 0x62, 0x95, 0x5e, 0x7e, 0x5c, 0x6f, 0xda, 0x39, 0x59, 0xbc, 0xbf, 0x97, 0xe5, 0x82, 0x8e, 0x1b, 0x5a, 0x23, 0x9d, 0xd8, 0x7f, 0x57, 0xd2, 0x71, 0xf2, 0xd4, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5e, 0x6a, 0x86, 0x3d, 0x4f, 0x20, 0x5f, 0xcb, 0xbd, 0x8e, 0x12, 0xe5, 0xa4, 0x5d, 0xc0, 0xbe, 0xb3, 0xa0, 0x37, 0x79, 0xba, 0x7d, 0x92, 0xcd, 0xe2, 0xaa, 0x2b, 0x67, 0xf8, 0x8f, 0x7e, 0x9}),
-				new(big.Int).SetBytes([]byte{0xc2, 0x57, 0x1a, 0x7, 0x8a, 0x38, 0x8e, 0xdf, 0xc4, 0x44, 0xa8, 0xd1, 0xf6, 0x94, 0x2, 0x34, 0xa6, 0x8e, 0x50, 0x37, 0x23, 0xb4, 0xcf, 0xe3, 0xbb, 0xd6, 0x28, 0x67, 0x48, 0xdf, 0xca, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb2, 0xa4, 0x42, 0x15, 0x7a, 0x54, 0xf5, 0x80, 0xa1, 0x33, 0xee, 0x22, 0x5f, 0x1, 0xb3, 0x98, 0x82, 0xa0, 0xf0, 0xb0, 0x69, 0xc3, 0xc7, 0x44, 0x4a, 0x58, 0x19, 0xb, 0x83, 0x64, 0x8b, 0xba}),
-				new(big.Int).SetBytes([]byte{0xfe, 0x4f, 0x5f, 0xc2, 0xb6, 0x93, 0x66, 0x61, 0x9e, 0x9f, 0xbc, 0x97, 0x8f, 0xbd, 0x7e, 0x31, 0xc0, 0xd8, 0x55, 0x15, 0x1c, 0x9c, 0x17, 0xbb, 0x24, 0x6f, 0xab, 0x4a, 0x0, 0xfb, 0x64, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7, 0xa1, 0xff, 0xea, 0x8f, 0xe5, 0x11, 0x4b, 0x8a, 0x63, 0x83, 0x6, 0x3b, 0x78, 0x11, 0xc3, 0x25, 0xa4, 0xc, 0x19, 0x42, 0x45, 0x15, 0x57, 0x67, 0x9e, 0x75, 0xe2, 0xb3, 0x4, 0xab, 0x91}),
-				new(big.Int).SetBytes([]byte{0xe1, 0xc, 0x4, 0x21, 0xb0, 0xfd, 0x7c, 0x54, 0x69, 0x10, 0x43, 0x7c, 0x43, 0x44, 0xa5, 0x95, 0x28, 0x7e, 0x5d, 0xea, 0x19, 0x0, 0x9d, 0x9d, 0x35, 0xaf, 0x46, 0x3b, 0x67, 0x97, 0xe5, 0x54}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1b, 0x90, 0x8e, 0xf, 0x3d, 0x45, 0x38, 0x65, 0x1b, 0xa9, 0x70, 0x19, 0x18, 0xd9, 0x70, 0x7, 0xf7, 0xb, 0x41, 0xfa, 0x2c, 0x45, 0x10, 0x3f, 0xcf, 0x6d, 0xd2, 0x2e, 0xeb, 0xab, 0x27, 0xd0}),
-				new(big.Int).SetBytes([]byte{0x9b, 0x6d, 0x62, 0x5e, 0xec, 0x46, 0x6b, 0x5e, 0x6f, 0x35, 0x7c, 0x91, 0x81, 0x7c, 0x4b, 0x9a, 0xb5, 0xbb, 0xca, 0x93, 0x58, 0x7e, 0x51, 0xb5, 0x71, 0xe8, 0x67, 0x5e, 0x40, 0xd3, 0xd1, 0x10}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4e, 0x7c, 0x27, 0x2a, 0x7a, 0xf4, 0xb3, 0x4e, 0x8d, 0xbb, 0x93, 0x52, 0xa5, 0x41, 0x9a, 0x87, 0xe2, 0x83, 0x8c, 0x70, 0xad, 0xc6, 0x2c, 0xdd, 0xf0, 0xcc, 0x3a, 0x3b, 0x8, 0xfb, 0xd5, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x17, 0x74, 0x9c, 0x76, 0x6c, 0x9d, 0xb, 0x18, 0xe1, 0x6f, 0xd0, 0x9f, 0x6d, 0xef, 0x68, 0x1b, 0x53, 0xb, 0x96, 0x14, 0xbf, 0xf7, 0xdd, 0x33, 0xe0, 0xb3, 0x94, 0x18, 0x17, 0xdc, 0xaa, 0xe6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x90, 0x17, 0xb0, 0x26, 0x96, 0x88, 0x8f, 0x26, 0x8a, 0x26, 0x9f, 0x4e, 0x38, 0x5d, 0x9c, 0x9b, 0x11, 0xf2, 0x5a, 0x1b, 0xef, 0x87, 0x90, 0xe2, 0x82, 0x1e, 0x6e, 0x7c, 0x6e, 0x1b, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x43, 0xae, 0x2c, 0xda, 0xb5, 0xb3, 0x34, 0xf0, 0xbb, 0x45, 0x79, 0x83, 0x36, 0x35, 0x8b, 0xfa, 0xe4, 0xe5, 0x1b, 0xc0, 0xf9, 0x32, 0xb2, 0x12, 0x0, 0x9a, 0xeb, 0xda, 0xd8, 0x14, 0xab, 0x2b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2, 0x48, 0x4e, 0x30, 0x10, 0xc9, 0x45, 0x5c, 0x75, 0xe4, 0x4d, 0x2b, 0xe9, 0xae, 0x24, 0xa5, 0x23, 0x3c, 0x7, 0x17, 0xe9, 0xaa, 0x75, 0xc, 0xee, 0x29, 0x84, 0x64, 0xe5, 0x21, 0xb3, 0xff}),
-				new(big.Int).SetBytes([]byte{0x96, 0x19, 0xd0, 0xa0, 0xaa, 0x23, 0xe3, 0xd, 0x7, 0xec, 0x2b, 0x3c, 0x2b, 0x2d, 0xe, 0xeb, 0xad, 0x70, 0x6, 0xde, 0x92, 0x3a, 0xc8, 0xbb, 0xcc, 0x1a, 0xe4, 0xb9, 0x2, 0x69, 0xda, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x67, 0xf6, 0x44, 0xf7, 0x6e, 0x90, 0x5f, 0xd4, 0xa8, 0xf4, 0x72, 0x8e, 0x63, 0x22, 0x7f, 0xe, 0x28, 0x31, 0xf5, 0xbf, 0x91, 0xb5, 0x83, 0xa8, 0xaf, 0x26, 0x35, 0xa1, 0x7e, 0x5f, 0x71, 0x2f}),
-				new(big.Int).SetBytes([]byte{0xb8, 0x33, 0xd6, 0x8f, 0x66, 0x44, 0x5d, 0x4, 0xf0, 0x5a, 0xde, 0xb7, 0xb5, 0x86, 0xcf, 0x78, 0x5e, 0xe, 0x14, 0x88, 0xf7, 0xd3, 0x61, 0x98, 0xd6, 0x8a, 0xcb, 0x5e, 0x70, 0x71, 0x60, 0xe5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0xc1, 0xc5, 0x26, 0xcf, 0xb6, 0xce, 0x57, 0xef, 0x79, 0xb7, 0xd, 0x67, 0x2c, 0x95, 0x4e, 0x20, 0xd6, 0xa4, 0x70, 0x30, 0x74, 0x17, 0x51, 0xf9, 0x6b, 0x79, 0xfa, 0x80, 0x4b, 0xa7, 0xb9}),
-				new(big.Int).SetBytes([]byte{0xdb, 0x15, 0x7f, 0x7c, 0x34, 0x3, 0x14, 0x39, 0x6, 0x26, 0x27, 0x45, 0x9c, 0x8a, 0x94, 0xde, 0xec, 0x75, 0x5c, 0x1, 0xbd, 0xc9, 0xa9, 0xcc, 0x47, 0x5b, 0xe3, 0xa5, 0x1c, 0x5b, 0xd7, 0x41}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4e, 0x53, 0xc8, 0xb4, 0x99, 0x1, 0xff, 0x80, 0xe, 0xa, 0xad, 0xef, 0x4b, 0x1f, 0x19, 0xe, 0xdc, 0xf4, 0x3c, 0x6c, 0xcb, 0xd9, 0xd, 0xfe, 0x2b, 0x26, 0x9, 0x61, 0x40, 0x69, 0x2f, 0xd1}),
-				new(big.Int).SetBytes([]byte{0xdd, 0x6e, 0x3e, 0x4f, 0x4d, 0x41, 0xa0, 0x1e, 0x18, 0x6c, 0xd7, 0x9, 0xa7, 0x21, 0x9d, 0xa1, 0xa8, 0x7a, 0x50, 0xda, 0x57, 0xfc, 0x97, 0x53, 0x93, 0x49, 0x30, 0xe7, 0x32, 0x86, 0x25, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x97, 0x3c, 0xd7, 0x53, 0xf9, 0x31, 0xaf, 0x87, 0xa8, 0x9c, 0xe7, 0x74, 0x52, 0x7b, 0x66, 0x95, 0x82, 0x1b, 0x53, 0x80, 0xec, 0x8, 0x25, 0xe8, 0x36, 0x48, 0x7a, 0xa3, 0x66, 0x83, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x38, 0xcf, 0x5a, 0x2c, 0xc3, 0xc, 0xa3, 0xa3, 0x64, 0x3e, 0x12, 0xb6, 0x32, 0xb5, 0xa2, 0x6c, 0xd7, 0x78, 0x84, 0xe1, 0x8f, 0xe9, 0x81, 0xde, 0xdf, 0x5f, 0xb2, 0xb7, 0x7f, 0x4a, 0x57, 0x7f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0x7f, 0x87, 0x6c, 0x93, 0x65, 0x25, 0x55, 0xfa, 0x80, 0xa0, 0x54, 0x96, 0x8b, 0x47, 0x12, 0x93, 0xd, 0xc9, 0x30, 0x12, 0xee, 0x6b, 0x8d, 0xc1, 0x2, 0x63, 0xed, 0x3b, 0x89, 0xa7, 0x62}),
-				new(big.Int).SetBytes([]byte{0xb2, 0xd4, 0x4, 0xea, 0xb3, 0x52, 0x40, 0x26, 0xb0, 0x99, 0x69, 0x25, 0x5e, 0x19, 0x97, 0xb9, 0x75, 0x53, 0x50, 0x70, 0xfe, 0xbd, 0x7d, 0xfe, 0x9c, 0x9f, 0xd9, 0x59, 0xb9, 0x20, 0x33, 0x1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xba, 0x6a, 0x9b, 0xba, 0x72, 0xec, 0xf1, 0x51, 0x1c, 0x2c, 0x4, 0xf4, 0xb0, 0x20, 0x57, 0x65, 0x71, 0x3c, 0x13, 0x32, 0x25, 0x4, 0x3b, 0x57, 0x18, 0xb, 0x39, 0x99, 0xc0, 0xbf, 0x6f, 0x6}),
-				new(big.Int).SetBytes([]byte{0x13, 0x77, 0x1e, 0x38, 0xb3, 0x4a, 0x11, 0xb0, 0x32, 0x2, 0xb, 0xf2, 0xe5, 0x58, 0xbd, 0x8e, 0x9e, 0x5d, 0x14, 0xf7, 0xac, 0x69, 0x7a, 0xaf, 0xe3, 0x2b, 0xef, 0x70, 0x78, 0x1d, 0xb5, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc, 0xf3, 0xc5, 0xda, 0x6b, 0x99, 0xac, 0x5c, 0xc3, 0xd5, 0xb9, 0x28, 0x6c, 0x3, 0x1, 0x6a, 0x6c, 0x52, 0xb9, 0x98, 0x5e, 0xc7, 0x5f, 0xa2, 0x79, 0x9f, 0x11, 0x99, 0x55, 0x63, 0x7b, 0x39}),
-				new(big.Int).SetBytes([]byte{0x2, 0xcf, 0xb5, 0x2f, 0xd9, 0xf3, 0x9b, 0x64, 0x13, 0x46, 0x71, 0xad, 0xc1, 0x80, 0x24, 0xa5, 0x37, 0xac, 0xd3, 0x5, 0x23, 0xc2, 0x5c, 0x3c, 0xd2, 0x47, 0xa3, 0xd7, 0xa2, 0x85, 0x2a, 0x40}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x35, 0xc5, 0xbc, 0xcc, 0x27, 0xcc, 0x4d, 0xd2, 0xd8, 0xae, 0xd9, 0x50, 0x64, 0xde, 0x44, 0x2f, 0x44, 0xd5, 0x14, 0xd6, 0x65, 0xad, 0x43, 0xb9, 0xfd, 0x1e, 0xf9, 0xf0, 0x71, 0x23, 0x1a, 0x43}),
-				new(big.Int).SetBytes([]byte{0xe, 0x36, 0x75, 0x72, 0x3b, 0x97, 0x46, 0xce, 0x73, 0x4e, 0x58, 0x20, 0xb6, 0xfa, 0x58, 0x9d, 0x4c, 0xf7, 0xa4, 0xcc, 0x6, 0x3c, 0xf0, 0xae, 0x7d, 0xb7, 0x2e, 0x45, 0x16, 0x20, 0xc8, 0xf6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xce, 0x47, 0xd0, 0xf5, 0xe0, 0xd3, 0x75, 0xf3, 0xa8, 0x21, 0x2e, 0x6, 0xde, 0x2c, 0xe3, 0x11, 0xfc, 0xc4, 0xf7, 0xf3, 0xe1, 0x21, 0xbd, 0x61, 0x48, 0xce, 0x35, 0x7d, 0xb5, 0x49, 0x82, 0x7b}),
-				new(big.Int).SetBytes([]byte{0x15, 0xc6, 0x98, 0xfb, 0x99, 0x63, 0xd, 0x49, 0xd5, 0x89, 0x13, 0x9f, 0xe5, 0xb9, 0xad, 0xf, 0x29, 0xe6, 0x59, 0x2f, 0xf0, 0x57, 0x69, 0xdf, 0x43, 0xcc, 0x9a, 0x5f, 0xef, 0xae, 0xd0, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x47, 0x9c, 0x24, 0x5c, 0x55, 0x86, 0xbc, 0xa1, 0x22, 0xf1, 0x2f, 0xc5, 0x88, 0x35, 0x6a, 0xc7, 0x41, 0x90, 0x98, 0xd, 0x81, 0xcd, 0x76, 0xbf, 0x40, 0xbc, 0x56, 0xfd, 0xd4, 0x6, 0x9}),
-				new(big.Int).SetBytes([]byte{0x30, 0x69, 0xcf, 0xb5, 0x26, 0x94, 0xaf, 0xef, 0xf2, 0xca, 0xd7, 0xa4, 0x3c, 0x98, 0x41, 0xfd, 0x91, 0x13, 0xae, 0xd7, 0x26, 0x1c, 0xa4, 0x73, 0x9d, 0xe2, 0xf1, 0x36, 0x6a, 0x64, 0xca, 0x53}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x93, 0x1b, 0xf8, 0xbd, 0x4a, 0x8e, 0x42, 0xf3, 0x4e, 0xb0, 0xe2, 0x20, 0x7f, 0x95, 0x43, 0xc7, 0xf6, 0x6, 0x82, 0xea, 0x16, 0x98, 0xfe, 0x13, 0xb3, 0x8f, 0xe7, 0x15, 0x7d, 0x48, 0xbe, 0x0}),
-				new(big.Int).SetBytes([]byte{0xf1, 0x15, 0x3b, 0xf0, 0x48, 0x9c, 0x20, 0x83, 0xd1, 0xb3, 0x88, 0xe6, 0x11, 0x40, 0xeb, 0xee, 0x6a, 0x43, 0xee, 0x6a, 0xb3, 0x33, 0xdd, 0xf5, 0x15, 0x86, 0xa9, 0x13, 0x1f, 0x44, 0xf4, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6c, 0x40, 0x0, 0x2a, 0x3a, 0xcc, 0xd8, 0x64, 0xbd, 0xed, 0x8e, 0xb9, 0xaf, 0x2c, 0x7d, 0x54, 0x4f, 0x18, 0xb6, 0xcd, 0x53, 0xb8, 0xe8, 0x42, 0x4d, 0xb7, 0x2, 0xf5, 0x2b, 0x79, 0x9a, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x95, 0xc2, 0xdc, 0x15, 0x30, 0xb6, 0x35, 0xbe, 0x69, 0xb, 0x83, 0x6a, 0xe, 0x1d, 0xdc, 0xbd, 0xc0, 0x7a, 0xbd, 0x4c, 0x82, 0x4, 0x83, 0xf6, 0x8b, 0x5c, 0xc8, 0x2c, 0xd3, 0xc8, 0x1e, 0x32}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0xa7, 0x4e, 0x3d, 0xbe, 0x77, 0x8b, 0x1b, 0x10, 0xf2, 0x38, 0xad, 0x61, 0x68, 0x6a, 0xa5, 0xc7, 0x6e, 0x3d, 0xb2, 0xbe, 0x43, 0x5, 0x76, 0x32, 0x42, 0x7e, 0x28, 0x40, 0xfb, 0x27, 0xb6}),
-				new(big.Int).SetBytes([]byte{0x6e, 0x5, 0x68, 0xdb, 0x9b, 0xb, 0x13, 0x29, 0x7c, 0xf6, 0x74, 0xde, 0xcc, 0xb6, 0xaf, 0x93, 0x12, 0x6b, 0x59, 0x6b, 0x97, 0x3f, 0x7b, 0x77, 0x70, 0x1d, 0x3d, 0xb7, 0xf2, 0x3c, 0xb9, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xed, 0x94, 0x41, 0xc8, 0x30, 0x42, 0x80, 0xff, 0x18, 0xe, 0x3, 0xd8, 0x50, 0xe8, 0xcd, 0xe, 0xbb, 0x57, 0xe, 0xe5, 0xde, 0x37, 0x30, 0x48, 0x8f, 0xd9, 0x7c, 0x96, 0x1f, 0x97, 0x56, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x3d, 0xbe, 0x9e, 0x9e, 0xfe, 0x8b, 0xfa, 0x19, 0xaf, 0xa1, 0x76, 0x12, 0x8b, 0x13, 0x91, 0x1e, 0x9, 0xf2, 0x37, 0x74, 0xfe, 0x4d, 0xe9, 0x8b, 0xff, 0xe, 0x9, 0xf9, 0x3f, 0x3a, 0xbf, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x76, 0x2e, 0x8b, 0xc3, 0x32, 0x11, 0xfe, 0xa8, 0x22, 0x6c, 0xd9, 0x7b, 0x27, 0x18, 0x99, 0xf3, 0xf2, 0x7b, 0x64, 0x99, 0x7b, 0x0, 0x4b, 0xb2, 0x5d, 0xd8, 0x1a, 0xe9, 0xbe, 0x88, 0x97, 0x56}),
-				new(big.Int).SetBytes([]byte{0xc0, 0x28, 0x94, 0x26, 0xa, 0xf3, 0xe9, 0x7c, 0x3c, 0x7c, 0xc4, 0xf1, 0x49, 0x82, 0xe3, 0x47, 0x19, 0x72, 0xdb, 0x31, 0x48, 0x84, 0xfa, 0x5e, 0x25, 0xe2, 0x59, 0xe0, 0x7c, 0xa6, 0xb7, 0x74}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x29, 0xd9, 0x69, 0x8e, 0xe6, 0x7a, 0x7c, 0x3f, 0xc9, 0xfe, 0xd3, 0xf6, 0x24, 0xb4, 0x87, 0x51, 0x5b, 0x10, 0xbd, 0xd8, 0x4f, 0xab, 0x4d, 0x30, 0x15, 0xba, 0xd0, 0x33, 0xd5, 0x1c, 0xf1, 0x19}),
-				new(big.Int).SetBytes([]byte{0x7f, 0xd0, 0x2c, 0x51, 0x7d, 0xc8, 0x2b, 0x45, 0x27, 0x7a, 0x12, 0x54, 0x4, 0xf1, 0xc9, 0x6f, 0xb8, 0x9c, 0x94, 0xe, 0x93, 0xa7, 0xc2, 0x96, 0x3c, 0x88, 0x74, 0x5, 0x75, 0x5, 0x63, 0x39}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdf, 0x7, 0x7d, 0x47, 0xdf, 0x60, 0x95, 0x34, 0x8e, 0x19, 0xbd, 0xbb, 0x23, 0x8, 0xf4, 0xa9, 0x1e, 0x52, 0xac, 0xfa, 0x10, 0x14, 0xe8, 0xea, 0x26, 0xf7, 0x5e, 0x97, 0x9, 0x75, 0xd2, 0xea}),
-				new(big.Int).SetBytes([]byte{0xf8, 0x61, 0x7a, 0x88, 0x0, 0xef, 0x7f, 0x44, 0x24, 0xc8, 0x42, 0x5c, 0x98, 0xa2, 0x52, 0x7c, 0x8a, 0x1e, 0xc5, 0xb8, 0x4f, 0xbd, 0xd2, 0x77, 0xaa, 0x3c, 0x2d, 0x9e, 0x31, 0x93, 0x6f, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0xb8, 0x2a, 0x75, 0x57, 0x9a, 0xd3, 0x6b, 0x9d, 0x47, 0xef, 0x64, 0xf1, 0xa5, 0xa8, 0x5c, 0x24, 0x7f, 0x21, 0x2, 0x7e, 0x56, 0xc6, 0xc2, 0x87, 0x55, 0x80, 0xa5, 0xa6, 0x71, 0x45, 0x60}),
-				new(big.Int).SetBytes([]byte{0xf9, 0xd8, 0xa6, 0x97, 0x6f, 0x26, 0x1e, 0xf4, 0x28, 0xbf, 0x56, 0x34, 0x61, 0x64, 0x34, 0x59, 0x7d, 0x94, 0xf2, 0x3b, 0xef, 0x71, 0x62, 0x84, 0xad, 0xa8, 0x73, 0x34, 0xa7, 0x74, 0x29, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9f, 0x3e, 0x7d, 0x75, 0x8b, 0xd3, 0xda, 0x3, 0x2e, 0x17, 0xde, 0xa8, 0x33, 0x4b, 0x14, 0x29, 0x35, 0xdb, 0x4d, 0x6e, 0xe5, 0x43, 0x91, 0xb4, 0x5b, 0x84, 0x91, 0xfb, 0xbc, 0x4c, 0x92, 0xd7}),
-				new(big.Int).SetBytes([]byte{0xec, 0xd2, 0x84, 0x1e, 0xa7, 0x7d, 0x46, 0x6b, 0x58, 0x86, 0x2b, 0x21, 0xcb, 0xab, 0x15, 0x2, 0x45, 0x2a, 0x23, 0x3, 0xd6, 0x94, 0xe1, 0x18, 0x6c, 0xbb, 0xbf, 0xcf, 0xb1, 0x49, 0x6, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0x6b, 0x57, 0xd0, 0x50, 0x13, 0x93, 0x6d, 0x6f, 0x3f, 0xb7, 0xbd, 0x33, 0x58, 0xa, 0x31, 0xfd, 0x45, 0x3e, 0x4a, 0x86, 0x6, 0xc, 0xff, 0x46, 0x7c, 0x44, 0x53, 0x7f, 0x42, 0x24, 0x91}),
-				new(big.Int).SetBytes([]byte{0xc1, 0xa7, 0xdc, 0x13, 0x6, 0x16, 0x62, 0xc2, 0xe3, 0xc4, 0xa3, 0xeb, 0xa2, 0xbf, 0x3f, 0xb0, 0xe1, 0x48, 0xba, 0xc3, 0xb, 0xf3, 0x93, 0x47, 0xaf, 0xa3, 0x1f, 0x19, 0x9d, 0xa3, 0xef, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa0, 0xcc, 0x79, 0x5d, 0x7b, 0x5c, 0xcf, 0x9e, 0xdc, 0x38, 0xc3, 0xd2, 0x2e, 0xf9, 0x52, 0x81, 0x17, 0x4b, 0xc, 0x88, 0xc5, 0x4, 0xa, 0xc3, 0x5a, 0xe0, 0xd7, 0x32, 0xb2, 0xa8, 0xc4, 0x83}),
-				new(big.Int).SetBytes([]byte{0xab, 0xc3, 0x1, 0x22, 0xf8, 0xb3, 0x87, 0x3e, 0xe2, 0x37, 0x4f, 0xc9, 0x72, 0x31, 0xdf, 0x78, 0x6b, 0x34, 0x8f, 0x1b, 0xdf, 0x69, 0x36, 0x5, 0x50, 0x96, 0x74, 0x55, 0x92, 0xcc, 0x6b, 0xa9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1a, 0x24, 0x11, 0x79, 0xb9, 0xe8, 0x1f, 0x56, 0x70, 0xcf, 0xaf, 0xfa, 0xc8, 0xa0, 0xb5, 0xbf, 0x38, 0xa0, 0xae, 0xfb, 0x97, 0xad, 0x75, 0x76, 0x5, 0x15, 0x62, 0x3a, 0x3a, 0xfa, 0xf4, 0x3}),
-				new(big.Int).SetBytes([]byte{0x8e, 0x1c, 0xa0, 0xc0, 0xa8, 0x67, 0x40, 0xc2, 0xc4, 0xbf, 0x27, 0x49, 0x39, 0x5, 0xb7, 0x6c, 0x2c, 0x81, 0x77, 0xf4, 0x17, 0x8d, 0xf6, 0xd, 0x59, 0xb, 0x6e, 0x40, 0xe4, 0xd7, 0x9a, 0x16}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6d, 0x1c, 0x50, 0xa5, 0x15, 0x53, 0xc7, 0xcc, 0xe0, 0xe2, 0x2a, 0x9a, 0xc6, 0xa9, 0x76, 0xca, 0x13, 0x15, 0x3a, 0x8a, 0x8f, 0x96, 0xd2, 0xf2, 0x17, 0xf, 0x1b, 0x6b, 0xc5, 0xdd, 0x3a, 0xee}),
-				new(big.Int).SetBytes([]byte{0xaf, 0xff, 0x14, 0x8e, 0x6, 0xab, 0xdf, 0xdc, 0x97, 0x72, 0x72, 0x0, 0x22, 0x87, 0xd4, 0x74, 0x85, 0x1e, 0x31, 0xa, 0xa8, 0xed, 0x53, 0xad, 0xfe, 0xe3, 0x54, 0xe4, 0xfd, 0xf5, 0x97, 0xf7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0x10, 0xc5, 0xae, 0x9a, 0xc9, 0x68, 0xee, 0x1f, 0x46, 0xbb, 0xed, 0x41, 0x39, 0x6, 0x20, 0x26, 0xf2, 0x83, 0x5f, 0x98, 0xcc, 0xfc, 0xd0, 0x76, 0x22, 0xeb, 0x4, 0x9b, 0x72, 0xb, 0x26}),
-				new(big.Int).SetBytes([]byte{0xc7, 0x35, 0xc6, 0xcf, 0xfe, 0x42, 0x88, 0x91, 0x5a, 0x86, 0x65, 0xb2, 0x87, 0x21, 0x5e, 0x28, 0xe2, 0xff, 0x79, 0xa4, 0xdb, 0xe0, 0x9e, 0x43, 0x34, 0x6e, 0x98, 0xc7, 0xad, 0xd9, 0x2d, 0xb5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5e, 0x5f, 0x1d, 0x61, 0x8b, 0x97, 0xf9, 0xf3, 0xf3, 0x11, 0xa6, 0xd8, 0xa8, 0xa6, 0x41, 0x8f, 0x65, 0x9d, 0x31, 0x22, 0xf5, 0xa1, 0xaf, 0xdc, 0x67, 0x8c, 0xa9, 0xb7, 0xe4, 0xa6, 0xd0, 0xbb}),
-				new(big.Int).SetBytes([]byte{0xd7, 0xb1, 0x50, 0x2b, 0x6, 0xa7, 0xe6, 0xf7, 0x35, 0x8f, 0x6b, 0xcd, 0x4, 0xe7, 0x21, 0xda, 0x72, 0x55, 0x5f, 0x2e, 0x50, 0x6f, 0x65, 0x3a, 0x16, 0x83, 0x84, 0x79, 0x10, 0x33, 0xea, 0xf9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x73, 0xf8, 0x7b, 0xae, 0x97, 0x34, 0x85, 0x4a, 0x8a, 0xb6, 0x84, 0xd0, 0x58, 0xe, 0xa8, 0xa0, 0x3c, 0x45, 0x29, 0xb6, 0xc2, 0x2d, 0x2, 0x99, 0xe0, 0x8, 0x3a, 0x7d, 0x65, 0x54, 0x3c, 0xb4}),
-				new(big.Int).SetBytes([]byte{0x37, 0xc6, 0x6c, 0xf0, 0x3a, 0x87, 0xf8, 0x6f, 0xee, 0xbf, 0xbf, 0xc1, 0x1a, 0x14, 0x2, 0x89, 0x9d, 0x5c, 0x42, 0xf5, 0x35, 0x7f, 0x2d, 0x7a, 0x3d, 0x2d, 0xd8, 0x72, 0xc4, 0x85, 0x6c, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf8, 0x13, 0x8a, 0x6b, 0x3c, 0x16, 0x42, 0x7f, 0xff, 0xd3, 0xcb, 0x9a, 0xce, 0x1, 0xa1, 0x49, 0x5a, 0xf6, 0x8e, 0x31, 0xbe, 0xc3, 0x9b, 0xd2, 0xdd, 0xa9, 0xb5, 0xe4, 0x4f, 0x0, 0x5e, 0x3f}),
-				new(big.Int).SetBytes([]byte{0xca, 0x75, 0x8f, 0x3b, 0xef, 0xb4, 0xeb, 0xd9, 0xf9, 0xd0, 0x15, 0xe5, 0x7a, 0xa5, 0xcb, 0x51, 0xf4, 0xec, 0x41, 0xbd, 0x55, 0x4b, 0xe2, 0x13, 0x42, 0xd7, 0xe0, 0x20, 0x2f, 0x35, 0x7e, 0xb7}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x76, 0xe6, 0x41, 0x13, 0xf6, 0x77, 0xcf, 0xe, 0x10, 0xa2, 0x57, 0xd, 0x59, 0x99, 0x68, 0xd3, 0x15, 0x44, 0xe1, 0x79, 0xb7, 0x60, 0x43, 0x29, 0x52, 0xc0, 0x2a, 0x44, 0x17, 0xbd, 0xde, 0x39}),
-				new(big.Int).SetBytes([]byte{0xc9, 0xd, 0xdf, 0x8d, 0xee, 0x4e, 0x95, 0xcf, 0x57, 0x70, 0x66, 0xd7, 0x6, 0x81, 0xf0, 0xd3, 0x5e, 0x2a, 0x33, 0xd2, 0xb5, 0x6d, 0x20, 0x32, 0xb4, 0xb1, 0x75, 0x2d, 0x19, 0x1, 0xac, 0x1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x70, 0x8a, 0x53, 0xe, 0x9e, 0x52, 0xc7, 0x3b, 0xee, 0x87, 0xc9, 0xd8, 0x81, 0x61, 0xc8, 0x10, 0x0, 0x5d, 0x57, 0x62, 0x2c, 0x29, 0xae, 0x69, 0x1c, 0xf9, 0x99, 0xa8, 0x3a, 0x11, 0x87, 0xa5}),
-				new(big.Int).SetBytes([]byte{0x9b, 0x88, 0x48, 0x11, 0xe1, 0xf9, 0xa8, 0x97, 0xfa, 0x96, 0x56, 0xdc, 0xbb, 0x6d, 0x38, 0x28, 0x3e, 0xcd, 0xa7, 0x3c, 0x6d, 0x35, 0x3e, 0x8a, 0x58, 0xa4, 0xf1, 0x9b, 0x47, 0x3d, 0xb9, 0xc0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x8e, 0x57, 0xad, 0x85, 0x9d, 0xa9, 0xbe, 0x25, 0xff, 0x72, 0x63, 0xaa, 0x9b, 0x4f, 0xf6, 0x4e, 0xbf, 0x20, 0xce, 0x50, 0x69, 0x19, 0x44, 0x2f, 0x5, 0x9, 0x1c, 0xc0, 0x78, 0xee, 0x8d}),
-				new(big.Int).SetBytes([]byte{0x85, 0x2e, 0x97, 0x98, 0x4a, 0xb4, 0x88, 0xd7, 0x2d, 0x61, 0x72, 0xee, 0x75, 0x7e, 0x6d, 0xf4, 0x12, 0x3e, 0xf7, 0xcf, 0x94, 0x22, 0xed, 0x9d, 0xe9, 0x97, 0xf4, 0xdc, 0x2d, 0xa6, 0x3e, 0x86}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x19, 0xcf, 0x3, 0x4f, 0xc4, 0x8b, 0x3b, 0xe2, 0x19, 0xbd, 0x64, 0x83, 0x95, 0xe4, 0x62, 0xcf, 0x9f, 0x37, 0x4b, 0x6d, 0x86, 0xb2, 0xb5, 0x9e, 0x2e, 0x1b, 0x16, 0xc6, 0xcd, 0xe4, 0xf5, 0xbe}),
-				new(big.Int).SetBytes([]byte{0x28, 0xe3, 0x2b, 0x6, 0xa1, 0x5a, 0xb4, 0x66, 0xc3, 0xb4, 0xbe, 0x68, 0xab, 0x18, 0x19, 0x47, 0xef, 0x91, 0xd1, 0xc9, 0x3f, 0xf, 0x1c, 0xc, 0xa, 0x91, 0x53, 0x2b, 0x6f, 0x32, 0x1a, 0xf2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7d, 0xa6, 0xc0, 0x85, 0xe4, 0xd4, 0x4d, 0x27, 0x5f, 0x58, 0xbe, 0x80, 0xec, 0xd3, 0x1d, 0x8, 0xc, 0xae, 0x3a, 0xcf, 0x1e, 0x48, 0x25, 0x48, 0x15, 0x91, 0x46, 0x70, 0x42, 0x91, 0x29, 0xec}),
-				new(big.Int).SetBytes([]byte{0xf4, 0x98, 0x14, 0x6b, 0xb9, 0xf4, 0x18, 0x57, 0x51, 0x1d, 0x2, 0x7, 0x49, 0x16, 0x27, 0xbf, 0x4, 0x48, 0xc0, 0x86, 0x54, 0xca, 0x58, 0x6a, 0xeb, 0x50, 0xae, 0xe2, 0xac, 0xd9, 0xff, 0xe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0x35, 0xce, 0xa5, 0xe9, 0x9e, 0xeb, 0x23, 0x76, 0x5b, 0x34, 0x44, 0xd9, 0xbc, 0x7b, 0xe6, 0x1, 0xda, 0x67, 0xd6, 0x91, 0xbc, 0xc4, 0x2f, 0xd4, 0x3a, 0xe5, 0x43, 0xe, 0x9c, 0x22, 0xbc}),
-				new(big.Int).SetBytes([]byte{0x3b, 0xf8, 0xd0, 0x20, 0x76, 0x9c, 0x52, 0x24, 0xa0, 0x67, 0xf0, 0x80, 0xf0, 0xce, 0xb8, 0x6a, 0xc8, 0xc3, 0xc, 0x23, 0x6c, 0xa1, 0xf1, 0x9b, 0x3c, 0x2c, 0x26, 0x72, 0xcb, 0xda, 0xcb, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x90, 0xd0, 0x90, 0xcf, 0xf5, 0xc1, 0xbe, 0x6e, 0x21, 0xbe, 0x90, 0x1, 0xbe, 0x69, 0xd9, 0x4f, 0xb2, 0xf7, 0xf3, 0x94, 0x23, 0x1a, 0xae, 0xe9, 0x4f, 0x83, 0xd4, 0x95, 0x51, 0x65, 0x4f, 0x22}),
-				new(big.Int).SetBytes([]byte{0xcd, 0x56, 0x9a, 0x1d, 0x2b, 0xac, 0xf6, 0x1a, 0x95, 0x3f, 0x2, 0x1e, 0x6, 0xbf, 0x70, 0x33, 0x6d, 0xd6, 0x35, 0x65, 0x3d, 0xa3, 0xf8, 0x74, 0xf, 0x5d, 0xe0, 0x57, 0x60, 0x1a, 0x43, 0xe1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaf, 0x6c, 0x44, 0xa0, 0x78, 0xcb, 0x5f, 0xd, 0x7c, 0x71, 0x9c, 0x2f, 0x83, 0x97, 0xf5, 0x76, 0xee, 0x93, 0xbd, 0x3, 0x4b, 0xea, 0x22, 0x19, 0xe3, 0xab, 0xc2, 0x9, 0xd1, 0x7c, 0xf3, 0xe8}),
-				new(big.Int).SetBytes([]byte{0x7, 0x84, 0x9, 0x6f, 0xe8, 0x5d, 0x4b, 0x30, 0xaf, 0x9e, 0x73, 0x15, 0x3c, 0xb2, 0x46, 0xdf, 0xec, 0x36, 0x2a, 0xea, 0x7c, 0xa0, 0xd4, 0x35, 0xb8, 0xad, 0xd0, 0x60, 0x17, 0x51, 0xba, 0xea}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x47, 0x98, 0x58, 0x7c, 0xf1, 0xd1, 0xec, 0xc7, 0x32, 0x2c, 0x31, 0x8, 0x21, 0x29, 0x2e, 0xd9, 0xd6, 0xe0, 0x6d, 0x6, 0x88, 0x19, 0xd7, 0x1b, 0x6e, 0xf6, 0x51, 0xc7, 0x6b, 0x19, 0xfa}),
-				new(big.Int).SetBytes([]byte{0xeb, 0xb1, 0xd7, 0x78, 0x9b, 0xcc, 0xaf, 0x1, 0xd1, 0x73, 0x69, 0x7, 0x7e, 0x16, 0x56, 0x9e, 0xa6, 0x7b, 0xd1, 0x3e, 0x95, 0x53, 0xfc, 0x20, 0x6a, 0xa2, 0x6e, 0xeb, 0x32, 0x6f, 0x5a, 0xf7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaa, 0x21, 0xc6, 0xd, 0x6f, 0x7d, 0x62, 0x53, 0x9d, 0x42, 0xba, 0xfe, 0x95, 0xc5, 0x8f, 0x30, 0x33, 0xc0, 0x39, 0x6c, 0xfa, 0xc, 0xee, 0xf9, 0x6d, 0x2f, 0x91, 0xed, 0xd6, 0x56, 0x90, 0x44}),
-				new(big.Int).SetBytes([]byte{0xb2, 0xae, 0x68, 0x86, 0xfc, 0x4e, 0x6a, 0xea, 0x6d, 0x1d, 0x26, 0xad, 0xd4, 0x1a, 0xdf, 0x4e, 0xee, 0x5a, 0xba, 0xc1, 0x13, 0x92, 0x83, 0x34, 0xc1, 0x92, 0x4c, 0x41, 0xc2, 0xf3, 0x77, 0x66}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe3, 0xe4, 0x75, 0x4, 0xa8, 0xdd, 0xee, 0x6a, 0xd8, 0x17, 0xaa, 0xf5, 0x82, 0xe9, 0x89, 0x7c, 0xf0, 0xc0, 0x87, 0xaf, 0x39, 0xd8, 0x63, 0x1e, 0xcd, 0x92, 0xfb, 0x65, 0xb9, 0x13, 0x5d, 0xbd}),
-				new(big.Int).SetBytes([]byte{0x93, 0xa, 0x5b, 0xf8, 0x9, 0xcb, 0xc6, 0xc4, 0xb, 0x98, 0xc8, 0x19, 0x52, 0xb8, 0x3f, 0xfe, 0xa5, 0xa2, 0x2, 0xff, 0x5e, 0xc9, 0x5d, 0xa9, 0x69, 0x6, 0xaf, 0x3e, 0x47, 0x48, 0x4, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x57, 0x16, 0xdc, 0x35, 0x5d, 0xf2, 0x2, 0xa2, 0xc3, 0xb4, 0x3, 0x1c, 0xf1, 0x4a, 0x28, 0x58, 0xa5, 0xca, 0xbd, 0x91, 0xe9, 0xd3, 0xc2, 0xbe, 0x5b, 0xc1, 0xe6, 0x21, 0x4f, 0xf4, 0xde, 0x54}),
-				new(big.Int).SetBytes([]byte{0x98, 0x5f, 0x6c, 0x40, 0xa, 0x5b, 0x83, 0xf3, 0x91, 0xea, 0xa6, 0x61, 0xa9, 0x93, 0x24, 0xc5, 0xeb, 0xf3, 0xfe, 0xcc, 0xd0, 0x47, 0xe4, 0x17, 0xd3, 0xea, 0x4f, 0xd5, 0x50, 0x10, 0xf, 0xa5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7e, 0x8c, 0x65, 0x6f, 0xe8, 0x70, 0xa9, 0xb0, 0xa4, 0x74, 0x53, 0x38, 0xf8, 0xb, 0xdb, 0x4a, 0xff, 0x67, 0xff, 0xe6, 0xe8, 0xce, 0x40, 0x75, 0xcb, 0xf0, 0xb9, 0xe5, 0xcd, 0xee, 0x45, 0x5f}),
-				new(big.Int).SetBytes([]byte{0xbd, 0xdd, 0xc6, 0x32, 0xca, 0x30, 0x74, 0xf, 0x2a, 0x3f, 0x76, 0xf, 0x48, 0x3, 0xa5, 0x3b, 0x4a, 0xa2, 0xd1, 0x67, 0x46, 0xf4, 0x66, 0x27, 0x17, 0x3d, 0xe9, 0x98, 0x17, 0x1b, 0x9c, 0xba}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x6, 0x51, 0xcb, 0x59, 0x2d, 0x28, 0x2b, 0x53, 0x48, 0xf1, 0x92, 0x5b, 0xe3, 0xf0, 0x4d, 0xda, 0x50, 0x33, 0xbc, 0x39, 0xe2, 0xfc, 0x7b, 0x1e, 0x61, 0x50, 0xd, 0x97, 0x69, 0xb5, 0x7c}),
-				new(big.Int).SetBytes([]byte{0x25, 0x20, 0x88, 0x33, 0xbb, 0x7a, 0xc0, 0x98, 0xac, 0x4e, 0x24, 0x23, 0xe8, 0x56, 0x82, 0xc3, 0x19, 0x2a, 0x4d, 0x18, 0x73, 0x9f, 0x78, 0x5, 0x7f, 0xf3, 0xa5, 0x5, 0xf3, 0x32, 0x36, 0xe3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3d, 0xf8, 0x41, 0xf8, 0x91, 0xa9, 0x5e, 0xb1, 0x5b, 0xeb, 0x3, 0x5a, 0xb3, 0xe3, 0xc7, 0x26, 0xa, 0x33, 0x59, 0x25, 0x89, 0x34, 0x47, 0xa4, 0x37, 0x79, 0x6b, 0x9b, 0x66, 0x98, 0xb5, 0x9e}),
-				new(big.Int).SetBytes([]byte{0xb3, 0x8f, 0xe6, 0xdf, 0x98, 0xb0, 0x90, 0xc1, 0xda, 0x2, 0xba, 0xe8, 0xf1, 0x1c, 0xf3, 0x86, 0x7b, 0xfc, 0x86, 0x7b, 0x21, 0x49, 0x49, 0xf8, 0xbc, 0xf8, 0xe6, 0x79, 0x6b, 0x92, 0xba, 0xf5}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc7, 0x38, 0xc5, 0x6b, 0x3, 0xb2, 0xab, 0xe1, 0xe8, 0x28, 0x1b, 0xaa, 0x74, 0x3f, 0x8f, 0x9a, 0x8f, 0x7c, 0xc6, 0x43, 0xdf, 0x26, 0xcb, 0xee, 0x3a, 0xb1, 0x50, 0x24, 0x2b, 0xcb, 0xb8, 0x91}),
-				new(big.Int).SetBytes([]byte{0x89, 0x3f, 0xb5, 0x78, 0x95, 0x1a, 0xd2, 0x53, 0x7f, 0x71, 0x8f, 0x2e, 0xac, 0xbf, 0xbb, 0xbb, 0x82, 0x31, 0x4e, 0xef, 0x78, 0x80, 0xcf, 0xe9, 0x17, 0xe7, 0x35, 0xd9, 0x69, 0x9a, 0x84, 0xc3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x55, 0x78, 0x84, 0x5e, 0xcd, 0x7c, 0x3, 0x74, 0x35, 0xb3, 0x2a, 0x69, 0x92, 0xe7, 0xaa, 0x94, 0x64, 0x71, 0x97, 0xea, 0x49, 0xb8, 0xc9, 0xe4, 0xdd, 0xaa, 0xb0, 0x78, 0x46, 0x62, 0xab, 0x1b}),
-				new(big.Int).SetBytes([]byte{0xe6, 0x1d, 0x7, 0x97, 0x8b, 0x6d, 0xe2, 0xc3, 0xce, 0xa6, 0xd0, 0xa5, 0x1d, 0x2a, 0x40, 0x53, 0xf6, 0x53, 0xa7, 0x74, 0x6a, 0x5d, 0x64, 0xde, 0x31, 0x6d, 0x18, 0xf3, 0x5, 0x6f, 0x35, 0x11}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0xc4, 0x61, 0x27, 0x82, 0x3f, 0x61, 0x46, 0x10, 0xa2, 0x40, 0xa3, 0x57, 0x20, 0xdf, 0x7a, 0xca, 0x4c, 0x9b, 0xe4, 0x8, 0xd6, 0xe, 0x2f, 0x34, 0xba, 0xaf, 0x33, 0x87, 0x61, 0xd5, 0x8d}),
-				new(big.Int).SetBytes([]byte{0x8f, 0x9e, 0xd9, 0x6c, 0x51, 0x70, 0xe3, 0x7d, 0x14, 0xa4, 0x58, 0xf6, 0x97, 0xf3, 0xc5, 0x5, 0x3f, 0x75, 0x4, 0x78, 0x5e, 0x10, 0x7c, 0x5b, 0x63, 0x8e, 0xa0, 0xba, 0x9d, 0x10, 0x51, 0xa4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x47, 0xf3, 0x38, 0x38, 0x88, 0xa3, 0x64, 0xcc, 0x4a, 0xbf, 0xa3, 0xbc, 0x1d, 0xc, 0xec, 0xcd, 0x22, 0xf1, 0x23, 0x54, 0xfc, 0xe3, 0x99, 0x60, 0x94, 0xf8, 0x69, 0xb8, 0x94, 0x8b, 0x6c, 0x29}),
-				new(big.Int).SetBytes([]byte{0x48, 0xca, 0x9a, 0x8d, 0xf, 0x3, 0x29, 0x37, 0x19, 0xe, 0x48, 0x67, 0x5b, 0x41, 0x6c, 0x71, 0x18, 0xbb, 0x49, 0x95, 0x88, 0xf9, 0x94, 0xa8, 0x1e, 0xde, 0xe1, 0x12, 0xe, 0x53, 0x7e, 0xf9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8, 0xd5, 0x6e, 0x9f, 0x71, 0x2, 0x71, 0xf7, 0xa3, 0x50, 0xc9, 0x93, 0xfe, 0x9a, 0x36, 0x71, 0xf3, 0xf4, 0x7d, 0xb9, 0xf0, 0x13, 0x4a, 0xdc, 0x2d, 0xb0, 0xb3, 0x4, 0x5, 0xb, 0x0, 0x40}),
-				new(big.Int).SetBytes([]byte{0xa1, 0x21, 0x85, 0xae, 0xbd, 0xa, 0x9a, 0xa2, 0x1f, 0xfd, 0x15, 0xa, 0x8d, 0x79, 0xb2, 0x85, 0xf6, 0xd7, 0xc4, 0x72, 0xe, 0x17, 0x82, 0xbe, 0xb5, 0x8e, 0x26, 0x7a, 0x5b, 0x3f, 0xd0, 0xa1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6b, 0x0, 0x40, 0x33, 0x18, 0x81, 0x8d, 0x2b, 0x7c, 0x78, 0x12, 0x5d, 0x8e, 0x48, 0xa, 0x2e, 0xca, 0xee, 0xbf, 0x4a, 0x41, 0xff, 0xf3, 0x6d, 0xa1, 0x4d, 0xea, 0x1a, 0x11, 0x66, 0xff, 0x40}),
-				new(big.Int).SetBytes([]byte{0x41, 0xcd, 0x1b, 0x3a, 0xe8, 0xaa, 0xfa, 0xc9, 0x96, 0x89, 0x43, 0xa0, 0x59, 0xb, 0xf2, 0xf0, 0x7, 0x68, 0x83, 0xf6, 0xe7, 0xf3, 0xed, 0xe9, 0xeb, 0x74, 0x13, 0xe, 0x9d, 0x71, 0xb8, 0x47}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdc, 0x13, 0xf2, 0x32, 0xd4, 0x2f, 0xce, 0x63, 0x41, 0xe2, 0xf, 0x97, 0x7b, 0xe6, 0x5a, 0x37, 0x88, 0x9a, 0x1c, 0x5e, 0xd3, 0xb, 0x62, 0x70, 0xf5, 0x65, 0x63, 0xdf, 0x13, 0x57, 0x3b, 0x7f}),
-				new(big.Int).SetBytes([]byte{0xc9, 0x9, 0xba, 0x80, 0x42, 0x9e, 0x34, 0xc, 0x3c, 0x74, 0x10, 0xda, 0x84, 0xa9, 0xa, 0x76, 0x70, 0x45, 0x54, 0x70, 0x17, 0x40, 0x4b, 0x1c, 0x42, 0xac, 0xd2, 0x28, 0x4c, 0x1f, 0x2b, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc0, 0xc0, 0x1f, 0x34, 0xae, 0x41, 0xb8, 0xcf, 0xe4, 0x66, 0xb4, 0xc9, 0xc6, 0xa5, 0xd5, 0xf6, 0x14, 0xf5, 0x70, 0xd6, 0xfc, 0xbe, 0xf7, 0x68, 0xa8, 0x1a, 0x6c, 0x8f, 0x5, 0xff, 0x4a, 0xdb}),
-				new(big.Int).SetBytes([]byte{0xb, 0x84, 0xf5, 0xbe, 0xe4, 0x35, 0x7f, 0x5c, 0x7c, 0x93, 0x7a, 0xb, 0x40, 0x75, 0xb8, 0xce, 0xcd, 0xbc, 0x43, 0xd1, 0x70, 0xd1, 0x5b, 0x85, 0xfc, 0x4e, 0xff, 0x73, 0xac, 0x35, 0x10, 0x65}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x25, 0xc0, 0x2d, 0xe6, 0x1, 0xdf, 0x7f, 0x7, 0x3a, 0xf1, 0x95, 0x65, 0xdb, 0x21, 0x19, 0xa8, 0x22, 0x6c, 0x21, 0xea, 0x70, 0xef, 0x2d, 0x14, 0xb0, 0x2f, 0x59, 0xb, 0x87, 0xae, 0x9c, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x9f, 0xea, 0xd2, 0xc8, 0x12, 0x38, 0x3c, 0xc, 0x88, 0x4e, 0xd, 0xcf, 0xad, 0x11, 0xd5, 0x37, 0xc6, 0x41, 0xdf, 0xa, 0x65, 0x87, 0x26, 0x47, 0x9, 0xd, 0x13, 0x4b, 0xc6, 0xe2, 0x75}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0x93, 0x2f, 0xbc, 0x46, 0x8e, 0xcb, 0xdb, 0x3d, 0x22, 0x9e, 0xa4, 0x0, 0xb6, 0x3b, 0x93, 0xa2, 0xf3, 0x2b, 0x1a, 0x2, 0x2b, 0xdd, 0x6e, 0x36, 0x50, 0x19, 0xd7, 0x93, 0x2c, 0x1a, 0x1c}),
-				new(big.Int).SetBytes([]byte{0xad, 0x3d, 0x80, 0x54, 0xf, 0x34, 0x57, 0xa8, 0x1, 0xc9, 0x54, 0x7d, 0x4d, 0xc8, 0x1d, 0xa, 0xc0, 0x41, 0x7e, 0x15, 0x74, 0x9a, 0x28, 0xb6, 0x84, 0xaa, 0x92, 0x69, 0x7d, 0x34, 0x35, 0x40}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0xdd, 0x9d, 0x1b, 0x2c, 0xf8, 0xe4, 0x9c, 0xe8, 0xab, 0x9f, 0x4c, 0xa7, 0xae, 0x3e, 0x58, 0xae, 0xce, 0x82, 0xc0, 0x29, 0x59, 0x49, 0xfe, 0x1f, 0x80, 0x98, 0xf6, 0xec, 0x5a, 0x3c, 0x34}),
-				new(big.Int).SetBytes([]byte{0xa5, 0x2e, 0x24, 0xc3, 0x18, 0x53, 0xb8, 0xe0, 0xb0, 0xbe, 0x85, 0x31, 0x2c, 0x8f, 0x4b, 0x0, 0xad, 0xae, 0xb5, 0x46, 0x99, 0xbf, 0x82, 0x97, 0x62, 0xb5, 0x4b, 0xf4, 0xe1, 0x3d, 0x77, 0x14}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc3, 0x33, 0x73, 0x43, 0x45, 0x1c, 0xb8, 0x3c, 0xa3, 0x52, 0x1e, 0x92, 0x76, 0xb3, 0x46, 0xb9, 0x46, 0xbd, 0x3, 0x3e, 0x98, 0x24, 0xfc, 0xbb, 0x4, 0x52, 0x10, 0x6b, 0x80, 0xd6, 0xbf, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x2e, 0xef, 0xe9, 0x39, 0x1b, 0x81, 0x2c, 0xda, 0x13, 0xdf, 0xf3, 0x1a, 0xf7, 0x2c, 0x31, 0x55, 0x98, 0xba, 0x36, 0x42, 0xe, 0x26, 0x61, 0x62, 0xf9, 0x5e, 0x58, 0x42, 0x3c, 0xb0, 0x1f, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0xe, 0x32, 0x86, 0xbc, 0x6e, 0xa4, 0x8c, 0x2d, 0xee, 0x2d, 0x7f, 0x5e, 0x84, 0x88, 0xb3, 0x73, 0x2, 0x1b, 0x91, 0xa1, 0x13, 0x60, 0x76, 0x9b, 0x4e, 0x54, 0xd1, 0xaa, 0xb6, 0xa3, 0x96}),
-				new(big.Int).SetBytes([]byte{0xca, 0x44, 0x81, 0x72, 0xfa, 0x3b, 0x37, 0x96, 0x4f, 0x31, 0xfe, 0x1d, 0xeb, 0x45, 0x3f, 0xc6, 0x6a, 0xb0, 0x10, 0x41, 0xf3, 0x46, 0x8f, 0x6a, 0x2e, 0xb3, 0x1e, 0x48, 0xd9, 0x80, 0xe2, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0x62, 0x87, 0x3d, 0x3e, 0xa8, 0x3e, 0x25, 0x3c, 0x3c, 0x7c, 0xd5, 0x37, 0xed, 0x6d, 0x17, 0xd7, 0xca, 0x75, 0xdb, 0xee, 0x54, 0x67, 0x7e, 0x27, 0xbf, 0x4c, 0x48, 0xda, 0xf0, 0x27, 0xe9}),
-				new(big.Int).SetBytes([]byte{0xf6, 0xd9, 0x44, 0x79, 0xa5, 0xbf, 0x6a, 0xfd, 0xa7, 0x64, 0x9, 0xcf, 0xee, 0xc5, 0x7a, 0x43, 0x98, 0x8a, 0xf4, 0x30, 0x9c, 0xa6, 0x75, 0xfb, 0x31, 0x99, 0xd9, 0x5, 0x84, 0x4c, 0x68, 0xb4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x34, 0x4a, 0xb9, 0x30, 0x80, 0xc3, 0x2d, 0x28, 0x49, 0x82, 0xe0, 0x20, 0x9, 0x82, 0xb9, 0xaf, 0x56, 0x45, 0xd8, 0xf7, 0x6f, 0xec, 0xf5, 0x70, 0xaa, 0xf3, 0x58, 0x62, 0x34, 0x10, 0x23, 0xec}),
-				new(big.Int).SetBytes([]byte{0x6e, 0x1c, 0x2b, 0x4, 0x2b, 0x24, 0x46, 0x2a, 0x73, 0xf8, 0xf2, 0xd1, 0xea, 0x51, 0x4c, 0x5f, 0xfc, 0x4e, 0x97, 0x6a, 0xfc, 0x39, 0x95, 0xd7, 0x4, 0xf8, 0x98, 0x29, 0x1e, 0x1e, 0xeb, 0x87}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd8, 0x95, 0x62, 0x65, 0x48, 0xb6, 0x5b, 0x81, 0xe2, 0x64, 0xc7, 0x63, 0x7c, 0x97, 0x28, 0x77, 0xd1, 0xd7, 0x2e, 0x5f, 0x3a, 0x92, 0x50, 0x14, 0x37, 0x2e, 0x9f, 0x65, 0x88, 0xf6, 0xc1, 0x4b}),
-				new(big.Int).SetBytes([]byte{0xfe, 0xbf, 0xaa, 0x38, 0xf2, 0xbc, 0x7e, 0xae, 0x72, 0x8e, 0xc6, 0x8, 0x18, 0xc3, 0x40, 0xeb, 0x3, 0x42, 0x8d, 0x63, 0x2b, 0xb0, 0x67, 0xe1, 0x79, 0x36, 0x3e, 0xd7, 0x5d, 0x7d, 0x99, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x13, 0x6e, 0xef, 0x89, 0x71, 0x4, 0x4e, 0x8a, 0x3a, 0x43, 0x62, 0x20, 0x3, 0xa2, 0x67, 0x3, 0xec, 0xaf, 0x7a, 0xe, 0xc4, 0xc, 0x3f, 0xba, 0x5b, 0x59, 0x4b, 0x77, 0x7, 0x84, 0x24}),
-				new(big.Int).SetBytes([]byte{0x21, 0x8d, 0xa8, 0x34, 0xf3, 0xc6, 0x52, 0xcc, 0x67, 0xa1, 0xd1, 0x91, 0xb5, 0xc5, 0xef, 0xa5, 0x7c, 0xf2, 0xb1, 0xf7, 0x8a, 0x2a, 0xdf, 0xa8, 0xcd, 0x61, 0xee, 0xef, 0xc6, 0x71, 0xdd, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6d, 0x8c, 0x78, 0x2f, 0x71, 0x6d, 0xf1, 0x26, 0x72, 0xd3, 0x62, 0xda, 0x50, 0x60, 0xb4, 0x16, 0x3, 0x42, 0x8b, 0xe4, 0xab, 0xa0, 0x97, 0x4, 0x80, 0xba, 0x87, 0xff, 0x61, 0x27, 0xb7, 0x56}),
-				new(big.Int).SetBytes([]byte{0x99, 0xae, 0xdf, 0x8, 0x96, 0xfd, 0xb9, 0x11, 0x14, 0x59, 0xa8, 0x2d, 0x36, 0xd3, 0x4d, 0xaf, 0x4e, 0x3a, 0xa6, 0xda, 0x2d, 0x7c, 0xdc, 0xca, 0x65, 0x51, 0xf7, 0x4a, 0xbf, 0x17, 0x25, 0x71}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd9, 0x9e, 0x8e, 0x9d, 0xd9, 0x63, 0x8d, 0x14, 0xe, 0x9c, 0xca, 0x53, 0x67, 0x51, 0x9f, 0x86, 0x1b, 0x70, 0x3, 0xa0, 0xd4, 0x3f, 0x2, 0x4a, 0x5f, 0x1d, 0x84, 0xec, 0x8d, 0xb1, 0xcb, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x36, 0xdc, 0x19, 0xad, 0x1c, 0xc0, 0xa3, 0xa7, 0xa9, 0x45, 0xbb, 0x32, 0x1b, 0xce, 0xba, 0x6e, 0x62, 0x86, 0xfe, 0xf8, 0xff, 0xc8, 0x76, 0x5c, 0xd8, 0x8a, 0x29, 0xe3, 0x6b, 0x86, 0x37, 0xa7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0xca, 0xbe, 0xdd, 0x95, 0xbf, 0x7a, 0xca, 0x8d, 0x5, 0x9a, 0xef, 0x1b, 0x40, 0x97, 0xf8, 0xf1, 0xf8, 0x5d, 0xcb, 0xa5, 0x88, 0x23, 0x52, 0x4d, 0xee, 0x1a, 0x73, 0x75, 0x8c, 0xf1, 0x7a}),
-				new(big.Int).SetBytes([]byte{0x47, 0xd3, 0xce, 0xf, 0x8f, 0x22, 0xb9, 0xcb, 0x2, 0xc0, 0xa, 0x1b, 0x67, 0xc3, 0x2e, 0x6b, 0x10, 0xfa, 0xd2, 0x12, 0xd0, 0xac, 0xe9, 0x5c, 0x44, 0x6c, 0xdc, 0x5f, 0x5c, 0xaa, 0xc, 0xcd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x56, 0xc9, 0xda, 0x94, 0x67, 0xca, 0xcd, 0x5b, 0x48, 0xcc, 0x1f, 0x2b, 0x4a, 0xae, 0x67, 0x14, 0xd, 0xf7, 0x1, 0xca, 0x8e, 0xce, 0xe2, 0x58, 0xe4, 0xc4, 0xb2, 0xc5, 0x51, 0xa3, 0xc4, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x38, 0xd4, 0x6a, 0xcb, 0x42, 0xc7, 0x9e, 0xc2, 0xf3, 0x3c, 0x79, 0x64, 0xdd, 0xcb, 0x68, 0x52, 0x41, 0xd4, 0xf, 0xf9, 0x32, 0xed, 0xca, 0xa1, 0x82, 0x4d, 0x8b, 0x7d, 0x20, 0xd7, 0xc9, 0xed}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe8, 0xdf, 0x4d, 0x2e, 0x4b, 0xd4, 0xce, 0x24, 0x45, 0x33, 0x1a, 0x36, 0x9e, 0x17, 0xfc, 0x28, 0x11, 0x85, 0x62, 0x1a, 0x1, 0x7c, 0x8a, 0xfb, 0xbc, 0xee, 0xe5, 0x15, 0xac, 0x85, 0x5c, 0x5b}),
-				new(big.Int).SetBytes([]byte{0x6c, 0x57, 0xfd, 0x70, 0xc4, 0x7f, 0x9b, 0x26, 0x44, 0x44, 0xdb, 0x4f, 0x59, 0xef, 0x32, 0xc5, 0x96, 0x63, 0xb5, 0x5f, 0x1e, 0x1e, 0x4d, 0x7d, 0x59, 0xd5, 0xe7, 0x2c, 0x2e, 0x46, 0x56, 0x50}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0xfd, 0xf1, 0x61, 0x9a, 0x19, 0x83, 0x17, 0xa1, 0xbd, 0x8a, 0x54, 0xe5, 0xb0, 0x91, 0x91, 0xd2, 0x3, 0x35, 0x1e, 0x4, 0x40, 0xe6, 0x36, 0xfd, 0x46, 0xf6, 0x8d, 0x3c, 0x38, 0x51, 0x72}),
-				new(big.Int).SetBytes([]byte{0x40, 0x8d, 0x2, 0xc0, 0x6e, 0x5c, 0x12, 0xc3, 0xfe, 0x47, 0xc, 0x7d, 0x3c, 0x85, 0x73, 0x75, 0x5b, 0x9b, 0x92, 0x9e, 0x90, 0xe7, 0x23, 0x2b, 0x79, 0xac, 0x67, 0xf0, 0xfc, 0xcb, 0x97, 0x94}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x40, 0x68, 0xd3, 0xd7, 0x18, 0xec, 0xa8, 0x32, 0x91, 0x24, 0x86, 0x2, 0x3a, 0x98, 0x16, 0x62, 0xfe, 0x32, 0xef, 0xef, 0xf, 0xe, 0x61, 0x50, 0xd0, 0x8f, 0x5b, 0xda, 0x52, 0xe7, 0xe4, 0x54}),
-				new(big.Int).SetBytes([]byte{0x8c, 0xd8, 0x53, 0xb7, 0x25, 0x4a, 0x47, 0xaa, 0xb9, 0xec, 0x14, 0x59, 0x7d, 0x9d, 0x38, 0xd1, 0x98, 0x8d, 0x21, 0xed, 0x13, 0xc3, 0x73, 0x32, 0x77, 0x9a, 0xcd, 0xe5, 0xd8, 0xef, 0x19, 0x1e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x33, 0xea, 0xc9, 0xbb, 0xa2, 0xc3, 0x55, 0xce, 0x56, 0xe1, 0x1, 0xf9, 0xaf, 0xa0, 0xb7, 0xa0, 0xcd, 0x47, 0x80, 0xc2, 0x51, 0x16, 0x91, 0xeb, 0x40, 0xc, 0x1f, 0xf5, 0x35, 0xd8, 0x24, 0x7e}),
-				new(big.Int).SetBytes([]byte{0x35, 0x97, 0x31, 0x73, 0xe4, 0xe1, 0x7c, 0x41, 0xd4, 0x46, 0x1f, 0x2a, 0xfd, 0x15, 0xef, 0x2d, 0x84, 0xf8, 0xcf, 0x37, 0xb7, 0xea, 0x40, 0x7f, 0x37, 0x21, 0x6a, 0xb0, 0xaf, 0x3d, 0xa9, 0xbf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0x55, 0xe, 0xcd, 0xb1, 0xd8, 0x25, 0xe0, 0xb5, 0x5b, 0xf3, 0x2e, 0x60, 0xca, 0x2, 0x7e, 0xa4, 0x75, 0xa2, 0x87, 0x7b, 0xa9, 0x33, 0xde, 0x8, 0x57, 0xad, 0xf8, 0x46, 0xc5, 0xd9, 0x39}),
-				new(big.Int).SetBytes([]byte{0x7b, 0x8f, 0x83, 0x34, 0xf8, 0x72, 0xd7, 0xa7, 0x54, 0x48, 0xe4, 0x3c, 0x17, 0x72, 0x4a, 0x72, 0x83, 0x8b, 0x74, 0x1f, 0xec, 0xd8, 0xd4, 0x31, 0x92, 0xa7, 0x4f, 0xc5, 0x59, 0xe7, 0x57, 0xc9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb7, 0x1e, 0x54, 0x6d, 0x92, 0x2d, 0xc9, 0x2, 0x9d, 0x32, 0xc2, 0xb3, 0xc0, 0x1f, 0x87, 0x50, 0x33, 0x5a, 0x52, 0x11, 0xee, 0x9, 0x4c, 0x87, 0xb9, 0xe2, 0x49, 0xd0, 0x2d, 0xf7, 0x71, 0xc8}),
-				new(big.Int).SetBytes([]byte{0xe7, 0xcc, 0xef, 0xa8, 0xce, 0xc9, 0xf9, 0x0, 0x78, 0x91, 0x2a, 0x4c, 0xcc, 0xe2, 0x88, 0xf4, 0x26, 0xbd, 0x52, 0x2b, 0x55, 0xbb, 0xa2, 0x86, 0x24, 0x53, 0xa2, 0x6f, 0x93, 0xf4, 0x8c, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x94, 0x4d, 0x67, 0x1a, 0xc3, 0xb5, 0x85, 0xd4, 0xc6, 0x9b, 0xb7, 0xb5, 0x3a, 0xfa, 0xd0, 0x8f, 0x85, 0x9, 0x3c, 0x54, 0x34, 0xf3, 0x74, 0x21, 0x38, 0xd0, 0xaa, 0xc6, 0x56, 0xc8, 0xa5, 0x6f}),
-				new(big.Int).SetBytes([]byte{0xa7, 0x10, 0x65, 0x97, 0x7b, 0x50, 0x1f, 0xff, 0x4c, 0x14, 0x39, 0x36, 0x13, 0x3a, 0xc6, 0x19, 0x67, 0xd4, 0xc1, 0x74, 0xdd, 0x6, 0x51, 0x7e, 0xb9, 0xf4, 0xdc, 0x65, 0xda, 0x7b, 0xe2, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfa, 0xcf, 0x5d, 0x96, 0xba, 0x15, 0x26, 0xb8, 0x61, 0x22, 0x39, 0x66, 0x6d, 0x87, 0xa1, 0x9e, 0x85, 0xf, 0xea, 0x94, 0xba, 0xa7, 0xfe, 0xa2, 0x68, 0xf7, 0x30, 0x92, 0xd3, 0x19, 0x44, 0x44}),
-				new(big.Int).SetBytes([]byte{0x7c, 0xeb, 0x90, 0x7b, 0xb8, 0xad, 0x44, 0xf6, 0x93, 0x5b, 0x77, 0x56, 0x39, 0x2d, 0xda, 0xc6, 0xbe, 0x72, 0xb2, 0x45, 0xb9, 0x47, 0xd0, 0xb, 0x55, 0x2b, 0xf4, 0x88, 0x44, 0xba, 0xd6, 0x76}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x86, 0xa5, 0x4e, 0x91, 0xcd, 0x9, 0x9a, 0xc, 0xfe, 0x6b, 0x3, 0x65, 0x5a, 0xd3, 0xc3, 0x5, 0xfd, 0x69, 0xe5, 0x6d, 0xc8, 0x12, 0x90, 0xb, 0xae, 0xeb, 0x8a, 0x18, 0xae, 0x8, 0xf1, 0x5a}),
-				new(big.Int).SetBytes([]byte{0xcf, 0xee, 0x61, 0x48, 0x37, 0xa7, 0x84, 0x60, 0x3a, 0x72, 0xa8, 0xd0, 0x6d, 0x75, 0x68, 0x67, 0x77, 0x12, 0x9d, 0x12, 0x5b, 0x35, 0x25, 0x4d, 0x35, 0x24, 0xd1, 0xe7, 0xc1, 0x38, 0x83, 0x8}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0xda, 0x94, 0x3, 0x2a, 0x95, 0x75, 0x18, 0xeb, 0xf, 0x64, 0x33, 0x57, 0x1e, 0x87, 0x61, 0xce, 0xff, 0xc7, 0x36, 0x93, 0xe8, 0x4e, 0xdd, 0x49, 0x15, 0xa, 0x56, 0x4f, 0x67, 0x6e, 0x3}),
-				new(big.Int).SetBytes([]byte{0x28, 0x4, 0xdf, 0xa4, 0x48, 0x5, 0xa1, 0xe4, 0xd7, 0xc9, 0x9c, 0xc9, 0x76, 0x28, 0x8, 0xb0, 0x92, 0xcc, 0x58, 0x4d, 0x95, 0xff, 0x3b, 0x51, 0x14, 0x88, 0xe4, 0xe7, 0x4e, 0xfd, 0xf6, 0xe7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6d, 0x36, 0xd1, 0x5, 0xed, 0x8c, 0xc5, 0xce, 0x53, 0xf2, 0xcb, 0x69, 0x8a, 0xb6, 0x20, 0xf9, 0x46, 0x9a, 0x3e, 0x5c, 0xb2, 0x5b, 0xf6, 0xe6, 0xd4, 0x13, 0xf4, 0x14, 0xc5, 0xaf, 0x72, 0x6a}),
-				new(big.Int).SetBytes([]byte{0xe4, 0xba, 0x5c, 0x34, 0xe3, 0x77, 0x66, 0x9e, 0x72, 0xd8, 0xc6, 0x6c, 0x95, 0xc5, 0x0, 0x29, 0xdc, 0xc5, 0x99, 0x36, 0xb4, 0x10, 0x8a, 0x35, 0xc5, 0x70, 0x49, 0x1a, 0x13, 0xf9, 0xfc, 0x7d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6, 0x90, 0x68, 0xff, 0x9, 0x82, 0xd1, 0xb, 0xe5, 0x4c, 0x76, 0x1f, 0x14, 0xd1, 0x52, 0xc0, 0x5a, 0xbe, 0x7b, 0x10, 0x38, 0x5a, 0xf1, 0xc5, 0xea, 0x19, 0x84, 0x9d, 0xc6, 0xe1, 0x34, 0x6b}),
-				new(big.Int).SetBytes([]byte{0xb8, 0x63, 0xe3, 0xe0, 0x90, 0xbf, 0xde, 0x26, 0xda, 0x85, 0xdb, 0x2b, 0xd0, 0x86, 0x44, 0x2a, 0xa4, 0xf6, 0x89, 0x39, 0x94, 0xc6, 0x2, 0x6e, 0x7b, 0xb5, 0x8a, 0x54, 0xd7, 0x22, 0x6c, 0x13}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0xb6, 0xbd, 0xe1, 0xc, 0xd3, 0xac, 0xc, 0xd0, 0x68, 0x83, 0xfa, 0x66, 0xf0, 0xb0, 0xe3, 0xeb, 0x13, 0x9, 0xc0, 0x53, 0x4b, 0x81, 0x22, 0x86, 0xe2, 0xa3, 0xc, 0xa5, 0x40, 0xdb, 0x99}),
-				new(big.Int).SetBytes([]byte{0xba, 0xca, 0x62, 0x7, 0x9b, 0xe8, 0x71, 0xd7, 0xfc, 0x31, 0x17, 0xa9, 0x6a, 0x13, 0xe9, 0x9c, 0x38, 0xd1, 0x37, 0xb0, 0xe3, 0x69, 0xc0, 0x43, 0xe6, 0x87, 0x3f, 0xe3, 0x1b, 0xda, 0x78, 0xa3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x8c, 0x34, 0x93, 0xcb, 0x25, 0x97, 0x61, 0x52, 0x86, 0xdc, 0x5c, 0xb1, 0xe8, 0x6c, 0xe1, 0x6, 0x8c, 0xbd, 0x14, 0x34, 0x8c, 0xff, 0x1a, 0x30, 0xe6, 0x91, 0xfc, 0xdc, 0xa1, 0xf6, 0xa1}),
-				new(big.Int).SetBytes([]byte{0x75, 0xf7, 0x59, 0x86, 0xab, 0x56, 0xa5, 0x54, 0x9d, 0x84, 0x54, 0x24, 0x52, 0xac, 0x6e, 0x93, 0xb2, 0xa7, 0xcf, 0x97, 0x9f, 0x2b, 0xd7, 0x9c, 0xa4, 0xad, 0xc2, 0xf, 0x16, 0x4f, 0x64, 0x7c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6, 0x3c, 0x46, 0x24, 0x35, 0xef, 0x97, 0x4b, 0x39, 0x3b, 0x5, 0xb3, 0x7d, 0x1c, 0x89, 0xd7, 0xb, 0xd, 0x89, 0x58, 0xeb, 0xd5, 0x41, 0xd7, 0x58, 0x4e, 0x2b, 0xbc, 0x72, 0x35, 0xc7, 0x95}),
-				new(big.Int).SetBytes([]byte{0xe2, 0x7f, 0x9b, 0xb9, 0x13, 0x3, 0x84, 0x4, 0x43, 0x1f, 0x66, 0xd, 0x93, 0x1c, 0x85, 0xb6, 0x9e, 0x4a, 0xcb, 0xac, 0xfa, 0x49, 0xa9, 0xbd, 0x3f, 0x32, 0xdc, 0x9, 0x5b, 0x11, 0x2, 0x58}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb2, 0x13, 0xe2, 0xfe, 0xd2, 0x91, 0x8b, 0xf0, 0x1a, 0x52, 0x99, 0xd7, 0x2, 0x2a, 0x27, 0x4e, 0x5e, 0x56, 0xc8, 0xb9, 0x17, 0xa0, 0x43, 0x28, 0xaa, 0x69, 0xe0, 0x3c, 0x3d, 0x1e, 0x39, 0x98}),
-				new(big.Int).SetBytes([]byte{0x22, 0x9f, 0x8e, 0xc2, 0xf, 0x2d, 0x3c, 0x12, 0xc3, 0xd6, 0x1e, 0xbf, 0x83, 0xa4, 0x3b, 0xc3, 0xd5, 0x34, 0x16, 0x5b, 0xeb, 0xde, 0xd1, 0x75, 0xec, 0x2c, 0xbd, 0xc6, 0x32, 0x5f, 0xb8, 0x1e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x79, 0x66, 0x34, 0xe3, 0xf1, 0xad, 0x56, 0xf0, 0xfd, 0xba, 0x6, 0x9d, 0x9d, 0x7, 0xbc, 0xe2, 0xba, 0x2f, 0xd4, 0xf3, 0x73, 0xdd, 0xd3, 0xba, 0x77, 0x77, 0xbf, 0x27, 0x9f, 0x10, 0x48, 0xda}),
-				new(big.Int).SetBytes([]byte{0x4d, 0x8e, 0xe2, 0xb6, 0xcf, 0xb2, 0xb, 0x89, 0x56, 0xde, 0x74, 0x73, 0x5a, 0x79, 0x27, 0xf2, 0x53, 0x25, 0x76, 0xd8, 0xcf, 0xd7, 0x48, 0x62, 0xe8, 0xf9, 0xbe, 0x24, 0xa1, 0x6, 0xcf, 0x1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x3b, 0x3a, 0xd8, 0x16, 0xc7, 0xf9, 0x3e, 0x5d, 0x1d, 0xb, 0xa6, 0x91, 0x60, 0x6e, 0x6, 0xe, 0x83, 0xe3, 0xe7, 0xd, 0xdf, 0x35, 0x7, 0x0, 0xbe, 0x1f, 0xec, 0xa2, 0x5b, 0xe2, 0x34}),
-				new(big.Int).SetBytes([]byte{0x5e, 0xec, 0x2, 0x3b, 0x85, 0xda, 0xbc, 0x9c, 0x78, 0x75, 0xe9, 0x6d, 0xfd, 0x8c, 0xc0, 0x4c, 0x41, 0xe7, 0xfd, 0x92, 0xcf, 0x21, 0x1b, 0x84, 0x6, 0x3b, 0x7e, 0x3, 0x92, 0xe, 0x83, 0x62}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc2, 0x99, 0xc6, 0xb0, 0x6e, 0x6c, 0x78, 0xae, 0x85, 0x2b, 0xd5, 0x5c, 0xde, 0xa3, 0x5f, 0x99, 0xd2, 0x64, 0xcb, 0x5a, 0xe8, 0x36, 0xb7, 0x7d, 0xab, 0x20, 0x9a, 0xc9, 0xc0, 0x52, 0x1, 0xe1}),
-				new(big.Int).SetBytes([]byte{0x5a, 0xa7, 0x39, 0x92, 0x36, 0x11, 0x80, 0x5b, 0x38, 0x88, 0x4f, 0x24, 0xfd, 0x77, 0x4c, 0xd7, 0xbd, 0x74, 0xdc, 0xf5, 0x83, 0xac, 0x2a, 0xe9, 0xad, 0xd3, 0x31, 0x3a, 0x50, 0xf5, 0x1b, 0xa3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9f, 0x7b, 0x88, 0xb6, 0xdd, 0xb0, 0x4f, 0x96, 0x2, 0x2, 0x51, 0xb0, 0x27, 0x89, 0x65, 0xbe, 0x29, 0x76, 0xb0, 0xf0, 0xe2, 0xc7, 0x67, 0x7, 0xb9, 0x66, 0x34, 0xa7, 0xe2, 0x89, 0xf5, 0x5e}),
-				new(big.Int).SetBytes([]byte{0x32, 0xf9, 0xf7, 0x84, 0xc7, 0x4, 0x10, 0xf7, 0xbe, 0x31, 0x46, 0xb4, 0x29, 0x8f, 0xc7, 0x1f, 0xc0, 0xe5, 0x36, 0xe0, 0xeb, 0x10, 0xa2, 0xb1, 0xb3, 0xcd, 0x3b, 0x65, 0xfe, 0x1e, 0x4b, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x31, 0xae, 0x98, 0xfb, 0x35, 0x60, 0x71, 0x59, 0x8a, 0xb6, 0xc1, 0x8a, 0x70, 0x63, 0x5c, 0x1a, 0xca, 0xb8, 0x79, 0x3, 0x3d, 0x56, 0x4c, 0xfd, 0x37, 0xa7, 0xf1, 0x6c, 0x1c, 0xba, 0xab}),
-				new(big.Int).SetBytes([]byte{0x41, 0x51, 0x96, 0xb4, 0xe9, 0x54, 0x58, 0x4e, 0x8e, 0x6e, 0x4c, 0x6, 0xad, 0xcb, 0x4d, 0xcf, 0x6d, 0xfb, 0x4a, 0x4f, 0xdb, 0xd6, 0x5f, 0xdc, 0x4c, 0x39, 0x4, 0x7f, 0x85, 0x11, 0x6b, 0x26}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd5, 0x8a, 0x43, 0xe9, 0xcb, 0x74, 0x48, 0xe3, 0xe7, 0x6c, 0xca, 0xdf, 0xd3, 0xfb, 0x73, 0xa4, 0xc4, 0x93, 0xf5, 0x9, 0xe5, 0x68, 0x78, 0xe5, 0x16, 0x54, 0x9c, 0x8c, 0x1d, 0xfe, 0x1d, 0x2b}),
-				new(big.Int).SetBytes([]byte{0xfc, 0x17, 0x86, 0x6b, 0xa0, 0x58, 0x83, 0xcf, 0x10, 0xa7, 0x29, 0x13, 0x28, 0x28, 0x27, 0x6b, 0x54, 0xd9, 0xba, 0x21, 0x5c, 0xbd, 0x6f, 0xbf, 0xfc, 0xf2, 0x79, 0x5b, 0x8c, 0xd5, 0x9, 0x22}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0xe8, 0x5a, 0xd8, 0x6a, 0x95, 0x35, 0x64, 0x39, 0xb9, 0x79, 0x57, 0x7b, 0xed, 0xd0, 0xe9, 0x6e, 0x2e, 0xed, 0x72, 0x76, 0xba, 0x26, 0x9a, 0x62, 0x6f, 0xbd, 0x58, 0x44, 0x79, 0x96, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x74, 0x8, 0xb0, 0xae, 0xb4, 0x24, 0x49, 0x3e, 0xfb, 0x21, 0x7e, 0x5c, 0xe0, 0xe, 0xd5, 0x5a, 0x21, 0xb6, 0x42, 0xad, 0x9c, 0x56, 0x9d, 0x54, 0x48, 0x88, 0xbc, 0x64, 0xb8, 0x15, 0x1d, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x21, 0xd2, 0x71, 0x39, 0x71, 0x11, 0x83, 0x10, 0xcd, 0x6d, 0x96, 0x9c, 0x3c, 0x27, 0xea, 0x4e, 0xed, 0x44, 0x6, 0xaa, 0x99, 0xce, 0x80, 0x5e, 0x12, 0x41, 0x51, 0xbe, 0x24, 0x9c, 0x79, 0x5e}),
-				new(big.Int).SetBytes([]byte{0x94, 0xc5, 0xf9, 0xb4, 0xb0, 0x75, 0xac, 0xea, 0x17, 0x73, 0x5b, 0x85, 0x52, 0x2b, 0xb4, 0xc3, 0xd6, 0x71, 0x94, 0x4a, 0x4a, 0xdf, 0xb7, 0x61, 0xb0, 0x1, 0x2e, 0xc3, 0x99, 0x20, 0x8e, 0xce}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe8, 0xf, 0xea, 0x14, 0x44, 0x1f, 0xb3, 0x3a, 0x7d, 0x8a, 0xda, 0xb9, 0x47, 0x5d, 0x7f, 0xab, 0x20, 0x19, 0xef, 0xfb, 0x51, 0x56, 0xa7, 0x92, 0xf1, 0xa1, 0x17, 0x78, 0xe3, 0xc0, 0xdf, 0x5d}),
-				new(big.Int).SetBytes([]byte{0xee, 0xd1, 0xde, 0x7f, 0x63, 0x8e, 0x0, 0x77, 0x1e, 0x89, 0x76, 0x8c, 0xa3, 0xca, 0x94, 0x47, 0x2d, 0x15, 0x5e, 0x80, 0xaf, 0x32, 0x2e, 0xa9, 0xfc, 0xb4, 0x29, 0x1b, 0x6a, 0xc9, 0xec, 0x78}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x44, 0xc, 0xa1, 0xf0, 0x8e, 0xa4, 0x12, 0x65, 0x98, 0x1a, 0xc4, 0xed, 0x1e, 0xfe, 0x7a, 0x37, 0x12, 0x2d, 0xcc, 0x38, 0x77, 0xd2, 0xf9, 0x16, 0x2d, 0xb0, 0xe7, 0x8b, 0xf, 0x83, 0xcd, 0x58}),
-				new(big.Int).SetBytes([]byte{0xa6, 0xc8, 0xb0, 0xd2, 0xcd, 0x5e, 0xe1, 0x22, 0xaf, 0x89, 0x54, 0xdc, 0x9d, 0x4e, 0x2f, 0x2, 0xa2, 0x1e, 0x4d, 0x42, 0x69, 0xc0, 0xa2, 0x60, 0xb0, 0x7b, 0xc0, 0x69, 0xb8, 0x8a, 0x3f, 0x4b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5d, 0x2e, 0xc6, 0xdb, 0xc4, 0xa1, 0x5, 0x26, 0x8, 0xaf, 0xef, 0x69, 0x63, 0x3c, 0xe3, 0xb1, 0x6, 0xd5, 0xb9, 0x47, 0x40, 0xe3, 0x50, 0x19, 0x2c, 0x3, 0x59, 0xec, 0xd7, 0x59, 0x2d, 0x55}),
-				new(big.Int).SetBytes([]byte{0xa, 0x92, 0xcd, 0xf8, 0x9c, 0x6e, 0x45, 0xeb, 0x58, 0xf7, 0x10, 0xfa, 0x26, 0x8c, 0xd6, 0x95, 0xe, 0x2a, 0x7b, 0xcd, 0xad, 0x11, 0x51, 0x74, 0x26, 0x6e, 0x5d, 0xe, 0xaf, 0x51, 0x83, 0xa7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf6, 0x94, 0xcb, 0xaf, 0x2b, 0x96, 0x6c, 0x1c, 0xc5, 0xf7, 0xf8, 0x29, 0xd3, 0xa9, 0x7, 0x81, 0x9b, 0xc7, 0xe, 0xbc, 0xc1, 0xb2, 0x29, 0xd9, 0xe8, 0x1b, 0xda, 0x27, 0x12, 0x99, 0x8b, 0x10}),
-				new(big.Int).SetBytes([]byte{0x40, 0xa6, 0x3e, 0xba, 0x61, 0xbe, 0xf0, 0x3d, 0x63, 0x3c, 0x5f, 0xfa, 0xcc, 0x46, 0xd8, 0x2a, 0xeb, 0x6c, 0x64, 0xc3, 0x18, 0x3c, 0x2a, 0x47, 0xf6, 0x78, 0x8b, 0x17, 0x0, 0xf0, 0x5e, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0x37, 0x1d, 0xc3, 0xb1, 0x1b, 0xf7, 0x42, 0xfe, 0x2c, 0xae, 0x34, 0x21, 0x5f, 0x40, 0x4d, 0x73, 0x61, 0xf1, 0xe1, 0x59, 0x88, 0xa, 0x51, 0x99, 0x1f, 0x4b, 0x49, 0xfe, 0x8f, 0x9f, 0x5c}),
-				new(big.Int).SetBytes([]byte{0xc5, 0x16, 0x16, 0xc1, 0x87, 0x9, 0xa4, 0x77, 0xff, 0x21, 0x1, 0xe7, 0x32, 0x54, 0xe7, 0x35, 0x22, 0x95, 0x34, 0x58, 0xee, 0x75, 0x1e, 0x1d, 0x17, 0xa8, 0x3f, 0xf3, 0x32, 0x5a, 0x50, 0x3c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x62, 0x78, 0x28, 0x99, 0xce, 0xb9, 0x6c, 0xc8, 0xe5, 0xdd, 0x3c, 0x25, 0x22, 0x3c, 0xae, 0x4f, 0xd9, 0x64, 0xc6, 0xed, 0x57, 0xd, 0x90, 0x27, 0xa7, 0x44, 0xb8, 0xf8, 0xe5, 0x5b, 0xf8, 0x4c}),
-				new(big.Int).SetBytes([]byte{0xd6, 0x70, 0xac, 0xa4, 0x9, 0x11, 0xa2, 0x89, 0x1, 0xc5, 0x49, 0x72, 0x29, 0x72, 0x67, 0x83, 0x9a, 0x2, 0xd, 0xe7, 0xdd, 0xd1, 0x76, 0xed, 0x6f, 0x51, 0xcf, 0xab, 0x5f, 0x15, 0xfa, 0x2a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x42, 0x0, 0x3a, 0x14, 0xf1, 0x84, 0xc, 0x93, 0x48, 0xc0, 0xbd, 0x2, 0x22, 0xa1, 0x7a, 0x40, 0x89, 0x3b, 0xc4, 0x52, 0xaf, 0x38, 0x5d, 0xf4, 0xa1, 0xc8, 0xd5, 0x19, 0xe3, 0x34, 0x46}),
-				new(big.Int).SetBytes([]byte{0xa9, 0xfd, 0x3, 0x95, 0x95, 0xa5, 0x7, 0x7a, 0x4d, 0x8b, 0x2d, 0x6d, 0xe, 0xfd, 0xe4, 0xe0, 0x6b, 0xfd, 0x77, 0x36, 0x79, 0xe7, 0x4f, 0x98, 0x40, 0xd3, 0x8a, 0x0, 0xe6, 0x38, 0x76, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8b, 0x6e, 0x86, 0x2a, 0x35, 0x56, 0x68, 0x48, 0x50, 0xb6, 0xd4, 0xf4, 0x39, 0xa2, 0x59, 0x50, 0x47, 0xab, 0xf6, 0x95, 0xc0, 0x8b, 0x64, 0x14, 0xf9, 0x5a, 0x13, 0x35, 0x8d, 0xd5, 0x53, 0xfd}),
-				new(big.Int).SetBytes([]byte{0xea, 0x5e, 0x8, 0x91, 0xe, 0xd1, 0x1c, 0xb4, 0xd, 0x10, 0xbc, 0x2d, 0xf4, 0xeb, 0x9f, 0xa1, 0x24, 0xac, 0x3c, 0x5a, 0x18, 0x33, 0x83, 0xd0, 0xd8, 0x3, 0xda, 0xd3, 0x3e, 0x9b, 0xe5, 0xed}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x27, 0x70, 0x26, 0x6b, 0x30, 0xa3, 0x42, 0xa8, 0xa2, 0xcd, 0xa4, 0x4f, 0xf5, 0x50, 0x5c, 0x98, 0x99, 0xeb, 0x41, 0x9c, 0x1, 0x3b, 0xd6, 0xd0, 0x3d, 0x72, 0x8c, 0x95, 0xb9, 0x13, 0xcb, 0x26}),
-				new(big.Int).SetBytes([]byte{0xf6, 0x49, 0xbc, 0x5e, 0x79, 0xb0, 0xdb, 0x1a, 0x55, 0x52, 0xd2, 0x4d, 0x7, 0xf2, 0xb3, 0x24, 0xe9, 0x40, 0xc9, 0x1b, 0xcc, 0x81, 0x5b, 0xeb, 0xbf, 0xe7, 0x17, 0x33, 0xf3, 0x48, 0xa7, 0xa2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0xad, 0xf0, 0x6e, 0x24, 0x84, 0x4c, 0x9, 0x32, 0xd9, 0xe1, 0x0, 0x56, 0xaa, 0xec, 0xf6, 0x28, 0xd4, 0x9f, 0x4d, 0xf6, 0x44, 0x64, 0xce, 0x23, 0xf4, 0x24, 0x4a, 0xcf, 0xc, 0x58, 0xbd}),
-				new(big.Int).SetBytes([]byte{0x57, 0xe9, 0x9d, 0xb4, 0xfc, 0x2a, 0x60, 0x77, 0xbd, 0xfb, 0x2f, 0xeb, 0xcf, 0xf6, 0x38, 0xba, 0xae, 0xe3, 0x86, 0x9f, 0xa4, 0xb9, 0x8c, 0x9c, 0xe5, 0x2e, 0x4a, 0xb9, 0x37, 0xc8, 0x93, 0x87}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x75, 0xe4, 0xd0, 0xd3, 0x83, 0xcb, 0xed, 0x21, 0x78, 0xe5, 0xe6, 0xe0, 0x1c, 0xf8, 0xba, 0x90, 0x97, 0xbf, 0xdc, 0x45, 0x56, 0x86, 0xcd, 0x1a, 0xf0, 0xaa, 0xa0, 0x21, 0x59, 0x5e, 0x82, 0x47}),
-				new(big.Int).SetBytes([]byte{0x1f, 0xce, 0x42, 0xa1, 0x75, 0x41, 0x9, 0x90, 0x74, 0x1f, 0x97, 0xe, 0xc7, 0xb7, 0x26, 0xd4, 0x7d, 0xda, 0xb5, 0xb6, 0xea, 0x9a, 0xd8, 0x93, 0xca, 0x23, 0xfd, 0x9d, 0x59, 0x98, 0x2d, 0x22}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x18, 0x9e, 0x88, 0xf1, 0x9a, 0x3d, 0x85, 0xb4, 0x11, 0xf7, 0x8c, 0xea, 0x33, 0xfe, 0xdd, 0x69, 0x0, 0x65, 0x21, 0xf9, 0x8a, 0x52, 0x9a, 0x39, 0x94, 0x71, 0xd0, 0xf3, 0x97, 0x15, 0xc2}),
-				new(big.Int).SetBytes([]byte{0xc9, 0x1a, 0x39, 0xf8, 0xf3, 0xc5, 0xd7, 0xcf, 0xdf, 0x70, 0x84, 0x75, 0xed, 0x10, 0xf9, 0xf7, 0xeb, 0x33, 0x33, 0xe2, 0xc3, 0x68, 0x54, 0xf0, 0x66, 0xa6, 0x35, 0x84, 0x41, 0x71, 0xdf, 0xea}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0xd1, 0xb6, 0x38, 0x33, 0x61, 0xbb, 0xf0, 0x3f, 0x2, 0xd, 0x60, 0x59, 0x84, 0xda, 0x84, 0x9c, 0xbb, 0xbc, 0x1c, 0x68, 0xf4, 0xab, 0xf4, 0x74, 0x79, 0xf4, 0x4d, 0xa6, 0x33, 0x33, 0x23}),
-				new(big.Int).SetBytes([]byte{0xd8, 0x4f, 0x2d, 0xa4, 0x8b, 0x5b, 0x4e, 0xe7, 0x5d, 0x97, 0xc9, 0x89, 0x68, 0x8f, 0x92, 0x9e, 0xa9, 0xb7, 0x28, 0xa1, 0xb, 0x78, 0xf7, 0x29, 0x4b, 0x13, 0xfc, 0x48, 0xb5, 0xd6, 0x1b, 0xa3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd2, 0xcd, 0xc8, 0xbb, 0xa, 0x1b, 0x6b, 0xb7, 0x77, 0xe4, 0x6b, 0x40, 0x88, 0x8a, 0x6f, 0x7e, 0x77, 0xee, 0x68, 0x94, 0x4d, 0x90, 0xe3, 0x98, 0x87, 0x56, 0x2e, 0xdc, 0xfa, 0x3e, 0xb3, 0xfc}),
-				new(big.Int).SetBytes([]byte{0xc5, 0x53, 0x8a, 0xf9, 0xe5, 0xc5, 0x61, 0x4, 0x6d, 0xde, 0x95, 0x36, 0x2c, 0xf9, 0x26, 0xee, 0xc1, 0xf2, 0x17, 0xb9, 0x31, 0xb, 0x49, 0x27, 0x44, 0x28, 0x7d, 0xb3, 0xde, 0xd3, 0x75, 0x64}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x70, 0xfd, 0xd2, 0xf0, 0x6a, 0x75, 0x81, 0x81, 0xb8, 0x46, 0xfd, 0x77, 0x23, 0x48, 0xdd, 0x43, 0x68, 0xa3, 0x70, 0x2e, 0x50, 0xdd, 0x0, 0xba, 0xf2, 0x50, 0x57, 0xd4, 0xb3, 0x39, 0x80, 0xbf}),
-				new(big.Int).SetBytes([]byte{0x5e, 0xdf, 0xca, 0xc0, 0xd0, 0xd5, 0x86, 0x96, 0x95, 0x7a, 0x41, 0xd, 0x11, 0xee, 0x59, 0x6b, 0xa, 0x10, 0x35, 0x83, 0x85, 0xd7, 0x4a, 0x56, 0x12, 0xee, 0xa8, 0xce, 0xa0, 0xb7, 0x57, 0x85}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa3, 0x1, 0x69, 0x7b, 0xdf, 0xcd, 0x70, 0x43, 0x13, 0xba, 0x48, 0xe5, 0x1d, 0x56, 0x75, 0x43, 0xf2, 0xa1, 0x82, 0x3, 0x1e, 0xfd, 0x69, 0x15, 0xdd, 0xc0, 0x7b, 0xbc, 0xc4, 0xe1, 0x60, 0x70}),
-				new(big.Int).SetBytes([]byte{0x73, 0x70, 0xf9, 0x1c, 0xfb, 0x67, 0xe4, 0xf5, 0x8, 0x18, 0x9, 0xfa, 0x25, 0xd4, 0xf, 0x9b, 0x17, 0x35, 0xdb, 0xf7, 0xc0, 0xa1, 0x1a, 0x13, 0xc, 0xd, 0x1a, 0x4, 0x1e, 0x17, 0x7e, 0xa1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x27, 0xe1, 0xe5, 0x9c, 0xff, 0x79, 0xf0, 0x49, 0xf3, 0xe8, 0xd2, 0x41, 0x9e, 0xb, 0xff, 0x74, 0xb4, 0x39, 0x65, 0x0, 0x4c, 0x34, 0xb5, 0xd8, 0x11, 0x42, 0x3, 0x16, 0xf2, 0x4b, 0xa5, 0xae}),
-				new(big.Int).SetBytes([]byte{0x31, 0xb, 0x26, 0xa6, 0xc8, 0x4, 0xe2, 0x9, 0xee, 0x1b, 0x5e, 0x3c, 0xfc, 0x79, 0xdf, 0x5, 0xdf, 0x48, 0xa1, 0xa6, 0x9a, 0xfa, 0x63, 0xf7, 0x84, 0xa5, 0xbf, 0xee, 0x88, 0x3a, 0x45, 0xb3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6e, 0x83, 0x13, 0xa3, 0x8, 0x15, 0xeb, 0x11, 0x15, 0x6b, 0x13, 0x30, 0x82, 0x20, 0xa, 0x4d, 0x83, 0x59, 0x6a, 0x67, 0xad, 0x72, 0x85, 0x62, 0x67, 0x1, 0x27, 0x0, 0x13, 0x80, 0x11, 0xfc}),
-				new(big.Int).SetBytes([]byte{0xc1, 0x47, 0x81, 0x8b, 0xdc, 0x24, 0xf2, 0x4, 0xc1, 0xa1, 0x2d, 0xb2, 0x1, 0xda, 0xc3, 0x4, 0x26, 0xaf, 0x91, 0x5a, 0xe9, 0xc5, 0x1f, 0x9a, 0x6a, 0xcb, 0x69, 0xfa, 0x3f, 0x15, 0xab, 0x7d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc7, 0x12, 0xe7, 0xa5, 0xf6, 0x86, 0x4a, 0xee, 0x16, 0x58, 0x8e, 0xc3, 0x89, 0x2d, 0x7e, 0x4f, 0x5a, 0x39, 0xad, 0xde, 0x84, 0xfb, 0xfb, 0x4f, 0x99, 0x69, 0x17, 0x5c, 0x9c, 0xae, 0xd7, 0xae}),
-				new(big.Int).SetBytes([]byte{0x49, 0x64, 0x41, 0x7, 0x51, 0x63, 0x63, 0xb3, 0x65, 0xed, 0x4b, 0x82, 0x31, 0x1d, 0xd9, 0xe5, 0x38, 0xd, 0x8e, 0x54, 0x4b, 0xc, 0xe6, 0x37, 0x84, 0xd1, 0x48, 0xaa, 0x46, 0x15, 0x62, 0x94}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf9, 0x52, 0xa9, 0x9, 0x97, 0x84, 0x85, 0x1f, 0x2b, 0xd1, 0xe0, 0x38, 0xa4, 0xd9, 0xe1, 0xb4, 0x3d, 0x36, 0xec, 0x5b, 0x44, 0x91, 0x6f, 0x7f, 0x53, 0xa7, 0x49, 0xb8, 0xd0, 0xe, 0x6b, 0xa7}),
-				new(big.Int).SetBytes([]byte{0xd8, 0xa9, 0x3a, 0x5b, 0x8, 0xab, 0xce, 0xbf, 0x86, 0x13, 0x76, 0xa2, 0xe2, 0x7f, 0xa0, 0xf6, 0x55, 0x57, 0x16, 0x7b, 0x4c, 0x62, 0xa2, 0xb9, 0x8d, 0xba, 0xee, 0xe5, 0x1, 0x75, 0xe4, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0xac, 0x7d, 0x1d, 0x4, 0xcd, 0xa3, 0xc, 0x83, 0xa0, 0x53, 0xaa, 0xd0, 0x98, 0x76, 0xcd, 0xbe, 0xb3, 0x2b, 0x53, 0xd3, 0x8b, 0x20, 0x21, 0xf9, 0x54, 0x9f, 0x5c, 0x59, 0x5b, 0x6f, 0x7e}),
-				new(big.Int).SetBytes([]byte{0xdf, 0xb, 0x8a, 0xa, 0xb5, 0x40, 0xf5, 0x5b, 0x36, 0xcc, 0x3c, 0x92, 0x47, 0xd9, 0xee, 0x1b, 0x99, 0xe3, 0x91, 0x60, 0xf5, 0x81, 0x8a, 0x48, 0x48, 0xdb, 0xb7, 0x30, 0x8a, 0xb1, 0x9c, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x94, 0x1, 0x6d, 0x5e, 0x31, 0xd3, 0xfe, 0xe7, 0x50, 0x40, 0x31, 0xa1, 0x9d, 0x9e, 0x89, 0x3a, 0x8f, 0x2e, 0x39, 0x43, 0xae, 0xfb, 0x1f, 0x62, 0x69, 0x0, 0x65, 0xa2, 0x83, 0xaa, 0xe, 0x93}),
-				new(big.Int).SetBytes([]byte{0x67, 0x50, 0x32, 0xee, 0x5c, 0x45, 0x4d, 0x96, 0x40, 0x35, 0x5d, 0x35, 0x4e, 0xee, 0x6f, 0xd7, 0xf4, 0x84, 0x37, 0x3b, 0xaa, 0x57, 0xb0, 0x7a, 0x38, 0xee, 0xd2, 0x68, 0x87, 0xad, 0xda, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb, 0xfc, 0x5, 0x4, 0xa4, 0xb3, 0x23, 0x5d, 0x6, 0x5c, 0xd, 0x42, 0x6b, 0x86, 0x75, 0xfc, 0xb2, 0xc8, 0x5d, 0x6f, 0x58, 0x27, 0x5d, 0x79, 0x1b, 0x43, 0xe1, 0xfe, 0x44, 0xa6, 0xdb, 0x3}),
-				new(big.Int).SetBytes([]byte{0x19, 0x55, 0x46, 0x7a, 0x6c, 0x34, 0xf3, 0x45, 0x3f, 0xb8, 0xec, 0x7f, 0x94, 0xa6, 0xc9, 0x92, 0x37, 0x42, 0x71, 0x97, 0x34, 0x5d, 0x4f, 0x5, 0x58, 0xac, 0x8d, 0x1a, 0x46, 0x4b, 0x85, 0x42}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xef, 0x22, 0xd1, 0x74, 0xd5, 0x9f, 0xb2, 0x89, 0x1b, 0xb9, 0x78, 0x84, 0x6a, 0x9f, 0x9, 0xc2, 0x2, 0x35, 0x68, 0xa2, 0x2, 0x62, 0xbb, 0x32, 0xb0, 0xf4, 0x86, 0x2e, 0x2, 0x66, 0xb1, 0x7b}),
-				new(big.Int).SetBytes([]byte{0xbc, 0x57, 0x84, 0xc9, 0x7a, 0xb2, 0x4c, 0x75, 0x68, 0xf9, 0xcc, 0xc3, 0xae, 0x34, 0xa1, 0x7, 0x3e, 0xe7, 0x27, 0x6f, 0x71, 0xc3, 0x41, 0xb7, 0x32, 0xa6, 0xe0, 0x43, 0x79, 0xda, 0xc8, 0x3e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x98, 0x85, 0xf5, 0xfb, 0xe1, 0x94, 0x8d, 0xf, 0x55, 0x78, 0x8a, 0x38, 0xdf, 0x20, 0x57, 0xee, 0x72, 0xed, 0x5c, 0x81, 0x2f, 0x5b, 0x84, 0x56, 0x57, 0xf2, 0xc5, 0x42, 0xeb, 0x43, 0x18, 0xb5}),
-				new(big.Int).SetBytes([]byte{0x13, 0x5f, 0xd6, 0xf6, 0x9e, 0xf1, 0x98, 0xf9, 0x96, 0xd2, 0xc4, 0x67, 0x76, 0x8c, 0xeb, 0x8e, 0x7c, 0x82, 0xad, 0xed, 0x67, 0x69, 0x4, 0xaf, 0x2f, 0xe5, 0x42, 0x54, 0x15, 0x99, 0x17, 0x5c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb5, 0xf7, 0xef, 0xce, 0x4c, 0xeb, 0x89, 0x2d, 0x39, 0xca, 0xcc, 0x78, 0xb, 0x2d, 0xf9, 0x27, 0xf2, 0x8d, 0xcb, 0xbc, 0xe2, 0xec, 0xd9, 0xae, 0x23, 0x13, 0x1, 0x5a, 0x92, 0xd3, 0x82, 0xa0}),
-				new(big.Int).SetBytes([]byte{0x68, 0x43, 0x54, 0x5b, 0x51, 0xc3, 0xf2, 0x35, 0x3c, 0x9, 0x57, 0xc6, 0xe0, 0xcb, 0xdd, 0xd3, 0x71, 0xfb, 0x9, 0x6d, 0x29, 0xac, 0xf8, 0xc5, 0xba, 0x2f, 0xa, 0xbb, 0xa5, 0xb4, 0xb5, 0x32}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x36, 0xf, 0x5, 0x46, 0x5c, 0x4, 0x63, 0x5a, 0xd8, 0x15, 0xb8, 0xd6, 0x8c, 0xe7, 0x67, 0xd2, 0x53, 0x65, 0xe5, 0x8a, 0xbb, 0x5b, 0x33, 0xb9, 0x3a, 0x4a, 0xe4, 0xce, 0x81, 0xc1, 0xbc}),
-				new(big.Int).SetBytes([]byte{0x7e, 0x48, 0xb5, 0xf9, 0xc, 0x57, 0x50, 0x1, 0x4f, 0xf6, 0x9c, 0x42, 0x51, 0x30, 0x67, 0x53, 0x23, 0x17, 0x28, 0xeb, 0x5c, 0x81, 0x15, 0x1, 0x76, 0xdd, 0x28, 0x96, 0x56, 0x6c, 0xbe, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0xdd, 0xc3, 0xd2, 0xb2, 0xe7, 0x10, 0x76, 0x40, 0x87, 0x54, 0xd8, 0xfb, 0xd4, 0x62, 0x43, 0x89, 0xed, 0x42, 0x8, 0x66, 0x8c, 0xc5, 0xc4, 0x7e, 0x69, 0x24, 0x64, 0x41, 0x2c, 0xff, 0xa5}),
-				new(big.Int).SetBytes([]byte{0x1e, 0x47, 0x6a, 0xc, 0xb2, 0xf1, 0xf8, 0xa6, 0x3f, 0xdb, 0x12, 0x6b, 0xf9, 0x42, 0xb0, 0x8a, 0xc4, 0x1c, 0x98, 0x42, 0x6b, 0x36, 0xb5, 0x28, 0xad, 0xe6, 0xd8, 0x9c, 0xd5, 0x21, 0x95, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8, 0x75, 0x34, 0x6a, 0x56, 0xa3, 0x85, 0x11, 0x63, 0x34, 0xfb, 0xe1, 0xbc, 0x8b, 0xae, 0x42, 0x96, 0x25, 0x52, 0x23, 0xf8, 0x27, 0x3c, 0xe1, 0x15, 0x9, 0xf7, 0x34, 0x4, 0x89, 0x93, 0xf7}),
-				new(big.Int).SetBytes([]byte{0x6, 0x4e, 0x6c, 0xa8, 0xa2, 0xef, 0x8b, 0x3, 0x10, 0xe9, 0x94, 0xe6, 0xe7, 0x5f, 0xa2, 0x87, 0xc9, 0xf4, 0xd4, 0xf1, 0xb1, 0x26, 0x1, 0x8e, 0x60, 0x1c, 0x2c, 0x81, 0x72, 0xb9, 0xdc, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2a, 0xcc, 0xb3, 0x59, 0xf2, 0x5c, 0xe9, 0x39, 0xf2, 0x1f, 0x8b, 0x8b, 0x94, 0x88, 0x35, 0xb0, 0xcc, 0x8, 0x19, 0xc5, 0x21, 0x7a, 0x8e, 0x2a, 0xd3, 0x5f, 0xe4, 0x39, 0x34, 0xa9, 0xf2, 0x2f}),
-				new(big.Int).SetBytes([]byte{0xd5, 0x18, 0xa4, 0xe9, 0x58, 0x8a, 0xd2, 0xe5, 0x19, 0x21, 0x3a, 0x37, 0xed, 0xf0, 0xed, 0xf1, 0x15, 0x4b, 0xd7, 0xbe, 0xc5, 0xba, 0x55, 0xb, 0x7b, 0x75, 0xdc, 0xb3, 0xb7, 0x5c, 0x49, 0x27}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x90, 0xad, 0x85, 0xb3, 0x89, 0xd6, 0xb9, 0x36, 0x46, 0x3f, 0x9d, 0x5, 0x12, 0x67, 0x8d, 0xe2, 0x8, 0xcc, 0x33, 0xb, 0x11, 0x30, 0x7f, 0xff, 0xab, 0x7a, 0xc6, 0x3e, 0x3f, 0xb0, 0x4e, 0xd4}),
-				new(big.Int).SetBytes([]byte{0xe, 0x50, 0x7a, 0x36, 0x20, 0xa3, 0x82, 0x61, 0xaf, 0xfd, 0xcb, 0xd9, 0x42, 0x72, 0x22, 0xb8, 0x39, 0xae, 0xfa, 0xbe, 0x15, 0x82, 0x89, 0x4d, 0x99, 0x1d, 0x4d, 0x48, 0xcb, 0x6e, 0xf1, 0x50}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7e, 0x2c, 0xd4, 0xe, 0xf8, 0xc9, 0x40, 0x77, 0xf4, 0x4b, 0x1d, 0x15, 0x48, 0x42, 0x5e, 0x3d, 0x7e, 0x12, 0x5b, 0xe6, 0x46, 0x70, 0x7b, 0xad, 0x28, 0x18, 0xb0, 0xed, 0xa7, 0xdc, 0x1, 0x51}),
-				new(big.Int).SetBytes([]byte{0x90, 0x5b, 0x75, 0x8, 0x2a, 0xdc, 0xfa, 0xb3, 0x82, 0xa6, 0x1a, 0x8b, 0x32, 0x1e, 0xf9, 0x5d, 0x88, 0x9b, 0xee, 0x40, 0xae, 0xee, 0x8, 0x2c, 0x9a, 0x3b, 0xc5, 0x39, 0x20, 0x72, 0x1e, 0xc7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x18, 0x6e, 0x49, 0x73, 0x34, 0xe4, 0x23, 0x1b, 0xa1, 0x98, 0x6b, 0xca, 0x42, 0x6b, 0x6c, 0x76, 0xf1, 0x3c, 0x43, 0x11, 0xbc, 0xf6, 0x38, 0x16, 0x62, 0x75, 0xdb, 0x33, 0xb0, 0xb7, 0xb6, 0x78}),
-				new(big.Int).SetBytes([]byte{0xc0, 0xd4, 0x60, 0xe4, 0x98, 0x7, 0xbd, 0x84, 0xaa, 0x7f, 0xc8, 0x25, 0xc0, 0xb7, 0xe6, 0x7e, 0x11, 0x7, 0x72, 0x25, 0x44, 0x95, 0x35, 0xdc, 0xa7, 0xa0, 0x76, 0xf2, 0xf8, 0xd9, 0x1f, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa1, 0x46, 0xf5, 0x21, 0x95, 0xbe, 0xda, 0xce, 0x21, 0xc9, 0x75, 0xbb, 0xd1, 0xef, 0x52, 0xa7, 0x9c, 0x63, 0x6b, 0xf9, 0xdb, 0x85, 0x3c, 0xf9, 0xe, 0x10, 0x3a, 0xe4, 0x13, 0x45, 0xe5, 0x97}),
-				new(big.Int).SetBytes([]byte{0xa5, 0xa9, 0x9b, 0xa, 0xb0, 0x53, 0xfe, 0xb0, 0x9a, 0xe9, 0x5d, 0xd2, 0xdb, 0xb3, 0x1b, 0x40, 0xea, 0x67, 0xa5, 0xb2, 0x21, 0xf0, 0x94, 0xb0, 0x76, 0x75, 0x67, 0x6a, 0xf4, 0x5a, 0x77, 0xa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6, 0x1c, 0x8d, 0x83, 0x4f, 0x6d, 0xbf, 0x62, 0xe7, 0xe0, 0x6b, 0x34, 0xc3, 0xb7, 0x24, 0x12, 0x98, 0x49, 0x71, 0xf2, 0xef, 0x5a, 0x55, 0xd0, 0xcf, 0x6, 0xe5, 0xcb, 0xb3, 0x42, 0x1f, 0xb8}),
-				new(big.Int).SetBytes([]byte{0x6d, 0xfc, 0x6a, 0xd9, 0x90, 0x3, 0xb4, 0xb7, 0xba, 0x93, 0x92, 0x59, 0xe, 0x5, 0xeb, 0x5c, 0x80, 0x67, 0x13, 0x4b, 0x3a, 0x6f, 0xcd, 0xd2, 0x99, 0x5e, 0xf6, 0x68, 0x4e, 0x3c, 0xcd, 0x80}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x99, 0x41, 0xce, 0xe1, 0xc2, 0x68, 0x64, 0x24, 0x8f, 0x70, 0x35, 0x35, 0x27, 0x87, 0xd1, 0xab, 0xa9, 0xe9, 0x3e, 0xf5, 0xed, 0xd3, 0x33, 0xd0, 0x8c, 0x89, 0xb8, 0x4b, 0xb9, 0xdc, 0x8f}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x41, 0xec, 0x75, 0xbd, 0x40, 0xe6, 0xf4, 0x6d, 0xe9, 0x7e, 0x5, 0x78, 0x88, 0x59, 0xe6, 0x78, 0x72, 0xb2, 0xfd, 0xee, 0xfe, 0x93, 0x8d, 0x74, 0x3e, 0xae, 0x53, 0xe5, 0x97, 0x80, 0xd5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf6, 0xa6, 0xb6, 0x3a, 0x20, 0x8e, 0xe5, 0x13, 0xb7, 0x36, 0x32, 0x40, 0x73, 0x3a, 0x68, 0xad, 0x88, 0x9f, 0x37, 0xa6, 0xab, 0x27, 0xfb, 0x48, 0x71, 0x24, 0xbe, 0x18, 0xaf, 0x6b, 0x35, 0xa4}),
-				new(big.Int).SetBytes([]byte{0x3d, 0xf7, 0xc8, 0xa8, 0x0, 0x2d, 0x13, 0x8b, 0x1c, 0xad, 0x37, 0x4, 0xf5, 0x60, 0x18, 0x6f, 0x19, 0xd5, 0x75, 0x6e, 0x75, 0xee, 0x18, 0x62, 0xca, 0xeb, 0x6f, 0xec, 0x81, 0xf4, 0x22, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd2, 0x4c, 0x75, 0xa1, 0xcf, 0x19, 0x93, 0xb9, 0xbc, 0xfb, 0xf9, 0xda, 0xb2, 0x5a, 0x81, 0x14, 0xdb, 0xde, 0x42, 0x1e, 0xfe, 0xcc, 0xc4, 0xe2, 0xc, 0xbb, 0x53, 0xfc, 0x4c, 0xe4, 0x54, 0x44}),
-				new(big.Int).SetBytes([]byte{0x58, 0xfe, 0x1d, 0x2d, 0xe8, 0x4d, 0xc1, 0xd1, 0xcf, 0xcb, 0x7d, 0x18, 0x10, 0xe5, 0xa7, 0x8a, 0xbf, 0x75, 0x93, 0xf4, 0x99, 0xf1, 0xe5, 0x24, 0xcb, 0x93, 0x24, 0x69, 0x87, 0xdd, 0x4a, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x87, 0xa2, 0xfc, 0x28, 0xc2, 0x86, 0xc3, 0x76, 0x22, 0xd, 0x1b, 0xf, 0x15, 0x7, 0x78, 0xff, 0x7f, 0xa8, 0xa4, 0x3c, 0x15, 0x60, 0xd0, 0x18, 0xb9, 0x68, 0x23, 0xe8, 0xa6, 0x95, 0x4c, 0x11}),
-				new(big.Int).SetBytes([]byte{0x33, 0xad, 0x51, 0x8b, 0x45, 0xaa, 0xec, 0xef, 0x97, 0xb1, 0x94, 0x8d, 0xa0, 0xd2, 0x75, 0x32, 0xcc, 0x48, 0x93, 0x3f, 0x2a, 0x78, 0x12, 0x4b, 0x75, 0x6e, 0x33, 0x2e, 0x27, 0x2a, 0x8b, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa8, 0xb0, 0x8b, 0x86, 0x49, 0x46, 0xac, 0x6d, 0xa1, 0x1, 0xeb, 0x85, 0xee, 0x7b, 0xdf, 0x55, 0x24, 0x28, 0x2c, 0xa1, 0xa9, 0x95, 0x6e, 0x4a, 0xee, 0xb1, 0xaf, 0xe9, 0x87, 0xa4, 0x3d, 0xdc}),
-				new(big.Int).SetBytes([]byte{0x7f, 0x0, 0xfe, 0x8b, 0xc8, 0xa3, 0x6a, 0x1, 0xf3, 0x15, 0x91, 0xff, 0xdf, 0xe, 0x8e, 0xdc, 0xa3, 0x25, 0xb4, 0x45, 0x2b, 0x10, 0x76, 0xc3, 0x87, 0x0, 0x1c, 0x82, 0xdf, 0xa2, 0xa6, 0xb8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0x47, 0x2e, 0x4, 0x6e, 0x78, 0x81, 0xa6, 0x84, 0x55, 0x6e, 0xfc, 0xbc, 0x38, 0x4c, 0xfe, 0x3c, 0xb, 0x34, 0x1d, 0xd2, 0xe5, 0x3c, 0xd, 0xae, 0x47, 0x47, 0x2a, 0xe2, 0x70, 0x6a, 0xb6}),
-				new(big.Int).SetBytes([]byte{0x82, 0xcd, 0x92, 0xaa, 0x47, 0xc5, 0x8f, 0xc6, 0x83, 0xf6, 0x91, 0x97, 0x8c, 0x86, 0x21, 0xf8, 0xaa, 0x58, 0x9d, 0x55, 0x45, 0x3c, 0xd2, 0x75, 0x36, 0xe8, 0x6a, 0x16, 0x9d, 0x58, 0xde, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc5, 0xf0, 0x95, 0x4f, 0xb1, 0xb8, 0xa6, 0x3e, 0x6e, 0x74, 0x78, 0x1a, 0x2e, 0x31, 0x32, 0xfb, 0x2d, 0xcd, 0xb2, 0x80, 0x7e, 0x71, 0xf1, 0xf8, 0xe3, 0xe9, 0x43, 0x2c, 0x3b, 0x96, 0x36, 0x45}),
-				new(big.Int).SetBytes([]byte{0xd5, 0x52, 0xd3, 0x10, 0x21, 0xb0, 0xb6, 0xfb, 0x18, 0xa3, 0xcb, 0x44, 0x2, 0x60, 0xd7, 0xe6, 0xa3, 0x30, 0x50, 0x2b, 0x0, 0x47, 0xbe, 0x4e, 0xdb, 0xf8, 0x87, 0x4f, 0x0, 0x8b, 0xe2, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0xaf, 0x92, 0xc8, 0x4, 0xf8, 0xae, 0xf5, 0xde, 0x8b, 0x4d, 0x60, 0x1e, 0x48, 0xb9, 0xeb, 0xc4, 0x23, 0x68, 0x75, 0xf, 0xa1, 0x50, 0x21, 0xeb, 0xfa, 0xe5, 0x42, 0x7, 0xa8, 0x4f, 0xb6}),
-				new(big.Int).SetBytes([]byte{0xbc, 0x24, 0xb8, 0x5a, 0xc9, 0x76, 0xe5, 0x3a, 0x48, 0x1a, 0x64, 0xf8, 0xff, 0x10, 0x16, 0x22, 0xec, 0x1c, 0xc1, 0x69, 0x6a, 0x2c, 0xe6, 0x76, 0x16, 0xb1, 0x81, 0x90, 0x27, 0x14, 0x91, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdf, 0x3a, 0xcf, 0x55, 0xbc, 0x6d, 0x97, 0xce, 0x34, 0xeb, 0xeb, 0x46, 0x34, 0xfe, 0x10, 0x10, 0xe, 0xd3, 0xd6, 0xd6, 0x38, 0x3c, 0x11, 0xa7, 0x53, 0xa9, 0x31, 0x23, 0xe9, 0xc8, 0x38, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x6b, 0x3d, 0xd8, 0xca, 0x51, 0x90, 0x7a, 0x75, 0xa6, 0x9, 0x9c, 0x1d, 0xed, 0xac, 0xd1, 0xeb, 0xb1, 0x1, 0x95, 0x7e, 0x67, 0xe3, 0x89, 0xe6, 0x1a, 0xff, 0xd6, 0x96, 0x23, 0x47, 0xde, 0x3a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x70, 0x5b, 0xfd, 0x69, 0xaf, 0x9, 0x65, 0xd, 0x25, 0x89, 0xef, 0xe9, 0xb3, 0xd7, 0x83, 0x97, 0x10, 0x7e, 0x16, 0xa2, 0x60, 0xde, 0xd5, 0x2d, 0xb7, 0x68, 0xcf, 0xe1, 0x4a, 0x95, 0x59, 0x11}),
-				new(big.Int).SetBytes([]byte{0xe1, 0x4a, 0xa4, 0x13, 0xb, 0x73, 0xf9, 0x90, 0xa7, 0xe6, 0x3d, 0x81, 0xf5, 0x40, 0x51, 0xfa, 0x29, 0x7f, 0x3b, 0x52, 0x2a, 0x42, 0xe7, 0x2d, 0x8b, 0xb7, 0xc6, 0x93, 0xff, 0xd2, 0x0, 0xe4}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8f, 0x68, 0xb9, 0xd2, 0xf6, 0x3b, 0x5f, 0x33, 0x92, 0x39, 0xc1, 0xad, 0x98, 0x1f, 0x16, 0x2e, 0xe8, 0x8c, 0x56, 0x78, 0x72, 0x3e, 0xa3, 0x35, 0x1b, 0x7b, 0x44, 0x4c, 0x9e, 0xc4, 0xc0, 0xda}),
-				new(big.Int).SetBytes([]byte{0x66, 0x2a, 0x9f, 0x2d, 0xba, 0x6, 0x39, 0x86, 0xde, 0x1d, 0x90, 0xc2, 0xb6, 0xbe, 0x21, 0x5d, 0xbb, 0xea, 0x2c, 0xfe, 0x95, 0x51, 0xb, 0xfd, 0xf2, 0x3c, 0xbf, 0x79, 0x50, 0x1f, 0xff, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0x49, 0xae, 0xfd, 0x78, 0x4e, 0x81, 0x58, 0xfc, 0xaf, 0xeb, 0xe7, 0x7f, 0xd9, 0xaf, 0x59, 0xd8, 0x98, 0x58, 0xad, 0xe7, 0x62, 0x7e, 0xae, 0xe6, 0x84, 0x7d, 0xf8, 0x4c, 0xf2, 0x70, 0x76}),
-				new(big.Int).SetBytes([]byte{0xcd, 0x32, 0xfc, 0x59, 0xa1, 0xd, 0xd1, 0x35, 0xe7, 0x23, 0xf2, 0x10, 0x35, 0x9c, 0xa6, 0xf0, 0x6e, 0xf, 0x2d, 0x1a, 0x7d, 0xf4, 0xd8, 0x46, 0x6b, 0x90, 0xb6, 0x62, 0x3, 0xaa, 0x78, 0x1e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x38, 0x1d, 0xbe, 0x2e, 0x50, 0x9f, 0x22, 0x8b, 0xa9, 0x33, 0x63, 0xf2, 0x45, 0x1f, 0x8, 0xfd, 0x84, 0x5c, 0xb3, 0x51, 0xd9, 0x54, 0xbe, 0x18, 0xe2, 0xb8, 0xed, 0xd2, 0x38, 0x9, 0xfa}),
-				new(big.Int).SetBytes([]byte{0xe4, 0xa3, 0x2d, 0xa, 0xf, 0xb9, 0x17, 0xdc, 0xb0, 0x94, 0x5, 0xa5, 0x52, 0xe, 0xb1, 0xcc, 0x36, 0x81, 0xfc, 0xcb, 0x32, 0xd8, 0xf2, 0x4d, 0xbd, 0x70, 0x75, 0x18, 0x33, 0x1f, 0xed, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x75, 0x64, 0x53, 0x9e, 0x85, 0xd5, 0x6f, 0x85, 0x37, 0xd6, 0x61, 0x9e, 0x1f, 0x5c, 0x5a, 0xa7, 0x8d, 0x2a, 0x3d, 0xe0, 0x88, 0x9d, 0x1d, 0x4e, 0xe8, 0xdb, 0xcb, 0x57, 0x29, 0xb6, 0x20, 0x26}),
-				new(big.Int).SetBytes([]byte{0xc1, 0xd6, 0x85, 0x41, 0x37, 0x49, 0xb3, 0xc6, 0x52, 0x31, 0xdf, 0x52, 0x4a, 0x72, 0x29, 0x25, 0x68, 0x4a, 0xac, 0xd9, 0x54, 0xb7, 0x9f, 0x33, 0x41, 0x72, 0xc8, 0xfa, 0xda, 0xce, 0xc, 0xf3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x49, 0x26, 0x27, 0x24, 0xe4, 0x37, 0x2a, 0xe6, 0xf6, 0x92, 0x1b, 0x82, 0xaa, 0x46, 0x99, 0xa1, 0xf1, 0x86, 0xae, 0xa5, 0x40, 0x12, 0x26, 0x30, 0x3e, 0xa4, 0x26, 0x48, 0x97, 0xc2, 0xa3, 0x10}),
-				new(big.Int).SetBytes([]byte{0x13, 0x37, 0xe7, 0x73, 0xbc, 0xa7, 0xab, 0xf9, 0x5a, 0x2c, 0xfa, 0x56, 0x97, 0x14, 0x30, 0x3b, 0x6d, 0x16, 0x36, 0x12, 0xa7, 0x5f, 0xf8, 0xce, 0xc, 0x41, 0xb6, 0x81, 0x5e, 0x27, 0xde, 0xd0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6a, 0x66, 0x4a, 0x35, 0x6a, 0xa5, 0x70, 0x5e, 0x68, 0x8, 0xa6, 0xed, 0x7c, 0x44, 0xaa, 0x2b, 0xa5, 0xa3, 0x62, 0x91, 0x9f, 0x5d, 0xb, 0x81, 0xf8, 0x16, 0x6c, 0x19, 0x3, 0x66, 0x3d, 0xa4}),
-				new(big.Int).SetBytes([]byte{0x44, 0x9a, 0x12, 0x59, 0x54, 0xfd, 0xe9, 0x8b, 0x29, 0xf8, 0x6e, 0xc1, 0x96, 0xbf, 0xc, 0xd5, 0x8, 0x99, 0x16, 0x12, 0x7e, 0x6c, 0x4, 0xc9, 0xc2, 0x83, 0x13, 0xfb, 0x33, 0xfc, 0x22, 0xc4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe3, 0x6, 0x56, 0x8c, 0x1a, 0x24, 0xc, 0x90, 0xd5, 0xe2, 0x53, 0xb3, 0xe4, 0x77, 0xe2, 0xf8, 0x4d, 0xcc, 0x1a, 0x56, 0xff, 0x6, 0xdb, 0x8d, 0x13, 0x84, 0xb0, 0x79, 0xce, 0xbd, 0x2d, 0x31}),
-				new(big.Int).SetBytes([]byte{0xe, 0xac, 0x6f, 0xe3, 0x78, 0x93, 0x42, 0x60, 0x88, 0x8f, 0x2b, 0x10, 0x7f, 0x7d, 0xd, 0xb6, 0xff, 0xbc, 0x80, 0x42, 0xbe, 0x37, 0x38, 0x26, 0x69, 0x2b, 0x40, 0x83, 0x92, 0x54, 0x6e, 0x44}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x21, 0xa, 0x91, 0x7a, 0xd9, 0xdf, 0x27, 0x79, 0x67, 0x46, 0xff, 0x30, 0x1a, 0xd9, 0xcc, 0xc8, 0x78, 0xf6, 0x1a, 0x5f, 0x1f, 0xf4, 0x8, 0x2b, 0x53, 0x64, 0xda, 0xcd, 0x57, 0xb4, 0xa2, 0x78}),
-				new(big.Int).SetBytes([]byte{0x67, 0xe, 0x1b, 0x54, 0x50, 0xb5, 0xe5, 0x7b, 0x7a, 0x39, 0xbe, 0x81, 0xf8, 0xd6, 0x73, 0x7d, 0x37, 0x89, 0xe6, 0x1a, 0xaf, 0xf2, 0xb, 0xfc, 0x7f, 0x27, 0x13, 0xfd, 0xc, 0x7b, 0x22, 0x31}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3b, 0x9e, 0x10, 0xe, 0x24, 0x28, 0xce, 0xfc, 0x27, 0x1b, 0xe, 0x76, 0x23, 0xfb, 0xd6, 0x33, 0x74, 0xeb, 0xf8, 0xd9, 0xaa, 0xb4, 0x1d, 0xd9, 0xc5, 0x30, 0xc3, 0x9e, 0x36, 0x31, 0x36, 0xb0}),
-				new(big.Int).SetBytes([]byte{0xfa, 0xfb, 0x98, 0x15, 0x2d, 0x16, 0xbb, 0x71, 0xdf, 0x15, 0x33, 0xeb, 0x8f, 0x47, 0x5b, 0x26, 0xa2, 0xae, 0x28, 0xa3, 0x3a, 0xd3, 0x1f, 0x81, 0x95, 0x3e, 0xc1, 0x6f, 0x6c, 0xdb, 0xbc, 0x8a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7a, 0xea, 0xca, 0x93, 0xc0, 0x6c, 0x55, 0x41, 0x77, 0xe9, 0xa1, 0x94, 0x6d, 0xb3, 0x81, 0x56, 0xc1, 0xe8, 0x2, 0x68, 0x52, 0x81, 0xc8, 0x5d, 0xea, 0x98, 0xeb, 0x53, 0xdd, 0x90, 0x12, 0x4e}),
-				new(big.Int).SetBytes([]byte{0xdf, 0xb2, 0x9b, 0x19, 0xc, 0x13, 0x90, 0xf0, 0x4f, 0xac, 0xaf, 0xf0, 0x46, 0xc1, 0xd9, 0x51, 0x59, 0xbc, 0x1b, 0xf9, 0xb9, 0xa, 0x90, 0x4b, 0x3f, 0x50, 0x78, 0x10, 0x22, 0x2, 0x1e, 0x31}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbb, 0xa, 0xad, 0x49, 0x71, 0x2a, 0xc9, 0xa9, 0x2b, 0x76, 0xca, 0x80, 0xf5, 0xde, 0xde, 0xf7, 0x17, 0xca, 0x7, 0x68, 0x81, 0x7, 0xbe, 0xee, 0x96, 0x8, 0xf0, 0x47, 0x2f, 0x48, 0x5d, 0x3f}),
-				new(big.Int).SetBytes([]byte{0xea, 0x69, 0x9c, 0x53, 0xc5, 0x83, 0x54, 0x79, 0x8e, 0xcd, 0x20, 0x1f, 0x72, 0x97, 0xda, 0x34, 0x89, 0x5a, 0x5a, 0xfa, 0x31, 0x67, 0xb, 0xff, 0xe7, 0x93, 0x92, 0x50, 0x3c, 0xa2, 0xf9, 0x75}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x55, 0x68, 0xda, 0xc6, 0x79, 0xf7, 0x4a, 0x32, 0xeb, 0xb5, 0xfa, 0xd2, 0x19, 0x54, 0x7a, 0xd1, 0x66, 0xf4, 0x40, 0xab, 0xc1, 0xc0, 0x17, 0xb4, 0x70, 0xf7, 0x2, 0xd5, 0x5, 0xed, 0x81, 0x5e}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x85, 0xf8, 0x74, 0x27, 0x88, 0xba, 0x64, 0x58, 0xd, 0x6f, 0xe0, 0x1d, 0x7, 0x3f, 0x2b, 0xeb, 0x5, 0xf7, 0xee, 0xe2, 0x58, 0x21, 0x51, 0xd9, 0xbb, 0xf6, 0x4c, 0x0, 0x60, 0x2d, 0xf0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x79, 0x9, 0xa, 0xc8, 0xe4, 0xee, 0xfc, 0xc0, 0xd4, 0xe8, 0xeb, 0x19, 0x7a, 0xfe, 0x1, 0x13, 0xe1, 0xe5, 0x8b, 0x4d, 0xb0, 0x11, 0x23, 0xde, 0x4a, 0xee, 0xd3, 0x3a, 0x36, 0x71, 0x8d, 0xc9}),
-				new(big.Int).SetBytes([]byte{0xea, 0xab, 0x72, 0x2b, 0x91, 0x90, 0x5b, 0x8f, 0x13, 0xd8, 0x16, 0xcb, 0xcd, 0x9a, 0xaa, 0x56, 0xdd, 0x36, 0xaf, 0xb7, 0xb, 0xa9, 0x0, 0x8b, 0x96, 0x33, 0x22, 0xb1, 0x1c, 0xfa, 0xe7, 0xc5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x60, 0x1e, 0x9e, 0x88, 0x48, 0x7, 0x94, 0x3c, 0xbc, 0x9b, 0xae, 0x60, 0xc, 0x74, 0x36, 0xc3, 0x2c, 0x9b, 0xe7, 0x40, 0x2e, 0xe2, 0x99, 0x32, 0xab, 0xec, 0x1b, 0xdc, 0x2e, 0x44, 0xa0, 0xc8}),
-				new(big.Int).SetBytes([]byte{0x5a, 0x7a, 0x22, 0xaa, 0x6f, 0xdc, 0x8e, 0xcc, 0x71, 0x4b, 0x8c, 0xfa, 0x9f, 0xfa, 0xbb, 0xb1, 0x42, 0x4e, 0xc, 0x5a, 0x6b, 0x46, 0xe6, 0x59, 0x54, 0x79, 0x34, 0x12, 0xba, 0x0, 0xe5, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe7, 0x7c, 0x81, 0xad, 0xe9, 0xf9, 0x7b, 0x55, 0x1c, 0x3, 0xdb, 0xbc, 0xe5, 0x49, 0xba, 0x66, 0x8d, 0xd7, 0x1d, 0xe7, 0xcd, 0x77, 0x5a, 0xd2, 0xa2, 0x69, 0x69, 0x4c, 0x7f, 0x60, 0xc7, 0xd1}),
-				new(big.Int).SetBytes([]byte{0x3a, 0xcf, 0x14, 0x78, 0xee, 0xf8, 0x13, 0x21, 0xc5, 0xfc, 0x3b, 0x32, 0x3e, 0xa8, 0x15, 0x43, 0x63, 0x14, 0x70, 0xf7, 0x1c, 0x29, 0x86, 0xd3, 0x4e, 0xc5, 0x81, 0xf2, 0x82, 0xd7, 0x24, 0x49}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe4, 0xf3, 0xfb, 0x1, 0x76, 0xaf, 0x85, 0xd6, 0x5f, 0xf9, 0x9f, 0xf9, 0x19, 0x8c, 0x36, 0x9, 0x1f, 0x48, 0xe8, 0x65, 0x3, 0x68, 0x1e, 0x3e, 0x66, 0x86, 0xfd, 0x50, 0x53, 0x23, 0x1e, 0x11}),
-				new(big.Int).SetBytes([]byte{0x1e, 0x63, 0x63, 0x3a, 0xd0, 0xef, 0x4f, 0x1c, 0x16, 0x61, 0xa6, 0xd0, 0xea, 0x2, 0xb7, 0x28, 0x6c, 0xc7, 0xe7, 0x4e, 0xc9, 0x51, 0xd1, 0xc9, 0x82, 0x2c, 0x38, 0x57, 0x6f, 0xeb, 0x73, 0xbc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x30, 0xcb, 0xb7, 0x68, 0x67, 0x73, 0xe0, 0x1e, 0xc6, 0x41, 0x10, 0xab, 0xdb, 0x36, 0x2f, 0x88, 0x53, 0x1a, 0x82, 0x5b, 0xa1, 0x72, 0x95, 0x3b, 0xfe, 0xe2, 0x23, 0x3b, 0xcd, 0xaf, 0x2f}),
-				new(big.Int).SetBytes([]byte{0x74, 0xc6, 0x35, 0x2, 0x65, 0xbb, 0x62, 0x9b, 0x6f, 0x9e, 0x2c, 0x57, 0x77, 0xc3, 0xc4, 0xa9, 0x1f, 0xdf, 0x3c, 0x81, 0xe4, 0x34, 0x85, 0x75, 0x68, 0x3, 0x3d, 0x46, 0x3d, 0x26, 0xb5, 0xb7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x90, 0xc, 0x32, 0x41, 0xbe, 0xe4, 0x4f, 0xe9, 0x8, 0x32, 0xf5, 0x1f, 0xeb, 0x47, 0xd, 0xec, 0xa2, 0xf5, 0x6e, 0x3, 0x21, 0x2a, 0x99, 0x46, 0x53, 0x99, 0xf0, 0x4e, 0x6b, 0xf0, 0x5b, 0xd6}),
-				new(big.Int).SetBytes([]byte{0x6c, 0x31, 0xf9, 0xe8, 0xe8, 0xb1, 0xf0, 0xf5, 0xf9, 0x5c, 0x72, 0x4, 0x57, 0xb, 0x24, 0x39, 0xd6, 0x98, 0x53, 0x58, 0x3c, 0x4e, 0xfb, 0x15, 0xde, 0x52, 0xad, 0x3b, 0xf0, 0xd, 0x35, 0x8b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcb, 0xb4, 0x34, 0xaa, 0x7a, 0xe1, 0x70, 0xd, 0xcd, 0x15, 0xb2, 0xb, 0x17, 0x46, 0x48, 0x17, 0xec, 0x11, 0x71, 0x50, 0x50, 0xe0, 0xfa, 0x19, 0x2f, 0xfe, 0x9c, 0x29, 0xa6, 0x73, 0x5, 0x9f}),
-				new(big.Int).SetBytes([]byte{0x4a, 0x1a, 0x20, 0xa, 0xb4, 0xda, 0xbd, 0x17, 0x56, 0x2d, 0x49, 0x23, 0x38, 0xb5, 0xdf, 0xad, 0x41, 0xd4, 0x5e, 0x4f, 0xa, 0xd5, 0xf8, 0x45, 0xb7, 0xda, 0x96, 0x42, 0x22, 0x7c, 0x7, 0xc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0x8d, 0x3, 0x62, 0xab, 0x5, 0x90, 0xaa, 0xd6, 0x28, 0xcc, 0x34, 0x3, 0xc2, 0x33, 0xcd, 0x82, 0xe0, 0xde, 0xef, 0x7b, 0x13, 0x85, 0x25, 0x7a, 0x7c, 0x28, 0xcc, 0x9f, 0x10, 0x5c, 0x50}),
-				new(big.Int).SetBytes([]byte{0xc0, 0x59, 0xea, 0xb1, 0x13, 0xd4, 0xe5, 0x36, 0x93, 0x6a, 0x6b, 0x72, 0x41, 0x43, 0xff, 0x74, 0xa6, 0x5, 0xf6, 0x8a, 0x66, 0xd0, 0x13, 0xc3, 0x5c, 0x9b, 0x20, 0x18, 0x38, 0xa4, 0xcd, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x29, 0xdf, 0xe4, 0x80, 0x5c, 0xd5, 0x34, 0xb9, 0x1a, 0x62, 0x89, 0xea, 0xed, 0xf7, 0xed, 0xc6, 0xf1, 0x58, 0xeb, 0xa3, 0xf0, 0xd, 0xd, 0xd5, 0xbe, 0x6f, 0xb2, 0xf7, 0xa7, 0x2e, 0x29, 0x4d}),
-				new(big.Int).SetBytes([]byte{0xbf, 0x66, 0xd8, 0x26, 0xd0, 0x6, 0x72, 0xe1, 0x9b, 0x43, 0x4e, 0x75, 0x88, 0xa0, 0x5e, 0x4d, 0xd1, 0xf1, 0x96, 0x1c, 0x39, 0x29, 0xee, 0x69, 0x44, 0x13, 0x72, 0x60, 0xec, 0x46, 0x9b, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd9, 0x3f, 0x4d, 0x3, 0x12, 0x32, 0xf6, 0xa, 0x48, 0xef, 0x3a, 0x57, 0x76, 0xcb, 0xa4, 0xe8, 0x37, 0x72, 0xf8, 0xa5, 0x92, 0x92, 0x29, 0x2c, 0x64, 0x7e, 0x18, 0xb9, 0xb2, 0xd6, 0x4f, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x79, 0x25, 0x55, 0x5d, 0x45, 0xcb, 0x27, 0x33, 0xa2, 0x37, 0x31, 0x1c, 0x54, 0xdc, 0x8c, 0x4d, 0x93, 0xe0, 0x43, 0xb, 0xee, 0xc9, 0xd, 0xa3, 0x7b, 0xf, 0xbd, 0x59, 0x34, 0x69, 0x83, 0x59}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf4, 0x78, 0x5, 0x6d, 0x9c, 0x10, 0x2c, 0x1c, 0xd0, 0x6d, 0x7b, 0x1e, 0x75, 0x57, 0x24, 0x4c, 0x6d, 0x9c, 0xda, 0xc5, 0x87, 0x46, 0x10, 0xe9, 0x4d, 0x47, 0x86, 0xe1, 0x6, 0xde, 0x12, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x7f, 0x9, 0xe6, 0x10, 0xf3, 0x3e, 0x39, 0x46, 0xe6, 0x80, 0x95, 0xe0, 0x10, 0x68, 0x69, 0x4c, 0x26, 0xc1, 0x7e, 0xf6, 0x9, 0xab, 0x92, 0xd7, 0x69, 0xa7, 0x6c, 0xe6, 0xca, 0x53, 0x61, 0xfe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2f, 0x7, 0x69, 0xc6, 0xe3, 0x6f, 0x28, 0x44, 0x1b, 0x14, 0xe2, 0xbc, 0x66, 0x2a, 0xc6, 0x5b, 0x7e, 0x70, 0x62, 0x91, 0x60, 0x57, 0xa0, 0x16, 0x72, 0x22, 0x4, 0xcd, 0x43, 0x9f, 0xbc, 0x84}),
-				new(big.Int).SetBytes([]byte{0xb4, 0x34, 0xf3, 0x72, 0x4d, 0x73, 0xbd, 0xdd, 0xfa, 0x5d, 0xf0, 0x7, 0x99, 0xe, 0x21, 0x49, 0xcc, 0xa7, 0x9b, 0x6a, 0x4c, 0x4d, 0xd5, 0xd7, 0x3f, 0xb9, 0x2, 0xde, 0x1c, 0x20, 0x1b, 0xec}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1d, 0xdc, 0xfa, 0x48, 0xd5, 0x61, 0xbb, 0xfb, 0xd7, 0x1a, 0x1e, 0x26, 0xd9, 0xa6, 0xdd, 0x61, 0x87, 0xc8, 0x4c, 0x45, 0x2c, 0x59, 0xc5, 0xd8, 0x92, 0x8b, 0x2f, 0xd2, 0xd0, 0x26, 0xde, 0xa2}),
-				new(big.Int).SetBytes([]byte{0xc6, 0xfa, 0x40, 0x1f, 0xa9, 0x92, 0x3a, 0x7d, 0x41, 0xfe, 0xa3, 0x4d, 0x64, 0x2, 0xc0, 0x20, 0x66, 0x6f, 0xd6, 0x8c, 0x51, 0xc0, 0x67, 0x34, 0x6, 0xf4, 0x87, 0x3d, 0x18, 0x3d, 0xe5, 0x22}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0x7e, 0x67, 0x80, 0x55, 0x9e, 0x3, 0x9, 0x95, 0x86, 0x89, 0x5a, 0x15, 0x41, 0x6e, 0x39, 0x91, 0x18, 0x83, 0x18, 0x92, 0x7f, 0x19, 0xc4, 0xd5, 0x37, 0xea, 0xcc, 0x86, 0xa7, 0x58, 0xea}),
-				new(big.Int).SetBytes([]byte{0x38, 0xaa, 0x69, 0x67, 0xa2, 0x81, 0xdc, 0xb9, 0x88, 0xc0, 0x8e, 0x16, 0x98, 0x49, 0x25, 0x1f, 0x3b, 0x80, 0x17, 0x7, 0x44, 0x31, 0x20, 0xd1, 0xe4, 0xf2, 0x2d, 0x95, 0xdb, 0x7a, 0xb6, 0x49}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x35, 0x5b, 0x32, 0x26, 0xf5, 0xdd, 0x23, 0x6d, 0x3e, 0x14, 0x10, 0xc0, 0x9, 0xe3, 0x72, 0xa1, 0x50, 0xd4, 0x4f, 0xd3, 0x45, 0xb5, 0x99, 0x95, 0x3c, 0x7f, 0x0, 0x46, 0xc3, 0x37, 0x73, 0xf7}),
-				new(big.Int).SetBytes([]byte{0x8, 0xec, 0xa4, 0x80, 0x70, 0x47, 0x3, 0xad, 0x49, 0xf1, 0xe, 0xed, 0xdd, 0x21, 0xfc, 0xb8, 0x1e, 0x25, 0xdf, 0x78, 0xcc, 0xe2, 0x51, 0x9, 0x0, 0x53, 0xc3, 0xff, 0x18, 0x87, 0xc1, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x91, 0xba, 0xf5, 0xca, 0x75, 0x38, 0x31, 0x27, 0xd6, 0xdf, 0x6f, 0x91, 0x50, 0xc3, 0xaf, 0x85, 0x10, 0x18, 0x4, 0x4f, 0x70, 0x65, 0x1a, 0xfa, 0xde, 0x71, 0x56, 0x26, 0x8c, 0xff, 0xfe, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x4, 0xe5, 0xcf, 0x11, 0x93, 0x47, 0xbc, 0xeb, 0xe5, 0xd9, 0x69, 0xf1, 0x27, 0xdd, 0x3d, 0x92, 0xf4, 0xbe, 0xf3, 0x10, 0xe6, 0xfd, 0x30, 0x3d, 0xc7, 0xd3, 0x6f, 0x34, 0xdf, 0x7f, 0x6f, 0xaf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x92, 0xb1, 0xe0, 0xf1, 0x7f, 0x26, 0xb, 0xae, 0x56, 0x19, 0xf4, 0x3c, 0xb2, 0xf8, 0x66, 0xb7, 0x52, 0x91, 0xde, 0x5e, 0x4a, 0xea, 0xa5, 0xd1, 0xdd, 0x3d, 0xf4, 0x6, 0x90, 0x78, 0x54, 0x91}),
-				new(big.Int).SetBytes([]byte{0x81, 0xec, 0x44, 0x2, 0x8d, 0x8d, 0x9e, 0x63, 0xe1, 0x67, 0xc1, 0xd1, 0x3, 0x4e, 0x5a, 0xd0, 0x39, 0x85, 0xfb, 0x77, 0xe2, 0x28, 0x8d, 0x7b, 0x9c, 0x98, 0xd3, 0x60, 0x58, 0xbe, 0x55, 0x5b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x44, 0x58, 0x41, 0x21, 0xcb, 0x3f, 0x78, 0xa7, 0xcb, 0x2c, 0x53, 0x73, 0xe0, 0xf5, 0xdd, 0xd7, 0x82, 0x57, 0x5f, 0xb3, 0x28, 0x5e, 0xda, 0xf3, 0x2f, 0xc0, 0x91, 0x7e, 0x8d, 0x33, 0xc5, 0x46}),
-				new(big.Int).SetBytes([]byte{0x30, 0x8d, 0x93, 0x77, 0xf3, 0xf8, 0x75, 0x3a, 0x77, 0x22, 0x5e, 0xae, 0x39, 0x1a, 0xda, 0x20, 0x85, 0x2f, 0xa, 0x3c, 0xfb, 0x2c, 0xa7, 0x36, 0x60, 0xa6, 0xd1, 0x87, 0x0, 0xb, 0x4f, 0xd4}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8c, 0x0, 0xfa, 0x9b, 0x18, 0xeb, 0xf3, 0x31, 0xeb, 0x96, 0x15, 0x37, 0xa4, 0x5a, 0x42, 0x66, 0xc7, 0x3, 0x4f, 0x2f, 0xd, 0x4e, 0x1d, 0x7, 0x16, 0xfb, 0x6e, 0xae, 0x20, 0xea, 0xe2, 0x9e}),
-				new(big.Int).SetBytes([]byte{0xef, 0xa4, 0x72, 0x67, 0xfe, 0xa5, 0x21, 0xa1, 0xa9, 0xdc, 0x34, 0x3a, 0x37, 0x36, 0xc9, 0x74, 0xc2, 0xfa, 0xda, 0xfa, 0x81, 0xe3, 0x6c, 0x54, 0xe7, 0xd2, 0xa4, 0xc6, 0x67, 0x2, 0x41, 0x4b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x24, 0xcf, 0xc0, 0x17, 0x6d, 0xa2, 0xb4, 0x6f, 0xa8, 0xbb, 0x5b, 0xf9, 0x63, 0x6b, 0xe1, 0xef, 0xfd, 0x7e, 0x29, 0x7f, 0x29, 0x12, 0x2f, 0xb3, 0xe8, 0x4c, 0x9a, 0xb0, 0xc1, 0x8a, 0xda, 0x5f}),
-				new(big.Int).SetBytes([]byte{0xeb, 0xff, 0x8f, 0xbb, 0x7, 0x9c, 0x61, 0xa6, 0x98, 0x68, 0x71, 0x4d, 0x5d, 0xed, 0xa9, 0x27, 0xed, 0x95, 0x9c, 0xa1, 0xa4, 0xf8, 0x14, 0xf2, 0x68, 0xfa, 0x61, 0x39, 0x97, 0x8a, 0x58, 0x6b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x36, 0x36, 0x2a, 0xa7, 0xe9, 0x7, 0xdd, 0xf8, 0x74, 0xd0, 0x7a, 0x8, 0x4c, 0x2a, 0x8d, 0x20, 0x50, 0xa6, 0x80, 0xe6, 0xee, 0x54, 0xc9, 0xea, 0xc3, 0xf9, 0x56, 0x3, 0xeb, 0xfd, 0x91, 0x3d}),
-				new(big.Int).SetBytes([]byte{0x48, 0xf2, 0x78, 0x67, 0x6c, 0xb8, 0xaf, 0xd5, 0x34, 0x16, 0x24, 0x43, 0x70, 0xda, 0x2a, 0x82, 0xd8, 0x30, 0xbb, 0x10, 0xd6, 0xb2, 0xfa, 0xaf, 0xc4, 0x4f, 0x9a, 0xea, 0xc5, 0x2e, 0x24, 0x3d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4a, 0x7d, 0x58, 0xd4, 0xb9, 0xbc, 0x82, 0xea, 0x2d, 0xed, 0x72, 0xa1, 0x29, 0x2e, 0xc6, 0x16, 0xdd, 0xd6, 0x7f, 0xc7, 0xf0, 0x57, 0xed, 0xf1, 0x3, 0x18, 0x95, 0x94, 0x67, 0x9d, 0xa2}),
-				new(big.Int).SetBytes([]byte{0xb9, 0x8a, 0xc5, 0xb7, 0x67, 0x2, 0xcb, 0x75, 0xe6, 0xb1, 0xd8, 0x14, 0x7e, 0xc7, 0x1b, 0x3b, 0x71, 0xc3, 0xb4, 0x94, 0x96, 0x3f, 0xa2, 0x8a, 0x48, 0x77, 0xf4, 0x84, 0x77, 0x9f, 0xfe, 0x26}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x44, 0x87, 0x97, 0x6d, 0xf3, 0x2a, 0x1e, 0x2, 0xf2, 0x95, 0xad, 0x96, 0x2d, 0xd9, 0x20, 0xd, 0xfc, 0xb1, 0xe3, 0xba, 0xb7, 0xbc, 0x6c, 0x96, 0x7c, 0xab, 0xab, 0xf9, 0xad, 0x13, 0x28, 0x96}),
-				new(big.Int).SetBytes([]byte{0x27, 0xbd, 0x58, 0x60, 0xd1, 0x15, 0xaf, 0xe1, 0xef, 0xc9, 0xa9, 0x7, 0x74, 0x56, 0x1a, 0x33, 0x6, 0xb4, 0xd, 0x5a, 0x62, 0x76, 0xaa, 0x7a, 0x48, 0xc0, 0x1b, 0x12, 0xaf, 0x68, 0x52, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8f, 0x3c, 0xcf, 0x31, 0xf8, 0xb7, 0x4b, 0x9b, 0x62, 0x4a, 0x5d, 0x1b, 0x7c, 0xb1, 0x9, 0x6e, 0x20, 0x2f, 0xe5, 0xe7, 0x23, 0x37, 0x77, 0xaa, 0x85, 0x98, 0x64, 0xd3, 0x77, 0x57, 0x32, 0xc0}),
-				new(big.Int).SetBytes([]byte{0x67, 0xf1, 0xcd, 0x3e, 0x2d, 0x39, 0xe5, 0x31, 0x89, 0xef, 0x46, 0xd9, 0x97, 0xce, 0x53, 0xd8, 0xa9, 0xe, 0x68, 0x77, 0x54, 0x85, 0x88, 0x25, 0xe7, 0x3b, 0xde, 0x5e, 0x65, 0xb9, 0x41, 0x5b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4a, 0x4d, 0x3a, 0xc2, 0x8b, 0xcb, 0x83, 0x78, 0x8b, 0x6a, 0x9c, 0xaa, 0x83, 0x35, 0x3, 0x24, 0x74, 0x0, 0xf8, 0x2b, 0xa0, 0x62, 0x73, 0xe6, 0xe0, 0x81, 0x56, 0xf6, 0xbf, 0xa2, 0x67, 0xc}),
-				new(big.Int).SetBytes([]byte{0x70, 0xab, 0xb9, 0x1c, 0x1, 0x84, 0x5a, 0x4f, 0x17, 0xe7, 0x71, 0x1d, 0xde, 0xf0, 0x2b, 0x9d, 0x49, 0x30, 0x59, 0x4c, 0xbf, 0x29, 0xbe, 0xed, 0x80, 0x8b, 0x9f, 0xfd, 0xd6, 0xc1, 0x76, 0x4d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xee, 0x7d, 0x69, 0xc4, 0xcb, 0xd0, 0x1, 0xc7, 0xfc, 0x76, 0xc5, 0xe2, 0xc0, 0x66, 0xce, 0x49, 0x96, 0xf8, 0x80, 0x8a, 0x1e, 0x7, 0xb2, 0xa9, 0xcc, 0xf3, 0x4e, 0xad, 0xc8, 0x7c, 0x4b, 0x65}),
-				new(big.Int).SetBytes([]byte{0xec, 0xc8, 0x62, 0x6e, 0xc1, 0xa4, 0x13, 0x82, 0x1a, 0x19, 0x2a, 0xbf, 0x3, 0xf, 0x2e, 0xe2, 0xc3, 0x3e, 0x89, 0x99, 0xba, 0xe9, 0x42, 0xe5, 0x23, 0xe8, 0xf4, 0x4e, 0xd1, 0x36, 0xa9, 0x5a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5f, 0x7b, 0x2d, 0x19, 0xa, 0xe9, 0x18, 0x2, 0xc3, 0xae, 0x6f, 0xd8, 0x9f, 0xf8, 0xfe, 0x18, 0x90, 0x31, 0x8, 0xc6, 0x39, 0x34, 0x5c, 0x53, 0xd, 0x96, 0xaf, 0xe2, 0x4c, 0xb2, 0x4a, 0xa7}),
-				new(big.Int).SetBytes([]byte{0x7, 0x70, 0x36, 0x0, 0xc4, 0xfd, 0xf2, 0xb3, 0x30, 0xa3, 0xf8, 0xa, 0x3c, 0xe6, 0x7c, 0x47, 0xce, 0x8d, 0xeb, 0x70, 0x93, 0xc8, 0x1d, 0xc, 0x6d, 0x78, 0x7, 0x43, 0x4a, 0x69, 0x3d, 0x7d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x50, 0xd7, 0x75, 0xb5, 0xf7, 0x2d, 0x18, 0x6b, 0x26, 0x6a, 0x14, 0xe5, 0x25, 0x4c, 0xb5, 0xaa, 0x49, 0xfd, 0x86, 0x33, 0x81, 0xc3, 0x6b, 0x9, 0x84, 0x26, 0x32, 0xd7, 0xff, 0x0, 0x41, 0x30}),
-				new(big.Int).SetBytes([]byte{0x34, 0x1, 0xee, 0x3b, 0xf, 0x4e, 0xa5, 0x57, 0xc5, 0x17, 0x24, 0x34, 0x60, 0x10, 0x6, 0x3f, 0x29, 0x75, 0xd9, 0x45, 0xee, 0xf6, 0xa4, 0xc3, 0x45, 0x6b, 0x8c, 0x64, 0xdf, 0x6e, 0x40, 0x6d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x51, 0x39, 0x74, 0x51, 0x33, 0x9d, 0x90, 0xd0, 0x2a, 0xec, 0x24, 0xe6, 0xd9, 0x48, 0x86, 0xd8, 0x7b, 0xc4, 0x64, 0xa1, 0x5f, 0xe4, 0x9, 0xa, 0x41, 0x91, 0xbc, 0x5f, 0x2f, 0x7f, 0xb8, 0xbd}),
-				new(big.Int).SetBytes([]byte{0xfc, 0xd7, 0x14, 0x3e, 0x47, 0x64, 0x3b, 0xf3, 0x4b, 0x88, 0xfe, 0xfd, 0xae, 0x81, 0x5c, 0x47, 0xfa, 0xfc, 0x32, 0xd3, 0xe3, 0xf9, 0x8d, 0x5a, 0x80, 0x94, 0xac, 0x2d, 0x83, 0x90, 0x8c, 0xf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x87, 0x99, 0x10, 0x26, 0xf1, 0xb2, 0x11, 0x96, 0x21, 0xea, 0x34, 0x69, 0xb1, 0x69, 0xb0, 0xeb, 0xc4, 0xfe, 0xb2, 0x8b, 0x45, 0x9, 0x5d, 0xa7, 0xf2, 0xa1, 0xf8, 0x39, 0x34, 0x88, 0x9f, 0x3f}),
-				new(big.Int).SetBytes([]byte{0xdd, 0xea, 0xb9, 0x4c, 0xa5, 0xa9, 0x1d, 0x96, 0x21, 0x32, 0xf2, 0xcb, 0x71, 0x7f, 0x27, 0x9d, 0xe9, 0x32, 0xf6, 0x1b, 0xf2, 0x86, 0x3, 0x7b, 0x49, 0xe2, 0xdf, 0x9b, 0xc3, 0xbb, 0x79, 0x2b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcf, 0x83, 0x16, 0xd6, 0x2b, 0xfb, 0xdf, 0xb8, 0xaa, 0x42, 0x91, 0x12, 0xbf, 0x1, 0x48, 0x87, 0xea, 0xc8, 0x64, 0xd3, 0x6b, 0xbb, 0xf3, 0xed, 0xe2, 0xb7, 0x49, 0x15, 0xee, 0x36, 0xca, 0x73}),
-				new(big.Int).SetBytes([]byte{0x9, 0xfe, 0xb5, 0x8d, 0xeb, 0x5d, 0x9d, 0x73, 0xe3, 0x84, 0x45, 0x8, 0x90, 0xdc, 0xca, 0x68, 0x1c, 0x96, 0x15, 0x98, 0xd8, 0x8d, 0x71, 0xf, 0x5b, 0x49, 0x21, 0x94, 0x2e, 0xc2, 0x55, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6e, 0x21, 0x14, 0xed, 0x29, 0x7b, 0xa4, 0x4f, 0xc3, 0x92, 0x66, 0x3, 0xbc, 0x3, 0xa8, 0x7c, 0xaf, 0x3e, 0x9f, 0x2c, 0x8b, 0xd8, 0x4e, 0x64, 0xaf, 0xcd, 0x98, 0x46, 0xbd, 0x9c, 0x8f, 0xa}),
-				new(big.Int).SetBytes([]byte{0x5, 0x59, 0x1a, 0xc, 0xc2, 0x6f, 0x6, 0xe2, 0x7d, 0xa6, 0xd5, 0xf, 0x80, 0xc5, 0xd9, 0x1d, 0x9, 0xb, 0xec, 0x75, 0xd0, 0xf9, 0x59, 0x5e, 0xbd, 0x79, 0x14, 0x8d, 0xd1, 0x6a, 0x2c, 0x9b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x86, 0x10, 0xde, 0x9a, 0x4c, 0x4b, 0xb4, 0x9d, 0xe5, 0x39, 0xd2, 0x86, 0x68, 0xcf, 0xa6, 0x16, 0x5a, 0x2e, 0xfb, 0xc7, 0x5f, 0x34, 0x76, 0x61, 0x3c, 0x89, 0x37, 0xd6, 0xb7, 0x52, 0xf9, 0x7d}),
-				new(big.Int).SetBytes([]byte{0xd3, 0x19, 0x97, 0xa0, 0x2b, 0x63, 0xb, 0xbc, 0x93, 0x7c, 0xd9, 0x9b, 0x25, 0x33, 0xf9, 0x9e, 0x57, 0xab, 0x4d, 0x9f, 0x54, 0x55, 0x43, 0xe0, 0x80, 0x57, 0xdc, 0xd4, 0x1f, 0x1b, 0x1a, 0xf2}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe7, 0xa2, 0x6c, 0xe6, 0x9d, 0xd4, 0x82, 0x9f, 0x3e, 0x10, 0xce, 0xc0, 0xa9, 0xe9, 0x8e, 0xd3, 0x14, 0x3d, 0x8, 0x4f, 0x30, 0x8b, 0x92, 0xc0, 0x99, 0x7f, 0xdd, 0xfc, 0x60, 0xcb, 0x3e, 0x41}),
-				new(big.Int).SetBytes([]byte{0x2a, 0x75, 0x8e, 0x30, 0xf, 0xa7, 0x98, 0x4b, 0x47, 0x1b, 0x0, 0x6a, 0x1a, 0xaf, 0xbb, 0x18, 0xd0, 0xa6, 0xb2, 0xc0, 0x42, 0xe, 0x83, 0xe2, 0xe, 0x8a, 0x94, 0x21, 0xcf, 0x2c, 0xfd, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf5, 0xca, 0xfa, 0xba, 0x3, 0x6b, 0xf8, 0xd0, 0xd, 0x38, 0xbf, 0xb6, 0x77, 0x20, 0x89, 0xf5, 0x20, 0x3c, 0x35, 0xe4, 0xd6, 0xe3, 0x2f, 0xa9, 0xd9, 0x7e, 0x5b, 0x91, 0x7b, 0x4a, 0xe8, 0x61}),
-				new(big.Int).SetBytes([]byte{0x19, 0xe8, 0x3b, 0x8a, 0x2, 0x2a, 0x6d, 0x81, 0x7b, 0xff, 0x99, 0x4, 0x64, 0x8, 0x39, 0x15, 0x9b, 0x3b, 0x2a, 0x9c, 0x55, 0x2f, 0x5, 0xf3, 0xcc, 0x9c, 0x23, 0x9c, 0xd, 0x82, 0x23, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcc, 0x34, 0x27, 0xe7, 0xd9, 0xb5, 0x91, 0x50, 0xfc, 0x7b, 0x6e, 0xdb, 0x91, 0xec, 0xbf, 0xc1, 0xd9, 0xbb, 0xbc, 0x5b, 0x47, 0x30, 0x71, 0x4a, 0x70, 0xf9, 0xfd, 0x2b, 0xfa, 0xee, 0x42, 0xdb}),
-				new(big.Int).SetBytes([]byte{0xea, 0x24, 0x98, 0x41, 0xa5, 0x21, 0xc6, 0xa1, 0x88, 0x5e, 0x3f, 0xd3, 0xfb, 0x21, 0x52, 0x0, 0x10, 0xe5, 0xcb, 0xad, 0x8e, 0x72, 0x42, 0x2d, 0xc6, 0x22, 0x9c, 0x1, 0x15, 0xd8, 0x7b, 0xdb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe9, 0x38, 0x90, 0x24, 0xce, 0xb6, 0x3f, 0x1f, 0x12, 0xdf, 0x51, 0x56, 0xd7, 0xe8, 0x5, 0x42, 0x8f, 0x9e, 0x50, 0x9c, 0x49, 0x4c, 0x98, 0x20, 0x84, 0xfd, 0x4c, 0xd7, 0xbd, 0x2a, 0x96, 0x51}),
-				new(big.Int).SetBytes([]byte{0x86, 0x48, 0x68, 0x87, 0x23, 0x72, 0x65, 0x95, 0xf9, 0x28, 0x7a, 0xba, 0xf6, 0x71, 0xaa, 0xf1, 0x8d, 0x71, 0x10, 0xce, 0xc6, 0x77, 0xb, 0xfe, 0xfe, 0xfd, 0xe2, 0xb7, 0x5e, 0x78, 0x68, 0x24}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x94, 0x8f, 0x5, 0xba, 0xcd, 0x98, 0x44, 0x5d, 0xec, 0x83, 0xc5, 0x85, 0xfb, 0xcb, 0x5c, 0xf4, 0xb1, 0xd7, 0x5c, 0x15, 0x8e, 0x9c, 0x41, 0xe, 0xff, 0x43, 0x66, 0xc6, 0x7e, 0xd4, 0xa0, 0x86}),
-				new(big.Int).SetBytes([]byte{0x86, 0x4c, 0xa8, 0x9f, 0xfb, 0x5a, 0x2a, 0x33, 0x82, 0xf4, 0x50, 0xa6, 0x60, 0x11, 0x39, 0x41, 0x1c, 0x23, 0x28, 0xa7, 0x1c, 0x3f, 0xa2, 0xd1, 0xa2, 0xa1, 0x80, 0xf, 0x9e, 0x2b, 0xca, 0x4b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x56, 0xbb, 0x14, 0x8f, 0x1, 0x98, 0x19, 0x7e, 0x20, 0x17, 0x77, 0x8, 0x33, 0x5e, 0xfc, 0xca, 0xff, 0x5f, 0x1, 0x60, 0xa, 0xe8, 0x0, 0x30, 0xcb, 0xa, 0x71, 0x65, 0x2e, 0x96, 0xe4, 0xf1}),
-				new(big.Int).SetBytes([]byte{0xa0, 0x95, 0x84, 0xa5, 0x61, 0x30, 0xa, 0x33, 0xbe, 0xb0, 0x3e, 0xd8, 0xbe, 0x30, 0xea, 0x9f, 0x73, 0x13, 0xd1, 0xd2, 0x0, 0x4a, 0xf0, 0xeb, 0x58, 0x89, 0xf0, 0x19, 0xee, 0xb0, 0x58, 0x2e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x25, 0x84, 0x19, 0x62, 0x92, 0x91, 0x9a, 0xc9, 0x6, 0x56, 0xfc, 0x45, 0x45, 0x1d, 0x6d, 0x43, 0xae, 0x19, 0xf4, 0xd2, 0x8e, 0xa6, 0x4c, 0x15, 0xc0, 0xcb, 0xea, 0x6c, 0xb7, 0x54, 0x2c, 0x21}),
-				new(big.Int).SetBytes([]byte{0xfc, 0xb3, 0x5b, 0x1f, 0x1c, 0xdb, 0x24, 0x48, 0x1, 0xe5, 0xda, 0xa4, 0xcc, 0x75, 0x13, 0xd5, 0xf3, 0xe3, 0xa1, 0x86, 0xe4, 0xc2, 0xad, 0x7b, 0x3b, 0xeb, 0xe3, 0x19, 0x67, 0x2b, 0xfa, 0xbf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x26, 0x45, 0x59, 0xd8, 0x78, 0x29, 0x25, 0x6b, 0xed, 0x11, 0x69, 0x0, 0xd8, 0x2d, 0xc, 0x37, 0x9f, 0xe, 0x4d, 0x12, 0x53, 0xc6, 0x8e, 0x6f, 0xcf, 0x2d, 0x41, 0xae, 0x7c, 0xdd, 0xab, 0x8b}),
-				new(big.Int).SetBytes([]byte{0x79, 0xe5, 0xbd, 0x19, 0x26, 0xd3, 0x51, 0x2c, 0xef, 0x7b, 0xc6, 0x37, 0x3, 0x40, 0x72, 0xd7, 0x7a, 0x86, 0x31, 0xaf, 0x39, 0xca, 0xf1, 0xe6, 0xc9, 0xf6, 0x4b, 0x45, 0x0, 0x1d, 0xe4, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7e, 0x12, 0xcd, 0xc4, 0x13, 0x73, 0xc5, 0x25, 0x22, 0xa9, 0x75, 0x4c, 0xc1, 0xe8, 0x42, 0x1f, 0x64, 0x89, 0xf0, 0xde, 0x9a, 0xee, 0xd7, 0xca, 0x43, 0xc4, 0x61, 0xf4, 0xc, 0x1e, 0xcb, 0xf8}),
-				new(big.Int).SetBytes([]byte{0x71, 0x28, 0x4f, 0x88, 0x88, 0x8d, 0xc8, 0x37, 0x14, 0x3f, 0x94, 0xda, 0xbe, 0x4d, 0x12, 0x59, 0xc9, 0x69, 0xf6, 0x1d, 0x8f, 0xe1, 0xd9, 0xf5, 0x9d, 0x45, 0xb8, 0xcd, 0xb0, 0xbe, 0xd6, 0x15}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb6, 0xa0, 0xc8, 0xfd, 0x37, 0x3b, 0x52, 0xbb, 0x6e, 0xa3, 0x51, 0x57, 0x9c, 0x26, 0xe1, 0xc5, 0x9a, 0x95, 0x4f, 0xa1, 0x73, 0x93, 0xb0, 0x4a, 0xe2, 0xda, 0x28, 0xfb, 0x89, 0x8a, 0x26, 0xc1}),
-				new(big.Int).SetBytes([]byte{0x5e, 0xf1, 0x93, 0xbf, 0xda, 0x3, 0x74, 0x94, 0x9a, 0x90, 0x1e, 0x3f, 0xeb, 0xda, 0x7f, 0xac, 0xfc, 0x94, 0xa5, 0xe8, 0x58, 0xab, 0x5a, 0x6, 0xf5, 0x55, 0xe, 0xda, 0x8a, 0x78, 0x21, 0x7c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x8e, 0xc2, 0xff, 0x2a, 0x37, 0x42, 0xa6, 0x77, 0x2b, 0x91, 0xca, 0x7a, 0x5, 0xa6, 0xcf, 0x1c, 0xfe, 0xec, 0x47, 0x8e, 0x66, 0x7f, 0xf4, 0x17, 0xe6, 0x65, 0x1c, 0x14, 0x55, 0x7d, 0x86}),
-				new(big.Int).SetBytes([]byte{0xfd, 0xe6, 0xc3, 0xff, 0x4, 0xc1, 0x57, 0xab, 0x14, 0xa4, 0x7, 0x2d, 0xe5, 0x27, 0xac, 0x5f, 0x28, 0xd5, 0xf2, 0xef, 0xf, 0xc3, 0xf6, 0x92, 0xbd, 0xd9, 0xb1, 0x91, 0xb5, 0x16, 0x7e, 0xb9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb3, 0x98, 0xe8, 0x15, 0x3d, 0x67, 0xb, 0xbb, 0xbd, 0x3d, 0x48, 0x9e, 0x1f, 0xd8, 0xa0, 0xa6, 0xb6, 0xb2, 0x5f, 0xd7, 0x20, 0xc0, 0x36, 0xd8, 0xa4, 0xc5, 0x7f, 0x59, 0x81, 0xf2, 0x3d, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x69, 0xf5, 0x9c, 0x22, 0x57, 0x2d, 0xf4, 0x7f, 0x7, 0xf7, 0xfe, 0xb2, 0xfb, 0x0, 0xba, 0x52, 0xea, 0x79, 0x1d, 0x8a, 0xd2, 0x61, 0x56, 0xcd, 0x15, 0x83, 0xeb, 0xff, 0x83, 0x2f, 0xf3, 0xfc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfc, 0xd5, 0xc1, 0x7, 0x33, 0x96, 0x3a, 0x42, 0x53, 0x24, 0x36, 0xdc, 0x69, 0x7a, 0xc0, 0x7e, 0x5f, 0x8a, 0x6d, 0x5e, 0x92, 0x65, 0x69, 0x1d, 0x52, 0xb8, 0x2d, 0x3b, 0x47, 0x80, 0x76, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x18, 0xdd, 0xab, 0xe2, 0xe5, 0x52, 0x2c, 0x46, 0x3b, 0x72, 0xd, 0xb3, 0x5f, 0x72, 0xe3, 0x8c, 0xb1, 0x19, 0xb2, 0x2e, 0x71, 0x64, 0x63, 0xa4, 0xb5, 0x4e, 0x74, 0x20, 0x38, 0xe8, 0x51, 0xcb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x63, 0x4a, 0xd, 0xc1, 0xbb, 0x42, 0x5d, 0xff, 0xa6, 0xcd, 0xb, 0x20, 0x54, 0x63, 0x1a, 0x73, 0x48, 0xb8, 0x48, 0x9, 0x82, 0x4f, 0x28, 0xad, 0x2b, 0x1c, 0x47, 0xa6, 0x33, 0xe9, 0xfc, 0x8d}),
-				new(big.Int).SetBytes([]byte{0xd7, 0x5b, 0xe2, 0x1f, 0xe7, 0x5a, 0x4b, 0x6c, 0xaf, 0xe0, 0x5a, 0x65, 0x7d, 0xe4, 0xa9, 0xcf, 0x51, 0x9a, 0xd5, 0xa1, 0x8e, 0xd5, 0xc6, 0x16, 0x95, 0x87, 0xf3, 0xb, 0x27, 0x3f, 0x87, 0xb6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x90, 0xf, 0x2c, 0xee, 0x58, 0x43, 0xcb, 0xe5, 0x22, 0x38, 0xa5, 0xda, 0xf8, 0x26, 0x6e, 0x53, 0xf6, 0x8e, 0x48, 0xf4, 0x2, 0x27, 0x2e, 0x66, 0xbc, 0xe3, 0xbe, 0x30, 0x5d, 0x8e, 0xf6, 0x86}),
-				new(big.Int).SetBytes([]byte{0x73, 0x2a, 0xc1, 0x55, 0xec, 0x60, 0x42, 0x5a, 0xd6, 0x1c, 0x55, 0x1b, 0x13, 0x49, 0xd1, 0x10, 0x59, 0x6c, 0xdf, 0xf4, 0x3c, 0x91, 0x56, 0xd5, 0x7, 0x3a, 0x49, 0x67, 0xe1, 0x21, 0xa8, 0xcf}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb6, 0x45, 0x9e, 0xe, 0xe3, 0x66, 0x2e, 0xc8, 0xd2, 0x35, 0x40, 0xc2, 0x23, 0xbc, 0xbd, 0xc5, 0x71, 0xcb, 0xcb, 0x96, 0x7d, 0x79, 0x42, 0x4f, 0x3c, 0xf2, 0x9e, 0xb3, 0xde, 0x6b, 0x80, 0xef}),
-				new(big.Int).SetBytes([]byte{0x6, 0x7c, 0x87, 0x6d, 0x6, 0xf3, 0xe0, 0x6d, 0xe1, 0xda, 0xdf, 0x16, 0xe5, 0x66, 0x1d, 0xb3, 0xc4, 0xb3, 0xae, 0x6d, 0x48, 0xe3, 0x5b, 0x2f, 0xf3, 0xb, 0xf0, 0xb6, 0x1a, 0x71, 0xba, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe5, 0xd8, 0xe8, 0xf0, 0xd9, 0x82, 0x3c, 0x88, 0xe4, 0xd3, 0x6f, 0x73, 0x1, 0xf4, 0x15, 0x93, 0xb6, 0x89, 0x5, 0x76, 0xbe, 0x79, 0xc2, 0x11, 0x25, 0x3e, 0xf3, 0x75, 0x3, 0x3e, 0xb5, 0x1f}),
-				new(big.Int).SetBytes([]byte{0x4d, 0xc1, 0xe9, 0xb7, 0x86, 0x1e, 0x3e, 0x4, 0xab, 0xb1, 0x6a, 0x57, 0xd8, 0xfe, 0xee, 0xf0, 0xe5, 0x9, 0xdc, 0x46, 0xd9, 0xf0, 0xf5, 0x49, 0x79, 0xd5, 0xbd, 0x96, 0x5a, 0x62, 0xa2, 0xd9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1f, 0x90, 0xea, 0x77, 0x3a, 0xc3, 0xa6, 0xe2, 0xdd, 0xe6, 0xd, 0x30, 0x29, 0x66, 0x81, 0x67, 0x3d, 0x12, 0xba, 0x6b, 0xf2, 0x44, 0x8a, 0x8b, 0x43, 0x9c, 0xf2, 0x79, 0x31, 0x98, 0x88, 0xe9}),
-				new(big.Int).SetBytes([]byte{0x89, 0xbe, 0x36, 0x7c, 0x15, 0xda, 0xa1, 0xe, 0x95, 0x81, 0x53, 0xd2, 0x71, 0xeb, 0x96, 0xa8, 0x21, 0x37, 0x51, 0xfe, 0xe5, 0x95, 0x22, 0xe6, 0x56, 0xfd, 0xfc, 0x97, 0xef, 0x11, 0x3b, 0x79}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0xca, 0x27, 0xf7, 0x7d, 0xbc, 0x8c, 0x3d, 0xc5, 0x6b, 0xf, 0x73, 0x21, 0xba, 0xe0, 0xdd, 0xab, 0x66, 0xbe, 0x4f, 0xa8, 0xa3, 0x1, 0x17, 0x37, 0xa6, 0x76, 0x48, 0xf, 0x15, 0x5e, 0x64}),
-				new(big.Int).SetBytes([]byte{0xf4, 0xbb, 0x33, 0x56, 0x78, 0xfb, 0x14, 0xd4, 0xd1, 0x97, 0xd2, 0x24, 0x6c, 0x2, 0xd0, 0x4, 0x87, 0x5d, 0x41, 0x82, 0x1b, 0xca, 0xf0, 0xae, 0x1f, 0x3f, 0x33, 0x3c, 0x56, 0x1b, 0x32, 0x97}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0xa4, 0xe5, 0x4d, 0xed, 0xff, 0xe0, 0xad, 0x6e, 0x70, 0x2b, 0xad, 0x33, 0x4b, 0x52, 0xba, 0xde, 0x3, 0x44, 0x79, 0x91, 0xe6, 0xf2, 0xc6, 0x8, 0x5e, 0xfb, 0x6f, 0x35, 0x62, 0x22, 0x2c}),
-				new(big.Int).SetBytes([]byte{0xc9, 0xd6, 0x7d, 0x4e, 0x58, 0x16, 0xe8, 0x13, 0xb2, 0xca, 0xc2, 0xf8, 0x1d, 0x60, 0x9a, 0x52, 0xd4, 0xf9, 0xc5, 0x77, 0xad, 0xc9, 0x4, 0xc0, 0x8c, 0xb1, 0x66, 0x8c, 0xa8, 0x20, 0x1, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x2e, 0x1b, 0x3c, 0xd6, 0xc1, 0x5, 0xc7, 0xee, 0x74, 0x65, 0x48, 0x16, 0xce, 0xe7, 0x4e, 0xf7, 0x79, 0x3d, 0x9b, 0x11, 0x98, 0x87, 0x53, 0x93, 0xea, 0xb3, 0xdd, 0xc1, 0xbf, 0x6c, 0x42}),
-				new(big.Int).SetBytes([]byte{0x9a, 0xb, 0x74, 0xf3, 0xca, 0x8e, 0x22, 0x58, 0x12, 0x73, 0x22, 0x70, 0xd0, 0xd2, 0x45, 0x26, 0xbc, 0xa5, 0x87, 0x9b, 0x93, 0x1b, 0x6a, 0xac, 0x13, 0x36, 0x32, 0x8f, 0x87, 0xbc, 0x54, 0xcc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x61, 0x99, 0x1e, 0xbf, 0x23, 0x3c, 0xaa, 0xdd, 0x1d, 0x40, 0x7c, 0x5, 0xd8, 0x45, 0x5d, 0xbd, 0x12, 0xed, 0x3a, 0x63, 0xfa, 0xc9, 0x25, 0x25, 0x43, 0x7e, 0x4d, 0xc0, 0xf4, 0x3b, 0x46, 0xbb}),
-				new(big.Int).SetBytes([]byte{0x1, 0xc5, 0xe3, 0x8, 0xf3, 0xfd, 0xe4, 0x92, 0x95, 0x2f, 0x38, 0x71, 0x66, 0xfb, 0xad, 0xf1, 0x1c, 0xa8, 0x72, 0x9c, 0xa5, 0x2e, 0xcd, 0x8e, 0xf4, 0x8f, 0x59, 0x1, 0xcc, 0x20, 0xa8, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x68, 0xfb, 0x71, 0x80, 0x6, 0x86, 0xd7, 0xf2, 0x5e, 0xba, 0x10, 0x56, 0x11, 0xcf, 0xe7, 0x59, 0x1f, 0x47, 0x8e, 0x84, 0x7f, 0x51, 0xce, 0xe0, 0x6d, 0x4b, 0xc6, 0x29, 0xd6, 0xee, 0x24, 0x7c}),
-				new(big.Int).SetBytes([]byte{0xcd, 0x12, 0xd2, 0x34, 0x62, 0xdd, 0x96, 0x36, 0x73, 0x73, 0x54, 0x27, 0x50, 0x1b, 0xc, 0x7, 0x9a, 0x8d, 0x58, 0xb, 0x4, 0xc7, 0x3c, 0x9d, 0xae, 0x1f, 0x82, 0x2d, 0x1a, 0x1, 0x86, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xea, 0x27, 0xae, 0xa4, 0xb7, 0x87, 0xd3, 0x87, 0x49, 0x3, 0x9c, 0xdb, 0xd, 0x16, 0x26, 0x11, 0x8b, 0x9a, 0x94, 0x4f, 0x45, 0x72, 0x7d, 0x8f, 0x7e, 0x5b, 0xe3, 0xdd, 0xd2, 0x1a, 0xdd, 0x3b}),
-				new(big.Int).SetBytes([]byte{0xc7, 0xf, 0xf1, 0xe6, 0xe4, 0x2a, 0x17, 0xde, 0x57, 0x23, 0xce, 0xb2, 0x32, 0x5c, 0x2f, 0x62, 0x78, 0xb2, 0x12, 0xd6, 0x8e, 0x4e, 0x1b, 0xe7, 0x1b, 0x5e, 0x29, 0x1d, 0xb6, 0x8c, 0xe7, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0x37, 0xf7, 0xb2, 0x3d, 0xf2, 0x60, 0xeb, 0x98, 0x75, 0x50, 0xab, 0x19, 0x34, 0x51, 0x16, 0x1b, 0x2b, 0x52, 0xc2, 0x23, 0x14, 0x25, 0xdf, 0x93, 0x45, 0xab, 0xdb, 0xe2, 0xda, 0x1f, 0x54}),
-				new(big.Int).SetBytes([]byte{0x5f, 0xe7, 0x8a, 0xd8, 0x4, 0x35, 0x80, 0x74, 0xe5, 0xcd, 0xac, 0xb1, 0x7f, 0x26, 0x0, 0xa7, 0xeb, 0xf0, 0x81, 0xd5, 0x46, 0xcf, 0xdb, 0xb8, 0x4a, 0xf8, 0xd4, 0x36, 0xcd, 0xc3, 0xed, 0xbc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x59, 0x46, 0x51, 0x35, 0x6b, 0x7f, 0xfd, 0x54, 0xba, 0x90, 0x10, 0xf8, 0xb6, 0xe2, 0xb6, 0xda, 0x9b, 0x4c, 0xe9, 0x74, 0x1c, 0x4c, 0x22, 0x6e, 0x6e, 0x8a, 0xf8, 0xbb, 0x61, 0x1f, 0xf7, 0x57}),
-				new(big.Int).SetBytes([]byte{0x36, 0x11, 0x36, 0xc, 0xe5, 0xdf, 0x27, 0x50, 0x4, 0x20, 0x38, 0xd0, 0x15, 0xc4, 0x6, 0xf9, 0x96, 0x1b, 0xbc, 0x7b, 0x7f, 0xbf, 0xfe, 0x3b, 0x28, 0xa6, 0x6e, 0xca, 0x89, 0x4d, 0x3, 0x1a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x84, 0x56, 0xa8, 0x52, 0xbc, 0xa1, 0x75, 0x7f, 0xea, 0x17, 0x39, 0x81, 0x39, 0xd1, 0x43, 0x48, 0x3d, 0xe7, 0x64, 0x14, 0xc8, 0xf3, 0x8f, 0x1c, 0xa1, 0xef, 0x47, 0xf1, 0x8f, 0x6c, 0x15, 0xc2}),
-				new(big.Int).SetBytes([]byte{0xe1, 0xb9, 0xda, 0x46, 0xd4, 0x84, 0x2c, 0xde, 0xfd, 0x34, 0x9e, 0xc3, 0x71, 0x7, 0x9e, 0x7f, 0x4c, 0xc9, 0xf, 0x9, 0x85, 0x6f, 0xb6, 0x71, 0xff, 0x1e, 0xfc, 0x32, 0x8b, 0x17, 0x41, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0xb2, 0x6e, 0x39, 0x15, 0xf7, 0x32, 0x90, 0xe8, 0x43, 0xaf, 0x79, 0x70, 0x9, 0x1e, 0xc0, 0xcc, 0xc5, 0x3f, 0x24, 0x70, 0xe7, 0xeb, 0xc0, 0x67, 0x62, 0x58, 0xa3, 0x63, 0x61, 0x60, 0xb5}),
-				new(big.Int).SetBytes([]byte{0x6, 0xf, 0x56, 0x5a, 0x63, 0x2, 0xef, 0x8b, 0xb6, 0x36, 0x43, 0x52, 0x46, 0x36, 0x54, 0xd4, 0xe1, 0x36, 0x3a, 0xac, 0x70, 0xba, 0x3e, 0xf8, 0x14, 0x62, 0x5f, 0xbf, 0x58, 0xa1, 0xdc, 0xe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0x15, 0x15, 0x7c, 0x98, 0x35, 0x1c, 0xe6, 0x5c, 0x2, 0xec, 0xcf, 0x2c, 0xfa, 0xae, 0x4d, 0xd3, 0x6a, 0xee, 0xa2, 0xb0, 0xd2, 0xe5, 0x18, 0x3d, 0x61, 0xde, 0x93, 0x8e, 0x1b, 0x79, 0x3e}),
-				new(big.Int).SetBytes([]byte{0xc0, 0x94, 0xe3, 0x66, 0x7, 0x9d, 0xb9, 0x39, 0xfc, 0x9d, 0xa9, 0xdb, 0x9c, 0xd4, 0xa0, 0xce, 0xc0, 0xda, 0xff, 0x4b, 0x35, 0x4a, 0x47, 0xaa, 0xb4, 0xfc, 0xc1, 0xfd, 0xc3, 0xba, 0x98, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2, 0x4b, 0x52, 0x95, 0xc4, 0x55, 0x9f, 0x93, 0x1b, 0xea, 0x14, 0x3c, 0x84, 0x2c, 0xea, 0x85, 0xc9, 0x1e, 0xd7, 0x49, 0xf6, 0x47, 0x79, 0x1f, 0x39, 0x64, 0xd2, 0x89, 0x37, 0xb3, 0x2d, 0xb8}),
-				new(big.Int).SetBytes([]byte{0x60, 0x9f, 0xef, 0x8f, 0x5b, 0xff, 0x30, 0x9c, 0x7b, 0x6e, 0x28, 0x21, 0xcc, 0x5e, 0x5b, 0xe8, 0x62, 0xab, 0x68, 0x11, 0x20, 0x5b, 0x81, 0xa9, 0x59, 0x45, 0x4f, 0xaa, 0xaa, 0x64, 0xf7, 0x60}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd6, 0x8a, 0x80, 0xc8, 0x28, 0xb, 0xb8, 0x40, 0x79, 0x32, 0x34, 0xaa, 0x11, 0x8f, 0x6, 0x23, 0x1d, 0x6f, 0x1f, 0xc6, 0x7e, 0x73, 0xc5, 0xa5, 0xde, 0xda, 0xf, 0x5b, 0x49, 0x69, 0x43, 0xe8}),
-				new(big.Int).SetBytes([]byte{0xdb, 0x8b, 0xa9, 0xff, 0xf4, 0xb5, 0x86, 0xd0, 0xc, 0x4b, 0x1f, 0x91, 0x77, 0xb0, 0xe2, 0x8b, 0x5b, 0xe, 0x7b, 0x8f, 0x78, 0x45, 0x29, 0x5a, 0x29, 0x4c, 0x84, 0x26, 0x6b, 0x13, 0x31, 0x20}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf1, 0x6a, 0x40, 0x9c, 0x67, 0x7a, 0x40, 0xbe, 0x40, 0x2f, 0x8e, 0xfb, 0x37, 0x52, 0x37, 0x3c, 0xac, 0xed, 0x5, 0x3c, 0x6f, 0x70, 0x2b, 0x82, 0x8b, 0xda, 0x22, 0x2c, 0xa4, 0x12, 0xb6, 0xfd}),
-				new(big.Int).SetBytes([]byte{0x2a, 0x41, 0x31, 0x17, 0x14, 0x53, 0x27, 0x99, 0xd7, 0xa6, 0xa7, 0x5a, 0x74, 0xe3, 0xe, 0x4e, 0x16, 0x54, 0x6, 0x59, 0x24, 0x9e, 0xbc, 0xa4, 0x26, 0x8d, 0xae, 0x77, 0xec, 0xa0, 0x52, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x78, 0x15, 0xf7, 0x8f, 0x22, 0xbd, 0x72, 0x8c, 0x4c, 0x40, 0xb8, 0x3d, 0xa6, 0x1f, 0x16, 0xf4, 0x4f, 0x4e, 0xfe, 0x34, 0xca, 0xb7, 0xe2, 0x82, 0x35, 0xb4, 0x2a, 0xa1, 0x8c, 0xa1, 0xc4, 0xf9}),
-				new(big.Int).SetBytes([]byte{0xc1, 0xc6, 0x1, 0xe8, 0xcd, 0x39, 0xaf, 0x6a, 0x7d, 0xd9, 0x3c, 0xf3, 0x14, 0x58, 0xc3, 0x5d, 0x5c, 0xb4, 0x2d, 0xe6, 0x15, 0x77, 0xd1, 0xe1, 0x9a, 0xb1, 0xea, 0x36, 0xb7, 0x78, 0xbc, 0x15}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x41, 0x54, 0xb5, 0x6, 0xab, 0x76, 0x6f, 0x42, 0xfb, 0xe3, 0x7f, 0x69, 0x99, 0x76, 0xf8, 0x4d, 0xb8, 0x9f, 0x4f, 0x2f, 0x6b, 0xed, 0x98, 0x32, 0x5c, 0x1a, 0xb, 0x6e, 0x32, 0x6d, 0xd4, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x23, 0xad, 0x7, 0x50, 0x43, 0xc5, 0x98, 0x88, 0x94, 0xc6, 0xe4, 0x4d, 0x61, 0x2, 0x5f, 0xf6, 0x41, 0x4e, 0xa9, 0xd9, 0xd1, 0xe2, 0x2d, 0xd4, 0x6c, 0x85, 0x92, 0x95, 0x7, 0x5d, 0xed, 0x1c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc3, 0x92, 0x73, 0xcd, 0xa0, 0xec, 0x40, 0x17, 0x6e, 0x37, 0x7c, 0x64, 0x1, 0x6a, 0x6c, 0x6d, 0x51, 0x2d, 0xf6, 0x81, 0xef, 0x8f, 0x4d, 0x8e, 0x6d, 0x26, 0xe1, 0xc3, 0xee, 0x8c, 0x85, 0x30}),
-				new(big.Int).SetBytes([]byte{0x8c, 0x41, 0xbe, 0x42, 0x57, 0x43, 0x3c, 0x8e, 0x9d, 0x1, 0xd3, 0x94, 0x41, 0xc3, 0x19, 0x98, 0x50, 0xed, 0xfc, 0xbd, 0xa2, 0xee, 0xd7, 0x15, 0xc4, 0x3, 0xf, 0xf, 0x79, 0x84, 0x46, 0xc7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1a, 0x46, 0xb7, 0xe9, 0xfe, 0x99, 0xa4, 0xea, 0x49, 0x2f, 0xbc, 0x90, 0x32, 0x81, 0xb9, 0x24, 0x68, 0x31, 0xfe, 0x59, 0x93, 0x60, 0xaf, 0x53, 0xbd, 0xe4, 0xce, 0x8b, 0x43, 0xed, 0x59, 0x96}),
-				new(big.Int).SetBytes([]byte{0x68, 0x3c, 0xe8, 0x1e, 0xa3, 0xf1, 0xdc, 0x57, 0xe, 0x3e, 0xe0, 0x84, 0xa0, 0x63, 0xeb, 0xf5, 0x50, 0x87, 0x94, 0xa9, 0xef, 0x52, 0x74, 0x5c, 0x2e, 0xe4, 0xed, 0x11, 0xe8, 0xc8, 0x5c, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x31, 0x74, 0x47, 0xf5, 0x84, 0x11, 0xb0, 0x27, 0x52, 0x24, 0x31, 0xc1, 0xd0, 0x3b, 0x25, 0x16, 0x6c, 0x6e, 0x58, 0xc0, 0xdb, 0xf6, 0xa5, 0x31, 0xf2, 0x40, 0xbb, 0x23, 0x7a, 0x26, 0xc1}),
-				new(big.Int).SetBytes([]byte{0x75, 0x3b, 0x97, 0xb8, 0x7f, 0x5d, 0x8c, 0x69, 0xd2, 0x38, 0x41, 0x67, 0xc2, 0x59, 0xd8, 0xb7, 0xd3, 0x68, 0x36, 0xc5, 0xd7, 0xa8, 0x15, 0x25, 0xf5, 0x18, 0x36, 0xec, 0xec, 0xf7, 0x4d, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb7, 0x3c, 0x65, 0x27, 0x69, 0xcc, 0x95, 0xc1, 0x8, 0xa, 0x8d, 0x4d, 0xb, 0x59, 0x56, 0xea, 0x93, 0xe8, 0x6e, 0x49, 0xfc, 0x72, 0x7d, 0xdf, 0x4c, 0x51, 0xa7, 0xa6, 0x3f, 0x7f, 0x2, 0x46}),
-				new(big.Int).SetBytes([]byte{0x9a, 0x67, 0xdb, 0x10, 0x71, 0x74, 0xca, 0x9d, 0x4b, 0x53, 0x58, 0x93, 0xc5, 0xb6, 0xc1, 0xea, 0x1a, 0xd, 0x72, 0xe4, 0xc6, 0xe5, 0x54, 0xe5, 0x59, 0x7e, 0x51, 0x64, 0xea, 0x2a, 0x40, 0x7b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6a, 0x4b, 0xe6, 0xcb, 0x2, 0xaf, 0xe, 0x6c, 0x10, 0x64, 0xac, 0x50, 0x8b, 0xff, 0x23, 0x1a, 0x23, 0x93, 0x92, 0xda, 0x66, 0xf5, 0x56, 0x3, 0xd2, 0xb6, 0x28, 0xa9, 0x37, 0x39, 0xdc, 0x49}),
-				new(big.Int).SetBytes([]byte{0xf1, 0x5d, 0x8f, 0xaa, 0x2a, 0x89, 0x7, 0x59, 0x7d, 0xc1, 0x66, 0xe3, 0x60, 0xfa, 0x80, 0x58, 0xab, 0x7e, 0x2b, 0x4c, 0xb1, 0x4a, 0x4a, 0x4d, 0x3e, 0x6a, 0x2b, 0x9d, 0xf2, 0xed, 0xd5, 0xcf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x36, 0x59, 0xba, 0x70, 0x60, 0xd8, 0x20, 0xc, 0x95, 0x12, 0xfa, 0xcb, 0x5c, 0x73, 0x1, 0x11, 0x4c, 0xdc, 0x2b, 0x9b, 0xaa, 0x5b, 0xde, 0x14, 0x8a, 0xd9, 0xbf, 0x9d, 0xd8, 0xf8, 0xfb, 0x8c}),
-				new(big.Int).SetBytes([]byte{0x4e, 0xe7, 0xa9, 0xe8, 0x6, 0xe0, 0x1a, 0x17, 0x13, 0x97, 0x25, 0xf8, 0xec, 0x74, 0x98, 0x7f, 0x1e, 0x14, 0x2e, 0xd4, 0xe4, 0x4b, 0xb1, 0x35, 0x75, 0xe4, 0x6b, 0x8c, 0x7c, 0xc8, 0xa3, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1, 0xf7, 0xc6, 0x9f, 0x13, 0xae, 0x7f, 0x13, 0x44, 0xe9, 0x82, 0x60, 0x3e, 0xc9, 0x8a, 0x3c, 0x8b, 0xc9, 0xf, 0x86, 0x40, 0xa8, 0x6f, 0x67, 0x98, 0xbe, 0x8a, 0xc, 0x46, 0x98, 0x7f, 0xac}),
-				new(big.Int).SetBytes([]byte{0x9d, 0x4e, 0xcc, 0x3f, 0x36, 0xfd, 0xa2, 0x2d, 0xe5, 0xb1, 0x3, 0x6e, 0x32, 0x9a, 0xb6, 0xf9, 0xcb, 0x7f, 0x5d, 0xe2, 0x19, 0xf6, 0xbc, 0x26, 0x3f, 0x91, 0xe7, 0x38, 0x19, 0x17, 0x4c, 0x68}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7, 0x73, 0x3f, 0x67, 0xfd, 0x31, 0x77, 0x2c, 0x20, 0xd1, 0x11, 0xf5, 0x65, 0x7f, 0xdd, 0x2b, 0x88, 0xaa, 0x1e, 0xec, 0x6c, 0xf, 0x36, 0xaa, 0x1f, 0x4d, 0x35, 0x9c, 0xee, 0x2e, 0x60, 0x7b}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x9f, 0xe8, 0xfb, 0xac, 0x17, 0x82, 0x85, 0xea, 0xe6, 0x3a, 0x8c, 0x5b, 0xb1, 0x56, 0xf4, 0x89, 0xe3, 0xbd, 0xe0, 0x5b, 0xe0, 0x17, 0xd2, 0x6a, 0x2f, 0x31, 0x1d, 0xcc, 0x61, 0x47, 0x63}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x55, 0x7e, 0x94, 0x1, 0x76, 0x1f, 0xd3, 0x81, 0xcb, 0x70, 0x62, 0xed, 0xd8, 0xfa, 0x2b, 0xcb, 0x9c, 0x65, 0xfb, 0xe, 0x20, 0x31, 0x96, 0x26, 0x1d, 0x33, 0xa1, 0xbd, 0x76, 0xda, 0xba, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x7e, 0x8f, 0x3a, 0x60, 0x1f, 0xa7, 0xe0, 0x46, 0xd3, 0x5c, 0xfa, 0x15, 0xcc, 0xbc, 0x86, 0xc5, 0xe9, 0xb8, 0xf5, 0xfa, 0x43, 0x59, 0xa, 0x51, 0x37, 0x4a, 0x1b, 0xb7, 0xa5, 0x23, 0x16}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe3, 0xe4, 0x75, 0xa, 0x0, 0x31, 0xc, 0x48, 0x38, 0x92, 0x6, 0x54, 0xb6, 0xaf, 0xa0, 0x32, 0x79, 0x58, 0x9d, 0x52, 0x74, 0xe4, 0x81, 0x39, 0x6e, 0x22, 0xd9, 0xcd, 0x3c, 0x5, 0xfb, 0xdd}),
-				new(big.Int).SetBytes([]byte{0xf, 0xa7, 0x97, 0x1, 0xab, 0xad, 0x25, 0x90, 0x5, 0x23, 0x50, 0x49, 0x96, 0x1a, 0x12, 0x4, 0xb4, 0x65, 0x87, 0xbf, 0xc5, 0xcf, 0xaf, 0xfb, 0xcb, 0xa2, 0x23, 0x1d, 0x15, 0x35, 0xb8, 0x83}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0xda, 0xda, 0x82, 0x19, 0x98, 0x85, 0xa9, 0x3b, 0x29, 0xd4, 0xe, 0xa7, 0xb1, 0x1, 0x41, 0x9c, 0xc7, 0xa3, 0x2c, 0x66, 0x96, 0x36, 0xb8, 0x3, 0x8f, 0x52, 0xff, 0x29, 0xf5, 0x34, 0x1a}),
-				new(big.Int).SetBytes([]byte{0xbf, 0xea, 0x1e, 0x22, 0x89, 0x99, 0x36, 0x9f, 0x55, 0x2e, 0x35, 0xbf, 0x36, 0xab, 0x10, 0x17, 0xe9, 0xd6, 0xd2, 0xe5, 0xb4, 0x5e, 0x8f, 0xbc, 0xb5, 0x15, 0x80, 0x93, 0x65, 0x86, 0xc6, 0xcc}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0x4a, 0xed, 0x7d, 0xf6, 0x5c, 0x80, 0x42, 0x52, 0xdc, 0x2, 0x70, 0x90, 0x7a, 0x30, 0xb0, 0x96, 0x12, 0xae, 0xb9, 0x73, 0x44, 0x9c, 0xea, 0x40, 0x95, 0x98, 0xf, 0xc2, 0x8d, 0x3d, 0x5d}),
-				new(big.Int).SetBytes([]byte{0x64, 0x8a, 0x36, 0x57, 0x74, 0xb6, 0x1f, 0x2f, 0xf1, 0x30, 0xc0, 0xc3, 0x5a, 0xec, 0x1f, 0x4f, 0x19, 0x21, 0x3b, 0xc, 0x7e, 0x33, 0x28, 0x43, 0x96, 0x72, 0x24, 0xaf, 0x96, 0xab, 0x7c, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0xc9, 0x33, 0x1e, 0xa2, 0x6f, 0x49, 0x2, 0x28, 0xd3, 0x26, 0x81, 0x88, 0xd, 0x72, 0x3, 0xf7, 0x2b, 0x3e, 0x4a, 0x8d, 0xe0, 0xdb, 0x1f, 0xa8, 0xf3, 0x83, 0x81, 0xb2, 0x91, 0x97, 0x49}),
-				new(big.Int).SetBytes([]byte{0xd7, 0xcd, 0x27, 0x2b, 0x34, 0x20, 0x9c, 0xb5, 0x69, 0x5a, 0x2f, 0x2, 0xb6, 0xf3, 0xdb, 0xb8, 0x26, 0x8a, 0x4a, 0xbd, 0xae, 0x39, 0xab, 0x9, 0x63, 0x1e, 0x97, 0xb0, 0xf2, 0x90, 0xb5, 0xe3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x20, 0x84, 0xb, 0xd5, 0x99, 0x67, 0x72, 0xad, 0x5b, 0x8f, 0x60, 0xb9, 0x31, 0xdf, 0x7c, 0x49, 0x16, 0x3f, 0x74, 0xfb, 0x9d, 0xa5, 0x6c, 0xce, 0xf5, 0xc9, 0x17, 0x58, 0x2f, 0xd5, 0x3e, 0xd3}),
-				new(big.Int).SetBytes([]byte{0xf2, 0x99, 0x34, 0x97, 0xce, 0xc1, 0x82, 0x43, 0x48, 0x7b, 0xd4, 0x76, 0xa6, 0xba, 0xfa, 0xc2, 0x54, 0x87, 0xc4, 0x73, 0x94, 0xe7, 0x8, 0x99, 0x87, 0x14, 0x3f, 0xe5, 0x1a, 0x7a, 0x71, 0x32}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0x29, 0x2f, 0x3b, 0x3b, 0x98, 0x37, 0x85, 0x4a, 0x2, 0xf6, 0xa7, 0xf, 0xec, 0x6b, 0x1c, 0x69, 0xc1, 0x61, 0xb6, 0xe1, 0x84, 0x6b, 0x8e, 0x1e, 0x1c, 0x22, 0x52, 0x7b, 0x97, 0x95, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x8c, 0x43, 0xc2, 0x5a, 0x96, 0xee, 0xbe, 0x80, 0x16, 0x96, 0x63, 0x4a, 0xf1, 0x45, 0x83, 0x5b, 0x57, 0x13, 0x1d, 0x75, 0x9, 0x11, 0x1c, 0x6f, 0x5b, 0x7e, 0x9d, 0x2f, 0xae, 0x53, 0xa0, 0xfe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe6, 0xb6, 0xbf, 0xf6, 0xe, 0xb3, 0x39, 0xbb, 0xbd, 0x13, 0xd0, 0x29, 0xe5, 0x88, 0xeb, 0xfd, 0x1a, 0xb5, 0xd8, 0x8a, 0x5c, 0xa, 0x12, 0x1e, 0xdb, 0xd2, 0xcb, 0xb5, 0x88, 0xa3, 0x5b, 0x35}),
-				new(big.Int).SetBytes([]byte{0x1a, 0x5f, 0xf2, 0xbd, 0x33, 0x0, 0xd2, 0xf3, 0x26, 0x6f, 0x43, 0xe3, 0x83, 0x59, 0x61, 0xdd, 0xfe, 0x3b, 0x6c, 0x9d, 0x3b, 0xe9, 0x99, 0xc7, 0xc5, 0x72, 0x93, 0xdb, 0x9c, 0x10, 0x7, 0xbd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x25, 0xaa, 0xc6, 0xbd, 0x9a, 0x6b, 0x26, 0x40, 0xed, 0x23, 0x74, 0xcb, 0x31, 0xff, 0x8f, 0x63, 0xce, 0x56, 0x6f, 0x50, 0xfa, 0x1f, 0xc6, 0xcc, 0xc4, 0x8b, 0x8e, 0x29, 0x20, 0x32, 0xf9, 0xa2}),
-				new(big.Int).SetBytes([]byte{0xf5, 0xa6, 0xc6, 0x3b, 0xa6, 0x44, 0x54, 0x6c, 0x16, 0xf3, 0x2f, 0x54, 0xf4, 0xf1, 0x90, 0xf6, 0x55, 0x98, 0x83, 0xbb, 0xc4, 0x19, 0xf3, 0xd9, 0xe7, 0x62, 0x30, 0xa1, 0x2b, 0x51, 0xd4, 0xf0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe0, 0x53, 0x17, 0x74, 0x5b, 0xe4, 0x99, 0xb2, 0x88, 0xb0, 0xb8, 0x8, 0x6e, 0xc, 0xe3, 0xbb, 0x47, 0xa8, 0xa8, 0x36, 0xd8, 0x50, 0xe2, 0x9, 0xd, 0x4e, 0xf8, 0xd2, 0xc0, 0x36, 0xd, 0xd3}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x1c, 0x3b, 0x71, 0xa5, 0xd9, 0x2f, 0x39, 0xcd, 0x80, 0x95, 0x82, 0xb5, 0xb6, 0xa0, 0x14, 0x61, 0xb9, 0x87, 0xde, 0x98, 0x10, 0x3d, 0xc2, 0xb2, 0xae, 0xcd, 0x91, 0x3c, 0x24, 0xf8, 0x7b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa6, 0x5a, 0x3a, 0x1, 0xdf, 0x3b, 0x5e, 0xf2, 0xe6, 0x20, 0xd4, 0x31, 0x0, 0x49, 0xfb, 0xe1, 0x4d, 0x71, 0x45, 0x7f, 0x19, 0xd1, 0xed, 0x35, 0xae, 0xa3, 0x9d, 0x57, 0x89, 0x30, 0x3f, 0xdd}),
-				new(big.Int).SetBytes([]byte{0x79, 0x8e, 0xa0, 0x94, 0xc, 0xff, 0x5c, 0x6f, 0xb8, 0xf4, 0x3d, 0x8d, 0x90, 0xed, 0x2c, 0x76, 0x86, 0x86, 0x1d, 0x2, 0x4f, 0xae, 0xd3, 0xca, 0xda, 0xd4, 0x4a, 0x8d, 0x2, 0xe6, 0x87, 0x3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa8, 0x15, 0x3b, 0x3a, 0x77, 0x88, 0x6c, 0x59, 0xe0, 0x18, 0xe5, 0xd2, 0xa8, 0x3a, 0x5e, 0x57, 0xfa, 0xdc, 0xb3, 0xc9, 0x2e, 0xa6, 0x78, 0x79, 0x7c, 0x14, 0x66, 0x62, 0xd0, 0x98, 0xdf, 0xea}),
-				new(big.Int).SetBytes([]byte{0x2a, 0x47, 0x39, 0x64, 0x61, 0xd0, 0x60, 0xfc, 0x53, 0xd8, 0xdb, 0x9c, 0x57, 0x85, 0x3e, 0x3, 0xb1, 0xb5, 0xe0, 0xf7, 0x42, 0xda, 0xf4, 0x15, 0x8a, 0x49, 0xa9, 0xdf, 0x6e, 0x6d, 0x89, 0x2f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x62, 0x81, 0xbb, 0x15, 0xd9, 0xd5, 0x67, 0xc2, 0x9a, 0x27, 0x88, 0x7f, 0xf6, 0xe9, 0xd5, 0x28, 0xc0, 0xae, 0xf4, 0xe6, 0xe1, 0x4e, 0x4a, 0x98, 0x61, 0x63, 0xd, 0x52, 0xd2, 0x4c, 0x94, 0x89}),
-				new(big.Int).SetBytes([]byte{0x2f, 0xd3, 0xc5, 0xa0, 0x90, 0x8d, 0xa8, 0x65, 0x92, 0x27, 0x38, 0xf4, 0x56, 0xe9, 0xbb, 0xf5, 0x45, 0xfd, 0x96, 0x4d, 0xd3, 0x21, 0xb3, 0xad, 0x91, 0x87, 0xf2, 0x6b, 0x9d, 0xf4, 0x2b, 0xc6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0x5, 0xc3, 0xb3, 0xf, 0xec, 0xad, 0xda, 0xde, 0xb4, 0x69, 0x5c, 0x63, 0x8e, 0xda, 0x3b, 0x2a, 0xd, 0x62, 0xda, 0x1b, 0x16, 0xbb, 0x2, 0xa, 0x7e, 0x77, 0xa4, 0x8b, 0xce, 0xcf, 0xa5}),
-				new(big.Int).SetBytes([]byte{0x53, 0xa4, 0x99, 0xea, 0x60, 0x3d, 0x42, 0x6f, 0xad, 0xd6, 0x32, 0x36, 0x15, 0x95, 0x34, 0xb9, 0x44, 0x7e, 0xe4, 0x31, 0xf0, 0x1b, 0x48, 0xcd, 0x2e, 0xcd, 0x2, 0x4a, 0x95, 0xd3, 0x7b, 0x53}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf8, 0x2f, 0xef, 0xbc, 0x9f, 0x6, 0xc6, 0xbf, 0xb1, 0x2f, 0x11, 0xb6, 0xc6, 0x1, 0xc8, 0xd5, 0xdf, 0x95, 0x35, 0xf0, 0x81, 0xcf, 0x1e, 0xc5, 0x90, 0x13, 0x1c, 0xba, 0x94, 0x44, 0x5a, 0x7a}),
-				new(big.Int).SetBytes([]byte{0x94, 0xbb, 0x37, 0xbd, 0xc6, 0xfb, 0x6b, 0x67, 0x55, 0x3, 0x94, 0x2c, 0x19, 0x40, 0xb4, 0xa4, 0xb5, 0x1c, 0x83, 0xb6, 0x46, 0x40, 0xd1, 0xe5, 0x96, 0x3a, 0xce, 0x18, 0x64, 0x2a, 0x7a, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd9, 0x30, 0x9a, 0xb9, 0x9f, 0x67, 0xa9, 0x1b, 0xe2, 0x64, 0x5, 0xe1, 0xaa, 0x3b, 0x98, 0xe, 0xd0, 0x29, 0x86, 0x35, 0x52, 0x2a, 0xbc, 0xce, 0x46, 0x1e, 0x76, 0x59, 0x8, 0x4b, 0x96, 0xaa}),
-				new(big.Int).SetBytes([]byte{0x6e, 0xf9, 0x9b, 0x2a, 0x9b, 0x4e, 0xc5, 0x57, 0xd7, 0xe1, 0xf, 0x6, 0x62, 0x74, 0xcb, 0x5f, 0xd3, 0xb6, 0xc9, 0xfb, 0xaf, 0xcf, 0xe9, 0x78, 0xf2, 0x6c, 0x4f, 0x37, 0xc2, 0xb2, 0x8a, 0x86}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0x85, 0xab, 0xaa, 0x97, 0x83, 0x42, 0x3, 0xc8, 0x30, 0xf3, 0xd3, 0x22, 0xef, 0x50, 0x2e, 0x26, 0x37, 0xb8, 0x4d, 0x1d, 0x56, 0xea, 0x66, 0xf6, 0xfd, 0xd4, 0x2b, 0x16, 0xb7, 0xd2, 0xd5}),
-				new(big.Int).SetBytes([]byte{0xe5, 0x6e, 0x96, 0x92, 0xcd, 0x1b, 0x7a, 0xc6, 0x92, 0xb9, 0x52, 0xdf, 0xe1, 0xb, 0x2e, 0x2a, 0xd8, 0x65, 0x73, 0x21, 0xb6, 0xb7, 0x53, 0xe9, 0xc4, 0x5, 0xd5, 0x2f, 0x90, 0x1, 0xf9, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7d, 0x58, 0x7c, 0xa1, 0x38, 0x56, 0x2d, 0x35, 0xba, 0x9c, 0x34, 0x46, 0xde, 0xaa, 0x34, 0x11, 0xd6, 0x5b, 0x6f, 0xfd, 0xe7, 0xc4, 0xa6, 0xfa, 0xcf, 0x7b, 0x8d, 0x5, 0x9f, 0x8f, 0xcf, 0xe}),
-				new(big.Int).SetBytes([]byte{0xa7, 0x29, 0x5c, 0x3, 0x88, 0x30, 0x4c, 0x61, 0x27, 0x23, 0x40, 0xb, 0x6c, 0xfd, 0x6a, 0xd0, 0xc4, 0x63, 0x90, 0xa, 0xd3, 0x23, 0x78, 0xa, 0x50, 0x1c, 0x1a, 0x9, 0xd8, 0xbc, 0x94, 0x59}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0xf9, 0xc1, 0x49, 0x19, 0xcd, 0xe6, 0x1f, 0x6d, 0x51, 0xdf, 0xdb, 0xe5, 0xfe, 0xe5, 0xdc, 0xee, 0xc4, 0x14, 0x3b, 0xa8, 0xd1, 0xca, 0x88, 0x8e, 0x8b, 0xd3, 0x73, 0xfd, 0x5, 0x4c, 0x96}),
-				new(big.Int).SetBytes([]byte{0x35, 0xec, 0x51, 0x9, 0x2d, 0x87, 0x28, 0x5, 0x9, 0x74, 0xc2, 0x3a, 0x1d, 0x85, 0xd4, 0xb5, 0xd5, 0x6, 0xcd, 0xc2, 0x88, 0x49, 0x1, 0x92, 0xeb, 0xac, 0x6, 0xca, 0xd1, 0xd, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xed, 0x32, 0xca, 0xd8, 0xd2, 0xcc, 0x99, 0x8c, 0xd2, 0x53, 0x17, 0xd4, 0xe4, 0xb8, 0x70, 0x88, 0xe9, 0xde, 0x45, 0x54, 0xe5, 0x7a, 0x8d, 0x70, 0xc0, 0xc6, 0xb0, 0xfc, 0x1d, 0xa4, 0x9e, 0x4}),
-				new(big.Int).SetBytes([]byte{0x12, 0x9f, 0xef, 0x5f, 0x1d, 0x3, 0x2, 0x4, 0xa5, 0x41, 0xca, 0x37, 0x58, 0x59, 0xd2, 0xb, 0x52, 0xda, 0x9f, 0xac, 0xb4, 0x9f, 0xab, 0x7d, 0xb6, 0x31, 0x20, 0xd1, 0x7c, 0x1d, 0xb9, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0x49, 0xa3, 0x2d, 0xb2, 0x7e, 0x2c, 0xaf, 0x20, 0xe0, 0xbd, 0x1c, 0x9, 0xe3, 0xb6, 0x4b, 0x21, 0xf9, 0xbb, 0xd6, 0x98, 0x9b, 0xa2, 0x7e, 0xf4, 0xf2, 0x25, 0xda, 0x5d, 0xef, 0x0, 0x1d}),
-				new(big.Int).SetBytes([]byte{0x79, 0x9b, 0x7a, 0x79, 0x6, 0xd9, 0x66, 0xcb, 0xdf, 0x4c, 0xc3, 0xe, 0xd8, 0xa5, 0x94, 0x56, 0xeb, 0x14, 0x1e, 0x2a, 0x62, 0xc9, 0x70, 0x5a, 0xdd, 0x5d, 0xb1, 0xa7, 0xf0, 0x62, 0x47, 0x83}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe8, 0x21, 0xab, 0x72, 0x4d, 0x63, 0x60, 0xf1, 0x80, 0x49, 0xe4, 0x11, 0x1c, 0x70, 0x36, 0x6e, 0x28, 0xc3, 0x6d, 0xcb, 0x63, 0xc3, 0x40, 0x16, 0xcb, 0x74, 0x18, 0xd4, 0xe8, 0x83, 0xf8, 0x55}),
-				new(big.Int).SetBytes([]byte{0xad, 0xef, 0xcb, 0xf8, 0x63, 0xf5, 0x3c, 0xe3, 0x67, 0xd0, 0xd4, 0x11, 0x54, 0x16, 0xcf, 0x59, 0x8b, 0x3b, 0x19, 0xc6, 0x14, 0xec, 0x23, 0xef, 0xed, 0x4e, 0xc, 0x6a, 0x59, 0x85, 0x2d, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7e, 0x79, 0x8f, 0x30, 0xda, 0x7, 0xec, 0xd1, 0x23, 0xf0, 0x8b, 0xd9, 0x83, 0x53, 0x2e, 0x6e, 0xc9, 0x54, 0xde, 0xfb, 0x3c, 0x9, 0xec, 0x5a, 0x5, 0xae, 0xf1, 0xe5, 0xc5, 0x2e, 0xd4, 0xcb}),
-				new(big.Int).SetBytes([]byte{0xd2, 0x3d, 0xcc, 0xc4, 0xa2, 0x4d, 0xac, 0x83, 0x4, 0x1a, 0x95, 0x49, 0xde, 0xf2, 0xf0, 0x57, 0xb1, 0x73, 0x57, 0x1b, 0xff, 0x9f, 0x37, 0xa1, 0x25, 0x71, 0x66, 0x7, 0x94, 0xbb, 0x94, 0x62}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb5, 0x26, 0xab, 0x87, 0xf2, 0x86, 0x80, 0x2, 0xcf, 0x4e, 0x83, 0xf4, 0x6b, 0x5a, 0x27, 0x4f, 0x4d, 0x8c, 0x18, 0x94, 0x8c, 0x50, 0x47, 0x31, 0xa2, 0x47, 0x78, 0xac, 0x42, 0x6, 0xe3, 0x7a}),
-				new(big.Int).SetBytes([]byte{0x64, 0x1f, 0x35, 0xd7, 0x78, 0x10, 0xd, 0x8e, 0xd5, 0xca, 0xac, 0xed, 0x60, 0xa6, 0xf1, 0x8d, 0x28, 0xce, 0x53, 0x9b, 0x0, 0xfa, 0x39, 0x2a, 0xbf, 0x36, 0x78, 0xff, 0xf0, 0xc5, 0xcc, 0xab}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x22, 0x4f, 0xa2, 0x0, 0x31, 0x51, 0x47, 0x83, 0xad, 0x5b, 0x9e, 0x96, 0xba, 0x8c, 0x81, 0x8, 0x84, 0x63, 0xf1, 0x28, 0x63, 0xda, 0xc0, 0x67, 0xfd, 0xf3, 0xa0, 0xfb, 0xd3, 0x63, 0x8, 0x34}),
-				new(big.Int).SetBytes([]byte{0xbb, 0xe5, 0x4e, 0x32, 0xbb, 0x3e, 0xda, 0x5c, 0x3c, 0x4b, 0x70, 0xfa, 0x39, 0x1d, 0x9, 0xf5, 0x3d, 0x62, 0x92, 0x4e, 0xc0, 0xa5, 0xf, 0x75, 0xcd, 0x3c, 0x47, 0x37, 0xc4, 0x7a, 0x22, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3f, 0xd, 0x89, 0x94, 0xe5, 0x1a, 0xd2, 0x12, 0xf4, 0x55, 0x45, 0x2f, 0xbc, 0x96, 0x93, 0xa7, 0x2f, 0x14, 0xa5, 0x47, 0xaf, 0x38, 0x6, 0xe9, 0xfb, 0xff, 0x59, 0xee, 0xb4, 0x41, 0x74, 0x2e}),
-				new(big.Int).SetBytes([]byte{0xfb, 0xd7, 0x6c, 0x23, 0xf2, 0x8c, 0x3d, 0xc4, 0x45, 0xe5, 0xcb, 0xe, 0x84, 0x7a, 0x6e, 0xb, 0x1e, 0x20, 0x5e, 0x2c, 0x3a, 0xd1, 0x3d, 0x95, 0x8c, 0x65, 0x36, 0x3b, 0xcf, 0xec, 0xad, 0xbe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc2, 0xd4, 0xa0, 0xcc, 0x60, 0xa, 0x39, 0xe4, 0x12, 0x9a, 0x25, 0xcc, 0xe4, 0x22, 0x8e, 0xf2, 0xbb, 0xbb, 0x5e, 0x45, 0xac, 0x2c, 0xf5, 0x7b, 0xb6, 0x76, 0x49, 0xd1, 0x58, 0x3c, 0x19, 0xd7}),
-				new(big.Int).SetBytes([]byte{0x65, 0xad, 0xa0, 0xf9, 0xc0, 0x80, 0x62, 0x94, 0xd4, 0x18, 0x3b, 0x5f, 0x7f, 0x41, 0x26, 0xd, 0x26, 0x80, 0x4a, 0x83, 0x17, 0x34, 0x5c, 0x97, 0x72, 0xf2, 0x8b, 0x82, 0xc5, 0x7, 0x92, 0x8d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0x93, 0x79, 0x9a, 0xbb, 0x39, 0xd3, 0xbe, 0x5, 0x7, 0x2c, 0xe1, 0xe, 0x94, 0x84, 0x54, 0x1c, 0xc9, 0xb1, 0x0, 0xfc, 0x9e, 0x27, 0xbb, 0xa0, 0x45, 0x41, 0xe5, 0x5d, 0x84, 0x81, 0x16}),
-				new(big.Int).SetBytes([]byte{0xc4, 0x9c, 0x43, 0x3b, 0x9c, 0xb2, 0xd8, 0xc5, 0x4e, 0x6a, 0xf6, 0xf, 0x16, 0xc6, 0xd, 0xee, 0xc0, 0x31, 0x93, 0xe5, 0xc4, 0xd9, 0x92, 0xe4, 0x83, 0xdf, 0x66, 0xc2, 0x2, 0x71, 0x98, 0xb3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2e, 0xc2, 0x2a, 0x7e, 0xc3, 0x5, 0xf3, 0x19, 0xe, 0x55, 0x85, 0xe2, 0x74, 0x4d, 0xdc, 0x21, 0x13, 0xcf, 0x3c, 0xe1, 0x3a, 0xf4, 0x23, 0x30, 0x84, 0xc8, 0xa, 0xd4, 0xbc, 0x94, 0xc, 0x9a}),
-				new(big.Int).SetBytes([]byte{0x8b, 0x1d, 0xa4, 0x68, 0xde, 0x33, 0x79, 0x68, 0x4c, 0x2e, 0x24, 0xf5, 0x2, 0x9c, 0x7f, 0xeb, 0x12, 0x40, 0x51, 0x83, 0xd9, 0xa3, 0x5b, 0xda, 0xcd, 0x19, 0x4f, 0xd9, 0x85, 0x1e, 0x7a, 0x4d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x34, 0x1b, 0x53, 0x8f, 0x72, 0xaa, 0x6a, 0x6f, 0x2f, 0x95, 0xf8, 0xec, 0xaf, 0xbd, 0x1e, 0x91, 0x29, 0xfc, 0x79, 0x1b, 0x1f, 0x88, 0xba, 0x7d, 0x7e, 0x93, 0x83, 0x1e, 0xb9, 0xd9, 0x12, 0x3f}),
-				new(big.Int).SetBytes([]byte{0x58, 0x58, 0x12, 0x47, 0x19, 0xc1, 0xc5, 0x9e, 0xba, 0x6f, 0x2f, 0x4, 0x11, 0x24, 0xd9, 0xac, 0x72, 0xde, 0xe6, 0xa7, 0x5, 0x2a, 0x26, 0xed, 0x19, 0xea, 0xa, 0xaf, 0x18, 0xbb, 0x9a, 0x40}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaf, 0xc6, 0x69, 0x73, 0x8f, 0x9a, 0x8c, 0xec, 0xab, 0x1a, 0xf6, 0x52, 0x50, 0x84, 0xb8, 0xf3, 0x3e, 0x36, 0xeb, 0x38, 0xa0, 0x8d, 0x31, 0x33, 0xaa, 0x70, 0xee, 0x5f, 0xdd, 0x68, 0x3e, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x2c, 0x21, 0x97, 0x6d, 0xd2, 0x27, 0x52, 0xbc, 0x35, 0x9c, 0x7f, 0xca, 0x23, 0x9c, 0x16, 0x57, 0xb, 0xab, 0x55, 0x25, 0xed, 0xdf, 0xdc, 0x91, 0xe2, 0x9c, 0xab, 0x30, 0xcd, 0x45, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0xe, 0xab, 0x3e, 0xc6, 0x8e, 0xb8, 0xde, 0x32, 0x84, 0xaf, 0xc3, 0xa7, 0x69, 0x96, 0x70, 0x6d, 0x4e, 0xbb, 0xdf, 0xf, 0x5a, 0x7b, 0x7f, 0xa7, 0xd0, 0x7b, 0x1c, 0xc7, 0xaa, 0xd7, 0x7f}),
-				new(big.Int).SetBytes([]byte{0xb2, 0x8e, 0xa2, 0x71, 0x76, 0xeb, 0xb7, 0x57, 0x5a, 0x8, 0xde, 0x30, 0x62, 0xac, 0x2f, 0x28, 0xad, 0xaa, 0x7, 0x8d, 0x2, 0x5a, 0xc9, 0x2c, 0xe6, 0xc3, 0xf1, 0x1d, 0xcc, 0xe6, 0xee, 0xa8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd2, 0x55, 0x7b, 0x5a, 0x9f, 0x34, 0x33, 0x9, 0x12, 0x1b, 0xc8, 0xcf, 0x37, 0xb, 0x28, 0x2e, 0x10, 0xe9, 0x78, 0xb5, 0x65, 0x3c, 0x51, 0x39, 0xea, 0x20, 0x1f, 0x87, 0xb7, 0x6f, 0x42, 0x93}),
-				new(big.Int).SetBytes([]byte{0xa7, 0x87, 0xb3, 0x43, 0x55, 0xe4, 0x27, 0xf0, 0x12, 0x68, 0x2f, 0x6, 0xeb, 0x4a, 0xd2, 0x1f, 0xe3, 0x4b, 0x68, 0x38, 0xde, 0x40, 0x5a, 0x5f, 0x1d, 0xb1, 0x87, 0xa2, 0x52, 0x82, 0x74, 0xa}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9c, 0x39, 0x19, 0xa8, 0x4a, 0x47, 0x48, 0x70, 0xfa, 0xed, 0x8a, 0x9c, 0x1c, 0xc6, 0x60, 0x21, 0x52, 0x34, 0x89, 0x5, 0x4d, 0x7f, 0x3, 0x8, 0xcb, 0xfc, 0x99, 0xc8, 0xac, 0x1f, 0x98, 0xcd}),
-				new(big.Int).SetBytes([]byte{0xdd, 0xb8, 0x4f, 0xf, 0x4a, 0x4d, 0xdd, 0x57, 0x58, 0x4f, 0x4, 0x4b, 0xf2, 0x60, 0xe6, 0x41, 0x90, 0x53, 0x26, 0xf7, 0x6c, 0x64, 0xc8, 0xe6, 0xbe, 0x7e, 0x5e, 0x3, 0xd4, 0xfc, 0x59, 0x9d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2e, 0x3c, 0x5, 0x32, 0x62, 0x55, 0xd8, 0xf, 0xa, 0x42, 0xfc, 0x69, 0xd5, 0xc9, 0x2a, 0xa4, 0xc, 0xd3, 0x26, 0xa5, 0x3e, 0x85, 0x35, 0xf0, 0x43, 0x5e, 0xfb, 0x7b, 0x69, 0x4a, 0x9, 0xec}),
-				new(big.Int).SetBytes([]byte{0x1f, 0xf8, 0x91, 0x65, 0x6c, 0x6f, 0xb5, 0xbd, 0xda, 0xe2, 0x40, 0xb8, 0x2f, 0xc1, 0xab, 0xe0, 0x48, 0xa5, 0x3c, 0x70, 0x7b, 0x66, 0x51, 0x25, 0x34, 0x86, 0x81, 0x88, 0xc7, 0x32, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x14, 0x23, 0x92, 0x29, 0xbd, 0xcc, 0xb7, 0x40, 0xbb, 0xb8, 0x3f, 0xbe, 0x53, 0xb8, 0xd6, 0xa7, 0xed, 0xe4, 0xca, 0x39, 0xdd, 0x53, 0x84, 0x17, 0xb9, 0x8d, 0x53, 0x8f, 0xb6, 0x4d, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x12, 0x37, 0xf6, 0xdc, 0x5b, 0x48, 0x6f, 0xc2, 0xa5, 0xce, 0xcd, 0xe4, 0xfe, 0x97, 0x8b, 0xd1, 0xa8, 0x75, 0x80, 0x90, 0x4d, 0x45, 0x67, 0xd1, 0xe2, 0x30, 0xce, 0x9f, 0xfc, 0x2, 0x59, 0xbe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe8, 0xe2, 0xa2, 0x4c, 0xcf, 0xa4, 0x15, 0x87, 0xae, 0x15, 0xfb, 0x7e, 0x3e, 0x24, 0xdd, 0xa4, 0x33, 0x71, 0x3, 0x16, 0xa1, 0x90, 0x89, 0x34, 0x20, 0x5f, 0x19, 0xa2, 0xab, 0x9c, 0x7c, 0xe6}),
-				new(big.Int).SetBytes([]byte{0x46, 0xc9, 0x83, 0xce, 0xc, 0x6f, 0x5d, 0x1b, 0x4c, 0xaf, 0x2b, 0x2b, 0x3b, 0xee, 0x20, 0x59, 0x6e, 0x9, 0xe6, 0x3, 0xb5, 0xc2, 0x7a, 0x73, 0xb2, 0xc0, 0x1e, 0xb6, 0x88, 0x36, 0x26, 0x7c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6c, 0x5b, 0x4b, 0xf8, 0x31, 0xa7, 0x72, 0x24, 0x8, 0x2d, 0x9c, 0x2c, 0x19, 0x26, 0x34, 0x71, 0x3a, 0x52, 0x21, 0x8c, 0x55, 0x45, 0x59, 0xea, 0x1e, 0xde, 0xd8, 0x34, 0x3, 0x8, 0x1e, 0x46}),
-				new(big.Int).SetBytes([]byte{0xcb, 0x5, 0x13, 0x71, 0x49, 0x26, 0xd4, 0x2f, 0xb2, 0x34, 0x78, 0x63, 0xce, 0x2b, 0xe4, 0x78, 0x41, 0xd0, 0xcf, 0x82, 0x6a, 0xc2, 0x2a, 0x62, 0xed, 0x1f, 0x9c, 0xb8, 0xb, 0xfb, 0xcd, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf8, 0x5, 0x83, 0x24, 0xc6, 0xb9, 0xc2, 0xe7, 0xe6, 0x21, 0x47, 0xe9, 0xa4, 0x1a, 0xd7, 0x8d, 0x60, 0xe3, 0xec, 0xf4, 0x17, 0x52, 0x4c, 0x5, 0x80, 0x83, 0x2a, 0xdd, 0xf1, 0x13, 0x49, 0xe2}),
-				new(big.Int).SetBytes([]byte{0x95, 0xc6, 0xe, 0x5a, 0xa, 0x88, 0x56, 0xcd, 0xcd, 0xe8, 0x1a, 0xa6, 0xe, 0xa1, 0x12, 0x23, 0x50, 0x94, 0x98, 0xb5, 0x62, 0x6d, 0xe8, 0x8d, 0x5f, 0xac, 0x46, 0x9e, 0x5b, 0x20, 0x25, 0xfc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe1, 0xe9, 0xa8, 0x56, 0x67, 0xc, 0xad, 0xe4, 0xb5, 0x67, 0x6, 0x65, 0xca, 0xb1, 0xa, 0x45, 0xc, 0x30, 0xc7, 0xd5, 0x99, 0x11, 0xc1, 0x24, 0xdc, 0xae, 0x5a, 0xec, 0x46, 0x4d, 0xcd, 0x4b}),
-				new(big.Int).SetBytes([]byte{0x56, 0x2b, 0xa, 0x95, 0x44, 0x55, 0xc5, 0x31, 0xb7, 0xac, 0x43, 0x59, 0x9b, 0x25, 0x77, 0x92, 0x7f, 0x44, 0xd1, 0x9a, 0xac, 0xa1, 0x6b, 0x29, 0x2d, 0xb, 0x62, 0x5e, 0xbb, 0x4, 0x1f, 0x2c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x54, 0x9a, 0xac, 0x5d, 0x85, 0x73, 0xc2, 0xb2, 0xf0, 0xa3, 0x8b, 0x17, 0x0, 0x32, 0xa2, 0x12, 0xac, 0xaf, 0x92, 0x38, 0x3d, 0x5b, 0x5f, 0x5b, 0xd, 0x39, 0x66, 0x8a, 0xc7, 0xb3, 0xc2}),
-				new(big.Int).SetBytes([]byte{0xbd, 0x17, 0xd1, 0xb9, 0xd, 0x1c, 0x24, 0x15, 0x33, 0x5a, 0x1d, 0x70, 0xc1, 0x94, 0x7d, 0x2b, 0x5d, 0x6b, 0x51, 0x15, 0x53, 0x71, 0x16, 0xdf, 0xfa, 0xc, 0x91, 0x71, 0x92, 0x87, 0xea, 0xef}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x51, 0xb2, 0x1a, 0x57, 0xad, 0x11, 0xb0, 0x99, 0x77, 0x8a, 0x74, 0xe4, 0x2e, 0xdc, 0x14, 0x20, 0x8f, 0xd9, 0xcd, 0xd9, 0x2, 0xa6, 0x4b, 0x7d, 0x0, 0x58, 0x76, 0xfe, 0x2b, 0xad, 0xd7, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x79, 0x30, 0x10, 0x0, 0x1f, 0xd3, 0xe5, 0xd5, 0x4a, 0x7, 0xf0, 0x1d, 0x2c, 0x1c, 0xfa, 0x6c, 0x20, 0x13, 0xf, 0x28, 0xc7, 0x34, 0xba, 0x6f, 0x7d, 0x4a, 0xe1, 0xeb, 0x36, 0xd8, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xca, 0x7, 0xcb, 0xfb, 0xb2, 0x4a, 0xd1, 0xa5, 0xed, 0xd9, 0xa1, 0x2a, 0x8a, 0xc5, 0x41, 0x57, 0x6f, 0x3f, 0x2b, 0xa1, 0x4b, 0x87, 0x8d, 0x82, 0xab, 0x7d, 0xc9, 0x96, 0xbd, 0x7e, 0x2c, 0x95}),
-				new(big.Int).SetBytes([]byte{0xae, 0xdc, 0x31, 0x10, 0x32, 0xdf, 0xe, 0xdf, 0x4a, 0x8a, 0x22, 0x67, 0xbc, 0x10, 0x66, 0xc9, 0x3a, 0xc3, 0x6, 0xf3, 0x3b, 0x7e, 0xc, 0xbf, 0x5, 0xe9, 0x9b, 0xac, 0x8d, 0x50, 0xdf, 0xa2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0x1, 0xf3, 0xa6, 0x3b, 0x1c, 0xb7, 0x98, 0x61, 0xab, 0x62, 0x96, 0xf3, 0xf3, 0x9d, 0x61, 0xe5, 0x88, 0x73, 0xe6, 0x8, 0xcc, 0x66, 0xce, 0x6d, 0x75, 0xd0, 0xb7, 0x3b, 0x9, 0xf3, 0x4b}),
-				new(big.Int).SetBytes([]byte{0x3d, 0xd4, 0x4b, 0xbb, 0x8c, 0xaf, 0xe, 0xd1, 0x5d, 0x70, 0x31, 0xb5, 0x31, 0x56, 0x83, 0x37, 0x71, 0x4, 0xbc, 0x39, 0x7f, 0xdc, 0xc7, 0x94, 0xac, 0xdc, 0x85, 0xc, 0xc0, 0xdf, 0x57, 0x93}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2e, 0x6d, 0xb0, 0xc9, 0xe4, 0x81, 0x7e, 0x29, 0x2f, 0xb0, 0x72, 0xb0, 0x53, 0x7a, 0x34, 0x1a, 0xdc, 0xe4, 0x88, 0x7d, 0x88, 0x98, 0x39, 0x23, 0x17, 0x37, 0x1d, 0x11, 0xae, 0x54, 0x84, 0x18}),
-				new(big.Int).SetBytes([]byte{0x74, 0xeb, 0x6a, 0x41, 0x1d, 0x77, 0x6f, 0xbf, 0xa2, 0x13, 0x14, 0x89, 0x78, 0xa9, 0x11, 0x64, 0x4b, 0x8e, 0x1d, 0x73, 0xe1, 0xc3, 0xf5, 0xf1, 0xe6, 0x97, 0x92, 0xc0, 0x10, 0xa2, 0xb9, 0x18}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa0, 0x36, 0xb4, 0x1d, 0x2c, 0x9e, 0x66, 0xcc, 0x44, 0x55, 0x92, 0xe0, 0x40, 0xd6, 0x3c, 0x57, 0x52, 0x70, 0xf7, 0x1e, 0x87, 0x27, 0x78, 0x30, 0x15, 0xce, 0x62, 0x23, 0xbf, 0x1e, 0x2f, 0x46}),
-				new(big.Int).SetBytes([]byte{0xc3, 0xbf, 0x91, 0xa0, 0x3, 0xe9, 0x6b, 0x3d, 0x7d, 0xf1, 0xf6, 0xaa, 0x47, 0x9e, 0xb0, 0x8d, 0x6, 0x80, 0xe2, 0x82, 0xcf, 0xf0, 0xb1, 0xf4, 0xf7, 0x31, 0xe2, 0x69, 0x5e, 0xff, 0xb3, 0x49}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb0, 0x9d, 0xcc, 0x4, 0xd9, 0xc3, 0xc, 0x35, 0x2b, 0xd6, 0x38, 0x80, 0xa, 0x76, 0x6d, 0xa1, 0xf6, 0x31, 0x42, 0x87, 0xdc, 0x20, 0x1b, 0xbf, 0x9, 0x60, 0x55, 0x16, 0x3, 0xdb, 0x2a, 0x9}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x7f, 0x30, 0x64, 0x81, 0xcf, 0x24, 0xd, 0xf7, 0x8, 0xd1, 0x63, 0x5a, 0xea, 0xd0, 0xe, 0x6a, 0x95, 0x1b, 0xd4, 0xc1, 0x6a, 0xd4, 0xe7, 0xae, 0xd7, 0xdb, 0x3a, 0x80, 0xb1, 0xa0, 0x93}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6a, 0x85, 0xfa, 0xdb, 0xaa, 0x4e, 0x8c, 0x50, 0x6f, 0x4a, 0x61, 0x15, 0x34, 0x0, 0x46, 0x52, 0x65, 0x4b, 0x58, 0xba, 0xe, 0xe, 0xa2, 0x1d, 0xbc, 0xd8, 0xb9, 0x3, 0x8c, 0x4c, 0xea, 0x8}),
-				new(big.Int).SetBytes([]byte{0xb6, 0x46, 0x4, 0xba, 0xe4, 0x65, 0x9d, 0x53, 0x19, 0x33, 0xc1, 0x48, 0xfe, 0x62, 0x30, 0xb4, 0x27, 0x1a, 0x4b, 0x43, 0xd7, 0x21, 0xb6, 0xa1, 0x9d, 0xec, 0xe5, 0x98, 0x62, 0xf4, 0xf3}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x60, 0x57, 0x17, 0xb, 0x1d, 0xd1, 0x2f, 0xdf, 0x8d, 0xe0, 0x5f, 0x28, 0x1d, 0x8e, 0x6, 0xbb, 0x91, 0xe1, 0x49, 0x3a, 0x8b, 0x91, 0xd4, 0xcc, 0x5a, 0x21, 0x38, 0x21, 0x20, 0xa9, 0x59, 0xe5}),
-				new(big.Int).SetBytes([]byte{0x9a, 0x1a, 0xf0, 0xb2, 0x6a, 0x6a, 0x48, 0x7, 0xad, 0xd9, 0xa2, 0xda, 0xf7, 0x1d, 0xf2, 0x62, 0x46, 0x51, 0x52, 0xbc, 0x3e, 0xe2, 0x4c, 0x65, 0xe8, 0x99, 0xbe, 0x93, 0x23, 0x85, 0xa2, 0xa8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x67, 0x73, 0xfd, 0x67, 0x7c, 0x52, 0xe0, 0x64, 0x3, 0x94, 0x11, 0xa, 0x46, 0xdc, 0x85, 0xdf, 0x7c, 0x13, 0x3f, 0x8d, 0xd4, 0xa2, 0x8e, 0x66, 0x18, 0x99, 0xca, 0x5d, 0x82, 0xfd, 0x54, 0x5c}),
-				new(big.Int).SetBytes([]byte{0x44, 0x4e, 0xb6, 0xd8, 0xcd, 0x97, 0x65, 0x2f, 0xf, 0xf, 0x25, 0xc9, 0xdd, 0x2b, 0x24, 0x6b, 0xea, 0xd7, 0x80, 0xf5, 0xa1, 0xc6, 0xcf, 0x98, 0xe8, 0xc7, 0xf0, 0x34, 0x94, 0x7e, 0xb1, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0xeb, 0xa8, 0xfc, 0xd6, 0xe0, 0x2, 0x60, 0x3d, 0xd1, 0x1b, 0x5f, 0xdf, 0xc7, 0x66, 0xc5, 0xff, 0x6b, 0x66, 0x8a, 0x17, 0xaf, 0xdc, 0x98, 0xd, 0x4d, 0xa1, 0x62, 0x97, 0x1c, 0x3, 0x2b}),
-				new(big.Int).SetBytes([]byte{0xd2, 0xff, 0x12, 0x62, 0x4b, 0x61, 0xd3, 0x9d, 0xef, 0x66, 0x5, 0x16, 0xf5, 0x4c, 0xbb, 0x7f, 0x71, 0x93, 0x1a, 0xd1, 0x77, 0x4b, 0x47, 0x55, 0xe7, 0xab, 0x5a, 0x8e, 0x16, 0x68, 0x35, 0x9f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe0, 0xf8, 0x6d, 0x94, 0xd1, 0x7c, 0xe5, 0x65, 0x23, 0x7c, 0x79, 0xaa, 0xce, 0xc, 0x87, 0xc2, 0x3, 0x74, 0xe4, 0x38, 0x10, 0x46, 0x80, 0x50, 0x37, 0x3c, 0x61, 0x6b, 0xb, 0x86, 0xf0, 0x21}),
-				new(big.Int).SetBytes([]byte{0xc, 0x57, 0x1c, 0x73, 0x73, 0xa, 0xbc, 0xf4, 0x7a, 0x91, 0xe8, 0x32, 0xf1, 0xc8, 0x9a, 0x2c, 0x9a, 0x80, 0xbc, 0xc0, 0x11, 0x5f, 0xc4, 0x5b, 0x3b, 0x6b, 0x79, 0xcc, 0xb5, 0xbf, 0x32, 0x5a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0x5e, 0xc3, 0x5, 0xb3, 0x24, 0x9d, 0x23, 0x2c, 0xa1, 0x74, 0x42, 0xba, 0xaa, 0x9d, 0xd0, 0x50, 0x78, 0x68, 0xf4, 0x69, 0x7, 0x5, 0x74, 0xd0, 0x6e, 0x47, 0x45, 0x2a, 0x3, 0xa6, 0x1c}),
-				new(big.Int).SetBytes([]byte{0xd, 0xd8, 0x5d, 0x2e, 0xc5, 0xf0, 0x1c, 0x17, 0xf5, 0x43, 0xcb, 0xff, 0x9b, 0x42, 0xfb, 0x4e, 0xd3, 0x32, 0xf7, 0x4e, 0xa1, 0x7e, 0x44, 0x96, 0x5d, 0xfc, 0x6e, 0xaa, 0xc6, 0x5d, 0xfc, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x41, 0x7f, 0xe2, 0x49, 0xd3, 0xc3, 0xae, 0x28, 0x79, 0x43, 0xeb, 0xc1, 0x8c, 0x46, 0x71, 0xff, 0x63, 0xef, 0xba, 0xb, 0xef, 0x78, 0x6c, 0xd6, 0x6d, 0xf0, 0xd9, 0xf7, 0x3a, 0xaa, 0x13, 0x8b}),
-				new(big.Int).SetBytes([]byte{0x2, 0x35, 0x89, 0xd7, 0xbc, 0xd2, 0x3e, 0x38, 0xc2, 0xa, 0x5d, 0x29, 0xf0, 0xfa, 0x9e, 0x57, 0xc1, 0x9d, 0xaf, 0xde, 0xc0, 0x55, 0x66, 0xaf, 0xd1, 0xc9, 0x1b, 0x33, 0x4a, 0x1a, 0xe8, 0x69}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3c, 0xe4, 0x48, 0x6a, 0xba, 0xb3, 0xfb, 0xf1, 0xf1, 0x50, 0xd2, 0x9a, 0x30, 0x95, 0xbf, 0xa2, 0x6, 0x18, 0xcc, 0xa4, 0x74, 0x6a, 0x6, 0x78, 0xb0, 0xc, 0xa, 0x48, 0x1f, 0x32, 0xd7, 0x6}),
-				new(big.Int).SetBytes([]byte{0xe7, 0x75, 0x40, 0x8d, 0xaa, 0xe3, 0x78, 0x52, 0x88, 0xef, 0xd0, 0x46, 0xb1, 0x9, 0x49, 0x6, 0xed, 0xd1, 0x56, 0x43, 0xcd, 0x61, 0xe8, 0x9f, 0x40, 0xb7, 0x36, 0x37, 0xfc, 0x7f, 0xd9, 0xe4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x42, 0xca, 0x15, 0xab, 0x9f, 0x24, 0x50, 0x41, 0xce, 0x99, 0x1e, 0x19, 0x3d, 0x69, 0x6f, 0x4f, 0x4c, 0x27, 0x7d, 0xf9, 0x8, 0xca, 0xd6, 0x3, 0x8a, 0xd0, 0x77, 0x2c, 0x2, 0xda, 0x6e, 0x3}),
-				new(big.Int).SetBytes([]byte{0x68, 0xd2, 0xef, 0x26, 0xc8, 0x1c, 0x57, 0xc9, 0x64, 0x7c, 0xe4, 0xd1, 0xfc, 0xb8, 0x0, 0xee, 0xd6, 0x6e, 0x85, 0xa6, 0x81, 0x6, 0xbe, 0xa7, 0x83, 0x68, 0x89, 0xfa, 0x8c, 0x34, 0x77, 0x93}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x11, 0xcc, 0xc5, 0x14, 0x3f, 0x4e, 0x37, 0xfa, 0xf0, 0x2e, 0x3, 0x21, 0x8f, 0x88, 0x44, 0xeb, 0x4a, 0x2a, 0x33, 0xfd, 0x97, 0x29, 0xbe, 0x68, 0x6d, 0xb4, 0xc, 0xbb, 0x79, 0x1c, 0xd3, 0xfb}),
-				new(big.Int).SetBytes([]byte{0x4a, 0xa5, 0x6b, 0x2a, 0x90, 0x2b, 0x11, 0xde, 0xb5, 0x28, 0xe2, 0x44, 0x93, 0x8c, 0xc2, 0x39, 0xfa, 0xb, 0x1e, 0xfd, 0x2c, 0xdd, 0x34, 0x72, 0xa9, 0x37, 0x16, 0xba, 0x55, 0x16, 0xd, 0x86}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0x89, 0x57, 0x3f, 0x16, 0xd0, 0xf7, 0xe5, 0x57, 0x27, 0x5d, 0xbb, 0xed, 0x94, 0x87, 0x1c, 0xb1, 0x52, 0x5, 0x99, 0x8b, 0x15, 0x68, 0x8, 0x10, 0x8b, 0xaf, 0x4, 0x29, 0x58, 0x3a, 0x3e}),
-				new(big.Int).SetBytes([]byte{0x1a, 0xfa, 0x28, 0x62, 0xc5, 0x58, 0x56, 0xfe, 0x4, 0x4f, 0x5d, 0xf8, 0xa9, 0xa6, 0xb4, 0xe4, 0x96, 0xfe, 0x29, 0x4c, 0xd4, 0xcc, 0x69, 0xcf, 0x8f, 0x15, 0x98, 0xcb, 0x7e, 0x5b, 0x12, 0x4f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2, 0x50, 0xbb, 0x17, 0xe5, 0x14, 0x9c, 0x6f, 0x43, 0xb9, 0x77, 0x74, 0xf8, 0x23, 0x82, 0xdd, 0xbd, 0x41, 0x13, 0x8b, 0x98, 0x5, 0x4f, 0xac, 0x38, 0x7b, 0x91, 0x19, 0x61, 0x8f, 0x75, 0x52}),
-				new(big.Int).SetBytes([]byte{0x73, 0x19, 0x30, 0xfc, 0x9b, 0xbe, 0x80, 0x82, 0x34, 0x2d, 0x7d, 0xf7, 0x5f, 0xe9, 0xd8, 0x17, 0xda, 0x0, 0x6f, 0x3c, 0x33, 0x3d, 0x6f, 0x1e, 0x3f, 0x8, 0x49, 0x62, 0x35, 0x10, 0xb1, 0x4d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x22, 0x19, 0xf1, 0x60, 0x16, 0x38, 0x76, 0x44, 0x3d, 0xdb, 0xc2, 0x9b, 0xda, 0x21, 0x12, 0xc8, 0xb4, 0xa7, 0x7d, 0xe7, 0x32, 0x16, 0x11, 0xd9, 0x7e, 0x15, 0x8b, 0x41, 0x3, 0x7, 0xe6, 0x36}),
-				new(big.Int).SetBytes([]byte{0x4, 0xa9, 0x84, 0xac, 0x5c, 0xb6, 0xa2, 0xf6, 0x1b, 0x80, 0x6d, 0x4f, 0x1b, 0x82, 0x30, 0xbd, 0x6d, 0xd7, 0x23, 0xd, 0x3f, 0x73, 0xaf, 0x55, 0xe0, 0x7a, 0x1b, 0x2d, 0x71, 0xf1, 0x51, 0x9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1a, 0x74, 0x9c, 0x88, 0xb3, 0x46, 0x7f, 0xd3, 0xab, 0x4a, 0x39, 0xce, 0xa, 0x2, 0x2f, 0xbf, 0xd9, 0x46, 0x42, 0xbb, 0xdc, 0xb5, 0xf4, 0x75, 0x5, 0xd1, 0x3e, 0x93, 0x9f, 0x72, 0x5d, 0x12}),
-				new(big.Int).SetBytes([]byte{0x7e, 0x91, 0xd1, 0x95, 0x73, 0xec, 0xe, 0x2c, 0xf4, 0x58, 0xbe, 0x75, 0xc2, 0xaf, 0x3d, 0x8f, 0x60, 0x72, 0x8, 0x69, 0x89, 0x90, 0xda, 0xa7, 0x1, 0x36, 0x51, 0x4d, 0x23, 0xac, 0x56, 0xe4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x7a, 0xa9, 0x7, 0xc9, 0x2f, 0x26, 0x74, 0xa1, 0x67, 0xf8, 0xa6, 0xe3, 0x50, 0x78, 0x24, 0x56, 0x4, 0x46, 0x60, 0xec, 0xde, 0x39, 0x4f, 0x5d, 0x22, 0xd6, 0x70, 0xb6, 0xc7, 0x4, 0xc0}),
-				new(big.Int).SetBytes([]byte{0xb8, 0x88, 0x1a, 0xe5, 0xbf, 0x35, 0xcf, 0xe4, 0xf2, 0x1e, 0x18, 0x8e, 0x5c, 0x2b, 0xd5, 0xa9, 0x4c, 0x92, 0x1f, 0xc4, 0x74, 0x15, 0x94, 0x62, 0xaf, 0x8b, 0xc7, 0x4, 0x7e, 0xdf, 0xea, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc7, 0x50, 0x68, 0x5b, 0xe0, 0xf8, 0x21, 0x8c, 0x10, 0x9d, 0x15, 0x6a, 0x5d, 0xfb, 0x7b, 0x32, 0x8b, 0xa, 0x5c, 0x42, 0x76, 0x6e, 0xc9, 0x7f, 0xd6, 0x10, 0x48, 0xe5, 0x29, 0xfa, 0xe4, 0x58}),
-				new(big.Int).SetBytes([]byte{0xea, 0xd7, 0x95, 0xf8, 0xcf, 0x92, 0x1e, 0x5, 0xfb, 0x52, 0xf4, 0x14, 0xc6, 0x72, 0xc7, 0xb4, 0xa, 0x5d, 0xca, 0x50, 0x33, 0x59, 0x51, 0xa2, 0xb0, 0x16, 0x84, 0x19, 0x57, 0x57, 0xb5, 0x98}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0x76, 0xdf, 0x8e, 0x23, 0xa0, 0x84, 0x11, 0x42, 0x14, 0x39, 0xa4, 0x51, 0x8d, 0xa3, 0x18, 0x80, 0xce, 0xf0, 0xfb, 0xa7, 0xd4, 0xdf, 0x12, 0xb1, 0xa6, 0x97, 0x3e, 0xec, 0xb9, 0x42, 0x66}),
-				new(big.Int).SetBytes([]byte{0x40, 0xa6, 0xbf, 0x20, 0xe7, 0x66, 0x40, 0xb2, 0xc9, 0x2b, 0x97, 0xaf, 0xe5, 0x8c, 0xd8, 0x2c, 0x43, 0x2e, 0x10, 0xa7, 0xf5, 0x14, 0xd9, 0xf3, 0xee, 0x8b, 0xe1, 0x1a, 0xe1, 0xb2, 0x8e, 0xc8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9e, 0x5d, 0xcc, 0x62, 0xef, 0x3b, 0x5a, 0x3b, 0x54, 0x65, 0x20, 0x86, 0x7b, 0xe7, 0x1b, 0xae, 0x6f, 0x3b, 0xa0, 0x63, 0xc9, 0xac, 0xfb, 0x8d, 0xce, 0xc5, 0x72, 0x5b, 0xda, 0x70, 0x48, 0x96}),
-				new(big.Int).SetBytes([]byte{0x6f, 0xed, 0xd1, 0x2d, 0xdb, 0x92, 0x5f, 0x3e, 0xa5, 0xfd, 0x3a, 0x21, 0x54, 0xc7, 0x61, 0x22, 0x79, 0x60, 0x5d, 0x18, 0x60, 0x30, 0xf5, 0x12, 0x48, 0xf2, 0x76, 0x9d, 0xca, 0x82, 0xc8, 0x35}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x28, 0x33, 0x6d, 0xcb, 0x74, 0xf5, 0x3e, 0x80, 0xbf, 0xc1, 0x87, 0x70, 0x5e, 0xdd, 0xe, 0xc2, 0x4e, 0x74, 0x5b, 0xc3, 0xd5, 0x93, 0xd6, 0xb6, 0x8a, 0xeb, 0x58, 0xcd, 0x9e, 0xd6, 0xc1}),
-				new(big.Int).SetBytes([]byte{0x71, 0xa8, 0x98, 0x38, 0x12, 0xfd, 0x9f, 0x28, 0xc4, 0x6d, 0x59, 0x43, 0xa2, 0xd, 0x7c, 0x8c, 0x26, 0x5b, 0xf4, 0xdf, 0x25, 0xcb, 0x49, 0x4a, 0xde, 0xc6, 0xeb, 0xe6, 0xf8, 0xfa, 0xfe, 0xee}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0xde, 0x8, 0x37, 0x5b, 0x87, 0x45, 0xad, 0xf8, 0xd6, 0xe9, 0xf9, 0x76, 0xf0, 0x3b, 0x20, 0xe3, 0x36, 0x25, 0xa0, 0x5c, 0xef, 0x58, 0x33, 0x95, 0x3e, 0xd5, 0x87, 0x44, 0xbf, 0x7e, 0xa0}),
-				new(big.Int).SetBytes([]byte{0xa6, 0x3d, 0x96, 0xb0, 0x57, 0xad, 0xa5, 0xe5, 0x21, 0x4, 0xa0, 0xb3, 0x34, 0x88, 0x8e, 0x9a, 0x64, 0x5a, 0x47, 0xc0, 0xfe, 0xbc, 0x5a, 0xa2, 0xe0, 0x4c, 0x5, 0x53, 0x9b, 0xbc, 0xab, 0xaa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xce, 0x4f, 0x4e, 0xae, 0x8b, 0x91, 0x1c, 0x54, 0xdc, 0x63, 0x92, 0x6d, 0x70, 0xfe, 0x15, 0x31, 0xe3, 0x8a, 0x10, 0x37, 0x1, 0x3b, 0xce, 0xb2, 0x91, 0x9a, 0x9a, 0x8d, 0x32, 0x35, 0x98, 0x3a}),
-				new(big.Int).SetBytes([]byte{0xf3, 0xc9, 0xf9, 0x73, 0xc3, 0x90, 0xfb, 0xbb, 0x3e, 0xad, 0xe0, 0x24, 0x9e, 0x70, 0x75, 0x43, 0x52, 0x6c, 0x65, 0x22, 0x8e, 0xbf, 0x74, 0xa, 0xec, 0xfd, 0x6b, 0x19, 0xd, 0x3c, 0xde, 0xcf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x69, 0xc, 0xda, 0xe3, 0x98, 0x39, 0x18, 0xb9, 0xdb, 0xde, 0xa2, 0xa7, 0x46, 0x31, 0xbc, 0x1e, 0x98, 0xc4, 0x99, 0x6e, 0xfd, 0xcd, 0xd9, 0xf8, 0x6b, 0x75, 0x64, 0x8a, 0x66, 0xda, 0x57, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x84, 0xa, 0xdc, 0x79, 0x67, 0x7b, 0x79, 0xa4, 0x7a, 0x9f, 0xd9, 0x1b, 0xf5, 0x95, 0x89, 0x4d, 0xf8, 0x63, 0xd8, 0xfb, 0xce, 0x95, 0xa3, 0xd4, 0x2b, 0x61, 0x95, 0xb4, 0x93, 0x3f, 0x33, 0xbb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4d, 0xbe, 0xbf, 0xa5, 0x4b, 0x98, 0x62, 0x22, 0x78, 0xe2, 0x8f, 0xb3, 0x6d, 0xf8, 0xbc, 0xbd, 0xdb, 0x5f, 0xf9, 0xcf, 0x78, 0x6e, 0x4c, 0x89, 0xa6, 0xda, 0xab, 0x66, 0x55, 0xb0, 0xe6, 0xc9}),
-				new(big.Int).SetBytes([]byte{0x10, 0xfe, 0xe7, 0xb0, 0x3c, 0x91, 0x3a, 0xed, 0xf4, 0x5a, 0x62, 0x6e, 0x6e, 0x22, 0x29, 0xf3, 0x58, 0x93, 0x11, 0xb2, 0xbc, 0x50, 0x4e, 0xfa, 0xe9, 0x4a, 0x78, 0x55, 0x5e, 0xb2, 0xcc, 0x25}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc2, 0x66, 0x65, 0x8e, 0x68, 0x90, 0x80, 0xc9, 0xc1, 0x3c, 0x35, 0xac, 0x1, 0xcf, 0xf4, 0xcb, 0xe6, 0x80, 0x65, 0xfd, 0xe9, 0x49, 0xe4, 0xa3, 0xa9, 0xf8, 0xfa, 0x10, 0x4a, 0xd9, 0x16, 0xfb}),
-				new(big.Int).SetBytes([]byte{0xe7, 0xe8, 0x59, 0x38, 0x54, 0xe7, 0xda, 0xab, 0xf, 0x79, 0x81, 0x70, 0xb2, 0x46, 0x27, 0xab, 0x6b, 0x8f, 0xec, 0xdf, 0xeb, 0x61, 0x13, 0x88, 0x56, 0xae, 0xf5, 0x2b, 0xa0, 0x88, 0x78, 0x14}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa4, 0x2a, 0x24, 0xb, 0xfe, 0xf4, 0x5c, 0x21, 0x8b, 0x2e, 0x11, 0x8f, 0xca, 0x1c, 0xa1, 0x20, 0x69, 0xc8, 0xe4, 0x7f, 0x3, 0x43, 0x3f, 0x1, 0x64, 0xe0, 0x1a, 0x11, 0xa5, 0x85, 0x72, 0x95}),
-				new(big.Int).SetBytes([]byte{0x3a, 0xa0, 0xb3, 0xf2, 0x61, 0x0, 0x5d, 0x45, 0x67, 0xa6, 0xb2, 0x2b, 0x24, 0xdd, 0xa4, 0xc4, 0x27, 0xa1, 0xc2, 0x2c, 0xd1, 0x51, 0x93, 0x95, 0x56, 0xb3, 0x77, 0xfe, 0xca, 0x7e, 0xb9, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa4, 0xb5, 0x3c, 0xc8, 0xbf, 0x53, 0xcd, 0x14, 0xda, 0xde, 0xd3, 0x82, 0xe8, 0xcc, 0xc3, 0xdf, 0x15, 0xb, 0x96, 0x87, 0x9b, 0xb9, 0xfd, 0x1e, 0x26, 0x9b, 0xcf, 0xd6, 0x0, 0x5c, 0x5b, 0x40}),
-				new(big.Int).SetBytes([]byte{0xce, 0xef, 0x8, 0xce, 0x83, 0x0, 0x5b, 0x80, 0x44, 0x95, 0xca, 0x0, 0x3d, 0xfd, 0xa5, 0x87, 0x19, 0x9c, 0xca, 0x9b, 0xf7, 0x52, 0x59, 0xe3, 0xd1, 0xf5, 0xed, 0x16, 0xd1, 0xc6, 0x1b, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x34, 0xe1, 0x2b, 0x2f, 0x96, 0xfb, 0x22, 0x26, 0x7a, 0x7f, 0x1f, 0x86, 0xc0, 0xc4, 0x99, 0xc1, 0x55, 0xa, 0xe0, 0xf, 0x6a, 0xe4, 0x68, 0x3, 0x22, 0x20, 0xff, 0x55, 0x6f, 0xcd, 0xc0, 0x98}),
-				new(big.Int).SetBytes([]byte{0xa6, 0x4a, 0xc2, 0xdd, 0xd7, 0xb5, 0xd3, 0x22, 0xd9, 0x74, 0xcb, 0xc8, 0x41, 0x79, 0x72, 0xdb, 0x4, 0xdf, 0x51, 0x30, 0xf1, 0x98, 0x83, 0xf6, 0x64, 0xcb, 0x1a, 0xde, 0x6a, 0x74, 0x74, 0xe2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcf, 0xf6, 0x27, 0x51, 0xfb, 0x16, 0x47, 0xb4, 0x8a, 0xe1, 0x17, 0xdd, 0x1f, 0xd6, 0x7e, 0x83, 0x3a, 0xd0, 0x9a, 0x92, 0xc0, 0xda, 0x35, 0x8, 0x5f, 0x98, 0x98, 0xaa, 0x5c, 0xfb, 0x80, 0x36}),
-				new(big.Int).SetBytes([]byte{0xcd, 0xf4, 0xed, 0x26, 0xbd, 0x54, 0x2d, 0x1a, 0xc5, 0xfe, 0x34, 0xb5, 0x88, 0x61, 0x43, 0xc6, 0x78, 0xa5, 0x89, 0xd8, 0xf6, 0x3, 0x28, 0x25, 0x84, 0x3f, 0x3b, 0x5a, 0x24, 0xf5, 0x9d, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x10, 0xf4, 0xd2, 0x40, 0xd9, 0xbb, 0x91, 0xf2, 0x9b, 0xe3, 0x1a, 0x21, 0x8f, 0x4d, 0x65, 0x68, 0x3b, 0x7e, 0x72, 0xf4, 0x16, 0x9f, 0xb7, 0x61, 0x1c, 0xd6, 0xeb, 0x5e, 0x8f, 0x17, 0x3b, 0x92}),
-				new(big.Int).SetBytes([]byte{0x85, 0xe, 0x2d, 0x95, 0x9, 0x17, 0x53, 0xad, 0x21, 0x1b, 0x25, 0xa5, 0xa3, 0x39, 0x8, 0xba, 0x90, 0xbb, 0x76, 0x9d, 0x14, 0x86, 0xad, 0xb7, 0xc9, 0x2, 0x0, 0x6d, 0x63, 0x31, 0x46, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xde, 0xa1, 0xfc, 0x1, 0x8a, 0x78, 0xf3, 0xb8, 0x43, 0x17, 0xf8, 0x93, 0x62, 0x35, 0x73, 0xe3, 0x86, 0x9d, 0xff, 0x25, 0x67, 0x74, 0x9d, 0xe1, 0xee, 0x66, 0x60, 0xed, 0xd0, 0x43, 0x93, 0xd7}),
-				new(big.Int).SetBytes([]byte{0x4f, 0x65, 0x49, 0xcc, 0x94, 0x2e, 0x5c, 0x50, 0xd3, 0xbf, 0x13, 0x4f, 0xf6, 0xd6, 0x6e, 0x3, 0xaf, 0x6d, 0xef, 0x9a, 0x2b, 0x79, 0xcc, 0xbd, 0xbf, 0x97, 0xd4, 0xef, 0x95, 0x71, 0xc, 0xcd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe8, 0x46, 0xe8, 0xb, 0x69, 0xb6, 0x77, 0xe7, 0x49, 0xf1, 0x9b, 0x97, 0x6d, 0x40, 0xcc, 0x86, 0x6a, 0x11, 0xde, 0xb1, 0x6c, 0xac, 0xc5, 0xca, 0xee, 0x6f, 0xd1, 0xd9, 0xa8, 0xd8, 0x49, 0x58}),
-				new(big.Int).SetBytes([]byte{0xa2, 0x9c, 0xb8, 0xfc, 0x89, 0x4c, 0x7e, 0x25, 0x41, 0xdb, 0xd9, 0x10, 0x47, 0x98, 0xb7, 0x6f, 0xad, 0x51, 0xa3, 0x36, 0x71, 0xe0, 0xc1, 0xa6, 0x40, 0xc4, 0x64, 0x7b, 0x78, 0x82, 0xcf, 0x9f}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x77, 0x78, 0xa7, 0x8c, 0x28, 0xde, 0xc3, 0xe3, 0xa, 0x5, 0xfe, 0x96, 0x29, 0xde, 0x8c, 0x38, 0xbb, 0x30, 0xd1, 0xf5, 0xcf, 0x9a, 0x3a, 0x20, 0x8f, 0x76, 0x38, 0x89, 0xbe, 0x58, 0xad, 0x71}),
-				new(big.Int).SetBytes([]byte{0x34, 0x62, 0x6d, 0x9a, 0xb5, 0xa5, 0xb2, 0x2f, 0xf7, 0x9, 0x8e, 0x12, 0xf2, 0xff, 0x58, 0x0, 0x87, 0xb3, 0x84, 0x11, 0xff, 0x24, 0xac, 0x56, 0x3b, 0x51, 0x3f, 0xc1, 0xfd, 0x9f, 0x43, 0xac}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe7, 0xb9, 0x79, 0x6b, 0x5c, 0xa0, 0x6, 0xd1, 0x63, 0x2f, 0x48, 0x2d, 0x7f, 0xf, 0xe3, 0x93, 0x2c, 0xf1, 0x6a, 0x5a, 0xe1, 0x4, 0xee, 0xa7, 0xa7, 0xea, 0x1c, 0x25, 0x10, 0x73, 0xe8, 0x79}),
-				new(big.Int).SetBytes([]byte{0x12, 0xb8, 0x98, 0x8c, 0x19, 0x16, 0x9e, 0x2f, 0xdf, 0x42, 0x10, 0x2a, 0x73, 0x7c, 0xc1, 0xca, 0x9c, 0xb5, 0xbf, 0x25, 0xed, 0xa9, 0x8a, 0xf3, 0x38, 0xe7, 0x10, 0x89, 0xba, 0xa8, 0x9d, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x30, 0x18, 0x4, 0x5d, 0x98, 0x17, 0x3f, 0xc8, 0xd0, 0x18, 0x39, 0x2, 0x2f, 0xcc, 0xac, 0xdc, 0x18, 0xa9, 0xf9, 0x5d, 0x76, 0x1e, 0xb2, 0x70, 0x2f, 0x7c, 0x6e, 0xae, 0x33, 0x19, 0xc8, 0x69}),
-				new(big.Int).SetBytes([]byte{0xac, 0x5f, 0xc5, 0x78, 0x25, 0x3, 0xb7, 0xb6, 0xf8, 0x66, 0x24, 0x32, 0xd, 0x62, 0x2e, 0x3b, 0xca, 0x2a, 0x84, 0xca, 0x4a, 0x3d, 0x9a, 0x7b, 0xfc, 0xc, 0x76, 0xc9, 0xd2, 0xb8, 0x56, 0xf0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7, 0x1b, 0xf0, 0x18, 0x50, 0x87, 0x62, 0x3, 0xc2, 0xc9, 0x15, 0xa2, 0x4b, 0xe0, 0x9a, 0x73, 0x65, 0x42, 0x3d, 0xaa, 0xf2, 0xae, 0xe9, 0x19, 0x86, 0x5d, 0x72, 0x2b, 0xf2, 0x62, 0x8f, 0xf}),
-				new(big.Int).SetBytes([]byte{0x52, 0x7a, 0xa1, 0x5d, 0x50, 0x4d, 0xcf, 0x4a, 0xe3, 0x36, 0x0, 0xbc, 0x1c, 0x8, 0x4c, 0xe2, 0x9, 0x8f, 0x9c, 0x6a, 0x23, 0x1c, 0x80, 0xbb, 0xb5, 0x7c, 0x5c, 0xbd, 0x45, 0xa1, 0xc3, 0x34}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x32, 0x28, 0x81, 0xb6, 0x1e, 0xe5, 0x7e, 0xf3, 0x43, 0xab, 0x67, 0xdb, 0x9a, 0x63, 0xc8, 0x85, 0xf5, 0x83, 0xb, 0x60, 0x71, 0x2a, 0xdd, 0x1b, 0x4c, 0x66, 0x98, 0x6f, 0xa5, 0xed, 0x29, 0xb5}),
-				new(big.Int).SetBytes([]byte{0x16, 0x77, 0x2, 0x84, 0x17, 0xa0, 0x34, 0x4e, 0xb1, 0x10, 0xb1, 0x9d, 0xf4, 0x15, 0x31, 0xe1, 0xca, 0x83, 0xae, 0xab, 0x94, 0xee, 0x76, 0x4, 0xef, 0x4f, 0x12, 0x6e, 0x7c, 0xd1, 0x5a, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa, 0xb, 0x2b, 0x4f, 0xed, 0xd, 0xdd, 0x23, 0x88, 0x12, 0x80, 0x6c, 0xf, 0xcc, 0xdf, 0xa9, 0x7f, 0xb3, 0xb4, 0x2a, 0x74, 0x87, 0x21, 0xae, 0x64, 0x77, 0xdc, 0x9b, 0x18, 0x95, 0x31, 0x33}),
-				new(big.Int).SetBytes([]byte{0xcd, 0xa1, 0x18, 0x2c, 0xfb, 0x5a, 0xbf, 0x2f, 0x3, 0xc1, 0xc6, 0xca, 0x86, 0x45, 0x8c, 0x8d, 0x6f, 0xa3, 0x84, 0xe8, 0xb0, 0xed, 0x4b, 0xa9, 0x4b, 0xc2, 0x4a, 0x37, 0x34, 0xaf, 0xf, 0xc9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3f, 0x38, 0x47, 0x3a, 0xc0, 0xfb, 0x1b, 0x9f, 0xfb, 0x43, 0xdd, 0x9b, 0x2d, 0xf3, 0xdb, 0xcc, 0xa1, 0x63, 0x1, 0x1a, 0xa6, 0xe9, 0xc8, 0xe6, 0x3b, 0xfa, 0x84, 0x7, 0x86, 0xc9, 0x61, 0x0}),
-				new(big.Int).SetBytes([]byte{0x70, 0xc6, 0x9c, 0x55, 0xf5, 0xd4, 0x3, 0x95, 0x25, 0x34, 0xc4, 0x46, 0x2f, 0x7, 0x9c, 0xe5, 0x66, 0x57, 0x1c, 0x7c, 0xb, 0x75, 0xf1, 0xbc, 0xf1, 0xe2, 0xac, 0x1b, 0xf2, 0x62, 0x47, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2, 0x18, 0x34, 0x3a, 0xcb, 0x9b, 0xe5, 0x68, 0x33, 0xa3, 0x2e, 0x59, 0x4c, 0x3, 0xc3, 0x9e, 0x5b, 0x19, 0x11, 0xc8, 0x50, 0x12, 0x13, 0x78, 0x6f, 0x63, 0x76, 0xdf, 0xa3, 0x96, 0x20, 0xe1}),
-				new(big.Int).SetBytes([]byte{0xbe, 0xa8, 0x1d, 0x48, 0x97, 0xa, 0x50, 0xbe, 0xaf, 0x3f, 0x24, 0xfd, 0x60, 0x2f, 0xbf, 0xc0, 0x44, 0x32, 0x99, 0xa4, 0x2f, 0x43, 0xc9, 0xec, 0x5e, 0x1, 0x99, 0xf6, 0x50, 0x69, 0x98, 0xb5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x28, 0x81, 0x13, 0xc5, 0xfd, 0x27, 0xa7, 0x6d, 0xcb, 0x43, 0xc0, 0xe0, 0x97, 0x85, 0xdf, 0x2f, 0x10, 0xdd, 0xfe, 0x12, 0x6d, 0xca, 0xac, 0xe0, 0x23, 0x4c, 0x6e, 0xc6, 0xfd, 0x22, 0xd2, 0xac}),
-				new(big.Int).SetBytes([]byte{0xa1, 0xa7, 0xeb, 0x1, 0x58, 0xfb, 0xc5, 0xc8, 0xf2, 0x7, 0x77, 0x26, 0xc, 0x97, 0x61, 0x37, 0xdd, 0xc3, 0xd4, 0x4a, 0x15, 0x48, 0xc8, 0xfd, 0x8, 0x1e, 0xb5, 0x5f, 0x8f, 0x42, 0xa2, 0x68}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0xbf, 0x9a, 0xfe, 0xe6, 0xee, 0xc1, 0x82, 0xe, 0xf5, 0x86, 0x6a, 0xb4, 0xbd, 0xfe, 0x2e, 0x9d, 0x4, 0x53, 0x23, 0x34, 0x3a, 0xa4, 0x22, 0x8c, 0x1a, 0x13, 0xae, 0xfe, 0xe5, 0x15, 0xdd}),
-				new(big.Int).SetBytes([]byte{0x5a, 0x11, 0xbc, 0x71, 0xc5, 0x5c, 0xab, 0x7b, 0x3a, 0xa, 0x5e, 0x2a, 0xbc, 0x5, 0xea, 0x28, 0x0, 0xf2, 0xe3, 0xaa, 0x9a, 0x8e, 0x98, 0x74, 0x5c, 0x3a, 0x96, 0xb0, 0x6, 0xc3, 0x2, 0x12}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf9, 0x63, 0xa2, 0x0, 0xc8, 0x46, 0x3a, 0x2a, 0x55, 0x3f, 0x4d, 0x95, 0xd7, 0x11, 0x9f, 0xe5, 0xd1, 0x72, 0x48, 0xe3, 0x96, 0x4d, 0x29, 0x0, 0xd4, 0xba, 0x80, 0xdd, 0x24, 0x5f, 0x8e, 0xa8}),
-				new(big.Int).SetBytes([]byte{0x9c, 0x85, 0x94, 0x26, 0x8c, 0x7f, 0x83, 0xc0, 0xa3, 0xb7, 0x22, 0x30, 0x1f, 0xf4, 0x27, 0x20, 0x8b, 0xb8, 0xf2, 0x35, 0x9d, 0x8b, 0x60, 0x82, 0x4b, 0xc5, 0x5f, 0xb0, 0x59, 0xbc, 0x99, 0xeb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6f, 0x86, 0x51, 0x8d, 0x7, 0xc3, 0x99, 0x7c, 0x3a, 0x83, 0x94, 0x57, 0x43, 0xa9, 0xb8, 0x92, 0xd5, 0x1d, 0xca, 0xb3, 0xf8, 0x16, 0x61, 0x1e, 0x7e, 0xaf, 0xe, 0xc0, 0xdf, 0xa, 0x2a, 0x53}),
-				new(big.Int).SetBytes([]byte{0xd5, 0xa, 0xf6, 0x16, 0xfd, 0x45, 0x82, 0xf2, 0x9b, 0x77, 0x6e, 0xa9, 0x5a, 0x70, 0x99, 0x68, 0xbf, 0x54, 0xf7, 0x72, 0xea, 0xa0, 0x5f, 0x35, 0x11, 0x39, 0xa5, 0x74, 0x73, 0x3b, 0xfe, 0xb2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0xd9, 0x22, 0x4c, 0xff, 0x70, 0xd9, 0xa7, 0xf9, 0x8d, 0x8b, 0x2a, 0x59, 0xe, 0x9, 0x7e, 0x40, 0x72, 0x60, 0x72, 0xf2, 0x69, 0x63, 0xad, 0x9e, 0x1a, 0x88, 0x31, 0x26, 0x6, 0xa3, 0x15}),
-				new(big.Int).SetBytes([]byte{0x87, 0x32, 0x8b, 0x0, 0x3b, 0x20, 0xda, 0x5b, 0x34, 0x33, 0x6, 0xab, 0xd7, 0x98, 0xd, 0xe9, 0xed, 0x4d, 0xd, 0x34, 0x7d, 0x11, 0xa7, 0xc9, 0xd3, 0x56, 0x13, 0x35, 0x9e, 0x3b, 0x68, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3b, 0xc2, 0x4b, 0xc9, 0xcb, 0xc5, 0x8d, 0xe3, 0x46, 0x64, 0x4d, 0xe9, 0xb, 0x17, 0xff, 0xa7, 0x39, 0xa0, 0xf7, 0xd8, 0x83, 0xeb, 0x9f, 0x52, 0xaf, 0x19, 0xea, 0xf4, 0xd8, 0xd5, 0x28, 0x91}),
-				new(big.Int).SetBytes([]byte{0xf0, 0x8e, 0x30, 0xa4, 0x8a, 0x78, 0x3d, 0x8a, 0x7f, 0xeb, 0xed, 0x84, 0xb, 0xfb, 0x48, 0xf4, 0x4d, 0xa8, 0x45, 0x28, 0xa, 0x48, 0x72, 0xd1, 0x38, 0x6d, 0x1e, 0x28, 0x4d, 0x5, 0xb7, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf1, 0xfe, 0x98, 0x2e, 0x1b, 0x73, 0xc3, 0x60, 0x4e, 0xb4, 0xe4, 0x1d, 0x0, 0xe5, 0xe6, 0xa4, 0x96, 0x41, 0x1a, 0x83, 0xc, 0xcc, 0x4d, 0x46, 0x9c, 0xdb, 0x7b, 0x54, 0x6, 0x23, 0x14, 0x93}),
-				new(big.Int).SetBytes([]byte{0x2e, 0x20, 0x19, 0xea, 0xed, 0x9a, 0x4d, 0xdc, 0x21, 0x6d, 0x3c, 0x20, 0x5f, 0x7d, 0x99, 0xd3, 0xf1, 0x73, 0xfb, 0x34, 0x6e, 0x5f, 0xf0, 0xd0, 0x19, 0x6a, 0x99, 0xa6, 0x75, 0x88, 0x48, 0xc1}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9, 0x28, 0x95, 0x5e, 0xe6, 0x37, 0xa8, 0x44, 0x63, 0x72, 0x9f, 0xd3, 0xe, 0x7a, 0xfd, 0x2e, 0xd5, 0xf9, 0x62, 0x74, 0xe5, 0xad, 0x7e, 0x5c, 0xb0, 0x9e, 0xda, 0x9c, 0x6, 0xd9, 0x3, 0xac}),
-				new(big.Int).SetBytes([]byte{0xc2, 0x56, 0x21, 0x0, 0x3d, 0x3f, 0x42, 0xa8, 0x27, 0xb7, 0x8a, 0x13, 0x9, 0x3a, 0x95, 0xee, 0xac, 0x3d, 0x26, 0xef, 0xa8, 0xa8, 0xd8, 0x3f, 0xc5, 0x18, 0xe, 0x93, 0x5b, 0xcd, 0x9, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0x89, 0xbd, 0xee, 0x37, 0x71, 0xd3, 0x50, 0xda, 0xd1, 0x63, 0xb0, 0x4c, 0xb1, 0x8a, 0xd6, 0x7c, 0xe5, 0xe9, 0xc5, 0x5b, 0x58, 0xf0, 0xe7, 0x23, 0x10, 0x47, 0xa6, 0xf, 0x59, 0xdd, 0x9e}),
-				new(big.Int).SetBytes([]byte{0xca, 0x79, 0x52, 0xd5, 0x22, 0x7a, 0x1f, 0x69, 0x5c, 0x4b, 0xaf, 0x4c, 0x4, 0x3b, 0xb2, 0x47, 0x1e, 0x48, 0x82, 0x50, 0x66, 0x38, 0xdf, 0x5c, 0x10, 0x16, 0xae, 0x32, 0x1, 0x56, 0xb0, 0x49}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0x3, 0xb8, 0x1f, 0xc0, 0xe1, 0xe5, 0xa8, 0x5, 0x3d, 0xf0, 0xdf, 0xb2, 0x30, 0xb6, 0xae, 0xbe, 0x41, 0x15, 0xb3, 0x95, 0x3d, 0x2b, 0x41, 0x81, 0x11, 0x28, 0x75, 0x78, 0x74, 0xb8, 0x39}),
-				new(big.Int).SetBytes([]byte{0xd1, 0x3a, 0xe1, 0x63, 0xdf, 0xf0, 0x7f, 0x42, 0xc4, 0x4f, 0x66, 0x7, 0x57, 0x19, 0x8f, 0x66, 0x7d, 0xe5, 0xc5, 0xf0, 0xfd, 0xab, 0x5b, 0x8d, 0xa0, 0xc1, 0xce, 0x56, 0x7c, 0x5, 0x94, 0xba}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcb, 0x9e, 0x83, 0x4, 0xca, 0xe3, 0xc5, 0xa8, 0xc, 0x39, 0x6b, 0xac, 0xa2, 0xc3, 0xc4, 0xc9, 0x94, 0xb6, 0x68, 0xf0, 0x79, 0xa2, 0x45, 0xbf, 0x52, 0x9c, 0x31, 0x4c, 0xff, 0xf0, 0x11, 0x97}),
-				new(big.Int).SetBytes([]byte{0x62, 0xc7, 0xd2, 0x80, 0x1e, 0xb8, 0xe, 0x6a, 0x12, 0x72, 0x58, 0xcd, 0xff, 0x8, 0x89, 0x17, 0x41, 0xb2, 0xd1, 0x8c, 0x1, 0x5e, 0xa, 0x24, 0xc3, 0x34, 0xe0, 0x76, 0x3b, 0x98, 0x9c, 0x1d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe6, 0x62, 0xc0, 0xb7, 0xa2, 0xf4, 0x49, 0x2c, 0xd6, 0x2f, 0xa2, 0x83, 0xaa, 0x29, 0x22, 0xc5, 0xf1, 0x51, 0xbd, 0x13, 0x45, 0xb3, 0x2, 0x3f, 0x3a, 0x3f, 0x78, 0xe6, 0x83, 0x57, 0xa5, 0x13}),
-				new(big.Int).SetBytes([]byte{0xab, 0xb, 0x19, 0x3c, 0xe6, 0x12, 0x45, 0x23, 0x5, 0xda, 0xe2, 0x8, 0xa1, 0x21, 0xa4, 0x19, 0xd8, 0x3, 0x5c, 0xd6, 0x25, 0x53, 0x8d, 0x89, 0x19, 0x2a, 0x20, 0x1c, 0xa0, 0x17, 0xd0, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc2, 0xc5, 0x8a, 0x54, 0x28, 0xd, 0xf6, 0x39, 0x47, 0x78, 0xff, 0xe0, 0xa2, 0x2c, 0x23, 0x4b, 0x5e, 0x83, 0xa8, 0xc1, 0x88, 0xea, 0x59, 0x45, 0x9b, 0xae, 0x90, 0xd3, 0xb9, 0xa7, 0xd1, 0x97}),
-				new(big.Int).SetBytes([]byte{0x72, 0xea, 0x32, 0x88, 0x36, 0x6e, 0x9, 0x27, 0x57, 0x30, 0x41, 0xab, 0x62, 0xed, 0x39, 0xa6, 0xa, 0x47, 0xec, 0x45, 0x45, 0xad, 0x82, 0x48, 0x4e, 0x41, 0xcc, 0xbf, 0x89, 0xee, 0xb5, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x40, 0xbf, 0x80, 0xb1, 0xc9, 0x4c, 0xf6, 0xcb, 0x84, 0x38, 0x62, 0xc7, 0xcb, 0xe3, 0x58, 0x7d, 0xc2, 0x9f, 0xec, 0xa6, 0x7, 0x9c, 0x14, 0xb0, 0x49, 0x95, 0xf7, 0xef, 0xdf, 0x37, 0xd2, 0x42}),
-				new(big.Int).SetBytes([]byte{0xb5, 0x79, 0xdd, 0x35, 0xd8, 0x56, 0xaa, 0xf0, 0x4f, 0x53, 0xfe, 0x9b, 0x1b, 0x26, 0xfe, 0x12, 0x74, 0x32, 0x1e, 0xba, 0x42, 0xbd, 0x35, 0x58, 0x3d, 0x1d, 0x82, 0x79, 0xa6, 0x40, 0x50, 0x88}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe2, 0xf3, 0x49, 0xb0, 0xf8, 0x9c, 0x69, 0xbd, 0x3c, 0x8c, 0xf2, 0xa4, 0x10, 0x73, 0xd, 0xc5, 0x8e, 0xb, 0xee, 0xd4, 0x70, 0x48, 0xc5, 0x8c, 0x15, 0xf9, 0xff, 0xc2, 0x50, 0x8d, 0x2c, 0xc2}),
-				new(big.Int).SetBytes([]byte{0x1f, 0xeb, 0x2f, 0x28, 0xf, 0x82, 0x72, 0x37, 0x81, 0x86, 0xa, 0xec, 0x76, 0x2, 0x15, 0xba, 0x42, 0x34, 0x4b, 0xe8, 0xe0, 0x9c, 0xbd, 0xb3, 0x7e, 0x34, 0x7b, 0xd8, 0xe0, 0xd4, 0xc0, 0x4f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3d, 0x14, 0xfe, 0x97, 0x60, 0x1d, 0xca, 0x70, 0x80, 0x6f, 0x97, 0x81, 0x38, 0xdb, 0x59, 0xf6, 0xac, 0x7, 0x1f, 0x85, 0xe2, 0x34, 0xa7, 0xf2, 0xba, 0xf2, 0xb3, 0x64, 0x59, 0x5a, 0x35, 0x58}),
-				new(big.Int).SetBytes([]byte{0x16, 0xc6, 0xbd, 0xd6, 0xe8, 0x46, 0x81, 0xef, 0x8f, 0x29, 0xa9, 0x31, 0xdb, 0xf5, 0x6e, 0x14, 0x82, 0xe0, 0xa2, 0xe1, 0x47, 0xc1, 0xd7, 0x27, 0xc6, 0xad, 0xb3, 0xac, 0x64, 0x43, 0xdf, 0x4c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4, 0x70, 0xa8, 0x72, 0xd1, 0x75, 0x63, 0x68, 0x68, 0x4, 0x53, 0xc6, 0xa7, 0x74, 0x94, 0xcb, 0x78, 0x2e, 0x3, 0x54, 0xe4, 0xc7, 0x7c, 0xd5, 0x10, 0xfc, 0x70, 0x24, 0x14, 0xea, 0x41, 0x78}),
-				new(big.Int).SetBytes([]byte{0x8, 0x99, 0xa7, 0xa9, 0xc1, 0x10, 0x9a, 0xcd, 0x67, 0x61, 0x45, 0xe9, 0xfd, 0x39, 0xc0, 0xcb, 0x19, 0xb5, 0x68, 0x70, 0x69, 0x84, 0xda, 0xea, 0x7, 0x94, 0x49, 0xf1, 0xb8, 0x9, 0x8b, 0xd6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdd, 0x6f, 0xa5, 0x40, 0xe8, 0x2e, 0x65, 0x25, 0x1c, 0xc1, 0xac, 0x4d, 0x2c, 0x92, 0x48, 0x65, 0x25, 0x28, 0xd8, 0x1c, 0x7e, 0x3f, 0x75, 0x70, 0xed, 0xe9, 0xf5, 0xad, 0x82, 0xb1, 0xfc, 0x24}),
-				new(big.Int).SetBytes([]byte{0x67, 0xe6, 0x2b, 0x7e, 0xb1, 0xd3, 0x18, 0x6f, 0xe3, 0x66, 0xf1, 0xeb, 0xf1, 0x65, 0x7e, 0x77, 0xec, 0x10, 0xde, 0x6b, 0xed, 0x73, 0x22, 0x30, 0x4a, 0x43, 0x1c, 0x46, 0xe, 0x9b, 0x74, 0xca}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x42, 0xdc, 0x50, 0xff, 0xcb, 0xa4, 0xd6, 0x24, 0xa2, 0x1a, 0x27, 0x8f, 0x54, 0x4, 0xea, 0xcd, 0xca, 0x1c, 0x39, 0x69, 0x0, 0x66, 0x11, 0xea, 0xd7, 0x4d, 0xe6, 0xd9, 0x63, 0x36, 0x92, 0x17}),
-				new(big.Int).SetBytes([]byte{0xa5, 0xba, 0x9a, 0x1f, 0x66, 0x38, 0x5b, 0x1d, 0x7e, 0xd7, 0x8f, 0x5f, 0x16, 0xb, 0xa8, 0x53, 0xa7, 0xa1, 0x39, 0xec, 0xa8, 0xe0, 0x1e, 0x84, 0x1c, 0x30, 0x97, 0xe2, 0x3e, 0x2d, 0xbc, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0xfa, 0x2d, 0xa8, 0x2d, 0xe5, 0x5d, 0x78, 0xf, 0x86, 0x94, 0x99, 0xaa, 0x3d, 0x1e, 0x80, 0x10, 0x85, 0x47, 0xb7, 0xfd, 0xad, 0xf0, 0x28, 0x81, 0xea, 0x91, 0x59, 0x3d, 0xd0, 0x8e, 0x2}),
-				new(big.Int).SetBytes([]byte{0x13, 0x63, 0x38, 0xb0, 0x45, 0x55, 0xda, 0x72, 0x31, 0x33, 0x56, 0x20, 0x6b, 0xb4, 0x32, 0xc7, 0xe6, 0x44, 0xaf, 0xbb, 0x19, 0xa4, 0x2b, 0x97, 0xda, 0x55, 0x41, 0xdc, 0x3c, 0xb0, 0x34, 0x10}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x95, 0x82, 0xb7, 0x9c, 0x4b, 0xa, 0xef, 0x75, 0x7d, 0x1f, 0x82, 0xbb, 0x9c, 0xca, 0x75, 0xdd, 0x5d, 0xcb, 0xb7, 0xa7, 0xd8, 0xae, 0x7b, 0x4, 0x23, 0xf1, 0xac, 0xe9, 0x16, 0x46, 0xa9, 0xe9}),
-				new(big.Int).SetBytes([]byte{0x22, 0x6, 0x7d, 0x80, 0xe, 0xd4, 0x26, 0xee, 0xd4, 0xec, 0x6a, 0xce, 0xea, 0xf8, 0xe6, 0xa4, 0x1, 0xcb, 0xb5, 0xc5, 0xf1, 0x3b, 0x82, 0xff, 0xbc, 0x4d, 0x74, 0x93, 0xaa, 0x56, 0xa6, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x6c, 0x48, 0x51, 0xd3, 0xc7, 0xa, 0x44, 0x3f, 0x7c, 0x10, 0xb6, 0x72, 0xc6, 0x82, 0x82, 0x87, 0x9b, 0x56, 0xdd, 0x3b, 0x7d, 0x69, 0xa6, 0xac, 0x23, 0x3a, 0xb9, 0x94, 0x4e, 0xe4, 0x1a}),
-				new(big.Int).SetBytes([]byte{0x58, 0x15, 0xfd, 0x4c, 0x5, 0x84, 0xd0, 0xad, 0xbb, 0xb0, 0xac, 0xd4, 0xf4, 0xf6, 0x30, 0x91, 0x9b, 0x7b, 0x84, 0x34, 0x61, 0x62, 0xee, 0x25, 0x2f, 0x1f, 0x84, 0xe9, 0x8b, 0x29, 0x5e, 0x6f}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0xd0, 0xfe, 0xf3, 0xec, 0x6d, 0xb1, 0x9, 0x39, 0x90, 0x64, 0xf3, 0xa0, 0xe3, 0xb2, 0x85, 0x56, 0x45, 0xb4, 0xa9, 0x7, 0xad, 0x35, 0x45, 0x27, 0xaa, 0xe7, 0x51, 0x63, 0xd8, 0x27, 0x51}),
-				new(big.Int).SetBytes([]byte{0x1f, 0x3, 0x64, 0x84, 0x13, 0xa3, 0x8c, 0xb, 0xe2, 0x9d, 0x49, 0x6e, 0x58, 0x2c, 0xf5, 0x66, 0x3e, 0x87, 0x51, 0xe9, 0x68, 0x77, 0x33, 0x15, 0x82, 0xc2, 0x37, 0xa2, 0x4e, 0xb1, 0xf9, 0x62}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6b, 0x79, 0xf, 0x4b, 0x19, 0xa4, 0xc4, 0xf4, 0xf6, 0x7, 0xa6, 0xcf, 0xcd, 0x11, 0xdf, 0x4, 0x68, 0xb4, 0x82, 0xe0, 0x9, 0x71, 0x1f, 0xf7, 0x56, 0x35, 0x6d, 0x14, 0x1d, 0x5f, 0xca, 0xde}),
-				new(big.Int).SetBytes([]byte{0xd0, 0x3a, 0x98, 0x1b, 0x2f, 0xf9, 0xeb, 0x3e, 0xf2, 0x96, 0x66, 0x1f, 0x9c, 0xae, 0x9, 0xcb, 0xa8, 0x3f, 0xa5, 0xb4, 0x7b, 0xe2, 0x6b, 0xa, 0xb6, 0xff, 0xf8, 0x6f, 0xc3, 0x38, 0xd3, 0xff}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x4d, 0xab, 0x4a, 0xc1, 0x14, 0x22, 0xc3, 0xbe, 0x7c, 0x2d, 0x26, 0xd1, 0x5d, 0x9a, 0xe7, 0x93, 0x40, 0xe5, 0x35, 0x47, 0x8a, 0x6, 0x6a, 0xb9, 0x95, 0x50, 0x61, 0xec, 0xde, 0x4c, 0xf3}),
-				new(big.Int).SetBytes([]byte{0xd6, 0xe3, 0xc5, 0xbc, 0xd1, 0xb9, 0xca, 0x43, 0xbf, 0xb4, 0xed, 0x6d, 0xa2, 0xb4, 0xc6, 0xf0, 0x1f, 0x43, 0xa, 0x33, 0x97, 0x51, 0xba, 0x73, 0xb7, 0xc7, 0x96, 0xb2, 0x25, 0x2d, 0x5, 0x66}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x41, 0x14, 0x9b, 0x2c, 0x2d, 0x7e, 0xbe, 0xd3, 0xc1, 0x62, 0xc3, 0x67, 0xac, 0xc4, 0xf8, 0xfe, 0x3d, 0x24, 0x79, 0xde, 0x85, 0x97, 0x8b, 0xe0, 0xbb, 0xc, 0xcd, 0xab, 0xe3, 0xa3, 0xe0, 0xcb}),
-				new(big.Int).SetBytes([]byte{0xc9, 0xd, 0x5b, 0x92, 0xdb, 0x7c, 0x30, 0x54, 0x2b, 0x41, 0x5c, 0x9b, 0x99, 0x2, 0xcf, 0x28, 0xb3, 0xec, 0x78, 0x5, 0xef, 0x49, 0xf, 0x24, 0x70, 0xe9, 0x2e, 0x98, 0x33, 0x90, 0x33, 0xa8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x14, 0xf0, 0xec, 0xe, 0xb7, 0xd4, 0x15, 0xaa, 0x41, 0xb1, 0x61, 0xb, 0x4e, 0x82, 0xed, 0x48, 0x40, 0x35, 0x5f, 0x5c, 0x38, 0xe, 0xcb, 0x8b, 0x98, 0xaf, 0x3e, 0x92, 0x1e, 0x52, 0x38, 0xc2}),
-				new(big.Int).SetBytes([]byte{0xab, 0x12, 0xd5, 0x3d, 0xd4, 0x83, 0x5d, 0x80, 0x92, 0x1f, 0xc7, 0x3e, 0x38, 0x42, 0x74, 0x7f, 0xd2, 0x5c, 0x0, 0xd8, 0x3, 0x4, 0x93, 0x9d, 0x6e, 0x86, 0xa3, 0x47, 0x92, 0xf0, 0x79, 0x22}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8a, 0x31, 0x87, 0x9, 0x49, 0xbf, 0xe1, 0x5c, 0x81, 0x98, 0xc1, 0xef, 0xcb, 0xc4, 0x41, 0xa0, 0xe1, 0x92, 0x16, 0x4, 0x2, 0x52, 0x1e, 0x1f, 0x8a, 0x43, 0x66, 0xa0, 0x8a, 0x97, 0x26, 0x27}),
-				new(big.Int).SetBytes([]byte{0x64, 0xbb, 0x7c, 0x87, 0x68, 0xf7, 0xa6, 0x4d, 0x9, 0x57, 0x7e, 0xd2, 0x85, 0x87, 0xba, 0xa4, 0x17, 0x44, 0x36, 0x5a, 0xc3, 0xef, 0xcb, 0xcc, 0xe7, 0xb4, 0x7b, 0x56, 0x46, 0xbb, 0x8a, 0xf9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0x42, 0xf3, 0xbb, 0x78, 0x2c, 0x28, 0x7e, 0xc4, 0x1c, 0x34, 0x67, 0x8a, 0x5a, 0xd, 0xda, 0xdd, 0x13, 0x2, 0xa7, 0x56, 0xf9, 0x8e, 0x18, 0x8f, 0x63, 0x52, 0xef, 0xd5, 0x43, 0xe9, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x23, 0x4a, 0x6d, 0x7, 0x4f, 0x25, 0xd9, 0x2d, 0xc9, 0xa0, 0xdd, 0x57, 0x2f, 0xcd, 0xfb, 0x77, 0x4c, 0xd8, 0x46, 0x8d, 0xe6, 0xd, 0x6d, 0x42, 0x40, 0x55, 0x9d, 0xe4, 0x45, 0x92, 0x4d, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd1, 0xfa, 0xd4, 0xfa, 0x4e, 0x7c, 0x84, 0x9d, 0xfa, 0xec, 0x3d, 0xfe, 0x28, 0x72, 0xa7, 0xba, 0x66, 0x4a, 0x9b, 0x82, 0x5, 0xc2, 0x9c, 0xeb, 0xf8, 0xdd, 0xdd, 0x28, 0xe3, 0xf3, 0xd3, 0xfc}),
-				new(big.Int).SetBytes([]byte{0x8f, 0xe1, 0x97, 0x14, 0xa3, 0x48, 0xfd, 0xfe, 0x54, 0x73, 0xf7, 0xe, 0x85, 0x8b, 0x78, 0x18, 0xba, 0xd3, 0x71, 0x31, 0xef, 0xf3, 0x73, 0x26, 0xed, 0x22, 0x34, 0x3c, 0x50, 0xf3, 0x70, 0x4d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0xf1, 0x38, 0xca, 0x51, 0x68, 0x20, 0xd9, 0xa9, 0xee, 0xc0, 0x2f, 0x6e, 0x6, 0xa9, 0x20, 0x45, 0x9b, 0xac, 0xde, 0x8c, 0x66, 0x73, 0x27, 0x3, 0x24, 0xe5, 0xb5, 0x7e, 0x19, 0xaa, 0xed}),
-				new(big.Int).SetBytes([]byte{0xd6, 0xff, 0xda, 0xf3, 0x17, 0x1c, 0x63, 0x86, 0x5f, 0x43, 0x72, 0xa6, 0x6b, 0x6d, 0xde, 0x17, 0x47, 0x9e, 0xef, 0x8f, 0xef, 0xc7, 0x7f, 0xfb, 0xc1, 0x36, 0xea, 0x5d, 0x69, 0x2f, 0x40, 0xe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5, 0xcb, 0xea, 0xab, 0xfe, 0x2b, 0x74, 0x12, 0x7f, 0x26, 0x68, 0x38, 0xc1, 0xda, 0xd3, 0x12, 0xbc, 0xa7, 0xc9, 0x43, 0x8c, 0x7e, 0xad, 0x65, 0x37, 0x47, 0x3b, 0xa2, 0xe0, 0xba, 0x68, 0xdd}),
-				new(big.Int).SetBytes([]byte{0x13, 0xca, 0x23, 0xb6, 0xfa, 0x90, 0x15, 0x6e, 0xae, 0x93, 0x86, 0x66, 0x55, 0x6, 0xef, 0xa0, 0x7f, 0x3d, 0x96, 0x70, 0x5d, 0xe5, 0x33, 0xb5, 0x94, 0x22, 0x9f, 0x32, 0x7d, 0xd0, 0x39, 0x59}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb5, 0x86, 0x86, 0xbe, 0x5f, 0x40, 0xd4, 0x12, 0xc8, 0x75, 0xa0, 0x96, 0x84, 0x99, 0x90, 0xd9, 0x37, 0xa6, 0x63, 0x7b, 0x4a, 0x78, 0xfa, 0xc5, 0x5f, 0x6c, 0xe, 0x9f, 0xb6, 0xa8, 0xaa, 0x39}),
-				new(big.Int).SetBytes([]byte{0x56, 0x32, 0xd5, 0x43, 0x24, 0xc7, 0x8c, 0x2c, 0x14, 0x94, 0x78, 0xd4, 0x54, 0xc0, 0xa9, 0x1d, 0xab, 0xbd, 0xaf, 0x98, 0xe6, 0xdb, 0x34, 0x7b, 0x8f, 0xc7, 0x93, 0x5d, 0x2b, 0x49, 0x3c, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0xfc, 0x69, 0xab, 0x34, 0x5, 0x57, 0x41, 0x2d, 0xd3, 0x9, 0x76, 0xe1, 0xf8, 0xb7, 0x51, 0x80, 0x1d, 0xa9, 0x9f, 0x8e, 0xff, 0xdf, 0x27, 0x9a, 0x54, 0x4c, 0x67, 0xca, 0x3a, 0x15, 0x50}),
-				new(big.Int).SetBytes([]byte{0xe2, 0xff, 0xe8, 0xcd, 0xb9, 0x9c, 0xb5, 0x40, 0xa3, 0xdf, 0x19, 0x9d, 0xb7, 0xf4, 0xfd, 0x7, 0x79, 0x83, 0xc8, 0x18, 0xe6, 0x8c, 0xb, 0x19, 0x51, 0xf4, 0x2b, 0xf6, 0x41, 0x36, 0x2f, 0x6e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5f, 0xc1, 0x9d, 0xb0, 0x6b, 0x8b, 0xcc, 0x2b, 0x67, 0xb5, 0xbc, 0x4f, 0xe7, 0xf3, 0xa8, 0x2, 0x57, 0x28, 0x31, 0xba, 0x5a, 0x1c, 0x77, 0xc2, 0xee, 0x91, 0xeb, 0xe3, 0xb0, 0x3b, 0x4c, 0xc5}),
-				new(big.Int).SetBytes([]byte{0xec, 0x8e, 0x84, 0xc0, 0xec, 0x98, 0xc7, 0xc6, 0x6e, 0xe2, 0x13, 0x8f, 0x9a, 0x3a, 0x5e, 0xf4, 0x92, 0x76, 0xf5, 0x3, 0xcc, 0x8, 0x7e, 0xc4, 0xa3, 0xb3, 0x9f, 0xb8, 0x65, 0xb5, 0x7c, 0x28}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6c, 0xe5, 0xc3, 0x50, 0xce, 0x93, 0xa8, 0x66, 0x10, 0x50, 0xb6, 0x53, 0x37, 0xaa, 0xc9, 0x80, 0xe6, 0x6a, 0xa5, 0x61, 0x6d, 0x44, 0x18, 0xe9, 0x48, 0xbb, 0xf3, 0x8e, 0x5e, 0x8d, 0x31, 0x9f}),
-				new(big.Int).SetBytes([]byte{0x95, 0xd, 0x0, 0x26, 0xe2, 0x50, 0x3c, 0x85, 0xbe, 0xd3, 0xe3, 0xe8, 0xcb, 0xbe, 0x23, 0xf1, 0x2d, 0xa8, 0xf2, 0xe0, 0xe, 0xc5, 0x37, 0x83, 0x8, 0xac, 0x6b, 0xd7, 0x95, 0xcf, 0x51, 0x3f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0x8f, 0xb7, 0xd, 0x42, 0xed, 0xe2, 0x89, 0xde, 0xba, 0x8a, 0x40, 0xfd, 0x3e, 0xea, 0x1b, 0xe1, 0xb, 0x7f, 0xdf, 0x4d, 0x38, 0x89, 0x48, 0xbb, 0x4b, 0x85, 0x7d, 0x4c, 0xb3, 0x8c, 0xb5}),
-				new(big.Int).SetBytes([]byte{0xd6, 0xc7, 0x36, 0xad, 0x46, 0xea, 0x32, 0x80, 0x60, 0x34, 0xbb, 0x99, 0x4b, 0x35, 0xa8, 0xe2, 0x4d, 0x4f, 0x3e, 0x12, 0x27, 0xaf, 0x8c, 0x71, 0x97, 0xbf, 0xa7, 0x56, 0x59, 0x65, 0xf3, 0xd7}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xff, 0x2b, 0xd, 0xce, 0x97, 0xee, 0xce, 0x97, 0xc1, 0xc9, 0xb6, 0x4, 0x17, 0x98, 0xb8, 0x5d, 0xfd, 0xfb, 0x6d, 0x88, 0x82, 0xda, 0x20, 0x30, 0x8f, 0x54, 0x4, 0x82, 0x45, 0x26, 0x8, 0x7e}),
-				new(big.Int).SetBytes([]byte{0x49, 0x3d, 0x13, 0xfe, 0xf5, 0x24, 0xba, 0x18, 0x8a, 0xf4, 0xc4, 0xdc, 0x54, 0xd0, 0x79, 0x36, 0xc7, 0xb7, 0xed, 0x6f, 0xb9, 0xe, 0x2c, 0xeb, 0x2c, 0x95, 0x1e, 0x1, 0xf0, 0xc2, 0x99, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x29, 0x82, 0xdb, 0xbc, 0x5f, 0x36, 0x6c, 0x9f, 0x78, 0xe2, 0x9e, 0xbb, 0xec, 0xb1, 0xbb, 0x22, 0x3d, 0xeb, 0x5c, 0x4e, 0xe6, 0x38, 0xb4, 0x58, 0x3b, 0xd3, 0xa9, 0xaf, 0x31, 0x49, 0xf8, 0xef}),
-				new(big.Int).SetBytes([]byte{0xa6, 0x1b, 0x5b, 0xe9, 0xaf, 0x66, 0x22, 0xa, 0xb9, 0xfa, 0x53, 0x39, 0xc7, 0xb5, 0xbc, 0x9d, 0x9, 0x5d, 0xb9, 0x94, 0x12, 0xe3, 0xed, 0x84, 0x56, 0xe7, 0x26, 0xb0, 0x16, 0xc7, 0xa2, 0x48}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc7, 0x45, 0xfd, 0xf2, 0x77, 0x5f, 0x23, 0x8, 0x88, 0xb7, 0xff, 0x25, 0xe0, 0x2c, 0x94, 0xb0, 0x66, 0xce, 0xe, 0xef, 0xac, 0x8f, 0xeb, 0x9f, 0xc5, 0x90, 0x54, 0xfe, 0x79, 0xd6, 0x81, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x59, 0x2, 0x22, 0xf2, 0xf6, 0xb9, 0xe5, 0xe7, 0x8a, 0x71, 0x39, 0x4c, 0x70, 0xe8, 0x18, 0x67, 0x8c, 0xdb, 0xd3, 0x35, 0xc6, 0x7d, 0x45, 0xc7, 0x60, 0x31, 0x73, 0x43, 0x7b, 0xbb, 0x12, 0x47}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1a, 0x28, 0xe5, 0x4, 0x2a, 0xf0, 0xc0, 0xf6, 0xb4, 0x36, 0xeb, 0x59, 0x4, 0x97, 0xdb, 0x58, 0x60, 0x1, 0x1f, 0x45, 0x80, 0xe1, 0x76, 0x58, 0x85, 0x28, 0x9f, 0x61, 0x23, 0x80, 0x44, 0x1b}),
-				new(big.Int).SetBytes([]byte{0x55, 0x77, 0x9a, 0x79, 0x96, 0xc5, 0x9d, 0xab, 0x7c, 0x78, 0x32, 0x9a, 0x89, 0x76, 0xf0, 0xed, 0x4, 0xb3, 0xe7, 0x5b, 0x46, 0xee, 0x67, 0xae, 0xb0, 0x5f, 0x60, 0x6a, 0x84, 0x52, 0xaf, 0x25}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xab, 0xb2, 0x79, 0xf3, 0xa9, 0x75, 0x5, 0xb, 0x27, 0xa5, 0x9e, 0x5e, 0xb6, 0x72, 0xe7, 0xf2, 0xb3, 0x44, 0x78, 0xe8, 0x20, 0xca, 0xc4, 0x81, 0x5e, 0x4, 0xce, 0xed, 0x35, 0xcd, 0xe, 0xa3}),
-				new(big.Int).SetBytes([]byte{0x5d, 0xee, 0x10, 0x3b, 0xbf, 0x17, 0x97, 0xd, 0x9f, 0xb4, 0xbe, 0xc, 0x3, 0x7, 0x8e, 0xd4, 0x7c, 0x97, 0x69, 0x5, 0x9e, 0x2, 0xf3, 0xb6, 0x47, 0x9, 0x31, 0x33, 0x7c, 0x30, 0x7b, 0xce}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x73, 0xc0, 0x52, 0xb1, 0x94, 0xc6, 0xc6, 0xdd, 0x46, 0xac, 0xa9, 0xd6, 0x40, 0x98, 0x1a, 0xec, 0x79, 0x60, 0x9, 0x17, 0xa5, 0x65, 0xeb, 0xe7, 0x7f, 0xd5, 0x34, 0x64, 0x9a, 0x21, 0x15}),
-				new(big.Int).SetBytes([]byte{0x62, 0x7, 0x68, 0xc1, 0xc8, 0x17, 0x88, 0x44, 0x2, 0xa, 0xba, 0xb0, 0x26, 0xf7, 0xd6, 0xd9, 0x4, 0xf6, 0x1, 0x56, 0x2e, 0x9d, 0x42, 0x1f, 0x4, 0x9c, 0xab, 0x7b, 0xa6, 0xba, 0x6c, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3f, 0x81, 0x15, 0xb, 0x59, 0xfc, 0x68, 0x28, 0x27, 0xfa, 0xea, 0xa7, 0x42, 0x67, 0xed, 0x11, 0xea, 0x9f, 0xa1, 0xa9, 0x63, 0xe7, 0x38, 0x2c, 0x5c, 0x2e, 0x2f, 0x3f, 0x1b, 0xc9, 0xee, 0x3e}),
-				new(big.Int).SetBytes([]byte{0x19, 0xc8, 0x8a, 0x68, 0xfd, 0xbf, 0xa8, 0x2d, 0x46, 0x71, 0xbe, 0xb3, 0xc4, 0x79, 0x56, 0x62, 0x76, 0x23, 0xb2, 0xdc, 0xfc, 0xda, 0x81, 0x60, 0x3c, 0xea, 0xdb, 0xc, 0x59, 0x9a, 0xae, 0x6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc, 0x8b, 0x83, 0xe9, 0x53, 0x5f, 0x30, 0x60, 0x1d, 0x25, 0xc, 0xc0, 0xbd, 0x3f, 0x20, 0x14, 0x2e, 0xdd, 0x5e, 0xb7, 0x98, 0x5d, 0x83, 0x24, 0x2e, 0xef, 0xe, 0x39, 0x62, 0x1e, 0x30, 0xa7}),
-				new(big.Int).SetBytes([]byte{0xd, 0xcc, 0x70, 0x77, 0x6, 0x5f, 0xda, 0xc7, 0xb8, 0x50, 0xe3, 0xf1, 0x7e, 0xfd, 0xc8, 0x54, 0xaa, 0xca, 0xd2, 0x37, 0xb9, 0x87, 0x13, 0x4d, 0xbe, 0xbf, 0x7b, 0xeb, 0x9f, 0xf6, 0x88, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5c, 0xf8, 0x13, 0x2d, 0xd0, 0x8, 0x2d, 0xe6, 0x95, 0x94, 0xba, 0x33, 0xaa, 0x56, 0xc7, 0xec, 0x15, 0xec, 0xee, 0xcf, 0x8, 0xbb, 0x35, 0x8b, 0x61, 0x71, 0xbe, 0xfa, 0xf8, 0xe4, 0xa0, 0x7}),
-				new(big.Int).SetBytes([]byte{0xb4, 0x5a, 0xee, 0x5c, 0xd, 0x61, 0xfa, 0xd, 0xf4, 0xad, 0x41, 0x58, 0x84, 0x8c, 0x3d, 0xf6, 0xc8, 0x94, 0x6f, 0x96, 0xc7, 0x9c, 0x7f, 0xe8, 0xce, 0x63, 0xf0, 0x90, 0x90, 0x68, 0xb8, 0x83}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb3, 0xfa, 0x5, 0x45, 0x83, 0x51, 0xd, 0x6b, 0x91, 0x22, 0x67, 0x11, 0x4, 0xba, 0xc7, 0xf4, 0xba, 0x4a, 0xe5, 0xfb, 0xba, 0xad, 0x72, 0x30, 0x30, 0x3, 0xef, 0xcc, 0x4c, 0x3c, 0x18, 0xe1}),
-				new(big.Int).SetBytes([]byte{0xa8, 0x5c, 0x24, 0x75, 0x93, 0x40, 0xf5, 0x6b, 0x7b, 0xa1, 0x82, 0x98, 0x6, 0x4f, 0xe6, 0xfd, 0xaa, 0x55, 0xa4, 0x99, 0xf1, 0xc, 0xe5, 0x42, 0xcb, 0x11, 0xfb, 0xee, 0x12, 0xaa, 0x2b, 0x4f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb6, 0xd5, 0xfe, 0x4d, 0xd, 0x4b, 0xa4, 0x94, 0x65, 0x3e, 0x33, 0x97, 0x3c, 0x40, 0x4e, 0xd2, 0x1b, 0xc3, 0x6f, 0x93, 0x3b, 0xcf, 0xff, 0xb1, 0x9, 0x56, 0xfe, 0x7b, 0x26, 0xc2, 0xd4, 0xa7}),
-				new(big.Int).SetBytes([]byte{0x47, 0xf3, 0x73, 0xa1, 0x3a, 0xbb, 0xd6, 0xc1, 0x4d, 0xfc, 0xf9, 0x20, 0x9d, 0xff, 0xfd, 0x8e, 0x74, 0x5b, 0x7e, 0x4e, 0x6f, 0x5e, 0x3c, 0xc8, 0x11, 0x8d, 0xce, 0x7e, 0x3a, 0xe8, 0x63, 0x71}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0x4d, 0x46, 0xdd, 0x65, 0x95, 0x1a, 0x67, 0x6e, 0x81, 0x65, 0xfe, 0x12, 0x6c, 0x5d, 0x5d, 0xee, 0xc, 0xb7, 0x84, 0x12, 0x28, 0xee, 0x11, 0xfd, 0xb8, 0x6c, 0x35, 0x1b, 0x81, 0x6b, 0x2f}),
-				new(big.Int).SetBytes([]byte{0x77, 0xc9, 0x23, 0x96, 0xca, 0xe, 0x60, 0xab, 0x2, 0x83, 0xe2, 0x7f, 0x8f, 0x3d, 0xfc, 0xd6, 0xb5, 0xe, 0x6c, 0xc5, 0xfb, 0x3e, 0xcc, 0xeb, 0xaf, 0x97, 0xae, 0xb8, 0x73, 0xe8, 0x6c, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x61, 0xc8, 0xbb, 0xc0, 0x66, 0xcf, 0x58, 0x87, 0x6, 0xbb, 0x1b, 0x15, 0x0, 0x29, 0xb0, 0x22, 0xea, 0x8f, 0xbc, 0x8f, 0xf1, 0xdc, 0x76, 0xdc, 0x50, 0x93, 0x36, 0x22, 0xfc, 0x66, 0xdd, 0x33}),
-				new(big.Int).SetBytes([]byte{0x93, 0x59, 0x9e, 0x23, 0x9e, 0x54, 0xf7, 0x3, 0x44, 0x66, 0x99, 0x94, 0xb9, 0x85, 0xd3, 0xfb, 0x6a, 0x51, 0x6, 0xea, 0xc2, 0x82, 0xc, 0x8c, 0xa3, 0xbb, 0xa9, 0xf3, 0xb6, 0xf1, 0xb, 0xfa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4f, 0xd6, 0x99, 0xb1, 0x2c, 0x72, 0xc, 0xcd, 0x7b, 0x97, 0x7e, 0x5b, 0xdc, 0x62, 0x26, 0x71, 0x18, 0xac, 0x30, 0x28, 0xbd, 0x63, 0x5b, 0x26, 0xfc, 0xe6, 0x48, 0xcf, 0x33, 0x5c, 0xac, 0xbb}),
-				new(big.Int).SetBytes([]byte{0xc6, 0xc9, 0xe, 0xf3, 0xf5, 0x11, 0xa0, 0xae, 0xd9, 0x47, 0x9d, 0xbf, 0xcd, 0xb6, 0x1e, 0x2c, 0xaa, 0x99, 0x8e, 0xb2, 0xe8, 0x22, 0x3e, 0x61, 0x33, 0xb2, 0xef, 0xa8, 0x8e, 0x1a, 0xd8, 0x39}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x94, 0xe3, 0x2b, 0xa5, 0x74, 0x26, 0x78, 0x51, 0x87, 0xc9, 0xf5, 0x37, 0x3e, 0xc3, 0xbd, 0xf1, 0x87, 0x63, 0x36, 0xc1, 0x72, 0x86, 0xc8, 0xfa, 0x98, 0x5, 0xb7, 0xcc, 0xdd, 0x6b, 0x2f, 0xf8}),
-				new(big.Int).SetBytes([]byte{0xd, 0x1b, 0x44, 0x8b, 0x43, 0xda, 0x4, 0xe1, 0x92, 0xf1, 0xe4, 0x88, 0x66, 0x2d, 0x9e, 0x8c, 0xb3, 0x3a, 0xf0, 0x42, 0x52, 0x6b, 0xfe, 0x13, 0xad, 0xd4, 0x85, 0x55, 0x2c, 0xe1, 0xaf, 0x3e}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x82, 0x7f, 0xbb, 0xe4, 0xb1, 0xe8, 0x80, 0xea, 0x9e, 0xd2, 0xb2, 0xe6, 0x30, 0x1b, 0x21, 0x2b, 0x57, 0xf1, 0xee, 0x14, 0x8c, 0xd6, 0xdd, 0x28, 0x78, 0xe, 0x5e, 0x2c, 0xf8, 0x56, 0xe2, 0x41}),
-				new(big.Int).SetBytes([]byte{0xc6, 0xf, 0x9c, 0x92, 0x3c, 0x72, 0x7b, 0xb, 0x71, 0xbe, 0xf2, 0xc6, 0x7d, 0x1d, 0x12, 0x68, 0x7f, 0xf7, 0xa6, 0x31, 0x86, 0x90, 0x31, 0x66, 0xd6, 0x5, 0xb6, 0x8b, 0xae, 0xc2, 0x93, 0xec}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb7, 0x7f, 0x12, 0xa7, 0xdc, 0xe5, 0x6b, 0x97, 0x3e, 0x2d, 0x7c, 0x8d, 0x57, 0x6e, 0x6b, 0x36, 0x60, 0x47, 0xa, 0x92, 0x18, 0xb8, 0x74, 0x61, 0xef, 0x6e, 0x44, 0xb7, 0xc, 0xb1, 0x81, 0x5d}),
-				new(big.Int).SetBytes([]byte{0x4b, 0x6f, 0x85, 0xb1, 0x4f, 0x86, 0xac, 0xc4, 0x3f, 0xc, 0xef, 0xb3, 0x73, 0xcc, 0x2e, 0x65, 0x4c, 0x42, 0xf0, 0xf9, 0x1a, 0x44, 0x81, 0x6d, 0x6b, 0xa3, 0xd2, 0xbc, 0x8e, 0x57, 0xdb, 0xc5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8, 0x57, 0xe3, 0x1f, 0x63, 0x8, 0xc2, 0xfb, 0xc0, 0xd1, 0xa0, 0x6b, 0xd3, 0x20, 0x81, 0x9f, 0x3a, 0xa7, 0xda, 0x6b, 0xb7, 0x4, 0x13, 0x88, 0x63, 0x44, 0x85, 0xcb, 0x3b, 0xb8, 0xf, 0xa7}),
-				new(big.Int).SetBytes([]byte{0xf6, 0x43, 0x93, 0x42, 0x3a, 0xe0, 0x17, 0x20, 0x92, 0xd7, 0xcc, 0x9d, 0x1d, 0xcb, 0x71, 0x47, 0x17, 0x2e, 0x37, 0x4, 0x3c, 0xd7, 0x1, 0x6a, 0xbd, 0x98, 0x21, 0x1f, 0x9, 0x36, 0x6b, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x48, 0x97, 0x3b, 0x94, 0x30, 0x18, 0xbf, 0x12, 0x47, 0xb3, 0x8, 0xb2, 0xcb, 0x79, 0xf9, 0x56, 0xd8, 0x58, 0xd8, 0xdf, 0x49, 0x77, 0xc5, 0x97, 0xf, 0xe5, 0xda, 0xd2, 0xc4, 0x55, 0x65, 0xec}),
-				new(big.Int).SetBytes([]byte{0x76, 0x1f, 0x75, 0x68, 0x4f, 0x3c, 0xdc, 0x1b, 0x64, 0x37, 0xbb, 0x3a, 0x1, 0x44, 0x5a, 0xf1, 0x51, 0x1b, 0x35, 0x96, 0x58, 0x4, 0x77, 0xb8, 0x3b, 0x87, 0x90, 0x75, 0xfa, 0xed, 0x7, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x28, 0xaa, 0xcc, 0xea, 0x56, 0xbd, 0x60, 0x4, 0x35, 0x45, 0xc6, 0x55, 0x76, 0x4a, 0x67, 0x2e, 0x9c, 0xbb, 0x8b, 0x78, 0xe7, 0x53, 0xd4, 0x96, 0xf5, 0xd0, 0x2c, 0x3a, 0x9, 0xc7, 0xe, 0x63}),
-				new(big.Int).SetBytes([]byte{0x2b, 0x69, 0x32, 0x2e, 0xf8, 0x1a, 0xe, 0x15, 0x67, 0xa8, 0x96, 0x67, 0xc7, 0x68, 0xeb, 0xb7, 0xf5, 0xfc, 0x59, 0xce, 0x44, 0x4d, 0xa1, 0xe8, 0xb9, 0xf0, 0x38, 0x82, 0xf0, 0x57, 0xda, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xea, 0x12, 0x66, 0x16, 0x7f, 0x2b, 0x81, 0x84, 0x81, 0xa6, 0x9b, 0x87, 0x5, 0x65, 0x23, 0x97, 0x34, 0x16, 0x61, 0x1d, 0x3, 0x89, 0x37, 0x88, 0x33, 0xa0, 0xa, 0xbe, 0x7b, 0x6d, 0x6c, 0xd3}),
-				new(big.Int).SetBytes([]byte{0x21, 0xa4, 0xe2, 0xe5, 0x7, 0x8e, 0xe3, 0xfd, 0xaa, 0xce, 0x11, 0x2b, 0xf1, 0x14, 0x5e, 0x25, 0xb1, 0xc9, 0x75, 0x2d, 0xeb, 0xbe, 0x1a, 0x88, 0xaa, 0xe6, 0x29, 0x25, 0xf4, 0xf4, 0x50, 0xbe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa2, 0xf3, 0xb6, 0x25, 0xa0, 0x55, 0xa6, 0x61, 0x45, 0xdd, 0xb2, 0xc9, 0xbc, 0xeb, 0x8a, 0x4f, 0x92, 0xd8, 0xf6, 0x9c, 0xe7, 0x6, 0xa, 0xac, 0x60, 0x1d, 0xc5, 0x2f, 0x73, 0xe6, 0x74, 0xb5}),
-				new(big.Int).SetBytes([]byte{0x62, 0xe, 0xa1, 0x59, 0x61, 0x4c, 0x68, 0xae, 0x80, 0x4, 0x4a, 0x90, 0xf3, 0x2a, 0x7c, 0x4b, 0x92, 0x22, 0xfa, 0x31, 0x7c, 0x33, 0xfa, 0x53, 0xb0, 0x93, 0x8c, 0x8e, 0xe, 0x93, 0x79, 0x41}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe9, 0x31, 0x25, 0x8e, 0x8e, 0xb5, 0x55, 0x9c, 0x6d, 0x69, 0x72, 0x72, 0x8a, 0x70, 0x4c, 0x17, 0xb, 0x77, 0x5a, 0x26, 0x5b, 0x45, 0x27, 0xd4, 0xa4, 0xd4, 0xd7, 0x42, 0xbb, 0xfd, 0x71, 0xfa}),
-				new(big.Int).SetBytes([]byte{0xfb, 0x1e, 0x33, 0x36, 0x4c, 0x3f, 0xde, 0xe0, 0xe8, 0x5e, 0xb4, 0x16, 0x9c, 0x95, 0x4b, 0x40, 0xb3, 0x94, 0x6c, 0xe1, 0xbb, 0x5e, 0x35, 0xf3, 0x3d, 0x9b, 0xd0, 0xd3, 0x17, 0x4d, 0x33, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3c, 0x4f, 0x83, 0xc9, 0xea, 0xae, 0x59, 0x23, 0x1d, 0x98, 0xb5, 0xc6, 0xa3, 0xf5, 0x15, 0xf5, 0xa0, 0x2, 0xef, 0xdb, 0x8e, 0xcf, 0xd3, 0x86, 0xc7, 0x60, 0x16, 0x31, 0xd9, 0x1e, 0xb0, 0x56}),
-				new(big.Int).SetBytes([]byte{0x94, 0x47, 0x90, 0x7, 0x51, 0x4a, 0x8b, 0x6a, 0x33, 0xad, 0x65, 0x73, 0xc3, 0xb2, 0x78, 0x80, 0x19, 0x82, 0xb5, 0x44, 0x81, 0xcf, 0x6b, 0x87, 0x74, 0x25, 0x89, 0x3d, 0xa0, 0x5c, 0xec, 0xab}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xed, 0xf3, 0x84, 0x38, 0x7f, 0x7f, 0x42, 0x44, 0x9c, 0x36, 0x5f, 0x8d, 0xe8, 0x91, 0x81, 0x7b, 0x0, 0x35, 0x9b, 0x87, 0xa7, 0x12, 0xdf, 0x9d, 0xe6, 0x43, 0xd8, 0xcb, 0x54, 0x8f, 0xad, 0xf1}),
-				new(big.Int).SetBytes([]byte{0x72, 0xd3, 0xb5, 0xb0, 0xa1, 0x89, 0x85, 0x0, 0xde, 0xd8, 0x2, 0x35, 0x12, 0xa, 0xa6, 0xb7, 0xf, 0x4e, 0x88, 0x13, 0xde, 0xa3, 0x7d, 0x28, 0x79, 0xc4, 0x36, 0xcf, 0x6c, 0x41, 0x5f, 0xb6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaf, 0xf8, 0xe1, 0x28, 0x8a, 0x99, 0x67, 0xd4, 0xe2, 0x9c, 0x1e, 0x3, 0xe8, 0x10, 0x3b, 0xaa, 0xdc, 0x38, 0xb4, 0xa4, 0x9, 0xb9, 0x93, 0xa7, 0x19, 0x6, 0x1f, 0xdb, 0xbd, 0x86, 0xdf, 0xa9}),
-				new(big.Int).SetBytes([]byte{0x8d, 0x8b, 0x4b, 0xb3, 0x21, 0x37, 0x7c, 0x3f, 0xd3, 0xd3, 0xeb, 0xaa, 0x1f, 0xcf, 0x53, 0xf4, 0xf9, 0x11, 0x6d, 0xa9, 0x44, 0x19, 0xf9, 0xc7, 0x7, 0x10, 0x3c, 0x36, 0xc1, 0xd1, 0x26, 0x81}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcf, 0x36, 0xad, 0xfd, 0xfb, 0x25, 0x44, 0x2c, 0xa6, 0xc1, 0x2c, 0x1e, 0x78, 0x2b, 0x4b, 0x63, 0x5f, 0x4e, 0xfd, 0x28, 0x1a, 0x27, 0x5e, 0xc9, 0x77, 0x12, 0x3d, 0x42, 0x5c, 0xff, 0x55, 0xc5}),
-				new(big.Int).SetBytes([]byte{0xd1, 0x6a, 0x12, 0x16, 0x6a, 0xe0, 0x76, 0x86, 0x49, 0xc2, 0xf5, 0x8b, 0x3b, 0xd9, 0xf2, 0x7, 0xa7, 0x54, 0x7e, 0xc2, 0x5c, 0xd, 0x27, 0x6, 0xac, 0xa2, 0x78, 0x85, 0x21, 0xa0, 0x22, 0x8e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0xfb, 0x78, 0x45, 0x28, 0xdc, 0x0, 0xb3, 0xe8, 0x45, 0x82, 0x62, 0xae, 0xce, 0xe5, 0x79, 0xe4, 0xf, 0x7c, 0xa5, 0xa0, 0x9d, 0x33, 0x8e, 0x28, 0xf2, 0x73, 0x6b, 0x98, 0x9b, 0xa9, 0xc1}),
-				new(big.Int).SetBytes([]byte{0xf8, 0x94, 0xd4, 0x34, 0xef, 0xa6, 0xb4, 0xe7, 0xca, 0x7c, 0xa0, 0x57, 0x58, 0xb7, 0xf, 0xe6, 0x34, 0x1c, 0xed, 0xd5, 0xc0, 0x11, 0xad, 0xe0, 0x6, 0x1b, 0x88, 0xdf, 0xeb, 0x51, 0xb2, 0x67}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x31, 0xc8, 0xc, 0xa2, 0x6c, 0x63, 0xdf, 0xae, 0xe2, 0xdf, 0x82, 0xe7, 0xc6, 0xa5, 0xca, 0x26, 0xe0, 0x45, 0xd8, 0x65, 0xc, 0xe4, 0xad, 0xb4, 0xfe, 0x85, 0x40, 0xcb, 0xc1, 0x81, 0xf7, 0x9b}),
-				new(big.Int).SetBytes([]byte{0x92, 0xb5, 0x9e, 0xc1, 0x20, 0x29, 0x26, 0xdf, 0x30, 0x94, 0xe4, 0xd3, 0x68, 0xf3, 0xd, 0x91, 0x62, 0x2f, 0xf9, 0xea, 0xf5, 0x11, 0x25, 0xf1, 0xf0, 0xb9, 0xe5, 0x26, 0xd5, 0x22, 0x54, 0x0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4e, 0xc9, 0x30, 0xe, 0xa, 0xc9, 0xaa, 0x88, 0xff, 0x47, 0x29, 0x88, 0x39, 0x18, 0x7d, 0x7f, 0x63, 0x91, 0x2, 0x7c, 0x53, 0x59, 0x8d, 0x99, 0x9d, 0xa9, 0x6c, 0x1d, 0xf, 0x2b, 0xb9, 0x9}),
-				new(big.Int).SetBytes([]byte{0x78, 0x2b, 0xa1, 0x6, 0xbe, 0x1b, 0x4, 0xdc, 0x5c, 0x80, 0xdc, 0x45, 0x18, 0x4c, 0x49, 0xba, 0xd5, 0x2e, 0x16, 0x82, 0xd, 0xac, 0x38, 0x27, 0xb8, 0x8a, 0x38, 0x3f, 0xa4, 0xcf, 0x40, 0x71}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xea, 0xa6, 0x49, 0xf2, 0x1f, 0x51, 0xbd, 0xba, 0xe7, 0xbe, 0x4a, 0xe3, 0x4c, 0xe6, 0xe5, 0x21, 0x7a, 0x58, 0xfd, 0xce, 0x7f, 0x47, 0xf9, 0xaa, 0x7f, 0x3b, 0x58, 0xfa, 0x21, 0x20, 0xe2, 0xb3}),
-				new(big.Int).SetBytes([]byte{0xbe, 0x32, 0x79, 0xed, 0x5b, 0xbb, 0xb0, 0x3a, 0xc6, 0x9a, 0x80, 0xf8, 0x98, 0x79, 0xaa, 0x5a, 0x1, 0xa6, 0xb9, 0x65, 0xf1, 0x3f, 0x7e, 0x59, 0xd4, 0x7a, 0x53, 0x5, 0xba, 0x5a, 0xd9, 0x3d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3a, 0xdb, 0x9d, 0xb3, 0xbe, 0xb9, 0x97, 0xee, 0xc2, 0x62, 0x3e, 0xa5, 0x0, 0x22, 0x79, 0xea, 0x9e, 0x33, 0x7b, 0x5c, 0x70, 0x5f, 0x3d, 0xb4, 0x53, 0xdb, 0xc1, 0xcc, 0x1f, 0xc9, 0xb0, 0xa8}),
-				new(big.Int).SetBytes([]byte{0x37, 0x4e, 0x2d, 0x6d, 0xae, 0xe7, 0x4e, 0x71, 0x3c, 0x77, 0x4d, 0xe0, 0x7c, 0x9, 0x5f, 0xf6, 0xaa, 0xd9, 0xc8, 0xf9, 0x87, 0x2, 0x66, 0xcc, 0x61, 0xae, 0x79, 0x75, 0xf0, 0x5b, 0xbd, 0xda}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x72, 0xa5, 0xe9, 0x4, 0x2f, 0x4a, 0xbf, 0xf4, 0x87, 0x31, 0xc3, 0xb8, 0x50, 0x47, 0xe2, 0x29, 0xaa, 0xb7, 0x1c, 0xc5, 0x2a, 0x6a, 0x98, 0xf5, 0x83, 0xfd, 0x3a, 0x3f, 0x2e, 0x7, 0xd}),
-				new(big.Int).SetBytes([]byte{0x59, 0x9e, 0x1d, 0x4e, 0x1d, 0x6a, 0xe1, 0xcf, 0x60, 0x27, 0x7b, 0xb3, 0x6d, 0xf, 0x3c, 0x10, 0xb0, 0xb4, 0x65, 0xdd, 0xd2, 0x94, 0x8c, 0x3d, 0xe4, 0x4b, 0xa8, 0x2e, 0xe9, 0x6d, 0xd7, 0x80}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0x9e, 0x53, 0xac, 0x42, 0x8e, 0x9c, 0xbb, 0x7e, 0x10, 0x95, 0x5e, 0x56, 0xc5, 0xfc, 0x69, 0xfe, 0xfd, 0xff, 0x56, 0x96, 0x3e, 0x7c, 0xaf, 0x5, 0x4e, 0x9e, 0xc, 0x90, 0xae, 0x86, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x41, 0x5e, 0xcb, 0x95, 0x8a, 0xee, 0x9a, 0x29, 0xb2, 0xda, 0x21, 0x15, 0xb7, 0x12, 0x18, 0x3f, 0xb2, 0xa2, 0x32, 0xfd, 0x16, 0xb3, 0xe0, 0x1b, 0x82, 0x2e, 0xfd, 0xcd, 0x1e, 0x89, 0xc8, 0x5d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0xfc, 0x93, 0xfc, 0x65, 0x39, 0xc8, 0xe2, 0x85, 0xa6, 0xbf, 0xbe, 0xb5, 0xe1, 0xfd, 0x61, 0x3e, 0xf5, 0x49, 0x96, 0x58, 0x51, 0x25, 0xa1, 0xe9, 0xce, 0x7f, 0xd8, 0x4a, 0x2, 0x59, 0x1c}),
-				new(big.Int).SetBytes([]byte{0x9c, 0x2c, 0xe7, 0x39, 0xdc, 0x53, 0x87, 0x17, 0x3e, 0x84, 0xc1, 0x7a, 0x1a, 0x91, 0x65, 0xe5, 0xca, 0x88, 0x8c, 0x41, 0x5f, 0xcf, 0x72, 0x53, 0x79, 0xa, 0xdd, 0xef, 0x69, 0xbe, 0xc2, 0xdc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaa, 0x71, 0x21, 0xd4, 0xe3, 0xfb, 0x5b, 0x78, 0x6a, 0xb4, 0x99, 0x69, 0x4a, 0xaf, 0x5, 0x43, 0x27, 0xf9, 0xca, 0x4, 0xe4, 0x60, 0x91, 0x13, 0x42, 0x87, 0x0, 0xa0, 0xf8, 0x59, 0x12, 0xec}),
-				new(big.Int).SetBytes([]byte{0xc6, 0x90, 0xf0, 0x77, 0xda, 0xd0, 0x95, 0x9, 0xc5, 0x5, 0x26, 0x6b, 0x96, 0xa2, 0xed, 0xeb, 0xfe, 0xe1, 0x34, 0xa8, 0xce, 0x5, 0x6c, 0xbd, 0xb1, 0x14, 0xcb, 0x26, 0x4a, 0xe3, 0x59, 0x78}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc9, 0x40, 0x1, 0x7c, 0x1a, 0x6f, 0x9f, 0xa, 0x6a, 0x7d, 0x7a, 0xc1, 0x20, 0x9b, 0x2, 0x77, 0x9, 0xa2, 0x8b, 0xae, 0x13, 0xcb, 0xbc, 0x2e, 0x11, 0x0, 0x6e, 0xe, 0x2d, 0x96, 0x8b, 0x59}),
-				new(big.Int).SetBytes([]byte{0x39, 0xd9, 0x22, 0x50, 0xc, 0x9b, 0x86, 0x20, 0x13, 0xb8, 0xa1, 0xbf, 0xa5, 0xb5, 0x74, 0x2c, 0xe2, 0x84, 0x2c, 0xb6, 0x43, 0x90, 0xc9, 0xc8, 0xfe, 0xfd, 0x76, 0x40, 0x8d, 0xe5, 0x72, 0xfb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x60, 0x14, 0x44, 0x94, 0xc8, 0xf6, 0x94, 0x48, 0x5b, 0x85, 0xec, 0xb6, 0xae, 0xe1, 0x9, 0x56, 0xc7, 0x56, 0x26, 0x7d, 0x12, 0x89, 0x47, 0x11, 0x92, 0x22, 0x43, 0xd5, 0xe8, 0x55, 0xb8, 0xda}),
-				new(big.Int).SetBytes([]byte{0x8b, 0xb5, 0xd6, 0x69, 0xf6, 0x81, 0xe6, 0x46, 0x9e, 0x8b, 0xe1, 0xfd, 0x91, 0x32, 0xe6, 0x5b, 0x54, 0x39, 0x55, 0xc2, 0x7e, 0x3f, 0x2a, 0x4b, 0xad, 0x50, 0x5, 0x90, 0xf3, 0x4e, 0x4b, 0xbd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1f, 0x84, 0xbb, 0x9d, 0x7e, 0xed, 0x0, 0x24, 0xec, 0x67, 0x93, 0xa5, 0xf7, 0xb, 0xf8, 0xe0, 0x31, 0x3, 0x88, 0xd0, 0x73, 0xee, 0x5d, 0xe6, 0xa2, 0x87, 0x33, 0x35, 0x72, 0x6b, 0x33, 0x32}),
-				new(big.Int).SetBytes([]byte{0xee, 0x72, 0x6d, 0x7, 0x2b, 0xca, 0x9e, 0xcc, 0x25, 0x47, 0xc2, 0x7f, 0x75, 0xb9, 0xed, 0xf0, 0xc2, 0xbc, 0xce, 0x2d, 0x43, 0x6d, 0xc3, 0xa2, 0x55, 0x44, 0x28, 0xa3, 0x14, 0xe8, 0xd5, 0x2e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3d, 0xf2, 0xd0, 0x57, 0xc8, 0xbb, 0x9f, 0x2, 0x8f, 0x68, 0xe0, 0x21, 0x3e, 0xda, 0x17, 0x76, 0xc2, 0xf2, 0x2e, 0x3e, 0x24, 0x1e, 0xcc, 0xeb, 0x9d, 0xd9, 0x59, 0x79, 0xb1, 0x97, 0x2b, 0xcb}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x7e, 0xb1, 0xc6, 0x40, 0xc8, 0x6e, 0xea, 0xd6, 0xe6, 0xb2, 0x3b, 0x4, 0x6a, 0x68, 0x4b, 0x1b, 0x91, 0x44, 0x96, 0x80, 0x19, 0x47, 0x71, 0xb2, 0x82, 0x2c, 0xd3, 0xd6, 0x5d, 0x9, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf, 0x13, 0xe0, 0x89, 0x9, 0x45, 0xfc, 0xd0, 0x6c, 0xa8, 0x15, 0x7b, 0x39, 0x91, 0xe9, 0xc7, 0x72, 0x48, 0x44, 0x68, 0x11, 0xac, 0x27, 0x99, 0x5c, 0xda, 0xa5, 0x4a, 0xcd, 0xef, 0xa9, 0x8e}),
-				new(big.Int).SetBytes([]byte{0xad, 0xd5, 0x21, 0xf1, 0x76, 0x4e, 0x7c, 0x50, 0x35, 0xe3, 0xf8, 0xb0, 0xa7, 0xb3, 0x62, 0xdb, 0x28, 0x7e, 0x70, 0x5e, 0x16, 0xce, 0xac, 0xcd, 0xc0, 0x8a, 0x77, 0x69, 0xbe, 0x28, 0x67, 0x67}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4b, 0x99, 0x15, 0x53, 0x37, 0x20, 0xf9, 0x34, 0xba, 0x48, 0x7, 0xd, 0x37, 0xbd, 0x79, 0xd9, 0x77, 0x5e, 0x26, 0x80, 0xb1, 0x77, 0x14, 0xce, 0x1b, 0x80, 0x6a, 0x24, 0xff, 0xd2, 0x92, 0xdc}),
-				new(big.Int).SetBytes([]byte{0xe3, 0x27, 0x75, 0x49, 0x61, 0x10, 0x3a, 0x22, 0x6d, 0xfc, 0xa, 0x74, 0xb5, 0xf0, 0x1f, 0x57, 0x93, 0x83, 0x23, 0xc4, 0x5b, 0x37, 0x6a, 0xfd, 0x43, 0x3a, 0x5f, 0xfe, 0x57, 0x9, 0x5c, 0xa6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdd, 0xe1, 0x91, 0xa5, 0x51, 0xdd, 0xec, 0x7d, 0xb3, 0x72, 0x8a, 0x1d, 0x2d, 0x58, 0x26, 0xd3, 0xe9, 0xb3, 0x8c, 0x63, 0xc1, 0x88, 0x8e, 0x2f, 0xa5, 0xe1, 0xc0, 0x3b, 0xe9, 0xf5, 0x9b, 0x6b}),
-				new(big.Int).SetBytes([]byte{0x5b, 0x2b, 0xfb, 0x78, 0x2, 0x82, 0x75, 0xcb, 0xe0, 0xa7, 0x57, 0xd6, 0x1c, 0x52, 0xe1, 0x4e, 0x8b, 0x57, 0x38, 0xbb, 0xe7, 0x15, 0x4f, 0xec, 0xf6, 0xad, 0x96, 0x29, 0x26, 0xcb, 0x14, 0x10}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x83, 0xc4, 0x3, 0x97, 0x9e, 0xb9, 0x5e, 0x93, 0x7e, 0xa4, 0x82, 0x5f, 0xff, 0x35, 0x9c, 0x5c, 0x18, 0x1c, 0x94, 0x1, 0xbf, 0x81, 0x18, 0x4f, 0xee, 0xed, 0x1b, 0x34, 0x23, 0xb4, 0x3e, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x29, 0x57, 0x5f, 0xf2, 0x43, 0x7c, 0xee, 0x3d, 0xb, 0xfc, 0x1e, 0x46, 0xd0, 0xd, 0x61, 0xb2, 0x95, 0xb6, 0xbf, 0xca, 0xb5, 0x53, 0xfb, 0x40, 0xaf, 0xf3, 0x40, 0x86, 0xd4, 0x84, 0xfb, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5f, 0xab, 0x1, 0x2e, 0xed, 0x83, 0x6f, 0x7a, 0x5a, 0x16, 0xd7, 0xbf, 0x97, 0xb4, 0x16, 0x82, 0xa8, 0x6f, 0x57, 0x35, 0x6b, 0xe4, 0x68, 0x71, 0xb6, 0x8c, 0x55, 0xfd, 0xc9, 0xc6, 0xb6, 0x99}),
-				new(big.Int).SetBytes([]byte{0x2d, 0x9b, 0xfa, 0x70, 0xa3, 0xc3, 0xd1, 0x44, 0xe9, 0xeb, 0xb4, 0x11, 0x30, 0xd6, 0x2f, 0x7b, 0x90, 0xab, 0x11, 0x7f, 0xea, 0x88, 0x35, 0x19, 0xb1, 0x73, 0x21, 0x52, 0xc1, 0xc6, 0xc3, 0xaa}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe4, 0xa4, 0x2d, 0x43, 0xc5, 0xcf, 0x16, 0x9d, 0x93, 0x91, 0xdf, 0x6d, 0xec, 0xf4, 0x2e, 0xe5, 0x41, 0xb6, 0xd8, 0xf0, 0xc9, 0xa1, 0x37, 0x40, 0x1e, 0x23, 0x63, 0x2d, 0xda, 0x34, 0xd2, 0x4f}),
-				new(big.Int).SetBytes([]byte{0x4d, 0x9f, 0x92, 0xe7, 0x16, 0xd1, 0xc7, 0x35, 0x26, 0xfc, 0x99, 0xcc, 0xfb, 0x8a, 0xd3, 0x4c, 0xe8, 0x86, 0xee, 0xdf, 0xa8, 0xd8, 0xe4, 0xf1, 0x3a, 0x7f, 0x71, 0x31, 0xde, 0xba, 0x94, 0x14}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x64, 0x51, 0xfb, 0x84, 0xcf, 0xb1, 0x8d, 0x3e, 0xf0, 0xac, 0xf8, 0x56, 0xc4, 0xef, 0x4d, 0x5, 0x53, 0xc5, 0x62, 0xf7, 0xae, 0x4d, 0x2a, 0x30, 0x3f, 0x2e, 0xa3, 0x3e, 0x8f, 0x62, 0xbb}),
-				new(big.Int).SetBytes([]byte{0xe7, 0x45, 0xce, 0xb2, 0xb1, 0x87, 0x15, 0x78, 0xb6, 0xfe, 0x7a, 0x5c, 0x1b, 0xc3, 0x44, 0xcc, 0xfa, 0x2a, 0xb4, 0x92, 0xd2, 0x0, 0xe8, 0x3f, 0xd0, 0xad, 0x90, 0x86, 0x13, 0x2c, 0x9, 0x11}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3e, 0x41, 0x96, 0x34, 0xe1, 0x56, 0xa3, 0xa2, 0x49, 0x49, 0xbc, 0x8e, 0x8d, 0x39, 0x6f, 0xaf, 0x9, 0x43, 0x1, 0x23, 0x67, 0x7b, 0x39, 0x2b, 0x5c, 0x84, 0x10, 0xaf, 0x3b, 0xea, 0xc, 0x68}),
-				new(big.Int).SetBytes([]byte{0x1, 0x23, 0xc5, 0x9d, 0x92, 0x4b, 0x21, 0xf7, 0xf3, 0x73, 0xcb, 0xfe, 0x37, 0x6, 0x93, 0x6, 0x2f, 0xa1, 0x19, 0x46, 0x30, 0x3c, 0xda, 0x1a, 0xbc, 0xbb, 0x6f, 0xf7, 0x1a, 0x45, 0xed, 0xb6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1e, 0xee, 0x20, 0x7c, 0xb2, 0x40, 0x86, 0xbc, 0x71, 0x6e, 0x81, 0xa0, 0x6f, 0x9e, 0xdb, 0xbb, 0x0, 0x42, 0xe2, 0xd5, 0xdc, 0xf3, 0xc7, 0xa1, 0xfa, 0x1d, 0x1f, 0xb9, 0xd5, 0xfe, 0x69, 0x6b}),
-				new(big.Int).SetBytes([]byte{0x65, 0x2c, 0xbd, 0x19, 0xae, 0xf6, 0x26, 0x9c, 0xd2, 0xb1, 0x96, 0xd1, 0x24, 0x61, 0xc9, 0x5f, 0x7a, 0x2, 0x6, 0x2e, 0xa, 0xfd, 0x69, 0x4e, 0xbb, 0x45, 0x67, 0xe, 0x74, 0x29, 0x33, 0x7b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8d, 0x94, 0x38, 0xf5, 0x45, 0x5d, 0x75, 0x8, 0xee, 0xd4, 0xa3, 0xe6, 0x2f, 0x7f, 0xb, 0x57, 0x6e, 0xb7, 0xb6, 0x4c, 0x35, 0x1c, 0x98, 0x97, 0xaf, 0x75, 0xd2, 0x3c, 0x93, 0x98, 0x24, 0xd7}),
-				new(big.Int).SetBytes([]byte{0x32, 0x61, 0xe0, 0x73, 0x4f, 0xee, 0x6c, 0x2a, 0x2c, 0xa6, 0xb, 0xd3, 0x1a, 0xb6, 0xef, 0x6f, 0x8f, 0xb9, 0xe2, 0xb8, 0x32, 0x6b, 0x6, 0x3d, 0x8a, 0x0, 0x4f, 0x48, 0x93, 0x66, 0x48, 0x9f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf1, 0x3a, 0x99, 0xe5, 0x8d, 0xc7, 0x2f, 0xcb, 0xc, 0x62, 0xa4, 0x92, 0xd2, 0x85, 0x7, 0x4, 0x62, 0x1d, 0xdf, 0x48, 0xf1, 0xf4, 0x33, 0xe6, 0x9a, 0x98, 0x14, 0xc4, 0x17, 0xd4, 0xb8, 0x4a}),
-				new(big.Int).SetBytes([]byte{0x33, 0xc2, 0xc8, 0xcd, 0xf, 0xb, 0xe9, 0x95, 0xaa, 0x6b, 0x91, 0xcd, 0x1e, 0x3f, 0xe0, 0x6e, 0xb6, 0xe3, 0x7d, 0x47, 0x10, 0xf2, 0xd9, 0x62, 0x85, 0x99, 0xf, 0xc5, 0x53, 0xfd, 0x1c, 0x81}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb7, 0x25, 0x24, 0xc5, 0x58, 0xee, 0x54, 0x42, 0xd, 0x4a, 0x91, 0x2a, 0x2f, 0xe5, 0x45, 0x43, 0x93, 0x60, 0xc2, 0xfb, 0x74, 0x28, 0xe6, 0x20, 0x8e, 0x48, 0x7, 0x1a, 0x98, 0xd7, 0x13, 0xde}),
-				new(big.Int).SetBytes([]byte{0x4c, 0x51, 0xb3, 0x9a, 0x8a, 0x28, 0x3e, 0x45, 0x10, 0x42, 0xd1, 0x82, 0xe9, 0xd6, 0x94, 0x15, 0x4, 0x82, 0xd2, 0x6f, 0xe4, 0x4a, 0x5f, 0xcb, 0x76, 0xff, 0xe5, 0x25, 0x9b, 0x83, 0x50, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcc, 0xe, 0xa3, 0x3e, 0xa8, 0xa9, 0xeb, 0x14, 0xd4, 0x65, 0xab, 0x2c, 0x34, 0x6e, 0x21, 0x11, 0xe1, 0xc0, 0xfc, 0x1, 0x7c, 0x57, 0x25, 0x79, 0x8, 0xd4, 0xf, 0x19, 0xef, 0x94, 0xc0, 0xd5}),
-				new(big.Int).SetBytes([]byte{0xf9, 0x90, 0x7a, 0x3b, 0x71, 0x1c, 0x8a, 0x2f, 0xb2, 0x3d, 0xd2, 0x3, 0xb5, 0xfb, 0xe6, 0x63, 0xf6, 0x7, 0x4f, 0x26, 0x61, 0x13, 0xf5, 0x43, 0xde, 0xab, 0xe5, 0x97, 0xaf, 0x45, 0x2f, 0xe6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3d, 0xe4, 0x5f, 0x5a, 0x21, 0x6d, 0x12, 0x51, 0x9a, 0xdc, 0x76, 0xe3, 0x81, 0x21, 0x14, 0x9e, 0x30, 0x49, 0xf3, 0x5b, 0xf3, 0x72, 0x10, 0x40, 0x2c, 0xc6, 0xc2, 0x93, 0xfe, 0x3b, 0x2c, 0xb4}),
-				new(big.Int).SetBytes([]byte{0xba, 0x2a, 0x85, 0x98, 0x40, 0x5e, 0xad, 0xdc, 0x50, 0xe0, 0x8a, 0xa4, 0xa9, 0x7c, 0x91, 0x6, 0x82, 0x3e, 0x28, 0xe6, 0x5a, 0x87, 0x4, 0x4c, 0xf6, 0x1e, 0x33, 0xac, 0x30, 0x7e, 0xb0, 0x2f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9e, 0x4a, 0xc6, 0x4d, 0x9c, 0xa5, 0x8a, 0x4, 0x46, 0xca, 0x18, 0xbf, 0xc1, 0x70, 0xf, 0x2c, 0x16, 0x93, 0x7e, 0xf9, 0xc8, 0x2e, 0x8b, 0x55, 0x9c, 0xcb, 0xd7, 0x51, 0x2a, 0xc, 0x2, 0x22}),
-				new(big.Int).SetBytes([]byte{0xce, 0xa4, 0x5d, 0x1d, 0x9f, 0xe4, 0xe7, 0x4d, 0x30, 0x80, 0xe8, 0xdf, 0x63, 0xb3, 0x0, 0xd0, 0x1c, 0x62, 0x3, 0xf, 0x3d, 0xab, 0x90, 0x4e, 0x16, 0xcf, 0x76, 0x73, 0xa4, 0xfd, 0xc9, 0xbb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0x11, 0xf1, 0xc6, 0x89, 0x59, 0xbe, 0x87, 0xc1, 0xe3, 0xfc, 0xe3, 0x74, 0x89, 0x6, 0xae, 0x9b, 0x67, 0xca, 0x86, 0xa0, 0x90, 0x5, 0xb1, 0x72, 0x34, 0x76, 0x6f, 0x6c, 0xe4, 0xe5, 0xbf}),
-				new(big.Int).SetBytes([]byte{0xec, 0xdf, 0xf5, 0xcf, 0x91, 0xbc, 0xd4, 0x87, 0x4c, 0x1d, 0xdf, 0x7e, 0xc6, 0xaa, 0x0, 0x23, 0x2d, 0xa8, 0x78, 0x89, 0xbc, 0x53, 0x65, 0x26, 0x2f, 0xc9, 0x29, 0x44, 0xc1, 0x78, 0x9c, 0x8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x67, 0x8a, 0xc7, 0xc0, 0x79, 0x9b, 0x56, 0xcb, 0x46, 0xb8, 0xcd, 0xda, 0xd6, 0x12, 0x2, 0xfc, 0x40, 0x3, 0xe0, 0x96, 0x6b, 0xe6, 0x1f, 0xa0, 0x5f, 0xc, 0x3b, 0x14, 0x4e, 0x73, 0x5a, 0x1e}),
-				new(big.Int).SetBytes([]byte{0x8, 0x51, 0x4e, 0x33, 0xd9, 0xa5, 0x28, 0x5b, 0x7a, 0x70, 0xff, 0x3d, 0x57, 0x10, 0x5e, 0x8e, 0x59, 0x7f, 0xff, 0xb3, 0x3d, 0x37, 0x14, 0x57, 0xa3, 0x63, 0xa5, 0xf7, 0x12, 0xf7, 0xc0, 0x55}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8c, 0x5a, 0x5, 0x2e, 0x81, 0xcf, 0x60, 0x22, 0x2c, 0xfa, 0x1c, 0x72, 0xee, 0x14, 0xab, 0x59, 0x57, 0xd2, 0x42, 0x81, 0x8a, 0xbc, 0x14, 0xdc, 0x31, 0xb5, 0x66, 0x8a, 0x3d, 0x32, 0x58, 0xab}),
-				new(big.Int).SetBytes([]byte{0x68, 0x11, 0xdb, 0x4b, 0xb4, 0x43, 0xbd, 0xa1, 0x7, 0x9a, 0x80, 0xc7, 0x90, 0x4c, 0x8, 0xc2, 0x46, 0xda, 0xe8, 0xf9, 0xc0, 0xd9, 0x44, 0xad, 0xe5, 0x7e, 0x8, 0x53, 0x2, 0x2d, 0xf, 0x8f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x64, 0xba, 0x95, 0x14, 0xd8, 0x68, 0xf, 0x6c, 0xdd, 0x66, 0x89, 0x5d, 0x9c, 0x5a, 0xd4, 0x5f, 0x0, 0x0, 0x6e, 0x29, 0x33, 0x50, 0x6f, 0x8e, 0xd7, 0xbe, 0x97, 0x70, 0x82, 0x2a, 0xa9, 0xb8}),
-				new(big.Int).SetBytes([]byte{0x81, 0x27, 0x3f, 0x22, 0xa6, 0x43, 0x1b, 0xdb, 0xb7, 0xe0, 0x77, 0x28, 0xc1, 0x9b, 0xe4, 0x9e, 0x5f, 0xef, 0x1b, 0x6f, 0x68, 0x30, 0x7b, 0x35, 0xe9, 0x3d, 0x1f, 0xb9, 0xb6, 0x16, 0x35, 0x65}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xce, 0xf7, 0xf8, 0x16, 0xde, 0xbd, 0x35, 0x60, 0xbd, 0xc6, 0x3c, 0xec, 0x55, 0x6b, 0xf1, 0x37, 0xf5, 0xcf, 0x1d, 0x8f, 0x1e, 0xc, 0xd, 0x84, 0x15, 0x8c, 0x2a, 0xa, 0xbf, 0x91, 0xbc, 0xee}),
-				new(big.Int).SetBytes([]byte{0x67, 0xe7, 0x4c, 0x83, 0x63, 0x74, 0x87, 0x1, 0x24, 0x63, 0xb3, 0x39, 0xc0, 0x1d, 0x24, 0x5f, 0xa6, 0x3e, 0x4b, 0x2, 0xa1, 0x72, 0xf6, 0xac, 0x6b, 0xb4, 0xda, 0x9f, 0x81, 0x96, 0x6b, 0x33}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1e, 0xc8, 0xf, 0xef, 0x36, 0xc, 0xbd, 0xd9, 0x54, 0x16, 0xf, 0xad, 0xab, 0x35, 0x2b, 0x6b, 0x92, 0xb5, 0x35, 0x76, 0xa8, 0x8f, 0xea, 0x49, 0x47, 0x17, 0x3b, 0x9d, 0x43, 0x0, 0xbf, 0x19}),
-				new(big.Int).SetBytes([]byte{0xae, 0xef, 0xe9, 0x37, 0x56, 0xb5, 0x34, 0xd, 0x2f, 0x3a, 0x49, 0x58, 0xa7, 0xab, 0xbf, 0x5e, 0x1, 0x46, 0xe7, 0x7f, 0x62, 0x95, 0xa0, 0x7b, 0x67, 0x1c, 0xdc, 0x1c, 0xc1, 0x7, 0xce, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5b, 0xe7, 0xea, 0x35, 0x19, 0xf0, 0x4b, 0xc6, 0xcb, 0xee, 0xaa, 0x3, 0x44, 0xfc, 0x90, 0xbb, 0x8e, 0x84, 0x62, 0xf6, 0xeb, 0xd8, 0x90, 0x56, 0xd, 0xae, 0x80, 0x5d, 0x41, 0x4f, 0xf9, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x32, 0xf3, 0x2e, 0xc3, 0xf6, 0x38, 0xe6, 0x5, 0x47, 0x7f, 0x89, 0xf, 0x65, 0x5a, 0xb7, 0xfe, 0xe, 0x99, 0xc6, 0x30, 0x21, 0x19, 0xa3, 0x9, 0x40, 0x30, 0xb0, 0x78, 0x47, 0xe0, 0xbd, 0xbb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa6, 0xdc, 0x88, 0xa, 0x55, 0xd1, 0xf2, 0xe8, 0x3b, 0xcf, 0xdd, 0xab, 0x67, 0x10, 0x65, 0x31, 0xc4, 0xff, 0xb, 0x50, 0x8c, 0x4, 0x52, 0xb9, 0x4b, 0x17, 0xcb, 0xbc, 0x52, 0xfe, 0xa1, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x7e, 0xf1, 0xa8, 0x54, 0x7d, 0xc3, 0x67, 0xc3, 0x3, 0x86, 0x83, 0xa1, 0x16, 0xac, 0xbc, 0x50, 0x5, 0x7b, 0x89, 0xdb, 0x7e, 0x68, 0xf7, 0xe6, 0x3b, 0x1c, 0x14, 0xe4, 0x7b, 0xc3, 0x45, 0xe9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0xf0, 0x99, 0x11, 0x6e, 0xae, 0x4e, 0x65, 0x8, 0x13, 0xfc, 0x86, 0x98, 0xdf, 0x7f, 0x5c, 0xd5, 0x0, 0x28, 0x64, 0x9f, 0x85, 0x39, 0x91, 0xe3, 0xfb, 0x54, 0x5f, 0x4d, 0xdb, 0x7b, 0xb8}),
-				new(big.Int).SetBytes([]byte{0x7e, 0x7, 0x0, 0x2a, 0xaf, 0xfe, 0x11, 0x1a, 0xd, 0x62, 0xff, 0x76, 0x14, 0x63, 0x80, 0x66, 0x50, 0x7e, 0xe4, 0x6, 0x2d, 0x17, 0x43, 0x2, 0xbd, 0xec, 0x73, 0x58, 0x2e, 0x5b, 0x2d, 0x6e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0x6b, 0xa9, 0x3f, 0xea, 0x42, 0x45, 0x99, 0x2b, 0xdc, 0x22, 0x9c, 0x78, 0xa4, 0x81, 0xba, 0x8c, 0x6c, 0x4c, 0xe8, 0x74, 0x86, 0x56, 0x37, 0xc8, 0xd4, 0xc, 0x3f, 0x6, 0xd6, 0xc9, 0xb3}),
-				new(big.Int).SetBytes([]byte{0x7e, 0xe9, 0x18, 0xd7, 0x40, 0x53, 0x98, 0x72, 0xb6, 0xbb, 0x41, 0xb3, 0x45, 0x41, 0x3b, 0x56, 0xd9, 0x80, 0xf1, 0xbf, 0x5, 0xc2, 0xe9, 0xb0, 0xc, 0x2c, 0x78, 0x8f, 0xa9, 0x48, 0xbd, 0xfb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x23, 0x20, 0xb5, 0xca, 0xf7, 0xb5, 0x9b, 0x7c, 0xe5, 0x42, 0x84, 0x28, 0x2, 0xf7, 0x4c, 0x34, 0x13, 0x4b, 0xfc, 0x49, 0x5b, 0x9e, 0x2b, 0x10, 0x8b, 0x61, 0x3e, 0x77, 0x1c, 0x79, 0x85, 0xc4}),
-				new(big.Int).SetBytes([]byte{0xc7, 0x9f, 0x94, 0x3d, 0xc8, 0x8b, 0xe9, 0x43, 0x18, 0xc7, 0x21, 0xb8, 0x1f, 0x9b, 0xca, 0x93, 0xd9, 0x6f, 0x10, 0x21, 0x1c, 0xd9, 0xeb, 0x8d, 0x4f, 0xf4, 0xf1, 0xdf, 0x4c, 0x2c, 0x6d, 0x44}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x15, 0xd5, 0xe2, 0xf1, 0x46, 0xfc, 0x98, 0xbf, 0xb0, 0x20, 0xc8, 0xc2, 0xdc, 0x8, 0xde, 0xd1, 0xb9, 0x64, 0x80, 0x6e, 0x44, 0x2c, 0x4b, 0x64, 0x42, 0x2f, 0x10, 0x73, 0xc, 0xf9, 0x51, 0x51}),
-				new(big.Int).SetBytes([]byte{0xa5, 0xb7, 0x2e, 0x31, 0x91, 0x5f, 0xd4, 0xec, 0x7f, 0x90, 0xe1, 0x9, 0x78, 0x90, 0x23, 0xf9, 0xbb, 0x20, 0x4f, 0xb9, 0x7d, 0xd8, 0xc0, 0xf9, 0x48, 0x2a, 0x7, 0xcc, 0x2f, 0xf8, 0xec, 0xf2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb0, 0xf9, 0xe4, 0xb9, 0xb2, 0x97, 0x90, 0xb6, 0x33, 0xbc, 0xc0, 0x4f, 0xd8, 0x60, 0xcb, 0xf, 0x82, 0x3d, 0x8d, 0x1a, 0x4c, 0xc1, 0xa1, 0xc1, 0x41, 0x3c, 0x16, 0x6, 0xcc, 0x9a, 0x8e, 0x2c}),
-				new(big.Int).SetBytes([]byte{0x49, 0xe8, 0x2b, 0xf1, 0x84, 0x3a, 0xde, 0x6d, 0x41, 0xcb, 0xb0, 0xb9, 0x6, 0xfd, 0xe3, 0xf0, 0x33, 0x50, 0xcc, 0x2, 0xc1, 0x71, 0xce, 0xe7, 0x6c, 0x20, 0x66, 0xc4, 0xdf, 0x3d, 0xd, 0xb4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x84, 0xc0, 0xe8, 0x72, 0x56, 0x88, 0x44, 0x7a, 0xd8, 0xc7, 0xe0, 0x9, 0x27, 0xae, 0x29, 0xbe, 0x38, 0x14, 0xb2, 0x52, 0x41, 0xef, 0x65, 0x64, 0x3d, 0x78, 0x10, 0xb9, 0x29, 0x6a, 0x56, 0x58}),
-				new(big.Int).SetBytes([]byte{0x26, 0x59, 0x83, 0x80, 0xc1, 0x60, 0x22, 0xc4, 0xa0, 0x87, 0xe3, 0x19, 0x6, 0x53, 0x72, 0x5e, 0xd3, 0xa6, 0x1a, 0x98, 0x1f, 0x4f, 0x1f, 0xd2, 0x2d, 0x96, 0x72, 0x9b, 0xd8, 0x1b, 0x80, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x56, 0xdb, 0x62, 0x80, 0x67, 0xa, 0x91, 0xec, 0x8f, 0xad, 0x4, 0xce, 0x21, 0x3d, 0x59, 0xf4, 0x94, 0xb3, 0x57, 0xcf, 0x1c, 0x9a, 0x12, 0x4c, 0x5a, 0xa0, 0x2f, 0x29, 0xd9, 0xd6, 0xfa, 0xd4}),
-				new(big.Int).SetBytes([]byte{0xda, 0xd, 0x3b, 0x94, 0xdb, 0x57, 0x27, 0x24, 0x50, 0xf5, 0x91, 0x1, 0x6d, 0x68, 0x30, 0xc7, 0xf5, 0xb8, 0xb2, 0xa1, 0xa3, 0x1e, 0x5c, 0xc8, 0x67, 0xa9, 0xc0, 0xfd, 0x35, 0x9e, 0x8, 0x36}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfe, 0xd6, 0xb1, 0xc7, 0x1a, 0x93, 0x73, 0x1d, 0x56, 0x52, 0x64, 0xe7, 0xa2, 0xc0, 0x77, 0xc2, 0x74, 0x43, 0x41, 0x91, 0x42, 0xfe, 0x15, 0x75, 0x37, 0x5b, 0xb5, 0xc8, 0x94, 0xdc, 0x6a, 0xf}),
-				new(big.Int).SetBytes([]byte{0x8d, 0xe2, 0xab, 0xe6, 0xb0, 0xff, 0xff, 0xa, 0x84, 0x79, 0x18, 0x1a, 0xe4, 0xd3, 0xae, 0x23, 0x13, 0x74, 0xd5, 0x93, 0xa, 0x3f, 0xc4, 0x9, 0xcc, 0xb8, 0xd7, 0x2d, 0x97, 0x6f, 0xc7, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0x5e, 0x7e, 0xc8, 0x39, 0x33, 0xdf, 0x56, 0xbf, 0xbc, 0xf9, 0x7e, 0x42, 0x54, 0xa8, 0x9e, 0xda, 0xb6, 0x62, 0xf5, 0xd7, 0x98, 0x34, 0x6d, 0x79, 0xfe, 0xb, 0xb, 0xca, 0x3a, 0x80, 0x9c}),
-				new(big.Int).SetBytes([]byte{0xe0, 0xd3, 0xf6, 0xed, 0xe2, 0x54, 0xcb, 0x38, 0x88, 0x77, 0x47, 0xca, 0xf5, 0x72, 0x1a, 0x37, 0x1e, 0x4e, 0x3b, 0x2e, 0xf0, 0x7f, 0xca, 0x3d, 0xda, 0x8c, 0x90, 0xa, 0x94, 0x9e, 0x70, 0xd9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2a, 0x6a, 0x4d, 0xd9, 0x92, 0xd3, 0xcb, 0xc7, 0xf, 0xce, 0xf8, 0x60, 0x40, 0x23, 0xb9, 0xd8, 0x2f, 0x1c, 0x8e, 0x93, 0x39, 0x72, 0xd, 0x86, 0xdb, 0x38, 0x85, 0xf1, 0x18, 0xe2, 0x93, 0x55}),
-				new(big.Int).SetBytes([]byte{0x86, 0x77, 0xdf, 0xba, 0x7c, 0x4a, 0x7e, 0xc, 0x76, 0xf, 0xdc, 0x81, 0xe2, 0x68, 0xf9, 0xd, 0xfe, 0x90, 0x5a, 0x3a, 0x64, 0xc4, 0xc7, 0x6c, 0x0, 0x53, 0xe0, 0xf, 0xb5, 0xcc, 0x28, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa1, 0xac, 0xb3, 0xf1, 0x18, 0x5d, 0x20, 0xe1, 0xbf, 0x33, 0xce, 0x97, 0xdd, 0xaf, 0x3d, 0x8f, 0x19, 0x6e, 0x23, 0x5e, 0xaf, 0xcb, 0x9b, 0xb4, 0x7f, 0x52, 0x95, 0x11, 0xe7, 0x68, 0x10, 0x28}),
-				new(big.Int).SetBytes([]byte{0x55, 0xa8, 0x83, 0xa6, 0x1a, 0xbb, 0xb2, 0x9b, 0x6a, 0x4, 0x75, 0x88, 0x28, 0x8f, 0xc, 0x5a, 0x66, 0xee, 0xcb, 0x27, 0x1f, 0xa0, 0xd7, 0x88, 0x1f, 0x1a, 0x46, 0x3e, 0x14, 0xa1, 0x1c, 0x9d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe4, 0x2d, 0x93, 0x2, 0x6c, 0x92, 0x75, 0x46, 0xd9, 0x76, 0x4e, 0xc2, 0x35, 0xfa, 0xe7, 0x35, 0xc1, 0xad, 0xd2, 0xb9, 0x34, 0x9d, 0x78, 0xeb, 0x1f, 0x9f, 0xa8, 0x8f, 0xe1, 0x48, 0xda, 0xbf}),
-				new(big.Int).SetBytes([]byte{0x64, 0x13, 0x86, 0x2c, 0x6e, 0x2d, 0x84, 0xb0, 0xd8, 0xb3, 0x8c, 0xeb, 0xfd, 0xf3, 0x85, 0x30, 0x32, 0x72, 0x75, 0x70, 0x19, 0xe7, 0x7e, 0xb9, 0xd7, 0x72, 0xc7, 0x56, 0x81, 0x1e, 0x13, 0x61}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x14, 0x6a, 0x77, 0x8c, 0x4, 0x67, 0xc, 0x2f, 0x91, 0xb0, 0xa, 0xf4, 0x68, 0xd, 0xfa, 0x8b, 0xce, 0x34, 0x90, 0x71, 0x7d, 0x58, 0xba, 0x88, 0x9d, 0xdb, 0x59, 0x28, 0x36, 0x66, 0x42, 0xbe}),
-				new(big.Int).SetBytes([]byte{0xb3, 0x18, 0xe0, 0xec, 0x33, 0x54, 0x2, 0x8a, 0xdd, 0x66, 0x98, 0x27, 0xf9, 0xd4, 0xb2, 0x87, 0xa, 0xaa, 0x97, 0x1d, 0x2f, 0x7e, 0x5e, 0xd1, 0xd0, 0xb2, 0x97, 0x48, 0x3d, 0x83, 0xef, 0xd0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x57, 0x4e, 0xf0, 0xce, 0x8a, 0x59, 0x7e, 0x24, 0xe5, 0x67, 0xb, 0x5c, 0xb, 0xcd, 0x14, 0xcf, 0xee, 0xfc, 0x98, 0x3c, 0x7e, 0xcb, 0x26, 0x19, 0x11, 0xb2, 0x36, 0x55, 0x79, 0xde, 0x5c, 0xac}),
-				new(big.Int).SetBytes([]byte{0x9, 0xb9, 0x99, 0x30, 0x28, 0x1f, 0x19, 0xc7, 0x3b, 0xd6, 0xad, 0xa0, 0x56, 0x9b, 0x78, 0x45, 0x1a, 0x26, 0xa, 0x7b, 0xef, 0x10, 0x0, 0x8c, 0xae, 0x59, 0xae, 0xa6, 0xc7, 0x5a, 0x48, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfc, 0x69, 0x6c, 0x4, 0x6, 0x60, 0x93, 0x5f, 0xf4, 0x2c, 0x89, 0x98, 0x20, 0xa1, 0x42, 0xa1, 0x3d, 0x79, 0xbb, 0xd5, 0x4a, 0xe8, 0x67, 0x29, 0x9d, 0x93, 0x87, 0x38, 0x27, 0x31, 0x54, 0x43}),
-				new(big.Int).SetBytes([]byte{0xe0, 0xce, 0x27, 0xeb, 0xf8, 0x3b, 0x58, 0x92, 0xbb, 0xf0, 0xe1, 0xcd, 0xd6, 0x96, 0x77, 0xb5, 0x48, 0x7f, 0xf4, 0x86, 0x10, 0x9b, 0xd1, 0xb1, 0x74, 0x90, 0xd6, 0xb, 0x57, 0xd2, 0x89, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd3, 0xd9, 0x7e, 0x79, 0x9d, 0x8b, 0xf9, 0xf8, 0x5d, 0x90, 0x93, 0x97, 0xb9, 0x8c, 0x83, 0x5d, 0x10, 0xa7, 0x70, 0xc1, 0xae, 0xff, 0x86, 0x45, 0x80, 0x8c, 0x2d, 0x74, 0x26, 0x9, 0x66, 0xd3}),
-				new(big.Int).SetBytes([]byte{0x8d, 0xdb, 0xb4, 0x63, 0x76, 0xba, 0xc9, 0x5e, 0x6a, 0xaa, 0x89, 0x27, 0x5d, 0x40, 0x3a, 0xd3, 0xb5, 0xe4, 0x87, 0x11, 0xbe, 0x8d, 0xc4, 0xee, 0xbd, 0xde, 0xb8, 0x50, 0x83, 0x3c, 0x2e, 0x52}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0x4a, 0xf2, 0x21, 0xff, 0x4f, 0xf2, 0xad, 0x1a, 0xe5, 0x6a, 0x35, 0x62, 0xbe, 0xb0, 0x92, 0x8f, 0xa3, 0xdd, 0x79, 0xc6, 0x2e, 0x6a, 0x79, 0xd1, 0xbb, 0x2f, 0x5c, 0x16, 0xfd, 0xb4, 0xeb}),
-				new(big.Int).SetBytes([]byte{0x55, 0x52, 0x38, 0x7d, 0x53, 0x50, 0x3, 0xca, 0x64, 0xd1, 0xe4, 0x3d, 0x2, 0xc0, 0x90, 0xed, 0x11, 0x1c, 0x57, 0x2a, 0x3b, 0xff, 0xc2, 0x34, 0x84, 0x9, 0xc3, 0xdf, 0xa9, 0xf6, 0xf4, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa0, 0xe8, 0x86, 0x57, 0x0, 0xef, 0x43, 0x38, 0xcd, 0xf5, 0x87, 0xed, 0x3b, 0xf2, 0x0, 0xe5, 0x41, 0x85, 0xc8, 0xaa, 0xed, 0x88, 0x8b, 0x60, 0x7f, 0x36, 0x15, 0xfd, 0x7c, 0x6b, 0x5b, 0x56}),
-				new(big.Int).SetBytes([]byte{0xf5, 0xba, 0x46, 0x83, 0x9f, 0xa9, 0xad, 0x50, 0xb9, 0x9a, 0x6b, 0x89, 0xb8, 0x53, 0xde, 0xad, 0x52, 0x6b, 0x87, 0x1d, 0x70, 0x85, 0x8a, 0x51, 0xa1, 0x6b, 0xde, 0x51, 0x3a, 0x1d, 0x75, 0x18}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5b, 0xaa, 0xb5, 0x9b, 0x49, 0xde, 0x39, 0x8b, 0x9, 0x12, 0x1b, 0x3a, 0x66, 0x92, 0x70, 0xc5, 0xe8, 0x28, 0x4b, 0xc3, 0x66, 0x58, 0xc8, 0x13, 0x73, 0x90, 0x4a, 0xdb, 0x5d, 0x5a, 0xee, 0xe3}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x57, 0x7f, 0x61, 0x7c, 0xf, 0x7e, 0x85, 0x6e, 0x5a, 0x0, 0x86, 0x61, 0xff, 0xda, 0xf7, 0x21, 0x5d, 0xdc, 0x9d, 0xab, 0xd3, 0x1e, 0xed, 0x82, 0xd2, 0x9a, 0xfb, 0x70, 0xf6, 0x97, 0x17}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb1, 0xaa, 0x65, 0x32, 0x88, 0xb3, 0x18, 0x98, 0x7b, 0x97, 0x4e, 0x78, 0x2c, 0xbb, 0xee, 0xa, 0xb2, 0xbe, 0x78, 0xcf, 0x8f, 0x49, 0x4c, 0x12, 0x0, 0x40, 0xfb, 0x93, 0x96, 0x8c, 0x6d, 0x4b}),
-				new(big.Int).SetBytes([]byte{0x7e, 0xd6, 0x7, 0x1c, 0x60, 0x81, 0xd, 0x71, 0x26, 0x84, 0xaa, 0x8e, 0x2d, 0x63, 0xa8, 0x3b, 0x10, 0xa, 0x1d, 0x90, 0x9d, 0x62, 0x3c, 0xc3, 0x83, 0xd9, 0xe6, 0x2a, 0xe8, 0x91, 0xac, 0x51}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfb, 0x68, 0x5f, 0xa7, 0xeb, 0x49, 0xc4, 0x3d, 0x1, 0x3, 0x2f, 0xce, 0xbf, 0x91, 0xa, 0xe, 0xe5, 0x90, 0x25, 0xfe, 0xac, 0x83, 0x7d, 0x15, 0x85, 0x1b, 0x1e, 0x44, 0xf5, 0x72, 0x68, 0x90}),
-				new(big.Int).SetBytes([]byte{0xd7, 0x50, 0xdf, 0x22, 0xc9, 0x8e, 0x5e, 0x2e, 0x86, 0x9c, 0xa1, 0x6d, 0x98, 0x64, 0xba, 0x9, 0x4f, 0xa6, 0xcb, 0x12, 0xe9, 0xe, 0x79, 0xb2, 0x60, 0x6e, 0xa8, 0xf3, 0xb8, 0x35, 0x78, 0x3a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x25, 0x11, 0x6c, 0x41, 0x8, 0xc7, 0x10, 0x5f, 0x35, 0x8a, 0xb0, 0xb8, 0x39, 0x2a, 0x59, 0x7f, 0x24, 0xfd, 0xff, 0xee, 0x35, 0x6c, 0xfe, 0x6a, 0xb7, 0xee, 0xda, 0xec, 0x62, 0xc6, 0xe5, 0xbb}),
-				new(big.Int).SetBytes([]byte{0x60, 0xd8, 0x69, 0x13, 0xe7, 0x71, 0x77, 0xe0, 0x1e, 0xb7, 0x40, 0x6d, 0xc8, 0x15, 0xdd, 0x8f, 0x77, 0xc5, 0x8, 0xdf, 0x51, 0xcc, 0x27, 0x2d, 0x4d, 0xc4, 0x73, 0x2a, 0xf, 0x7e, 0x13, 0x21}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5d, 0xe5, 0x8f, 0xf6, 0x59, 0xe2, 0x99, 0x5a, 0xa, 0xb2, 0x7b, 0x91, 0xc2, 0x87, 0xf7, 0x1d, 0x78, 0xb4, 0xd5, 0xf4, 0xe8, 0x3d, 0xf6, 0x2f, 0x54, 0x64, 0xbf, 0x13, 0xa8, 0xbc, 0xa4, 0x8a}),
-				new(big.Int).SetBytes([]byte{0x68, 0x5a, 0x3b, 0x3d, 0x80, 0x35, 0x9b, 0x55, 0x30, 0x9a, 0x62, 0x3c, 0x25, 0x2d, 0x3c, 0x84, 0x3f, 0xe5, 0xbd, 0x9c, 0x4e, 0xf, 0x8a, 0xa9, 0x58, 0x79, 0x4e, 0x80, 0xd2, 0xfd, 0x41, 0xcd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xaf, 0x88, 0x48, 0x37, 0x29, 0x61, 0xeb, 0xb2, 0x61, 0x1d, 0x81, 0x0, 0xf9, 0x63, 0x9e, 0x2a, 0x3c, 0xc8, 0xb9, 0x9d, 0xea, 0x57, 0x15, 0x54, 0x1d, 0x76, 0x5d, 0xf7, 0x50, 0xce, 0xdd, 0x26}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x57, 0xfb, 0xf9, 0x7b, 0x16, 0x70, 0x30, 0xd2, 0x8c, 0xbb, 0x88, 0xeb, 0xed, 0x2f, 0x96, 0x54, 0x54, 0x9e, 0x48, 0x67, 0xaa, 0x41, 0x70, 0x8b, 0x6e, 0x7a, 0xd3, 0xb0, 0xe4, 0x3f, 0x7f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6f, 0x18, 0x25, 0xcd, 0xea, 0xd6, 0x18, 0xbf, 0xaf, 0x1b, 0x7f, 0xda, 0x3b, 0x4f, 0xc1, 0xc8, 0x5a, 0xb4, 0xd0, 0x16, 0x73, 0x1b, 0x18, 0x2e, 0xbd, 0xe3, 0x83, 0x44, 0xe5, 0xef, 0x63, 0xb6}),
-				new(big.Int).SetBytes([]byte{0xff, 0x9, 0x7e, 0x7d, 0xd5, 0x91, 0x91, 0x1b, 0x98, 0xf4, 0x20, 0x4d, 0x78, 0x33, 0x34, 0x55, 0x3c, 0x89, 0x5a, 0x64, 0x2, 0x7f, 0x94, 0x54, 0x12, 0xc8, 0x46, 0xcf, 0x45, 0xc6, 0x55, 0xf3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x52, 0x10, 0xd6, 0x60, 0x5b, 0x88, 0x80, 0x36, 0x5b, 0x25, 0x6e, 0x3e, 0xa0, 0x81, 0xad, 0xd0, 0xb5, 0xa2, 0x75, 0xec, 0xf6, 0xae, 0x4b, 0xdc, 0x39, 0xf9, 0xe5, 0xb1, 0xfa, 0xa5, 0xe, 0x2d}),
-				new(big.Int).SetBytes([]byte{0x35, 0xf6, 0x67, 0x4a, 0x5d, 0xec, 0xd3, 0x1, 0x39, 0xc1, 0x3c, 0xe, 0x7f, 0xf6, 0x58, 0x8b, 0x5a, 0x27, 0x3d, 0xcd, 0xc6, 0x42, 0x68, 0x6e, 0xb0, 0x29, 0xa8, 0x93, 0x5a, 0xfd, 0xa8, 0xee}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc5, 0xec, 0xb8, 0x95, 0xf7, 0x62, 0xa3, 0x76, 0xb5, 0x87, 0x11, 0x5f, 0x5e, 0x76, 0xc7, 0x65, 0x83, 0x20, 0x3b, 0x1, 0x99, 0x78, 0xf9, 0x72, 0x7b, 0x50, 0x47, 0xed, 0xf4, 0xa6, 0x3f, 0x3b}),
-				new(big.Int).SetBytes([]byte{0x22, 0x25, 0x25, 0x18, 0x19, 0x78, 0x81, 0xf3, 0x74, 0xea, 0x40, 0x58, 0xff, 0x92, 0x33, 0xec, 0x8b, 0xeb, 0x65, 0xee, 0x5e, 0x30, 0xbe, 0xe5, 0xb5, 0x3f, 0x37, 0x46, 0x85, 0x0, 0x50, 0x24}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfa, 0x50, 0xc0, 0xf6, 0x1d, 0x22, 0xe5, 0xf0, 0x7e, 0x3a, 0xce, 0xbb, 0x1a, 0xa0, 0x7b, 0x12, 0x8d, 0x0, 0x12, 0x20, 0x9a, 0x28, 0xb9, 0x77, 0x6d, 0x76, 0xa8, 0x79, 0x31, 0x80, 0xee, 0xf9}),
-				new(big.Int).SetBytes([]byte{0x6b, 0x84, 0xc6, 0x92, 0x23, 0x97, 0xeb, 0xa9, 0xb7, 0x2c, 0xd2, 0x87, 0x22, 0x81, 0xa6, 0x8a, 0x5e, 0x68, 0x32, 0x93, 0xa5, 0x7a, 0x21, 0x3b, 0x38, 0xcd, 0x8d, 0x7d, 0x3f, 0x4f, 0x28, 0x11}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x63, 0x96, 0x4e, 0xee, 0x61, 0x90, 0x74, 0xe0, 0x78, 0x1, 0x40, 0xfe, 0x2, 0xe9, 0x8, 0x36, 0xe7, 0x23, 0x28, 0xd2, 0x44, 0x83, 0x86, 0xd4, 0x59, 0xc5, 0xbe, 0x23, 0x18, 0x7f, 0x50, 0x48}),
-				new(big.Int).SetBytes([]byte{0x3b, 0x6c, 0xfb, 0x3a, 0x6b, 0x89, 0xcf, 0x41, 0xa3, 0x9f, 0xf9, 0xb1, 0xc3, 0x4b, 0xfb, 0xc9, 0x3d, 0x58, 0xb, 0x93, 0x4d, 0xde, 0x6c, 0x84, 0x38, 0x3a, 0x28, 0x4d, 0x89, 0x30, 0x9d, 0xf8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf7, 0x50, 0x2e, 0x3c, 0x43, 0x79, 0xe3, 0x1b, 0xcd, 0xa3, 0x29, 0xf9, 0x3a, 0x1c, 0xa2, 0xb6, 0xa9, 0xc6, 0xa, 0x40, 0x15, 0xca, 0xcb, 0x29, 0x71, 0xee, 0xe, 0x33, 0x91, 0xda, 0x5e, 0x12}),
-				new(big.Int).SetBytes([]byte{0x3c, 0x57, 0xf5, 0xed, 0xd6, 0x7c, 0xfa, 0xfd, 0x59, 0x97, 0x9, 0x45, 0xc3, 0xd6, 0x72, 0x4, 0x7b, 0x86, 0xd3, 0x2e, 0xf7, 0x25, 0xce, 0xbc, 0x7a, 0x4b, 0x9c, 0x5e, 0x83, 0x85, 0xf4, 0xeb}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5a, 0x3c, 0xe2, 0x5b, 0x4d, 0x15, 0xb7, 0xe2, 0x2d, 0x14, 0x69, 0xdd, 0xf0, 0xfc, 0x9f, 0x75, 0xaf, 0xd7, 0xf1, 0x2a, 0xd3, 0xcb, 0xda, 0x31, 0xf8, 0x14, 0xba, 0x1e, 0xba, 0xdb, 0x2a, 0x65}),
-				new(big.Int).SetBytes([]byte{0x8b, 0x34, 0x12, 0x5b, 0x92, 0xe0, 0x5f, 0x63, 0x87, 0x3a, 0x6d, 0xbf, 0xbf, 0x3f, 0x99, 0xaf, 0x3e, 0xe2, 0x8b, 0xc3, 0xd8, 0x25, 0xfe, 0x8e, 0xd8, 0xb1, 0x70, 0xcf, 0x1d, 0x32, 0x7f, 0x1d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x12, 0xfe, 0x78, 0xf9, 0x83, 0xae, 0x58, 0x62, 0xa3, 0xa4, 0xf6, 0x62, 0x4e, 0x34, 0x55, 0xb3, 0xf4, 0xca, 0x5c, 0x4b, 0x94, 0xe5, 0x7c, 0x9f, 0x20, 0x74, 0x93, 0x31, 0x10, 0xb7, 0xd1, 0x5}),
-				new(big.Int).SetBytes([]byte{0x20, 0x62, 0xf1, 0xa3, 0x38, 0xd6, 0xbc, 0xf7, 0x78, 0x6b, 0x5a, 0xa1, 0x99, 0xa7, 0xcb, 0x77, 0x1d, 0xec, 0xe2, 0x65, 0xd6, 0xee, 0x90, 0xb8, 0x45, 0x8a, 0xc6, 0xfb, 0x9f, 0x79, 0x4a, 0x60}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd4, 0x20, 0x11, 0xd6, 0x1, 0x6, 0x13, 0x88, 0xf, 0xec, 0x6b, 0x7f, 0x3f, 0x33, 0x2b, 0x20, 0x24, 0xab, 0x31, 0x8f, 0x2a, 0x9b, 0xa7, 0xed, 0x23, 0x73, 0x12, 0x7, 0x3e, 0x32, 0x47, 0x8e}),
-				new(big.Int).SetBytes([]byte{0xba, 0xe5, 0xd4, 0xe9, 0xa3, 0x7d, 0x4e, 0xc, 0x1b, 0x2d, 0x5f, 0x5f, 0x44, 0xbf, 0x84, 0x7b, 0x6f, 0xb2, 0x4b, 0x2f, 0x35, 0x50, 0x8c, 0xc3, 0x1c, 0xe0, 0x56, 0x81, 0xd0, 0x4e, 0x88, 0xd4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x76, 0xaa, 0xc3, 0x13, 0x47, 0xdf, 0x47, 0x3d, 0x58, 0xc4, 0xbb, 0x10, 0x28, 0x8, 0x4b, 0x1a, 0x48, 0xe, 0x6c, 0x50, 0xaa, 0x57, 0x2d, 0xaf, 0xf6, 0x21, 0xd8, 0x33, 0x9e, 0xc, 0x5d, 0x5}),
-				new(big.Int).SetBytes([]byte{0xef, 0x55, 0x76, 0xef, 0xd, 0x5c, 0x70, 0xef, 0xab, 0xef, 0x32, 0xc8, 0x32, 0x2, 0x62, 0x5f, 0x4b, 0x12, 0x25, 0xcf, 0x1, 0x5e, 0x6e, 0xe3, 0x55, 0x66, 0x19, 0xb7, 0x51, 0xec, 0xe6, 0x3e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5c, 0xe6, 0x5, 0xaf, 0x98, 0xf9, 0x3e, 0xda, 0x69, 0x10, 0xbe, 0x34, 0xf0, 0xde, 0x41, 0xff, 0x85, 0xdb, 0xcb, 0x6e, 0x69, 0xa8, 0xfa, 0x0, 0x16, 0xa7, 0x33, 0x75, 0x4a, 0x9f, 0x44, 0xd0}),
-				new(big.Int).SetBytes([]byte{0x4c, 0xdd, 0xcf, 0x9b, 0xec, 0x22, 0x6b, 0xfe, 0x7b, 0xa5, 0x6b, 0xd0, 0x31, 0xc7, 0x6c, 0x58, 0xab, 0x3c, 0xb1, 0xbf, 0xa3, 0x2e, 0xcc, 0xc6, 0xc0, 0xd0, 0x5f, 0x34, 0x89, 0xd3, 0x1, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa6, 0x63, 0xfe, 0x5b, 0xbe, 0x5c, 0x5c, 0xcc, 0x87, 0xe6, 0xc, 0x31, 0x45, 0xe8, 0x81, 0x4, 0xc6, 0xb5, 0x5c, 0x34, 0x9c, 0x9a, 0x1a, 0xec, 0x9e, 0x26, 0xf4, 0x85, 0xfc, 0x53, 0xc0, 0x86}),
-				new(big.Int).SetBytes([]byte{0xb5, 0x41, 0x99, 0x7f, 0x6b, 0x21, 0x1f, 0xbf, 0x5d, 0x1b, 0x10, 0x2c, 0x89, 0x82, 0x3d, 0xc3, 0x99, 0x1e, 0xcc, 0xa5, 0x73, 0x99, 0x4f, 0xc0, 0xe6, 0x9c, 0x50, 0x32, 0xa5, 0x1, 0x62, 0x1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe0, 0x34, 0x6d, 0x21, 0x12, 0x1f, 0xf7, 0x41, 0xfe, 0x4e, 0xaa, 0x23, 0x93, 0x8a, 0x43, 0x47, 0xa7, 0x1d, 0xf4, 0x42, 0x9e, 0x55, 0x35, 0x9e, 0xaa, 0x20, 0xd6, 0x91, 0xc9, 0xf4, 0x8, 0x40}),
-				new(big.Int).SetBytes([]byte{0x3e, 0xe6, 0x83, 0x71, 0x3c, 0x99, 0x89, 0x56, 0xd7, 0x56, 0xd4, 0xa9, 0x7e, 0xe7, 0xba, 0x2a, 0x26, 0x9c, 0xd3, 0x9a, 0x6e, 0xbd, 0x33, 0xed, 0xc3, 0x9a, 0x1a, 0xaf, 0xf3, 0xd7, 0xce, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xde, 0x95, 0x52, 0x7a, 0x2, 0x6, 0xcd, 0x82, 0x5b, 0x7c, 0xa1, 0x2, 0x7a, 0xe2, 0xbd, 0xf3, 0xd8, 0x32, 0x66, 0x89, 0x43, 0x4f, 0x9c, 0x6e, 0x9a, 0xd8, 0x1, 0xed, 0x4b, 0x75, 0x85, 0x74}),
-				new(big.Int).SetBytes([]byte{0x4c, 0xd3, 0xe0, 0x56, 0xac, 0x93, 0xd1, 0x4e, 0x4e, 0x21, 0xdc, 0x84, 0x7f, 0x16, 0x9e, 0xd7, 0xba, 0xe6, 0xa1, 0x5, 0x15, 0x5a, 0x2d, 0xeb, 0x4b, 0x7d, 0xf1, 0xfc, 0xfb, 0x67, 0x23, 0x2a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1a, 0xe1, 0xad, 0x36, 0xf6, 0x4e, 0x99, 0xed, 0xf7, 0xb5, 0xbe, 0xb6, 0xcb, 0x87, 0x61, 0xaf, 0x4, 0x4b, 0x11, 0x0, 0x21, 0xea, 0x0, 0x35, 0x56, 0xa7, 0x23, 0x7b, 0xa1, 0x6d, 0xdc, 0xd3}),
-				new(big.Int).SetBytes([]byte{0x70, 0x16, 0x5, 0x49, 0x87, 0xa0, 0x92, 0x20, 0x59, 0xc7, 0x4f, 0x77, 0x52, 0x10, 0x44, 0x89, 0x59, 0x61, 0x6a, 0xdd, 0xfc, 0xbb, 0x61, 0x3c, 0x7c, 0xc4, 0x61, 0x9d, 0x2e, 0x7e, 0xb2, 0xb7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8d, 0xa6, 0xbc, 0xe0, 0x66, 0xd3, 0x2a, 0xdd, 0xbf, 0xed, 0xcc, 0xbe, 0x44, 0x78, 0x5a, 0xea, 0x89, 0x4c, 0x4a, 0x2b, 0x89, 0x63, 0x46, 0x68, 0xd7, 0x79, 0xb8, 0xab, 0xfd, 0x85, 0x47, 0x4f}),
-				new(big.Int).SetBytes([]byte{0x13, 0xfc, 0x6c, 0x6a, 0xf3, 0x1e, 0xd4, 0xff, 0x84, 0xfd, 0xdf, 0x5d, 0x7, 0x1d, 0xff, 0xf1, 0xb, 0x1c, 0xc5, 0x93, 0x5, 0xdb, 0xdd, 0x7a, 0x5f, 0xdf, 0x9c, 0x7d, 0x3d, 0x7b, 0x4e, 0xf7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x11, 0x38, 0xad, 0x12, 0x33, 0x37, 0x90, 0xf9, 0x20, 0xe9, 0x79, 0xf6, 0xa6, 0x47, 0x12, 0x74, 0x2a, 0x9b, 0xb5, 0x67, 0x2, 0x35, 0x6e, 0x3c, 0x80, 0xff, 0xff, 0x89, 0xa, 0x9d, 0xe5, 0x33}),
-				new(big.Int).SetBytes([]byte{0xb3, 0x55, 0x25, 0xb7, 0x58, 0x75, 0x1d, 0x94, 0x86, 0xf0, 0xdc, 0x3e, 0x9f, 0x95, 0xa7, 0xdf, 0xf6, 0x79, 0xbd, 0xb, 0x45, 0x4a, 0x40, 0x0, 0x6a, 0x8b, 0x70, 0x94, 0xfd, 0x33, 0x25, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x73, 0x29, 0xac, 0xc7, 0xcf, 0xb3, 0xba, 0x39, 0xb1, 0xbd, 0x66, 0x49, 0xe3, 0x3c, 0x93, 0xbe, 0xeb, 0xfa, 0x26, 0xa6, 0x45, 0x8e, 0xaf, 0xd0, 0x6b, 0x5d, 0x42, 0x56, 0x7d, 0xd0, 0x42, 0xea}),
-				new(big.Int).SetBytes([]byte{0x92, 0x72, 0x49, 0x35, 0x41, 0xb7, 0x6f, 0x14, 0x89, 0x28, 0x4e, 0x79, 0x4f, 0xab, 0x68, 0xee, 0x18, 0x48, 0x97, 0x73, 0x1d, 0xfa, 0xb4, 0xf0, 0xad, 0xa8, 0xcf, 0xec, 0x2f, 0x5c, 0x94, 0xa1}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x1d, 0x61, 0xd0, 0xca, 0x72, 0x1a, 0x11, 0xb1, 0xa5, 0xbf, 0x6b, 0x7d, 0x88, 0xe8, 0x42, 0x1a, 0x28, 0x8a, 0xb5, 0xd5, 0xbb, 0xa5, 0x22, 0xe, 0x53, 0xd3, 0x2b, 0x5f, 0x6, 0x7e, 0xc2}),
-				new(big.Int).SetBytes([]byte{0x81, 0x57, 0xf5, 0x5a, 0x7c, 0x99, 0x30, 0x6c, 0x79, 0xc0, 0x76, 0x61, 0x61, 0xc9, 0x1e, 0x29, 0x66, 0xa7, 0x38, 0x99, 0xd2, 0x79, 0xb4, 0x8a, 0x65, 0x5f, 0xba, 0xf, 0x1a, 0xd8, 0x36, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9c, 0x7b, 0xe0, 0xb, 0x4e, 0xf4, 0xc4, 0x44, 0xdf, 0x85, 0xd5, 0xf6, 0x1d, 0xc1, 0x28, 0x3a, 0x23, 0x60, 0x54, 0x83, 0xe1, 0xf8, 0xe9, 0x34, 0xb3, 0xc2, 0x10, 0xd2, 0x2c, 0xd3, 0xc3, 0x69}),
-				new(big.Int).SetBytes([]byte{0x92, 0x20, 0xc0, 0xde, 0x74, 0xb2, 0xd, 0x20, 0x52, 0xa2, 0x6d, 0x45, 0x5c, 0xe4, 0x1, 0x48, 0x3e, 0x31, 0x15, 0x3a, 0x16, 0x76, 0x9c, 0xbd, 0x29, 0xee, 0x3f, 0xeb, 0xa2, 0x32, 0x95, 0x15}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe3, 0xe9, 0xd, 0xa4, 0x63, 0x3, 0xdd, 0x4, 0x19, 0xe9, 0x66, 0x46, 0x99, 0x1b, 0x17, 0x23, 0xb8, 0x3e, 0xc0, 0xc4, 0x47, 0x9d, 0x36, 0xf6, 0x15, 0xd8, 0x77, 0x32, 0xfa, 0x95, 0xa8, 0xdb}),
-				new(big.Int).SetBytes([]byte{0xfb, 0xe5, 0x3b, 0xc0, 0x5, 0x6c, 0x17, 0x8b, 0xb0, 0xf, 0xaa, 0x90, 0xc7, 0x2, 0xe7, 0x6a, 0xfb, 0xb1, 0xef, 0x97, 0xcc, 0x98, 0x4d, 0x3d, 0x10, 0x16, 0xcf, 0x7f, 0x1b, 0xd, 0x1c, 0xf9}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf, 0xcd, 0x83, 0xf4, 0x28, 0x25, 0x26, 0x3b, 0xb5, 0x56, 0x64, 0xb2, 0x38, 0xcc, 0xc4, 0x91, 0x74, 0xdd, 0x6, 0xa7, 0x5, 0x41, 0x17, 0x8e, 0x76, 0xbc, 0xd9, 0x2d, 0x7b, 0xb8, 0xc9, 0xe3}),
-				new(big.Int).SetBytes([]byte{0x6c, 0xb, 0xc1, 0xcf, 0xea, 0xc5, 0xfb, 0xce, 0xd1, 0xd8, 0x23, 0x2d, 0xe5, 0xfd, 0xb6, 0x83, 0xad, 0xbe, 0xae, 0xcd, 0xf1, 0x62, 0x7b, 0xf4, 0xe8, 0x6d, 0x55, 0xfb, 0xdf, 0x4a, 0xa9, 0xad}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9f, 0x4f, 0xfe, 0xc7, 0x32, 0xe3, 0xd7, 0x75, 0xa8, 0xb6, 0x50, 0xdf, 0xd5, 0xc0, 0xc0, 0x1c, 0x78, 0x2a, 0x4e, 0x97, 0x95, 0x93, 0x15, 0x40, 0x17, 0xaa, 0xfd, 0x64, 0x11, 0x2e, 0xe2, 0x14}),
-				new(big.Int).SetBytes([]byte{0x63, 0x9c, 0xe2, 0xe1, 0x31, 0x8e, 0x2f, 0x2e, 0x60, 0xe9, 0x66, 0x82, 0xfd, 0x75, 0xc6, 0x9d, 0x97, 0x19, 0xb6, 0x93, 0xb, 0xc8, 0x80, 0x28, 0x3e, 0xf4, 0x42, 0x22, 0x50, 0x85, 0xf3, 0x7a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x14, 0x29, 0x5a, 0x29, 0x37, 0xf1, 0xa9, 0x41, 0x2e, 0xe1, 0x4c, 0xce, 0x33, 0xa0, 0x5f, 0xb2, 0x74, 0x89, 0x92, 0xa2, 0xcf, 0x59, 0x8d, 0x41, 0xf4, 0xfc, 0x3d, 0xcb, 0x54, 0x1d, 0xc, 0xe6}),
-				new(big.Int).SetBytes([]byte{0xfe, 0xa7, 0x53, 0x63, 0xdf, 0x11, 0x50, 0x71, 0x1b, 0x3, 0x1c, 0x44, 0x84, 0x40, 0x83, 0xb1, 0x31, 0x8, 0x95, 0x60, 0x1b, 0x5d, 0x24, 0x1a, 0x52, 0xa8, 0x1a, 0x8a, 0xd7, 0x38, 0xbb, 0x86}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x43, 0x1f, 0x62, 0x2d, 0x41, 0x13, 0x4a, 0xc1, 0xb1, 0x71, 0xa0, 0xa4, 0x1f, 0x44, 0xb, 0x2c, 0x4b, 0x12, 0x53, 0xe1, 0xb3, 0xc1, 0x4e, 0x3e, 0xf8, 0xb6, 0x81, 0x45, 0x1f, 0x61, 0xa0, 0xa5}),
-				new(big.Int).SetBytes([]byte{0xa2, 0x98, 0x32, 0x7f, 0xe7, 0xaa, 0x43, 0x8f, 0x6d, 0x6d, 0xe6, 0x51, 0x97, 0x23, 0xaa, 0x72, 0x41, 0xba, 0xaa, 0x4, 0x35, 0x56, 0x57, 0x72, 0x46, 0xb7, 0xb7, 0xfe, 0x78, 0xce, 0xf8, 0x99}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0x75, 0x40, 0x7f, 0x1b, 0x58, 0xf0, 0x10, 0xd4, 0xcd, 0xa4, 0xc6, 0x25, 0x11, 0xe5, 0x9d, 0xb7, 0xed, 0xcf, 0x28, 0xf5, 0x47, 0x6d, 0x99, 0x5c, 0xf3, 0x99, 0x44, 0xb2, 0x6b, 0x64, 0xf1}),
-				new(big.Int).SetBytes([]byte{0x43, 0xb4, 0x55, 0x43, 0x44, 0xe3, 0xd5, 0x50, 0xf3, 0x6d, 0x34, 0x1, 0x13, 0x4c, 0xc8, 0x6e, 0xb0, 0x1f, 0xe8, 0xb7, 0x74, 0x47, 0x1d, 0x2a, 0x42, 0x6e, 0x7e, 0xfa, 0xb2, 0x42, 0x34, 0xd5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x99, 0x3d, 0xba, 0xdd, 0xec, 0xe7, 0x8f, 0xb8, 0x6c, 0xb6, 0x2e, 0xf3, 0x9f, 0x8a, 0x12, 0xac, 0xe, 0x6e, 0xe, 0x47, 0x73, 0x13, 0x28, 0xe7, 0xca, 0xc2, 0x67, 0xd5, 0x67, 0x74, 0x85, 0x3}),
-				new(big.Int).SetBytes([]byte{0xa5, 0x21, 0xa9, 0xee, 0x85, 0x69, 0xee, 0xeb, 0x78, 0x5f, 0x78, 0x47, 0x87, 0xa1, 0xf5, 0x1a, 0x17, 0xf8, 0x7b, 0xf6, 0x9b, 0x8a, 0xda, 0xa4, 0x9b, 0xa7, 0x36, 0x13, 0x7e, 0xb0, 0xce, 0xe2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc, 0x20, 0xe8, 0x6b, 0x84, 0xe5, 0x17, 0x72, 0xba, 0x82, 0x89, 0x21, 0x24, 0x18, 0xcf, 0xac, 0x3a, 0xca, 0x86, 0xa2, 0x3c, 0xef, 0xe2, 0xf4, 0xe4, 0x64, 0xdb, 0xa9, 0x97, 0x42, 0x1e, 0x21}),
-				new(big.Int).SetBytes([]byte{0xf5, 0x61, 0xe9, 0xbf, 0x76, 0xd0, 0xad, 0xac, 0x1a, 0x12, 0x37, 0xa1, 0x1e, 0x19, 0xa5, 0xb7, 0x86, 0xee, 0x76, 0xf6, 0x5b, 0xd0, 0x93, 0x49, 0xdf, 0xc8, 0x3d, 0x64, 0xa1, 0xaa, 0x6a, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x75, 0xf1, 0x7a, 0xba, 0x6, 0xad, 0x5e, 0xa5, 0xa3, 0x39, 0x43, 0x1c, 0xb9, 0x9, 0x62, 0xc0, 0xe1, 0xb, 0xd1, 0x1f, 0x26, 0x47, 0x4d, 0x47, 0x8d, 0xea, 0x22, 0x75, 0xa6, 0xd2, 0x3d, 0x80}),
-				new(big.Int).SetBytes([]byte{0xa0, 0x7a, 0x62, 0xfe, 0x15, 0x63, 0x9a, 0x80, 0xc7, 0xfc, 0x7d, 0xb, 0xf1, 0x9b, 0x62, 0x92, 0x37, 0x2f, 0xfb, 0xed, 0xbb, 0x4e, 0xa5, 0x90, 0x98, 0x6a, 0x85, 0xb0, 0x28, 0xd, 0xd5, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x35, 0x4c, 0xc8, 0x4b, 0xd9, 0x72, 0xe0, 0xae, 0xe, 0x97, 0xe3, 0x2c, 0xbd, 0x26, 0xd4, 0x10, 0xee, 0x8b, 0x89, 0xfd, 0x48, 0xce, 0x60, 0xc4, 0xc7, 0x18, 0xcf, 0x9c, 0x4b, 0x34, 0x4f, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x9c, 0x4, 0xbf, 0x29, 0x29, 0x1c, 0x6b, 0x5d, 0x67, 0x50, 0xb4, 0x93, 0xf9, 0xba, 0x88, 0x55, 0x8e, 0x36, 0xc6, 0x1, 0x86, 0x2, 0xe0, 0xec, 0x7e, 0x4b, 0x11, 0xae, 0xa5, 0xdb, 0x82, 0xd6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x66, 0x78, 0x55, 0x5e, 0x11, 0xd9, 0xf4, 0xa0, 0xa6, 0xe9, 0x5f, 0x48, 0x5c, 0xb8, 0x7b, 0x7a, 0x8d, 0xb9, 0xc0, 0x4, 0x3, 0x27, 0xd6, 0x84, 0xae, 0x2b, 0x9b, 0x6d, 0x61, 0xad, 0x34, 0x13}),
-				new(big.Int).SetBytes([]byte{0x51, 0xd, 0xda, 0x3d, 0xe2, 0x78, 0xf6, 0x46, 0x92, 0x52, 0x66, 0xd9, 0x27, 0xcb, 0xdf, 0x9e, 0xa6, 0xce, 0x2d, 0x15, 0x3a, 0xdc, 0x66, 0x60, 0x12, 0x48, 0x10, 0x20, 0x7e, 0xbc, 0xa6, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0xef, 0x14, 0x18, 0xda, 0xf, 0x94, 0x28, 0x40, 0x48, 0x6c, 0xf, 0x26, 0x22, 0x13, 0xa9, 0xc4, 0xaf, 0x25, 0x54, 0xd6, 0xc0, 0x8a, 0x44, 0x74, 0x87, 0xb5, 0xf8, 0x35, 0x2, 0xb4, 0xb1}),
-				new(big.Int).SetBytes([]byte{0xc4, 0xd3, 0x55, 0x9f, 0xd7, 0xe8, 0x7a, 0x4d, 0xe0, 0x40, 0x17, 0xb5, 0x3d, 0x89, 0x71, 0xcd, 0x2a, 0x3f, 0x62, 0x90, 0xad, 0xf1, 0x87, 0x8c, 0x6f, 0x81, 0xfa, 0x79, 0x66, 0xe8, 0x5b, 0xfc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0xdc, 0x4a, 0x36, 0x2c, 0xda, 0x77, 0x48, 0xad, 0xac, 0x82, 0xbd, 0x81, 0x96, 0x36, 0x88, 0x92, 0x24, 0xdf, 0x5b, 0xff, 0xe1, 0x1, 0xea, 0xac, 0x0, 0xaf, 0x5b, 0x6, 0xcc, 0x85, 0x63}),
-				new(big.Int).SetBytes([]byte{0x65, 0x9a, 0x31, 0x44, 0xe8, 0x56, 0xd3, 0x5f, 0x9b, 0x1e, 0xe3, 0x5e, 0x6c, 0xdd, 0x4f, 0x7, 0xbe, 0xc4, 0x7, 0x92, 0x49, 0x20, 0x4f, 0x92, 0x49, 0xb1, 0x7b, 0xce, 0xa3, 0x94, 0xcc, 0xfa}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa8, 0xe2, 0x82, 0xff, 0xc, 0x97, 0x6, 0x90, 0x72, 0x15, 0xff, 0x98, 0xe8, 0xfd, 0x41, 0x66, 0x15, 0x31, 0x1d, 0xe0, 0x44, 0x6f, 0x1e, 0x6, 0x2a, 0x73, 0xb0, 0x61, 0xd, 0x6, 0x4e, 0x13}),
-				new(big.Int).SetBytes([]byte{0x7f, 0x97, 0x35, 0x5b, 0x8d, 0xb8, 0x1c, 0x9, 0xab, 0xfb, 0x7f, 0x3c, 0x5b, 0x25, 0x15, 0x88, 0x8b, 0x67, 0x9a, 0x3e, 0x50, 0xdd, 0x6b, 0xd6, 0xce, 0xf7, 0xc7, 0x31, 0x11, 0xf4, 0xcc, 0xc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xca, 0xc6, 0xf2, 0xe7, 0xe2, 0x7f, 0xae, 0xcb, 0xcb, 0x87, 0x6f, 0x80, 0x5e, 0xa6, 0x6e, 0x63, 0xef, 0xbe, 0x9e, 0xaa, 0x75, 0x3d, 0x67, 0xc1, 0xc1, 0x5e, 0xb9, 0xea, 0x7f, 0x76, 0x53, 0xa1}),
-				new(big.Int).SetBytes([]byte{0xf7, 0xd4, 0x16, 0xe5, 0xe2, 0xaa, 0x6f, 0x19, 0x4c, 0xdb, 0x65, 0xd9, 0xa4, 0x2a, 0x34, 0x50, 0x81, 0xe8, 0x3a, 0xe5, 0x68, 0x81, 0x3, 0xa0, 0x68, 0xc1, 0xa, 0xd0, 0xfe, 0xc5, 0xe5, 0x56}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xae, 0x22, 0x7, 0xc5, 0xcd, 0xad, 0xe2, 0x63, 0x27, 0xf3, 0x7f, 0xb, 0x1e, 0xe4, 0xe, 0x50, 0xba, 0x28, 0x8f, 0x8d, 0xe6, 0x7e, 0x82, 0x9e, 0x22, 0xa1, 0x99, 0xb0, 0xba, 0x39, 0x79, 0xb5}),
-				new(big.Int).SetBytes([]byte{0xea, 0x91, 0xfe, 0x51, 0xc, 0x7, 0x9f, 0x71, 0xad, 0x4c, 0x92, 0x45, 0x23, 0xad, 0x70, 0x60, 0xfc, 0xa8, 0x7b, 0x7d, 0x37, 0xd4, 0xf8, 0x89, 0x68, 0xf3, 0xcd, 0x66, 0x84, 0x50, 0xfa, 0x6f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe6, 0xdf, 0xde, 0x46, 0xee, 0x37, 0xd2, 0x6, 0xef, 0xbc, 0x59, 0x32, 0xe5, 0x8e, 0x43, 0x25, 0x4a, 0xb7, 0x67, 0x29, 0x42, 0x38, 0xcb, 0x11, 0xcc, 0x9f, 0x4a, 0xb0, 0x86, 0x24, 0x0, 0x3d}),
-				new(big.Int).SetBytes([]byte{0x87, 0x27, 0xb3, 0xb7, 0xbe, 0x91, 0x39, 0x49, 0x8f, 0x2f, 0x48, 0xf7, 0xb8, 0x8f, 0x92, 0x20, 0x3b, 0x1c, 0xe5, 0xea, 0x52, 0x7f, 0xd7, 0xdd, 0x75, 0x48, 0x65, 0xe, 0x22, 0x16, 0xb9, 0x3b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcb, 0x8d, 0xed, 0xc, 0xad, 0x72, 0xac, 0xe5, 0x9c, 0xec, 0x54, 0x10, 0x6, 0x58, 0x54, 0x61, 0xe0, 0xbe, 0xc, 0x4f, 0xea, 0x21, 0x64, 0xfa, 0xe4, 0x10, 0x19, 0x10, 0xe, 0xfc, 0xa8, 0x24}),
-				new(big.Int).SetBytes([]byte{0x33, 0xa5, 0x0, 0x8f, 0x74, 0xd, 0x88, 0xc8, 0x5b, 0x31, 0x6c, 0x48, 0x7a, 0x2c, 0xcb, 0xc4, 0x96, 0xad, 0x1f, 0xdf, 0xc7, 0x93, 0x17, 0x42, 0xf1, 0x40, 0xbd, 0x5, 0x8f, 0x22, 0x73, 0x61}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x49, 0xdb, 0xe4, 0xf7, 0xb2, 0x79, 0x2b, 0x64, 0xbc, 0x9e, 0xca, 0x37, 0xe8, 0xd6, 0x4c, 0x46, 0xa, 0x0, 0xe4, 0x9c, 0xa3, 0x22, 0x1c, 0xef, 0x55, 0x9e, 0x11, 0xe1, 0xec, 0xe4, 0xdd, 0x6a}),
-				new(big.Int).SetBytes([]byte{0xb8, 0xb5, 0x72, 0x98, 0x47, 0x4, 0x81, 0xc7, 0x2c, 0x5f, 0xe3, 0x3d, 0xb6, 0x92, 0x25, 0x5a, 0x1c, 0x38, 0x55, 0x2f, 0xca, 0x5, 0xbb, 0x69, 0xb1, 0x3b, 0x72, 0xa4, 0x2a, 0x9e, 0x3e, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb0, 0xc5, 0x3b, 0x29, 0x8a, 0xf1, 0x83, 0x67, 0x8, 0xe9, 0x49, 0x0, 0xd7, 0xe7, 0x6e, 0xd6, 0xb0, 0x4e, 0x8, 0x52, 0x21, 0xe4, 0xaa, 0xee, 0x94, 0x11, 0xb4, 0xda, 0x3b, 0xcb, 0xd3, 0x27}),
-				new(big.Int).SetBytes([]byte{0xee, 0x2a, 0x97, 0x40, 0x1f, 0xbd, 0x7e, 0xa1, 0x63, 0x8f, 0x6a, 0x60, 0x1b, 0x66, 0xb2, 0xaf, 0xd8, 0x2a, 0x22, 0xc, 0x74, 0x63, 0x6a, 0xf, 0x16, 0x16, 0xbc, 0x4a, 0x2d, 0x7b, 0xe4, 0x36}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3c, 0x4e, 0x8, 0x9c, 0xd9, 0xa6, 0x82, 0x3d, 0x66, 0xa4, 0xc, 0xfc, 0x7a, 0xc9, 0x60, 0x82, 0xe2, 0x50, 0xe3, 0x14, 0x9c, 0xf2, 0x11, 0xd3, 0xb0, 0xe1, 0x10, 0x35, 0x48, 0xdc, 0xe1, 0x9}),
-				new(big.Int).SetBytes([]byte{0x43, 0xfb, 0xbe, 0x66, 0x9f, 0xe1, 0x91, 0xb4, 0x80, 0x75, 0x7b, 0xca, 0x15, 0x76, 0x4d, 0x37, 0x95, 0x79, 0xe1, 0x42, 0xd9, 0x7f, 0xe6, 0x97, 0xe2, 0xbf, 0x65, 0x92, 0x3a, 0x19, 0xae, 0xea}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6f, 0x6b, 0xa7, 0x3f, 0x32, 0x9d, 0xb9, 0x20, 0xd4, 0xd8, 0x86, 0x2e, 0xce, 0x77, 0xf5, 0x26, 0xc8, 0x6, 0x40, 0x5f, 0xf7, 0x67, 0x9e, 0xf7, 0xde, 0x6e, 0xfb, 0x63, 0x97, 0xb8, 0x36, 0xa1}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x77, 0x8a, 0x17, 0xff, 0x5f, 0xc1, 0x8e, 0x3d, 0xb0, 0xb8, 0xa4, 0x5, 0xbe, 0xe9, 0xc0, 0xd8, 0x99, 0xc, 0x52, 0x88, 0xe1, 0xff, 0x93, 0x4d, 0xbd, 0xf2, 0xfb, 0x6, 0x83, 0x2b, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf8, 0xcc, 0x1d, 0xad, 0xa7, 0x79, 0x67, 0x4e, 0x53, 0x42, 0x77, 0xd3, 0x40, 0xb3, 0xea, 0x8a, 0x56, 0x3e, 0xd7, 0xca, 0x92, 0xb, 0xd2, 0xf6, 0x68, 0x5, 0x6b, 0x7f, 0x82, 0x5d, 0x52, 0x41}),
-				new(big.Int).SetBytes([]byte{0x13, 0xde, 0x4e, 0x44, 0x10, 0xb1, 0x70, 0xfe, 0xb3, 0x3e, 0x89, 0x2f, 0xa5, 0x16, 0x6f, 0x4, 0x52, 0xc2, 0xd7, 0x6c, 0x61, 0xeb, 0xb, 0xd4, 0x96, 0xa, 0xf0, 0x38, 0xf0, 0x70, 0xe1, 0xa}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7d, 0x47, 0x1a, 0x7d, 0xc7, 0x42, 0x2a, 0xf4, 0xbb, 0xcf, 0xf4, 0x67, 0xe1, 0x2, 0x43, 0x60, 0x31, 0x8, 0x80, 0x32, 0xd7, 0x6, 0x12, 0xd4, 0x2a, 0xee, 0x34, 0x20, 0x9e, 0xb3, 0x9e, 0xde}),
-				new(big.Int).SetBytes([]byte{0x4a, 0x7b, 0x19, 0xf3, 0xa6, 0x85, 0x65, 0x7, 0xb8, 0x22, 0x31, 0xef, 0xfc, 0x86, 0xfa, 0x77, 0x95, 0xcf, 0x7d, 0x1c, 0x22, 0xa8, 0xfd, 0xc, 0x9b, 0xcd, 0x94, 0xab, 0xf6, 0x60, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x80, 0xc3, 0x4f, 0xdd, 0x2, 0x89, 0xbe, 0x33, 0x73, 0x7d, 0xcf, 0xb3, 0x3d, 0x7d, 0xfe, 0x35, 0x96, 0x56, 0x5f, 0xeb, 0x1e, 0x16, 0x6, 0xd5, 0x4f, 0xe8, 0xb5, 0xbd, 0xf4, 0xe5, 0xf0, 0x3a}),
-				new(big.Int).SetBytes([]byte{0x24, 0x7e, 0x8, 0x56, 0xa7, 0x8c, 0x1e, 0xcd, 0x55, 0xed, 0xb5, 0x70, 0x7, 0xdc, 0x9a, 0x3, 0xd3, 0xb, 0x2a, 0xef, 0x1f, 0x32, 0x45, 0xcb, 0x11, 0x7f, 0x45, 0x46, 0xfe, 0x3c, 0xa5, 0x4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf0, 0x2, 0x51, 0x63, 0xf9, 0xb7, 0x3f, 0xac, 0x70, 0x70, 0xfc, 0xc3, 0x19, 0x82, 0xf0, 0x96, 0x7f, 0xcd, 0x75, 0x67, 0xcd, 0xae, 0x5c, 0x5a, 0xa5, 0x6d, 0x49, 0xe2, 0x56, 0x5e, 0xb1, 0xc1}),
-				new(big.Int).SetBytes([]byte{0x5, 0x4e, 0xa9, 0xe8, 0x88, 0x3d, 0x20, 0xdf, 0x4c, 0x66, 0xb4, 0xa5, 0xda, 0x26, 0xaf, 0xed, 0x85, 0x76, 0xff, 0x5c, 0xd6, 0xcd, 0x11, 0xe5, 0x7d, 0xa7, 0xd7, 0x4, 0x6d, 0xde, 0x2a, 0xe0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6c, 0xed, 0x1a, 0xe8, 0x97, 0xa9, 0xdf, 0x3d, 0x4a, 0xfe, 0x80, 0x22, 0x14, 0xdb, 0xc7, 0xbc, 0xb3, 0x83, 0xe4, 0xed, 0x9e, 0x4c, 0x4d, 0xec, 0xa6, 0x10, 0xa0, 0x61, 0xca, 0x14, 0xf4, 0x22}),
-				new(big.Int).SetBytes([]byte{0x25, 0x88, 0x8a, 0xeb, 0x1b, 0xf, 0x8e, 0x7a, 0xbb, 0x49, 0x5a, 0x72, 0x8b, 0xe6, 0xb9, 0xb8, 0x8c, 0xa1, 0x2, 0xc8, 0x79, 0xa9, 0xc9, 0x32, 0xae, 0x73, 0xda, 0x43, 0xd8, 0x4f, 0xc0, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xac, 0x12, 0x14, 0x0, 0x1, 0xed, 0x23, 0xaa, 0xf, 0x70, 0x4e, 0x95, 0xf, 0x47, 0x71, 0x5e, 0xe, 0x36, 0xfe, 0x3, 0xec, 0xae, 0x4b, 0xf8, 0x4c, 0xf3, 0x50, 0x93, 0xda, 0xc3, 0x2c, 0x64}),
-				new(big.Int).SetBytes([]byte{0xce, 0x8e, 0xbc, 0xcb, 0xac, 0x33, 0x8b, 0xaf, 0x18, 0x70, 0xb6, 0x95, 0x73, 0x4, 0x99, 0xa3, 0xed, 0x5a, 0x5d, 0x3a, 0x5a, 0x53, 0xf0, 0x89, 0x88, 0x12, 0xad, 0xba, 0xde, 0xb1, 0xa8, 0x67}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x17, 0x4a, 0x53, 0xb9, 0xc9, 0xa2, 0x85, 0x87, 0x2d, 0x39, 0xe5, 0x6e, 0x69, 0x13, 0xca, 0xb1, 0x5d, 0x59, 0xb1, 0xfa, 0x51, 0x25, 0x8, 0xc0, 0x22, 0xf3, 0x82, 0xde, 0x83, 0x19, 0x49, 0x7c}),
-				new(big.Int).SetBytes([]byte{0xcc, 0xc9, 0xdc, 0x37, 0xab, 0xfc, 0x9c, 0x16, 0x57, 0xb4, 0x15, 0x5f, 0x2c, 0x47, 0xf9, 0xe6, 0x64, 0x6b, 0x3a, 0x1d, 0x8c, 0xb9, 0x85, 0x43, 0x83, 0xda, 0x13, 0xac, 0x7, 0x9a, 0xfa, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x20, 0xe6, 0xe2, 0xe7, 0x96, 0x94, 0x6b, 0xb6, 0x30, 0xc7, 0x7, 0x1e, 0xf1, 0xb9, 0x2e, 0xa3, 0xd5, 0x3d, 0x28, 0xe, 0xe, 0x45, 0x1, 0x11, 0x5f, 0x5d, 0xa3, 0x6f, 0x84, 0xd, 0xd2, 0x73}),
-				new(big.Int).SetBytes([]byte{0xd3, 0xad, 0x7a, 0xfe, 0x4f, 0x15, 0x59, 0xe4, 0x4a, 0xb, 0xa1, 0xad, 0x97, 0x87, 0x46, 0x55, 0x81, 0x1e, 0xc9, 0x79, 0x3d, 0xa8, 0x69, 0x3c, 0xc0, 0x7c, 0xfd, 0x15, 0xbb, 0x46, 0xb5, 0x93}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5e, 0x5a, 0x9, 0x4a, 0xe4, 0x46, 0x52, 0x6e, 0x40, 0xca, 0xa5, 0xd4, 0x58, 0x43, 0x6a, 0x5d, 0xb7, 0x75, 0xb7, 0x7d, 0x67, 0xd5, 0x6, 0xbf, 0xe9, 0x48, 0x7, 0x3d, 0x75, 0x4b, 0x83, 0x67}),
-				new(big.Int).SetBytes([]byte{0xae, 0x8a, 0xf8, 0xc9, 0xa4, 0x79, 0x5e, 0x5, 0xc2, 0xc9, 0x1, 0xf1, 0x57, 0x2a, 0x4b, 0x7a, 0x79, 0x6a, 0xa9, 0xef, 0x3d, 0xe5, 0xff, 0xa4, 0x45, 0x32, 0x9a, 0x9d, 0x91, 0xce, 0x85, 0xca}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0xc, 0xa8, 0x24, 0xd7, 0xa3, 0x51, 0xdb, 0xa8, 0x2, 0x80, 0xa0, 0x7e, 0x71, 0xdb, 0x70, 0x35, 0xae, 0x68, 0x13, 0x6c, 0xc2, 0x4c, 0xa3, 0xe7, 0xb5, 0x4f, 0x30, 0x1a, 0x7, 0x76, 0x74}),
-				new(big.Int).SetBytes([]byte{0x4, 0xec, 0x56, 0x7, 0x59, 0x19, 0x2d, 0x41, 0xdc, 0x56, 0x9d, 0x24, 0xda, 0x62, 0xcf, 0x57, 0xcf, 0xf6, 0x4, 0x19, 0xd2, 0xf9, 0x10, 0x29, 0xb, 0x84, 0xcb, 0xec, 0x12, 0xb7, 0xed, 0x98}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd4, 0xe0, 0xad, 0xb9, 0x70, 0x2e, 0x85, 0x9d, 0xec, 0xcb, 0x83, 0x31, 0xc, 0xb8, 0x2d, 0xe6, 0xa5, 0x2f, 0x18, 0x9d, 0x1f, 0x8f, 0xca, 0xf1, 0x98, 0x57, 0x93, 0x97, 0xb1, 0xd, 0x9d}),
-				new(big.Int).SetBytes([]byte{0x87, 0xb8, 0x1, 0x98, 0x18, 0x37, 0x64, 0x9, 0x47, 0xd2, 0xf8, 0x46, 0x43, 0x22, 0x87, 0xf7, 0xb, 0x58, 0x98, 0xc9, 0x78, 0xe2, 0xd9, 0x23, 0xe8, 0x21, 0x0, 0x48, 0x71, 0x40, 0xdc, 0xed}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2e, 0xd7, 0x6c, 0x11, 0x52, 0xac, 0x36, 0x0, 0x7a, 0xa1, 0x7c, 0x85, 0xa5, 0xf9, 0x2, 0xf1, 0x7c, 0x84, 0x5a, 0x5, 0xa4, 0xe0, 0xaa, 0xa0, 0x7b, 0x5, 0xe8, 0x36, 0xc, 0xbc, 0xad, 0x59}),
-				new(big.Int).SetBytes([]byte{0x63, 0x9f, 0x4d, 0x40, 0x43, 0xb8, 0x5f, 0x22, 0x2c, 0xda, 0x6e, 0xae, 0xc8, 0x17, 0x67, 0xed, 0x5f, 0x72, 0xc6, 0x1, 0x30, 0xa7, 0xf9, 0x41, 0x14, 0xac, 0x7a, 0x89, 0xc4, 0xea, 0x66, 0xfe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x66, 0x52, 0x13, 0x68, 0xef, 0x74, 0xc0, 0xf6, 0xf7, 0xa1, 0x9a, 0xee, 0xc4, 0xa6, 0x67, 0x63, 0x2e, 0x20, 0x45, 0xb1, 0xa2, 0x70, 0xbb, 0x20, 0x81, 0x1a, 0x60, 0x9c, 0x9c, 0xae, 0xe8}),
-				new(big.Int).SetBytes([]byte{0x1f, 0x18, 0xc3, 0x2b, 0x2a, 0x93, 0xde, 0xd9, 0x99, 0x49, 0xfc, 0x68, 0x1b, 0xa6, 0x94, 0x45, 0x1, 0xcb, 0xa9, 0x89, 0x32, 0x95, 0xba, 0x7f, 0xa, 0x9e, 0xc6, 0xa3, 0xb7, 0x72, 0xb7, 0x11}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf7, 0xbb, 0x50, 0xda, 0x51, 0xc9, 0x82, 0xd1, 0xc5, 0xfa, 0x63, 0x55, 0x3e, 0x3d, 0x66, 0xc1, 0xaf, 0xdb, 0x58, 0x21, 0xa3, 0x21, 0xb4, 0xaf, 0xe9, 0x6a, 0xfc, 0x5e, 0xa8, 0x19, 0x24, 0x41}),
-				new(big.Int).SetBytes([]byte{0x93, 0xcc, 0x3b, 0xe3, 0x3, 0x34, 0xa5, 0x26, 0x31, 0x1b, 0xc6, 0x3b, 0xdd, 0xe6, 0x48, 0x5d, 0xb1, 0xcf, 0xdc, 0x1f, 0xbb, 0xc4, 0xc7, 0x4b, 0xbc, 0x64, 0xe, 0xa1, 0xd4, 0x51, 0x65, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe, 0x58, 0x91, 0x5, 0x6f, 0xdb, 0x8f, 0x1f, 0xaa, 0x6, 0x76, 0xc4, 0x53, 0xf6, 0xc6, 0xea, 0xde, 0x37, 0xde, 0x8, 0xe6, 0x1, 0xc0, 0x63, 0x53, 0xff, 0x97, 0x51, 0x94, 0x46, 0xc8, 0x5c}),
-				new(big.Int).SetBytes([]byte{0xe3, 0x9a, 0x48, 0x5f, 0x11, 0xfa, 0x7b, 0xda, 0xe, 0xc7, 0x41, 0x8b, 0xaf, 0x48, 0x18, 0x98, 0xf4, 0x90, 0x23, 0x4e, 0x80, 0xcd, 0xda, 0xdd, 0x5f, 0x6c, 0x77, 0xe7, 0x21, 0x48, 0x97, 0x2e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa3, 0x46, 0x67, 0x15, 0x5b, 0x6e, 0xa5, 0x98, 0x2d, 0x39, 0x45, 0xfb, 0x74, 0x3a, 0x15, 0x10, 0xc6, 0x71, 0xb9, 0x6f, 0xa3, 0x49, 0x4a, 0x57, 0xe0, 0x34, 0x90, 0x10, 0x5, 0x5a, 0xe0, 0x87}),
-				new(big.Int).SetBytes([]byte{0x21, 0xca, 0x5f, 0xa5, 0xe5, 0x6b, 0xc5, 0xbd, 0x8d, 0xef, 0xe9, 0xa1, 0xaa, 0x2a, 0x30, 0x51, 0xc5, 0x3e, 0xab, 0x6f, 0x23, 0x94, 0xf0, 0xbc, 0xcb, 0x3f, 0x36, 0x63, 0x22, 0x88, 0x76, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x40, 0x12, 0x6d, 0x26, 0xf1, 0x46, 0x3f, 0x2b, 0x6c, 0xf5, 0xfa, 0xa0, 0x7, 0x77, 0x71, 0x93, 0x17, 0x39, 0xa4, 0x6, 0x81, 0x36, 0x2a, 0xff, 0x18, 0xad, 0xf3, 0xc2, 0x2e, 0x12, 0x59}),
-				new(big.Int).SetBytes([]byte{0x5, 0xa9, 0xff, 0xba, 0xf1, 0x94, 0xf1, 0xff, 0x1b, 0x5, 0xf1, 0x74, 0xa6, 0x7c, 0x7e, 0xe5, 0x17, 0x86, 0x5e, 0x75, 0x3b, 0x1d, 0x6d, 0xad, 0x5f, 0x77, 0x17, 0x24, 0x33, 0x4a, 0x5f, 0x43}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x5f, 0xb1, 0xdf, 0xe, 0x7d, 0x45, 0xa4, 0xf5, 0x41, 0x91, 0xf, 0x57, 0x7d, 0x41, 0x7f, 0x70, 0x91, 0xad, 0xf4, 0xc7, 0x99, 0xe8, 0x17, 0xea, 0x83, 0xf, 0x45, 0x71, 0xe4, 0xb8, 0x4b, 0x48}),
-				new(big.Int).SetBytes([]byte{0x21, 0x52, 0xfb, 0xbc, 0xbc, 0x3c, 0x4d, 0x49, 0xfb, 0xcf, 0x46, 0x46, 0xfe, 0x6d, 0x25, 0x82, 0x6, 0x29, 0xc3, 0x90, 0x87, 0x3f, 0x21, 0xad, 0x77, 0xed, 0xea, 0x6a, 0x37, 0xbe, 0x8e, 0xb8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x87, 0xe4, 0xb8, 0x21, 0x6d, 0x94, 0x74, 0xe4, 0xa6, 0x54, 0x5, 0x76, 0x5d, 0x82, 0xd1, 0x77, 0xd7, 0xce, 0x7e, 0xe7, 0xb, 0xa0, 0x87, 0xbf, 0xd8, 0x8a, 0x17, 0xa3, 0xcb, 0x8, 0xf1, 0xfe}),
-				new(big.Int).SetBytes([]byte{0xf7, 0x4e, 0x3a, 0xc0, 0x76, 0x63, 0x48, 0xce, 0xcd, 0x7d, 0x7f, 0x8e, 0xa2, 0xf8, 0xc2, 0xcc, 0x6e, 0x2f, 0x23, 0xdb, 0xd6, 0x78, 0x6d, 0x9d, 0x75, 0xf7, 0x4a, 0xc9, 0xae, 0xd5, 0xe2, 0xec}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x95, 0xbb, 0xd9, 0x74, 0x78, 0xe1, 0xb8, 0xa4, 0x16, 0x22, 0xe4, 0x60, 0x8a, 0xa8, 0x2f, 0xe9, 0xe7, 0x7d, 0x43, 0xe7, 0x49, 0x2a, 0xd, 0x21, 0x11, 0x46, 0xd9, 0x87, 0xf9, 0xb8, 0xf2, 0x55}),
-				new(big.Int).SetBytes([]byte{0xbe, 0xd4, 0x3a, 0xd1, 0x29, 0xe3, 0xc7, 0x7f, 0xde, 0xf2, 0xc2, 0x8, 0xf0, 0xf2, 0xd0, 0xd8, 0xf2, 0xba, 0x72, 0xae, 0xfc, 0x77, 0x7f, 0xc1, 0x1, 0xe, 0x3f, 0xe8, 0x28, 0x36, 0x14, 0x39}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x35, 0xea, 0x1b, 0x46, 0x3f, 0xea, 0x79, 0xa5, 0xa9, 0x2c, 0x22, 0xc0, 0xc1, 0x25, 0x4a, 0x44, 0x10, 0x5e, 0xa7, 0xbf, 0x7, 0xc0, 0xe7, 0x4b, 0x9a, 0x87, 0x0, 0xe6, 0xa1, 0xc4, 0xd1, 0x5c}),
-				new(big.Int).SetBytes([]byte{0xad, 0x21, 0x91, 0x65, 0x1b, 0x9e, 0xd2, 0xcd, 0x78, 0x88, 0xfa, 0x5f, 0xd7, 0x4d, 0x3b, 0x18, 0x5d, 0x63, 0x3a, 0xa0, 0x30, 0x65, 0x9, 0x5e, 0xdc, 0x93, 0x9b, 0xaf, 0x7f, 0x3f, 0xe1, 0xea}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x95, 0x93, 0x96, 0x98, 0x19, 0x43, 0x78, 0x5c, 0x3d, 0x3e, 0x57, 0xed, 0xf5, 0x1, 0x8c, 0xdb, 0xe0, 0x39, 0xe7, 0x30, 0xe4, 0x91, 0x8b, 0x3d, 0x88, 0x4f, 0xdf, 0xf0, 0x94, 0x75, 0xb7, 0xba}),
-				new(big.Int).SetBytes([]byte{0x2e, 0x7e, 0x55, 0x28, 0x88, 0xc3, 0x31, 0xdd, 0x8b, 0xa0, 0x38, 0x6a, 0x4b, 0x9c, 0xd6, 0x84, 0x9c, 0x65, 0x3f, 0x64, 0xc8, 0x70, 0x93, 0x85, 0xe9, 0xb8, 0xab, 0xf8, 0x75, 0x24, 0xf2, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcb, 0xee, 0x14, 0x5, 0xff, 0xd, 0xa7, 0xde, 0xaf, 0xe3, 0x2c, 0xa7, 0xdd, 0x73, 0xd9, 0x5e, 0xd7, 0x2, 0x22, 0x6b, 0x39, 0x17, 0x47, 0xc7, 0x7, 0x27, 0x5a, 0x94, 0xb, 0xc8, 0xf5, 0x3b}),
-				new(big.Int).SetBytes([]byte{0xf6, 0x21, 0x1f, 0x4f, 0x4e, 0x75, 0xf9, 0x2, 0xb5, 0x1f, 0x3e, 0x68, 0x9b, 0x82, 0x94, 0xcf, 0xd, 0x9f, 0xf4, 0xf6, 0x81, 0x26, 0xf7, 0x28, 0x29, 0x22, 0xe6, 0xb2, 0x78, 0xc8, 0x7f, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa, 0xe9, 0x76, 0x75, 0xce, 0xb7, 0x2f, 0x7e, 0x78, 0x8f, 0x69, 0xd, 0xfb, 0xcc, 0xcf, 0x14, 0x9f, 0x30, 0x9c, 0xcb, 0x6d, 0xdf, 0x72, 0xae, 0xa0, 0x9c, 0x5d, 0xd9, 0xf, 0xd6, 0x99, 0x85}),
-				new(big.Int).SetBytes([]byte{0x91, 0x21, 0x99, 0x73, 0xf6, 0xe4, 0x8d, 0x14, 0xe9, 0xb8, 0xdf, 0xee, 0x5, 0x1a, 0x54, 0xc5, 0xd0, 0xb9, 0x9d, 0x41, 0x7a, 0xee, 0x1a, 0xff, 0x89, 0xc8, 0xeb, 0x41, 0x14, 0x9, 0xa0, 0x3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xad, 0xd5, 0xba, 0xd2, 0x8f, 0xaa, 0xf5, 0xac, 0xdd, 0x58, 0xb, 0xfa, 0xb, 0xa2, 0x52, 0xe0, 0x3d, 0xe3, 0xbe, 0xae, 0xfb, 0xd7, 0x1b, 0x9c, 0xf3, 0x77, 0xc8, 0x8b, 0x14, 0xb3, 0x11, 0xdd}),
-				new(big.Int).SetBytes([]byte{0xe9, 0xc4, 0x3c, 0xf4, 0xda, 0x3d, 0xc3, 0xa5, 0x97, 0x4e, 0x43, 0x4f, 0x83, 0x59, 0x81, 0x4f, 0x52, 0xd4, 0xe1, 0xe7, 0x66, 0x9b, 0x9b, 0x89, 0x2, 0xf9, 0x82, 0xf3, 0x49, 0xd6, 0xc3, 0x8d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2b, 0x15, 0x86, 0x2a, 0x5a, 0xc1, 0x61, 0x2e, 0xc9, 0xb6, 0x5f, 0x17, 0x78, 0x2, 0x5d, 0x1f, 0xb7, 0x23, 0xc4, 0xc1, 0xfe, 0x3c, 0xc2, 0x9a, 0x9d, 0xc1, 0x93, 0xdf, 0xd9, 0x26, 0x2b, 0x90}),
-				new(big.Int).SetBytes([]byte{0x2e, 0xb0, 0x5, 0x3d, 0xaa, 0xa, 0x33, 0xfa, 0xa7, 0xa3, 0xd, 0x52, 0xda, 0x87, 0x49, 0x6, 0x6f, 0x53, 0x49, 0x70, 0xf9, 0x94, 0x89, 0xa4, 0x99, 0x19, 0x96, 0xe6, 0x48, 0x3d, 0x75, 0x57}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x20, 0x9d, 0x6b, 0xcd, 0x76, 0x61, 0x63, 0xb5, 0x24, 0x8d, 0x44, 0x68, 0xc6, 0x6d, 0x17, 0x7, 0x14, 0xff, 0x12, 0xc6, 0xc4, 0x12, 0x61, 0x97, 0x4e, 0x75, 0xae, 0x79, 0x60, 0x78, 0xaf, 0xb0}),
-				new(big.Int).SetBytes([]byte{0x1a, 0x2f, 0x13, 0x42, 0x9e, 0x7b, 0x32, 0x80, 0xa0, 0x96, 0x5b, 0xab, 0xa9, 0x89, 0x8a, 0x5b, 0x1, 0xed, 0xb7, 0x93, 0x39, 0xf4, 0xe3, 0xe3, 0x85, 0xd1, 0xb7, 0x75, 0xa7, 0x40, 0xb3, 0x10}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6a, 0xf9, 0xea, 0xed, 0x1a, 0x96, 0xee, 0x67, 0x7e, 0xe9, 0x5c, 0x16, 0x16, 0xe4, 0x76, 0x9a, 0xf2, 0xd2, 0xc8, 0x94, 0x91, 0x4, 0xe, 0xe5, 0x93, 0xf9, 0x71, 0x4c, 0xa8, 0xe7, 0xbe, 0x40}),
-				new(big.Int).SetBytes([]byte{0xb3, 0x81, 0x2a, 0x11, 0x69, 0x0, 0x66, 0x49, 0x67, 0x9, 0xea, 0x42, 0x83, 0x47, 0xdc, 0x81, 0x45, 0xe3, 0xf6, 0x66, 0xa0, 0xf5, 0x95, 0x69, 0xfa, 0x41, 0x6e, 0x2, 0x6e, 0x38, 0x7e, 0x1c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0xf2, 0x43, 0x2b, 0xa8, 0x17, 0x17, 0x14, 0x3f, 0xa9, 0xdf, 0x3d, 0xff, 0x41, 0xce, 0xd2, 0x4a, 0x29, 0xb3, 0x14, 0xbc, 0x5a, 0x8c, 0x96, 0xf5, 0xf6, 0x40, 0xa, 0xd, 0x7c, 0x9, 0x79}),
-				new(big.Int).SetBytes([]byte{0xbd, 0x52, 0xef, 0xfb, 0xc1, 0xf0, 0x79, 0xb7, 0xcc, 0xd4, 0xe3, 0xe0, 0x91, 0x1b, 0x7, 0xde, 0x4b, 0xd5, 0xa4, 0xf5, 0xc9, 0xe8, 0xb8, 0x45, 0xf9, 0xf7, 0xe9, 0xc, 0x53, 0x7b, 0x36, 0xa2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x59, 0x66, 0x68, 0xee, 0x4, 0x44, 0x14, 0x4e, 0xeb, 0x3a, 0xab, 0x42, 0x4c, 0xb8, 0xd6, 0xf9, 0x40, 0x79, 0x4f, 0x8b, 0xc5, 0xbd, 0x41, 0x55, 0xd2, 0x6b, 0x6f, 0xa0, 0xf4, 0x82, 0x80, 0x1e}),
-				new(big.Int).SetBytes([]byte{0x94, 0x9a, 0xa0, 0xa8, 0x5b, 0xba, 0xef, 0x5f, 0x1e, 0xc8, 0xf9, 0x60, 0x87, 0x71, 0x30, 0x4e, 0x8d, 0xb6, 0xc1, 0xcb, 0x63, 0xd3, 0x53, 0x5a, 0xd4, 0x77, 0x14, 0x8f, 0x4, 0x87, 0xc, 0x37}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x62, 0xe6, 0x9e, 0x4, 0x7f, 0x9a, 0x6f, 0x61, 0xd5, 0xf, 0x83, 0x86, 0x7e, 0xf, 0xdb, 0x65, 0xbf, 0x6f, 0x8b, 0xfb, 0x13, 0xc, 0x4f, 0xef, 0x40, 0x40, 0xa5, 0x88, 0x97, 0x70, 0x63}),
-				new(big.Int).SetBytes([]byte{0x6b, 0x98, 0xdd, 0x57, 0xcb, 0xed, 0x11, 0x57, 0x81, 0xa1, 0x73, 0x2d, 0x93, 0x37, 0x2a, 0x89, 0x95, 0x8b, 0x48, 0xa3, 0x96, 0xdf, 0xce, 0x62, 0xa0, 0x63, 0x69, 0xf8, 0x29, 0x4f, 0x6e, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbe, 0x84, 0xd1, 0x88, 0x1b, 0x50, 0x50, 0x76, 0x2f, 0xdf, 0x4d, 0x17, 0xab, 0x57, 0xb, 0x5b, 0x4a, 0xca, 0xb8, 0xe9, 0x5, 0x80, 0x28, 0xc3, 0xe4, 0xc9, 0xb2, 0xe7, 0xc3, 0x2c, 0x19, 0xfd}),
-				new(big.Int).SetBytes([]byte{0xef, 0xf9, 0x60, 0xcb, 0x32, 0xdc, 0x50, 0x94, 0x4d, 0x1e, 0x65, 0xf4, 0x83, 0x8, 0x39, 0x94, 0x79, 0x55, 0xa7, 0xf5, 0x5e, 0x50, 0xdc, 0x37, 0x67, 0x69, 0x4a, 0x53, 0x72, 0xf1, 0x28, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x72, 0x62, 0x98, 0xeb, 0x9c, 0x6e, 0x31, 0x81, 0x81, 0x3, 0x7, 0xf6, 0x1, 0xd7, 0xc5, 0x78, 0xf6, 0xb0, 0x15, 0xef, 0x29, 0xea, 0x5b, 0xf8, 0xeb, 0xcb, 0x2, 0x98, 0xdf, 0x55, 0xa9, 0xbf}),
-				new(big.Int).SetBytes([]byte{0x38, 0xd4, 0x88, 0x1, 0xa9, 0xde, 0x9b, 0xfd, 0xa9, 0x83, 0x5a, 0x2e, 0xf3, 0xd0, 0x99, 0xe0, 0xbe, 0x79, 0x7b, 0xcb, 0xc0, 0x6, 0x73, 0xb4, 0xe1, 0x16, 0xf8, 0xb0, 0xbc, 0x3a, 0xcc, 0xae}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x98, 0x67, 0xa0, 0x31, 0x4c, 0xd, 0x7e, 0xe5, 0x41, 0xf5, 0x72, 0x74, 0xca, 0xf2, 0xbc, 0x88, 0xcb, 0xea, 0xb5, 0x40, 0x13, 0x2f, 0xa1, 0x67, 0xbd, 0x1b, 0x75, 0xfd, 0x56, 0xc6, 0x94, 0x82}),
-				new(big.Int).SetBytes([]byte{0xd0, 0x2e, 0x61, 0x5a, 0x3b, 0x10, 0x83, 0x4f, 0x9c, 0x79, 0x2d, 0x55, 0xb1, 0xba, 0x99, 0x63, 0x9e, 0x62, 0x45, 0xcf, 0x51, 0x56, 0x28, 0xa9, 0x16, 0xf0, 0x61, 0x14, 0x6f, 0x79, 0x2c, 0xd7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb9, 0x34, 0x13, 0xf, 0x13, 0x39, 0xe4, 0x8a, 0x69, 0x3a, 0xf7, 0x55, 0x59, 0x46, 0xde, 0x47, 0xa4, 0xc9, 0xe, 0x8c, 0x3e, 0xfa, 0xbd, 0x85, 0x99, 0x57, 0xf2, 0xd8, 0x41, 0xa7, 0xe7, 0xd2}),
-				new(big.Int).SetBytes([]byte{0x95, 0x23, 0x3f, 0x6b, 0x9b, 0x2d, 0x5, 0x76, 0xaa, 0x9, 0x29, 0xa5, 0xfb, 0xf7, 0x8d, 0x1f, 0xff, 0xd3, 0x81, 0xdb, 0xe7, 0xd, 0xcc, 0x41, 0xa4, 0xa9, 0x91, 0xa8, 0x98, 0x65, 0x3d, 0x39}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa8, 0x66, 0xf2, 0x45, 0x40, 0xd8, 0x81, 0x5a, 0x59, 0x27, 0xf2, 0xea, 0x0, 0x99, 0xd6, 0xab, 0xdf, 0x1d, 0x92, 0xfe, 0xcd, 0x1, 0xfc, 0x29, 0x78, 0xa2, 0x39, 0xd9, 0x1d, 0x55, 0x7a, 0xa1}),
-				new(big.Int).SetBytes([]byte{0xb5, 0x87, 0x39, 0xf6, 0xa8, 0x2, 0x2b, 0x44, 0xd7, 0x93, 0xd8, 0xfa, 0x5d, 0xb0, 0xe1, 0x39, 0x61, 0xeb, 0x69, 0xc6, 0x7c, 0x61, 0x1a, 0x4b, 0x79, 0x81, 0xbb, 0xb4, 0xc1, 0x43, 0x6, 0x34}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd2, 0xa6, 0x3a, 0x50, 0xae, 0x40, 0x1e, 0x56, 0xd6, 0x45, 0xa1, 0x15, 0x3b, 0x10, 0x9a, 0x8f, 0xcc, 0xa0, 0xa4, 0x3d, 0x56, 0x1f, 0xba, 0x2d, 0xbb, 0x51, 0x34, 0xc, 0x9d, 0x82, 0xb1, 0x51}),
-				new(big.Int).SetBytes([]byte{0xe8, 0x2d, 0x86, 0xfb, 0x64, 0x43, 0xfc, 0xb7, 0x56, 0x5a, 0xee, 0x58, 0xb2, 0x94, 0x82, 0x20, 0xa7, 0xf, 0x75, 0xa, 0xf4, 0x84, 0xca, 0x52, 0xd4, 0x14, 0x21, 0x74, 0xdc, 0xf8, 0x94, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xba, 0xf1, 0x83, 0xa7, 0x61, 0x0, 0x52, 0x5e, 0x23, 0xbc, 0x72, 0x2, 0x3, 0x37, 0x25, 0xf9, 0x22, 0xb9, 0xcd, 0x6b, 0x36, 0xc4, 0x13, 0x49, 0x7c, 0x6c, 0x4b, 0xac, 0xca, 0x72, 0xda, 0x5f}),
-				new(big.Int).SetBytes([]byte{0xde, 0xac, 0x9f, 0xbe, 0x9c, 0xcb, 0x4d, 0x33, 0x56, 0x88, 0xbd, 0x58, 0xdd, 0x69, 0xb1, 0xd1, 0x8e, 0x23, 0x36, 0xc5, 0xca, 0x73, 0x93, 0x61, 0x37, 0x7c, 0xe6, 0x28, 0xa8, 0xf2, 0xa0, 0xcf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x41, 0x8, 0x11, 0x5, 0x22, 0x1f, 0xfb, 0x73, 0xbe, 0xfe, 0x17, 0xf, 0x31, 0xbf, 0x24, 0x5f, 0x5c, 0x2a, 0xbe, 0xf5, 0xf1, 0x8b, 0xf1, 0xf1, 0x78, 0x59, 0xf6, 0x35, 0xee, 0x4b, 0x3b, 0xa0}),
-				new(big.Int).SetBytes([]byte{0xdc, 0x37, 0xf3, 0x69, 0x76, 0xff, 0x56, 0x68, 0xf2, 0xca, 0x65, 0xae, 0x1d, 0x6b, 0x84, 0x98, 0x13, 0x36, 0x49, 0x85, 0x65, 0xa0, 0x64, 0x55, 0x48, 0x69, 0x61, 0xdc, 0x17, 0x52, 0x55, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf7, 0xae, 0xf8, 0xa7, 0xe3, 0x84, 0x40, 0x23, 0x8f, 0x93, 0x32, 0x90, 0x6e, 0x48, 0xf6, 0xfd, 0x5a, 0xdb, 0xd0, 0x2d, 0x56, 0xb7, 0x6a, 0x5f, 0xfa, 0x5a, 0xca, 0x58, 0xc5, 0x6c, 0x39, 0x43}),
-				new(big.Int).SetBytes([]byte{0x4e, 0x3b, 0xb, 0x44, 0xd5, 0xff, 0xda, 0x79, 0x7c, 0x44, 0x2b, 0xbd, 0xc3, 0xab, 0x3f, 0xcf, 0xee, 0xc3, 0x1, 0x84, 0xa8, 0xdc, 0xd0, 0x3, 0x43, 0x1f, 0x62, 0x7f, 0xac, 0xf4, 0x42, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x63, 0xa2, 0xa2, 0x10, 0xa1, 0x6c, 0xc0, 0xc8, 0xc8, 0xcf, 0x22, 0x99, 0x5, 0x31, 0xd6, 0x5e, 0xdb, 0xdf, 0x22, 0x83, 0x3b, 0x8a, 0x2, 0x18, 0x46, 0x29, 0xc9, 0xb8, 0x93, 0xd9, 0x8d, 0xed}),
-				new(big.Int).SetBytes([]byte{0x88, 0x2b, 0x42, 0xe2, 0xe7, 0xfe, 0xc7, 0x6f, 0xd0, 0x65, 0x3, 0x32, 0x54, 0xed, 0x94, 0x46, 0x1f, 0xab, 0xf6, 0xa0, 0x9, 0xc7, 0x87, 0x3a, 0x51, 0x91, 0x97, 0xd4, 0xe0, 0xd1, 0xcf, 0xc2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1c, 0xd2, 0x76, 0xd7, 0x93, 0xa2, 0xbd, 0xff, 0x8e, 0x2c, 0x70, 0x86, 0x27, 0x32, 0xf, 0x12, 0x45, 0x13, 0x6, 0x8b, 0xf5, 0xda, 0x5d, 0xf0, 0x20, 0x89, 0xe6, 0x6a, 0x0, 0xe, 0x54, 0x85}),
-				new(big.Int).SetBytes([]byte{0x66, 0x15, 0xbd, 0xd1, 0x8b, 0x2e, 0xaf, 0x73, 0x8d, 0x4b, 0x34, 0xd4, 0x55, 0x2e, 0x97, 0x7f, 0x99, 0xd0, 0xe2, 0x70, 0xb2, 0x9, 0xa4, 0xec, 0xca, 0x5c, 0xf0, 0x51, 0x88, 0x5f, 0xbd, 0x7f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc, 0x15, 0x81, 0x5d, 0x44, 0x9d, 0x67, 0xca, 0x3d, 0xf1, 0x2e, 0x2e, 0x5d, 0x57, 0xbe, 0xf0, 0x80, 0xff, 0x73, 0x71, 0x90, 0x1e, 0x8d, 0x7e, 0xea, 0x86, 0x1a, 0x50, 0xb8, 0x4, 0x54, 0x45}),
-				new(big.Int).SetBytes([]byte{0x2f, 0x30, 0xd6, 0xa, 0x3a, 0xe9, 0x41, 0x15, 0xd0, 0xa9, 0x3c, 0x3b, 0x66, 0x63, 0xcd, 0x28, 0xba, 0x30, 0x54, 0xe4, 0x36, 0x58, 0xce, 0xa3, 0x21, 0x4f, 0x87, 0xd5, 0x40, 0x54, 0xa2, 0x6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdf, 0xb5, 0x47, 0xcb, 0x10, 0x1, 0x90, 0x36, 0xc5, 0xa2, 0xe2, 0x9f, 0xd, 0xdd, 0xbb, 0x1f, 0x7a, 0xf2, 0xfa, 0x25, 0xa3, 0xc7, 0xa7, 0x8c, 0x1f, 0xac, 0x94, 0x57, 0x11, 0x92, 0x44, 0x59}),
-				new(big.Int).SetBytes([]byte{0x9a, 0xcc, 0xd2, 0xa9, 0xba, 0xf, 0x47, 0x8, 0x8b, 0x83, 0x89, 0xce, 0x9d, 0xc8, 0x64, 0xcc, 0x22, 0xaf, 0x9, 0x30, 0xe5, 0xc0, 0x31, 0xdc, 0xfa, 0x20, 0x5e, 0xd, 0xcc, 0x65, 0xfd, 0x9e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc7, 0x57, 0xeb, 0x6b, 0x89, 0xae, 0x8a, 0x9c, 0xd, 0x57, 0xba, 0x8f, 0x48, 0x28, 0xef, 0x7f, 0x88, 0x2f, 0xca, 0xde, 0x1e, 0x96, 0x19, 0x70, 0x1e, 0x91, 0x23, 0x9c, 0xc3, 0x85, 0x7f, 0xaf}),
-				new(big.Int).SetBytes([]byte{0xf0, 0x3a, 0x59, 0xcf, 0xcd, 0x23, 0xff, 0x36, 0x25, 0x73, 0x69, 0xf2, 0x8, 0xbe, 0xc6, 0x1b, 0x62, 0x7d, 0x31, 0xd, 0x10, 0x18, 0x94, 0xc1, 0x1, 0xd9, 0xdb, 0xc, 0xa0, 0x58, 0x4c, 0xa4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0x20, 0x22, 0xf7, 0xcc, 0xc4, 0xdb, 0x24, 0x95, 0xdb, 0xc8, 0x6, 0x4a, 0x11, 0x5e, 0x25, 0x77, 0xc2, 0x77, 0x1a, 0x47, 0x17, 0x1b, 0xac, 0x9a, 0x8b, 0xb3, 0x51, 0x8e, 0xf8, 0xb5, 0x17}),
-				new(big.Int).SetBytes([]byte{0xbd, 0x57, 0x83, 0x38, 0xde, 0x4d, 0xe9, 0x28, 0xae, 0x71, 0x1, 0xf9, 0xb0, 0x94, 0x75, 0x41, 0xa3, 0x60, 0x3e, 0xdf, 0x3b, 0xd7, 0x9d, 0x77, 0x48, 0xb7, 0xdf, 0x28, 0x24, 0x9d, 0xa1, 0xac}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfa, 0x80, 0x63, 0xae, 0xad, 0xaf, 0xa0, 0xd0, 0xf8, 0x15, 0xd4, 0x9, 0x91, 0x9c, 0x2, 0xa2, 0x44, 0xfa, 0xf7, 0x7e, 0x2d, 0x88, 0x58, 0xd1, 0xe, 0x4d, 0x18, 0x4f, 0x54, 0x59, 0x22, 0x5d}),
-				new(big.Int).SetBytes([]byte{0xb, 0xa7, 0x1f, 0x90, 0x57, 0xc0, 0xc0, 0x69, 0x16, 0xd, 0xe2, 0x20, 0x82, 0x4c, 0x42, 0x5c, 0xa, 0x2b, 0x6c, 0x36, 0xd0, 0x7, 0xc5, 0x9c, 0x3a, 0x2b, 0x7e, 0x3b, 0x7e, 0xce, 0x15, 0x7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2c, 0xee, 0xd8, 0xed, 0x2c, 0x82, 0x7, 0x2e, 0x9e, 0x5a, 0x92, 0x5f, 0x74, 0x45, 0xf, 0x80, 0x84, 0xb0, 0xf3, 0x93, 0x51, 0xe0, 0x15, 0xe3, 0x3c, 0x75, 0x3d, 0x59, 0xa9, 0xb, 0xc0, 0x3e}),
-				new(big.Int).SetBytes([]byte{0xa4, 0x27, 0x6b, 0x80, 0xe9, 0xb9, 0xde, 0x3e, 0xcd, 0x85, 0xe, 0xb7, 0xaf, 0xc7, 0xda, 0xeb, 0x4a, 0x1d, 0xf9, 0xcf, 0x20, 0xd5, 0x3c, 0xd0, 0x1c, 0xb7, 0x2b, 0x8b, 0x7a, 0xb8, 0x2a, 0xdc}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x65, 0x8c, 0xa3, 0xc5, 0x9d, 0x2, 0x57, 0xa8, 0x9e, 0x2f, 0x69, 0xcf, 0x86, 0x2e, 0xe0, 0xa5, 0xaa, 0xba, 0x9f, 0xd4, 0x90, 0x9e, 0x61, 0xa7, 0x96, 0x79, 0xfe, 0x94, 0x16, 0xf0, 0xd0, 0x44}),
-				new(big.Int).SetBytes([]byte{0xae, 0x5c, 0xc1, 0x37, 0x7a, 0xea, 0x25, 0x46, 0xf, 0x16, 0x5d, 0x62, 0x71, 0x3b, 0xd3, 0x76, 0x55, 0xa1, 0x67, 0x63, 0xc4, 0x18, 0xce, 0x89, 0xad, 0xd1, 0xf3, 0x2e, 0xf7, 0xcb, 0x87, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x37, 0x9c, 0x74, 0x9a, 0x45, 0x39, 0xe8, 0xec, 0xdd, 0x11, 0x36, 0xe7, 0xc4, 0xeb, 0xbc, 0x82, 0x7b, 0xdc, 0xdd, 0xfe, 0x7d, 0xd1, 0xc, 0x9a, 0x50, 0xcd, 0x4e, 0x31, 0x42, 0xaa, 0x24, 0x94}),
-				new(big.Int).SetBytes([]byte{0xa2, 0x54, 0x63, 0x84, 0x8b, 0xf9, 0xc0, 0x31, 0x3c, 0x9c, 0xe, 0xf1, 0xd6, 0x78, 0x3f, 0x11, 0x13, 0x64, 0xf6, 0xa7, 0xb7, 0xe8, 0x56, 0x43, 0x5b, 0xef, 0xa9, 0xe8, 0xce, 0xc8, 0x6c, 0x63}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x31, 0xc9, 0x4b, 0x4a, 0xaf, 0x91, 0xf2, 0xba, 0xce, 0x4c, 0x19, 0x83, 0x5d, 0x3f, 0x30, 0x5c, 0x67, 0x6a, 0xf1, 0x60, 0x56, 0x56, 0x14, 0x17, 0xab, 0x5a, 0xc9, 0x57, 0x36, 0x96, 0x75, 0x6d}),
-				new(big.Int).SetBytes([]byte{0xf8, 0xf, 0x88, 0x4b, 0x55, 0x6e, 0x83, 0xa9, 0xbf, 0x4b, 0x8b, 0x59, 0xaa, 0xf4, 0x16, 0x6, 0x83, 0xae, 0xdf, 0xca, 0xe6, 0xad, 0x10, 0x70, 0xab, 0xc4, 0x5e, 0x8d, 0xde, 0x8, 0xae, 0x78}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x64, 0x58, 0x7e, 0x23, 0x35, 0x47, 0x1e, 0xb8, 0x90, 0xee, 0x78, 0x96, 0xd7, 0xcf, 0xdc, 0x86, 0x6b, 0xac, 0xbd, 0xbd, 0x38, 0x39, 0x31, 0x7b, 0x34, 0x36, 0xf9, 0xb4, 0x56, 0x17, 0xe0, 0x73}),
-				new(big.Int).SetBytes([]byte{0xd9, 0x9f, 0xcd, 0xd5, 0xbf, 0x69, 0x2, 0xe2, 0xae, 0x96, 0xdd, 0x64, 0x47, 0xc2, 0x99, 0xa1, 0x85, 0xb9, 0xa, 0x39, 0x13, 0x3a, 0xea, 0xb3, 0x58, 0x29, 0x9e, 0x5e, 0x9f, 0xaf, 0x65, 0x89}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb8, 0x66, 0xd6, 0xb1, 0x42, 0xdf, 0x94, 0xf, 0x2c, 0xf2, 0x8b, 0x54, 0xc9, 0x2f, 0xc, 0x12, 0x94, 0xe0, 0xb6, 0xa2, 0x2a, 0x91, 0xf2, 0xef, 0x44, 0xbc, 0xd8, 0x8c, 0x43, 0x84, 0x48, 0xd}),
-				new(big.Int).SetBytes([]byte{0x19, 0x14, 0xb0, 0xb3, 0x42, 0x6a, 0xeb, 0x70, 0x89, 0xa2, 0x78, 0xd7, 0xea, 0x9a, 0xd7, 0xac, 0x24, 0xe5, 0x22, 0x80, 0x4b, 0x1d, 0x86, 0xd6, 0xe, 0x65, 0x9b, 0x47, 0xc, 0x4c, 0xaf, 0xa8}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbc, 0x47, 0x7b, 0xd5, 0x5a, 0x42, 0x3, 0xf8, 0x36, 0xa2, 0x13, 0xcf, 0xc5, 0x92, 0xa1, 0x7a, 0xc3, 0x46, 0x4, 0xc0, 0x7c, 0x0, 0x48, 0x59, 0xad, 0xf7, 0x14, 0x72, 0xe, 0x10, 0x3d, 0xd6}),
-				new(big.Int).SetBytes([]byte{0xe3, 0x1e, 0x1e, 0x24, 0x29, 0xa8, 0xdd, 0x52, 0x6c, 0xba, 0xc5, 0x52, 0xc6, 0xde, 0xa6, 0x39, 0x5d, 0x29, 0x35, 0x72, 0xc6, 0x3b, 0x44, 0xac, 0x63, 0x90, 0x82, 0xd8, 0xd6, 0xf7, 0xc3, 0x43}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xec, 0x2b, 0xb8, 0x90, 0x85, 0xde, 0x81, 0x9e, 0xc4, 0xd9, 0xd1, 0x64, 0x61, 0x2, 0xba, 0x87, 0xe2, 0xd5, 0x2a, 0xe4, 0xed, 0x4f, 0xe4, 0x55, 0xd2, 0x29, 0xcd, 0xa8, 0x1d, 0xb2, 0xd, 0x6c}),
-				new(big.Int).SetBytes([]byte{0xcc, 0xec, 0xc1, 0x76, 0x61, 0xe0, 0x13, 0xa1, 0x33, 0x2f, 0x66, 0xf0, 0x65, 0x9, 0x40, 0xc6, 0x33, 0xa2, 0x36, 0x4b, 0xe8, 0x7e, 0xfa, 0x98, 0xa0, 0xe9, 0x9c, 0x4d, 0x62, 0x9c, 0xf4, 0xa0}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0x9d, 0xb4, 0xfe, 0x5a, 0x6b, 0xb8, 0x38, 0x3, 0x3, 0xb4, 0x23, 0x26, 0x7b, 0xf8, 0xe8, 0xa, 0xc5, 0xaf, 0x14, 0x4, 0xe6, 0x34, 0x90, 0x7d, 0xd, 0xc3, 0xb0, 0xd4, 0x4e, 0xab, 0x31}),
-				new(big.Int).SetBytes([]byte{0x62, 0x55, 0x44, 0x5c, 0x10, 0x8a, 0xa2, 0xa4, 0xf6, 0x7, 0x6, 0x20, 0x24, 0xbd, 0x90, 0xf3, 0x1f, 0x61, 0xe, 0x55, 0x21, 0x48, 0xf8, 0xdb, 0x94, 0x1a, 0xeb, 0xe7, 0x51, 0x36, 0x1f, 0x6a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb1, 0xd2, 0x5d, 0x51, 0xb4, 0x55, 0x8f, 0x5f, 0xd0, 0xcc, 0xb8, 0x68, 0x3a, 0xf9, 0xa9, 0xcf, 0x62, 0xa1, 0x69, 0xc6, 0x91, 0x62, 0x7f, 0xa5, 0x92, 0xd8, 0xb, 0x18, 0x36, 0x69, 0x5f, 0x94}),
-				new(big.Int).SetBytes([]byte{0x70, 0x6d, 0xda, 0x72, 0x3, 0xe, 0x90, 0xb1, 0xf7, 0xbe, 0xa0, 0xac, 0x19, 0xab, 0xa8, 0x17, 0x60, 0xf6, 0xf1, 0x8d, 0xab, 0x86, 0x3e, 0xdc, 0xa5, 0xb7, 0xeb, 0x9a, 0x5e, 0xe3, 0x27, 0x36}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0x39, 0xb3, 0x37, 0xd1, 0x6e, 0x2f, 0xa2, 0xea, 0x8a, 0x18, 0x60, 0xad, 0x6e, 0xdb, 0x7c, 0x42, 0x83, 0x1c, 0x1c, 0x56, 0x3, 0x36, 0xc6, 0x7b, 0xf9, 0x61, 0x72, 0x9a, 0xc, 0x2c, 0x41}),
-				new(big.Int).SetBytes([]byte{0x9f, 0x9b, 0x29, 0x63, 0x62, 0xc7, 0xae, 0x5b, 0xa7, 0xa3, 0x24, 0xf, 0xd1, 0x13, 0xa3, 0x40, 0x6b, 0x12, 0x27, 0xf8, 0x7d, 0xe9, 0x23, 0xa4, 0x18, 0x5f, 0x5, 0x4b, 0xa9, 0xf1, 0xbc, 0x2b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0xc4, 0xa7, 0xe3, 0x89, 0xe2, 0x96, 0xce, 0xd3, 0x9d, 0x75, 0xef, 0x5e, 0x54, 0x59, 0x5, 0xe5, 0x0, 0x50, 0x64, 0xf, 0x50, 0xbe, 0xcf, 0x38, 0xa6, 0xe, 0xcb, 0x23, 0xb0, 0x9d, 0xf}),
-				new(big.Int).SetBytes([]byte{0x13, 0x13, 0xfa, 0xdb, 0x73, 0x7a, 0xf3, 0xba, 0xa, 0xf3, 0xe0, 0xa2, 0x92, 0xf8, 0x10, 0xaa, 0x78, 0x6f, 0x2b, 0x8, 0x4a, 0x62, 0xff, 0xc7, 0x63, 0x7b, 0x1f, 0x1, 0x72, 0xd, 0xdb, 0x62}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7f, 0xf3, 0xbb, 0xa1, 0x13, 0x63, 0xcf, 0x17, 0xcc, 0x50, 0x32, 0x9b, 0x50, 0x1, 0xb5, 0x68, 0xa8, 0xdd, 0xfe, 0xc8, 0x32, 0xc0, 0xcd, 0xec, 0x37, 0x4, 0xb1, 0xf8, 0x58, 0xfc, 0x47, 0xaf}),
-				new(big.Int).SetBytes([]byte{0x1f, 0x6b, 0xa7, 0xae, 0x80, 0x18, 0xa6, 0x29, 0xa0, 0xbc, 0x89, 0x1b, 0x74, 0x73, 0x44, 0x6b, 0x17, 0xa5, 0x3b, 0x1a, 0xd1, 0x8b, 0xac, 0x29, 0x7f, 0x86, 0x16, 0x4c, 0xf9, 0xba, 0x43, 0xa7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfc, 0x39, 0x5d, 0xc4, 0xa5, 0x11, 0x4d, 0xc8, 0xbc, 0xb0, 0xf7, 0xf0, 0x3, 0xa4, 0xd3, 0xe9, 0x38, 0xa8, 0x7a, 0x3d, 0x78, 0xd3, 0x38, 0x64, 0xd1, 0xdc, 0x4c, 0xba, 0x9e, 0x41, 0xe2, 0xe}),
-				new(big.Int).SetBytes([]byte{0x38, 0x66, 0xe0, 0x91, 0x8, 0xd0, 0x7d, 0x9, 0x4c, 0xeb, 0x81, 0xa9, 0x8b, 0xdd, 0xb6, 0xd6, 0x2, 0x21, 0x47, 0x5a, 0x68, 0x5e, 0x4e, 0x2b, 0x77, 0xad, 0x8c, 0xc2, 0xe7, 0xcf, 0x42, 0xee}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8e, 0xc6, 0x70, 0x46, 0x3c, 0xeb, 0x60, 0xbb, 0x61, 0x41, 0xe9, 0x39, 0x5f, 0xec, 0x7f, 0x61, 0x0, 0xb4, 0x29, 0x3b, 0x23, 0xd4, 0x2a, 0x99, 0x1, 0x84, 0xd6, 0x0, 0x95, 0x21, 0x37, 0x75}),
-				new(big.Int).SetBytes([]byte{0x8f, 0x58, 0xa6, 0x6f, 0xa0, 0xda, 0x5b, 0xe3, 0x6, 0xe6, 0x43, 0x41, 0x5c, 0xed, 0xee, 0xe, 0x71, 0xda, 0x98, 0xa0, 0xbc, 0xcf, 0x51, 0xe9, 0xe, 0x1, 0xf, 0x3e, 0x78, 0x41, 0xff, 0xff}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0xf, 0x8c, 0xc5, 0x35, 0x2, 0xba, 0x26, 0xa8, 0xd1, 0x58, 0x27, 0x65, 0xb3, 0xfd, 0xd0, 0xb7, 0xc3, 0xd, 0x8e, 0x76, 0x4, 0x25, 0xe3, 0x49, 0x97, 0x10, 0x18, 0xb6, 0xf8, 0x32, 0x31}),
-				new(big.Int).SetBytes([]byte{0xbc, 0x4a, 0x9d, 0xbe, 0x2, 0xa7, 0x90, 0x53, 0xd0, 0xbf, 0x70, 0x86, 0xb8, 0x2c, 0xc7, 0x89, 0xa, 0x85, 0xbf, 0xa1, 0x77, 0x22, 0xd7, 0x7f, 0x4a, 0x3, 0x1a, 0x9d, 0x5f, 0x2e, 0x83, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x83, 0xde, 0x61, 0xb4, 0x41, 0xe7, 0x1, 0xbb, 0x89, 0xdb, 0xac, 0x3f, 0xed, 0x95, 0xd4, 0x95, 0xae, 0x6a, 0xa7, 0x2f, 0xe2, 0xb9, 0x5c, 0x2e, 0x3c, 0xb3, 0xff, 0x8d, 0x34, 0x52, 0xab, 0xbb}),
-				new(big.Int).SetBytes([]byte{0x32, 0xf0, 0xe3, 0x34, 0xa3, 0x4c, 0x60, 0x9f, 0xa6, 0xbf, 0xb6, 0xaf, 0x8c, 0x4e, 0x15, 0x63, 0xd7, 0x9c, 0x5f, 0x36, 0xbb, 0xce, 0x42, 0x91, 0x8a, 0x5, 0x17, 0x6b, 0x5, 0x4e, 0xb6, 0x6e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x53, 0xfe, 0x8a, 0xf9, 0x8f, 0x94, 0x19, 0xfb, 0x14, 0x45, 0xbb, 0x6a, 0x94, 0x75, 0xd, 0x46, 0x46, 0xbe, 0x9f, 0x37, 0xb7, 0x15, 0x57, 0x63, 0x88, 0xb1, 0x10, 0x64, 0xdf, 0x2e, 0x6f, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x93, 0x28, 0x81, 0x7e, 0x7b, 0x51, 0x54, 0x62, 0x16, 0x40, 0xcf, 0x6c, 0x44, 0x64, 0xde, 0x5c, 0x5f, 0xb5, 0xf8, 0xe6, 0x3, 0xbd, 0x70, 0xc2, 0x2a, 0x1e, 0xb3, 0x72, 0x64, 0xe9, 0x2e, 0xb5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3d, 0x76, 0x63, 0x89, 0x8f, 0x50, 0xc1, 0xdb, 0x1a, 0x8b, 0xb5, 0xc0, 0xf, 0x7c, 0xd8, 0x68, 0x33, 0xae, 0x25, 0xb2, 0x92, 0xa1, 0x7b, 0x15, 0xe9, 0x68, 0x8c, 0x26, 0xf5, 0x92, 0x76, 0xf1}),
-				new(big.Int).SetBytes([]byte{0xd3, 0x76, 0x69, 0x73, 0x7a, 0x18, 0x7d, 0xdb, 0x60, 0x97, 0x6e, 0x34, 0xda, 0xd8, 0x33, 0x3e, 0xf3, 0x21, 0x98, 0x20, 0x19, 0xb1, 0x97, 0x86, 0x1, 0xe4, 0xa6, 0x5b, 0x85, 0x9c, 0xbc, 0xb3}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x84, 0x81, 0xbd, 0xe0, 0xe4, 0xe4, 0xd8, 0x85, 0xb3, 0xa5, 0x46, 0xd3, 0xe5, 0x49, 0xde, 0x4, 0x2f, 0xa, 0xa6, 0xce, 0xa2, 0x50, 0xe7, 0xfd, 0x35, 0x8d, 0x6c, 0x86, 0xdd, 0x45, 0xe4, 0x58}),
-				new(big.Int).SetBytes([]byte{0x38, 0xee, 0x7b, 0x8c, 0xba, 0x54, 0x4, 0xdd, 0x84, 0xa2, 0x5b, 0xf3, 0x9c, 0xec, 0xb2, 0xca, 0x90, 0xa, 0x79, 0xc4, 0x2b, 0x26, 0x2e, 0x55, 0x6d, 0x64, 0xb1, 0xb5, 0x97, 0x79, 0x5, 0x7e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x96, 0x29, 0xa4, 0x50, 0xbd, 0x38, 0x3a, 0x8b, 0x9f, 0xd4, 0x3c, 0x6c, 0xd1, 0xd4, 0x92, 0xbf, 0x39, 0x2e, 0xd6, 0x5, 0x29, 0x95, 0x61, 0xdd, 0xe5, 0x44, 0x33, 0x52, 0x6c, 0xe9, 0xf1, 0x14}),
-				new(big.Int).SetBytes([]byte{0xbf, 0x43, 0x9b, 0x28, 0xc, 0x5f, 0xb6, 0xd7, 0x57, 0x6b, 0xef, 0xd2, 0x20, 0xce, 0xf6, 0x4d, 0xb9, 0x25, 0x59, 0x3e, 0x5c, 0x56, 0xaf, 0x8d, 0xca, 0x39, 0x72, 0xc4, 0xa2, 0x4a, 0xa3, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3b, 0xea, 0xed, 0x1e, 0xf, 0x51, 0x8c, 0x5f, 0x8, 0x94, 0xe6, 0xb0, 0x5f, 0xe0, 0xb, 0xc8, 0x11, 0xdc, 0x13, 0xdb, 0x8, 0xd0, 0x64, 0x6b, 0x16, 0xa, 0xf, 0xa4, 0x15, 0x2d, 0xa1, 0x7d}),
-				new(big.Int).SetBytes([]byte{0xc3, 0xb0, 0xd7, 0xf5, 0x5a, 0xff, 0x7a, 0xcd, 0xfe, 0xf4, 0xa8, 0xbe, 0xbe, 0x80, 0xe1, 0xb5, 0x54, 0xcd, 0xe7, 0x7b, 0x8d, 0xfe, 0xc4, 0x16, 0xce, 0x70, 0x49, 0x85, 0xec, 0xc7, 0x68, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb7, 0x3b, 0x1c, 0x47, 0xef, 0x1e, 0x46, 0x88, 0xeb, 0x17, 0x30, 0xda, 0x7c, 0xc8, 0x93, 0xdf, 0x14, 0x77, 0xd7, 0x47, 0xe1, 0x87, 0xe1, 0x83, 0x83, 0xd3, 0x8d, 0x96, 0x26, 0xca, 0x6c, 0xc3}),
-				new(big.Int).SetBytes([]byte{0x58, 0x43, 0x15, 0xcb, 0x29, 0x49, 0x22, 0xa9, 0xa, 0x57, 0xd6, 0x4b, 0xbc, 0xc8, 0x5, 0x9, 0x73, 0x22, 0xa2, 0x52, 0x9, 0x75, 0x7f, 0x5a, 0xfa, 0xc3, 0x5d, 0x76, 0xa5, 0x4f, 0xdb, 0xa3}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6e, 0x73, 0xdb, 0xa0, 0xcb, 0xdc, 0x9d, 0x61, 0x87, 0x52, 0xdf, 0xc0, 0x39, 0x27, 0x59, 0x97, 0xe7, 0x4e, 0x32, 0x21, 0x42, 0x3, 0x11, 0xb7, 0x23, 0x4f, 0xa1, 0x7d, 0xba, 0x4e, 0xdc, 0xc5}),
-				new(big.Int).SetBytes([]byte{0xee, 0xa, 0xc1, 0xfc, 0x49, 0xee, 0xcc, 0x48, 0xbe, 0xa4, 0x55, 0x8, 0x88, 0x82, 0x86, 0x93, 0x27, 0x8a, 0x77, 0xda, 0x70, 0x6, 0x79, 0x3, 0x7b, 0x31, 0xf7, 0xcd, 0xd5, 0x9d, 0xa0, 0xe4}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0x16, 0x41, 0xd1, 0x1d, 0x60, 0x2b, 0x14, 0x72, 0x26, 0x15, 0x25, 0x3d, 0xbc, 0xf0, 0x27, 0xa9, 0x24, 0x29, 0xa2, 0xf7, 0xe3, 0x9a, 0x75, 0xc1, 0xb9, 0xad, 0x4, 0xd0, 0x63, 0xe1, 0xbe}),
-				new(big.Int).SetBytes([]byte{0x4c, 0x3b, 0xda, 0x61, 0x79, 0x60, 0x39, 0xf0, 0x6a, 0x42, 0xe3, 0xf, 0xcd, 0xbd, 0x2b, 0xeb, 0xbd, 0xf, 0xae, 0xc0, 0xf9, 0x74, 0x5b, 0x77, 0xfd, 0xd1, 0xff, 0x3e, 0x21, 0xfe, 0xc8, 0x90}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x77, 0x6, 0xdd, 0x89, 0x37, 0xe5, 0xb5, 0x92, 0x76, 0x27, 0xd9, 0x7e, 0xaa, 0x47, 0xc3, 0x10, 0x4, 0x90, 0xf5, 0xfa, 0x32, 0x9a, 0xbb, 0x31, 0x40, 0x9c, 0x4c, 0x42, 0x4a, 0x80, 0xb9, 0x79}),
-				new(big.Int).SetBytes([]byte{0x8a, 0x2, 0xa9, 0x82, 0x7d, 0x5b, 0xb7, 0x14, 0x77, 0x78, 0xdd, 0x79, 0x91, 0xea, 0x9c, 0x71, 0x17, 0xfa, 0xa, 0x34, 0xfd, 0x7e, 0xfe, 0x32, 0x14, 0xcf, 0x48, 0x10, 0x41, 0x26, 0xcf, 0xde}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xed, 0xfe, 0x16, 0xb2, 0xdb, 0x40, 0x18, 0x3, 0x11, 0xf9, 0x89, 0x20, 0x7, 0xa2, 0xfe, 0xf7, 0xd0, 0x5b, 0x2a, 0x3b, 0xb6, 0x76, 0x89, 0x9f, 0x9c, 0x6e, 0x21, 0x92, 0xd3, 0x8f, 0x93, 0xe0}),
-				new(big.Int).SetBytes([]byte{0xee, 0x69, 0x2, 0xf1, 0xfc, 0xa5, 0xdb, 0x36, 0x94, 0xd7, 0x4f, 0xaa, 0x4b, 0x5, 0xd0, 0xd2, 0x5b, 0x3d, 0x51, 0x0, 0xc4, 0x6e, 0x22, 0x7e, 0x3d, 0x1, 0x79, 0x3d, 0xe2, 0x94, 0x5, 0xad}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x16, 0xd4, 0x22, 0xc5, 0x92, 0x9b, 0xc0, 0xf8, 0xd, 0x2f, 0x3c, 0x7a, 0x41, 0xf9, 0xe0, 0x6a, 0xa4, 0x5b, 0xac, 0x29, 0x41, 0x3e, 0xf1, 0x27, 0xdc, 0xdb, 0x9c, 0x4b, 0x72, 0x6f, 0xe2, 0x85}),
-				new(big.Int).SetBytes([]byte{0xd, 0xf8, 0x88, 0xfa, 0x51, 0xe2, 0x26, 0x41, 0xa8, 0xab, 0x7e, 0x69, 0xa6, 0x35, 0x31, 0xb1, 0xe9, 0x3f, 0xc, 0x11, 0x37, 0x36, 0x4d, 0x42, 0x33, 0xe0, 0xff, 0xe6, 0xc1, 0x55, 0xb4, 0x41}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0x2c, 0xb3, 0x63, 0xf9, 0xf3, 0xc, 0x33, 0x49, 0x27, 0x12, 0x9b, 0x35, 0xf2, 0xe5, 0xc6, 0xb8, 0xb5, 0xf6, 0x24, 0xc4, 0xc2, 0x47, 0xf1, 0x5d, 0x31, 0x32, 0xb8, 0x69, 0xe3, 0x6, 0xa5}),
-				new(big.Int).SetBytes([]byte{0xae, 0x63, 0x47, 0x4, 0xe2, 0xdf, 0x92, 0xaa, 0x5f, 0x9e, 0x77, 0xfe, 0x5b, 0xc6, 0x50, 0x79, 0xb8, 0x57, 0x2, 0xed, 0xcc, 0xad, 0x4f, 0x35, 0xd0, 0x61, 0xfd, 0x40, 0xb6, 0xe8, 0x62, 0x95}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1f, 0xcc, 0xe, 0x47, 0xee, 0xc7, 0x6d, 0x63, 0xaa, 0x5a, 0x34, 0x47, 0x50, 0xf5, 0x6a, 0x9c, 0x4e, 0x24, 0x7, 0x9f, 0x9a, 0x60, 0xe3, 0xae, 0xae, 0x31, 0xb, 0xca, 0xba, 0xcf, 0xa5, 0x13}),
-				new(big.Int).SetBytes([]byte{0x16, 0x5d, 0xc1, 0xa0, 0x41, 0xe3, 0x54, 0x78, 0xb4, 0x8e, 0x38, 0x75, 0x43, 0x39, 0xf9, 0xb4, 0x4b, 0xed, 0xed, 0xd1, 0x64, 0x98, 0x39, 0x18, 0x75, 0x7, 0x5b, 0xa4, 0x10, 0x7a, 0xfc, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xab, 0x86, 0xc2, 0xad, 0x2a, 0xcf, 0xac, 0x53, 0xf, 0xef, 0x75, 0xad, 0xb0, 0x2b, 0xa3, 0x74, 0xab, 0x66, 0x93, 0xb, 0x85, 0xe9, 0xc7, 0x8d, 0xd1, 0x2a, 0x3, 0x52, 0x7c, 0x46, 0xe0, 0x79}),
-				new(big.Int).SetBytes([]byte{0x12, 0x9, 0xc2, 0x86, 0x1, 0x9f, 0x29, 0xab, 0xcf, 0x5c, 0x46, 0xd4, 0xd7, 0x32, 0x0, 0xc9, 0xce, 0x77, 0x28, 0xe8, 0x48, 0x50, 0xd7, 0xc0, 0xec, 0x42, 0x8b, 0xc1, 0x76, 0x36, 0xff, 0xe1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc1, 0x2b, 0x90, 0x73, 0xad, 0x6c, 0xb8, 0xa1, 0x73, 0x6, 0x43, 0x4, 0x35, 0xca, 0xa3, 0x86, 0xa5, 0xa, 0x60, 0x89, 0xb2, 0x6b, 0x77, 0x17, 0x21, 0xa2, 0x70, 0x7c, 0xfe, 0xdc, 0x69, 0xb2}),
-				new(big.Int).SetBytes([]byte{0x31, 0x73, 0xc4, 0x3f, 0xe5, 0x34, 0xcd, 0xf1, 0xd9, 0x77, 0x2d, 0xc8, 0x9f, 0x89, 0x49, 0x76, 0xb8, 0xee, 0x1d, 0xbe, 0x3d, 0x27, 0xb2, 0xa0, 0x3, 0x2a, 0xe9, 0xb0, 0x20, 0xdd, 0x41, 0xdd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x70, 0x1c, 0x11, 0xc, 0x2d, 0xa3, 0xb0, 0x1b, 0xa1, 0xbd, 0x21, 0x79, 0xa1, 0x16, 0x68, 0x7a, 0xe6, 0x84, 0xeb, 0x76, 0x47, 0x27, 0x60, 0xd3, 0xec, 0x7b, 0xce, 0x9e, 0x34, 0x11, 0x42}),
-				new(big.Int).SetBytes([]byte{0x2a, 0x2, 0xe, 0xb3, 0x8d, 0x6c, 0xdd, 0x99, 0x52, 0xf8, 0x69, 0xbb, 0xde, 0x19, 0xf5, 0xa1, 0x5d, 0x7, 0xfc, 0xba, 0x5b, 0xc9, 0xbe, 0x57, 0x89, 0x24, 0xc6, 0x15, 0x9e, 0xf, 0x4d, 0xad}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd0, 0x59, 0xbf, 0x85, 0xdc, 0x32, 0x7a, 0x8f, 0xa3, 0xf3, 0xe9, 0x31, 0x31, 0x2c, 0xa7, 0x3e, 0x8a, 0x14, 0x88, 0x6f, 0x8b, 0x10, 0x71, 0x97, 0xab, 0x6f, 0x58, 0x5d, 0xf9, 0x61, 0xfe, 0x4d}),
-				new(big.Int).SetBytes([]byte{0x45, 0x10, 0x7a, 0x60, 0xc0, 0x8b, 0x52, 0x5e, 0x3f, 0xe0, 0x76, 0x5d, 0xc8, 0x49, 0x55, 0xb4, 0xfd, 0x38, 0x8b, 0xa1, 0xf4, 0xda, 0xb6, 0x4c, 0xb9, 0x93, 0x5b, 0x6, 0x6a, 0xe8, 0x23, 0xc2}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x13, 0x46, 0x4a, 0x57, 0xa7, 0x81, 0x2, 0xaa, 0x62, 0xb6, 0x97, 0x9a, 0xe8, 0x17, 0xf4, 0x63, 0x7f, 0xfc, 0xfe, 0xd3, 0xc4, 0xb1, 0xce, 0x30, 0xbc, 0xd6, 0x30, 0x3f, 0x6c, 0xaf, 0x66, 0x6b}),
-				new(big.Int).SetBytes([]byte{0x69, 0xbe, 0x15, 0x90, 0x4, 0x61, 0x45, 0x80, 0xef, 0x7e, 0x43, 0x34, 0x53, 0xcc, 0xb0, 0xca, 0x48, 0xf3, 0x0, 0xa8, 0x1d, 0x9, 0x42, 0xe1, 0x3f, 0x49, 0x5a, 0x90, 0x7f, 0x6e, 0xcc, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xeb, 0x3c, 0xf8, 0xf5, 0x32, 0x24, 0x53, 0x62, 0xec, 0x5, 0xc8, 0x8c, 0x85, 0xfe, 0x12, 0xd1, 0x91, 0x82, 0xbe, 0x7d, 0xce, 0xab, 0xe5, 0x77, 0xc7, 0x58, 0x49, 0xc6, 0x6, 0x50, 0x84, 0xae}),
-				new(big.Int).SetBytes([]byte{0xc8, 0x33, 0xc7, 0x82, 0x22, 0xd9, 0xd7, 0x0, 0x43, 0xfe, 0x63, 0xdc, 0xef, 0xdc, 0xa4, 0xa1, 0xf5, 0x2b, 0x45, 0xc5, 0xe7, 0xdb, 0xd2, 0xa6, 0x6f, 0x67, 0xc1, 0xff, 0xf9, 0x6b, 0x94, 0x80}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xdd, 0xe9, 0xd5, 0x14, 0xdd, 0x9e, 0xe6, 0x96, 0x2c, 0x6e, 0xd6, 0xb2, 0xbf, 0x5, 0xb5, 0xcf, 0xdd, 0xea, 0x17, 0x1b, 0x94, 0xfc, 0x9a, 0xeb, 0xf2, 0x16, 0xb2, 0x9, 0x8e, 0xca, 0x5f, 0x51}),
-				new(big.Int).SetBytes([]byte{0xb8, 0x4e, 0x69, 0x13, 0x3c, 0xe2, 0x81, 0x11, 0xd8, 0x91, 0xf3, 0x4b, 0xa, 0x7f, 0x8f, 0x9, 0x50, 0xfe, 0xeb, 0xe8, 0xde, 0x89, 0x57, 0x1f, 0x9a, 0xd6, 0x9a, 0x73, 0xd0, 0xc6, 0x38, 0xf7}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbd, 0xf1, 0xa6, 0x7d, 0x9, 0x2d, 0x99, 0x97, 0x4f, 0x7a, 0x60, 0xf2, 0x18, 0x45, 0x19, 0xb2, 0xa5, 0x76, 0xfc, 0xf9, 0x84, 0xa2, 0x1, 0xd9, 0xf8, 0xe5, 0xbc, 0xbc, 0xc2, 0xe9, 0xa5, 0xd0}),
-				new(big.Int).SetBytes([]byte{0x40, 0x95, 0x90, 0x2b, 0xab, 0x65, 0xa1, 0xaa, 0xa8, 0xb, 0xe5, 0x4a, 0x86, 0xbf, 0x7b, 0xaa, 0xa6, 0x28, 0xb, 0x61, 0xe5, 0x62, 0x64, 0x61, 0xcd, 0xb4, 0xf7, 0x1, 0x85, 0x62, 0xff, 0x7b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x1a, 0x62, 0x10, 0x23, 0x69, 0x93, 0x73, 0x6e, 0x9f, 0x40, 0x6e, 0xb1, 0x20, 0x4b, 0x17, 0x9a, 0xed, 0x51, 0x3e, 0x20, 0xad, 0x46, 0xec, 0x2f, 0x90, 0x6b, 0x5, 0x99, 0x9c, 0x88, 0xe4}),
-				new(big.Int).SetBytes([]byte{0x1a, 0xc9, 0x7b, 0x54, 0xb9, 0xc8, 0xc2, 0xb, 0xfb, 0x13, 0xc0, 0x69, 0x54, 0x97, 0x77, 0x82, 0xdc, 0x6b, 0x71, 0xd4, 0x95, 0xcc, 0x0, 0xf2, 0x99, 0xc8, 0xc9, 0x16, 0x59, 0x5b, 0xc8, 0xdf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd, 0x53, 0x8c, 0xb1, 0xdb, 0xb0, 0xf4, 0xc8, 0xd5, 0xc4, 0x54, 0xf6, 0x8a, 0x28, 0xda, 0x59, 0xa3, 0x45, 0x75, 0x89, 0x24, 0xa4, 0xd6, 0xf0, 0x1c, 0xcc, 0x6a, 0x55, 0xa0, 0x9b, 0xc, 0xca}),
-				new(big.Int).SetBytes([]byte{0xc8, 0x19, 0x6b, 0xac, 0x7a, 0x3e, 0xc1, 0x10, 0xcb, 0xa, 0x8d, 0x5c, 0x40, 0xee, 0xd7, 0xcf, 0x85, 0x34, 0xcd, 0x99, 0xd8, 0x91, 0x34, 0xc9, 0x73, 0x1b, 0x14, 0x7e, 0xc, 0x92, 0x9e, 0x5}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4e, 0xe4, 0x85, 0x31, 0xd8, 0xc2, 0x96, 0xb9, 0xf1, 0x3f, 0xfc, 0xe4, 0xf2, 0xc8, 0x6d, 0xc7, 0xcf, 0x2d, 0xa5, 0x73, 0x8d, 0x89, 0x2d, 0x68, 0xbd, 0x51, 0x5b, 0x5b, 0x5f, 0x80, 0x18, 0xce}),
-				new(big.Int).SetBytes([]byte{0xb6, 0x8f, 0x9e, 0xd4, 0x81, 0xb, 0xf8, 0xb5, 0xfc, 0x7b, 0x44, 0x8, 0xd0, 0xc7, 0xc5, 0xe6, 0x71, 0x7, 0x49, 0x71, 0xb4, 0xe8, 0x6, 0x1, 0x3c, 0x35, 0xa6, 0x1b, 0x1e, 0x48, 0x38, 0x1f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x68, 0x85, 0x6a, 0x6e, 0xdd, 0xc4, 0xec, 0x29, 0xcd, 0x5b, 0xe2, 0x67, 0xb6, 0x44, 0x83, 0xb4, 0x8c, 0x3b, 0x41, 0x96, 0x47, 0x7d, 0xa6, 0x2a, 0xbd, 0xe5, 0xfc, 0x17, 0x3b, 0x27, 0xe7, 0x71}),
-				new(big.Int).SetBytes([]byte{0x77, 0xa3, 0x3d, 0xf1, 0x4f, 0x79, 0xa1, 0xfb, 0x13, 0xb6, 0xfd, 0x49, 0xc1, 0x9f, 0x7b, 0x4a, 0x33, 0x1d, 0x22, 0xf2, 0x93, 0xb0, 0x73, 0x3a, 0x61, 0x18, 0xd6, 0x2a, 0x7, 0xbb, 0xda, 0xb6}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xfd, 0x76, 0xcc, 0x9c, 0x34, 0xc4, 0x0, 0xdb, 0xff, 0x5e, 0x31, 0x4c, 0x55, 0xb8, 0xc0, 0x70, 0xcb, 0xa0, 0x57, 0x95, 0x9, 0x43, 0x92, 0xf7, 0x2, 0x85, 0x2e, 0x91, 0xf1, 0x47, 0x36, 0x78}),
-				new(big.Int).SetBytes([]byte{0xf2, 0x4, 0x65, 0x43, 0x78, 0x71, 0x43, 0xda, 0xe0, 0xfe, 0xe0, 0xa7, 0x3d, 0x3, 0x2c, 0xd6, 0x60, 0x62, 0x8e, 0xee, 0x14, 0x1, 0xc8, 0x43, 0x7e, 0x16, 0x4e, 0xea, 0x8d, 0x14, 0x4f, 0x4f}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x4c, 0xd5, 0xd8, 0xcf, 0xb9, 0xe9, 0xc7, 0xb8, 0x80, 0xe4, 0x4d, 0x99, 0xbf, 0x1a, 0xde, 0xd2, 0xe8, 0xb9, 0xc6, 0xf, 0xed, 0x7b, 0x83, 0x66, 0xb5, 0xf0, 0x5, 0x32, 0xce, 0xe6, 0x6b, 0x24}),
-				new(big.Int).SetBytes([]byte{0x57, 0x6e, 0xae, 0x82, 0xde, 0xb9, 0x4b, 0x55, 0x9f, 0xe4, 0xe8, 0xc3, 0x9c, 0xd6, 0xd1, 0x3, 0xca, 0x61, 0xe4, 0x5e, 0x12, 0xd2, 0xfa, 0xed, 0x22, 0x1e, 0xfd, 0xba, 0xf4, 0x9, 0x32, 0xfd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x85, 0xa2, 0xab, 0xa3, 0x31, 0x23, 0xb4, 0x2, 0x19, 0xe6, 0xce, 0x82, 0x74, 0xb4, 0xea, 0x21, 0x2c, 0x69, 0x1e, 0x44, 0xf1, 0x97, 0x54, 0x6e, 0xdf, 0x0, 0xb7, 0x14, 0x8d, 0x7a, 0x21, 0x93}),
-				new(big.Int).SetBytes([]byte{0x9c, 0x12, 0x98, 0x57, 0xeb, 0x2b, 0x5, 0x16, 0xd8, 0x2c, 0x60, 0x82, 0xd1, 0xcc, 0x2, 0x9f, 0xe1, 0xc6, 0xda, 0xdf, 0x9c, 0x3b, 0x12, 0x42, 0x7f, 0xc, 0x83, 0xd4, 0xb0, 0xcd, 0xcf, 0x3a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc3, 0x52, 0xd5, 0xf, 0x88, 0x28, 0xf7, 0xb2, 0x5a, 0xc6, 0x4, 0x72, 0x70, 0xea, 0x21, 0x7, 0xd3, 0xa0, 0xf2, 0x56, 0x85, 0x36, 0xc4, 0xd5, 0x6a, 0xe3, 0x3, 0xce, 0xed, 0xd7, 0xe5, 0x77}),
-				new(big.Int).SetBytes([]byte{0xbe, 0x77, 0x28, 0x38, 0xba, 0x76, 0xb9, 0xb9, 0x6a, 0xbd, 0x53, 0x14, 0x5b, 0xce, 0x79, 0xfb, 0x0, 0xa8, 0xab, 0xb2, 0xb6, 0x12, 0x17, 0x6, 0x3c, 0xa4, 0xff, 0x88, 0xf8, 0xd9, 0xb5, 0x70}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xd2, 0x5d, 0xdb, 0xfc, 0x73, 0xcf, 0xfe, 0x74, 0x44, 0xb, 0x6d, 0x6c, 0x57, 0x83, 0x65, 0xf, 0x35, 0xae, 0x8d, 0xd0, 0x19, 0xe0, 0x3b, 0x5, 0x35, 0xb0, 0x30, 0x5b, 0x37, 0x75, 0x68, 0xb0}),
-				new(big.Int).SetBytes([]byte{0x7a, 0x3a, 0xff, 0x75, 0x93, 0xf8, 0x48, 0x93, 0x8b, 0xea, 0xef, 0x74, 0xcd, 0xe7, 0xd9, 0x2b, 0x83, 0x22, 0x73, 0x88, 0x1e, 0x3c, 0x3f, 0x0, 0x60, 0xa1, 0x39, 0x26, 0xdf, 0x39, 0x92, 0x9c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x6, 0x91, 0x4e, 0x86, 0xb0, 0xa4, 0x3a, 0x9f, 0xe0, 0x86, 0xde, 0x71, 0xab, 0x63, 0x5e, 0x9e, 0xa8, 0x50, 0x51, 0xf5, 0x6f, 0xf5, 0xfe, 0x71, 0xa3, 0x35, 0x63, 0xe8, 0x1e, 0x71, 0x25, 0x36}),
-				new(big.Int).SetBytes([]byte{0x2b, 0x7e, 0x79, 0x28, 0x52, 0xd9, 0xbd, 0x5, 0x27, 0xd8, 0xac, 0xea, 0xe5, 0xac, 0x28, 0xc0, 0x89, 0x6c, 0x22, 0x6b, 0xa, 0xf7, 0xa9, 0xda, 0x2a, 0x24, 0x77, 0x13, 0x80, 0x11, 0x28, 0xf1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x45, 0x81, 0x35, 0x31, 0x43, 0xe9, 0x4a, 0x72, 0x55, 0xf0, 0x7e, 0x9a, 0x97, 0xaa, 0xc8, 0x5, 0x64, 0x1a, 0xb5, 0xf7, 0xfa, 0xf, 0xfa, 0x38, 0x55, 0xfe, 0x51, 0x18, 0xa7, 0x1d, 0x7c, 0x13}),
-				new(big.Int).SetBytes([]byte{0x9f, 0x58, 0x58, 0xec, 0x14, 0x97, 0x9a, 0xd8, 0xb5, 0x7f, 0x50, 0xf1, 0x77, 0xdd, 0x69, 0xc9, 0x70, 0x20, 0x6, 0x55, 0x42, 0xbd, 0x96, 0xb9, 0x96, 0x38, 0xbc, 0x55, 0x3a, 0xff, 0x63, 0xcf}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbc, 0x4a, 0x9d, 0xf5, 0xb7, 0x13, 0xfe, 0x2e, 0x9a, 0xef, 0x43, 0xb, 0xcc, 0x1d, 0xc9, 0x7a, 0xc, 0xd9, 0xcc, 0xed, 0xe2, 0xf2, 0x85, 0x88, 0xca, 0xda, 0x3a, 0xd, 0x2d, 0x83, 0xf3, 0x66}),
-				new(big.Int).SetBytes([]byte{0xd, 0x3a, 0x81, 0xca, 0x6e, 0x78, 0x5c, 0x6, 0x38, 0x39, 0x37, 0xad, 0xf4, 0xb7, 0x98, 0xca, 0xa6, 0xe8, 0xa9, 0xfb, 0xfa, 0x54, 0x7b, 0x16, 0xd7, 0x58, 0xd6, 0x66, 0x58, 0x1f, 0x33, 0xc1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xda, 0x43, 0x3d, 0x5e, 0x11, 0xce, 0xcc, 0xc0, 0xab, 0xc5, 0xc7, 0x62, 0x6c, 0xe7, 0xba, 0xb4, 0x2e, 0x89, 0xb2, 0x21, 0xf7, 0x85, 0xc4, 0x9, 0x28, 0x2d, 0xe5, 0x45, 0xf3, 0xfc, 0xeb, 0x19}),
-				new(big.Int).SetBytes([]byte{0xe4, 0x98, 0xdb, 0xd3, 0x21, 0xa8, 0x10, 0x30, 0x1d, 0xeb, 0xbd, 0xc4, 0xaf, 0x95, 0xe5, 0x21, 0x8e, 0x77, 0xfc, 0x2d, 0x92, 0x27, 0xb2, 0x77, 0x68, 0x4e, 0x71, 0x20, 0xa6, 0xf5, 0xcc, 0x64}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x39, 0xd7, 0x34, 0x9d, 0x93, 0x31, 0xb3, 0x78, 0xd3, 0xc7, 0x25, 0xdb, 0xab, 0x0, 0x15, 0x34, 0x72, 0x95, 0xf1, 0x8e, 0xda, 0x14, 0x6a, 0x66, 0xb0, 0x6a, 0x2e, 0x32, 0xf7, 0x12, 0xbe, 0x3c}),
-				new(big.Int).SetBytes([]byte{0x8f, 0x92, 0x9b, 0x4f, 0x56, 0xef, 0x3b, 0xf7, 0xfb, 0x12, 0x75, 0x54, 0xc6, 0x68, 0x12, 0xe8, 0xef, 0x5e, 0xf7, 0xa5, 0xec, 0xa4, 0x16, 0x44, 0xcc, 0x30, 0x19, 0xf4, 0x1c, 0x6f, 0xf6, 0x5c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3, 0x1e, 0x8e, 0x1e, 0xe9, 0xe8, 0xc7, 0xec, 0x1c, 0x1c, 0x11, 0x69, 0x81, 0xc1, 0x6e, 0xfd, 0xbc, 0xc4, 0x83, 0x8a, 0x72, 0x20, 0x7e, 0x6, 0x54, 0xde, 0x27, 0x5c, 0x5a, 0xcf, 0x69, 0x2a}),
-				new(big.Int).SetBytes([]byte{0xad, 0x7e, 0x7f, 0x5b, 0x46, 0x5b, 0x35, 0x3d, 0xd9, 0xd0, 0x97, 0x2, 0x90, 0xd6, 0x74, 0x3b, 0x70, 0x64, 0x98, 0x27, 0xc5, 0xbf, 0x73, 0xb0, 0x9c, 0xc2, 0xa8, 0x4e, 0xb1, 0x6f, 0x66, 0x7a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xf0, 0xcb, 0xa6, 0x17, 0xf7, 0xdc, 0x1d, 0xd0, 0x9e, 0x51, 0xcd, 0x6c, 0x29, 0x95, 0xaf, 0x26, 0xc6, 0x99, 0x72, 0x39, 0x94, 0xf8, 0x2e, 0x3, 0x5a, 0xae, 0x4f, 0xbc, 0xdf, 0x77, 0xf2, 0x2b}),
-				new(big.Int).SetBytes([]byte{0x1a, 0x25, 0xab, 0x43, 0x13, 0xf9, 0xdf, 0x98, 0x9e, 0xf3, 0x26, 0xc3, 0x65, 0x16, 0xd0, 0x40, 0x4, 0xf1, 0x8c, 0x7e, 0x90, 0xa0, 0x95, 0x66, 0x1f, 0xc8, 0xe2, 0xc7, 0x59, 0x9, 0xa0, 0x3c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb4, 0x31, 0x9c, 0xc9, 0xf, 0x3e, 0xd, 0x3b, 0x31, 0x36, 0x26, 0xae, 0x53, 0x85, 0x79, 0x6b, 0xc4, 0x9b, 0x30, 0xc, 0x88, 0xba, 0x45, 0x53, 0xde, 0xc0, 0xaa, 0x4a, 0x77, 0x82, 0x93, 0x72}),
-				new(big.Int).SetBytes([]byte{0x47, 0x7, 0xd4, 0x49, 0x9a, 0x6f, 0x50, 0x2b, 0xff, 0xb7, 0xaf, 0xe0, 0x9c, 0x21, 0xc5, 0xa2, 0x70, 0x7b, 0x12, 0x15, 0xca, 0x3e, 0x11, 0xbb, 0x77, 0x29, 0xcc, 0xc0, 0xa, 0xdd, 0x54, 0x27}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x1d, 0x7a, 0xb9, 0xdb, 0x21, 0x54, 0xd3, 0x21, 0xd1, 0x70, 0xe8, 0xd8, 0x72, 0x68, 0xed, 0x8d, 0x6b, 0xfe, 0x23, 0x5b, 0xd1, 0xf4, 0x76, 0x58, 0xac, 0xdc, 0xd4, 0xc6, 0x50, 0x9c, 0x12}),
-				new(big.Int).SetBytes([]byte{0xa4, 0x7a, 0xab, 0x5b, 0x7f, 0xda, 0x3d, 0xa9, 0xeb, 0x5d, 0x24, 0x57, 0x3a, 0x65, 0x81, 0xe7, 0x20, 0x58, 0x28, 0x58, 0x6c, 0xe6, 0xea, 0xf7, 0x54, 0x64, 0x2a, 0x8e, 0x2e, 0xb4, 0x61, 0x2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa9, 0x87, 0x86, 0x7, 0xa8, 0x8d, 0x61, 0x15, 0x5d, 0x3e, 0x0, 0xd8, 0x62, 0x65, 0x7f, 0x73, 0xe9, 0xc9, 0xbf, 0x36, 0x3f, 0xc7, 0xa9, 0x15, 0x92, 0xbb, 0xd7, 0xff, 0x81, 0xf4, 0x88, 0xb6}),
-				new(big.Int).SetBytes([]byte{0xd1, 0x81, 0xa1, 0xab, 0xd5, 0x88, 0x95, 0xd6, 0x1c, 0x6, 0x3e, 0x7c, 0x82, 0x15, 0x7c, 0x22, 0x39, 0xd0, 0xf0, 0x19, 0x64, 0xad, 0x5c, 0x6d, 0x49, 0x5a, 0x7b, 0xbb, 0x3, 0x1d, 0xab, 0x1d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa7, 0x3, 0xf0, 0x54, 0x67, 0xab, 0x87, 0xe6, 0x47, 0x93, 0xb7, 0x3e, 0x69, 0x4c, 0x8a, 0x53, 0xf6, 0x99, 0x56, 0xa5, 0xa0, 0x63, 0xbc, 0xe4, 0x8d, 0xb3, 0xb4, 0xd6, 0x38, 0x4f, 0xe9, 0x55}),
-				new(big.Int).SetBytes([]byte{0xd5, 0x0, 0xf9, 0x35, 0xa2, 0xb0, 0x47, 0xad, 0xc0, 0xea, 0x79, 0x2d, 0x6b, 0x19, 0x19, 0x9b, 0x53, 0x9e, 0x16, 0x29, 0xc5, 0x97, 0x27, 0x7f, 0xa2, 0x6b, 0x2e, 0xfb, 0xd6, 0x13, 0x44, 0xbd}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x33, 0x19, 0x24, 0xc7, 0x50, 0xb3, 0x41, 0x7e, 0x48, 0xe2, 0x79, 0xff, 0x6e, 0xd7, 0xf3, 0xdc, 0xba, 0x2e, 0x12, 0x1e, 0x9f, 0x69, 0xf8, 0xfb, 0xe6, 0x40, 0x93, 0xb6, 0xc3, 0x8a, 0x8c, 0xc6}),
-				new(big.Int).SetBytes([]byte{0xca, 0x8f, 0x79, 0x41, 0x1b, 0x33, 0xf0, 0x18, 0x2b, 0xa5, 0xb6, 0x1, 0xbe, 0x2f, 0xfb, 0x31, 0x7f, 0xb9, 0x9e, 0x9a, 0xce, 0x9e, 0x9b, 0x54, 0xc4, 0xe8, 0xe0, 0xfb, 0x46, 0x58, 0x78, 0x10}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x73, 0xc6, 0xb3, 0xc5, 0xaa, 0xd5, 0x50, 0xd7, 0x46, 0xc6, 0xad, 0xd, 0x70, 0x53, 0xa0, 0x3f, 0x1b, 0x7e, 0x45, 0x63, 0xa2, 0xf3, 0x21, 0x1d, 0x34, 0x99, 0x65, 0x6c, 0x6, 0xea, 0xce, 0x58}),
-				new(big.Int).SetBytes([]byte{0x3a, 0x2f, 0xb4, 0xa4, 0x73, 0x3a, 0x4f, 0x74, 0x1b, 0xa9, 0x9, 0x91, 0x36, 0x6d, 0x91, 0xc6, 0xaa, 0x6a, 0x87, 0x8d, 0xbb, 0xb8, 0xd2, 0x91, 0x71, 0x70, 0xa9, 0x17, 0x2a, 0xf8, 0x65, 0x4e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xcd, 0xcb, 0x12, 0xd7, 0x11, 0x9d, 0x33, 0x7a, 0x9e, 0xcc, 0x32, 0x44, 0x7f, 0xd4, 0x37, 0x61, 0xd6, 0xfa, 0x56, 0x89, 0xdc, 0xd8, 0x3c, 0x3d, 0xf4, 0xbf, 0x11, 0x26, 0xcd, 0xcb, 0xcb, 0x9e}),
-				new(big.Int).SetBytes([]byte{0xe6, 0x99, 0xe2, 0xe1, 0x7b, 0xb9, 0xf1, 0x1f, 0xbf, 0xef, 0xbe, 0xa0, 0x96, 0xde, 0x99, 0x49, 0xa1, 0x99, 0xaf, 0x24, 0x35, 0x8d, 0xf3, 0x40, 0xa0, 0x8a, 0x58, 0x5a, 0xc9, 0x61, 0xcf, 0xd1}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0x27, 0xf3, 0xe7, 0x50, 0x6d, 0x47, 0xfd, 0xa1, 0xd0, 0xd, 0x3a, 0x1c, 0xe, 0xdd, 0xb8, 0xde, 0x71, 0xb3, 0xa9, 0xb9, 0x5a, 0xaf, 0x20, 0x54, 0xb3, 0x88, 0xbf, 0x0, 0x18, 0x1d, 0x5e}),
-				new(big.Int).SetBytes([]byte{0xd7, 0x8f, 0x9d, 0xd7, 0x1e, 0x44, 0x2a, 0x63, 0xb2, 0xd6, 0x96, 0x1, 0x38, 0xa1, 0xf7, 0xd6, 0x8e, 0xf6, 0xb0, 0x5, 0x49, 0xcb, 0xe2, 0xc3, 0x92, 0x79, 0x0, 0xa6, 0x36, 0x9f, 0x88, 0x6d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc6, 0xed, 0x5e, 0x63, 0x28, 0xec, 0x31, 0xb1, 0x37, 0x4, 0x11, 0x8, 0x42, 0xd4, 0xac, 0x23, 0xfb, 0xb8, 0x83, 0xbb, 0x34, 0x9d, 0x88, 0xdb, 0x1d, 0x74, 0x7c, 0xf7, 0x32, 0x5f, 0xe9, 0xcc}),
-				new(big.Int).SetBytes([]byte{0xde, 0x47, 0x40, 0x5b, 0x49, 0xd7, 0xf8, 0x30, 0x29, 0x32, 0xee, 0x12, 0x5c, 0x7f, 0x7b, 0x2a, 0x84, 0x4f, 0x97, 0x2b, 0x60, 0xf2, 0x2c, 0xf1, 0xc, 0xb, 0xce, 0x1c, 0x4a, 0x8f, 0xd9, 0x4b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xbb, 0x88, 0xfa, 0xbe, 0xe, 0xdf, 0xd2, 0x33, 0xfa, 0x25, 0xf4, 0xa, 0x6f, 0x6, 0xde, 0x53, 0xa0, 0x47, 0x95, 0x8f, 0x28, 0xa4, 0x84, 0x7f, 0x34, 0xe2, 0x8d, 0x9f, 0xb7, 0x82, 0x8b, 0x16}),
-				new(big.Int).SetBytes([]byte{0xb7, 0x36, 0x76, 0xb3, 0x4, 0xf5, 0xe8, 0xdd, 0x34, 0x5d, 0xc5, 0x96, 0x1, 0x15, 0x91, 0x63, 0xec, 0x4a, 0x81, 0x74, 0xe3, 0xa1, 0xe9, 0x69, 0xc5, 0x14, 0x73, 0xa2, 0xa, 0xea, 0x5d, 0xf7}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8c, 0x28, 0xa9, 0x7b, 0xf8, 0x29, 0x8b, 0xc0, 0xd2, 0x3d, 0x8c, 0x74, 0x94, 0x52, 0xa3, 0x2e, 0x69, 0x4b, 0x65, 0xe3, 0xa, 0x94, 0x72, 0xa3, 0x95, 0x4a, 0xb3, 0xf, 0xe5, 0x32, 0x4c, 0xaa}),
-				new(big.Int).SetBytes([]byte{0x40, 0xa3, 0x4, 0x63, 0xa3, 0x30, 0x51, 0x93, 0x37, 0x8f, 0xed, 0xf3, 0x1f, 0x7c, 0xc0, 0xeb, 0x7a, 0xe7, 0x84, 0xf0, 0x45, 0x1c, 0xb9, 0x45, 0x9e, 0x71, 0xdc, 0x73, 0xcb, 0xef, 0x94, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xab, 0x1a, 0xc1, 0x87, 0x2a, 0x38, 0xa2, 0xf1, 0x96, 0xbe, 0xd5, 0xa6, 0x4, 0x7f, 0xd, 0xa2, 0xc8, 0x13, 0xf, 0xe8, 0xde, 0x49, 0xfc, 0x4d, 0x5d, 0xfb, 0x20, 0x1f, 0x76, 0x11, 0xd8, 0xe2}),
-				new(big.Int).SetBytes([]byte{0x13, 0xf4, 0xa3, 0x7a, 0x32, 0x4d, 0x17, 0xa1, 0xe9, 0xaa, 0x5f, 0x39, 0xdb, 0x6a, 0x42, 0xb6, 0xf7, 0xef, 0x93, 0xd3, 0x3e, 0x1e, 0x54, 0x5f, 0x1, 0xa5, 0x81, 0xf3, 0xc4, 0x29, 0xd1, 0x5b}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x97, 0x29, 0x24, 0x70, 0x32, 0xc0, 0xdf, 0xcf, 0x45, 0xb4, 0x84, 0x1f, 0xcd, 0x72, 0xf6, 0xe9, 0xa2, 0x42, 0x26, 0x31, 0xfc, 0x34, 0x66, 0xcf, 0x86, 0x3e, 0x87, 0x15, 0x47, 0x54, 0xdd, 0x40}),
-				new(big.Int).SetBytes([]byte{0x91, 0xd1, 0xa2, 0x44, 0x26, 0x5f, 0xea, 0x1d, 0xcd, 0x15, 0xc7, 0x5d, 0xcb, 0xd4, 0xdf, 0x36, 0x90, 0xda, 0xe8, 0x52, 0x55, 0xac, 0xaf, 0x49, 0x38, 0x4b, 0x49, 0x2f, 0x2a, 0xa3, 0x61, 0x43}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x25, 0x64, 0xfe, 0x9b, 0x5b, 0xee, 0xf8, 0x2d, 0x37, 0x3, 0xa6, 0x7, 0x25, 0x3f, 0x31, 0xef, 0x8e, 0xa1, 0xb3, 0x65, 0x77, 0x2d, 0xf4, 0x34, 0x22, 0x6a, 0xee, 0x64, 0x26, 0x51, 0xb3, 0xfa}),
-				new(big.Int).SetBytes([]byte{0x8a, 0xd9, 0xf7, 0xa6, 0x6, 0x78, 0x38, 0x90, 0x95, 0xfa, 0x14, 0xae, 0x12, 0x3, 0x92, 0x5f, 0x14, 0xf3, 0x7d, 0xab, 0x6b, 0x79, 0x81, 0x6e, 0xdb, 0x82, 0xe6, 0xa3, 0x1, 0xe5, 0x12, 0x2d}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x89, 0x63, 0x7f, 0x97, 0x58, 0xa, 0x79, 0x6e, 0x5, 0x7, 0x91, 0xad, 0x5a, 0x2f, 0x27, 0xaf, 0x18, 0x3, 0x64, 0x5d, 0x95, 0xdf, 0x2, 0x1a, 0x3c, 0x2d, 0x82, 0xeb, 0x8c, 0x2c, 0xa7, 0xff}),
-				new(big.Int).SetBytes([]byte{0x2d, 0x1f, 0xe1, 0x24, 0x8c, 0x88, 0x84, 0x24, 0xd5, 0x7b, 0x9c, 0xf1, 0x54, 0x35, 0x74, 0x89, 0xf8, 0x7b, 0xc6, 0xa3, 0x8e, 0x42, 0xea, 0xb7, 0xbe, 0xd4, 0x15, 0xe1, 0x70, 0x49, 0x3e, 0x68}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0xef, 0xa4, 0xe2, 0x6a, 0x41, 0x79, 0xe1, 0x12, 0x86, 0xb, 0x88, 0xfc, 0x98, 0x65, 0x8a, 0x4b, 0xdb, 0xc5, 0x9c, 0x7a, 0xb6, 0xd4, 0xf8, 0x5, 0x7c, 0x35, 0x33, 0xc, 0x7a, 0x89, 0xee}),
-				new(big.Int).SetBytes([]byte{0x14, 0x5f, 0xa8, 0x1f, 0x8b, 0xb6, 0x24, 0xae, 0x9e, 0xfb, 0x2c, 0x32, 0xb1, 0x72, 0x94, 0xa2, 0x2a, 0xaa, 0xfa, 0xb8, 0x8e, 0x5e, 0x9a, 0xb, 0x4f, 0x48, 0x93, 0x29, 0xc1, 0x36, 0x6a, 0x2e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x30, 0x81, 0x38, 0xe7, 0x1b, 0xe2, 0x5e, 0x9, 0x2f, 0xdc, 0x9d, 0xa0, 0x3d, 0x53, 0x57, 0x42, 0x1b, 0xc7, 0x28, 0x3, 0x56, 0xa1, 0x38, 0x1a, 0x61, 0x86, 0xd6, 0x3a, 0xc, 0xa8, 0xdd, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x28, 0xd1, 0xe2, 0xd2, 0x88, 0x28, 0xfc, 0x92, 0x5e, 0x39, 0xec, 0x45, 0xd1, 0x40, 0x8e, 0x18, 0xc8, 0x16, 0x56, 0x46, 0x43, 0x4a, 0xd9, 0x15, 0xe4, 0x15, 0xf2, 0x47, 0x8a, 0x92, 0xc7, 0xf2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xff, 0x3d, 0x61, 0x36, 0xff, 0xac, 0x5b, 0xc, 0xbf, 0xc6, 0xc5, 0xc0, 0xc3, 0xd, 0xc0, 0x1a, 0x7e, 0xa3, 0xd5, 0x6c, 0x20, 0xbd, 0x31, 0x3, 0xb1, 0x78, 0xe3, 0xd3, 0xae, 0x18, 0x0, 0x68}),
-				new(big.Int).SetBytes([]byte{0x13, 0x32, 0x39, 0xbe, 0x84, 0xe4, 0x0, 0xe, 0x40, 0xd0, 0x37, 0x2c, 0xdd, 0x96, 0xad, 0xc1, 0x54, 0x76, 0x76, 0xf2, 0x40, 0x1, 0xf5, 0xe6, 0x70, 0xa6, 0xbb, 0x6e, 0x18, 0x8c, 0x60, 0x77}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x57, 0x5f, 0xc4, 0xe8, 0x2a, 0x6d, 0xeb, 0x65, 0xd1, 0xe5, 0x75, 0xc, 0x85, 0xb6, 0x86, 0x2f, 0x6e, 0xc0, 0x9, 0x28, 0x19, 0x92, 0xe2, 0x6, 0xc0, 0xdc, 0xc5, 0x68, 0x86, 0x6a, 0x3f, 0xb1}),
-				new(big.Int).SetBytes([]byte{0x6f, 0x6e, 0xdb, 0x90, 0x42, 0xa6, 0xfc, 0xa2, 0xd6, 0x71, 0xdb, 0xc2, 0x97, 0x8e, 0x87, 0xda, 0xed, 0x33, 0xb5, 0x73, 0xc6, 0xa3, 0xaf, 0x2f, 0x9, 0xb8, 0xe9, 0xa, 0x90, 0x26, 0x55, 0xad}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3f, 0xa9, 0x15, 0x48, 0xb, 0xab, 0x8a, 0xd2, 0x53, 0x1e, 0x34, 0x2b, 0xa4, 0x35, 0x55, 0xe7, 0xdf, 0x45, 0xe1, 0x75, 0x83, 0x99, 0x8a, 0xd4, 0x95, 0x4e, 0x7f, 0xdc, 0xbd, 0x21, 0x25, 0xa}),
-				new(big.Int).SetBytes([]byte{0xbf, 0x9b, 0x66, 0x90, 0x54, 0x99, 0xb0, 0x30, 0xcf, 0x5b, 0xdf, 0x96, 0x3, 0xbb, 0x12, 0x7c, 0x2e, 0x45, 0xe5, 0x5a, 0x3, 0x2b, 0x5f, 0xb7, 0x91, 0xc8, 0x6c, 0x2, 0xfa, 0xa7, 0x67, 0x31}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa5, 0xec, 0x90, 0x36, 0xb6, 0x4e, 0xab, 0x7a, 0x22, 0x7f, 0x26, 0xf8, 0x1e, 0xea, 0x2a, 0x8f, 0xda, 0x25, 0x3b, 0xbb, 0xce, 0x20, 0x10, 0x29, 0x21, 0xb6, 0xa8, 0xa4, 0x79, 0x1, 0x17, 0xdf}),
-				new(big.Int).SetBytes([]byte{0xb7, 0x9d, 0xc6, 0x62, 0x5e, 0xc1, 0x40, 0x40, 0xc, 0x59, 0x79, 0x83, 0xad, 0x1f, 0xa0, 0xf7, 0x1c, 0x84, 0x61, 0xf0, 0x56, 0x14, 0xa3, 0x63, 0xd8, 0x12, 0x81, 0x33, 0xf8, 0x64, 0x62, 0xfe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x52, 0x4, 0x5b, 0xcc, 0x58, 0xe0, 0x71, 0x24, 0xa3, 0x75, 0xea, 0x0, 0x4b, 0x35, 0x8, 0xac, 0x80, 0xe6, 0x25, 0xda, 0x21, 0x6, 0xc7, 0x4f, 0x5c, 0xb0, 0x23, 0x49, 0x8d, 0xe0, 0x54, 0x5f}),
-				new(big.Int).SetBytes([]byte{0x1b, 0x3f, 0x31, 0xfc, 0xeb, 0xe3, 0x12, 0x3a, 0xd4, 0x30, 0xed, 0x9c, 0x20, 0xce, 0x31, 0x2b, 0x79, 0x3b, 0x9d, 0xf, 0x2a, 0x8f, 0xda, 0xf2, 0x36, 0xea, 0x34, 0xc0, 0x50, 0x6c, 0x3d, 0x91}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xa1, 0x53, 0xdf, 0xe9, 0x13, 0x31, 0xb, 0x9, 0x49, 0xde, 0x79, 0x76, 0x14, 0x63, 0x49, 0xb9, 0x5a, 0x39, 0x8c, 0xb0, 0xde, 0x10, 0x47, 0x29, 0xb, 0xf, 0x97, 0x5c, 0x17, 0x2a, 0xd7, 0x12}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x94, 0xd8, 0x41, 0x3f, 0xb0, 0x5b, 0x2f, 0xc4, 0x83, 0x18, 0xd5, 0xf1, 0xf1, 0xb8, 0x9a, 0xbd, 0x5, 0x3f, 0x28, 0x5a, 0xf0, 0xb3, 0x29, 0xc8, 0xa5, 0xf6, 0x53, 0x8d, 0x48, 0xfd, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x58, 0xb5, 0x43, 0x6e, 0xbe, 0x47, 0x2f, 0xdb, 0x80, 0x16, 0x2c, 0x8d, 0x23, 0x76, 0x3, 0x63, 0x5d, 0xe4, 0x7b, 0x95, 0xe5, 0xe5, 0xb3, 0x8a, 0x89, 0xf1, 0x3d, 0xc, 0x32, 0x20, 0x47, 0x9f}),
-				new(big.Int).SetBytes([]byte{0x85, 0xd7, 0xb6, 0xb0, 0x55, 0xcd, 0x67, 0x2c, 0x4e, 0x29, 0x13, 0xa2, 0x52, 0xf6, 0xde, 0x83, 0x96, 0xaf, 0x27, 0xc4, 0x82, 0x9b, 0xb3, 0x41, 0x44, 0xa7, 0x37, 0x5e, 0x56, 0x7d, 0xb1, 0x7a}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x9a, 0x54, 0x1a, 0xc6, 0xaf, 0x79, 0x46, 0x15, 0x93, 0x5c, 0x34, 0xd0, 0x88, 0xed, 0xc8, 0x24, 0xc4, 0x43, 0x3a, 0x83, 0xbd, 0xb5, 0xa7, 0x81, 0x3, 0xc, 0x37, 0x1, 0x11, 0xcf, 0x5b, 0x3a}),
-				new(big.Int).SetBytes([]byte{0xb6, 0x61, 0x48, 0xc1, 0xcb, 0x10, 0x6a, 0xb7, 0xca, 0xfe, 0x1a, 0xf3, 0x68, 0x8f, 0x47, 0x5f, 0x55, 0x48, 0xfe, 0xe2, 0x52, 0x1e, 0xa5, 0x2d, 0x63, 0xf5, 0x57, 0x5f, 0x36, 0xa4, 0x4a, 0xe4}),
-			},
-		},
-		{
-			{
-				new(big.Int).SetBytes([]byte{}),
-				new(big.Int).SetBytes([]byte{}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x8, 0xea, 0x96, 0x66, 0x13, 0x95, 0x27, 0xa8, 0xc1, 0xdd, 0x94, 0xce, 0x4f, 0x7, 0x1f, 0xd2, 0x3c, 0x8b, 0x35, 0xc, 0x5a, 0x4b, 0xb3, 0x37, 0x48, 0xc4, 0xba, 0x11, 0x1f, 0xac, 0xca, 0xe0}),
-				new(big.Int).SetBytes([]byte{0x62, 0xe, 0xfa, 0xbb, 0xc8, 0xee, 0x27, 0x82, 0xe2, 0x4e, 0x7c, 0xc, 0xfb, 0x95, 0xc5, 0xd7, 0x35, 0xb7, 0x83, 0xbe, 0x9c, 0xf0, 0xf8, 0xe9, 0x55, 0xaf, 0x34, 0xa3, 0xe, 0x62, 0xb9, 0x45}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xc2, 0x5f, 0x63, 0x71, 0x76, 0x22, 0xc, 0xd9, 0xf3, 0xa6, 0x6d, 0xf3, 0x15, 0x55, 0x9d, 0x82, 0x63, 0xcf, 0x2a, 0x23, 0xa4, 0xab, 0x5a, 0xb9, 0xa2, 0x93, 0x13, 0x1d, 0xa1, 0x90, 0xb6, 0x32}),
-				new(big.Int).SetBytes([]byte{0x53, 0x15, 0x4f, 0xed, 0xe9, 0x4d, 0x28, 0x73, 0x98, 0x90, 0x49, 0x90, 0x38, 0x9, 0xd7, 0x98, 0xa, 0x9f, 0x4, 0xff, 0x9e, 0x2, 0x7a, 0x1d, 0x6e, 0xeb, 0xf3, 0xd6, 0xfc, 0x95, 0x90, 0xcf}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x38, 0x3b, 0x24, 0xfb, 0xea, 0x14, 0x25, 0x3a, 0xc3, 0x7b, 0xd, 0x42, 0x12, 0x63, 0xb7, 0x16, 0xa3, 0x41, 0x92, 0x51, 0x6e, 0xa0, 0x83, 0x70, 0x21, 0xa4, 0xb, 0x59, 0x66, 0xa0, 0x6f, 0x5e}),
-				new(big.Int).SetBytes([]byte{0x54, 0xcf, 0x70, 0x6a, 0xc4, 0xed, 0xba, 0x20, 0x44, 0xcf, 0x56, 0x6d, 0x54, 0xea, 0x5a, 0x19, 0xe8, 0xf6, 0xae, 0x74, 0xbb, 0x8c, 0x2b, 0x4, 0x8, 0x9f, 0x47, 0x86, 0xd3, 0xc6, 0xe7, 0x72}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x2a, 0x9e, 0x8d, 0xfe, 0x3c, 0xce, 0x6b, 0xab, 0x3e, 0x82, 0xd8, 0x2a, 0x56, 0x88, 0x54, 0x4c, 0xc, 0x7b, 0x55, 0xdc, 0x31, 0x97, 0x8b, 0x4d, 0xe2, 0xcc, 0xb3, 0xb7, 0xd4, 0x66, 0xd5, 0x61}),
-				new(big.Int).SetBytes([]byte{0x1, 0xdf, 0xed, 0xa5, 0xc1, 0x6e, 0x65, 0x1f, 0xba, 0xc7, 0xb5, 0xad, 0x60, 0x8b, 0x96, 0xcf, 0x5e, 0x1, 0xea, 0xec, 0x17, 0xa0, 0x21, 0x82, 0xf9, 0x6c, 0xcf, 0x52, 0x52, 0xe7, 0x63, 0x73}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xe6, 0x84, 0x32, 0xd0, 0x3e, 0x2, 0xed, 0x6d, 0x78, 0x9e, 0x59, 0xc6, 0xb6, 0xd, 0x79, 0xc, 0x2b, 0xd, 0x1c, 0xe3, 0x36, 0x83, 0x81, 0x95, 0xc7, 0x97, 0x5c, 0x1d, 0x46, 0x38, 0xa1, 0x36}),
-				new(big.Int).SetBytes([]byte{0xca, 0x5b, 0xe4, 0x13, 0x98, 0xe3, 0x5a, 0x66, 0x24, 0xd2, 0xa7, 0x30, 0x3a, 0x1, 0xe6, 0x47, 0x2e, 0x9, 0xe3, 0xeb, 0xdb, 0x35, 0x73, 0x36, 0xaa, 0xfd, 0x18, 0x10, 0x8c, 0x6c, 0x25, 0x84}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x95, 0xe6, 0x2d, 0x42, 0x92, 0xe4, 0x62, 0x18, 0xbd, 0x49, 0xe4, 0xc9, 0x92, 0xff, 0x99, 0x80, 0x68, 0x11, 0x4a, 0x10, 0x5c, 0x1d, 0xc6, 0xc0, 0x21, 0x39, 0xb4, 0x8, 0xdb, 0xcf, 0x2d, 0xce}),
-				new(big.Int).SetBytes([]byte{0x6, 0xb6, 0x81, 0x84, 0x29, 0x6c, 0x28, 0x75, 0x18, 0x3a, 0x26, 0xe2, 0xb4, 0x28, 0xd5, 0x2e, 0x5e, 0x7b, 0x30, 0x60, 0x37, 0x4, 0xd2, 0xe, 0x62, 0xbd, 0xdf, 0xdf, 0x58, 0xc8, 0x65, 0x94}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x39, 0x5d, 0xd5, 0x59, 0xe2, 0xfe, 0x5c, 0x2a, 0xe, 0xef, 0xbd, 0xec, 0xe7, 0x30, 0x6e, 0x7f, 0xb4, 0x72, 0x98, 0x5f, 0x39, 0x1b, 0xd6, 0x80, 0x7c, 0xe8, 0x7c, 0x44, 0x16, 0xe8, 0xc1, 0xc}),
-				new(big.Int).SetBytes([]byte{0xfd, 0x62, 0xdc, 0xd4, 0xb4, 0x59, 0x2a, 0xc5, 0xd0, 0x41, 0x3e, 0x87, 0xaf, 0xfa, 0x4e, 0x27, 0x4c, 0x90, 0x9c, 0x4, 0xdc, 0x66, 0x92, 0x2c, 0xbc, 0x3d, 0x6d, 0x93, 0x5, 0xfe, 0x63, 0x8e}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb2, 0x37, 0x90, 0xa4, 0x2b, 0xe6, 0x3e, 0x1b, 0x25, 0x1a, 0xd6, 0xc9, 0x4f, 0xde, 0xf0, 0x72, 0x71, 0xec, 0xa, 0xad, 0xa3, 0x1d, 0xb6, 0xc3, 0xe8, 0xbd, 0x32, 0x4, 0x3f, 0x8b, 0xe3, 0x84}),
-				new(big.Int).SetBytes([]byte{0xfc, 0x6b, 0x69, 0x49, 0x19, 0xd5, 0x5e, 0xdb, 0xe8, 0xd5, 0xf, 0x88, 0xaa, 0x81, 0xf9, 0x45, 0x17, 0xf0, 0x4, 0xf4, 0x14, 0x9e, 0xcb, 0x58, 0xd1, 0xa, 0x47, 0x3d, 0xeb, 0x19, 0x88, 0xe}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x7a, 0x51, 0x4a, 0xdc, 0x35, 0x52, 0x5d, 0xcb, 0x9f, 0x6e, 0xed, 0x5b, 0xee, 0xe2, 0x64, 0x80, 0x8, 0x58, 0xfc, 0x75, 0x6, 0xf9, 0x3c, 0x8f, 0x51, 0x66, 0x4d, 0xb2, 0xd, 0x6c, 0x14, 0xef}),
-				new(big.Int).SetBytes([]byte{0x56, 0xed, 0xd1, 0xfe, 0xd1, 0x52, 0xe4, 0xd8, 0xe8, 0x97, 0xf0, 0xb9, 0x4c, 0x8f, 0x5c, 0x37, 0x29, 0xbe, 0xae, 0xf7, 0x2a, 0xa6, 0xe2, 0x4f, 0x7a, 0xd3, 0xe1, 0x87, 0xb, 0x3f, 0xbd, 0x13}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xb2, 0x11, 0x3b, 0xcb, 0x7b, 0x4a, 0xef, 0xed, 0x1e, 0xe2, 0x15, 0xaa, 0x8a, 0xe6, 0x81, 0x81, 0x27, 0xed, 0x70, 0x91, 0xc8, 0xd5, 0x9c, 0x2d, 0xff, 0x9f, 0x5, 0x3d, 0x9, 0x7e, 0xc9, 0x4b}),
-				new(big.Int).SetBytes([]byte{0x93, 0xbe, 0x9e, 0x73, 0x99, 0xe8, 0xee, 0x73, 0x10, 0x5a, 0xb, 0x53, 0x45, 0x4c, 0xb4, 0xeb, 0x9, 0x43, 0x22, 0xb, 0x23, 0x57, 0xd0, 0xfd, 0xbd, 0x5f, 0xbf, 0xfc, 0x68, 0x78, 0x31, 0xad}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x1e, 0xe1, 0xfd, 0x58, 0x43, 0x25, 0xd9, 0xd, 0x8e, 0xa8, 0xef, 0x3b, 0xf4, 0x90, 0x8f, 0x23, 0x5a, 0x99, 0x10, 0x77, 0x54, 0x40, 0x21, 0x52, 0x4a, 0x94, 0x63, 0xd0, 0x4f, 0x6d, 0x65, 0xeb}),
-				new(big.Int).SetBytes([]byte{0xbb, 0x69, 0x28, 0x91, 0xbd, 0xd6, 0xc7, 0x3c, 0x1c, 0xfa, 0xb9, 0xfc, 0x4f, 0x42, 0xfc, 0xfa, 0xca, 0x32, 0xf4, 0xe9, 0x86, 0x35, 0x82, 0xb1, 0x1e, 0x3, 0x6b, 0x92, 0x5d, 0x22, 0x94, 0x1c}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x71, 0xe9, 0x35, 0xc8, 0xe1, 0xf5, 0x4f, 0x25, 0xa6, 0x42, 0x42, 0x74, 0xab, 0x7, 0xe7, 0x89, 0x18, 0x73, 0xc3, 0xb1, 0xa2, 0x7a, 0x6c, 0x40, 0xb8, 0x5, 0x26, 0x45, 0x97, 0xa6, 0x25, 0x7f}),
-				new(big.Int).SetBytes([]byte{0x78, 0xd9, 0x3e, 0x59, 0xf4, 0x7c, 0x22, 0x51, 0x3d, 0xed, 0x86, 0xba, 0x47, 0xae, 0x2a, 0x52, 0xef, 0x25, 0x23, 0x54, 0xc, 0xf7, 0xf, 0x7a, 0x5b, 0x21, 0x74, 0x61, 0xd1, 0xb1, 0xe5, 0x82}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x15, 0x51, 0x56, 0x34, 0xd3, 0x8f, 0xaa, 0xa8, 0xbc, 0x5, 0x8f, 0xd0, 0xd8, 0x83, 0x35, 0x7d, 0x61, 0x7e, 0x8b, 0x6c, 0x8d, 0xca, 0x20, 0x19, 0x1f, 0x19, 0x50, 0xef, 0xe, 0x36, 0xcb, 0x44}),
-				new(big.Int).SetBytes([]byte{0xab, 0x4f, 0xff, 0xc7, 0x55, 0x57, 0x52, 0x15, 0xe3, 0x70, 0xe3, 0xf0, 0xb4, 0x63, 0x20, 0x5d, 0xa8, 0xc3, 0x71, 0x75, 0xf0, 0x20, 0xc1, 0xe5, 0x32, 0x9a, 0xf4, 0x65, 0x1f, 0x49, 0x5a, 0x68}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0xab, 0xc4, 0x51, 0xca, 0x4e, 0xe7, 0x95, 0xca, 0xc5, 0x2f, 0x8b, 0xe7, 0x9e, 0xe2, 0xc4, 0x61, 0x39, 0xe0, 0x15, 0x76, 0x2b, 0xb1, 0x3b, 0xac, 0xbf, 0x8, 0x47, 0x24, 0x79, 0xca, 0x95, 0xe}),
-				new(big.Int).SetBytes([]byte{0xae, 0x27, 0x18, 0xbe, 0xfe, 0x82, 0xa, 0x59, 0xdb, 0x1d, 0xfb, 0x80, 0xc2, 0xc9, 0x14, 0x56, 0x52, 0x68, 0x40, 0x0, 0xce, 0xa5, 0x53, 0xec, 0x7, 0xce, 0x7d, 0x8c, 0x9a, 0x94, 0x90, 0xd2}),
-			},
-			{
-				new(big.Int).SetBytes([]byte{0x3b, 0xc6, 0xbc, 0x64, 0x46, 0xbf, 0x52, 0x1, 0x36, 0x35, 0x8e, 0xb0, 0x95, 0x8d, 0xc4, 0xaa, 0x9e, 0x73, 0x31, 0x64, 0xdd, 0x2d, 0x62, 0xe1, 0x51, 0xf9, 0x46, 0x10, 0x74, 0x27, 0xba, 0xcc}),
-				new(big.Int).SetBytes([]byte{0x8e, 0x30, 0x5c, 0xc0, 0x71, 0x76, 0xc3, 0x5, 0xcd, 0xb6, 0x2e, 0xe2, 0x26, 0xd6, 0xc0, 0x2b, 0xd7, 0x1b, 0x75, 0xa5, 0x22, 0x8b, 0xeb, 0x47, 0x14, 0xc3, 0x3f, 0xd5, 0xea, 0xd6, 0xfd, 0xa6}),
-			},
-		},
-	}
-}
+import _ "embed"
+
+//go:embed precomputed_table.bin
+var basePointTableData string
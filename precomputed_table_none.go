@@ -0,0 +1,9 @@
+//go:build ekliptic_nobasetable
+// +build ekliptic_nobasetable
+
+package ekliptic
+
+// The ekliptic_nobasetable build tag drops the precomputed base point table from the
+// build, for size-constrained binaries. MultiplyBasePoint falls back to the Montgomery
+// ladder instead.
+const basePointTableData = ""
@@ -0,0 +1,52 @@
+package ekliptic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// rfc6979Nonce deterministically derives a nonce k in the range [1, Secp256k1_CurveOrder)
+// from the private key d and message hash h1, using HMAC-SHA256 as described in RFC 6979.
+//
+// https://www.rfc-editor.org/rfc/rfc6979#section-3.2
+func rfc6979Nonce(d *big.Int, h1 []byte) *big.Int {
+	x := d.FillBytes(make([]byte, 32))
+
+	// bits2octets(h1) = int2octets(bits2int(h1) mod N)
+	h := new(big.Int).SetBytes(h1)
+	if len(h1) > 32 {
+		h.Rsh(h, uint(len(h1)-32)*8)
+	}
+	modScalar(h)
+	hOctets := h.FillBytes(make([]byte, 32))
+
+	v := make([]byte, 32)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, 32)
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+
+	k = mac(k, v, []byte{0x00}, x, hOctets)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, x, hOctets)
+	v = mac(k, v)
+
+	for {
+		v = mac(k, v)
+		nonce := new(big.Int).SetBytes(v)
+		if IsValidScalar(nonce) {
+			return nonce
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,45 @@
+package ekliptic
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestRFC6979Nonce(t *testing.T) {
+	// Widely used secp256k1 RFC 6979 test vectors, from the Trezor and python-ecdsa test suites.
+	vectors := []struct {
+		d       string
+		message string
+		k       string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"Satoshi Nakamoto",
+			"8f8a276c19f4149656b280621e358cce24f5f52542772691ee69063b74f15d15",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"38aa22d72376b4dbc472e06c3ba403ee0a394da63fc58d88686c611aba98d6b3",
+		},
+		{
+			"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+			"Satoshi Nakamoto",
+			"33a19b60e25fb6f4435af53a3d42d493644827367e6453928554f43e49aa6f90",
+		},
+		{
+			"f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181",
+			"Alan Turing",
+			"525a82b70e67874398067543fd84c83d30c175fdc45fdeee082fe13b1d7cfdf1",
+		},
+	}
+
+	for i, vector := range vectors {
+		h := sha256.Sum256([]byte(vector.message))
+		k := rfc6979Nonce(hexint(vector.d), h[:])
+
+		if expected := hexint(vector.k); !equal(k, expected) {
+			t.Errorf("unexpected RFC 6979 nonce for vector %d\nGot:    %.64x\nWanted: %.64x", i, k, expected)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package ekliptic
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrSignerNotInRing is returned by SignRing if the public key of the
+	// given private key is not a member of the ring.
+	ErrSignerNotInRing = errors.New("ekliptic: signer's public key is not in the ring")
+
+	// ErrInvalidRingEncoding is returned when parsing a serialized ring or
+	// ring signature which is malformed.
+	ErrInvalidRingEncoding = errors.New("ekliptic: invalid ring encoding")
+)
+
+const keyImageDST = "ekliptic/bLSAG/key-image"
+
+// RingSignature is a back-linkable spontaneous anonymous group (bLSAG) signature. It proves
+// the signer owns the private key of one of the public keys in a ring, without revealing
+// which one. Every signature also carries a key image, which is unique to the signer's
+// private key. Two signatures made with the same private key have the same key image, so
+// a verifier can detect double-signing, even across different rings.
+//
+// The construction follows "Zero to Monero" (Second Edition), section 3.4, with every
+// challenge bound to the message, the ring and the key image.
+//
+// https://www.getmonero.org/library/Zero-to-Monero-2-0-0.pdf
+type RingSignature struct {
+	// KeyImage is k * Hp(K), where k is the signer's private key and K is their public key.
+	KeyImage [2]*big.Int
+
+	// C is the first challenge c_0 of the ring.
+	C *big.Int
+
+	// R holds the response r_i for each member of the ring.
+	R []*big.Int
+}
+
+// KeyImage returns the key image of the given private key k:
+//
+//	K = k * G
+//	Hp(K) = HashToCurve(K)
+//	I = k * Hp(K)
+//
+// It returns the affine point I.
+func KeyImage(privateKey *big.Int) (x, y *big.Int) {
+	pubX, pubY := MultiplyBasePoint(privateKey)
+	hX, hY := hashPointToCurve(pubX, pubY)
+	return MultiplyAffine(hX, hY, privateKey, nil)
+}
+
+// SignRing signs a message with a bLSAG ring signature, using the given private key whose
+// public key must be a member of the ring. Nonces are generated using the given source of
+// randomness, which should be cryptographically secure. The ring's order is significant;
+// verifiers must use the same ring in the same order.
+//
+// With signer index π and key image I:
+//
+//	a = random scalar
+//	c_(π+1) = H(m, a * G, a * Hp(K_π))
+//	for i = π+1, π+2, ..., π-1 (mod n):
+//	  r_i = random scalar
+//	  c_(i+1) = H(m, r_i * G + c_i * K_i, r_i * Hp(K_i) + c_i * I)
+//	r_π = a - c_π * k_π
+//
+// The signature is (c_0, r_0, ..., r_(n-1)) along with the key image I.
+func SignRing(
+	random io.Reader,
+	message []byte,
+	ring [][2]*big.Int,
+	privateKey *big.Int,
+) (*RingSignature, error) {
+	if !IsValidScalar(privateKey) {
+		panic("SignRing: expected private key to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	pubX, pubY := MultiplyBasePoint(privateKey)
+	signerIndex := -1
+	for i, key := range ring {
+		if EqualAffine(key[0], key[1], pubX, pubY) {
+			signerIndex = i
+			break
+		}
+	}
+	if signerIndex < 0 {
+		return nil, ErrSignerNotInRing
+	}
+
+	n := len(ring)
+	hashedKeys := make([][2]*big.Int, n)
+	for i, key := range ring {
+		hashedKeys[i][0], hashedKeys[i][1] = hashPointToCurve(key[0], key[1])
+	}
+
+	imageX, imageY := MultiplyAffine(hashedKeys[signerIndex][0], hashedKeys[signerIndex][1], privateKey, nil)
+	t := newRingTranscript(message, ring, imageX, imageY)
+
+	a, err := RandomScalar(random)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges := make([]*big.Int, n)
+	responses := make([]*big.Int, n)
+
+	lX, lY := MultiplyBasePoint(a)
+	rX, rY := MultiplyAffine(hashedKeys[signerIndex][0], hashedKeys[signerIndex][1], a, nil)
+	challenges[(signerIndex+1)%n] = ringChallenge(t, lX, lY, rX, rY)
+
+	for j := 1; j < n; j++ {
+		i := (signerIndex + j) % n
+		if responses[i], err = RandomScalar(random); err != nil {
+			return nil, err
+		}
+
+		lX, lY = linearCombination(
+			Secp256k1_GeneratorX, Secp256k1_GeneratorY, responses[i],
+			ring[i][0], ring[i][1], challenges[i],
+		)
+		rX, rY = linearCombination(
+			hashedKeys[i][0], hashedKeys[i][1], responses[i],
+			imageX, imageY, challenges[i],
+		)
+		challenges[(i+1)%n] = ringChallenge(t, lX, lY, rX, rY)
+	}
+
+	// r_π = a - c_π * k_π
+	r := new(big.Int).Mul(challenges[signerIndex], privateKey)
+	r.Sub(a, r)
+	modScalar(r)
+	responses[signerIndex] = r
+
+	return &RingSignature{
+		KeyImage: [2]*big.Int{imageX, imageY},
+		C:        challenges[0],
+		R:        responses,
+	}, nil
+}
+
+// VerifyRing returns true if the given RingSignature is a valid signature on the
+// message by the owner of one of the public keys in the ring.
+//
+//	for i = 0, 1, ..., n-1:
+//	  c_(i+1) = H(m, r_i * G + c_i * K_i, r_i * Hp(K_i) + c_i * I)
+//	c_n == c_0
+func VerifyRing(sig *RingSignature, message []byte, ring [][2]*big.Int) bool {
+	if sig == nil || len(ring) == 0 || len(sig.R) != len(ring) || !isValidProofScalar(sig.C) {
+		return false
+	}
+	if !isValidPublicPoint(sig.KeyImage[0], sig.KeyImage[1]) {
+		return false
+	}
+	for i, key := range ring {
+		if !isValidPublicPoint(key[0], key[1]) || !isValidProofScalar(sig.R[i]) {
+			return false
+		}
+	}
+
+	t := newRingTranscript(message, ring, sig.KeyImage[0], sig.KeyImage[1])
+
+	c := sig.C
+	for i, key := range ring {
+		hX, hY := hashPointToCurve(key[0], key[1])
+
+		lX, lY := linearCombination(
+			Secp256k1_GeneratorX, Secp256k1_GeneratorY, sig.R[i],
+			key[0], key[1], c,
+		)
+		rX, rY := linearCombination(
+			hX, hY, sig.R[i],
+			sig.KeyImage[0], sig.KeyImage[1], c,
+		)
+		c = ringChallenge(t, lX, lY, rX, rY)
+	}
+
+	return equal(c, sig.C)
+}
+
+// IsLinked returns true if both signatures were made with the same private key,
+// i.e. if they share the same key image.
+func (sig *RingSignature) IsLinked(other *RingSignature) bool {
+	return EqualAffine(sig.KeyImage[0], sig.KeyImage[1], other.KeyImage[0], other.KeyImage[1])
+}
+
+// MarshalBinary serializes the signature as the compressed key image, followed by the
+// 32-byte challenge c_0, followed by each 32-byte response r_i.
+func (sig *RingSignature) MarshalBinary() ([]byte, error) {
+	buf := serializeCompressed(sig.KeyImage[0], sig.KeyImage[1])
+	buf = append(buf, serializeProofScalars(sig.C)...)
+	buf = append(buf, serializeProofScalars(sig.R...)...)
+	return buf, nil
+}
+
+// UnmarshalBinary parses a signature serialized by MarshalBinary.
+func (sig *RingSignature) UnmarshalBinary(data []byte) error {
+	if len(data) < 33+64 || (len(data)-33)%32 != 0 {
+		return ErrInvalidRingEncoding
+	}
+
+	imageX, imageY, err := parseCompressed(data[:33])
+	if err != nil {
+		return err
+	}
+
+	scalars := make([]*big.Int, (len(data)-33)/32)
+	for i := range scalars {
+		scalars[i] = new(big.Int).SetBytes(data[33+i*32 : 33+(i+1)*32])
+		if !isValidProofScalar(scalars[i]) {
+			return ErrInvalidRingEncoding
+		}
+	}
+
+	sig.KeyImage = [2]*big.Int{imageX, imageY}
+	sig.C = scalars[0]
+	sig.R = scalars[1:]
+	return nil
+}
+
+// MarshalRing serializes a ring of public keys as the concatenation
+// of their 33-byte compressed encodings.
+func MarshalRing(ring [][2]*big.Int) []byte {
+	buf := make([]byte, 0, len(ring)*33)
+	for _, key := range ring {
+		buf = append(buf, serializeCompressed(key[0], key[1])...)
+	}
+	return buf
+}
+
+// UnmarshalRing parses a ring of public keys serialized by MarshalRing.
+func UnmarshalRing(data []byte) ([][2]*big.Int, error) {
+	if len(data) == 0 || len(data)%33 != 0 {
+		return nil, ErrInvalidRingEncoding
+	}
+
+	ring := make([][2]*big.Int, len(data)/33)
+	for i := range ring {
+		x, y, err := parseCompressed(data[i*33 : (i+1)*33])
+		if err != nil {
+			return nil, err
+		}
+		ring[i] = [2]*big.Int{x, y}
+	}
+	return ring, nil
+}
+
+// hashPointToCurve hashes a public key to a curve point whose discrete log
+// is unknown, using the compressed encoding of the key.
+func hashPointToCurve(x, y *big.Int) (hX, hY *big.Int) {
+	return HashToCurve(serializeCompressed(x, y), []byte(keyImageDST))
+}
+
+// newRingTranscript returns a Transcript bound to the message, ring and key image,
+// from which every challenge in the ring is derived.
+func newRingTranscript(message []byte, ring [][2]*big.Int, imageX, imageY *big.Int) *Transcript {
+	t := NewTranscript("ekliptic/bLSAG")
+	t.AppendMessage("message", message)
+	t.AppendMessage("ring", MarshalRing(ring))
+	t.AppendPoint("key_image", imageX, imageY)
+	return t
+}
+
+func ringChallenge(t *Transcript, lX, lY, rX, rY *big.Int) *big.Int {
+	t = t.Clone()
+	t.AppendPoint("L", lX, lY)
+	t.AppendPoint("R", rX, rY)
+	return t.ChallengeScalar("c")
+}
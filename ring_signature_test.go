@@ -0,0 +1,145 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func randomRing(t *testing.T, size int) (ring [][2]*big.Int, privateKeys []*big.Int) {
+	ring = make([][2]*big.Int, size)
+	privateKeys = make([]*big.Int, size)
+	for i := range ring {
+		d, err := RandomScalar(rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate private key: %s", err)
+		}
+		privateKeys[i] = d
+		ring[i][0], ring[i][1] = MultiplyBasePoint(d)
+	}
+	return
+}
+
+func TestRingSignature(t *testing.T) {
+	message := []byte("vote for proposal #7")
+
+	for _, size := range []int{1, 2, 3, 8} {
+		ring, privateKeys := randomRing(t, size)
+
+		for _, signerIndex := range []int{0, size - 1, size / 2} {
+			sig, err := SignRing(rand.Reader, message, ring, privateKeys[signerIndex])
+			if err != nil {
+				t.Errorf("failed to sign with ring of size %d: %s", size, err)
+				continue
+			}
+
+			if !VerifyRing(sig, message, ring) {
+				t.Errorf("failed to verify ring signature for ring size %d, signer %d", size, signerIndex)
+				continue
+			}
+
+			imageX, imageY := KeyImage(privateKeys[signerIndex])
+			if !EqualAffine(sig.KeyImage[0], sig.KeyImage[1], imageX, imageY) {
+				t.Errorf("unexpected key image for signer %d", signerIndex)
+			}
+
+			if VerifyRing(sig, []byte("vote for proposal #8"), ring) {
+				t.Errorf("verified ring signature for the wrong message")
+			}
+
+			serialized, _ := sig.MarshalBinary()
+			parsed := new(RingSignature)
+			if err := parsed.UnmarshalBinary(serialized); err != nil {
+				t.Errorf("failed to parse ring signature: %s", err)
+				continue
+			}
+			parsedRing, err := UnmarshalRing(MarshalRing(ring))
+			if err != nil {
+				t.Errorf("failed to parse ring: %s", err)
+				continue
+			}
+			if !VerifyRing(parsed, message, parsedRing) {
+				t.Errorf("failed to verify deserialized ring signature")
+			}
+		}
+
+		if size > 1 {
+			sig, _ := SignRing(rand.Reader, message, ring, privateKeys[0])
+			reordered := append(append([][2]*big.Int{}, ring[1:]...), ring[0])
+			if VerifyRing(sig, message, reordered) {
+				t.Errorf("verified ring signature with a reordered ring")
+			}
+		}
+	}
+}
+
+func TestRingSignature_Linkability(t *testing.T) {
+	ring1, privateKeys1 := randomRing(t, 3)
+	ring2, _ := randomRing(t, 3)
+	ring2[1] = ring1[2]
+
+	sig1, _ := SignRing(rand.Reader, []byte("first"), ring1, privateKeys1[2])
+	sig2, _ := SignRing(rand.Reader, []byte("second"), ring2, privateKeys1[2])
+	sig3, _ := SignRing(rand.Reader, []byte("third"), ring1, privateKeys1[0])
+
+	if !VerifyRing(sig2, []byte("second"), ring2) {
+		t.Fatalf("failed to verify ring signature")
+	}
+	if !sig1.IsLinked(sig2) {
+		t.Errorf("expected signatures by the same key in different rings to be linked")
+	}
+	if sig1.IsLinked(sig3) {
+		t.Errorf("expected signatures by different keys not to be linked")
+	}
+}
+
+func TestRingSignature_Forgery(t *testing.T) {
+	ring, privateKeys := randomRing(t, 4)
+
+	outsider, _ := RandomScalar(rand.Reader)
+	if _, err := SignRing(rand.Reader, nil, ring, outsider); err != ErrSignerNotInRing {
+		t.Errorf("expected ErrSignerNotInRing; got %v", err)
+	}
+
+	sig, _ := SignRing(rand.Reader, nil, ring, privateKeys[1])
+
+	// Swapping in another member's key image must invalidate the signature.
+	otherX, otherY := KeyImage(privateKeys[2])
+	forged := &RingSignature{KeyImage: [2]*big.Int{otherX, otherY}, C: sig.C, R: sig.R}
+	if VerifyRing(forged, nil, ring) {
+		t.Errorf("verified ring signature with a forged key image")
+	}
+
+	tampered := &RingSignature{KeyImage: sig.KeyImage, C: sig.C, R: append([]*big.Int{}, sig.R...)}
+	tampered.R[3] = new(big.Int).Add(tampered.R[3], one)
+	if VerifyRing(tampered, nil, ring) {
+		t.Errorf("verified tampered ring signature")
+	}
+
+	if VerifyRing(sig, nil, ring[:3]) {
+		t.Errorf("verified ring signature with a truncated ring")
+	}
+}
+
+func TestUnmarshalRing(t *testing.T) {
+	for _, data := range [][]byte{nil, make([]byte, 32), make([]byte, 33)} {
+		if _, err := UnmarshalRing(data); err == nil {
+			t.Errorf("expected error when parsing invalid ring %x", data)
+		}
+	}
+}
+
+func BenchmarkVerifyRing(b *testing.B) {
+	ring := make([][2]*big.Int, 8)
+	var privateKey *big.Int
+	for i := range ring {
+		privateKey, _ = RandomScalar(rand.Reader)
+		ring[i][0], ring[i][1] = MultiplyBasePoint(privateKey)
+	}
+	sig, _ := SignRing(rand.Reader, nil, ring, privateKey)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyRing(sig, nil, ring)
+	}
+}
@@ -0,0 +1,40 @@
+package ekliptic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestRIPEMD160(t *testing.T) {
+	// Test vectors from the RIPEMD-160 reference page.
+	vectors := []struct {
+		input  string
+		digest string
+	}{
+		{"", "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+		{"abc", "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+		{strings.Repeat("1234567890", 8), "9b752e45573d4b39f4dbd3323cab82bf63326bfb"},
+	}
+
+	for _, vector := range vectors {
+		digest := ripemd160([]byte(vector.input))
+		if hex.EncodeToString(digest[:]) != vector.digest {
+			t.Errorf("unexpected RIPEMD-160 digest of %q:\n  got %x\nwanted %s", vector.input, digest, vector.digest)
+		}
+	}
+}
+
+func TestHash160(t *testing.T) {
+	digest := hash160(serializeCompressed(Secp256k1_GeneratorX, Secp256k1_GeneratorY))
+	if hex.EncodeToString(digest[:]) != "751e76e8199196d454941c45d1b3a323f1433bd6" {
+		t.Errorf("unexpected hash160 of the generator point: %x", digest)
+	}
+}
+
+func BenchmarkRIPEMD160(b *testing.B) {
+	data := make([]byte, 32)
+	for i := 0; i < b.N; i++ {
+		ripemd160(data)
+	}
+}
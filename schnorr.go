@@ -0,0 +1,138 @@
+package ekliptic
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidSignatureEncoding is returned when parsing a serialized signature which is malformed.
+var ErrInvalidSignatureEncoding = errors.New("ekliptic: invalid signature encoding")
+
+const (
+	bip340AuxTag       = "BIP0340/aux"
+	bip340NonceTag     = "BIP0340/nonce"
+	bip340ChallengeTag = "BIP0340/challenge"
+)
+
+// SignSchnorr signs a message using the private key d, producing a BIP-340 Schnorr
+// signature (r, s), where r is the x-coordinate of the nonce point R.
+//
+// auxRand should be 32 bytes of fresh randomness. It is mixed into the deterministically
+// derived nonce to protect against side-channel attacks. If auxRand is nil, 32 zero bytes
+// are used, which is still secure but loses that protection.
+//
+// The private key d should be in the range [1, Secp256k1_CurveOrder). SignSchnorr panics
+// if d is not within this range, or if auxRand is neither nil nor 32 bytes long.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+func SignSchnorr(d *big.Int, message, auxRand []byte) (r, s *big.Int) {
+	if !IsValidScalar(d) {
+		panic("SignSchnorr: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	}
+	if auxRand == nil {
+		auxRand = make([]byte, 32)
+	} else if len(auxRand) != 32 {
+		panic("SignSchnorr: expected auxRand to be 32 bytes long")
+	}
+
+	pubX, pubY := MultiplyBasePoint(d)
+	d = evenYScalar(d, pubY)
+	pubXBytes := pubX.FillBytes(make([]byte, 32))
+
+	// t = bytes(d) xor hash_aux(a)
+	t := d.FillBytes(make([]byte, 32))
+	auxHash := taggedHash(bip340AuxTag, auxRand)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+
+	// k = hash_nonce(t || bytes(P) || m) mod N
+	nonceHash := taggedHash(bip340NonceTag, t, pubXBytes, message)
+	k := new(big.Int).SetBytes(nonceHash[:])
+	modScalar(k)
+	if k.Sign() == 0 {
+		// Occurs with negligible probability.
+		panic("SignSchnorr: derived nonce is zero")
+	}
+
+	r, rY := MultiplyBasePoint(k)
+	k = evenYScalar(k, rY)
+
+	// s = k + e * d mod N
+	s = schnorrChallenge(r, pubX, message)
+	s.Mul(s, d)
+	s.Add(s, k)
+	modScalar(s)
+	return
+}
+
+// VerifySchnorr returns true if the given BIP-340 signature (r, s) is a valid signature on
+// message from the public key whose x-coordinate is pubX. Per BIP-340, the public key is taken
+// to be the point with x-coordinate pubX and an even y-coordinate.
+//
+// The verifier checks that R = s * G - e * P has an even y-coordinate and x-coordinate r,
+// where e = hash_challenge(r || pubX || m).
+func VerifySchnorr(message []byte, r, s *big.Int, pubX *big.Int) bool {
+	if r == nil || s == nil || pubX == nil {
+		return false
+	}
+	if r.Sign() < 0 || r.Cmp(Secp256k1_P) >= 0 || !isValidProofScalar(s) {
+		return false
+	}
+	if pubX.Sign() <= 0 || pubX.Cmp(Secp256k1_P) >= 0 {
+		return false
+	}
+
+	pubY, _ := Weierstrass(pubX)
+	if pubY == nil {
+		return false
+	}
+
+	// R = s * G - e * P
+	e := schnorrChallenge(r, pubX, message)
+	e.Sub(Secp256k1_CurveOrder, e)
+	rX, rY := linearCombination(Secp256k1_GeneratorX, Secp256k1_GeneratorY, s, pubX, pubY, e)
+
+	if rX.Sign() == 0 && rY.Sign() == 0 {
+		return false
+	}
+	return isEven(rY) && equal(rX, r)
+}
+
+// SerializeSchnorrSignature encodes a BIP-340 signature (r, s) as 64 bytes.
+func SerializeSchnorrSignature(r, s *big.Int) []byte {
+	return serializeProofScalars(r, s)
+}
+
+// ParseSchnorrSignature decodes a 64-byte BIP-340 signature. The range of r and s
+// is checked by VerifySchnorr, not here.
+func ParseSchnorrSignature(sig []byte) (r, s *big.Int, err error) {
+	if len(sig) != 64 {
+		return nil, nil, ErrInvalidSignatureEncoding
+	}
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:])
+	return
+}
+
+// schnorrChallenge computes the BIP-340 challenge e = hash_challenge(r || pubX || m) mod N.
+func schnorrChallenge(r, pubX *big.Int, message []byte) *big.Int {
+	h := taggedHash(
+		bip340ChallengeTag,
+		r.FillBytes(make([]byte, 32)),
+		pubX.FillBytes(make([]byte, 32)),
+		message,
+	)
+	e := new(big.Int).SetBytes(h[:])
+	modScalar(e)
+	return e
+}
+
+// evenYScalar returns k if y is even, or N - k otherwise, so that
+// k * G always has an even y-coordinate, where y is the y-coordinate of k * G.
+func evenYScalar(k, y *big.Int) *big.Int {
+	if isEven(y) {
+		return k
+	}
+	return new(big.Int).Sub(Secp256k1_CurveOrder, k)
+}
@@ -0,0 +1,66 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestSchnorrProof(t *testing.T) {
+	g, _ := RandomScalar(rand.Reader)
+	gX, gY := MultiplyBasePoint(g)
+
+	bases := [][2]*big.Int{
+		{Secp256k1_GeneratorX, Secp256k1_GeneratorY},
+		{gX, gY},
+	}
+
+	for i, base := range bases {
+		x, _ := RandomScalar(rand.Reader)
+		aX, aY := MultiplyAffine(base[0], base[1], x, nil)
+
+		proof, err := ProveSchnorrKnowledge(rand.Reader, x, base[0], base[1])
+		if err != nil {
+			t.Errorf("failed to construct schnorr proof: %s", err)
+			return
+		}
+
+		if !VerifySchnorrKnowledge(proof, base[0], base[1], aX, aY) {
+			t.Errorf("failed to verify valid schnorr proof %d", i)
+			return
+		}
+
+		serialized, _ := proof.MarshalBinary()
+		parsed := new(SchnorrProof)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse serialized schnorr proof: %s", err)
+			return
+		}
+		if !VerifySchnorrKnowledge(parsed, base[0], base[1], aX, aY) {
+			t.Errorf("failed to verify deserialized schnorr proof %d", i)
+			return
+		}
+
+		otherX, otherY := DoubleAffine(aX, aY)
+		if VerifySchnorrKnowledge(proof, base[0], base[1], otherX, otherY) {
+			t.Errorf("verified schnorr proof %d for the wrong point", i)
+			return
+		}
+
+		if VerifySchnorrKnowledge(proof, base[0], base[1], aX, Negate(aY)) {
+			t.Errorf("verified schnorr proof %d for the negated point", i)
+			return
+		}
+	}
+}
+
+func BenchmarkVerifySchnorrKnowledge(b *testing.B) {
+	x, _ := RandomScalar(rand.Reader)
+	aX, aY := MultiplyBasePoint(x)
+	proof, _ := ProveSchnorrKnowledge(rand.Reader, x, Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifySchnorrKnowledge(proof, Secp256k1_GeneratorX, Secp256k1_GeneratorY, aX, aY)
+	}
+}
@@ -0,0 +1,120 @@
+package ekliptic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestSignSchnorr(t *testing.T) {
+	for i, vector := range test_vectors.BIP340Vectors {
+		if vector.PrivateKey == nil {
+			continue
+		}
+
+		pubX, _ := MultiplyBasePoint(vector.PrivateKey)
+		if !equal(pubX, vector.PublicKey) {
+			t.Errorf("unexpected public key for vector %d: %.64x", i, pubX)
+			continue
+		}
+
+		r, s := SignSchnorr(vector.PrivateKey, vector.Message, vector.AuxRand)
+		if sig := SerializeSchnorrSignature(r, s); !bytes.Equal(sig, vector.Signature) {
+			t.Errorf(`invalid Schnorr signature for vector %d. Got:
+	%x
+Wanted:
+	%x
+`, i, sig, vector.Signature)
+		}
+	}
+}
+
+func TestVerifySchnorr(t *testing.T) {
+	for i, vector := range test_vectors.BIP340Vectors {
+		r, s, err := ParseSchnorrSignature(vector.Signature)
+		if err != nil {
+			t.Errorf("failed to parse signature for vector %d: %s", i, err)
+			continue
+		}
+
+		if valid := VerifySchnorr(vector.Message, r, s, vector.PublicKey); valid != vector.Valid {
+			t.Errorf("expected Schnorr verification of vector %d to return %v (%s)", i, vector.Valid, vector.Comment)
+			continue
+		}
+
+		if vector.Valid && VerifySchnorr(append(vector.Message, 0), r, s, vector.PublicKey) {
+			t.Errorf("verified Schnorr signature on the wrong message for vector %d", i)
+		}
+	}
+}
+
+func TestVerifySchnorr_Invalid(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	pubX, _ := MultiplyBasePoint(d)
+	message := []byte("hello world")
+	r, s := SignSchnorr(d, message, nil)
+
+	if !VerifySchnorr(message, r, s, pubX) {
+		t.Fatalf("failed to verify Schnorr signature")
+	}
+
+	// The odd-y negation of a valid nonce shares its x-coordinate, but is not a valid signature.
+	negS := new(big.Int).Sub(Secp256k1_CurveOrder, s)
+
+	invalid := []struct {
+		name    string
+		r, s, x *big.Int
+	}{
+		{"negated s", r, negS, pubX},
+		{"r out of range", new(big.Int).Add(r, Secp256k1_P), s, pubX},
+		{"s out of range", r, new(big.Int).Add(s, Secp256k1_CurveOrder), pubX},
+		{"zero public key", r, s, big.NewInt(0)},
+		{"public key off curve", r, s, big.NewInt(5)},
+		{"nil signature", nil, nil, pubX},
+	}
+
+	for _, test := range invalid {
+		if VerifySchnorr(message, test.r, test.s, test.x) {
+			t.Errorf("verified invalid Schnorr signature: %s", test.name)
+		}
+	}
+
+	if _, _, err := ParseSchnorrSignature(make([]byte, 63)); err != ErrInvalidSignatureEncoding {
+		t.Errorf("expected ErrInvalidSignatureEncoding; got %v", err)
+	}
+}
+
+func TestSignSchnorr_OddPublicKey(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		d, _ := RandomScalar(rand.Reader)
+		pubX, pubY := MultiplyBasePoint(d)
+
+		auxRand := make([]byte, 32)
+		rand.Read(auxRand)
+		r, s := SignSchnorr(d, nil, auxRand)
+
+		if !VerifySchnorr(nil, r, s, pubX) {
+			t.Errorf("failed to verify Schnorr signature with public key y parity %d", pubY.Bit(0))
+		}
+	}
+}
+
+func BenchmarkSignSchnorr(b *testing.B) {
+	vector := test_vectors.BIP340Vectors[1]
+	for i := 0; i < b.N; i++ {
+		SignSchnorr(vector.PrivateKey, vector.Message, vector.AuxRand)
+	}
+}
+
+func BenchmarkVerifySchnorr(b *testing.B) {
+	vector := test_vectors.BIP340Vectors[1]
+	r, s, _ := ParseSchnorrSignature(vector.Signature)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifySchnorr(vector.Message, r, s, vector.PublicKey)
+	}
+}
@@ -0,0 +1,412 @@
+package ekliptic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrSilentPaymentNoInputs is returned when computing silent payment outputs without
+	// any eligible inputs or outpoints.
+	ErrSilentPaymentNoInputs = errors.New("ekliptic: silent payment requires at least one input")
+
+	// ErrSilentPaymentZeroSum is returned when the sum of a transaction's input keys is zero,
+	// in which case no silent payment outputs can be derived. This can only happen if the
+	// input keys were chosen maliciously.
+	ErrSilentPaymentZeroSum = errors.New("ekliptic: silent payment input keys sum to zero")
+
+	// ErrSilentPaymentTooManyRecipients is returned when more than SilentPaymentMaxRecipients
+	// recipients in a transaction share the same scan key.
+	ErrSilentPaymentTooManyRecipients = errors.New("ekliptic: too many silent payment recipients share a scan key")
+)
+
+// SilentPaymentMaxRecipients is the BIP-352 limit K_max on the number of outputs a transaction
+// may pay to recipients sharing one scan key. Receivers never scan beyond this many outputs.
+const SilentPaymentMaxRecipients = 2323
+
+const (
+	bip352InputsTag       = "BIP0352/Inputs"
+	bip352SharedSecretTag = "BIP0352/SharedSecret"
+	bip352LabelTag        = "BIP0352/Label"
+)
+
+// OutpointSize is the size in bytes of a serialized Outpoint.
+const OutpointSize = 36
+
+// Outpoint identifies a transaction output by the hash of its transaction and its index.
+type Outpoint struct {
+	// TxID is the transaction hash in internal byte order, which is the
+	// reverse of the hex string commonly used to display transaction IDs.
+	TxID [32]byte
+	Vout uint32
+}
+
+// MarshalBinary serializes the outpoint as it appears in a bitcoin transaction:
+// the 32-byte TxID, followed by the 4-byte little-endian output index.
+func (outpoint Outpoint) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, OutpointSize)
+	copy(buf, outpoint.TxID[:])
+	binary.LittleEndian.PutUint32(buf[32:], outpoint.Vout)
+	return buf, nil
+}
+
+// SilentPaymentInput is the private key of a transaction input which is eligible for
+// BIP-352 silent payments, held by the sender.
+type SilentPaymentInput struct {
+	PrivateKey *big.Int
+
+	// IsTaproot should be true if the input spends a taproot output. Taproot outputs commit
+	// to x-only public keys, so the private key is negated if its public key has an odd
+	// y-coordinate.
+	IsTaproot bool
+}
+
+// SilentPaymentRecipient is a BIP-352 silent payment address: a scan public key B_scan
+// and a spend public key B_m, which may be labeled.
+type SilentPaymentRecipient struct {
+	Scan  [2]*big.Int
+	Spend [2]*big.Int
+}
+
+// SilentPaymentOutputs derives the taproot output keys which a sender should pay to,
+// for a transaction spending the given eligible inputs. outpoints must list every
+// outpoint spent by the transaction, including inputs which are not eligible for silent
+// payments. It returns one x-only output public key for each recipient, in the same order.
+//
+// Recipients sharing the same scan key receive outputs derived with increasing counters k,
+// in the order given. ErrSilentPaymentTooManyRecipients is returned if more than
+// SilentPaymentMaxRecipients recipients share a scan key.
+//
+//	a = sum(a_i)
+//	A = a * G
+//	input_hash = hash_Inputs(outpoint_L || A)
+//	ecdh_shared_secret = input_hash * a * B_scan
+//	t_k = hash_SharedSecret(ecdh_shared_secret || k)
+//	P_k = B_m + t_k * G
+//
+// SilentPaymentOutputs panics if any private key is not in the range [1, Secp256k1_CurveOrder),
+// or if any recipient key is not a valid point.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0352.mediawiki
+func SilentPaymentOutputs(
+	inputs []SilentPaymentInput,
+	outpoints []Outpoint,
+	recipients []SilentPaymentRecipient,
+) ([]*big.Int, error) {
+	if len(inputs) == 0 || len(outpoints) == 0 {
+		return nil, ErrSilentPaymentNoInputs
+	}
+
+	// a = sum(a_i)
+	a := new(big.Int)
+	for _, input := range inputs {
+		if !IsValidScalar(input.PrivateKey) {
+			panic("SilentPaymentOutputs: expected input private keys to be in range [1, Secp256k1_CurveOrder)")
+		}
+
+		d := input.PrivateKey
+		if input.IsTaproot {
+			_, y := MultiplyBasePoint(d)
+			d = evenYScalar(d, y)
+		}
+		a.Add(a, d)
+	}
+	modScalar(a)
+	if a.Sign() == 0 {
+		return nil, ErrSilentPaymentZeroSum
+	}
+
+	counters := make(map[string]uint32)
+	for _, recipient := range recipients {
+		if !isValidPublicPoint(recipient.Scan[0], recipient.Scan[1]) ||
+			!isValidPublicPoint(recipient.Spend[0], recipient.Spend[1]) {
+			panic("SilentPaymentOutputs: expected recipient keys to be valid points on the curve")
+		}
+
+		scanKey := string(serializeCompressed(recipient.Scan[0], recipient.Scan[1]))
+		counters[scanKey]++
+		if counters[scanKey] > SilentPaymentMaxRecipients {
+			return nil, ErrSilentPaymentTooManyRecipients
+		}
+	}
+
+	aX, aY := MultiplyBasePoint(a)
+	inputHash := silentPaymentInputHash(outpoints, aX, aY)
+
+	// input_hash * a
+	sharedScalar := a.Mul(a, inputHash)
+	modScalar(sharedScalar)
+
+	// The shared secret is the same for every recipient in a scan key group.
+	sharedSecrets := make(map[string][2]*big.Int)
+	counters = make(map[string]uint32)
+	outputs := make([]*big.Int, len(recipients))
+
+	for i, recipient := range recipients {
+		scanKey := string(serializeCompressed(recipient.Scan[0], recipient.Scan[1]))
+		k := counters[scanKey]
+		counters[scanKey] = k + 1
+
+		shared, ok := sharedSecrets[scanKey]
+		if !ok {
+			shared[0], shared[1] = MultiplyAffine(recipient.Scan[0], recipient.Scan[1], sharedScalar, nil)
+			sharedSecrets[scanKey] = shared
+		}
+		t := silentPaymentSharedSecretTweak(shared[0], shared[1], k)
+
+		outputs[i], _ = silentPaymentOutputKey(recipient.Spend[0], recipient.Spend[1], t)
+	}
+
+	return outputs, nil
+}
+
+// SilentPaymentLabelTweak returns the BIP-352 tweak for label m of a receiver with the
+// given scan private key. The labeled spend key for label m is:
+//
+//	B_m = B_spend + hash_Label(b_scan || m) * G
+//
+// Label m = 0 is reserved for change outputs.
+func SilentPaymentLabelTweak(scanKey *big.Int, m uint32) *big.Int {
+	var mBytes [4]byte
+	binary.BigEndian.PutUint32(mBytes[:], m)
+	h := taggedHash(bip352LabelTag, scanKey.FillBytes(make([]byte, 32)), mBytes[:])
+
+	tweak := new(big.Int).SetBytes(h[:])
+	modScalar(tweak)
+	return tweak
+}
+
+// SilentPaymentMatch is a silent payment output found by a SilentPaymentReceiver.
+type SilentPaymentMatch struct {
+	// Output is the x-only public key of the matched taproot output.
+	Output *big.Int
+
+	// Tweak is added to the receiver's spend private key to obtain the output's private key.
+	// It includes the label tweak, if the output was paid to a labeled address.
+	Tweak *big.Int
+
+	// Label is the label m which the output was paid to, if Labeled is true.
+	Label   uint32
+	Labeled bool
+}
+
+// SpendKey returns the private key for a matched output, given the receiver's spend private key:
+//
+//	d = b_spend + tweak mod N
+//
+// The result can be used with SignSchnorr to spend the output.
+func (match *SilentPaymentMatch) SpendKey(spendKey *big.Int) *big.Int {
+	d := new(big.Int).Add(spendKey, match.Tweak)
+	modScalar(d)
+	return d
+}
+
+type silentPaymentLabel struct {
+	m     uint32
+	tweak *big.Int
+}
+
+// SilentPaymentReceiver scans transactions for BIP-352 silent payments, using the receiver's
+// scan private key and spend public key. The spend private key is not needed for scanning.
+// A SilentPaymentReceiver is not safe for concurrent use while labels are being added.
+type SilentPaymentReceiver struct {
+	scanKey        *big.Int
+	scanX, scanY   *big.Int
+	spendX, spendY *big.Int
+	labels         map[string]silentPaymentLabel
+}
+
+// NewSilentPaymentReceiver returns a SilentPaymentReceiver for the given scan private key
+// b_scan and spend public key B_spend. It panics if the scan key is not in the range
+// [1, Secp256k1_CurveOrder), or if the spend key is not a valid point.
+func NewSilentPaymentReceiver(scanKey, spendX, spendY *big.Int) *SilentPaymentReceiver {
+	if !IsValidScalar(scanKey) {
+		panic("NewSilentPaymentReceiver: expected scan key to be in range [1, Secp256k1_CurveOrder)")
+	} else if !isValidPublicPoint(spendX, spendY) {
+		panic("NewSilentPaymentReceiver: expected spend key to be a valid point on the curve")
+	}
+
+	scanX, scanY := MultiplyBasePoint(scanKey)
+	return &SilentPaymentReceiver{
+		scanKey: new(big.Int).Set(scanKey),
+		scanX:   scanX,
+		scanY:   scanY,
+		spendX:  new(big.Int).Set(spendX),
+		spendY:  new(big.Int).Set(spendY),
+		labels:  make(map[string]silentPaymentLabel),
+	}
+}
+
+// Address returns the receiver's unlabeled silent payment address.
+func (receiver *SilentPaymentReceiver) Address() SilentPaymentRecipient {
+	return SilentPaymentRecipient{
+		Scan:  [2]*big.Int{new(big.Int).Set(receiver.scanX), new(big.Int).Set(receiver.scanY)},
+		Spend: [2]*big.Int{new(big.Int).Set(receiver.spendX), new(big.Int).Set(receiver.spendY)},
+	}
+}
+
+// AddLabel registers label m with the receiver, so that Scan detects outputs paid to it,
+// and returns the labeled silent payment address.
+func (receiver *SilentPaymentReceiver) AddLabel(m uint32) SilentPaymentRecipient {
+	tweak := SilentPaymentLabelTweak(receiver.scanKey, m)
+	labelX, labelY := MultiplyBasePoint(tweak)
+	receiver.labels[string(serializeCompressed(labelX, labelY))] = silentPaymentLabel{m, tweak}
+
+	address := receiver.Address()
+	address.Spend[0], address.Spend[1] = AddAffine(receiver.spendX, receiver.spendY, labelX, labelY)
+	return address
+}
+
+// Scan searches a transaction's taproot outputs for silent payments to the receiver.
+// inputPublicKeys holds the public key of every eligible input to the transaction. Taproot
+// inputs' x-only keys must be lifted to the point with an even y-coordinate. outpoints must
+// list every outpoint spent by the transaction. outputs holds the x-only public keys of the
+// transaction's taproot outputs.
+//
+// Scan returns no matches if the input public keys sum to infinity, as senders cannot pay
+// to silent payment addresses from such a transaction.
+//
+//	A = sum(A_i)
+//	ecdh_shared_secret = input_hash * b_scan * A
+//	P_k = B_spend + t_k * G
+//
+// For each k, starting at zero, Scan checks whether any output is P_k or, for each
+// registered label, P_k + label * G. It stops at the first k with no matching output,
+// or after SilentPaymentMaxRecipients matches.
+func (receiver *SilentPaymentReceiver) Scan(
+	inputPublicKeys [][2]*big.Int,
+	outpoints []Outpoint,
+	outputs []*big.Int,
+) ([]*SilentPaymentMatch, error) {
+	if len(inputPublicKeys) == 0 || len(outpoints) == 0 {
+		return nil, ErrSilentPaymentNoInputs
+	}
+
+	// A = sum(A_i)
+	aX, aY := new(big.Int), new(big.Int)
+	for _, pub := range inputPublicKeys {
+		if !isValidPublicPoint(pub[0], pub[1]) {
+			return nil, ErrInvalidPointEncoding
+		}
+		aX, aY = AddAffine(aX, aY, pub[0], pub[1])
+	}
+	if aX.Sign() == 0 && aY.Sign() == 0 {
+		return nil, nil
+	}
+
+	inputHash := silentPaymentInputHash(outpoints, aX, aY)
+
+	// input_hash * b_scan
+	sharedScalar := new(big.Int).Mul(inputHash, receiver.scanKey)
+	modScalar(sharedScalar)
+	sharedX, sharedY := MultiplyAffine(aX, aY, sharedScalar, nil)
+
+	remaining := make([]*big.Int, len(outputs))
+	copy(remaining, outputs)
+
+	var matches []*SilentPaymentMatch
+
+	for k := uint32(0); k < SilentPaymentMaxRecipients && len(remaining) > 0; k++ {
+		t := silentPaymentSharedSecretTweak(sharedX, sharedY, k)
+		pX, pY := silentPaymentOutputKey(receiver.spendX, receiver.spendY, t)
+
+		index, match := receiver.matchOutput(remaining, pX, pY, t)
+		if match == nil {
+			break
+		}
+
+		matches = append(matches, match)
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+
+	return matches, nil
+}
+
+// matchOutput finds an output which is either P_k, or P_k plus a registered label.
+func (receiver *SilentPaymentReceiver) matchOutput(
+	outputs []*big.Int,
+	pX, pY, t *big.Int,
+) (int, *SilentPaymentMatch) {
+	for i, output := range outputs {
+		if equal(output, pX) {
+			return i, &SilentPaymentMatch{Output: output, Tweak: new(big.Int).Set(t)}
+		}
+	}
+
+	if len(receiver.labels) == 0 {
+		return -1, nil
+	}
+
+	for i, output := range outputs {
+		outputY, _ := Weierstrass(output)
+		if outputY == nil {
+			continue
+		}
+
+		// The output key is x-only, so the label is either output - P_k, or -output - P_k.
+		for _, y := range []*big.Int{outputY, Negate(outputY)} {
+			labelX, labelY := SubAffine(output, y, pX, pY)
+			if labelX.Sign() == 0 && labelY.Sign() == 0 {
+				continue
+			}
+
+			label, ok := receiver.labels[string(serializeCompressed(labelX, labelY))]
+			if !ok {
+				continue
+			}
+
+			tweak := new(big.Int).Add(t, label.tweak)
+			modScalar(tweak)
+			return i, &SilentPaymentMatch{
+				Output:  output,
+				Tweak:   tweak,
+				Label:   label.m,
+				Labeled: true,
+			}
+		}
+	}
+
+	return -1, nil
+}
+
+// silentPaymentInputHash computes input_hash = hash_Inputs(outpoint_L || A), where outpoint_L
+// is the lexicographically smallest serialized outpoint.
+func silentPaymentInputHash(outpoints []Outpoint, aX, aY *big.Int) *big.Int {
+	var smallest []byte
+	for _, outpoint := range outpoints {
+		serialized, _ := outpoint.MarshalBinary()
+		if smallest == nil || bytes.Compare(serialized, smallest) < 0 {
+			smallest = serialized
+		}
+	}
+
+	h := taggedHash(bip352InputsTag, smallest, serializeCompressed(aX, aY))
+	inputHash := new(big.Int).SetBytes(h[:])
+	if !IsValidScalar(inputHash) {
+		// Occurs with negligible probability.
+		panic("silentPaymentInputHash: input_hash is not a valid scalar")
+	}
+	return inputHash
+}
+
+// silentPaymentSharedSecretTweak computes t_k = hash_SharedSecret(ecdh_shared_secret || k).
+func silentPaymentSharedSecretTweak(sharedX, sharedY *big.Int, k uint32) *big.Int {
+	var kBytes [4]byte
+	binary.BigEndian.PutUint32(kBytes[:], k)
+	h := taggedHash(bip352SharedSecretTag, serializeCompressed(sharedX, sharedY), kBytes[:])
+
+	t := new(big.Int).SetBytes(h[:])
+	if !IsValidScalar(t) {
+		// Occurs with negligible probability.
+		panic("silentPaymentSharedSecretTweak: t_k is not a valid scalar")
+	}
+	return t
+}
+
+// silentPaymentOutputKey computes P_k = B_m + t_k * G.
+func silentPaymentOutputKey(spendX, spendY, t *big.Int) (x, y *big.Int) {
+	tX, tY := MultiplyBasePoint(t)
+	return AddAffine(spendX, spendY, tX, tY)
+}
@@ -0,0 +1,362 @@
+package ekliptic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+type silentPaymentWallet struct {
+	scanKey, spendKey *big.Int
+	receiver          *SilentPaymentReceiver
+}
+
+func newSilentPaymentWallet(t *testing.T) *silentPaymentWallet {
+	scanKey, err := RandomScalar(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate scan key: %s", err)
+	}
+	spendKey, err := RandomScalar(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate spend key: %s", err)
+	}
+	spendX, spendY := MultiplyBasePoint(spendKey)
+
+	return &silentPaymentWallet{
+		scanKey:  scanKey,
+		spendKey: spendKey,
+		receiver: NewSilentPaymentReceiver(scanKey, spendX, spendY),
+	}
+}
+
+// randomSilentPaymentInputs returns sender inputs along with the public keys a receiver would
+// extract from the transaction, lifting taproot keys to their even-y point.
+func randomSilentPaymentInputs(t *testing.T, count int) ([]SilentPaymentInput, [][2]*big.Int) {
+	inputs := make([]SilentPaymentInput, count)
+	publicKeys := make([][2]*big.Int, count)
+	for i := range inputs {
+		d, err := RandomScalar(rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate input key: %s", err)
+		}
+		inputs[i] = SilentPaymentInput{PrivateKey: d, IsTaproot: i%2 == 0}
+
+		x, y := MultiplyBasePoint(d)
+		if inputs[i].IsTaproot {
+			y, _ = Weierstrass(x)
+		}
+		publicKeys[i] = [2]*big.Int{x, y}
+	}
+	return inputs, publicKeys
+}
+
+func randomOutpoints(count int) []Outpoint {
+	outpoints := make([]Outpoint, count)
+	for i := range outpoints {
+		rand.Read(outpoints[i].TxID[:])
+		outpoints[i].Vout = uint32(i)
+	}
+	return outpoints
+}
+
+func TestSilentPayments(t *testing.T) {
+	alice := newSilentPaymentWallet(t)
+	bob := newSilentPaymentWallet(t)
+
+	aliceChange := alice.receiver.AddLabel(0)
+	aliceLabeled := alice.receiver.AddLabel(7)
+
+	for _, inputCount := range []int{1, 2, 3} {
+		inputs, publicKeys := randomSilentPaymentInputs(t, inputCount)
+		outpoints := randomOutpoints(inputCount + 1)
+
+		recipients := []SilentPaymentRecipient{
+			alice.receiver.Address(),
+			bob.receiver.Address(),
+			aliceLabeled,
+			alice.receiver.Address(),
+			aliceChange,
+		}
+
+		outputs, err := SilentPaymentOutputs(inputs, outpoints, recipients)
+		if err != nil {
+			t.Fatalf("failed to derive silent payment outputs: %s", err)
+		}
+
+		// Add an unrelated output, and shuffle the outputs by reversing them.
+		unrelated, _ := RandomScalar(rand.Reader)
+		unrelatedX, _ := MultiplyBasePoint(unrelated)
+		txOutputs := []*big.Int{unrelatedX}
+		for i := len(outputs) - 1; i >= 0; i-- {
+			txOutputs = append(txOutputs, outputs[i])
+		}
+
+		aliceMatches, err := alice.receiver.Scan(publicKeys, outpoints, txOutputs)
+		if err != nil {
+			t.Fatalf("failed to scan for silent payments: %s", err)
+		}
+		if len(aliceMatches) != 4 {
+			t.Errorf("expected alice to find 4 outputs; found %d", len(aliceMatches))
+		}
+
+		labelCounts := make(map[uint32]int)
+		for _, match := range aliceMatches {
+			if match.Labeled {
+				labelCounts[match.Label]++
+			}
+
+			// The derived private key must control the output.
+			x, _ := MultiplyBasePoint(match.SpendKey(alice.spendKey))
+			if !equal(x, match.Output) {
+				t.Errorf("derived spend key does not match output")
+			}
+
+			r, s := SignSchnorr(match.SpendKey(alice.spendKey), []byte("spend"), nil)
+			if !VerifySchnorr([]byte("spend"), r, s, match.Output) {
+				t.Errorf("failed to sign with derived spend key")
+			}
+		}
+		if labelCounts[0] != 1 || labelCounts[7] != 1 || len(labelCounts) != 2 {
+			t.Errorf("unexpected labels found: %v", labelCounts)
+		}
+
+		bobMatches, err := bob.receiver.Scan(publicKeys, outpoints, txOutputs)
+		if err != nil {
+			t.Fatalf("failed to scan for silent payments: %s", err)
+		}
+		if len(bobMatches) != 1 || !equal(bobMatches[0].Output, outputs[1]) || bobMatches[0].Labeled {
+			t.Errorf("expected bob to find exactly his one output")
+		}
+
+		// Outpoints and input keys are both bound into the shared secret.
+		otherMatches, _ := bob.receiver.Scan(publicKeys, randomOutpoints(1), txOutputs)
+		if len(otherMatches) != 0 {
+			t.Errorf("found silent payment output with wrong outpoints")
+		}
+	}
+}
+
+// silentPaymentVectorInputs returns the outpoints of every input in a BIP-352 test vector,
+// and the public keys of the eligible inputs, lifting taproot keys to their even-y point.
+func silentPaymentVectorInputs(
+	t *testing.T,
+	vectorInputs []*test_vectors.SilentPaymentVectorInput,
+) ([]Outpoint, [][2]*big.Int) {
+	outpoints := make([]Outpoint, len(vectorInputs))
+	var publicKeys [][2]*big.Int
+
+	for i, input := range vectorInputs {
+		outpoints[i] = Outpoint{TxID: input.TxID, Vout: input.Vout}
+		if input.PublicKey == nil {
+			continue
+		}
+
+		var x, y *big.Int
+		if input.IsTaproot {
+			x = new(big.Int).SetBytes(input.PublicKey)
+			y, _ = Weierstrass(x)
+		} else {
+			var err error
+			if x, y, err = parseCompressed(input.PublicKey); err != nil {
+				t.Fatalf("failed to parse input public key: %s", err)
+			}
+		}
+		publicKeys = append(publicKeys, [2]*big.Int{x, y})
+	}
+
+	return outpoints, publicKeys
+}
+
+func TestSilentPaymentOutputs_Vectors(t *testing.T) {
+	for i, vector := range test_vectors.SilentPaymentSendingVectors {
+		outpoints, publicKeys := silentPaymentVectorInputs(t, vector.Inputs)
+
+		if len(publicKeys) != len(vector.InputPublicKeys) {
+			t.Errorf("unexpected eligible input count for sending vector %d (%s)", i, vector.Comment)
+			continue
+		}
+		for j, pub := range publicKeys {
+			if !bytes.Equal(serializeCompressed(pub[0], pub[1]), vector.InputPublicKeys[j]) {
+				t.Errorf("unexpected input public key %d for sending vector %d (%s)", j, i, vector.Comment)
+			}
+		}
+
+		var inputs []SilentPaymentInput
+		for _, input := range vector.Inputs {
+			if input.PublicKey != nil {
+				inputs = append(inputs, SilentPaymentInput{PrivateKey: input.PrivateKey, IsTaproot: input.IsTaproot})
+			}
+		}
+
+		recipients := make([]SilentPaymentRecipient, len(vector.Recipients))
+		for j, recipient := range vector.Recipients {
+			scanX, scanY, err := parseCompressed(recipient.Scan)
+			if err != nil {
+				t.Fatalf("failed to parse recipient scan key: %s", err)
+			}
+			spendX, spendY, err := parseCompressed(recipient.Spend)
+			if err != nil {
+				t.Fatalf("failed to parse recipient spend key: %s", err)
+			}
+			recipients[j] = SilentPaymentRecipient{
+				Scan:  [2]*big.Int{scanX, scanY},
+				Spend: [2]*big.Int{spendX, spendY},
+			}
+		}
+
+		outputs, err := SilentPaymentOutputs(inputs, outpoints, recipients)
+
+		if len(vector.Outputs) == 1 && len(vector.Outputs[0]) == 0 {
+			if err == nil {
+				t.Errorf("expected sending to fail for vector %d (%s)", i, vector.Comment)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("failed to derive silent payment outputs for vector %d (%s): %s", i, vector.Comment, err)
+			continue
+		}
+
+		found := false
+		for _, expected := range vector.Outputs {
+			if sameIntSet(outputs, expected) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("unexpected silent payment outputs for sending vector %d (%s)", i, vector.Comment)
+		}
+	}
+}
+
+func TestSilentPaymentReceiver_Scan_Vectors(t *testing.T) {
+	// Matched outputs are signed with fixed inputs, so that signatures can be compared exactly.
+	message := sha256.Sum256([]byte("message"))
+	auxRand := sha256.Sum256([]byte("random auxiliary data"))
+
+	for i, vector := range test_vectors.SilentPaymentReceivingVectors {
+		spendX, spendY := MultiplyBasePoint(vector.SpendKey)
+		receiver := NewSilentPaymentReceiver(vector.ScanKey, spendX, spendY)
+		for _, m := range vector.Labels {
+			receiver.AddLabel(m)
+		}
+
+		outpoints, publicKeys := silentPaymentVectorInputs(t, vector.Inputs)
+
+		matches, err := receiver.Scan(publicKeys, outpoints, vector.Outputs)
+		if len(publicKeys) == 0 {
+			if err != ErrSilentPaymentNoInputs {
+				t.Errorf("expected ErrSilentPaymentNoInputs for receiving vector %d; got %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("failed to scan receiving vector %d (%s): %s", i, vector.Comment, err)
+			continue
+		}
+
+		if len(matches) != vector.ExpectedCount {
+			t.Errorf(
+				"expected to find %d outputs for receiving vector %d (%s); found %d",
+				vector.ExpectedCount, i, vector.Comment, len(matches),
+			)
+			continue
+		}
+
+		for _, expected := range vector.Expected {
+			var match *SilentPaymentMatch
+			for _, m := range matches {
+				if equal(m.Output, expected.PublicKey) {
+					match = m
+					break
+				}
+			}
+			if match == nil {
+				t.Errorf("failed to find output %.64x for receiving vector %d (%s)", expected.PublicKey, i, vector.Comment)
+				continue
+			}
+
+			if !equal(match.Tweak, expected.Tweak) {
+				t.Errorf("unexpected tweak for output %.64x in receiving vector %d", expected.PublicKey, i)
+			}
+
+			r, s := SignSchnorr(match.SpendKey(vector.SpendKey), message[:], auxRand[:])
+			if sig := SerializeSchnorrSignature(r, s); !bytes.Equal(sig, expected.Signature) {
+				t.Errorf("unexpected signature for output %.64x in receiving vector %d", expected.PublicKey, i)
+			}
+		}
+	}
+}
+
+// sameIntSet returns true if a and b hold the same values, in any order.
+func sameIntSet(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, n := range a {
+		seen[n.String()]++
+	}
+	for _, n := range b {
+		if seen[n.String()] == 0 {
+			return false
+		}
+		seen[n.String()]--
+	}
+	return true
+}
+
+func TestSilentPaymentOutputs_ZeroSum(t *testing.T) {
+	d, _ := RandomScalar(rand.Reader)
+	negD := new(big.Int).Sub(Secp256k1_CurveOrder, d)
+	inputs := []SilentPaymentInput{{PrivateKey: d}, {PrivateKey: negD}}
+
+	bob := newSilentPaymentWallet(t)
+	recipients := []SilentPaymentRecipient{bob.receiver.Address()}
+
+	if _, err := SilentPaymentOutputs(inputs, randomOutpoints(2), recipients); err != ErrSilentPaymentZeroSum {
+		t.Errorf("expected ErrSilentPaymentZeroSum; got %v", err)
+	}
+	if _, err := SilentPaymentOutputs(nil, randomOutpoints(1), recipients); err != ErrSilentPaymentNoInputs {
+		t.Errorf("expected ErrSilentPaymentNoInputs; got %v", err)
+	}
+
+	x, y := MultiplyBasePoint(d)
+	publicKeys := [][2]*big.Int{{x, y}, {x, Negate(y)}}
+	matches, err := bob.receiver.Scan(publicKeys, randomOutpoints(2), []*big.Int{x})
+	if err != nil || matches != nil {
+		t.Errorf("expected no matches when input keys sum to infinity; got %v, %v", matches, err)
+	}
+}
+
+func TestOutpoint_MarshalBinary(t *testing.T) {
+	outpoint := Outpoint{Vout: 0x01020304}
+	outpoint.TxID[0] = 0xab
+
+	serialized, _ := outpoint.MarshalBinary()
+	if len(serialized) != OutpointSize || serialized[0] != 0xab ||
+		serialized[32] != 0x04 || serialized[35] != 0x01 {
+		t.Errorf("unexpected outpoint serialization: %x", serialized)
+	}
+}
+
+func BenchmarkSilentPaymentReceiver_Scan(b *testing.B) {
+	scanKey, _ := RandomScalar(rand.Reader)
+	spendKey, _ := RandomScalar(rand.Reader)
+	spendX, spendY := MultiplyBasePoint(spendKey)
+	receiver := NewSilentPaymentReceiver(scanKey, spendX, spendY)
+	receiver.AddLabel(0)
+
+	d, _ := RandomScalar(rand.Reader)
+	x, y := MultiplyBasePoint(d)
+	inputs := [][2]*big.Int{{x, y}}
+	outpoints := randomOutpoints(1)
+	outputs := []*big.Int{x, spendX}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		receiver.Scan(inputs, outpoints, outputs)
+	}
+}
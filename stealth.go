@@ -0,0 +1,222 @@
+package ekliptic
+
+import (
+	"io"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+const stealthSharedSecretTag = "ekliptic/Stealth/SharedSecret"
+
+// StealthMetaAddress is a recipient's long-lived dual-key stealth address, consisting of
+// a scan public key A = a * G and a spend public key B = b * G. The recipient can share the
+// scan private key a with a third party to scan for payments on their behalf, without
+// giving away the ability to spend them.
+type StealthMetaAddress struct {
+	Scan  [2]*big.Int
+	Spend [2]*big.Int
+}
+
+// StealthAnnouncement is the data a sender publishes alongside a stealth payment, which
+// the recipient needs to find it: the ephemeral public key R = r * G, and the view tag.
+//
+// The view tag is the first byte of the hashed shared secret. A recipient can discard
+// 255 out of 256 announcements meant for someone else after a single scalar multiplication,
+// without deriving the one-time public key.
+type StealthAnnouncement struct {
+	Ephemeral [2]*big.Int
+	ViewTag   byte
+}
+
+// StealthPayment is a one-time stealth address derived by a sender for a recipient.
+type StealthPayment struct {
+	// OneTime is the one-time public key P to which the sender should pay.
+	OneTime [2]*big.Int
+
+	// Announcement must be published so the recipient can find the payment.
+	Announcement StealthAnnouncement
+}
+
+// GenerateStealthPayment derives a fresh one-time public key for a recipient's
+// StealthMetaAddress. The ephemeral key r is generated using the given source of
+// randomness, which should be cryptographically secure.
+//
+//	r = random scalar
+//	R = r * G
+//	s = H(r * A)
+//	P = s * G + B
+//
+// Each payment uses a new ephemeral key, so one-time public keys paid to the same
+// recipient cannot be linked to each other or to the meta-address. GenerateStealthPayment
+// panics if either key of the meta-address is not a valid point.
+func GenerateStealthPayment(random io.Reader, meta StealthMetaAddress) (*StealthPayment, error) {
+	if !isValidPublicPoint(meta.Scan[0], meta.Scan[1]) || !isValidPublicPoint(meta.Spend[0], meta.Spend[1]) {
+		panic("GenerateStealthPayment: expected meta-address keys to be valid points on the curve")
+	}
+
+	r, err := RandomScalar(random)
+	if err != nil {
+		return nil, err
+	}
+
+	rX, rY := MultiplyBasePoint(r)
+	sharedX, sharedY := MultiplyAffine(meta.Scan[0], meta.Scan[1], r, nil)
+	s, viewTag := stealthSharedSecret(sharedX, sharedY)
+
+	payment := &StealthPayment{
+		Announcement: StealthAnnouncement{
+			Ephemeral: [2]*big.Int{rX, rY},
+			ViewTag:   viewTag,
+		},
+	}
+	payment.OneTime[0], payment.OneTime[1] = stealthOneTimeKey(meta.Spend[0], meta.Spend[1], s)
+	return payment, nil
+}
+
+// StealthMatch is a stealth payment found by a StealthScanner.
+type StealthMatch struct {
+	// Index is the position of the matching announcement in the scanned batch.
+	Index int
+
+	// OneTime is the one-time public key P derived from the announcement.
+	OneTime [2]*big.Int
+
+	// Tweak is added to the recipient's spend private key to obtain the one-time private key.
+	Tweak *big.Int
+}
+
+// SpendKey returns the one-time private key for a matched payment, given the recipient's
+// spend private key b:
+//
+//	p = b + s mod N
+func (match *StealthMatch) SpendKey(spendKey *big.Int) *big.Int {
+	p := new(big.Int).Add(spendKey, match.Tweak)
+	modScalar(p)
+	return p
+}
+
+// StealthScanner finds stealth payments using a recipient's scan private key a and
+// spend public key B. It is safe for concurrent use.
+type StealthScanner struct {
+	scanKey        *big.Int
+	spendX, spendY *big.Int
+}
+
+// NewStealthScanner returns a StealthScanner for the given scan private key and spend public
+// key. It panics if the scan key is not in the range [1, Secp256k1_CurveOrder), or if the spend
+// key is not a valid point.
+func NewStealthScanner(scanKey, spendX, spendY *big.Int) *StealthScanner {
+	if !IsValidScalar(scanKey) {
+		panic("NewStealthScanner: expected scan key to be in range [1, Secp256k1_CurveOrder)")
+	} else if !isValidPublicPoint(spendX, spendY) {
+		panic("NewStealthScanner: expected spend key to be a valid point on the curve")
+	}
+
+	return &StealthScanner{
+		scanKey: new(big.Int).Set(scanKey),
+		spendX:  new(big.Int).Set(spendX),
+		spendY:  new(big.Int).Set(spendY),
+	}
+}
+
+// Check derives the one-time public key for a single announcement:
+//
+//	s = H(a * R)
+//	P = s * G + B
+//
+// It returns nil if the announcement's view tag does not match, in which case the payment is
+// certainly not for this recipient, or if the ephemeral key is invalid. Otherwise, the caller
+// must still compare the returned one-time public key against the key which was paid, since
+// one in 256 announcements for other recipients also has a matching view tag.
+func (scanner *StealthScanner) Check(announcement StealthAnnouncement) *StealthMatch {
+	ephemeral := announcement.Ephemeral
+	if !isValidPublicPoint(ephemeral[0], ephemeral[1]) {
+		return nil
+	}
+
+	sharedX, sharedY := MultiplyAffine(ephemeral[0], ephemeral[1], scanner.scanKey, nil)
+	s, viewTag := stealthSharedSecret(sharedX, sharedY)
+	if viewTag != announcement.ViewTag {
+		return nil
+	}
+
+	match := &StealthMatch{Tweak: s}
+	match.OneTime[0], match.OneTime[1] = stealthOneTimeKey(scanner.spendX, scanner.spendY, s)
+	return match
+}
+
+// Scan checks every announcement in a batch, and returns the matches in the order
+// of their announcements. See Check for details.
+func (scanner *StealthScanner) Scan(announcements []StealthAnnouncement) []*StealthMatch {
+	var matches []*StealthMatch
+	for i, announcement := range announcements {
+		if match := scanner.Check(announcement); match != nil {
+			match.Index = i
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// ScanParallel is like Scan, but splits the batch of announcements across the given number
+// of goroutines. If workers is zero or negative, runtime.GOMAXPROCS(0) is used. Matches are
+// returned in the order of their announcements, exactly as Scan would return them.
+func (scanner *StealthScanner) ScanParallel(announcements []StealthAnnouncement, workers int) []*StealthMatch {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(announcements) {
+		workers = len(announcements)
+	}
+	if workers <= 1 {
+		return scanner.Scan(announcements)
+	}
+
+	results := make([]*StealthMatch, len(announcements))
+	chunkSize := (len(announcements) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(announcements); start += chunkSize {
+		end := start + chunkSize
+		if end > len(announcements) {
+			end = len(announcements)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				results[i] = scanner.Check(announcements[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	var matches []*StealthMatch
+	for i, match := range results {
+		if match != nil {
+			match.Index = i
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// stealthSharedSecret hashes the ECDH shared secret point into the scalar s and the view tag.
+func stealthSharedSecret(sharedX, sharedY *big.Int) (s *big.Int, viewTag byte) {
+	h := taggedHash(stealthSharedSecretTag, serializeCompressed(sharedX, sharedY))
+
+	s = new(big.Int).SetBytes(h[:])
+	if !IsValidScalar(s) {
+		// Occurs with negligible probability.
+		panic("stealthSharedSecret: hashed shared secret is not a valid scalar")
+	}
+	return s, h[0]
+}
+
+// stealthOneTimeKey computes P = s * G + B.
+func stealthOneTimeKey(spendX, spendY, s *big.Int) (x, y *big.Int) {
+	sX, sY := MultiplyBasePoint(s)
+	return AddAffine(sX, sY, spendX, spendY)
+}
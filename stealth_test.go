@@ -0,0 +1,145 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func randomStealthKeys(t testing.TB) (scanKey, spendKey *big.Int, meta StealthMetaAddress) {
+	scanKey, err := RandomScalar(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate scan key: %s", err)
+	}
+	spendKey, err = RandomScalar(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate spend key: %s", err)
+	}
+
+	meta.Scan[0], meta.Scan[1] = MultiplyBasePoint(scanKey)
+	meta.Spend[0], meta.Spend[1] = MultiplyBasePoint(spendKey)
+	return
+}
+
+func TestStealthPayment(t *testing.T) {
+	scanKey, spendKey, meta := randomStealthKeys(t)
+	scanner := NewStealthScanner(scanKey, meta.Spend[0], meta.Spend[1])
+
+	for i := 0; i < 5; i++ {
+		payment, err := GenerateStealthPayment(rand.Reader, meta)
+		if err != nil {
+			t.Fatalf("failed to generate stealth payment: %s", err)
+		}
+
+		match := scanner.Check(payment.Announcement)
+		if match == nil {
+			t.Errorf("failed to find stealth payment")
+			continue
+		}
+
+		if !EqualAffine(match.OneTime[0], match.OneTime[1], payment.OneTime[0], payment.OneTime[1]) {
+			t.Errorf("scanner derived the wrong one-time public key")
+		}
+
+		x, y := MultiplyBasePoint(match.SpendKey(spendKey))
+		if !EqualAffine(x, y, payment.OneTime[0], payment.OneTime[1]) {
+			t.Errorf("derived one-time private key does not match one-time public key")
+		}
+
+		wrongTag := payment.Announcement
+		wrongTag.ViewTag++
+		if scanner.Check(wrongTag) != nil {
+			t.Errorf("matched announcement with the wrong view tag")
+		}
+	}
+
+	first, _ := GenerateStealthPayment(rand.Reader, meta)
+	second, _ := GenerateStealthPayment(rand.Reader, meta)
+	if equal(first.OneTime[0], second.OneTime[0]) {
+		t.Errorf("expected distinct one-time keys for each payment")
+	}
+}
+
+func TestStealthScanner_ScanParallel(t *testing.T) {
+	scanKey, _, meta := randomStealthKeys(t)
+	_, _, otherMeta := randomStealthKeys(t)
+	scanner := NewStealthScanner(scanKey, meta.Spend[0], meta.Spend[1])
+
+	var announcements []StealthAnnouncement
+	var expected []*StealthPayment
+	for i := 0; i < 20; i++ {
+		recipient := otherMeta
+		if i%3 == 0 {
+			recipient = meta
+		}
+
+		payment, err := GenerateStealthPayment(rand.Reader, recipient)
+		if err != nil {
+			t.Fatalf("failed to generate stealth payment: %s", err)
+		}
+		announcements = append(announcements, payment.Announcement)
+		if i%3 == 0 {
+			expected = append(expected, payment)
+		}
+	}
+	announcements = append(announcements, StealthAnnouncement{Ephemeral: [2]*big.Int{one, two}})
+
+	// Filter out view tag collisions by comparing one-time keys, as a caller would.
+	found := func(matches []*StealthMatch) []*StealthMatch {
+		var confirmed []*StealthMatch
+		for _, match := range matches {
+			for _, payment := range expected {
+				if EqualAffine(match.OneTime[0], match.OneTime[1], payment.OneTime[0], payment.OneTime[1]) {
+					confirmed = append(confirmed, match)
+				}
+			}
+		}
+		return confirmed
+	}
+
+	sequential := scanner.Scan(announcements)
+	if len(found(sequential)) != len(expected) {
+		t.Fatalf("expected to find %d payments; found %d", len(expected), len(found(sequential)))
+	}
+
+	for _, workers := range []int{0, 1, 3, 7, 100} {
+		parallel := scanner.ScanParallel(announcements, workers)
+		if len(parallel) != len(sequential) {
+			t.Errorf("parallel scan with %d workers found %d matches; wanted %d", workers, len(parallel), len(sequential))
+			continue
+		}
+		for i := range parallel {
+			if parallel[i].Index != sequential[i].Index ||
+				!EqualAffine(parallel[i].OneTime[0], parallel[i].OneTime[1], sequential[i].OneTime[0], sequential[i].OneTime[1]) {
+				t.Errorf("parallel scan with %d workers returned different match at %d", workers, i)
+			}
+		}
+	}
+
+	if matches := scanner.ScanParallel(nil, 4); len(matches) != 0 {
+		t.Errorf("expected no matches for an empty batch")
+	}
+}
+
+func BenchmarkStealthScanner_Scan(b *testing.B) {
+	scanKey, _, meta := randomStealthKeys(b)
+	scanner := NewStealthScanner(scanKey, meta.Spend[0], meta.Spend[1])
+
+	announcements := make([]StealthAnnouncement, 64)
+	for i := range announcements {
+		payment, _ := GenerateStealthPayment(rand.Reader, meta)
+		announcements[i] = payment.Announcement
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanner.Scan(announcements)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanner.ScanParallel(announcements, 0)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package ekliptic
+
+import (
+	"math/big"
+)
+
+const bip341TapTweakTag = "TapTweak"
+
+// TaprootOutputKey computes the x-only BIP-341 taproot output key Q for an internal
+// x-only public key P, committing to no script tree:
+//
+//	t = hash_TapTweak(x(P))
+//	Q = P + t * G
+//
+// where P is taken to have an even y-coordinate. It returns the x-coordinate of Q.
+// TaprootOutputKey panics if internalX is not the x-coordinate of a point on the curve.
+//
+// https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+func TaprootOutputKey(internalX *big.Int) *big.Int {
+	internalY, _ := Weierstrass(internalX)
+	if internalY == nil {
+		panic("TaprootOutputKey: expected internal key to be the x-coordinate of a point on the curve")
+	}
+
+	tX, tY := MultiplyBasePoint(taprootTweak(internalX))
+	outputX, _ := AddAffine(internalX, internalY, tX, tY)
+	return outputX
+}
+
+// TaprootTweakPrivateKey computes the private key of the taproot output key whose internal
+// key is d * G, for key path spending with SignSchnorr:
+//
+//	d' = d + t    (if d * G has an even y-coordinate)
+//	d' = -d + t   (otherwise)
+//
+// TaprootTweakPrivateKey panics if d is not within the range [1, Secp256k1_CurveOrder).
+func TaprootTweakPrivateKey(d *big.Int) *big.Int {
+	if !IsValidScalar(d) {
+		panic("TaprootTweakPrivateKey: expected private key d to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	pubX, pubY := MultiplyBasePoint(d)
+	tweaked := new(big.Int).Add(evenYScalar(d, pubY), taprootTweak(pubX))
+	modScalar(tweaked)
+	return tweaked
+}
+
+// taprootTweak computes t = hash_TapTweak(x(P)) for key path only outputs.
+func taprootTweak(internalX *big.Int) *big.Int {
+	h := taggedHash(bip341TapTweakTag, internalX.FillBytes(make([]byte, 32)))
+
+	t := new(big.Int).SetBytes(h[:])
+	if t.Cmp(Secp256k1_CurveOrder) >= 0 {
+		// Occurs with negligible probability.
+		panic("taprootTweak: tweak is not a valid scalar")
+	}
+	return t
+}
@@ -0,0 +1,37 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestTaprootTweakPrivateKey(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		d, _ := RandomScalar(rand.Reader)
+		pubX, _ := MultiplyBasePoint(d)
+
+		outputX := TaprootOutputKey(pubX)
+		tweakedX, _ := MultiplyBasePoint(TaprootTweakPrivateKey(d))
+		if !equal(outputX, tweakedX) {
+			t.Errorf("tweaked private key does not match taproot output key")
+			continue
+		}
+
+		message := []byte("spend")
+		r, s := SignSchnorr(TaprootTweakPrivateKey(d), message, nil)
+		if !VerifySchnorr(message, r, s, outputX) {
+			t.Errorf("failed to verify key path signature against taproot output key")
+		}
+	}
+}
+
+func TestTaprootOutputKey_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for x-coordinate not on the curve")
+		}
+	}()
+
+	// x = 5 is not the x-coordinate of any point on secp256k1.
+	TaprootOutputKey(five)
+}
@@ -0,0 +1,144 @@
+package test_vectors
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// EllSwiftDecodeVector represents a BIP-324 test vector for decoding a 64-byte
+// ElligatorSwift encoding to an x-coordinate.
+type EllSwiftDecodeVector struct {
+	EllSwift []byte
+	X        *big.Int
+	Comment  string
+}
+
+// XSwiftECInverseVector represents a BIP-324 test vector for the inverse of the XSwiftEC
+// mapping. T holds the expected preimage t for each of the 8 cases, or nil if the case
+// has no preimage.
+type XSwiftECInverseVector struct {
+	U, X    *big.Int
+	T       [8]*big.Int
+	Comment string
+}
+
+// EllSwiftECDHVector represents the x-only ECDH portion of a BIP-324 packet encoding
+// test vector. The remaining columns of packet_encoding_test_vectors.csv, which test
+// the v2 transport ciphers, are not loaded.
+type EllSwiftECDHVector struct {
+	PrivateKey     *big.Int
+	EllSwiftOurs   []byte
+	EllSwiftTheirs []byte
+	Initiating     bool
+	XOurs          *big.Int
+	XTheirs        *big.Int
+	XShared        *big.Int
+	SharedSecret   []byte
+}
+
+//go:embed ellswift_decode_test_vectors.csv
+var ellSwiftDecodeCsvBytes []byte
+
+//go:embed xswiftec_inv_test_vectors.csv
+var xSwiftECInverseCsvBytes []byte
+
+//go:embed packet_encoding_test_vectors.csv
+var packetEncodingCsvBytes []byte
+
+// loadCsvRecords parses a CSV file with a header row, returning each
+// subsequent row as a map of column names to values.
+func loadCsvRecords(data []byte) ([]map[string]string, error) {
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]string, 0, len(rows))
+	for _, row := range rows[1:] {
+		record := make(map[string]string)
+		for i, column := range rows[0] {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func loadEllSwiftDecodeVectors() ([]*EllSwiftDecodeVector, error) {
+	records, err := loadCsvRecords(ellSwiftDecodeCsvBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*EllSwiftDecodeVector, len(records))
+	for i, record := range records {
+		vector := &EllSwiftDecodeVector{
+			X:       hexint(record["x"]),
+			Comment: record["comment"],
+		}
+		if vector.EllSwift, err = hex.DecodeString(record["ellswift"]); err != nil {
+			return nil, err
+		}
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
+
+func loadXSwiftECInverseVectors() ([]*XSwiftECInverseVector, error) {
+	records, err := loadCsvRecords(xSwiftECInverseCsvBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*XSwiftECInverseVector, len(records))
+	for i, record := range records {
+		vector := &XSwiftECInverseVector{
+			U:       hexint(record["u"]),
+			X:       hexint(record["x"]),
+			Comment: record["comment"],
+		}
+		for c := range vector.T {
+			if t := record[fmt.Sprintf("case%d_t", c)]; t != "" {
+				vector.T[c] = hexint(t)
+			}
+		}
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
+
+func loadEllSwiftECDHVectors() ([]*EllSwiftECDHVector, error) {
+	records, err := loadCsvRecords(packetEncodingCsvBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*EllSwiftECDHVector, len(records))
+	for i, record := range records {
+		vector := &EllSwiftECDHVector{
+			PrivateKey: hexint(record["in_priv_ours"]),
+			Initiating: record["in_initiating"] == "1",
+			XOurs:      hexint(record["mid_x_ours"]),
+			XTheirs:    hexint(record["mid_x_theirs"]),
+			XShared:    hexint(record["mid_x_shared"]),
+		}
+		if vector.EllSwiftOurs, err = hex.DecodeString(record["in_ellswift_ours"]); err != nil {
+			return nil, err
+		}
+		if vector.EllSwiftTheirs, err = hex.DecodeString(record["in_ellswift_theirs"]); err != nil {
+			return nil, err
+		}
+		if vector.SharedSecret, err = hex.DecodeString(record["mid_shared_secret"]); err != nil {
+			return nil, err
+		}
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,61 @@
+package test_vectors
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"math/big"
+)
+
+// BIP340Vector represents a BIP-340 test vector for a Schnorr signature on a message.
+// Some vectors are only meant for verification, in which case PrivateKey and AuxRand are nil.
+// Valid reports whether the signature is expected to verify.
+type BIP340Vector struct {
+	PrivateKey *big.Int
+	PublicKey  *big.Int
+	AuxRand    []byte
+	Message    []byte
+	Signature  []byte
+	Valid      bool
+	Comment    string
+}
+
+//go:embed bip340_test_vectors.csv
+var bip340CsvBytes []byte
+
+func loadBIP340Vectors() ([]*BIP340Vector, error) {
+	records, err := loadCsvRecords(bip340CsvBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*BIP340Vector, len(records))
+
+	for i, record := range records {
+		vector := &BIP340Vector{
+			PublicKey: hexint(record["public key"]),
+			Valid:     record["verification result"] == "TRUE",
+			Comment:   record["comment"],
+		}
+
+		if d := record["secret key"]; d != "" {
+			vector.PrivateKey = hexint(d)
+		}
+
+		if vector.AuxRand, err = hex.DecodeString(record["aux_rand"]); err != nil {
+			return nil, err
+		}
+		if len(vector.AuxRand) == 0 {
+			vector.AuxRand = nil
+		}
+		if vector.Message, err = hex.DecodeString(record["message"]); err != nil {
+			return nil, err
+		}
+		if vector.Signature, err = hex.DecodeString(record["signature"]); err != nil {
+			return nil, err
+		}
+
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,411 @@
+package test_vectors
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// SilentPaymentVectorInput is a transaction input from a BIP-352 test vector.
+type SilentPaymentVectorInput struct {
+	// TxID is in internal byte order, the reverse of the hex string in the vector file.
+	TxID [32]byte
+	Vout uint32
+
+	// PrivateKey is only given for sending vectors.
+	PrivateKey *big.Int
+
+	// PublicKey is the key extracted from the input's scripts by the rules of BIP-352:
+	// a compressed point, or a 32-byte x-only key if IsTaproot is true. It is nil if
+	// the input is not eligible for silent payments.
+	PublicKey []byte
+	IsTaproot bool
+}
+
+// SilentPaymentRecipientVector is a silent payment address from a BIP-352 sending vector,
+// given as a pair of compressed points.
+type SilentPaymentRecipientVector struct {
+	Scan  []byte
+	Spend []byte
+}
+
+// SilentPaymentSendingVector represents a BIP-352 test vector for creating silent payment
+// outputs. InputPublicKeys holds the compressed public keys of the eligible inputs.
+// Outputs holds every valid set of output keys, as the order of recipients sharing a scan
+// key changes the outputs derived. If sending must fail, Outputs holds one empty set.
+type SilentPaymentSendingVector struct {
+	Comment         string
+	Inputs          []*SilentPaymentVectorInput
+	Recipients      []*SilentPaymentRecipientVector
+	InputPublicKeys [][]byte
+	Outputs         [][]*big.Int
+}
+
+// SilentPaymentOutputVector is an output which a BIP-352 receiving vector expects
+// the receiver to find, along with a BIP-340 signature made with its spending key.
+type SilentPaymentOutputVector struct {
+	PublicKey *big.Int
+	Tweak     *big.Int
+	Signature []byte
+}
+
+// SilentPaymentReceivingVector represents a BIP-352 test vector for scanning a transaction.
+// The receiver is expected to find ExpectedCount of the given Outputs. Expected holds the
+// details of each match, except in vectors which only specify a count, where it is nil.
+type SilentPaymentReceivingVector struct {
+	Comment       string
+	ScanKey       *big.Int
+	SpendKey      *big.Int
+	Labels        []uint32
+	Inputs        []*SilentPaymentVectorInput
+	Outputs       []*big.Int
+	Expected      []*SilentPaymentOutputVector
+	ExpectedCount int
+}
+
+//go:embed send_and_receive_test_vectors.json
+var silentPaymentsJsonBytes []byte
+
+// NUMS point H from BIP-341. Taproot inputs whose script path spend reveals it
+// as the internal key are not eligible for silent payments.
+var bip352NUMSPoint, _ = hex.DecodeString("50929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0")
+
+var secp256k1P = hexint("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+
+type silentPaymentJsonInput struct {
+	TxID        string `json:"txid"`
+	Vout        uint32 `json:"vout"`
+	ScriptSig   string `json:"scriptSig"`
+	TxInWitness string `json:"txinwitness"`
+	PrivateKey  string `json:"private_key"`
+	Prevout     struct {
+		ScriptPubKey struct {
+			Hex string `json:"hex"`
+		} `json:"scriptPubKey"`
+	} `json:"prevout"`
+}
+
+type silentPaymentJsonOutput struct {
+	PublicKey string `json:"pub_key"`
+	Tweak     string `json:"priv_key_tweak"`
+	Signature string `json:"signature"`
+}
+
+type silentPaymentJsonCase struct {
+	Comment string `json:"comment"`
+	Sending []struct {
+		Given struct {
+			Vin        []*silentPaymentJsonInput `json:"vin"`
+			Recipients []struct {
+				ScanPubKey  string `json:"scan_pub_key"`
+				SpendPubKey string `json:"spend_pub_key"`
+				Count       int    `json:"count"`
+			} `json:"recipients"`
+		} `json:"given"`
+		Expected struct {
+			InputPubKeys []string   `json:"input_pub_keys"`
+			Outputs      [][]string `json:"outputs"`
+		} `json:"expected"`
+	} `json:"sending"`
+	Receiving []struct {
+		Given struct {
+			Vin         []*silentPaymentJsonInput `json:"vin"`
+			Outputs     []string                  `json:"outputs"`
+			Labels      []uint32                  `json:"labels"`
+			KeyMaterial struct {
+				ScanPrivKey  string `json:"scan_priv_key"`
+				SpendPrivKey string `json:"spend_priv_key"`
+			} `json:"key_material"`
+		} `json:"given"`
+		Expected struct {
+			Outputs  []*silentPaymentJsonOutput `json:"outputs"`
+			NOutputs *int                       `json:"n_outputs"`
+		} `json:"expected"`
+	} `json:"receiving"`
+}
+
+func loadSilentPaymentVectors() ([]*SilentPaymentSendingVector, []*SilentPaymentReceivingVector, error) {
+	var cases []*silentPaymentJsonCase
+	if err := json.Unmarshal(silentPaymentsJsonBytes, &cases); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		sendingVectors   []*SilentPaymentSendingVector
+		receivingVectors []*SilentPaymentReceivingVector
+	)
+
+	for _, c := range cases {
+		for _, sending := range c.Sending {
+			vector := &SilentPaymentSendingVector{Comment: c.Comment}
+
+			for _, vin := range sending.Given.Vin {
+				input, err := parseSilentPaymentInput(vin)
+				if err != nil {
+					return nil, nil, err
+				}
+				vector.Inputs = append(vector.Inputs, input)
+			}
+
+			for _, r := range sending.Given.Recipients {
+				recipient := &SilentPaymentRecipientVector{}
+				var err error
+				if recipient.Scan, err = hex.DecodeString(r.ScanPubKey); err != nil {
+					return nil, nil, err
+				}
+				if recipient.Spend, err = hex.DecodeString(r.SpendPubKey); err != nil {
+					return nil, nil, err
+				}
+
+				count := r.Count
+				if count == 0 {
+					count = 1
+				}
+				for i := 0; i < count; i++ {
+					vector.Recipients = append(vector.Recipients, recipient)
+				}
+			}
+
+			for _, pub := range sending.Expected.InputPubKeys {
+				pubBytes, err := hex.DecodeString(pub)
+				if err != nil {
+					return nil, nil, err
+				}
+				vector.InputPublicKeys = append(vector.InputPublicKeys, pubBytes)
+			}
+
+			for _, outputSet := range sending.Expected.Outputs {
+				outputs := make([]*big.Int, len(outputSet))
+				for i, output := range outputSet {
+					outputs[i] = hexint(output)
+				}
+				vector.Outputs = append(vector.Outputs, outputs)
+			}
+
+			sendingVectors = append(sendingVectors, vector)
+		}
+
+		for _, receiving := range c.Receiving {
+			vector := &SilentPaymentReceivingVector{
+				Comment:  c.Comment,
+				ScanKey:  hexint(receiving.Given.KeyMaterial.ScanPrivKey),
+				SpendKey: hexint(receiving.Given.KeyMaterial.SpendPrivKey),
+				Labels:   receiving.Given.Labels,
+			}
+
+			for _, vin := range receiving.Given.Vin {
+				input, err := parseSilentPaymentInput(vin)
+				if err != nil {
+					return nil, nil, err
+				}
+				vector.Inputs = append(vector.Inputs, input)
+			}
+
+			for _, output := range receiving.Given.Outputs {
+				vector.Outputs = append(vector.Outputs, hexint(output))
+			}
+
+			if receiving.Expected.NOutputs != nil {
+				vector.ExpectedCount = *receiving.Expected.NOutputs
+			} else {
+				vector.Expected = make([]*SilentPaymentOutputVector, len(receiving.Expected.Outputs))
+				for i, output := range receiving.Expected.Outputs {
+					signature, err := hex.DecodeString(output.Signature)
+					if err != nil {
+						return nil, nil, err
+					}
+					vector.Expected[i] = &SilentPaymentOutputVector{
+						PublicKey: hexint(output.PublicKey),
+						Tweak:     hexint(output.Tweak),
+						Signature: signature,
+					}
+				}
+				vector.ExpectedCount = len(vector.Expected)
+			}
+
+			receivingVectors = append(receivingVectors, vector)
+		}
+	}
+
+	return sendingVectors, receivingVectors, nil
+}
+
+func parseSilentPaymentInput(vin *silentPaymentJsonInput) (*SilentPaymentVectorInput, error) {
+	input := &SilentPaymentVectorInput{Vout: vin.Vout}
+
+	txid, err := hex.DecodeString(vin.TxID)
+	if err != nil {
+		return nil, err
+	} else if len(txid) != 32 {
+		return nil, errors.New("invalid silent payment vector txid")
+	}
+	for i := range txid {
+		input.TxID[i] = txid[31-i]
+	}
+
+	if vin.PrivateKey != "" {
+		input.PrivateKey = hexint(vin.PrivateKey)
+	}
+
+	scriptSig, err := hex.DecodeString(vin.ScriptSig)
+	if err != nil {
+		return nil, err
+	}
+	prevout, err := hex.DecodeString(vin.Prevout.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, err
+	}
+	witness, err := parseWitnessStack(vin.TxInWitness)
+	if err != nil {
+		return nil, err
+	}
+
+	input.IsTaproot = isP2TR(prevout)
+	input.PublicKey = silentPaymentInputKey(prevout, scriptSig, witness)
+	return input, nil
+}
+
+// silentPaymentInputKey extracts the public key of an input eligible for silent payments,
+// following the reference implementation of BIP-352. It returns nil for ineligible inputs.
+func silentPaymentInputKey(prevout, scriptSig []byte, witness [][]byte) []byte {
+	switch {
+	case isP2PKH(prevout):
+		// The public key may not be the last push of a malleated scriptSig,
+		// so search for any compressed key which matches the public key hash.
+		for i := len(scriptSig); i >= 33; i-- {
+			pub := scriptSig[i-33 : i]
+			if h := hash160(pub); bytes.Equal(h[:], prevout[3:23]) && isCompressedPoint(pub) {
+				return pub
+			}
+		}
+
+	case isP2SH(prevout):
+		if len(scriptSig) > 0 && isP2WPKH(scriptSig[1:]) && len(witness) > 0 && isCompressedPoint(witness[len(witness)-1]) {
+			return witness[len(witness)-1]
+		}
+
+	case isP2WPKH(prevout):
+		if len(witness) > 0 && isCompressedPoint(witness[len(witness)-1]) {
+			return witness[len(witness)-1]
+		}
+
+	case isP2TR(prevout):
+		if len(witness) == 0 {
+			return nil
+		}
+		if len(witness) > 1 && len(witness[len(witness)-1]) > 0 && witness[len(witness)-1][0] == 0x50 {
+			// Drop the annex.
+			witness = witness[:len(witness)-1]
+		}
+		if len(witness) > 1 {
+			// Script path spend: the last item is the control block, holding the internal key.
+			controlBlock := witness[len(witness)-1]
+			if len(controlBlock) >= 33 && bytes.Equal(controlBlock[1:33], bip352NUMSPoint) {
+				return nil
+			}
+		}
+		if isXOnlyPoint(prevout[2:]) {
+			return prevout[2:]
+		}
+	}
+
+	return nil
+}
+
+// parseWitnessStack decodes a serialized input witness: a count of stack items, each
+// prefixed with its length, all encoded as Bitcoin CompactSize integers.
+func parseWitnessStack(witnessHex string) ([][]byte, error) {
+	buf, err := hex.DecodeString(witnessHex)
+	if err != nil {
+		return nil, err
+	}
+	r := bytes.NewReader(buf)
+	if r.Len() == 0 {
+		return nil, nil
+	}
+
+	count, err := readCompactSize(r)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := make([][]byte, count)
+	for i := range stack {
+		size, err := readCompactSize(r)
+		if err != nil {
+			return nil, err
+		} else if size > uint64(r.Len()) {
+			return nil, errors.New("witness stack item overflows buffer")
+		}
+		stack[i] = make([]byte, size)
+		r.Read(stack[i])
+	}
+	return stack, nil
+}
+
+func readCompactSize(r *bytes.Reader) (uint64, error) {
+	prefix, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int
+	switch prefix {
+	case 0xfd:
+		size = 2
+	case 0xfe:
+		size = 4
+	case 0xff:
+		size = 8
+	default:
+		return uint64(prefix), nil
+	}
+
+	var buf [8]byte
+	if n, _ := r.Read(buf[:size]); n != size {
+		return 0, errors.New("truncated compact size integer")
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+func isP2PKH(script []byte) bool {
+	return len(script) == 25 && script[0] == 0x76 && script[1] == 0xa9 && script[2] == 0x14 &&
+		script[23] == 0x88 && script[24] == 0xac
+}
+
+func isP2SH(script []byte) bool {
+	return len(script) == 23 && script[0] == 0xa9 && script[1] == 0x14 && script[22] == 0x87
+}
+
+func isP2WPKH(script []byte) bool {
+	return len(script) == 22 && script[0] == 0x00 && script[1] == 0x14
+}
+
+func isP2TR(script []byte) bool {
+	return len(script) == 34 && script[0] == 0x51 && script[1] == 0x20
+}
+
+func isCompressedPoint(pub []byte) bool {
+	return len(pub) == 33 && (pub[0] == 2 || pub[0] == 3) && isXOnlyPoint(pub[1:])
+}
+
+// isXOnlyPoint returns true if the 32-byte key is the x-coordinate of a point on the curve.
+func isXOnlyPoint(pub []byte) bool {
+	if len(pub) != 32 {
+		return false
+	}
+	x := new(big.Int).SetBytes(pub)
+	if x.Cmp(secp256k1P) >= 0 {
+		return false
+	}
+
+	// y² = x³ + 7 must be a quadratic residue.
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	ySquared.Add(ySquared, big.NewInt(7))
+	ySquared.Mod(ySquared, secp256k1P)
+	return big.Jacobi(ySquared, secp256k1P) != -1
+}
@@ -0,0 +1,88 @@
+package test_vectors
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strconv"
+)
+
+// HashToCurveVector represents an RFC 9380 test vector for hashing a message to
+// a point on the secp256k1 curve, using the given domain separation tag.
+//
+// Suite is either "RO" (hash_to_curve) or "NU" (encode_to_curve).
+type HashToCurveVector struct {
+	Suite string
+	DST   []byte
+	Msg   []byte
+	X, Y  *big.Int
+}
+
+// ExpandMessageXMDVector represents an RFC 9380 test vector for expanding
+// a message into uniformly random bytes using SHA-256.
+type ExpandMessageXMDVector struct {
+	DST          []byte
+	Msg          []byte
+	LenInBytes   int
+	UniformBytes []byte
+}
+
+//go:embed hash_to_curve.json
+var hashToCurveJsonBytes []byte
+
+//go:embed expand_message_xmd.json
+var expandMessageXMDJsonBytes []byte
+
+func loadHashToCurveVectors() ([]*HashToCurveVector, error) {
+	var rawJsonObjects []map[string]string
+
+	if err := json.Unmarshal(hashToCurveJsonBytes, &rawJsonObjects); err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*HashToCurveVector, len(rawJsonObjects))
+
+	for i, obj := range rawJsonObjects {
+		vectors[i] = &HashToCurveVector{
+			Suite: obj["suite"],
+			DST:   []byte(obj["dst"]),
+			Msg:   []byte(obj["msg"]),
+			X:     hexint(obj["x"]),
+			Y:     hexint(obj["y"]),
+		}
+	}
+
+	return vectors, nil
+}
+
+func loadExpandMessageXMDVectors() ([]*ExpandMessageXMDVector, error) {
+	var rawJsonObjects []map[string]string
+
+	if err := json.Unmarshal(expandMessageXMDJsonBytes, &rawJsonObjects); err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*ExpandMessageXMDVector, len(rawJsonObjects))
+
+	for i, obj := range rawJsonObjects {
+		length, err := strconv.Atoi(obj["lenInBytes"])
+		if err != nil {
+			return nil, err
+		}
+
+		uniformBytes, err := hex.DecodeString(obj["uniformBytes"])
+		if err != nil {
+			return nil, err
+		}
+
+		vectors[i] = &ExpandMessageXMDVector{
+			DST:          []byte(obj["dst"]),
+			Msg:          []byte(obj["msg"]),
+			LenInBytes:   length,
+			UniformBytes: uniformBytes,
+		}
+	}
+
+	return vectors, nil
+}
@@ -17,10 +17,15 @@ type JacobiAdditionVector struct {
 //go:embed jacobi_addition.json
 var jacobiAdditionJsonBytes []byte
 
-func loadJacobiAdditionVectors() ([]*JacobiAdditionVector, error) {
+// Additions where P1, P2 or P3 is the point at infinity, which is any point with z = 0.
+//
+//go:embed jacobi_infinity_addition.json
+var jacobiInfinityAdditionJsonBytes []byte
+
+func loadJacobiAdditionVectors(jsonBytes []byte) ([]*JacobiAdditionVector, error) {
 	var rawJsonObjects []map[string]string
 
-	if err := json.Unmarshal(jacobiAdditionJsonBytes, &rawJsonObjects); err != nil {
+	if err := json.Unmarshal(jsonBytes, &rawJsonObjects); err != nil {
 		return nil, err
 	}
 
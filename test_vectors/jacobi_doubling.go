@@ -15,10 +15,15 @@ type JacobiDoublingVector struct {
 //go:embed jacobi_doubling.json
 var jacobiDoublingJsonBytes []byte
 
-func loadJacobiDoublingVectors() ([]*JacobiDoublingVector, error) {
+// Doublings of the point at infinity, which is any point with z = 0.
+//
+//go:embed jacobi_infinity_doubling.json
+var jacobiInfinityDoublingJsonBytes []byte
+
+func loadJacobiDoublingVectors(jsonBytes []byte) ([]*JacobiDoublingVector, error) {
 	var rawJsonObjects []map[string]string
 
-	if err := json.Unmarshal(jacobiDoublingJsonBytes, &rawJsonObjects); err != nil {
+	if err := json.Unmarshal(jsonBytes, &rawJsonObjects); err != nil {
 		return nil, err
 	}
 
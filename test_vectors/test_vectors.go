@@ -2,23 +2,44 @@ package test_vectors
 
 var (
 	// Preloaded test vector structs.
-	JacobiAdditionVectors       []*JacobiAdditionVector
-	JacobiDoublingVectors       []*JacobiDoublingVector
-	JacobiPointVectors          []*JacobiPointVector
-	AffineMultiplicationVectors []*AffineMultiplicationVector
-	NegatedPointVectors         []*NegatedPointVector
-	ECDSAVectors                []*ECDSAVector
+	JacobiAdditionVectors         []*JacobiAdditionVector
+	JacobiDoublingVectors         []*JacobiDoublingVector
+	JacobiInfinityAdditionVectors []*JacobiAdditionVector
+	JacobiInfinityDoublingVectors []*JacobiDoublingVector
+	JacobiPointVectors            []*JacobiPointVector
+	AffineMultiplicationVectors   []*AffineMultiplicationVector
+	NegatedPointVectors           []*NegatedPointVector
+	ECDSAVectors                  []*ECDSAVector
+	HashToCurveVectors            []*HashToCurveVector
+	ExpandMessageXMDVectors       []*ExpandMessageXMDVector
+	BIP340Vectors                 []*BIP340Vector
+	EllSwiftDecodeVectors         []*EllSwiftDecodeVector
+	XSwiftECInverseVectors        []*XSwiftECInverseVector
+	EllSwiftECDHVectors           []*EllSwiftECDHVector
+	SilentPaymentSendingVectors   []*SilentPaymentSendingVector
+	SilentPaymentReceivingVectors []*SilentPaymentReceivingVector
+	VRFVectors                    []*VRFVector
 )
 
 func init() {
 	var err error
 
-	JacobiAdditionVectors, err = loadJacobiAdditionVectors()
+	JacobiAdditionVectors, err = loadJacobiAdditionVectors(jacobiAdditionJsonBytes)
 	if err != nil {
 		panic(err)
 	}
 
-	JacobiDoublingVectors, err = loadJacobiDoublingVectors()
+	JacobiDoublingVectors, err = loadJacobiDoublingVectors(jacobiDoublingJsonBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	JacobiInfinityAdditionVectors, err = loadJacobiAdditionVectors(jacobiInfinityAdditionJsonBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	JacobiInfinityDoublingVectors, err = loadJacobiDoublingVectors(jacobiInfinityDoublingJsonBytes)
 	if err != nil {
 		panic(err)
 	}
@@ -42,4 +63,44 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	HashToCurveVectors, err = loadHashToCurveVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	ExpandMessageXMDVectors, err = loadExpandMessageXMDVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	BIP340Vectors, err = loadBIP340Vectors()
+	if err != nil {
+		panic(err)
+	}
+
+	EllSwiftDecodeVectors, err = loadEllSwiftDecodeVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	XSwiftECInverseVectors, err = loadXSwiftECInverseVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	EllSwiftECDHVectors, err = loadEllSwiftECDHVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	SilentPaymentSendingVectors, SilentPaymentReceivingVectors, err = loadSilentPaymentVectors()
+	if err != nil {
+		panic(err)
+	}
+
+	VRFVectors, err = loadVRFVectors()
+	if err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,58 @@
+package test_vectors
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+)
+
+// VRFVector represents an ECVRF-SECP256K1-SHA256-TAI test vector, following the
+// draft-irtf-cfrg-vrf-06 encoding. PublicKey is a compressed point, Proof is the
+// 81-byte pi_string, and Beta is the VRF output hash of Alpha.
+//
+// From https://github.com/vechain/go-ecvrf/blob/master/secp256_k1_sha256_tai.json
+type VRFVector struct {
+	PrivateKey *big.Int
+	PublicKey  []byte
+	Alpha      []byte
+	Proof      []byte
+	Beta       []byte
+}
+
+//go:embed vrf_secp256k1_sha256_tai.json
+var vrfJsonBytes []byte
+
+func loadVRFVectors() ([]*VRFVector, error) {
+	var rawJsonObjects []map[string]string
+
+	if err := json.Unmarshal(vrfJsonBytes, &rawJsonObjects); err != nil {
+		return nil, err
+	}
+
+	vectors := make([]*VRFVector, len(rawJsonObjects))
+
+	for i, obj := range rawJsonObjects {
+		vector := &VRFVector{
+			PrivateKey: hexint(obj["sk"]),
+		}
+
+		var err error
+		if vector.PublicKey, err = hex.DecodeString(obj["pk"]); err != nil {
+			return nil, err
+		}
+		if vector.Alpha, err = hex.DecodeString(obj["alpha"]); err != nil {
+			return nil, err
+		}
+		if vector.Proof, err = hex.DecodeString(obj["pi"]); err != nil {
+			return nil, err
+		}
+		if vector.Beta, err = hex.DecodeString(obj["beta"]); err != nil {
+			return nil, err
+		}
+
+		vectors[i] = vector
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,86 @@
+package ekliptic
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTranscript(t *testing.T) {
+	newTranscript := func() *Transcript {
+		t := NewTranscript("test")
+		t.AppendMessage("message", []byte("hello"))
+		t.AppendPoint("G", Secp256k1_GeneratorX, Secp256k1_GeneratorY)
+		t.AppendScalar("seven", seven)
+		return t
+	}
+
+	c1 := newTranscript().ChallengeScalar("c")
+	c2 := newTranscript().ChallengeScalar("c")
+	if !equal(c1, c2) {
+		t.Errorf("expected identical transcripts to produce the same challenge")
+	}
+	if !isValidProofScalar(c1) {
+		t.Errorf("expected challenge to be in range [0, N); got %x", c1)
+	}
+
+	differing := []func(*Transcript){
+		func(t *Transcript) { t.AppendMessage("message", []byte("hell")) },
+		func(t *Transcript) { t.AppendMessage("messag", []byte("ehello")) },
+		func(t *Transcript) { t.AppendPoint("G", Secp256k1_GeneratorX, Negate(Secp256k1_GeneratorY)) },
+		func(t *Transcript) { t.AppendPoint("G", zero, zero) },
+		func(t *Transcript) { t.AppendScalar("seven", eight) },
+	}
+
+	for i, appendOther := range differing {
+		other := NewTranscript("test")
+		appendOther(other)
+		if equal(other.ChallengeScalar("c"), c1) {
+			t.Errorf("expected transcript %d to produce a different challenge", i)
+		}
+	}
+
+	if equal(NewTranscript("other").ChallengeScalar("c"), NewTranscript("test").ChallengeScalar("c")) {
+		t.Errorf("expected differing domain labels to produce different challenges")
+	}
+
+	// Successive challenges must differ.
+	tr := newTranscript()
+	first := tr.ChallengeScalar("c")
+	second := tr.ChallengeScalar("c")
+	if equal(first, second) {
+		t.Errorf("expected successive challenges to differ")
+	}
+}
+
+func TestTranscript_Clone(t *testing.T) {
+	original := NewTranscript("clone")
+	original.AppendMessage("prefix", []byte("shared"))
+
+	clone := original.Clone()
+	clone.AppendMessage("suffix", []byte("diverged"))
+
+	fresh := NewTranscript("clone")
+	fresh.AppendMessage("prefix", []byte("shared"))
+
+	if !equal(original.Clone().ChallengeScalar("c"), fresh.ChallengeScalar("c")) {
+		t.Errorf("expected clone to produce the same challenge as the original")
+	}
+	if equal(clone.ChallengeScalar("c"), original.ChallengeScalar("c")) {
+		t.Errorf("expected modifying a clone not to affect the original")
+	}
+}
+
+func TestTranscript_ChallengeScalarDistribution(t *testing.T) {
+	// Challenges should use all 256 bits of the scalar range.
+	tr := NewTranscript("distribution")
+	highBitSet := 0
+	for i := 0; i < 64; i++ {
+		c := tr.ChallengeScalar("c")
+		if c.Cmp(new(big.Int).Lsh(one, 255)) >= 0 {
+			highBitSet++
+		}
+	}
+	if highBitSet == 0 || highBitSet == 64 {
+		t.Errorf("expected challenge high bits to be roughly uniform; got %d/64 set", highBitSet)
+	}
+}
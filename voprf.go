@@ -0,0 +1,276 @@
+package ekliptic
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrInvalidVOPRFElement is returned when a blinded or evaluated VOPRF element
+	// is not a valid point on the secp256k1 curve.
+	ErrInvalidVOPRFElement = errors.New("ekliptic: invalid VOPRF element")
+
+	// ErrInvalidVOPRFEvaluation is returned by VOPRFClient.Finalize if the server's evaluation
+	// does not match the client's blinded inputs, or its DLEQ proof is invalid.
+	ErrInvalidVOPRFEvaluation = errors.New("ekliptic: invalid VOPRF evaluation")
+
+	// ErrInvalidTokenEncoding is returned when parsing a serialized VOPRFToken which is malformed.
+	ErrInvalidTokenEncoding = errors.New("ekliptic: invalid token encoding")
+)
+
+const (
+	voprfHashToGroupDST = "ekliptic/VOPRF/HashToGroup-secp256k1_XMD:SHA-256_SSWU_RO_"
+	voprfFinalizeTag    = "ekliptic/VOPRF/Finalize"
+)
+
+// VOPRFOutputSize is the size in bytes of a VOPRF output.
+const VOPRFOutputSize = 32
+
+// VOPRFServer holds a server's private key for a verifiable oblivious pseudorandom function
+// (VOPRF). The server evaluates the PRF on inputs which the client has blinded, so the server
+// never learns the inputs or outputs. Each evaluation comes with a DLEQ proof that the server
+// used the private key belonging to its public key, so the server can't tag individual clients
+// by evaluating their inputs with different keys.
+//
+// The protocol follows the VOPRF mode of RFC 9497, in the style of Privacy Pass:
+//
+//	client:  P = HashToCurve(input)
+//	client:  B = r * P                 (blind)
+//	server:  Z = k * B                 (evaluate, with DLEQ proof log_G(K) == log_B(Z))
+//	client:  N = r⁻¹ * Z = k * P       (unblind)
+//	client:  output = H(input, N)      (finalize)
+//
+// There is no registered RFC 9497 ciphersuite for secp256k1, so outputs are not interoperable
+// with other implementations.
+//
+// https://www.rfc-editor.org/rfc/rfc9497
+type VOPRFServer struct {
+	privateKey *big.Int
+	pubX, pubY *big.Int
+}
+
+// NewVOPRFServer returns a VOPRFServer using the given private key, which should be
+// in the range [1, Secp256k1_CurveOrder). NewVOPRFServer panics if it is not within this range.
+func NewVOPRFServer(privateKey *big.Int) *VOPRFServer {
+	if !IsValidScalar(privateKey) {
+		panic("NewVOPRFServer: expected private key to be in range [1, Secp256k1_CurveOrder)")
+	}
+
+	pubX, pubY := MultiplyBasePoint(privateKey)
+	return &VOPRFServer{
+		privateKey: new(big.Int).Set(privateKey),
+		pubX:       pubX,
+		pubY:       pubY,
+	}
+}
+
+// PublicKey returns the server's public key K = k * G, which clients
+// use to verify evaluations.
+func (server *VOPRFServer) PublicKey() (x, y *big.Int) {
+	return new(big.Int).Set(server.pubX), new(big.Int).Set(server.pubY)
+}
+
+// Evaluate multiplies each of the client's blinded elements by the server's private key, and
+// returns the evaluated elements along with a single batched DLEQ proof covering all of them.
+// The proof nonce is generated using the given source of randomness, which should be
+// cryptographically secure. It returns ErrInvalidVOPRFElement if any blinded element
+// is not a valid point, or if no elements are given.
+func (server *VOPRFServer) Evaluate(
+	random io.Reader,
+	blinded [][2]*big.Int,
+) (evaluated [][2]*big.Int, proof *DLEQProof, err error) {
+	if len(blinded) == 0 {
+		return nil, nil, ErrInvalidVOPRFElement
+	}
+	for _, b := range blinded {
+		if !isValidPublicPoint(b[0], b[1]) {
+			return nil, nil, ErrInvalidVOPRFElement
+		}
+	}
+
+	keys := make([]*big.Int, len(blinded))
+	for i := range keys {
+		keys[i] = server.privateKey
+	}
+	evaluated = batchMultiplyAffine(blinded, keys)
+
+	proof, err = proveBatchDLEQ(
+		random,
+		server.privateKey,
+		Secp256k1_GeneratorX, Secp256k1_GeneratorY,
+		server.pubX, server.pubY,
+		blinded, evaluated,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return evaluated, proof, nil
+}
+
+// FullEvaluate computes the PRF output for the given input directly, without blinding.
+// Servers use this to check tokens presented by clients for redemption.
+func (server *VOPRFServer) FullEvaluate(input []byte) [VOPRFOutputSize]byte {
+	pX, pY := HashToCurve(input, []byte(voprfHashToGroupDST))
+	nX, nY := MultiplyAffine(pX, pY, server.privateKey, nil)
+	return voprfFinalize(input, nX, nY)
+}
+
+// VerifyToken returns true if the token's output is the PRF output of its input
+// under the server's private key.
+func (server *VOPRFServer) VerifyToken(token *VOPRFToken) bool {
+	if token == nil {
+		return false
+	}
+	expected := server.FullEvaluate(token.Input)
+	return subtle.ConstantTimeCompare(expected[:], token.Output[:]) == 1
+}
+
+// VOPRFClient requests VOPRF evaluations from a server with a known public key.
+type VOPRFClient struct {
+	pubX, pubY *big.Int
+}
+
+// VOPRFClientState holds the client's secret blinding factors for a set of inputs,
+// between blinding them and finalizing the server's evaluation.
+type VOPRFClientState struct {
+	inputs  [][]byte
+	blinds  []*big.Int
+	blinded [][2]*big.Int
+}
+
+// NewVOPRFClient returns a VOPRFClient for the server with the given public key.
+// It panics if the public key is not a valid point on the secp256k1 curve.
+func NewVOPRFClient(pubX, pubY *big.Int) *VOPRFClient {
+	if !isValidPublicPoint(pubX, pubY) {
+		panic("NewVOPRFClient: expected public key to be a valid point on the secp256k1 curve")
+	}
+	return &VOPRFClient{
+		pubX: new(big.Int).Set(pubX),
+		pubY: new(big.Int).Set(pubY),
+	}
+}
+
+// Blind hashes each input to a curve point and blinds it with a random scalar generated using
+// the given source of randomness, which should be cryptographically secure. It returns
+// the client's state, which must be kept secret until Finalize, and the blinded elements,
+// which should be sent to the server.
+//
+//	P = HashToCurve(input)
+//	r = random scalar
+//	B = r * P
+func (client *VOPRFClient) Blind(
+	random io.Reader,
+	inputs [][]byte,
+) (state *VOPRFClientState, blinded [][2]*big.Int, err error) {
+	state = &VOPRFClientState{
+		inputs: make([][]byte, len(inputs)),
+		blinds: make([]*big.Int, len(inputs)),
+	}
+
+	hashed := make([][2]*big.Int, len(inputs))
+	for i, input := range inputs {
+		r, err := RandomScalar(random)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pX, pY := HashToCurve(input, []byte(voprfHashToGroupDST))
+
+		state.inputs[i] = append([]byte{}, input...)
+		state.blinds[i] = r
+		hashed[i] = [2]*big.Int{pX, pY}
+	}
+	state.blinded = batchMultiplyAffine(hashed, state.blinds)
+
+	blinded = make([][2]*big.Int, len(state.blinded))
+	copy(blinded, state.blinded)
+	return state, blinded, nil
+}
+
+// Finalize verifies the server's batched DLEQ proof, unblinds each evaluated element with the
+// inverse of its blinding factor, and returns one VOPRFToken per input, in the same order as
+// the inputs given to Blind. It returns ErrInvalidVOPRFEvaluation if the evaluation or
+// proof is invalid.
+//
+//	N = r⁻¹ * Z
+//	output = H(input, N)
+func (client *VOPRFClient) Finalize(
+	state *VOPRFClientState,
+	evaluated [][2]*big.Int,
+	proof *DLEQProof,
+) ([]*VOPRFToken, error) {
+	if len(evaluated) != len(state.blinded) {
+		return nil, ErrInvalidVOPRFEvaluation
+	}
+
+	valid := VerifyBatchDLEQ(
+		proof,
+		Secp256k1_GeneratorX, Secp256k1_GeneratorY,
+		client.pubX, client.pubY,
+		state.blinded, evaluated,
+	)
+	if !valid {
+		return nil, ErrInvalidVOPRFEvaluation
+	}
+
+	rInverses := make([]*big.Int, len(state.blinds))
+	for i, r := range state.blinds {
+		rInverses[i] = InvertScalar(r)
+	}
+	unblinded := batchMultiplyAffine(evaluated, rInverses)
+
+	tokens := make([]*VOPRFToken, len(unblinded))
+	for i, n := range unblinded {
+		tokens[i] = &VOPRFToken{
+			Input:  state.inputs[i],
+			Output: voprfFinalize(state.inputs[i], n[0], n[1]),
+		}
+	}
+
+	return tokens, nil
+}
+
+// VOPRFToken is a finalized VOPRF input and output pair. Clients redeem tokens with the
+// server, which checks them using VOPRFServer.VerifyToken. Since the server never saw
+// the input or output during evaluation, it cannot link a token to its issuance.
+type VOPRFToken struct {
+	Input  []byte
+	Output [VOPRFOutputSize]byte
+}
+
+// MarshalBinary serializes the token as a 4-byte big-endian input length,
+// followed by the input, followed by the 32-byte output.
+func (token *VOPRFToken) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 4+len(token.Input)+VOPRFOutputSize)
+	binary.BigEndian.PutUint32(buf, uint32(len(token.Input)))
+	copy(buf[4:], token.Input)
+	copy(buf[4+len(token.Input):], token.Output[:])
+	return buf, nil
+}
+
+// UnmarshalBinary parses a token serialized by MarshalBinary.
+func (token *VOPRFToken) UnmarshalBinary(data []byte) error {
+	if len(data) < 4+VOPRFOutputSize {
+		return ErrInvalidTokenEncoding
+	}
+
+	inputLength := binary.BigEndian.Uint32(data)
+	if uint64(len(data)) != 4+uint64(inputLength)+VOPRFOutputSize {
+		return ErrInvalidTokenEncoding
+	}
+
+	token.Input = append([]byte{}, data[4:4+inputLength]...)
+	copy(token.Output[:], data[4+inputLength:])
+	return nil
+}
+
+// voprfFinalize hashes an input and its unblinded PRF element N into the PRF output.
+func voprfFinalize(input []byte, nX, nY *big.Int) [VOPRFOutputSize]byte {
+	var inputLength [4]byte
+	binary.BigEndian.PutUint32(inputLength[:], uint32(len(input)))
+	return taggedHash(voprfFinalizeTag, inputLength[:], input, serializeCompressed(nX, nY))
+}
@@ -0,0 +1,130 @@
+package ekliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestVOPRF(t *testing.T) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	server := NewVOPRFServer(privateKey)
+	client := NewVOPRFClient(server.PublicKey())
+
+	inputs := [][]byte{[]byte("token 1"), []byte("token 2"), {}, []byte("token 1")}
+
+	state, blinded, err := client.Blind(rand.Reader, inputs)
+	if err != nil {
+		t.Fatalf("failed to blind inputs: %s", err)
+	}
+
+	evaluated, proof, err := server.Evaluate(rand.Reader, blinded)
+	if err != nil {
+		t.Fatalf("failed to evaluate blinded inputs: %s", err)
+	}
+
+	tokens, err := client.Finalize(state, evaluated, proof)
+	if err != nil {
+		t.Fatalf("failed to finalize evaluation: %s", err)
+	}
+
+	for i, token := range tokens {
+		if string(token.Input) != string(inputs[i]) {
+			t.Errorf("unexpected input for token %d: %q", i, token.Input)
+		}
+		if token.Output != server.FullEvaluate(inputs[i]) {
+			t.Errorf("token %d output does not match full evaluation", i)
+		}
+		if !server.VerifyToken(token) {
+			t.Errorf("failed to verify token %d", i)
+		}
+	}
+
+	if tokens[0].Output != tokens[3].Output {
+		t.Errorf("expected equal inputs to produce equal outputs")
+	}
+	if tokens[0].Output == tokens[1].Output {
+		t.Errorf("expected different inputs to produce different outputs")
+	}
+
+	otherKey, _ := RandomScalar(rand.Reader)
+	if NewVOPRFServer(otherKey).VerifyToken(tokens[0]) {
+		t.Errorf("verified token with the wrong server key")
+	}
+}
+
+func TestVOPRF_InvalidEvaluation(t *testing.T) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	server := NewVOPRFServer(privateKey)
+	client := NewVOPRFClient(server.PublicKey())
+
+	state, blinded, _ := client.Blind(rand.Reader, [][]byte{[]byte("a"), []byte("b")})
+
+	// A server which evaluates with a different key to tag the client must be caught.
+	otherKey, _ := RandomScalar(rand.Reader)
+	evaluated, proof, _ := NewVOPRFServer(otherKey).Evaluate(rand.Reader, blinded)
+	if _, err := client.Finalize(state, evaluated, proof); err != ErrInvalidVOPRFEvaluation {
+		t.Errorf("expected ErrInvalidVOPRFEvaluation with wrong key; got %v", err)
+	}
+
+	evaluated, proof, _ = server.Evaluate(rand.Reader, blinded)
+
+	if _, err := client.Finalize(state, evaluated[:1], proof); err != ErrInvalidVOPRFEvaluation {
+		t.Errorf("expected ErrInvalidVOPRFEvaluation with missing element; got %v", err)
+	}
+
+	swapped := [][2]*big.Int{evaluated[1], evaluated[0]}
+	if _, err := client.Finalize(state, swapped, proof); err != ErrInvalidVOPRFEvaluation {
+		t.Errorf("expected ErrInvalidVOPRFEvaluation with reordered elements; got %v", err)
+	}
+
+	if _, _, err := server.Evaluate(rand.Reader, nil); err != ErrInvalidVOPRFElement {
+		t.Errorf("expected ErrInvalidVOPRFElement with no elements; got %v", err)
+	}
+	invalid := [][2]*big.Int{{big.NewInt(1), big.NewInt(2)}}
+	if _, _, err := server.Evaluate(rand.Reader, invalid); err != ErrInvalidVOPRFElement {
+		t.Errorf("expected ErrInvalidVOPRFElement with invalid point; got %v", err)
+	}
+}
+
+func TestVOPRFToken_MarshalBinary(t *testing.T) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	server := NewVOPRFServer(privateKey)
+
+	for _, input := range [][]byte{{}, []byte("hello"), make([]byte, 300)} {
+		token := &VOPRFToken{Input: input, Output: server.FullEvaluate(input)}
+
+		serialized, _ := token.MarshalBinary()
+		parsed := new(VOPRFToken)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse token: %s", err)
+			continue
+		}
+
+		if string(parsed.Input) != string(input) || parsed.Output != token.Output {
+			t.Errorf("parsed token does not match original")
+		}
+		if !server.VerifyToken(parsed) {
+			t.Errorf("failed to verify parsed token")
+		}
+
+		for _, bad := range [][]byte{serialized[:len(serialized)-1], append(serialized, 0)} {
+			if err := new(VOPRFToken).UnmarshalBinary(bad); err != ErrInvalidTokenEncoding {
+				t.Errorf("expected ErrInvalidTokenEncoding; got %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkVOPRF(b *testing.B) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	server := NewVOPRFServer(privateKey)
+	client := NewVOPRFClient(server.PublicKey())
+	inputs := [][]byte{[]byte("token")}
+
+	for i := 0; i < b.N; i++ {
+		state, blinded, _ := client.Blind(rand.Reader, inputs)
+		evaluated, proof, _ := server.Evaluate(rand.Reader, blinded)
+		client.Finalize(state, evaluated, proof)
+	}
+}
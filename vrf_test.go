@@ -0,0 +1,143 @@
+package ekliptic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/kklash/ekliptic/test_vectors"
+)
+
+func TestVRF(t *testing.T) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(privateKey)
+
+	otherKey, _ := RandomScalar(rand.Reader)
+	otherX, otherY := MultiplyBasePoint(otherKey)
+
+	alphas := [][]byte{
+		nil,
+		[]byte("sample"),
+		[]byte("leader election round 42"),
+	}
+
+	seen := make(map[[32]byte]bool)
+
+	for i, alpha := range alphas {
+		proof := ProveVRF(privateKey, alpha)
+
+		if !VerifyVRF(proof, pubX, pubY, alpha) {
+			t.Errorf("failed to verify valid VRF proof %d", i)
+			continue
+		}
+
+		if again := ProveVRF(privateKey, alpha); !equal(again.S, proof.S) || !equal(again.C, proof.C) {
+			t.Errorf("expected VRF proofs to be deterministic")
+		}
+
+		beta := proof.ProofToHash()
+		if seen[beta] {
+			t.Errorf("found duplicate VRF output for alpha %q", alpha)
+		}
+		seen[beta] = true
+
+		if ProveVRF(otherKey, alpha).ProofToHash() == beta {
+			t.Errorf("expected VRF output to depend on the private key")
+		}
+
+		if VerifyVRF(proof, otherX, otherY, alpha) {
+			t.Errorf("verified VRF proof %d under the wrong public key", i)
+		}
+		if VerifyVRF(proof, pubX, pubY, append(alpha, 0)) {
+			t.Errorf("verified VRF proof %d for the wrong input", i)
+		}
+
+		serialized, _ := proof.MarshalBinary()
+		if len(serialized) != VRFProofSize {
+			t.Errorf("unexpected VRF proof size %d", len(serialized))
+		}
+
+		parsed := new(VRFProof)
+		if err := parsed.UnmarshalBinary(serialized); err != nil {
+			t.Errorf("failed to parse VRF proof: %s", err)
+			continue
+		}
+		if !VerifyVRF(parsed, pubX, pubY, alpha) || parsed.ProofToHash() != beta {
+			t.Errorf("failed to verify deserialized VRF proof %d", i)
+		}
+
+		tampered := &VRFProof{Gamma: proof.Gamma, C: proof.C, S: new(big.Int).Add(proof.S, one)}
+		if VerifyVRF(tampered, pubX, pubY, alpha) {
+			t.Errorf("verified tampered VRF proof %d", i)
+		}
+	}
+}
+
+func TestVRF_Vectors(t *testing.T) {
+	for i, vector := range test_vectors.VRFVectors {
+		pubX, pubY := MultiplyBasePoint(vector.PrivateKey)
+		if !bytes.Equal(serializeCompressed(pubX, pubY), vector.PublicKey) {
+			t.Errorf("VRF vector %d: public key does not match", i)
+			continue
+		}
+
+		proof := ProveVRF(vector.PrivateKey, vector.Alpha)
+		serialized, _ := proof.MarshalBinary()
+		if !bytes.Equal(serialized, vector.Proof) {
+			t.Errorf("VRF vector %d: proof does not match\nWanted %x\nGot    %x", i, vector.Proof, serialized)
+			continue
+		}
+
+		parsed := new(VRFProof)
+		if err := parsed.UnmarshalBinary(vector.Proof); err != nil {
+			t.Errorf("VRF vector %d: failed to parse proof: %s", i, err)
+			continue
+		}
+		if !VerifyVRF(parsed, pubX, pubY, vector.Alpha) {
+			t.Errorf("VRF vector %d: failed to verify proof", i)
+		}
+		if beta := parsed.ProofToHash(); !bytes.Equal(beta[:], vector.Beta) {
+			t.Errorf("VRF vector %d: output does not match\nWanted %x\nGot    %x", i, vector.Beta, beta)
+		}
+	}
+}
+
+func TestVRFProof_UnmarshalBinary(t *testing.T) {
+	proof := ProveVRF(big.NewInt(1), []byte("sample"))
+	serialized, _ := proof.MarshalBinary()
+
+	invalidGamma := append([]byte{}, serialized...)
+	invalidGamma[0] = 0x04
+
+	overflowS := append([]byte{}, serialized...)
+	Secp256k1_CurveOrder.FillBytes(overflowS[33+vrfChallengeLength:])
+
+	for i, data := range [][]byte{nil, serialized[1:], invalidGamma, overflowS} {
+		if err := new(VRFProof).UnmarshalBinary(data); err == nil {
+			t.Errorf("expected error when parsing invalid VRF proof %d", i)
+		}
+	}
+}
+
+func BenchmarkProveVRF(b *testing.B) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	alpha := []byte("sample")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveVRF(privateKey, alpha)
+	}
+}
+
+func BenchmarkVerifyVRF(b *testing.B) {
+	privateKey, _ := RandomScalar(rand.Reader)
+	pubX, pubY := MultiplyBasePoint(privateKey)
+	alpha := []byte("sample")
+	proof := ProveVRF(privateKey, alpha)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyVRF(proof, pubX, pubY, alpha)
+	}
+}
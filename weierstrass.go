@@ -33,13 +33,9 @@ func Weierstrass(x *big.Int) (evenY, oddY *big.Int) {
 	c.Add(c, Secp256k1_B)
 	modCoordinate(c)
 
-	// this is actually faster than using big.Int's ModSqrt method.
-	y := new(big.Int).Exp(c, squareRootExp, Secp256k1_P) // y = c^((p+1)/4)
-
-	ySquared := new(big.Int).Mul(y, y)
-	modCoordinate(ySquared)
-	if !equal(c, ySquared) {
-		// c != y² mod p - this means the given x-coordinate is not on the curve
+	y := sqrtCoordinate(c)
+	if y == nil {
+		// c is not a square mod p - this means the given x-coordinate is not on the curve
 		return nil, nil
 	}
 
@@ -54,6 +50,20 @@ func Weierstrass(x *big.Int) (evenY, oddY *big.Int) {
 	return evenY, oddY
 }
 
+// sqrtCoordinate returns a square root of the field element c modulo the curve prime P,
+// or nil if c is not a quadratic residue modulo P. c is expected to be within range [0, P-1].
+func sqrtCoordinate(c *big.Int) *big.Int {
+	// this is actually faster than using big.Int's ModSqrt method.
+	y := new(big.Int).Exp(c, squareRootExp, Secp256k1_P) // y = c^((p+1)/4)
+
+	ySquared := new(big.Int).Mul(y, y)
+	modCoordinate(ySquared)
+	if !equal(c, ySquared) {
+		return nil
+	}
+	return y
+}
+
 func isEven(y *big.Int) bool {
 	return y.Bit(0) == 0
 }